@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// oidc-mock is the OIDC provider the suites deploy instead of Keycloak, see utils.DeployOIDCMock. It serves
+// the discovery document, the JWKS of the shared signing key and a token endpoint that hands out test tokens
+// signed with that key. The key file is read on every request, so a rotated key Secret is served as soon as
+// the kubelet updates the volume, without a restart.
+package main
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+func main() {
+	listen := flag.String("listen", ":8080", "Address to serve on")
+	issuer := flag.String("issuer", auth.IssuerURL, "Issuer URL; its path prefixes the endpoints")
+	keyFile := flag.String("key-file", "/etc/oidc-mock/key.pem", "PKCS#1 PEM of the signing key")
+	lifetime := flag.Duration("token-lifetime", time.Hour, "Lifetime of the issued tokens")
+	flag.Parse()
+
+	handler, err := newHandler(*issuer, *keyFile, *lifetime)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Serving OIDC issuer %s on %s", *issuer, *listen)
+	server := &http.Server{Addr: *listen, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	log.Fatal(server.ListenAndServe())
+}
+
+// mock serves one issuer.
+type mock struct {
+	issuer   string
+	keyFile  string
+	lifetime time.Duration
+}
+
+// newHandler returns the endpoints of the issuer, under the path of the issuer URL as Keycloak serves them and
+// at the root.
+func newHandler(issuer, keyFile string, lifetime time.Duration) (http.Handler, error) {
+	u, err := url.Parse(issuer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer %q: %w", issuer, err)
+	}
+	m := &mock{issuer: strings.TrimSuffix(issuer, "/"), keyFile: keyFile, lifetime: lifetime}
+
+	mux := http.NewServeMux()
+	prefix := strings.TrimSuffix(u.Path, "/")
+	for _, p := range endpointPrefixes(prefix) {
+		mux.HandleFunc("GET "+p+"/.well-known/openid-configuration", m.discovery)
+		mux.HandleFunc("GET "+p+"/keys", m.keys)
+		mux.HandleFunc("POST "+p+"/protocol/openid-connect/token", m.token)
+	}
+	mux.HandleFunc("POST /token", m.token)
+	return mux, nil
+}
+
+// endpointPrefixes returns the issuer path and the root, once when they are the same.
+func endpointPrefixes(prefix string) []string {
+	if prefix == "" {
+		return []string{""}
+	}
+	return []string{prefix, ""}
+}
+
+func (m *mock) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                m.issuer,
+		"authorization_endpoint":                m.issuer + "/protocol/openid-connect/auth",
+		"token_endpoint":                        m.issuer + "/protocol/openid-connect/token",
+		"jwks_uri":                              m.issuer + "/keys",
+		"userinfo_endpoint":                     m.issuer + "/protocol/openid-connect/userinfo",
+		"grant_types_supported":                 []string{"client_credentials", "password"},
+		"response_types_supported":              []string{"code", "token", "id_token"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"PS512"},
+	})
+}
+
+func (m *mock) keys(w http.ResponseWriter, _ *http.Request) {
+	key, err := m.loadKey()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	jwks, err := auth.JWKSOf(&key.PublicKey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(jwks))
+}
+
+// token issues the test token of auth.GenerateProjectJWTForClient for the client_credentials and password
+// grants. The subject is username, else client_id; the audience is the comma separated audience parameter,
+// else cluster-manager; the roles are those of project_id, else of auth.DefaultProjectID. The tokens carry
+// auth.IssuerURL as issuer, like the ones the suites sign themselves.
+func (m *mock) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	grant := r.PostForm.Get("grant_type")
+	if grant != "client_credentials" && grant != "password" {
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", fmt.Sprintf("grant_type %q is not supported", grant))
+		return
+	}
+	clientID := r.PostForm.Get("client_id")
+	if clientID == "" {
+		writeError(w, http.StatusBadRequest, "invalid_client", "client_id is required")
+		return
+	}
+	subject := r.PostForm.Get("username")
+	if subject == "" {
+		subject = clientID
+	}
+	audience := []string{"cluster-manager"}
+	if aud := r.PostForm.Get("audience"); aud != "" {
+		audience = strings.Split(aud, ",")
+	}
+	projectID := r.PostForm.Get("project_id")
+	if projectID == "" {
+		projectID = auth.DefaultProjectID
+	}
+
+	key, err := m.loadKey()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	token, err := auth.SignProjectJWT(key, subject, projectID, audience, clientID, m.lifetime)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int(m.lifetime.Seconds()),
+	})
+}
+
+func (m *mock) loadKey() (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(m.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the signing key: %w", err)
+	}
+	return auth.ParsePrivateKeyPEM(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError writes an OAuth 2.0 error response.
+func writeError(w http.ResponseWriter, status int, code, description string) {
+	writeJSON(w, status, map[string]string{"error": code, "error_description": description})
+}
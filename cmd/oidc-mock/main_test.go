@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const testIssuer = "http://platform-keycloak.orch-platform.svc/realms/master"
+
+func writeTestKey(t *testing.T) (*rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return key, path
+}
+
+func testServer(t *testing.T, keyFile string) *httptest.Server {
+	t.Helper()
+	handler, err := newHandler(testIssuer, keyFile, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func getJSON(t *testing.T, url string, out interface{}) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Failed to get %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200 from %s, got %d", url, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		t.Fatalf("Failed to decode %s: %v", url, err)
+	}
+}
+
+func TestDiscoveryAndKeys(t *testing.T) {
+	key, keyFile := writeTestKey(t)
+	server := testServer(t, keyFile)
+
+	for _, prefix := range []string{"/realms/master", ""} {
+		var discovery map[string]interface{}
+		getJSON(t, server.URL+prefix+"/.well-known/openid-configuration", &discovery)
+		if discovery["issuer"] != testIssuer || discovery["jwks_uri"] != testIssuer+"/keys" {
+			t.Errorf("Expected the endpoints of %s, got %v", testIssuer, discovery)
+		}
+	}
+
+	var jwks json.RawMessage
+	getJSON(t, server.URL+"/realms/master/keys", &jwks)
+	want, err := auth.JWKSOf(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(jwks) != want {
+		t.Errorf("Expected JWKS %s, got %s", want, jwks)
+	}
+}
+
+func TestKeysFollowTheKeyFile(t *testing.T) {
+	_, keyFile := writeTestKey(t)
+	server := testServer(t, keyFile)
+	var before, after json.RawMessage
+	getJSON(t, server.URL+"/keys", &before)
+
+	rotated, rotatedFile := writeTestKey(t)
+	data, err := os.ReadFile(rotatedFile)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := os.WriteFile(keyFile, data, 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	getJSON(t, server.URL+"/keys", &after)
+	want, _ := auth.JWKSOf(&rotated.PublicKey)
+	if string(after) != want || string(after) == string(before) {
+		t.Errorf("Expected the JWKS of the rotated key, got %s", after)
+	}
+}
+
+func TestToken(t *testing.T) {
+	key, keyFile := writeTestKey(t)
+	server := testServer(t, keyFile)
+
+	resp, err := http.PostForm(server.URL+"/realms/master/protocol/openid-connect/token", url.Values{
+		"grant_type": {"password"},
+		"client_id":  {"system-client"},
+		"username":   {"test-user"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected a token, got status %d, %v", resp.StatusCode, err)
+	}
+	if body.TokenType != "Bearer" || body.ExpiresIn != 3600 {
+		t.Errorf("Expected a Bearer token valid for an hour, got %+v", body)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(body.AccessToken, claims, func(*jwt.Token) (interface{}, error) {
+		return &key.PublicKey, nil
+	}); err != nil {
+		t.Fatalf("Expected a token signed with the key file, got %v", err)
+	}
+	if claims["sub"] != "test-user" || claims["azp"] != "system-client" || claims["iss"] != auth.IssuerURL {
+		t.Errorf("Unexpected claims %v", claims)
+	}
+	if aud, _ := claims.GetAudience(); len(aud) != 1 || aud[0] != "cluster-manager" {
+		t.Errorf("Expected audience cluster-manager, got %v", aud)
+	}
+}
+
+func TestTokenRejectsInvalidRequests(t *testing.T) {
+	_, keyFile := writeTestKey(t)
+	server := testServer(t, keyFile)
+
+	for _, form := range []url.Values{
+		{"grant_type": {"authorization_code"}, "client_id": {"system-client"}},
+		{"grant_type": {"client_credentials"}},
+	} {
+		resp, err := http.PostForm(server.URL+"/token", form)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %v, got %d", form, resp.StatusCode)
+		}
+	}
+	if resp, err := http.Get(server.URL + "/token"); err == nil {
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusMethodNotAllowed {
+			t.Errorf("Expected status 405 for a GET of the token endpoint, got %d", resp.StatusCode)
+		}
+	}
+}
@@ -49,6 +49,31 @@ func (t Test) Bootstrap() error {
 	return t.bootstrap()
 }
 
+// RestoreEgress Lifts the air-gapped mode egress block from the kind nodes.
+func (t Test) RestoreEgress() error {
+	return t.restoreEgress()
+}
+
+// SyncAuthState Makes the deployed OIDC mock serve the key of the shared auth state.
+func (t Test) SyncAuthState() error {
+	return t.syncAuthState()
+}
+
+// ResetAuthState Drops the shared auth state, so a new key and tokens are generated, and updates the OIDC mock.
+func (t Test) ResetAuthState() error {
+	return t.resetAuthState()
+}
+
+// DeployOIDCMock Builds the Go OIDC mock of cmd/oidc-mock, loads it into kind and deploys it with the shared key.
+func (t Test) DeployOIDCMock() error {
+	return t.deployOIDCMock()
+}
+
+// DiffFingerprints Prints what differs between the environments of two runs (FINGERPRINT_BASE, FINGERPRINT_HEAD).
+func (t Test) DiffFingerprints() error {
+	return t.diffFingerprints()
+}
+
 // ClusterOrchClusterApiSmokeTest Runs cluster orch cluster api smoke test
 func (t Test) ClusterOrchClusterApiSmokeTest() error {
 	return t.clusterOrchClusterApiSmokeTest()
@@ -74,6 +99,161 @@ func (t Test) ClusterOrchRobustness() error {
 	return t.clusterOrchRobustness()
 }
 
+// ClusterOrchTemplateProfileTest Runs template pod-security profile tests
+func (t Test) ClusterOrchTemplateProfileTest() error {
+	return t.clusterOrchTemplateProfileTest()
+}
+
+// ClusterOrchCRApiTest Runs cluster orch tests that create clusters through CAPI CRs
+func (t Test) ClusterOrchCRApiTest() error {
+	return t.clusterOrchCRApiTest()
+}
+
+// ClusterOrchTrustedComputeTest Runs trusted-compute template extension tests
+func (t Test) ClusterOrchTrustedComputeTest() error {
+	return t.clusterOrchTrustedComputeTest()
+}
+
+// ClusterOrchTemplateMixTest Runs k3s and rke2 template coexistence tests
+func (t Test) ClusterOrchTemplateMixTest() error {
+	return t.clusterOrchTemplateMixTest()
+}
+
+// ClusterOrchAirGappedTest Runs air-gapped mode tests
+func (t Test) ClusterOrchAirGappedTest() error {
+	return t.clusterOrchAirGappedTest()
+}
+
+// ClusterOrchSlowRegistryTest Runs cluster orch tests behind a bandwidth-throttled registry proxy
+func (t Test) ClusterOrchSlowRegistryTest() error {
+	return t.clusterOrchSlowRegistryTest()
+}
+
+// PivotTest Runs the clusterctl move test that pivots a live cluster to a second management cluster
+func (t Test) PivotTest() error {
+	return t.pivotTest()
+}
+
+// BackupRestoreTest Runs the test that backs up, deletes and restores the CAPI objects of a running cluster
+func (t Test) BackupRestoreTest() error {
+	return t.backupRestoreTest()
+}
+
+// HelmValuesMatrixTest Runs the cluster-manager smoke tests once per chart configuration of configs/helm-values-matrix.yaml
+func (t Test) HelmValuesMatrixTest() error {
+	return t.helmValuesMatrixTest()
+}
+
+// EdgeNodeMatrixTest Runs the cluster api smoke tests once per edge node OS image and architecture of configs/edge-node-matrix.yaml
+func (t Test) EdgeNodeMatrixTest() error {
+	return t.edgeNodeMatrixTest()
+}
+
+// ClusterOrchProjectTest Runs cluster orch project namespace deletion tests
+func (t Test) ClusterOrchProjectTest() error {
+	return t.clusterOrchProjectTest()
+}
+
+// ClusterOrchInventoryTest Runs cluster orch node GUID inventory validation tests
+func (t Test) ClusterOrchInventoryTest() error {
+	return t.clusterOrchInventoryTest()
+}
+
+// ClusterOrchOnboardingTest Runs cluster orch edge node onboarding tests
+func (t Test) ClusterOrchOnboardingTest() error {
+	return t.clusterOrchOnboardingTest()
+}
+
+// ClusterOrchGatewayPerfTest Runs cluster orch gateway concurrent session load tests
+func (t Test) ClusterOrchGatewayPerfTest() error {
+	return t.clusterOrchGatewayPerfTest()
+}
+
+// ClusterOrchGatewayTest Runs cluster orch connect gateway streaming tests
+func (t Test) ClusterOrchGatewayTest() error {
+	return t.clusterOrchGatewayTest()
+}
+
+// ClusterOrchFuzzTest Runs cluster orch request payload fuzzing tests
+func (t Test) ClusterOrchFuzzTest() error {
+	return t.clusterOrchFuzzTest()
+}
+
+// ClusterOrchApiLimitsTest Runs cluster orch API limits boundary tests
+func (t Test) ClusterOrchApiLimitsTest() error {
+	return t.clusterOrchApiLimitsTest()
+}
+
+// ClusterOrchAuditLogTest Runs cluster orch audit log tests
+func (t Test) ClusterOrchAuditLogTest() error {
+	return t.clusterOrchAuditLogTest()
+}
+
+// Profile Runs the suites of the run profile PROFILE of configs/run-profiles.yaml
+func (t Test) Profile() error {
+	return t.profile()
+}
+
+// ClusterOrchClusterLabelsTest Runs cluster orch cluster label propagation tests
+func (t Test) ClusterOrchClusterLabelsTest() error {
+	return t.clusterOrchClusterLabelsTest()
+}
+
+// ClusterOrchNameValidationTest Runs cluster orch cluster and node name validation tests
+func (t Test) ClusterOrchNameValidationTest() error {
+	return t.clusterOrchNameValidationTest()
+}
+
+// ClusterOrchNodeDeleteTest Runs cluster orch node deletion tests
+func (t Test) ClusterOrchNodeDeleteTest() error {
+	return t.clusterOrchNodeDeleteTest()
+}
+
+// InClusterManifest Writes the manifest of a Job running the suites inside the management cluster
+func (t Test) InClusterManifest() error {
+	return t.inClusterManifest()
+}
+
+// ClusterOrchHTTPClientTest Runs cluster orch HTTP client connection reuse tests
+func (t Test) ClusterOrchHTTPClientTest() error {
+	return t.clusterOrchHTTPClientTest()
+}
+
+// ScanArtifacts Fails if the failure artifacts leak private keys, JWTs, bearer tokens or credentials
+func (t Test) ScanArtifacts() error {
+	return t.scanArtifacts()
+}
+
+// Quarantined Lists the specs quarantined with utils.SkipWithIssue and their issues
+func (t Test) Quarantined() error {
+	return t.quarantined()
+}
+
+// NewSuite Generates the suite tests/NAME-test with its label, mage target and make target
+func (t Test) NewSuite() error {
+	return t.newSuite()
+}
+
+// ClusterOrchApiVersionTest Runs cluster-manager API version contract tests
+func (t Test) ClusterOrchApiVersionTest() error {
+	return t.clusterOrchApiVersionTest()
+}
+
+// ClusterOrchScaleTest Runs cluster orch scale tests
+func (t Test) ClusterOrchScaleTest() error {
+	return t.clusterOrchScaleTest()
+}
+
+////// Report specific targets
+
+type Report mg.Namespace
+
+// Summarize Prints the per-spec durations, the slowest specs and the pass/fail matrix of the suites of the
+// JSON reports in ARTIFACTS_DIR.
+func (r Report) Summarize() error {
+	return r.summarize()
+}
+
 ////// Lint specific targets
 
 type Lint mg.Namespace
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+// artifactDirs returns FAILURE_ARTIFACTS_DIR when it is set, and the failure artifacts of every suite directory
+// otherwise, as ginkgo runs the suites from their own directory.
+func artifactDirs() ([]string, error) {
+	if dir := os.Getenv(utils.FailureArtifactsDirEnvVar); dir != "" {
+		return []string{dir}, nil
+	}
+	return filepath.Glob(filepath.Join("tests", "*", utils.DefaultFailureArtifactsDir))
+}
+
+// Test Scans the failure artifacts for private keys, JWTs, bearer tokens and credentials left unredacted.
+func (Test) scanArtifacts() error {
+	dirs, err := artifactDirs()
+	if err != nil {
+		return err
+	}
+	var findings []utils.SecretFinding
+	for _, dir := range dirs {
+		found, err := utils.ScanForSecrets(dir)
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", dir, err)
+		}
+		findings = append(findings, found...)
+	}
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("%d unredacted secrets in the failure artifacts", len(findings))
+	}
+	fmt.Printf("No secrets found in %d artifact directories\n", len(dirs))
+	return nil
+}
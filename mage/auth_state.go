@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+// syncAuthState makes the deployed OIDC mock serve the key of the shared auth state.
+func (Test) syncAuthState() error {
+	updated, err := utils.SyncOIDCMock()
+	if err != nil {
+		return err
+	}
+	if updated {
+		fmt.Printf("Updated the OIDC mock to the key of %s\n", auth.AuthStatePath())
+	} else {
+		fmt.Printf("The OIDC mock serves the key of %s, or is not deployed\n", auth.AuthStatePath())
+	}
+	return nil
+}
+
+// resetAuthState drops the key and the cached tokens of the shared auth state and synchronizes the deployed
+// OIDC mock with a new key. Tokens handed out before, e.g. to the cluster agent of the vEN, stop verifying.
+func (t Test) resetAuthState() error {
+	if err := auth.InvalidateAuthState(); err != nil {
+		return err
+	}
+	fmt.Printf("Removed %s\n", auth.AuthStatePath())
+	return t.syncAuthState()
+}
+
+// deployOIDCMock builds the Go OIDC mock, loads it into kind and deploys it with the key of the shared auth state.
+func (Test) deployOIDCMock() error {
+	if err := utils.DeployOIDCMock(); err != nil {
+		return err
+	}
+	fmt.Printf("The OIDC mock serves the key of %s\n", auth.AuthStatePath())
+	return nil
+}
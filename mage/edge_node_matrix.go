@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/magefile/mage/sh"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+const (
+	// edgeNodeMatrixFileEnvVar replaces configs/edge-node-matrix.yaml with another inventory of edge node
+	// platforms.
+	edgeNodeMatrixFileEnvVar  = "EDGE_NODE_MATRIX_FILE"
+	defaultEdgeNodeMatrixFile = "configs/edge-node-matrix.yaml"
+	// edgeNodePlatformsEnvVar restricts test:EdgeNodeMatrixTest to a comma-separated list of platform names.
+	edgeNodePlatformsEnvVar = "EDGE_NODE_PLATFORMS"
+	// defaultVENVMName is the VM of scripts/ven/bootstrap_vm_cluster_agent.sh when VEN_VM_NAME is not set.
+	defaultVENVMName = "cluster-tests-ven"
+)
+
+// EdgeNodePlatformConfiguration is an edge node OS image and architecture of the edge node matrix.
+type EdgeNodePlatformConfiguration struct {
+	Name string `yaml:"name"`
+	OS   string `yaml:"os"`
+	Arch string `yaml:"arch"`
+	// Requires are the variables the platform needs; it is not covered when one is not set.
+	Requires []string          `yaml:"requires"`
+	Env      map[string]string `yaml:"env"`
+}
+
+// missingRequirements returns the variables of Requires that are not set.
+func (p EdgeNodePlatformConfiguration) missingRequirements() []string {
+	var missing []string
+	for _, key := range p.Requires {
+		if strings.TrimSpace(os.Getenv(key)) == "" {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// edgeNodePlatformResult is the outcome of a platform of the edge node matrix.
+type edgeNodePlatformResult struct {
+	Platform EdgeNodePlatformConfiguration
+	// Outcome is "covered", "failed" or "not covered".
+	Outcome  string
+	Detail   string
+	Duration time.Duration
+}
+
+func loadEdgeNodeMatrix(path string) ([]EdgeNodePlatformConfiguration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var matrix []EdgeNodePlatformConfiguration
+	if err := yaml.Unmarshal(data, &matrix); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for _, platform := range matrix {
+		if platform.Name == "" || platform.OS == "" || platform.Arch == "" {
+			return nil, fmt.Errorf("%s: every platform needs a name, an os and an arch", path)
+		}
+	}
+	return matrix, nil
+}
+
+// edgeNodeMatrixSummary lists every platform of the matrix with its outcome.
+func edgeNodeMatrixSummary(results []edgeNodePlatformResult) string {
+	var summary strings.Builder
+	summary.WriteString("=== edge node matrix ===\n")
+	for _, result := range results {
+		line := fmt.Sprintf("  %-20s %-12s %s", result.Platform.Name, result.Platform.OS+"/"+result.Platform.Arch, result.Outcome)
+		if result.Duration > 0 {
+			line += fmt.Sprintf(" in %s", result.Duration.Round(time.Second))
+		}
+		if result.Detail != "" {
+			line += ": " + result.Detail
+		}
+		summary.WriteString(line + "\n")
+	}
+	return summary.String()
+}
+
+// removeVENVM deletes the VM of a platform, so the next platform can reuse the node GUID as its domain UUID.
+// The cached base image is kept.
+func removeVENVM(name string) error {
+	return runCommand(fmt.Sprintf("sudo virsh destroy %[1]s >/dev/null 2>&1 || true; "+
+		"sudo virsh undefine %[1]s --remove-all-storage >/dev/null 2>&1 || true", name))
+}
+
+// runEdgeNodePlatform provisions the vEN of a platform and runs the cluster API smoke specs against it.
+func runEdgeNodePlatform(platform EdgeNodePlatformConfiguration) error {
+	for key, value := range platform.Env {
+		if err := os.Setenv(key, os.ExpandEnv(value)); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		if name := os.Getenv("VEN_VM_NAME"); name != "" {
+			if err := removeVENVM(name); err != nil {
+				fmt.Printf("Failed to delete the VM %s: %v\n", name, err)
+			}
+		}
+	}()
+
+	if err := maybeBootstrapVEN(); err != nil {
+		return fmt.Errorf("failed to provision the edge node: %w", err)
+	}
+	if err := sourceEnvFile(".ven.env"); err != nil {
+		return err
+	}
+
+	return sh.RunWithV(map[string]string{utils.EdgeNodeOSEnvVar: platform.OS, utils.EdgeNodeArchEnvVar: platform.Arch},
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchClusterApiSmokeTest+"-"+platform.Name),
+		jsonReport(utils.ClusterOrchClusterApiSmokeTest+"-"+platform.Name),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchClusterApiSmokeTest),
+		"./tests/cluster-api-test",
+	)
+}
+
+// Test Provisions the vEN once per OS image and architecture of configs/edge-node-matrix.yaml and runs the
+// cluster API smoke tests against each. EDGE_NODE_PLATFORMS restricts the run to a comma-separated list of
+// platform names.
+func (Test) edgeNodeMatrixTest() error {
+	if !strings.EqualFold(utils.GetEdgeNodeProvider(), utils.EdgeNodeProviderVEN) || os.Getenv("VEN_BOOTSTRAP_CMD") == "" {
+		return fmt.Errorf("the edge node matrix provisions the vEN of each platform; set %s=%s and VEN_BOOTSTRAP_CMD",
+			utils.EdgeNodeProviderEnvVar, utils.EdgeNodeProviderVEN)
+	}
+	path := utils.GetEnv(edgeNodeMatrixFileEnvVar, defaultEdgeNodeMatrixFile)
+	matrix, err := loadEdgeNodeMatrix(path)
+	if err != nil {
+		return err
+	}
+
+	// The VM of the bootstrap holds the node GUID as its domain UUID, which the VM of each platform reuses.
+	if err := removeVENVM(utils.GetEnv("VEN_VM_NAME", defaultVENVMName)); err != nil {
+		return err
+	}
+
+	selected := splitEnvList(edgeNodePlatformsEnvVar)
+	var results []edgeNodePlatformResult
+	failed := false
+	for _, platform := range matrix {
+		if len(selected) > 0 && !slices.Contains(selected, platform.Name) {
+			continue
+		}
+		if missing := platform.missingRequirements(); len(missing) > 0 {
+			results = append(results, edgeNodePlatformResult{Platform: platform, Outcome: "not covered",
+				Detail: strings.Join(missing, ", ") + " not set"})
+			continue
+		}
+
+		fmt.Printf("=== cluster on a %s/%s edge node (%s) ===\n", platform.OS, platform.Arch, platform.Name)
+		start := time.Now()
+		result := edgeNodePlatformResult{Platform: platform, Outcome: "covered"}
+		if err := runEdgeNodePlatform(platform); err != nil {
+			result.Outcome, result.Detail, failed = "failed", err.Error(), true
+		}
+		result.Duration = time.Since(start)
+		results = append(results, result)
+	}
+
+	fmt.Print(edgeNodeMatrixSummary(results))
+	if failed {
+		return fmt.Errorf("the cluster could not be created on every platform of the edge node matrix")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadEdgeNodeMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []EdgeNodePlatformConfiguration
+		wantErr  string
+	}{
+		{
+			"platforms",
+			"- name: ubuntu-amd64\n  os: ubuntu\n  arch: amd64\n  env:\n    VEN_VM_NAME: vm\n" +
+				"- name: ubuntu-arm64\n  os: ubuntu\n  arch: arm64\n  requires: [VEN_VM_IMG_URL]\n",
+			[]EdgeNodePlatformConfiguration{
+				{Name: "ubuntu-amd64", OS: "ubuntu", Arch: "amd64", Env: map[string]string{"VEN_VM_NAME": "vm"}},
+				{Name: "ubuntu-arm64", OS: "ubuntu", Arch: "arm64", Requires: []string{"VEN_VM_IMG_URL"}},
+			},
+			"",
+		},
+		{"missing arch", "- name: ubuntu\n  os: ubuntu\n", nil, "every platform needs a name, an os and an arch"},
+		{"not a list", "name: ubuntu\n", nil, "failed to parse"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "edge-node-matrix.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			matrix, err := loadEdgeNodeMatrix(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(matrix, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, matrix)
+			}
+		})
+	}
+
+	t.Run("checked-in matrix", func(t *testing.T) {
+		if _, err := loadEdgeNodeMatrix(filepath.Join("..", defaultEdgeNodeMatrixFile)); err != nil {
+			t.Errorf("Expected %s to be valid, got %v", defaultEdgeNodeMatrixFile, err)
+		}
+	})
+}
+
+func TestMissingRequirements(t *testing.T) {
+	t.Setenv("MATRIX_TEST_SET", "value")
+	t.Setenv("MATRIX_TEST_BLANK", "  ")
+
+	tests := []struct {
+		name     string
+		requires []string
+		expected []string
+	}{
+		{"no requirements", nil, nil},
+		{"all set", []string{"MATRIX_TEST_SET"}, nil},
+		{"blank and unset", []string{"MATRIX_TEST_SET", "MATRIX_TEST_BLANK", "MATRIX_TEST_UNSET"}, []string{"MATRIX_TEST_BLANK", "MATRIX_TEST_UNSET"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platform := EdgeNodePlatformConfiguration{Name: "p", OS: "ubuntu", Arch: "amd64", Requires: tt.requires}
+			if missing := platform.missingRequirements(); !reflect.DeepEqual(missing, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, missing)
+			}
+		})
+	}
+}
+
+func TestEdgeNodeMatrixSummary(t *testing.T) {
+	ubuntu := EdgeNodePlatformConfiguration{Name: "ubuntu-amd64", OS: "ubuntu", Arch: "amd64"}
+	tests := []struct {
+		name     string
+		result   edgeNodePlatformResult
+		expected string
+	}{
+		{"covered", edgeNodePlatformResult{Platform: ubuntu, Outcome: "covered", Duration: 90*time.Second + 400*time.Millisecond},
+			"  ubuntu-amd64         ubuntu/amd64 covered in 1m30s"},
+		{"failed", edgeNodePlatformResult{Platform: ubuntu, Outcome: "failed", Detail: "exit status 1", Duration: time.Minute},
+			"  ubuntu-amd64         ubuntu/amd64 failed in 1m0s: exit status 1"},
+		{"not covered", edgeNodePlatformResult{Platform: ubuntu, Outcome: "not covered", Detail: "VEN_VM_IMG_URL not set"},
+			"  ubuntu-amd64         ubuntu/amd64 not covered: VEN_VM_IMG_URL not set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected := "=== edge node matrix ===\n" + tt.expected + "\n"
+			if summary := edgeNodeMatrixSummary([]edgeNodePlatformResult{tt.result}); summary != expected {
+				t.Errorf("Expected %q, got %q", expected, summary)
+			}
+		})
+	}
+}
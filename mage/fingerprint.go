@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+const (
+	// fingerprintBaseEnvVar and fingerprintHeadEnvVar are the runs test:DiffFingerprints compares: an
+	// environment-*.json fingerprint, or any report that embeds one.
+	fingerprintBaseEnvVar = "FINGERPRINT_BASE"
+	fingerprintHeadEnvVar = "FINGERPRINT_HEAD"
+)
+
+// Test Prints what differs between the environments of two runs, e.g. the run that passed yesterday and the
+// one that fails today.
+func (Test) diffFingerprints() error {
+	basePath, headPath := os.Getenv(fingerprintBaseEnvVar), os.Getenv(fingerprintHeadEnvVar)
+	if basePath == "" || headPath == "" {
+		return fmt.Errorf("set %s and %s to the fingerprints of the runs to compare", fingerprintBaseEnvVar, fingerprintHeadEnvVar)
+	}
+	base, err := utils.LoadEnvironmentFingerprint(basePath)
+	if err != nil {
+		return err
+	}
+	head, err := utils.LoadEnvironmentFingerprint(headPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("base %s captured %s\nhead %s captured %s\n", base.Digest, base.CapturedAt.Format("2006-01-02 15:04:05"),
+		head.Digest, head.CapturedAt.Format("2006-01-02 15:04:05"))
+	diff := utils.DiffEnvironmentFingerprints(base, head)
+	if len(diff) == 0 {
+		fmt.Println("The runs used the same environment")
+		return nil
+	}
+	for _, line := range diff {
+		fmt.Printf("  %s\n", line)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+const (
+	// inClusterManifestEnvVar is the file test:InClusterManifest writes, inClusterManifestFile by default.
+	inClusterManifestEnvVar = "IN_CLUSTER_MANIFEST"
+	inClusterManifestFile   = "in-cluster-runner.yaml"
+	// inClusterImageEnvVar is the image built from the Dockerfile that the runner Job runs.
+	inClusterImageEnvVar = "IN_CLUSTER_IMAGE"
+	inClusterImage       = "cluster-tests-runner:latest"
+	// inClusterRunnerNamespaceEnvVar is the namespace of the runner Job.
+	inClusterRunnerNamespaceEnvVar = "IN_CLUSTER_RUNNER_NAMESPACE"
+	inClusterRunnerNamespace       = "cluster-tests"
+	// inClusterLabelFilterEnvVar selects the specs to run when no PROFILE is set.
+	inClusterLabelFilterEnvVar = "IN_CLUSTER_LABEL_FILTER"
+	// inClusterEnvEnvVar lists the variables of the caller's environment to pass on to the runner,
+	// comma-separated, e.g. DISABLE_AUTH,NAMESPACE.
+	inClusterEnvEnvVar = "IN_CLUSTER_ENV"
+)
+
+// inClusterRunner is the runner Job of the suites inside the management cluster.
+type inClusterRunner struct {
+	Namespace string
+	Image     string
+	Args      []string
+	Env       [][2]string
+}
+
+// inClusterManifestTemplate is a ServiceAccount bound to cluster-admin, as the suites create namespaces and CAPI
+// objects and read the logs of the components, and a Job running ginkgo with it.
+var inClusterManifestTemplate = template.Must(template.New("runner").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(`---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: {{ .Namespace }}
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: cluster-tests-runner
+  namespace: {{ .Namespace }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: cluster-tests-runner
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: cluster-admin
+subjects:
+  - kind: ServiceAccount
+    name: cluster-tests-runner
+    namespace: {{ .Namespace }}
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: cluster-tests-runner
+  namespace: {{ .Namespace }}
+spec:
+  backoffLimit: 0
+  template:
+    metadata:
+      labels:
+        app: cluster-tests-runner
+    spec:
+      serviceAccountName: cluster-tests-runner
+      restartPolicy: Never
+      containers:
+        - name: runner
+          image: {{ quote .Image }}
+          command: ["ginkgo"]
+          args:
+{{- range .Args }}
+            - {{ quote . }}
+{{- end }}
+          env:
+{{- range .Env }}
+            - name: {{ index . 0 }}
+              value: {{ quote (index . 1) }}
+{{- end }}
+`))
+
+// newInClusterRunner returns the runner of the run profile PROFILE, or of the specs matching
+// IN_CLUSTER_LABEL_FILTER when no profile is set. The variables of the caller listed in IN_CLUSTER_ENV,
+// e.g. the NODEGUID of a VEN node, win over the ones of the profile.
+func newInClusterRunner() (*inClusterRunner, error) {
+	runner := &inClusterRunner{
+		Namespace: utils.GetEnv(inClusterRunnerNamespaceEnvVar, inClusterRunnerNamespace),
+		Image:     utils.GetEnv(inClusterImageEnvVar, inClusterImage),
+	}
+	env := map[string]string{}
+
+	if name := strings.TrimSpace(os.Getenv(runProfileEnvVar)); name != "" {
+		profile, err := loadRunProfile(name)
+		if err != nil {
+			return nil, err
+		}
+		if profile.Bootstrap {
+			fmt.Printf("Skipping the bootstrap of run profile %s: the runner tests the cluster it runs in\n", name)
+		}
+		runner.Args = profile.ginkgoArgs()
+		for key, value := range profile.Env {
+			env[key] = value
+		}
+		if profile.Provider != "" {
+			env[utils.EdgeNodeProviderEnvVar] = profile.Provider
+		}
+	} else {
+		filter := strings.TrimSpace(os.Getenv(inClusterLabelFilterEnvVar))
+		if filter == "" {
+			return nil, fmt.Errorf("set %s or %s to select the specs of the in-cluster runner", runProfileEnvVar, inClusterLabelFilterEnvVar)
+		}
+		runner.Args = []string{"-v", "-r", "--keep-going", "--label-filter=" + filter, "./tests"}
+	}
+
+	for _, key := range splitEnvList(inClusterEnvEnvVar) {
+		if value, ok := os.LookupEnv(key); ok {
+			env[key] = value
+		}
+	}
+	env[utils.InClusterEnvVar] = "true"
+	env[utils.InClusterServiceNamespaceEnvVar] = utils.GetEnv(utils.InClusterServiceNamespaceEnvVar, utils.DefaultInClusterServiceNamespace)
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		runner.Env = append(runner.Env, [2]string{key, env[key]})
+	}
+	return runner, nil
+}
+
+// Test Writes the manifest of a Job running the suites from a pod of the management cluster, for the
+// run profile PROFILE or the specs matching IN_CLUSTER_LABEL_FILTER.
+func (Test) inClusterManifest() error {
+	runner, err := newInClusterRunner()
+	if err != nil {
+		return err
+	}
+	file := utils.GetEnv(inClusterManifestEnvVar, inClusterManifestFile)
+	out, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if err := inClusterManifestTemplate.Execute(out, runner); err != nil {
+		return fmt.Errorf("failed to render %s: %w", file, err)
+	}
+	fmt.Printf("In-cluster runner manifest written to %s, apply it with kubectl apply -f %s\n", file, file)
+	return nil
+}
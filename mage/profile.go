@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+
+	"github.com/magefile/mage/sh"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// runProfilesFile defines the run profiles of test:Profile.
+	runProfilesFile = "configs/run-profiles.yaml"
+	// runProfileEnvVar selects the run profile.
+	runProfileEnvVar = "PROFILE"
+)
+
+// RunProfile is a named run configuration of configs/run-profiles.yaml.
+type RunProfile struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Provider    string            `yaml:"provider"`
+	Bootstrap   bool              `yaml:"bootstrap"`
+	Suites      []string          `yaml:"suites"`
+	Labels      []string          `yaml:"labels"`
+	Timeout     string            `yaml:"timeout"`
+	FailFast    bool              `yaml:"fail-fast"`
+	Env         map[string]string `yaml:"env"`
+}
+
+// loadRunProfile returns the run profile with the given name.
+func loadRunProfile(name string) (*RunProfile, error) {
+	data, err := os.ReadFile(runProfilesFile)
+	if err != nil {
+		return nil, err
+	}
+	var profiles []RunProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", runProfilesFile, err)
+	}
+
+	var names []string
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i], profiles[i].validate()
+		}
+		names = append(names, profiles[i].Name)
+	}
+	return nil, fmt.Errorf("unknown run profile %q, set %s to one of: %s", name, runProfileEnvVar, strings.Join(names, ", "))
+}
+
+func (p *RunProfile) validate() error {
+	if p.Provider != "" && !strings.EqualFold(p.Provider, utils.EdgeNodeProviderVEN) {
+		return fmt.Errorf("run profile %s: unsupported provider %q", p.Name, p.Provider)
+	}
+	if len(p.Suites) == 0 {
+		return fmt.Errorf("run profile %s: no suites", p.Name)
+	}
+	if len(p.Labels) == 0 {
+		return fmt.Errorf("run profile %s: no labels", p.Name)
+	}
+	for _, label := range p.Labels {
+		if !utils.IsSpecLabel(label) {
+			return fmt.Errorf("run profile %s: unknown label %q, see utils.SpecLabels", p.Name, label)
+		}
+	}
+	return nil
+}
+
+// applyEnv sets the environment of the profile, leaving alone the variables the caller already set.
+func (p *RunProfile) applyEnv() error {
+	env := map[string]string{}
+	for key, value := range p.Env {
+		env[key] = value
+	}
+	if p.Provider != "" {
+		env[utils.EdgeNodeProviderEnvVar] = p.Provider
+	}
+	for key, value := range env {
+		if _, ok := os.LookupEnv(key); ok {
+			fmt.Printf("Keeping %s from the environment over the profile\n", key)
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ginkgoArgs returns the ginkgo command line running the specs of the profile.
+func (p *RunProfile) ginkgoArgs() []string {
+	args := []string{"-v", "-r", "--race"}
+	if p.FailFast {
+		args = append(args, "--fail-fast")
+	} else {
+		// Keep running the remaining suites after a failed one.
+		args = append(args, "--keep-going")
+	}
+	if p.Timeout != "" {
+		args = append(args, "--timeout="+p.Timeout)
+	}
+	args = append(args, "--label-filter="+strings.Join(p.Labels, " || "))
+	return append(args, p.Suites...)
+}
+
+// sourceEnvFile sets the variables exported by an env file written by the bootstrap, as `source` does
+// for the Make targets. A missing file is not an error.
+func sourceEnvFile(file string) error {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !ok {
+			return fmt.Errorf("%s: unexpected line %q", file, line)
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if err := os.Setenv(strings.TrimSpace(key), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Test Runs the suites of the run profile PROFILE of configs/run-profiles.yaml, bootstrapping the
+// environment first when the profile asks for it.
+func (t Test) profile() error {
+	name := strings.TrimSpace(os.Getenv(runProfileEnvVar))
+	profile, err := loadRunProfile(name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("=== run profile %s: %s ===\n", profile.Name, profile.Description)
+
+	if err := profile.applyEnv(); err != nil {
+		return err
+	}
+	if profile.Bootstrap {
+		if err := t.bootstrap(); err != nil {
+			return fmt.Errorf("run profile %s: bootstrap failed: %w", profile.Name, err)
+		}
+	}
+	// The bootstrap hands the node and the project over through env files, see maybeBootstrapVEN.
+	for _, file := range []string{".ven.env", ".tenancy.env"} {
+		if err := sourceEnvFile(file); err != nil {
+			return err
+		}
+	}
+
+	// The in-cluster runner shares ginkgoArgs, but not a filesystem to write the reports to.
+	reports := []string{junitReport("profile-" + profile.Name), jsonReport("profile-" + profile.Name)}
+	return sh.RunV("ginkgo", append(reports, profile.ginkgoArgs()...)...)
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRunProfileValidate(t *testing.T) {
+	valid := func() RunProfile {
+		return RunProfile{Name: "smoke", Provider: "ven", Suites: []string{"./tests/cluster-api-test"},
+			Labels: []string{utils.ClusterOrchClusterApiSmokeTest}}
+	}
+	tests := []struct {
+		name    string
+		modify  func(p *RunProfile)
+		wantErr string
+	}{
+		{"valid", func(*RunProfile) {}, ""},
+		{"provider in upper case", func(p *RunProfile) { p.Provider = "VEN" }, ""},
+		{"no provider", func(p *RunProfile) { p.Provider = "" }, ""},
+		{"unsupported provider", func(p *RunProfile) { p.Provider = "kind" }, `unsupported provider "kind"`},
+		{"no suites", func(p *RunProfile) { p.Suites = nil }, "no suites"},
+		{"no labels", func(p *RunProfile) { p.Labels = nil }, "no labels"},
+		{"unknown label", func(p *RunProfile) { p.Labels = append(p.Labels, "no-such-label") }, `unknown label "no-such-label"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := valid()
+			tt.modify(&profile)
+			err := profile.validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRunProfileGinkgoArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  RunProfile
+		expected []string
+	}{
+		{
+			"keep going",
+			RunProfile{Suites: []string{"./tests/a-test"}, Labels: []string{"a"}},
+			[]string{"-v", "-r", "--race", "--keep-going", "--label-filter=a", "./tests/a-test"},
+		},
+		{
+			"fail fast with a timeout",
+			RunProfile{Suites: []string{"./tests/a-test", "./tests/b-test"}, Labels: []string{"a", "b"}, FailFast: true, Timeout: "45m"},
+			[]string{"-v", "-r", "--race", "--fail-fast", "--timeout=45m", "--label-filter=a || b", "./tests/a-test", "./tests/b-test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if args := tt.profile.ginkgoArgs(); !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, args)
+			}
+		})
+	}
+}
+
+func TestRunProfileApplyEnv(t *testing.T) {
+	t.Setenv("PROFILE_TEST_KEPT", "caller")
+	t.Setenv("PROFILE_TEST_SET", "")
+	os.Unsetenv("PROFILE_TEST_SET")
+	t.Setenv(utils.EdgeNodeProviderEnvVar, "")
+	os.Unsetenv(utils.EdgeNodeProviderEnvVar)
+
+	profile := RunProfile{Provider: "ven", Env: map[string]string{"PROFILE_TEST_KEPT": "profile", "PROFILE_TEST_SET": "profile"}}
+	if err := profile.applyEnv(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for key, expected := range map[string]string{"PROFILE_TEST_KEPT": "caller", "PROFILE_TEST_SET": "profile", utils.EdgeNodeProviderEnvVar: "ven"} {
+		if value := os.Getenv(key); value != expected {
+			t.Errorf("Expected %s=%s, got %q", key, expected, value)
+		}
+	}
+}
+
+func TestSourceEnvFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{"exports", "export ENV_FILE_A=1\nENV_FILE_B=two\n", map[string]string{"ENV_FILE_A": "1", "ENV_FILE_B": "two"}, false},
+		{"quoted values", `export ENV_FILE_A="a b"` + "\n", map[string]string{"ENV_FILE_A": "a b"}, false},
+		{"comments and blank lines", "# node\n\n  export ENV_FILE_A=1  \n", map[string]string{"ENV_FILE_A": "1"}, false},
+		{"not an assignment", "source other.env\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"ENV_FILE_A", "ENV_FILE_B"} {
+				t.Setenv(key, "")
+			}
+			file := filepath.Join(t.TempDir(), ".ven.env")
+			if err := os.WriteFile(file, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			err := sourceEnvFile(file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected an error %t, got %v", tt.wantErr, err)
+			}
+			for key, expected := range tt.expected {
+				if value := os.Getenv(key); value != expected {
+					t.Errorf("Expected %s=%q, got %q", key, expected, value)
+				}
+			}
+		})
+	}
+
+	if err := sourceEnvFile(filepath.Join(t.TempDir(), "missing.env")); err != nil {
+		t.Errorf("Expected a missing env file to be ignored, got %v", err)
+	}
+}
+
+func TestLoadRunProfile(t *testing.T) {
+	t.Chdir("..")
+
+	profile, err := loadRunProfile("smoke-fast")
+	if err != nil {
+		t.Fatalf("Expected the smoke-fast profile of %s to be valid, got %v", runProfilesFile, err)
+	}
+	if !profile.Bootstrap || len(profile.Suites) == 0 {
+		t.Errorf("Expected smoke-fast to bootstrap and run suites, got %+v", profile)
+	}
+
+	data, err := os.ReadFile(runProfilesFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var profiles []RunProfile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range profiles {
+		if _, err := loadRunProfile(p.Name); err != nil {
+			t.Errorf("Expected the run profile %s to be valid, got %v", p.Name, err)
+		}
+	}
+
+	if _, err := loadRunProfile("no-such-profile"); err == nil || !strings.Contains(err.Error(), "smoke-fast") {
+		t.Errorf("Expected an error listing the profiles, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+// ginkgoNodes are the ginkgo containers and specs SkipWithIssue decorates.
+var ginkgoNodes = map[string]bool{
+	"Describe": true, "Context": true, "When": true, "DescribeTable": true, "It": true, "Specify": true, "Entry": true,
+}
+
+// quarantinedSpec is a container or spec decorated with utils.SkipWithIssue.
+type quarantinedSpec struct {
+	Location string
+	Text     string
+	Issue    string
+	Reason   string
+}
+
+// quarantinedSpecs returns the containers and specs of the suites decorated with utils.SkipWithIssue, with
+// the text of their containers.
+func quarantinedSpecs() ([]quarantinedSpec, error) {
+	files, err := filepath.Glob(filepath.Join("tests", "*-test", "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var specs []quarantinedSpec
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		var visit func(node ast.Node, texts []string)
+		visit = func(node ast.Node, texts []string) {
+			ast.Inspect(node, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || !ginkgoNodes[strings.TrimLeft(callName(call), "FPX")] || len(call.Args) == 0 {
+					return true
+				}
+				text := "<computed>"
+				if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+					text, _ = strconv.Unquote(lit.Value)
+				}
+				// Not assigned to texts, so the text of a node does not end up in the texts of its siblings.
+				nested := append(texts[:len(texts):len(texts)], text)
+				for _, arg := range call.Args[1:] {
+					decorator, ok := arg.(*ast.CallExpr)
+					if !ok || callName(decorator) != "SkipWithIssue" || len(decorator.Args) != 2 {
+						continue
+					}
+					spec := quarantinedSpec{Location: fset.Position(call.Pos()).String(), Text: strings.Join(nested, " ")}
+					spec.Issue, spec.Reason = stringArg(decorator.Args[0]), stringArg(decorator.Args[1])
+					specs = append(specs, spec)
+				}
+				for _, arg := range call.Args[1:] {
+					visit(arg, nested)
+				}
+				return false
+			})
+		}
+		visit(file, nil)
+	}
+	return specs, nil
+}
+
+func callName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	}
+	return ""
+}
+
+// stringArg returns a string literal, or the source of another expression.
+func stringArg(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok {
+		if value, err := strconv.Unquote(lit.Value); err == nil {
+			return value
+		}
+	}
+	return fmt.Sprintf("<%T>", expr)
+}
+
+// Test Lists the specs quarantined with utils.SkipWithIssue and the issues to fix before enabling them again.
+func (Test) quarantined() error {
+	specs, err := quarantinedSpecs()
+	if err != nil {
+		return err
+	}
+	if len(specs) == 0 {
+		fmt.Println("No quarantined specs")
+		return nil
+	}
+	for _, spec := range specs {
+		issue := spec.Issue
+		if link := utils.IssueLink(spec.Issue); link != "" {
+			issue += " " + link
+		}
+		fmt.Printf("%s\n  %s\n  %s: %s\n", spec.Location, spec.Text, issue, spec.Reason)
+	}
+	fmt.Printf("%d quarantined containers and specs\n", len(specs))
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"go/ast"
+	"go/parser"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const quarantinedSuite = `package demo_test
+
+var _ = Describe("Demo", Label("demo"), func() {
+	It("is not quarantined", func() {})
+
+	It("flakes", utils.SkipWithIssue("org/repo#1", "times out"), func() {})
+
+	Context(name, utils.SkipWithIssue("org/repo#2", reason), func() {
+		FIt("is in a quarantined container", func() {})
+	})
+
+	DescribeTable("table", func(int) {},
+		Entry("broken entry", utils.SkipWithIssue("org/repo#3", "wrong value"), 1),
+	)
+})
+`
+
+func TestQuarantinedSpecs(t *testing.T) {
+	dir := t.TempDir()
+	suiteDir := filepath.Join(dir, "tests", "demo-test")
+	if err := os.MkdirAll(suiteDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(suiteDir, "demo_test.go"), []byte(quarantinedSuite), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	specs, err := quarantinedSpecs()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	location := filepath.Join("tests", "demo-test", "demo_test.go")
+	expected := []quarantinedSpec{
+		{Location: location + ":6:2", Text: "Demo flakes", Issue: "org/repo#1", Reason: "times out"},
+		{Location: location + ":8:2", Text: "Demo <computed>", Issue: "org/repo#2", Reason: "<*ast.Ident>"},
+		{Location: location + ":13:3", Text: "Demo table broken entry", Issue: "org/repo#3", Reason: "wrong value"},
+	}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, specs)
+	}
+}
+
+func TestCallName(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{`It("spec")`, "It"},
+		{`utils.SkipWithIssue("a", "b")`, "SkipWithIssue"},
+		{`func() {}()`, ""},
+	}
+
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name := callName(expr.(*ast.CallExpr)); name != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.expr, tt.expected, name)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const (
+	// artifactsDirEnvVar selects where the ginkgo runs of the mage targets write their JUnit and JSON reports.
+	artifactsDirEnvVar  = "ARTIFACTS_DIR"
+	defaultArtifactsDir = "artifacts"
+
+	// slowestSpecs is how many specs report:summarize lists as the slowest.
+	slowestSpecs = 10
+)
+
+// artifactsDir returns the absolute ARTIFACTS_DIR, as ginkgo -r resolves report paths from each suite directory.
+func artifactsDir() string {
+	dir := os.Getenv(artifactsDirEnvVar)
+	if dir == "" {
+		dir = defaultArtifactsDir
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+	return dir
+}
+
+// junitReport returns the ginkgo flag writing the JUnit report of the run name to ARTIFACTS_DIR.
+func junitReport(name string) string {
+	return "--junit-report=" + filepath.Join(artifactsDir(), name+".xml")
+}
+
+// jsonReport returns the ginkgo flag writing the JSON report of the run name to ARTIFACTS_DIR.
+func jsonReport(name string) string {
+	return "--json-report=" + filepath.Join(artifactsDir(), name+".json")
+}
+
+// specResult is a spec of a JSON report.
+type specResult struct {
+	Run      string
+	Suite    string
+	Text     string
+	State    types.SpecState
+	Duration time.Duration
+}
+
+// suiteResults counts the specs of a suite by state.
+type suiteResults struct {
+	Suite                            string
+	Passed, Failed, Skipped, Pending int
+}
+
+// loadSpecResults returns the specs of the JSON reports in dir, the run being the report name.
+func loadSpecResults(dir string) ([]specResult, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var results []specResult
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var reports []types.Report
+		if err := json.Unmarshal(data, &reports); err != nil {
+			return nil, fmt.Errorf("%s is not a ginkgo JSON report: %w", file, err)
+		}
+		run := strings.TrimSuffix(filepath.Base(file), ".json")
+		for _, report := range reports {
+			for _, spec := range report.SpecReports {
+				if spec.LeafNodeType != types.NodeTypeIt {
+					continue
+				}
+				results = append(results, specResult{
+					Run:      run,
+					Suite:    filepath.Base(report.SuitePath),
+					Text:     spec.FullText(),
+					State:    spec.State,
+					Duration: spec.RunTime,
+				})
+			}
+		}
+	}
+	return results, nil
+}
+
+// summarizeSuites returns the counts of the specs of each suite, by suite name.
+func summarizeSuites(results []specResult) []suiteResults {
+	bySuite := map[string]*suiteResults{}
+	var suites []*suiteResults
+	for _, result := range results {
+		suite := bySuite[result.Suite]
+		if suite == nil {
+			suite = &suiteResults{Suite: result.Suite}
+			bySuite[result.Suite] = suite
+			suites = append(suites, suite)
+		}
+		switch {
+		case result.State == types.SpecStatePassed:
+			suite.Passed++
+		case result.State.Is(types.SpecStateFailureStates):
+			suite.Failed++
+		case result.State == types.SpecStatePending:
+			suite.Pending++
+		default:
+			suite.Skipped++
+		}
+	}
+	sort.Slice(suites, func(i, j int) bool { return suites[i].Suite < suites[j].Suite })
+	summary := make([]suiteResults, len(suites))
+	for i, suite := range suites {
+		summary[i] = *suite
+	}
+	return summary
+}
+
+// writeSummary writes the specs that ran with their duration, the slowest of them and the pass/fail matrix
+// of the suites.
+func writeSummary(out io.Writer, results []specResult) error {
+	var ran []specResult
+	for _, result := range results {
+		if result.State != types.SpecStateSkipped && result.State != types.SpecStatePending {
+			ran = append(ran, result)
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "=== specs ===")
+	fmt.Fprintln(w, "RUN\tSUITE\tSTATE\tDURATION\tSPEC")
+	for _, result := range ran {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%s\n", result.Run, result.Suite, result.State, result.Duration.Round(time.Second), result.Text)
+	}
+
+	sort.SliceStable(ran, func(i, j int) bool { return ran[i].Duration > ran[j].Duration })
+	fmt.Fprintf(w, "\n=== %d slowest specs ===\n", min(slowestSpecs, len(ran)))
+	fmt.Fprintln(w, "DURATION\tSUITE\tSPEC")
+	for _, result := range ran[:min(slowestSpecs, len(ran))] {
+		fmt.Fprintf(w, "%v\t%s\t%s\n", result.Duration.Round(time.Second), result.Suite, result.Text)
+	}
+
+	fmt.Fprintln(w, "\n=== suites ===")
+	fmt.Fprintln(w, "SUITE\tPASSED\tFAILED\tSKIPPED\tPENDING")
+	for _, suite := range summarizeSuites(results) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\n", suite.Suite, suite.Passed, suite.Failed, suite.Skipped, suite.Pending)
+	}
+	return w.Flush()
+}
+
+// summarize prints the per-spec durations, the slowest specs and the pass/fail matrix of the suites of the
+// JSON reports in ARTIFACTS_DIR.
+func (Report) summarize() error {
+	dir := artifactsDir()
+	results, err := loadSpecResults(dir)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no ginkgo JSON reports in %s; run a mage test target first", dir)
+	}
+	return writeSummary(os.Stdout, results)
+}
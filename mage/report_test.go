@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestReportFlags(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		dir   string
+		junit string
+		json  string
+	}{
+		{"default", "", filepath.Join(wd, "artifacts", "smoke.xml"), filepath.Join(wd, "artifacts", "smoke.json")},
+		{"relative", "out/reports", filepath.Join(wd, "out", "reports", "smoke.xml"), filepath.Join(wd, "out", "reports", "smoke.json")},
+		{"absolute", "/tmp/reports", "/tmp/reports/smoke.xml", "/tmp/reports/smoke.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(artifactsDirEnvVar, tt.dir)
+			if flag := junitReport("smoke"); flag != "--junit-report="+tt.junit {
+				t.Errorf("Expected the JUnit report at %s, got %s", tt.junit, flag)
+			}
+			if flag := jsonReport("smoke"); flag != "--json-report="+tt.json {
+				t.Errorf("Expected the JSON report at %s, got %s", tt.json, flag)
+			}
+		})
+	}
+}
+
+// writeJSONReport writes a ginkgo JSON report of a suite with the given specs to dir.
+func writeJSONReport(t *testing.T, dir, run, suitePath string, specs types.SpecReports) {
+	t.Helper()
+	data, err := json.Marshal([]types.Report{{SuitePath: suitePath, SpecReports: specs}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, run+".json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSpecResults(t *testing.T) {
+	dir := t.TempDir()
+	writeJSONReport(t, dir, "smoke", "/repo/tests/cluster-api-test", types.SpecReports{
+		{LeafNodeType: types.NodeTypeBeforeSuite, State: types.SpecStatePassed},
+		{LeafNodeType: types.NodeTypeIt, ContainerHierarchyTexts: []string{"Cluster"}, LeafNodeText: "is created",
+			State: types.SpecStatePassed, RunTime: 2 * time.Minute},
+		{LeafNodeType: types.NodeTypeIt, ContainerHierarchyTexts: []string{"Cluster"}, LeafNodeText: "is deleted",
+			State: types.SpecStateFailed, RunTime: time.Minute},
+	})
+	if err := os.WriteFile(filepath.Join(dir, "smoke.xml"), []byte("<testsuites/>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := loadSpecResults(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []specResult{
+		{Run: "smoke", Suite: "cluster-api-test", Text: "Cluster is created", State: types.SpecStatePassed, Duration: 2 * time.Minute},
+		{Run: "smoke", Suite: "cluster-api-test", Text: "Cluster is deleted", State: types.SpecStateFailed, Duration: time.Minute},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, results)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadSpecResults(dir); err == nil || !strings.Contains(err.Error(), "not a ginkgo JSON report") {
+		t.Errorf("Expected an error for a report that is not JSON, got %v", err)
+	}
+}
+
+func TestSummarizeSuites(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []specResult
+		expected []suiteResults
+	}{
+		{"no specs", nil, []suiteResults{}},
+		{
+			"one suite",
+			[]specResult{
+				{Suite: "gateway-test", State: types.SpecStatePassed},
+				{Suite: "gateway-test", State: types.SpecStateFailed},
+				{Suite: "gateway-test", State: types.SpecStatePanicked},
+				{Suite: "gateway-test", State: types.SpecStateTimedout},
+				{Suite: "gateway-test", State: types.SpecStateSkipped},
+				{Suite: "gateway-test", State: types.SpecStatePending},
+			},
+			[]suiteResults{{Suite: "gateway-test", Passed: 1, Failed: 3, Skipped: 1, Pending: 1}},
+		},
+		{
+			"suites sorted by name",
+			[]specResult{
+				{Suite: "robustness-test", State: types.SpecStatePassed},
+				{Suite: "cluster-api-test", State: types.SpecStateSkipped},
+				{Suite: "robustness-test", State: types.SpecStatePassed},
+			},
+			[]suiteResults{{Suite: "cluster-api-test", Skipped: 1}, {Suite: "robustness-test", Passed: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if summary := summarizeSuites(tt.results); !reflect.DeepEqual(summary, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, summary)
+			}
+		})
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var results []specResult
+	for i := 1; i <= slowestSpecs+2; i++ {
+		results = append(results, specResult{Run: "all", Suite: "cluster-api-test", Text: "spec " + strings.Repeat("x", i),
+			State: types.SpecStatePassed, Duration: time.Duration(i) * time.Minute})
+	}
+	results = append(results, specResult{Run: "all", Suite: "cluster-api-test", Text: "skipped spec", State: types.SpecStateSkipped})
+
+	var out bytes.Buffer
+	if err := writeSummary(&out, results); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	summary := out.String()
+	specs, rest, _ := strings.Cut(summary, "slowest specs ===")
+	slowest, suites, _ := strings.Cut(rest, "=== suites ===")
+
+	if strings.Contains(specs, "skipped spec") {
+		t.Errorf("Expected the skipped spec to be left out of the specs that ran:\n%s", specs)
+	}
+	if !strings.Contains(specs, "=== 10 ") {
+		t.Errorf("Expected the slowest specs section to list %d specs:\n%s", slowestSpecs, summary)
+	}
+	if lines := strings.Split(strings.TrimSpace(slowest), "\n"); len(lines) != slowestSpecs+1 || !strings.HasPrefix(lines[1], "12m0s") {
+		t.Errorf("Expected the %d slowest specs, slowest first:\n%s", slowestSpecs, slowest)
+	}
+	if !strings.Contains(suites, "cluster-api-test  12") {
+		t.Errorf("Expected the suite with 12 passed specs:\n%s", suites)
+	}
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	// newSuiteNameEnvVar is the name of the suite test:NewSuite generates, e.g. "node-drain" for
+	// tests/node-drain-test.
+	newSuiteNameEnvVar = "NAME"
+
+	// The files test:NewSuite registers a suite in, and the markers the generated code is inserted before.
+	labelsFile          = "tests/utils/labels.go"
+	labelsConstEnd      = "\n)\n\n// SpecLabels"
+	labelsRegistryEnd   = "\n}\n\n// IsSpecLabel"
+	testTargetsFile     = "mage/test.go"
+	testTargetsEnd      = "/////// Helper functions ///////"
+	magefileTargetsFile = "mage/Magefile.go"
+	magefileTargetsEnd  = "////// Lint specific targets"
+	makefileTargetsFile = "Makefile"
+	makefileTargetsEnd  = ".PHONY: help\n"
+)
+
+var suiteNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// suiteScaffold names the pieces of a generated suite.
+type suiteScaffold struct {
+	// Name is the suite name without the -test suffix, e.g. "node-drain".
+	Name string
+	// Ident is the name in CamelCase, e.g. "NodeDrain".
+	Ident string
+	// Title is the name in words, e.g. "node drain".
+	Title string
+	Year  int
+}
+
+func newSuiteScaffold(name string) (suiteScaffold, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), "-test")
+	if !suiteNamePattern.MatchString(name) {
+		return suiteScaffold{}, fmt.Errorf("set %s to the lowercase, dash-separated name of the suite, e.g. %s=node-drain",
+			newSuiteNameEnvVar, newSuiteNameEnvVar)
+	}
+	words := strings.Split(name, "-")
+	var ident strings.Builder
+	for _, word := range words {
+		ident.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return suiteScaffold{Name: name, Ident: ident.String(), Title: strings.Join(words, " "), Year: time.Now().Year()}, nil
+}
+
+func (s suiteScaffold) Dir() string        { return filepath.Join("tests", s.Name+"-test") }
+func (s suiteScaffold) File() string       { return strings.ReplaceAll(s.Name, "-", "_") + "_test.go" }
+func (s suiteScaffold) Package() string    { return strings.ReplaceAll(s.Name, "-", "_") + "_test" }
+func (s suiteScaffold) Label() string      { return "ClusterOrch" + s.Ident + "Test" }
+func (s suiteScaffold) LabelValue() string { return "cluster-orch-" + s.Name + "-test" }
+func (s suiteScaffold) Target() string     { return "ClusterOrch" + s.Ident + "Test" }
+
+// The suite follows the current patterns: the framework hooks are registered, the namespace comes from utils,
+// utils.RegisterPortForwards holds the port-forwards for the whole suite, and clusters and downstream
+// kubeconfigs go through utils.NewSharedCluster and utils.WriteDownstreamKubeconfig instead of being
+// recreated per spec or edited with sed.
+var suiteTemplate = template.Must(template.New("suite").Parse(`// SPDX-FileCopyrightText: (C) {{.Year}} Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package {{.Package}}
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+)
+
+func Test{{.Ident}}Tests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch {{.Title}} tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch {{.Title}} test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+var _ = utils.RegisterSharedClusters()
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+// Specs that need a cluster share one created with utils.NewSharedCluster, and reach it through the
+// gateway with the kubeconfig of utils.WriteDownstreamKubeconfig.
+var _ = Describe("{{.Title}}", Ordered, Label(utils.{{.Label}}), func() {
+	var namespace string
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+	})
+
+	It("should reach cluster-manager", func() {
+		_, err := utils.ListClusters(namespace)
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
+`))
+
+var testTargetTemplate = template.Must(template.New("test target").Parse(`// Test Runs cluster orch {{.Title}} tests
+func (Test) clusterOrch{{.Ident}}Test() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.{{.Label}}),
+		jsonReport(utils.{{.Label}}),
+		fmt.Sprintf("--label-filter=%s", utils.{{.Label}}),
+		"./{{.Dir}}",
+	)
+}
+
+`))
+
+var magefileTargetTemplate = template.Must(template.New("magefile target").Parse(`// {{.Target}} Runs cluster orch {{.Title}} tests
+func (t Test) {{.Target}}() error {
+	return t.clusterOrch{{.Ident}}Test()
+}
+
+`))
+
+var makefileTargetTemplate = template.Must(template.New("makefile target").Parse(`.PHONY: {{.Name}}-test
+{{.Name}}-test: ## Runs cluster orch {{.Title}} tests
+	PATH=${ENV_PATH} DISABLE_AUTH=$${DISABLE_AUTH:-} bash -lc 'if [ -f .tenancy.env ]; then source .tenancy.env; fi; mage test:{{.Target}}'
+
+`))
+
+func render(tmpl *template.Template, s suiteScaffold) (string, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, s); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// insertBefore returns content with text inserted before the first marker, formatted when it is Go.
+func insertBefore(path, content, marker, text string) ([]byte, error) {
+	i := strings.Index(content, marker)
+	if i < 0 {
+		return nil, fmt.Errorf("%s has no %q to insert the new suite before", path, marker)
+	}
+	updated := []byte(content[:i] + text + content[i:])
+	if strings.HasSuffix(path, ".go") {
+		return format.Source(updated)
+	}
+	return updated, nil
+}
+
+// scaffoldFiles returns the new and updated files of a suite, read from the current directory.
+func scaffoldFiles(s suiteScaffold) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	suite, err := render(suiteTemplate, s)
+	if err != nil {
+		return nil, err
+	}
+	if files[filepath.Join(s.Dir(), s.File())], err = format.Source([]byte(suite)); err != nil {
+		return nil, err
+	}
+
+	labels, err := os.ReadFile(labelsFile)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(labels), s.Label()+" ") {
+		return nil, fmt.Errorf("%s already declares the label %s", labelsFile, s.Label())
+	}
+	updated, err := insertBefore(labelsFile, string(labels), labelsConstEnd, fmt.Sprintf("\n\t%s = %q", s.Label(), s.LabelValue()))
+	if err != nil {
+		return nil, err
+	}
+	if files[labelsFile], err = insertBefore(labelsFile, string(updated), labelsRegistryEnd, "\n\t"+s.Label()+","); err != nil {
+		return nil, err
+	}
+
+	for _, target := range []struct {
+		path, marker string
+		tmpl         *template.Template
+	}{
+		{testTargetsFile, testTargetsEnd, testTargetTemplate},
+		{magefileTargetsFile, magefileTargetsEnd, magefileTargetTemplate},
+		{makefileTargetsFile, makefileTargetsEnd, makefileTargetTemplate},
+	} {
+		content, err := os.ReadFile(target.path)
+		if err != nil {
+			return nil, err
+		}
+		text, err := render(target.tmpl, s)
+		if err != nil {
+			return nil, err
+		}
+		if files[target.path], err = insertBefore(target.path, string(content), target.marker, text); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// Test Generates the suite tests/NAME-test with its label, mage target and make target
+func (Test) newSuite() error {
+	s, err := newSuiteScaffold(os.Getenv(newSuiteNameEnvVar))
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(s.Dir()); err == nil {
+		return fmt.Errorf("%s already exists", s.Dir())
+	}
+	files, err := scaffoldFiles(s)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir(), 0o755); err != nil {
+		return err
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", path)
+	}
+	fmt.Printf("Run the suite with `make %s-test` or `mage test:%s`, and describe it in README.md\n", s.Name, s.Target())
+	return nil
+}
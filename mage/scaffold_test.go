@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package mage
+
+import (
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewSuiteScaffold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		ident string
+		title string
+		err   bool
+	}{
+		{"one word", "drain", "Drain", "drain", false},
+		{"dashed", "node-drain", "NodeDrain", "node drain", false},
+		{"test suffix and spaces", " node-drain-test ", "NodeDrain", "node drain", false},
+		{"digits", "ipv6-dual-stack", "Ipv6DualStack", "ipv6 dual stack", false},
+		{"empty", "", "", "", true},
+		{"upper case", "Node-Drain", "", "", true},
+		{"underscore", "node_drain", "", "", true},
+		{"double dash", "node--drain", "", "", true},
+		{"leading digit", "6lowpan", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSuiteScaffold(tt.input)
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected an error %t, got %v", tt.err, err)
+			}
+			if tt.err {
+				return
+			}
+			if s.Ident != tt.ident || s.Title != tt.title {
+				t.Errorf("Expected (%s, %s), got (%s, %s)", tt.ident, tt.title, s.Ident, s.Title)
+			}
+		})
+	}
+}
+
+func TestSuiteScaffoldNames(t *testing.T) {
+	s, err := newSuiteScaffold("node-drain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for got, expected := range map[string]string{
+		s.Dir():        filepath.Join("tests", "node-drain-test"),
+		s.File():       "node_drain_test.go",
+		s.Package():    "node_drain_test",
+		s.Label():      "ClusterOrchNodeDrainTest",
+		s.LabelValue(): "cluster-orch-node-drain-test",
+	} {
+		if got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestInsertBefore(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		content  string
+		marker   string
+		text     string
+		expected string
+		err      bool
+	}{
+		{"text", "Makefile", "a\n.PHONY: help\n", ".PHONY: help\n", "b\n", "a\nb\n.PHONY: help\n", false},
+		{"first marker only", "Makefile", "x-x", "x", "y", "yx-x", false},
+		{"go is formatted", "labels.go", "package utils\n\nconst (\n\tA = 1\n)\n", "\n)\n", "\n\tLonger   =   2", "package utils\n\nconst (\n\tA      = 1\n\tLonger = 2\n)\n", false},
+		{"missing marker", "Makefile", "a\n", ".PHONY: help\n", "b\n", "", true},
+		{"invalid go", "labels.go", "package utils\n\nconst (\n)\n", "\n)\n", "\n\t=", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := insertBefore(tt.path, tt.content, tt.marker, tt.text)
+			if (err != nil) != tt.err {
+				t.Fatalf("Expected an error %t, got %v", tt.err, err)
+			}
+			if !tt.err && string(updated) != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, updated)
+			}
+		})
+	}
+}
+
+func TestScaffoldFiles(t *testing.T) {
+	t.Chdir("..")
+
+	s, err := newSuiteScaffold("node-drain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := scaffoldFiles(s)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	suitePath := filepath.Join(s.Dir(), s.File())
+	for path, expected := range map[string]string{
+		suitePath:           "utils.RegisterPortForwards(",
+		labelsFile:          `"cluster-orch-node-drain-test"`,
+		testTargetsFile:     "func (Test) clusterOrchNodeDrainTest() error",
+		magefileTargetsFile: "func (t Test) ClusterOrchNodeDrainTest() error",
+		makefileTargetsFile: "node-drain-test:",
+	} {
+		content, ok := files[path]
+		if !ok {
+			t.Errorf("Expected %s to be written", path)
+			continue
+		}
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected %s to contain %q", path, expected)
+		}
+		if strings.HasSuffix(path, ".go") {
+			if _, err := parser.ParseFile(token.NewFileSet(), path, content, 0); err != nil {
+				t.Errorf("Expected %s to parse, got %v", path, err)
+			}
+		}
+	}
+	if len(files) != 5 {
+		t.Errorf("Expected 5 files, got %d", len(files))
+	}
+
+	existing, err := newSuiteScaffold("cluster-labels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scaffoldFiles(existing); err == nil || !strings.Contains(err.Error(), "already declares") {
+		t.Errorf("Expected an error for a suite whose label exists, got %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/open-edge-platform/cluster-tests/tests/utils"
@@ -20,6 +21,15 @@ import (
 
 const (
 	gitCommitHashRegex = `\b[0-9a-f]{5,40}\b` // Matches a git commit hash (min 5, max 40 characters)
+
+	// tenancyComponentName is the optional component of .test-dependencies.yaml that deploys the tenancy
+	// datamodel, tenancy-manager and the tenancy API.
+	tenancyComponentName = "tenancy-manager"
+
+	// infraComponentName is the optional component of .test-dependencies.yaml that deploys the infra
+	// manager's inventory and host API.
+	infraComponentName       = "infra-core"
+	defaultInventoryEndpoint = "inventory.default.svc.cluster.local:50051"
 )
 
 type HelmRepo struct {
@@ -56,23 +66,11 @@ type Config struct {
 }
 
 func (Test) bootstrap() error {
-	defaultConfig, err := parseConfig(".test-dependencies.yaml")
+	defaultConfig, err := loadConfig()
 	if err != nil {
 		return err
 	}
 
-	additionalConfigStr := os.Getenv("ADDITIONAL_CONFIG")
-	fmt.Printf("Additional config: %s\n", additionalConfigStr)
-	if additionalConfigStr != "" {
-		var additionalConfig Config
-		if err := json.Unmarshal([]byte(additionalConfigStr), &additionalConfig); err != nil {
-			return err
-		}
-		fmt.Printf("Additional config after unmarshal: %+v\n", additionalConfig)
-
-		mergeConfigs(defaultConfig, &additionalConfig)
-	}
-
 	if err := createKindCluster(defaultConfig.KindClusterConfig); err != nil {
 		return err
 	}
@@ -83,10 +81,160 @@ func (Test) bootstrap() error {
 		}
 	}
 
+	if err := maybeCreateTenancyProject(defaultConfig); err != nil {
+		return err
+	}
+
 	if err := maybeBootstrapVEN(); err != nil {
 		return err
 	}
 
+	if err := maybeDeploySimulatedEdgeNodes(); err != nil {
+		return err
+	}
+
+	return maybeBlockEgress()
+}
+
+// componentEnabled reports whether an optional component is deployed.
+func componentEnabled(config *Config, name string) bool {
+	return slices.ContainsFunc(config.Components, func(c Component) bool {
+		return c.Name == name && !c.SkipComponent
+	})
+}
+
+// replaceComponentSetting rewrites a setting in the helm overrides and make variables of a component.
+func replaceComponentSetting(config *Config, name, from, to string) {
+	for i := range config.Components {
+		component := &config.Components[i]
+		if component.Name != name {
+			continue
+		}
+		for j := range component.HelmRepo {
+			component.HelmRepo[j].Overrides = strings.ReplaceAll(component.HelmRepo[j].Overrides, from, to)
+		}
+		for j := range component.MakeVariables {
+			component.MakeVariables[j] = strings.ReplaceAll(component.MakeVariables[j], from, to)
+		}
+	}
+}
+
+// applyMultiTenancy turns the multi-tenancy support of cluster-manager back on when the tenancy component
+// is deployed, so that projects are set up by cluster-manager from the tenancy datamodel.
+func applyMultiTenancy(config *Config) {
+	if !componentEnabled(config, tenancyComponentName) {
+		return
+	}
+	replaceComponentSetting(config, "cluster-manager", "disable-multi-tenancy=true", "disable-multi-tenancy=false")
+	replaceComponentSetting(config, "cluster-manager", "DISABLE_MT=true", "DISABLE_MT=false")
+}
+
+// applyInventory points cluster-manager and the Intel infra provider at the real inventory when the infra
+// component is deployed, instead of the no-op client and the inventory stub. INVENTORY_ENDPOINT overrides
+// the inventory address given to cluster-manager.
+func applyInventory(config *Config) {
+	if !componentEnabled(config, infraComponentName) {
+		return
+	}
+	endpoint := utils.GetEnv("INVENTORY_ENDPOINT", defaultInventoryEndpoint)
+	replaceComponentSetting(config, "cluster-manager", "disable-inventory=true",
+		"disable-inventory=false --set clusterManager.extraArgs.inventory-endpoint="+endpoint)
+	replaceComponentSetting(config, "cluster-manager", "DISABLE_INV=true", "DISABLE_INV=false")
+	replaceComponentSetting(config, "cluster-api-provider-intel", "use-inv-stub=true", "use-inv-stub=false")
+	replaceComponentSetting(config, "cluster-api-provider-intel", "USE_INV_STUB=true", "USE_INV_STUB=false")
+}
+
+// maybeCreateTenancyProject creates the test project through the tenancy API when the tenancy component
+// is deployed, and writes its ID as NAMESPACE to .tenancy.env for Make to source; like .ven.env, the
+// environment of the bootstrap process does not reach the suites. TENANCY_PROJECT names the project.
+func maybeCreateTenancyProject(config *Config) error {
+	const tenancyEnvFile = ".tenancy.env"
+
+	if !componentEnabled(config, tenancyComponentName) {
+		_ = os.Remove(tenancyEnvFile)
+		return nil
+	}
+
+	portForward, err := utils.StartTenancyAPIPortForward()
+	if err != nil {
+		return err
+	}
+	defer utils.StopPortForwards(portForward)
+
+	project, err := utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg),
+		utils.GetEnv(utils.TenancyProjectEnvVar, utils.DefaultTenancyProject))
+	if err != nil {
+		return err
+	}
+	if err := utils.WaitForProjectSetup(project.ID, utils.ProjectSetupTimeout); err != nil {
+		return err
+	}
+	fmt.Printf("Created project %s/%s with namespace %s\n", project.Org, project.Name, project.ID)
+
+	lines := []string{
+		"# Generated by mage test:bootstrap (tenancy)",
+		"export " + utils.MultiTenancyEnvVar + "=\"true\"",
+		"export " + utils.NamespaceEnvVar + "=\"" + project.ID + "\"",
+	}
+	if err := os.WriteFile(tenancyEnvFile, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tenancyEnvFile, err)
+	}
+	return nil
+}
+
+// maybeBlockEgress switches the environment to air-gapped mode when AIR_GAPPED=true. It runs last so
+// that every image needed by the management cluster has been pulled by then; extra images (e.g. for
+// downstream addons served from the management cluster) can be preloaded with AIR_GAPPED_PRELOAD_IMAGES.
+func maybeBlockEgress() error {
+	if !utils.AirGappedModeEnabled() {
+		return nil
+	}
+
+	if images := splitEnvList(utils.AirGappedPreloadImagesEnvVar); len(images) > 0 {
+		fmt.Printf("Preloading images into kind: %v\n", images)
+		if err := utils.PreloadKindImages(images); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("AIR_GAPPED=true - blocking egress from the kind nodes")
+	return utils.BlockKindEgress(splitEnvList(utils.AirGappedAllowedCIDRsEnvVar))
+}
+
+func (Test) restoreEgress() error {
+	return utils.RestoreKindEgress()
+}
+
+func splitEnvList(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// maybeDeploySimulatedEdgeNodes runs SCALE_CLUSTER_COUNT simulated edge nodes for the scale suite and writes
+// their GUIDs as SCALE_NODE_GUIDS to .scale.env for Make to source, like .ven.env.
+func maybeDeploySimulatedEdgeNodes() error {
+	const scaleEnvFile = ".scale.env"
+
+	if strings.TrimSpace(os.Getenv(utils.ScaleClusterCountEnvVar)) == "" {
+		_ = os.Remove(scaleEnvFile)
+		return nil
+	}
+	count := utils.ScaleClusterCount()
+	guids, err := utils.DeploySimulatedEdgeNodes(count)
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("# Generated by mage test:bootstrap (scale mode)\nexport %s=\"%d\"\nexport %s=\"%s\"\n",
+		utils.ScaleClusterCountEnvVar, count, utils.ScaleNodeGUIDsEnvVar, strings.Join(guids, ","))
+	if err := os.WriteFile(scaleEnvFile, []byte(content), 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", scaleEnvFile, err)
+	}
+	fmt.Printf("Deployed %d simulated edge nodes, see %s\n", count, scaleEnvFile)
 	return nil
 }
 
@@ -181,6 +329,8 @@ func (Test) clusterOrchClusterApiSmokeTest() error {
 		"-r",
 		"--fail-fast",
 		"--race",
+		junitReport(utils.ClusterOrchClusterApiSmokeTest),
+		jsonReport(utils.ClusterOrchClusterApiSmokeTest),
 		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchClusterApiSmokeTest),
 		"./tests/cluster-api-test",
 	)
@@ -194,6 +344,8 @@ func (Test) clusterOrchTemplateApiSmokeTest() error {
 		"-r",
 		"--fail-fast",
 		"--race",
+		junitReport(utils.ClusterOrchTemplateApiSmokeTest),
+		jsonReport(utils.ClusterOrchTemplateApiSmokeTest),
 		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchTemplateApiSmokeTest),
 		"./tests/template-api-test",
 	)
@@ -207,6 +359,8 @@ func (Test) clusterOrchTemplateApiAllTest() error {
 		"-r",
 		"--fail-fast",
 		"--race",
+		junitReport(utils.ClusterOrchTemplateApiAllTest),
+		jsonReport(utils.ClusterOrchTemplateApiAllTest),
 		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchTemplateApiAllTest),
 		"./tests/template-api-test",
 	)
@@ -220,6 +374,8 @@ func (Test) clusterOrchClusterApiAllTest() error {
 		"-r",
 		"--fail-fast",
 		"--race",
+		junitReport(utils.ClusterOrchClusterApiAllTest),
+		jsonReport(utils.ClusterOrchClusterApiAllTest),
 		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchClusterApiAllTest),
 		"./tests/cluster-api-test",
 	)
@@ -233,11 +389,448 @@ func (Test) clusterOrchRobustness() error {
 		"-r",
 		"--fail-fast",
 		"--race",
+		junitReport(utils.ClusterOrchRobustnessTest),
+		jsonReport(utils.ClusterOrchRobustnessTest),
 		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchRobustnessTest),
 		"./tests/robustness-test",
 	)
 }
 
+// Test Runs cluster orch CR api tests
+func (Test) clusterOrchCRApiTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchCRApiTest),
+		jsonReport(utils.ClusterOrchCRApiTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchCRApiTest),
+		"./tests/cr-api-test",
+	)
+}
+
+// Test Runs cluster orch template pod-security profile tests
+func (Test) clusterOrchTemplateProfileTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchTemplateProfileTest),
+		jsonReport(utils.ClusterOrchTemplateProfileTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchTemplateProfileTest),
+		"./tests/template-profile-test",
+	)
+}
+
+// Test Runs trusted-compute template extension tests
+func (Test) clusterOrchTrustedComputeTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchTrustedComputeTest),
+		jsonReport(utils.ClusterOrchTrustedComputeTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchTrustedComputeTest),
+		"./tests/trusted-compute-test",
+	)
+}
+
+// Test Runs k3s and rke2 template coexistence tests
+func (Test) clusterOrchTemplateMixTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchTemplateMixTest),
+		jsonReport(utils.ClusterOrchTemplateMixTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchTemplateMixTest),
+		"./tests/template-mix-test",
+	)
+}
+
+// Test Runs air-gapped mode tests
+func (Test) clusterOrchAirGappedTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchAirGappedTest),
+		jsonReport(utils.ClusterOrchAirGappedTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchAirGappedTest),
+		"./tests/air-gapped-test",
+	)
+}
+
+// Test Runs cluster orch tests behind a bandwidth-throttled registry proxy
+func (Test) clusterOrchSlowRegistryTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchSlowRegistryTest),
+		jsonReport(utils.ClusterOrchSlowRegistryTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchSlowRegistryTest),
+		"./tests/slow-registry-test",
+	)
+}
+
+// Test Runs the clusterctl move test that pivots a live cluster to a second management cluster
+func (Test) pivotTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchPivotTest),
+		jsonReport(utils.ClusterOrchPivotTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchPivotTest),
+		"./tests/pivot-test",
+	)
+}
+
+// Test Runs the test that backs up, deletes and restores the CAPI objects of a running cluster
+func (Test) backupRestoreTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchBackupRestoreTest),
+		jsonReport(utils.ClusterOrchBackupRestoreTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchBackupRestoreTest),
+		"./tests/backup-restore-test",
+	)
+}
+
+// helmValuesMatrixFile lists the cluster-manager chart configurations run by test:HelmValuesMatrixTest.
+const helmValuesMatrixFile = "configs/helm-values-matrix.yaml"
+
+// HelmValuesConfiguration is a cluster-manager chart configuration of the helm values matrix.
+type HelmValuesConfiguration struct {
+	Name      string            `yaml:"name"`
+	Overrides string            `yaml:"overrides"`
+	Env       map[string]string `yaml:"env"`
+}
+
+// Test Runs the cluster-manager smoke tests once per chart configuration of configs/helm-values-matrix.yaml.
+// HELM_VALUES_CONFIGS restricts the run to a comma-separated list of configuration names.
+func (Test) helmValuesMatrixTest() error {
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	component, release, err := clusterManagerRelease(config)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(helmValuesMatrixFile)
+	if err != nil {
+		return err
+	}
+	var matrix []HelmValuesConfiguration
+	if err := yaml.Unmarshal(data, &matrix); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", helmValuesMatrixFile, err)
+	}
+
+	selected := splitEnvList("HELM_VALUES_CONFIGS")
+	var failures []string
+	for _, entry := range matrix {
+		if len(selected) > 0 && !slices.Contains(selected, entry.Name) {
+			continue
+		}
+
+		fmt.Printf("=== cluster-manager with the %s chart configuration ===\n", entry.Name)
+		if err := redeployHelmRelease(component, release, release.Overrides+" "+entry.Overrides); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: failed to deploy: %v", entry.Name, err))
+			continue
+		}
+
+		env := map[string]string{"HELM_VALUES_CONFIG": entry.Name}
+		for key, value := range entry.Env {
+			env[key] = value
+		}
+		if err := sh.RunWithV(env,
+			"ginkgo",
+			"-v",
+			"-r",
+			"--race",
+			junitReport(utils.ClusterOrchHelmValuesTest+"-"+entry.Name),
+			jsonReport(utils.ClusterOrchHelmValuesTest+"-"+entry.Name),
+			fmt.Sprintf("--label-filter=%s", utils.ClusterOrchHelmValuesTest),
+			"./tests/helm-values-test",
+		); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", entry.Name, err))
+		}
+	}
+
+	fmt.Println("=== restoring the bootstrap chart configuration of cluster-manager ===")
+	if err := redeployHelmRelease(component, release, release.Overrides); err != nil {
+		failures = append(failures, fmt.Sprintf("failed to restore cluster-manager: %v", err))
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("helm values matrix failed:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// clusterManagerRelease returns the cluster-manager component and its chart release from the bootstrap config.
+func clusterManagerRelease(config *Config) (Component, HelmRepo, error) {
+	for _, component := range config.Components {
+		if component.Name != "cluster-manager" {
+			continue
+		}
+		if !component.SkipLocalBuild {
+			return Component{}, HelmRepo{}, fmt.Errorf("the helm values matrix reinstalls the cluster-manager chart; set skip-local-build for cluster-manager")
+		}
+		for _, helm := range component.HelmRepo {
+			if helm.ReleaseName == "cluster-manager" {
+				return component, helm, nil
+			}
+		}
+	}
+	return Component{}, HelmRepo{}, fmt.Errorf("no cluster-manager helm release in the bootstrap config")
+}
+
+// redeployHelmRelease reinstalls a single helm release of a component with the given overrides, the way
+// bootstrap installs it, and runs the post-install commands of the component.
+func redeployHelmRelease(component Component, helm HelmRepo, overrides string) error {
+	if err := runCommand(fmt.Sprintf("helm uninstall %s --namespace %s --wait --ignore-not-found", helm.ReleaseName, helm.Namespace)); err != nil {
+		return err
+	}
+
+	helm.Overrides = strings.TrimSpace(overrides)
+	component.HelmRepo = []HelmRepo{helm}
+	component.PreInstallCommands = nil
+	return processComponent(component)
+}
+
+// Test Runs cluster orch project namespace deletion tests
+func (Test) clusterOrchProjectTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchProjectTest),
+		jsonReport(utils.ClusterOrchProjectTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchProjectTest),
+		"./tests/project-test",
+	)
+}
+
+// Test Runs cluster orch node GUID inventory validation tests
+func (Test) clusterOrchInventoryTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchInventoryTest),
+		jsonReport(utils.ClusterOrchInventoryTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchInventoryTest),
+		"./tests/inventory-test",
+	)
+}
+
+// Test Runs cluster orch edge node onboarding tests
+func (Test) clusterOrchOnboardingTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchOnboardingTest),
+		jsonReport(utils.ClusterOrchOnboardingTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchOnboardingTest),
+		"./tests/onboarding-test",
+	)
+}
+
+// Test Runs cluster orch gateway concurrent session load tests
+func (Test) clusterOrchGatewayPerfTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchGatewayPerfTest),
+		jsonReport(utils.ClusterOrchGatewayPerfTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchGatewayPerfTest),
+		"./tests/gateway-test",
+	)
+}
+
+// Test Runs cluster orch connect gateway streaming tests
+func (Test) clusterOrchGatewayTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchGatewayTest),
+		jsonReport(utils.ClusterOrchGatewayTest),
+		// The load test has a target of its own.
+		fmt.Sprintf("--label-filter=%s && !%s", utils.ClusterOrchGatewayTest, utils.ClusterOrchGatewayPerfTest),
+		"./tests/gateway-test",
+	)
+}
+
+// Test Runs cluster orch request payload fuzzing tests
+func (Test) clusterOrchFuzzTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchFuzzTest),
+		jsonReport(utils.ClusterOrchFuzzTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchFuzzTest),
+		"./tests/fuzz-test",
+	)
+}
+
+// Test Runs cluster orch API limits boundary tests
+func (Test) clusterOrchApiLimitsTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchApiLimitsTest),
+		jsonReport(utils.ClusterOrchApiLimitsTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchApiLimitsTest),
+		"./tests/api-limits-test",
+	)
+}
+
+// Test Runs cluster orch audit log tests
+func (Test) clusterOrchAuditLogTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchAuditLogTest),
+		jsonReport(utils.ClusterOrchAuditLogTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchAuditLogTest),
+		"./tests/audit-log-test",
+	)
+}
+
+// Test Runs cluster orch cluster label propagation tests
+func (Test) clusterOrchClusterLabelsTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchClusterLabelsTest),
+		jsonReport(utils.ClusterOrchClusterLabelsTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchClusterLabelsTest),
+		"./tests/cluster-labels-test",
+	)
+}
+
+// Test Runs cluster orch cluster and node name validation tests
+func (Test) clusterOrchNameValidationTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchNameValidationTest),
+		jsonReport(utils.ClusterOrchNameValidationTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchNameValidationTest),
+		"./tests/name-validation-test",
+	)
+}
+
+// Test Runs cluster orch node deletion tests
+func (Test) clusterOrchNodeDeleteTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchNodeDeleteTest),
+		jsonReport(utils.ClusterOrchNodeDeleteTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchNodeDeleteTest),
+		"./tests/node-delete-test",
+	)
+}
+
+// Test Runs cluster orch HTTP client connection reuse tests
+func (Test) clusterOrchHTTPClientTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchHTTPClientTest),
+		jsonReport(utils.ClusterOrchHTTPClientTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchHTTPClientTest),
+		"./tests/http-client-test",
+	)
+}
+
+// Test Runs cluster-manager API version contract tests
+func (Test) clusterOrchApiVersionTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchApiVersionTest),
+		jsonReport(utils.ClusterOrchApiVersionTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchApiVersionTest),
+		"./tests/api-version-test",
+	)
+}
+
+// Test Runs cluster orch scale tests
+func (Test) clusterOrchScaleTest() error {
+	return sh.RunV(
+		"ginkgo",
+		"-v",
+		"-r",
+		"--fail-fast",
+		"--race",
+		junitReport(utils.ClusterOrchScaleTest),
+		jsonReport(utils.ClusterOrchScaleTest),
+		fmt.Sprintf("--label-filter=%s", utils.ClusterOrchScaleTest),
+		"./tests/scale-test",
+	)
+}
+
 /////// Helper functions ///////
 
 func mergeConfigs(defaultConfig, additionalConfig *Config) {
@@ -292,6 +885,29 @@ func mergeComponent(defaultComponent, additionalComponent Component) Component {
 	return defaultComponent
 }
 
+// loadConfig reads .test-dependencies.yaml with the ADDITIONAL_CONFIG overrides merged in.
+func loadConfig() (*Config, error) {
+	defaultConfig, err := parseConfig(".test-dependencies.yaml")
+	if err != nil {
+		return nil, err
+	}
+
+	additionalConfigStr := os.Getenv("ADDITIONAL_CONFIG")
+	fmt.Printf("Additional config: %s\n", additionalConfigStr)
+	if additionalConfigStr != "" {
+		var additionalConfig Config
+		if err := json.Unmarshal([]byte(additionalConfigStr), &additionalConfig); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Additional config after unmarshal: %+v\n", additionalConfig)
+
+		mergeConfigs(defaultConfig, &additionalConfig)
+	}
+	applyMultiTenancy(defaultConfig)
+	applyInventory(defaultConfig)
+	return defaultConfig, nil
+}
+
 func parseConfig(file string) (*Config, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
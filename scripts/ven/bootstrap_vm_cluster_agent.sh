@@ -164,6 +164,11 @@ VEN_VM_MEM_MB="${VEN_VM_MEM_MB:-8192}"
 VEN_VM_DISK_GB="${VEN_VM_DISK_GB:-40}"
 VEN_VM_IMAGE_DIR="${VEN_VM_IMAGE_DIR:-/var/lib/libvirt/images}"
 VEN_VM_NET="${VEN_VM_NET:-default}"
+# Platform of the VM, set per combination by `mage test:EdgeNodeMatrixTest`. VEN_VM_ARCH is the libvirt
+# architecture, e.g. aarch64; empty uses the one of the host.
+VEN_VM_IMG_URL="${VEN_VM_IMG_URL:-${VEN_UBUNTU_IMG_URL:-https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img}}"
+VEN_VM_OS_VARIANT="${VEN_VM_OS_VARIANT:-ubuntu24.04}"
+VEN_VM_ARCH="${VEN_VM_ARCH:-}"
 VEN_REUSE_VM="${VEN_REUSE_VM:-false}"
 
 # SSH parameters
@@ -306,11 +311,10 @@ if [[ "$VEN_REUSE_VM" != "true" ]]; then
 fi
 
 if [[ "$vm_exists" != "true" ]]; then
-  # Download Ubuntu cloud image if missing
-  UBUNTU_IMG_URL="${VEN_UBUNTU_IMG_URL:-https://cloud-images.ubuntu.com/noble/current/noble-server-cloudimg-amd64.img}"
+  # Download the cloud image if missing
   if [[ ! -f "$base_img" ]]; then
-    echo "Downloading Ubuntu cloud image..." >&2
-    $SUDO curl -L "$UBUNTU_IMG_URL" -o "$base_img" >&2
+    echo "Downloading cloud image..." >&2
+    $SUDO curl -L "$VEN_VM_IMG_URL" -o "$base_img" >&2
   fi
 
   # Recreate VM disk
@@ -363,6 +367,10 @@ if ! $SUDO virsh net-info "$VEN_VM_NET" 2>/dev/null | awk -F': +' '/^Active:/ {p
 fi
 
 if [[ "$vm_exists" != "true" ]]; then
+  arch_args=()
+  if [[ -n "$VEN_VM_ARCH" ]]; then
+    arch_args=(--arch "$VEN_VM_ARCH")
+  fi
   $SUDO virt-install \
     --name "$VEN_VM_NAME" \
     --memory "$VEN_VM_MEM_MB" \
@@ -371,7 +379,8 @@ if [[ "$vm_exists" != "true" ]]; then
     --disk path="$vm_img",format=qcow2 \
     --disk path="$seed_img",device=cdrom \
     --network network="$VEN_VM_NET" \
-    --os-variant ubuntu24.04 \
+    --os-variant "$VEN_VM_OS_VARIANT" \
+    "${arch_args[@]}" \
     --noautoconsole \
     --uuid "$NODEGUID" \
     >/dev/null
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package air_gapped_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	kubeconfigFileName = "kubeconfig-air-gapped.yaml"
+
+	clusterReadinessTimeout = 15 * time.Minute
+	addonTimeout            = 10 * time.Minute
+	addonInterval           = 15 * time.Second
+)
+
+func TestAirGappedTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting air-gapped tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "air-gapped test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+var _ = Describe("Cluster orchestration without egress from the management cluster", Ordered, Label(utils.ClusterOrchAirGappedTest), func() {
+	var (
+		namespace string
+		nodeGUID  string
+		setUp     bool
+	)
+
+	BeforeAll(func() {
+		if !utils.AirGappedModeEnabled() {
+			Skip(fmt.Sprintf("air-gapped mode is disabled; bootstrap and run with %s=true", utils.AirGappedEnvVar))
+		}
+
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp || utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		if err := utils.DeleteCluster(namespace); err != nil {
+			fmt.Printf("Failed to delete cluster: %v\n", err)
+			return
+		}
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should not reach external registries from the kind nodes", func() {
+		blocked, err := utils.KindEgressBlocked()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blocked).To(BeTrue(), "kind nodes can still reach %s; egress was not blocked", utils.AirGappedProbeURL)
+	})
+
+	It("should import the cluster template", func() {
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	It("should create a cluster", func() {
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)).To(Succeed())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+	})
+
+	It("should deploy the downstream addons", func() {
+		Eventually(func() []string {
+			pods, err := utils.ListDownstreamPods(kubeconfigFileName)
+			if err != nil {
+				return []string{err.Error()}
+			}
+			if len(pods) == 0 {
+				return []string{"no pods yet"}
+			}
+
+			var pending []string
+			for _, pod := range pods {
+				if pod.Phase != "Running" && pod.Phase != "Succeeded" {
+					pending = append(pending, fmt.Sprintf("%s/%s: %s", pod.Namespace, pod.Name, pod.Phase))
+				}
+			}
+			return pending
+		}, addonTimeout, addonInterval).Should(BeEmpty())
+	})
+})
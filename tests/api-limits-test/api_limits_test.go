@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package api_limits_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// maxReportedBody keeps echoed oversized values out of the failure message.
+	maxReportedBody = 300
+)
+
+func TestApiLimitsTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch API limits tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch API limits test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+func truncateBody(body string) string {
+	if len(body) > maxReportedBody {
+		return body[:maxReportedBody] + "..."
+	}
+	return body
+}
+
+var _ = Describe("Cluster manager API limits", Ordered, Label(utils.ClusterOrchApiLimitsTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+	)
+
+	send := func(method, url string, body []byte) (int, string) {
+		status, response, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, method, url, body)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("  %s %s: HTTP %d\n", method, url, status)
+		return status, response
+	}
+
+	// expectAccepted checks that a request at a limit is served rather than truncated or refused.
+	expectAccepted := func(name string, status, expected int, body string) {
+		Expect(status).To(Equal(expected), "%s: %s", name, truncateBody(body))
+	}
+
+	// expectRejected checks that a request one over a limit gets a 400 with a message, not a 500 or a body
+	// exposing internals.
+	expectRejected := func(name string, status int, body string) {
+		Expect(status).To(Equal(http.StatusBadRequest), "%s: %s", name, truncateBody(body))
+		Expect(utils.NewAPIError(name, status, []byte(body))).To(utils.HaveAPIErrorMessage(Not(BeEmpty())), "%s has no message", name)
+		Expect(utils.LeakedInternals(body)).To(BeEmpty(), "%s: %s", name, truncateBody(body))
+	}
+
+	getTemplate := func(version string) *api.TemplateInfo {
+		status, body := send(http.MethodGet, fmt.Sprintf("%s/%s/%s", utils.ClusterTemplateURL, utils.K3sTemplateOnlyName, version), nil)
+		Expect(status).To(Equal(http.StatusOK), truncateBody(body))
+		var template api.TemplateInfo
+		Expect(json.Unmarshal([]byte(body), &template)).To(Succeed())
+		return &template
+	}
+
+	BeforeAll(func() {
+		// A throwaway project, so the clusters and templates created at the limits go away with it.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "limits-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("limits-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Importing the cluster template k3s baseline the clusters refer to")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	Context("templates", func() {
+		It("should store a template with the largest cluster configuration intact", func() {
+			padding := utils.TemplateConfigLimit()
+			body, err := utils.LimitTemplate("v9.0.1", utils.MaxTemplateDescriptionLength, padding)
+			Expect(err).NotTo(HaveOccurred())
+			By(fmt.Sprintf("Importing a template of %d bytes", len(body)))
+			status, response := send(http.MethodPost, utils.ClusterTemplateURL, body)
+			expectAccepted("template at the size limit", status, http.StatusCreated, response)
+
+			template := getTemplate("v9.0.1")
+			Expect(utils.TemplatePaddingLength(template)).To(Equal(padding), "the cluster configuration was truncated")
+			Expect(template.Description).NotTo(BeNil())
+			Expect(*template.Description).To(HaveLen(utils.MaxTemplateDescriptionLength), "the description was truncated")
+		})
+
+		It("should reject a template description one over the limit", func() {
+			body, err := utils.LimitTemplate("v9.0.2", utils.MaxTemplateDescriptionLength+1, 0)
+			Expect(err).NotTo(HaveOccurred())
+			status, response := send(http.MethodPost, utils.ClusterTemplateURL, body)
+			expectRejected("template description over the limit", status, response)
+		})
+
+		It("should reject a cluster configuration too large to be stored", func() {
+			body, err := utils.LimitTemplate("v9.0.3", 10, utils.OversizedTemplateConfigBytes)
+			Expect(err).NotTo(HaveOccurred())
+			By(fmt.Sprintf("Importing a template of %d bytes", len(body)))
+			status, response := send(http.MethodPost, utils.ClusterTemplateURL, body)
+			expectRejected("template over the size limit", status, response)
+		})
+	})
+
+	Context("clusters", func() {
+		It("should create a cluster with the longest name and labels at the limits", func() {
+			name := utils.LimitString(utils.MaxClusterNameLength)
+			labels := utils.LimitLabels()
+			body, err := utils.LimitCluster(name, utils.K3sTemplateName, 1, labels)
+			Expect(err).NotTo(HaveOccurred())
+			status, response := send(http.MethodPost, utils.ClusterCreateURL, body)
+			expectAccepted("cluster at the limits", status, http.StatusCreated, response)
+
+			status, response = send(http.MethodGet, fmt.Sprintf("%s/%s", utils.ClusterCreateURL, name), nil)
+			Expect(status).To(Equal(http.StatusOK), truncateBody(response))
+			var cluster api.ClusterDetailInfo
+			Expect(json.Unmarshal([]byte(response), &cluster)).To(Succeed())
+			Expect(cluster.Name).NotTo(BeNil())
+			Expect(*cluster.Name).To(Equal(name), "the cluster name was truncated")
+			Expect(cluster.Labels).NotTo(BeNil())
+			for key, value := range labels {
+				Expect(*cluster.Labels).To(HaveKeyWithValue(key, value), "the label %s was not stored intact", key)
+			}
+		})
+
+		It("should reject a cluster name one over the limit", func() {
+			body, err := utils.LimitCluster(utils.LimitString(utils.MaxClusterNameLength+1), utils.K3sTemplateName, 1, nil)
+			Expect(err).NotTo(HaveOccurred())
+			status, response := send(http.MethodPost, utils.ClusterCreateURL, body)
+			expectRejected("cluster name over the limit", status, response)
+		})
+
+		It("should reject the maximum number of nodes and one over it cleanly", func() {
+			// cluster-manager only supports single node clusters, so even the documented maximum is refused.
+			for i, nodes := range []int{utils.MaxClusterNodes, utils.MaxClusterNodes + 1} {
+				body, err := utils.LimitCluster(fmt.Sprintf("limits-nodes-%d", i), utils.K3sTemplateName, nodes, nil)
+				Expect(err).NotTo(HaveOccurred())
+				status, response := send(http.MethodPost, utils.ClusterCreateURL, body)
+				expectRejected(fmt.Sprintf("cluster with %d nodes", nodes), status, response)
+			}
+		})
+
+		It("should reject labels one over the limits", func() {
+			i := 0
+			for name, labels := range utils.OverLimitLabels() {
+				body, err := utils.LimitCluster(fmt.Sprintf("limits-labels-%d", i), utils.K3sTemplateName, 1, labels)
+				Expect(err).NotTo(HaveOccurred())
+				status, response := send(http.MethodPost, utils.ClusterCreateURL, body)
+				expectRejected(name, status, response)
+				i++
+			}
+		})
+
+		It("should reject label updates one over the limits", func() {
+			url := fmt.Sprintf("%s/%s/labels", utils.ClusterCreateURL, utils.LimitString(utils.MaxClusterNameLength))
+			for name, labels := range utils.OverLimitLabels() {
+				body, err := json.Marshal(api.ClusterLabels{Labels: &labels})
+				Expect(err).NotTo(HaveOccurred())
+				status, response := send(http.MethodPut, url, body)
+				expectRejected(name, status, response)
+			}
+		})
+	})
+})
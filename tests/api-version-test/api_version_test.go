@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package api_version_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+func TestApiVersionTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster-manager API version contract tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster-manager API version contract test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = utils.RegisterClusterManagerAPIGating()
+
+var _ = ReportAfterSuite("deprecated endpoints", func(Report) {
+	utils.ReportDeprecatedEndpoints()
+})
+
+// v3alpha1 is exercised when CLUSTER_MANAGER_API_VERSIONS lists it; versions beyond it are exercised by
+// NewerClusterManagerAPIVersions.
+const nextAPIVersion = "v3alpha1"
+
+var _ = Describe("cluster-manager API versions", Ordered, Label(utils.ClusterOrchApiVersionTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+	)
+
+	// send sends a request and checks that the endpoint, if deprecated, is still before its sunset.
+	send := func(method, url string, body []byte) utils.APIResponse {
+		resp, err := utils.ClusterManagerRequest(authContext, namespace, method, url, body)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("  %s %s: HTTP %d\n", method, url, resp.Status)
+		deprecation := utils.DeprecationOf(resp.Header)
+		utils.RecordDeprecation(method+" "+url, deprecation)
+		Expect(utils.CheckDeprecation(method+" "+url, deprecation, time.Now())).To(Succeed())
+		return resp
+	}
+
+	expectStatus := func(resp utils.APIResponse, status int) {
+		ExpectWithOffset(1, resp.Status).To(Equal(status), resp.Body)
+	}
+
+	// readEndpoints are the endpoints every version serves for a project with the baseline template.
+	readEndpoints := func(a utils.ClusterManagerAPI) []string {
+		return []string{
+			a.TemplatesURL(),
+			a.TemplateURL(utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion),
+			a.ClustersURL(),
+			a.ClustersSummaryURL(),
+		}
+	}
+
+	createCluster := func(a utils.ClusterManagerAPI, name string) {
+		body, err := a.ClusterBody(name, utils.K3sTemplateName, utils.NewProjectID())
+		Expect(err).NotTo(HaveOccurred())
+		expectStatus(send(http.MethodPost, a.ClustersURL(), body), http.StatusCreated)
+	}
+
+	expectCluster := func(a utils.ClusterManagerAPI, name string) {
+		resp := send(http.MethodGet, a.ClusterURL(name), nil)
+		expectStatus(resp, http.StatusOK)
+		var cluster api.ClusterDetailInfo
+		Expect(json.Unmarshal([]byte(resp.Body), &cluster)).To(Succeed())
+		Expect(cluster.Name).NotTo(BeNil())
+		Expect(*cluster.Name).To(Equal(name))
+	}
+
+	deleteCluster := func(a utils.ClusterManagerAPI, name string) {
+		expectStatus(send(http.MethodDelete, a.ClusterURL(name), nil), http.StatusNoContent)
+		Eventually(func() int {
+			return send(http.MethodGet, a.ClusterURL(name), nil).Status
+		}, time.Minute, 2*time.Second).Should(Equal(http.StatusNotFound))
+	}
+
+	BeforeAll(func() {
+		// A throwaway project, so that what a failing spec leaves behind goes away with it.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "apiver-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s, API versions %v\n", namespace, utils.ClusterManagerAPIVersions())
+
+		By("Ensuring the project namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("apiver-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Importing the cluster template k3s baseline through v2")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	versions := append([]string{utils.ClusterManagerAPIV2, nextAPIVersion}, utils.NewerClusterManagerAPIVersions()...)
+	seen := map[string]bool{}
+	for _, version := range versions {
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+
+		Context(version, utils.UsesClusterManagerAPI(version), func() {
+			It("should serve the read endpoints", func() {
+				for _, url := range readEndpoints(utils.CurrentClusterManagerAPI()) {
+					expectStatus(send(http.MethodGet, url, nil), http.StatusOK)
+				}
+			})
+
+			It("should create, read and delete a cluster", func() {
+				a := utils.CurrentClusterManagerAPI()
+				name := "apiver-" + a.Version()
+				createCluster(a, name)
+				expectCluster(a, name)
+				deleteCluster(a, name)
+			})
+
+			if version == utils.ClusterManagerAPIV2 {
+				return
+			}
+
+			It("should keep the v2 endpoints working on what it creates", func() {
+				a, v2 := utils.CurrentClusterManagerAPI(), utils.ClusterManagerAPIFor(utils.ClusterManagerAPIV2)
+				name := "apiver-cross-" + a.Version()
+				createCluster(a, name)
+				for _, url := range readEndpoints(v2) {
+					expectStatus(send(http.MethodGet, url, nil), http.StatusOK)
+				}
+				expectCluster(v2, name)
+				deleteCluster(v2, name)
+			})
+
+			It("should show what v2 creates", func() {
+				a, v2 := utils.CurrentClusterManagerAPI(), utils.ClusterManagerAPIFor(utils.ClusterManagerAPIV2)
+				name := "apiver-v2-" + a.Version()
+				createCluster(v2, name)
+				expectCluster(a, name)
+				deleteCluster(a, name)
+			})
+		})
+	}
+})
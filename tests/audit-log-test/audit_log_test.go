@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_log_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	auditClusterName = "audit-cluster"
+	auditSubject     = "audit-user"
+
+	// auditLogTimeout leaves time for the log lines of the operations to be flushed.
+	auditLogTimeout  = time.Minute
+	auditLogInterval = 5 * time.Second
+)
+
+func TestAuditLogTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch audit log tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch audit log test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("Cluster operations audit trail", Ordered, Label(utils.ClusterOrchAuditLogTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+		start       time.Time
+	)
+
+	BeforeAll(func() {
+		if !utils.AuditLogEnabled() {
+			Skip(fmt.Sprintf("set %s=true to check the audit trail of cluster operations", utils.AuditLogEnvVar))
+		}
+		if utils.AuthDisabled() {
+			Skip("the audit trail attributes operations to the subject of their JWT, which needs authentication")
+		}
+
+		// A throwaway project, so the audited cluster goes away with it whatever happens.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "audit-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Setting up JWT authentication for the project")
+		authContext, err = auth.SetupProjectAuthentication(auditSubject, namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Importing the cluster template k3s baseline")
+		Expect(utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+		if namespace == "" {
+			return
+		}
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	It("should create, access and delete a cluster on behalf of the subject", func() {
+		// Log timestamps have a resolution of a second.
+		start = time.Now().Add(-time.Second)
+
+		By("Creating a cluster")
+		data, err := utils.RenderClusterConfig(auditClusterName, utils.NewProjectID(), utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, utils.ClusterCreateURL, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusCreated), body)
+
+		// The cluster has no node, so the kubeconfig may not be served: the access attempt is what is audited.
+		By("Fetching the kubeconfig of the cluster")
+		resp, err := utils.GetClusterKubeconfigFromAPI(authContext, namespace, auditClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		resp.Body.Close()
+		fmt.Printf("Kubeconfig request answered with HTTP %d\n", resp.StatusCode)
+
+		By("Deleting the cluster")
+		status, body, err = utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodDelete,
+			fmt.Sprintf("%s/%s", utils.ClusterCreateURL, auditClusterName), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusNoContent), body)
+	})
+
+	It("should log every operation with the subject of its JWT", func() {
+		Expect(start).NotTo(BeZero(), "the operations did not run")
+		operations := utils.ClusterAuditOperations(auditClusterName)
+		subjectFields := utils.AuditSubjectFields()
+
+		Eventually(func() ([]string, error) {
+			records, err := utils.FetchAuditRecords(start)
+			if err != nil {
+				return nil, err
+			}
+			return utils.VerifyAuditTrail(records, operations, authContext.Subject, subjectFields), nil
+		}, auditLogTimeout, auditLogInterval).Should(BeEmpty(), "the cluster operations of %s are not traceable", authContext.Subject)
+	})
+})
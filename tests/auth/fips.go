@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto/fips140"
+	"crypto/rsa"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// FIPSModeEnvVar restricts key generation and token signing to FIPS-approved parameters when "true".
+	// The mode is also on when the binary runs with the Go FIPS 140-3 module enabled (GODEBUG=fips140=on|only).
+	FIPSModeEnvVar = "AUTH_FIPS_MODE"
+
+	defaultRSAKeySize = 2048
+	// FIPSRSAKeySize gives the 128 bits of security strength NIST SP 800-57 asks of keys used past 2030.
+	FIPSRSAKeySize = 3072
+)
+
+// fipsSigningAlgorithms are the JWT algorithms backed by FIPS 186-5 signature schemes with SHA-2 digests.
+var fipsSigningAlgorithms = map[string]bool{
+	"RS256": true, "RS384": true, "RS512": true,
+	"PS256": true, "PS384": true, "PS512": true,
+	"ES256": true, "ES384": true, "ES512": true,
+}
+
+// FIPSMode reports whether test keys and tokens have to stick to FIPS-approved parameters.
+func FIPSMode() bool {
+	if fips140.Enabled() {
+		return true
+	}
+	enabled, err := strconv.ParseBool(os.Getenv(FIPSModeEnvVar))
+	return err == nil && enabled
+}
+
+// modePath returns path, or in FIPS mode the file next to it FIPS mode keeps apart, e.g. keys-fips.pem for
+// keys.pem, so switching modes never reuses a key of the other one.
+func modePath(path string) string {
+	if !FIPSMode() {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-fips" + ext
+}
+
+// rsaKeySize returns the size of the RSA keys to generate in the current mode.
+func rsaKeySize() int {
+	if FIPSMode() {
+		return FIPSRSAKeySize
+	}
+	return defaultRSAKeySize
+}
+
+// checkKeySize rejects keys smaller than what the current mode generates.
+func checkKeySize(key *rsa.PublicKey) error {
+	if size := key.N.BitLen(); size < rsaKeySize() {
+		return fmt.Errorf("RSA key of %d bits is smaller than the %d bits required", size, rsaKeySize())
+	}
+	return nil
+}
+
+// CheckFIPSToken verifies that a token minted by this package only uses FIPS-approved parameters: an
+// approved signing algorithm and a signature that verifies with a key of at least FIPSRSAKeySize bits.
+func CheckFIPSToken(tokenString string) error {
+	_, publicKey, err := getOrGenerateKeys()
+	if err != nil {
+		return err
+	}
+	return checkFIPSToken(tokenString, publicKey)
+}
+
+func checkFIPSToken(tokenString string, publicKey *rsa.PublicKey) error {
+	if size := publicKey.N.BitLen(); size < FIPSRSAKeySize {
+		return fmt.Errorf("token is signed with a %d-bit RSA key, FIPS mode requires at least %d bits", size, FIPSRSAKeySize)
+	}
+	_, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if !fipsSigningAlgorithms[token.Method.Alg()] {
+			return nil, fmt.Errorf("signing algorithm %s is not FIPS-approved", token.Method.Alg())
+		}
+		return publicKey, nil
+	})
+	if err != nil {
+		return fmt.Errorf("token does not verify with FIPS-approved parameters: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto/fips140"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestFIPSModeFromEnv(t *testing.T) {
+	if fips140.Enabled() {
+		t.Skip("the Go FIPS 140-3 module is enabled, FIPS mode is always on")
+	}
+	for value, expected := range map[string]bool{"": false, "false": false, "invalid": false, "true": true, "1": true} {
+		t.Setenv(FIPSModeEnvVar, value)
+		if FIPSMode() != expected {
+			t.Errorf("Expected FIPSMode() %t for %s=%q, got %t", expected, FIPSModeEnvVar, value, !expected)
+		}
+	}
+}
+
+func TestRSAKeySizeFollowsFIPSMode(t *testing.T) {
+	t.Setenv(FIPSModeEnvVar, "true")
+	if rsaKeySize() != FIPSRSAKeySize {
+		t.Errorf("Expected %d-bit keys in FIPS mode, got %d", FIPSRSAKeySize, rsaKeySize())
+	}
+	if !strings.HasSuffix(keyFilePath(), "-fips.pem") {
+		t.Errorf("Expected a separate key file in FIPS mode, got %s", keyFilePath())
+	}
+}
+
+func TestCheckFIPSToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, FIPSRSAKeySize)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	generator := &TestJWTGenerator{privateKey: key, publicKey: &key.PublicKey}
+	token, err := generator.GenerateClusterManagerToken("test-user", DefaultProjectID, time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if err := checkFIPSToken(token, &key.PublicKey); err != nil {
+		t.Errorf("Expected a PS512 token with a %d-bit key to pass, got %v", FIPSRSAKeySize, err)
+	}
+
+	other, err := rsa.GenerateKey(rand.Reader, FIPSRSAKeySize)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if err := checkFIPSToken(token, &other.PublicKey); err == nil {
+		t.Error("Expected a token signed with another key to fail")
+	}
+}
+
+func TestCheckFIPSTokenRejectsSmallKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	generator := &TestJWTGenerator{privateKey: key, publicKey: &key.PublicKey}
+	token, err := generator.GenerateShortLivedToken("test-user", time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if err := checkFIPSToken(token, &key.PublicKey); err == nil || !strings.Contains(err.Error(), "2048-bit") {
+		t.Errorf("Expected a 2048-bit key to be rejected, got %v", err)
+	}
+}
+
+func TestCheckFIPSTokenRejectsUnapprovedAlgorithms(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, FIPSRSAKeySize)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "test-user"}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if err := checkFIPSToken(token, &key.PublicKey); err == nil || !strings.Contains(err.Error(), "not FIPS-approved") {
+		t.Errorf("Expected an unsigned token to be rejected, got %v", err)
+	}
+}
@@ -24,6 +24,8 @@ import (
 const (
 	KeyID     = "cluster-tests-key"
 	IssuerURL = "http://platform-keycloak.orch-platform.svc/realms/master"
+	// DefaultProjectID is the project test tokens carry roles for: the default namespace from cluster_utils.go
+	DefaultProjectID = "53cd37b9-66b2-4cc8-b080-3722ed7af64a"
 )
 
 // runtime-generated keys
@@ -34,23 +36,39 @@ var (
 	keyGenerationErr  error
 )
 
-// keyFilePath returns the path where keys should be stored
+// keyFilePath returns AUTH_KEY_FILE, or the path where earlier bootstraps stored their key, before the auth
+// state. FIPS mode keeps its own keys, so bootstrap and suites have to run in the same mode.
 func keyFilePath() string {
-	return "/tmp/cluster-tests-dynamic-keys.pem"
+	path := os.Getenv(KeyFileEnvVar)
+	if path == "" {
+		path = "/tmp/cluster-tests-dynamic-keys.pem"
+	}
+	return modePath(path)
 }
 
-// loadKeysFromFile attempts to load existing keys from file
+// loadKeysFromFile loads the key file of earlier bootstraps, nil when there is none. The auth state imports it,
+// since the OIDC mock they deployed serves its public key.
 func loadKeysFromFile() (*rsa.PrivateKey, error) {
-	keyPath := keyFilePath()
-	if _, err := os.Stat(keyPath); os.IsNotExist(err) {
-		return nil, nil // File doesn't exist, will generate new keys
+	keyData, err := os.ReadFile(keyFilePath())
+	if os.IsNotExist(err) {
+		return nil, nil
 	}
-
-	keyData, err := os.ReadFile(keyPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read key file: %w", err)
 	}
 
+	privateKey, err := ParsePrivateKeyPEM(keyData)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeySize(&privateKey.PublicKey); err != nil {
+		return nil, err
+	}
+	return privateKey, nil
+}
+
+// ParsePrivateKeyPEM parses a PKCS#1 RSA private key in PEM format, e.g. the key the OIDC mock signs with.
+func ParsePrivateKeyPEM(keyData []byte) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode(keyData)
 	if block == nil {
 		return nil, fmt.Errorf("failed to decode PEM block")
@@ -60,40 +78,14 @@ func loadKeysFromFile() (*rsa.PrivateKey, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse private key: %w", err)
 	}
-
 	return privateKey, nil
 }
 
-// saveKeysToFile saves the generated keys to file for reuse
-func saveKeysToFile(privateKey *rsa.PrivateKey) error {
-	keyPath := keyFilePath()
-	privateKeyBytes := x509.MarshalPKCS1PrivateKey(privateKey)
-	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
-		Type:  "RSA PRIVATE KEY",
-		Bytes: privateKeyBytes,
-	})
-
-	return os.WriteFile(keyPath, privateKeyPEM, 0600)
-}
-
-// generateRuntimeKeys creates a new RSA key pair at runtime or loads existing ones
+// generateRuntimeKeys loads the key of the shared auth state, creating it on the first run
 func generateRuntimeKeys() {
-	// First try to load existing keys
-	if existingKey, err := loadKeysFromFile(); err == nil && existingKey != nil {
-		dynamicPrivateKey = existingKey
-		dynamicPublicKey = &existingKey.PublicKey
-		return
-	}
-
-	// Generate new 2048-bit RSA key pair
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	privateKey, err := loadOrCreateKey()
 	if err != nil {
-		keyGenerationErr = fmt.Errorf("failed to generate RSA key pair: %w", err)
-		return
-	}
-
-	if saveErr := saveKeysToFile(privateKey); saveErr != nil {
-		keyGenerationErr = fmt.Errorf("failed to save keys to file: %w", saveErr)
+		keyGenerationErr = fmt.Errorf("failed to load the auth state: %w", err)
 		return
 	}
 
@@ -141,7 +133,11 @@ func getJWKS() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	return JWKSOf(publicKey)
+}
 
+// JWKSOf returns the JWKS of a public key under KeyID, as the OIDC mock serves it.
+func JWKSOf(publicKey *rsa.PublicKey) (string, error) {
 	jwks := map[string]interface{}{
 		"keys": []map[string]interface{}{
 			{
@@ -181,7 +177,7 @@ func (g *TestJWTGenerator) createToken(claims jwt.MapClaims) (string, error) {
 // NewTestJWTGenerator creates a new JWT generator with dynamic keys (backward compatibility)
 func NewTestJWTGenerator() (*TestJWTGenerator, error) {
 	// Generate unique keys for each generator instance (not shared)
-	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeySize())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate RSA key pair: %w", err)
 	}
@@ -327,6 +323,21 @@ func SetupTestAuthentication(username string) (*TestAuthContext, error) {
 	}, nil
 }
 
+// SetupProjectAuthentication creates authentication context for the given username, with roles in projectID
+func SetupProjectAuthentication(username, projectID string) (*TestAuthContext, error) {
+	token, err := GenerateProjectJWTForClient(username, projectID, []string{"cluster-manager"}, "system-client")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate test JWT: %w", err)
+	}
+
+	return &TestAuthContext{
+		Token:    token,
+		Subject:  username,
+		Issuer:   "cluster-tests",
+		Audience: []string{"cluster-manager"},
+	}, nil
+}
+
 // GenerateTestJWT creates a JWT token for testing with the given username using PS512
 func GenerateTestJWT(username string) (string, error) {
 	return GenerateTestJWTForClient(username, []string{"cluster-manager"}, "system-client")
@@ -338,21 +349,41 @@ func GenerateTestJWT(username string) (string, error) {
 // This is useful for components (e.g., southbound RBAC) that require tokens scoped to a
 // specific OIDC client id.
 func GenerateTestJWTForClient(username string, audience []string, azp string) (string, error) {
+	return GenerateProjectJWTForClient(username, DefaultProjectID, audience, azp)
+}
+
+// GenerateProjectJWTForClient is GenerateTestJWTForClient with the project roles bound to projectID, for
+// projects created through the tenancy API rather than the default test namespace. Tokens are cached in the
+// auth state and reused by later suites while they are valid for at least minTokenValidity.
+func GenerateProjectJWTForClient(username, projectID string, audience []string, azp string) (string, error) {
 	// Get the dynamically generated private key
 	privateKey, _, err := getOrGenerateKeys()
 	if err != nil {
 		return "", fmt.Errorf("failed to get private key: %w", err)
 	}
 
-	// Set issuer and audience to match unit test expectations
+	return cachedOrSignedToken(tokenCacheKey("project", username, projectID, audience, azp), func(now, expiresAt time.Time) (string, error) {
+		return signProjectJWT(privateKey, username, projectID, audience, azp, now, expiresAt)
+	})
+}
+
+// SignProjectJWT signs the claims of GenerateProjectJWTForClient with privateKey, valid for lifetime and not
+// cached, for the token endpoint of the OIDC mock.
+func SignProjectJWT(privateKey *rsa.PrivateKey, username, projectID string, audience []string, azp string, lifetime time.Duration) (string, error) {
 	now := time.Now()
-	clusterNamespace := "53cd37b9-66b2-4cc8-b080-3722ed7af64a" // Default namespace from cluster_utils.go
+	return signProjectJWT(privateKey, username, projectID, audience, azp, now, now.Add(lifetime))
+}
+
+// signProjectJWT signs the claims of GenerateProjectJWTForClient.
+func signProjectJWT(privateKey *rsa.PrivateKey, username, projectID string, audience []string, azp string, now, expiresAt time.Time) (string, error) {
+	// Set issuer and audience to match unit test expectations
+	clusterNamespace := projectID
 	claims := jwt.MapClaims{
 		"sub":   username,
 		"iss":   IssuerURL, // Use constant instead of hardcoded value
 		"aud":   audience,
 		"scope": "openid email roles profile", // Match working JWT scope
-		"exp":   now.Add(time.Hour).Unix(),
+		"exp":   expiresAt.Unix(),
 		"iat":   now.Unix(),
 		"typ":   "Bearer", // Token type
 		"azp":   azp,
@@ -401,6 +432,50 @@ func GenerateTestJWTForClient(username string, audience []string, azp string) (s
 	return tokenString, nil
 }
 
+// GenerateTenancyJWT creates a JWT token for the tenancy API: org administration, plus project
+// administration within orgID when it is not empty. Tokens are cached like those of GenerateProjectJWTForClient.
+func GenerateTenancyJWT(username, orgID string) (string, error) {
+	privateKey, _, err := getOrGenerateKeys()
+	if err != nil {
+		return "", fmt.Errorf("failed to get private key: %w", err)
+	}
+
+	return cachedOrSignedToken(tokenCacheKey("tenancy", username, orgID, []string{"tenancy-api"}, "system-client"), func(now, expiresAt time.Time) (string, error) {
+		return signTenancyJWT(privateKey, username, orgID, now, expiresAt)
+	})
+}
+
+// signTenancyJWT signs the claims of GenerateTenancyJWT.
+func signTenancyJWT(privateKey *rsa.PrivateKey, username, orgID string, now, expiresAt time.Time) (string, error) {
+	roles := []string{"org-read-role", "org-write-role", "org-update-role", "org-delete-role"}
+	if orgID != "" {
+		for _, role := range []string{"project-read-role", "project-write-role", "project-update-role", "project-delete-role"} {
+			roles = append(roles, orgID+"_"+role)
+		}
+	}
+
+	claims := jwt.MapClaims{
+		"sub":                username,
+		"iss":                IssuerURL,
+		"aud":                []string{"tenancy-api"},
+		"scope":              "openid email roles profile",
+		"exp":                expiresAt.Unix(),
+		"iat":                now.Unix(),
+		"typ":                "Bearer",
+		"azp":                "system-client",
+		"realm_access":       map[string]interface{}{"roles": roles},
+		"preferred_username": username,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodPS512, claims)
+	token.Header["kid"] = KeyID
+	tokenString, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT token: %w", err)
+	}
+	return tokenString, nil
+}
+
 // GenerateOIDCMockConfig generates a Kubernetes YAML configuration for OIDC mock server
 // with runtime-generated JWKS, replacing the bash script implementation
 func GenerateOIDCMockConfig() (string, error) {
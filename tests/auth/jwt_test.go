@@ -225,3 +225,55 @@ func TestGenerateOIDCMockConfigReplacesJWKSAndAvoidsTabs(t *testing.T) {
 		t.Fatal("Generated OIDC mock config still contains __JWKS_JSON__ placeholder")
 	}
 }
+
+// realmRoles returns the realm roles of a token signed with the runtime keys.
+func realmRoles(t *testing.T, tokenString string) []string {
+	t.Helper()
+	generator := &TestJWTGenerator{privateKey: dynamicPrivateKey, publicKey: dynamicPublicKey}
+	claims, err := generator.ValidateToken(tokenString)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+	realmAccess, _ := claims["realm_access"].(map[string]interface{})
+	roles, _ := realmAccess["roles"].([]interface{})
+	var names []string
+	for _, role := range roles {
+		names = append(names, role.(string))
+	}
+	return names
+}
+
+func TestGenerateProjectJWTForClient(t *testing.T) {
+	projectID := "0f3e4a2b-9c1d-4e5f-8a7b-6c5d4e3f2a1b"
+	tokenString, err := GenerateProjectJWTForClient("test-user", projectID, []string{"cluster-manager"}, "system-client")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	roles := strings.Join(realmRoles(t, tokenString), ",")
+	if !strings.Contains(roles, projectID+"_cl-rw") || !strings.Contains(roles, projectID+"_cl-tpl-rw") {
+		t.Errorf("Expected cluster roles in project %s, got %s", projectID, roles)
+	}
+	if strings.Contains(roles, DefaultProjectID) {
+		t.Errorf("Expected no roles in the default project, got %s", roles)
+	}
+}
+
+func TestGenerateTenancyJWT(t *testing.T) {
+	tokenString, err := GenerateTenancyJWT("tenancy-admin", "")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	roles := strings.Join(realmRoles(t, tokenString), ",")
+	if !strings.Contains(roles, "org-write-role") || strings.Contains(roles, "project-write-role") {
+		t.Errorf("Expected only org roles without an org, got %s", roles)
+	}
+
+	tokenString, err = GenerateTenancyJWT("tenancy-admin", "org-uid")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if roles := realmRoles(t, tokenString); !strings.Contains(strings.Join(roles, ","), "org-uid_project-write-role") {
+		t.Errorf("Expected project roles in the org, got %v", roles)
+	}
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMain keeps the tests off the auth state and the key file the bootstrap, the suites and the deployed OIDC
+// mock share, so running them neither rotates nor creates the signing key of a test environment.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "cluster-tests-auth-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create a temporary directory: %v\n", err)
+		os.Exit(1)
+	}
+	os.Setenv(AuthStateFileEnvVar, filepath.Join(dir, "state.json"))
+	os.Setenv(KeyFileEnvVar, filepath.Join(dir, "keys.pem"))
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// AuthStateFileEnvVar is the file the signing key and the cached tokens are shared through, by the
+	// bootstrap, the suites and the OIDC mock they deploy. FIPS mode keeps its own file.
+	AuthStateFileEnvVar = "AUTH_STATE_FILE"
+	// KeyFileEnvVar is the key file of bootstraps that predate the auth state, imported into a new one.
+	KeyFileEnvVar = "AUTH_KEY_FILE"
+
+	// tokenLifetime is how long a generated token is valid, and minTokenValidity how long a cached token
+	// still has to be valid to be reused.
+	tokenLifetime    = time.Hour
+	minTokenValidity = 15 * time.Minute
+)
+
+// authState is the content of the auth state file.
+type authState struct {
+	// PrivateKey is the PKCS#1 PEM of the key the tokens are signed with and the OIDC mock serves.
+	PrivateKey string                 `json:"privateKey"`
+	Tokens     map[string]cachedToken `json:"tokens,omitempty"`
+}
+
+// cachedToken is a signed token and its expiry.
+type cachedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AuthStatePath returns AUTH_STATE_FILE, or the default auth state file, in the variant of the current mode.
+func AuthStatePath() string {
+	path := os.Getenv(AuthStateFileEnvVar)
+	if path == "" {
+		path = "/tmp/cluster-tests-auth-state.json"
+	}
+	return modePath(path)
+}
+
+// withAuthState runs update on the auth state under an exclusive lock, so suites running side by side see the
+// same key and tokens, and writes the state back when update reports a change.
+func withAuthState(update func(state *authState) (bool, error)) error {
+	path := AuthStatePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the lock of %s: %w", path, err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN) //nolint:errcheck
+
+	state := &authState{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, state); err != nil {
+			return fmt.Errorf("failed to parse %s, reset it with `mage test:resetAuthState`: %w", path, err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	changed, err := update(state)
+	if err != nil || !changed {
+		return err
+	}
+	data, err = json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write next to the state and rename, so a reader without the lock never sees half a file.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// loadOrCreateKey returns the key of the auth state. A state without a key takes the key file of an earlier
+// bootstrap, which the deployed OIDC mock still serves, or else a new key.
+func loadOrCreateKey() (*rsa.PrivateKey, error) {
+	var privateKey *rsa.PrivateKey
+	err := withAuthState(func(state *authState) (bool, error) {
+		if state.PrivateKey != "" {
+			key, err := ParsePrivateKeyPEM([]byte(state.PrivateKey))
+			if err != nil {
+				return false, fmt.Errorf("invalid key in %s: %w", AuthStatePath(), err)
+			}
+			privateKey = key
+			return false, nil
+		}
+
+		key, err := loadKeysFromFile()
+		if err != nil {
+			return false, err
+		}
+		if key == nil {
+			if key, err = rsa.GenerateKey(rand.Reader, rsaKeySize()); err != nil {
+				return false, fmt.Errorf("failed to generate RSA key pair: %w", err)
+			}
+		}
+		privateKey = key
+		state.PrivateKey = string(pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}))
+		state.Tokens = nil
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeySize(&privateKey.PublicKey); err != nil {
+		return nil, fmt.Errorf("%w, reset %s with `mage test:resetAuthState`", err, AuthStatePath())
+	}
+	return privateKey, nil
+}
+
+// cachedOrSignedToken returns the cached token of key while it is valid for at least minTokenValidity, and
+// otherwise caches a token from sign, which signs claims that expire at expiresAt. Expired tokens are dropped.
+func cachedOrSignedToken(key string, sign func(now, expiresAt time.Time) (string, error)) (string, error) {
+	var token string
+	err := withAuthState(func(state *authState) (bool, error) {
+		now := time.Now()
+		if cached, ok := state.Tokens[key]; ok && cached.ExpiresAt.Sub(now) >= minTokenValidity {
+			token = cached.Token
+			return false, nil
+		}
+
+		expiresAt := now.Add(tokenLifetime)
+		signed, err := sign(now, expiresAt)
+		if err != nil {
+			return false, err
+		}
+		token = signed
+		if state.Tokens == nil {
+			state.Tokens = map[string]cachedToken{}
+		}
+		for k, cached := range state.Tokens {
+			if !cached.ExpiresAt.After(now) {
+				delete(state.Tokens, k)
+			}
+		}
+		// Second precision, as in the exp claim.
+		state.Tokens[key] = cachedToken{Token: signed, ExpiresAt: time.Unix(expiresAt.Unix(), 0).UTC()}
+		return true, nil
+	})
+	return token, err
+}
+
+// tokenCacheKey identifies the tokens of the same claims.
+func tokenCacheKey(kind, subject, scope string, audience []string, azp string) string {
+	return strings.Join([]string{kind, subject, scope, strings.Join(audience, ","), azp}, "|")
+}
+
+// InvalidateAuthState removes the auth state and the key file of earlier bootstraps, so that the next run
+// generates a new key and tokens. The OIDC mock has to be synchronized again afterwards.
+func InvalidateAuthState() error {
+	for _, path := range []string{AuthStatePath(), keyFilePath()} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// JWKSMatches reports whether a JWKS, e.g. the one the deployed OIDC mock serves, holds the public key of
+// KeyID that the tokens are signed with.
+func JWKSMatches(jwks string) (bool, error) {
+	_, publicKey, err := getOrGenerateKeys()
+	if err != nil {
+		return false, err
+	}
+	var served struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(jwks), &served); err != nil {
+		return false, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+	for _, key := range served.Keys {
+		if key.Kid == KeyID && key.N == encodeBase64URLBigInt(publicKey.N) &&
+			key.E == encodeBase64URLBigInt(big.NewInt(int64(publicKey.E))) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
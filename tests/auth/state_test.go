@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"crypto/fips140"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthStatePath(t *testing.T) {
+	t.Setenv(AuthStateFileEnvVar, "")
+	t.Setenv(FIPSModeEnvVar, "true")
+	if !strings.HasSuffix(AuthStatePath(), "-fips.json") {
+		t.Errorf("Expected a separate auth state in FIPS mode, got %s", AuthStatePath())
+	}
+
+	dir := t.TempDir()
+	t.Setenv(AuthStateFileEnvVar, filepath.Join(dir, "state.json"))
+	if expected := filepath.Join(dir, "state-fips.json"); AuthStatePath() != expected {
+		t.Errorf("Expected %s in FIPS mode, got %s", expected, AuthStatePath())
+	}
+	t.Setenv(FIPSModeEnvVar, "false")
+	if expected := filepath.Join(dir, "state.json"); !fips140.Enabled() && AuthStatePath() != expected {
+		t.Errorf("Expected %s, got %s", expected, AuthStatePath())
+	}
+}
+
+func TestCachedOrSignedTokenReusesValidTokens(t *testing.T) {
+	t.Setenv(AuthStateFileEnvVar, filepath.Join(t.TempDir(), "state.json"))
+	signed := 0
+	sign := func(now, expiresAt time.Time) (string, error) {
+		signed++
+		return fmt.Sprintf("token-%d", signed), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := cachedOrSignedToken("project|user", sign)
+		if err != nil {
+			t.Fatalf("Failed to get token: %v", err)
+		}
+		if token != "token-1" {
+			t.Errorf("Expected the cached token-1, got %s", token)
+		}
+	}
+
+	token, err := cachedOrSignedToken("project|other", sign)
+	if err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+	if token != "token-2" {
+		t.Errorf("Expected a new token for other claims, got %s", token)
+	}
+}
+
+func TestCachedOrSignedTokenReplacesExpiringTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	t.Setenv(AuthStateFileEnvVar, path)
+	state := authState{Tokens: map[string]cachedToken{
+		"expiring": {Token: "old", ExpiresAt: time.Now().Add(minTokenValidity / 2)},
+		"expired":  {Token: "gone", ExpiresAt: time.Now().Add(-time.Minute)},
+	}}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+
+	token, err := cachedOrSignedToken("expiring", func(now, expiresAt time.Time) (string, error) {
+		if expiresAt.Sub(now) != tokenLifetime {
+			t.Errorf("Expected a token valid for %v, got %v", tokenLifetime, expiresAt.Sub(now))
+		}
+		return "new", nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to get token: %v", err)
+	}
+	if token != "new" {
+		t.Errorf("Expected a new token instead of one about to expire, got %s", token)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read state: %v", err)
+	}
+	state = authState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Failed to parse state: %v", err)
+	}
+	if _, ok := state.Tokens["expired"]; ok {
+		t.Error("Expected the expired token to be dropped")
+	}
+	if state.Tokens["expiring"].Token != "new" {
+		t.Errorf("Expected the new token to be cached, got %+v", state.Tokens)
+	}
+}
+
+func TestLoadOrCreateKeyPersistsTheKey(t *testing.T) {
+	t.Setenv(AuthStateFileEnvVar, filepath.Join(t.TempDir(), "state.json"))
+	first, err := loadOrCreateKey()
+	if err != nil {
+		t.Fatalf("Failed to create key: %v", err)
+	}
+	second, err := loadOrCreateKey()
+	if err != nil {
+		t.Fatalf("Failed to load key: %v", err)
+	}
+	if !first.Equal(second) {
+		t.Error("Expected the key of the auth state to be reused")
+	}
+}
+
+func TestCorruptAuthStateIsReported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	t.Setenv(AuthStateFileEnvVar, path)
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("Failed to write state: %v", err)
+	}
+	if _, err := loadOrCreateKey(); err == nil || !strings.Contains(err.Error(), "resetAuthState") {
+		t.Errorf("Expected an error pointing at resetAuthState, got %v", err)
+	}
+}
+
+func TestJWKSMatches(t *testing.T) {
+	jwks, err := getJWKS()
+	if err != nil {
+		t.Fatalf("Failed to get JWKS: %v", err)
+	}
+	if matches, err := JWKSMatches(jwks); err != nil || !matches {
+		t.Errorf("Expected the JWKS of the signing key to match, got %t, %v", matches, err)
+	}
+
+	other := `{"keys":[{"kty":"RSA","kid":"` + KeyID + `","n":"AQAB","e":"AQAB"}]}`
+	if matches, err := JWKSMatches(other); err != nil || matches {
+		t.Errorf("Expected the JWKS of another key not to match, got %t, %v", matches, err)
+	}
+
+	if _, err := JWKSMatches("not json"); err == nil {
+		t.Error("Expected an error for an invalid JWKS")
+	}
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package backup_restore_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	kubeconfigFileName = "kubeconfig-backup-restore.yaml"
+
+	clusterReadinessTimeout = 15 * time.Minute
+	reconnectTimeout        = 10 * time.Minute
+	reconnectInterval       = 10 * time.Second
+)
+
+func TestBackupRestoreTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting backup and restore tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "backup and restore test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+// downstreamNodes returns the names and UIDs of the downstream nodes; a reprovisioned node registers anew
+// with a different UID.
+func downstreamNodes() (string, error) {
+	client, err := utils.NewKubeClient(kubeconfigFileName)
+	if err != nil {
+		return "", err
+	}
+	nodes, err := client.ListNodes()
+	if err != nil {
+		return "", err
+	}
+	var lines []string
+	for _, node := range nodes {
+		lines = append(lines, fmt.Sprintf("%s=%s", node.Name, node.UID))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+var _ = Describe("Restoring the management-side objects of a running cluster", Ordered, Label(utils.ClusterOrchBackupRestoreTest), func() {
+	var (
+		namespace      string
+		nodeGUID       string
+		backupDir      string
+		nodesBefore    string
+		objectsDeleted bool
+		setUp          bool
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+		backupDir = GinkgoT().TempDir()
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp {
+			return
+		}
+		if objectsDeleted {
+			By("Restoring the objects left deleted by a failed spec")
+			if err := utils.RestoreClusterAPIObjects(namespace, backupDir, ""); err != nil {
+				fmt.Printf("Failed to restore the cluster objects: %v\n", err)
+				return
+			}
+		}
+		if utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		if err := utils.DeleteCluster(namespace); err != nil {
+			fmt.Printf("Failed to delete cluster: %v\n", err)
+			return
+		}
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should create a cluster", func() {
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)).To(Succeed())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+		By("Recording the downstream nodes")
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+		var err error
+		nodesBefore, err = downstreamNodes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodesBefore).NotTo(BeEmpty())
+		fmt.Printf("Downstream nodes before the backup:\n%s\n", nodesBefore)
+	})
+
+	It("should back up the cluster objects", func() {
+		Expect(utils.BackupClusterAPIObjects(namespace, backupDir)).To(Succeed())
+
+		objects, err := utils.ListBackedUpObjects(backupDir)
+		Expect(err).NotTo(HaveOccurred())
+		for _, obj := range objects {
+			fmt.Printf("  backed up %s\n", obj)
+		}
+		Expect(objects).To(ContainElement(HaveField("Kind", "Cluster")))
+	})
+
+	It("should delete the management-side objects without deprovisioning the cluster", func() {
+		objects, err := utils.ListBackedUpObjects(backupDir)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(utils.SetClusterPaused(namespace, utils.ClusterName, true)).To(Succeed())
+		objectsDeleted = true
+		Expect(utils.DeleteObjectsOrphaningInfrastructure(objects)).To(Succeed())
+
+		Eventually(func() bool {
+			return utils.ManagedClusterExists("", namespace, utils.ClusterName)
+		}, 2*time.Minute, 5*time.Second).Should(BeFalse())
+	})
+
+	It("should restore the cluster objects", func() {
+		Expect(utils.RestoreClusterAPIObjects(namespace, backupDir, "")).To(Succeed())
+		objectsDeleted = false
+		Expect(utils.SetClusterPaused(namespace, utils.ClusterName, false)).To(Succeed())
+	})
+
+	It("should reconnect the downstream cluster", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), reconnectTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		lost, err := utils.CheckLostConnection("", namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lost).To(BeFalse(), "the connect agent should be connected")
+	})
+
+	It("should not have reprovisioned the downstream nodes", func() {
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+		Eventually(downstreamNodes, reconnectTimeout, reconnectInterval).Should(Equal(nodesBefore),
+			"the downstream nodes should be the same ones as before the backup")
+	})
+})
@@ -5,12 +5,12 @@ package cluster_api_test_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/exec"
-	"strconv"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +19,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/open-edge-platform/cluster-tests/tests/auth"
 	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
 )
 
 // Constants for commonly used values
@@ -26,35 +28,22 @@ const (
 	TempKubeconfigPattern = "/tmp/%s-kubeconfig.yaml"
 	KubeconfigFileName    = "kubeconfig.yaml"
 	LocalGatewayURL       = "http://127.0.0.1:8081/"
-	// NOTE: cluster readiness can be significantly slower on vEN due to VM bring-up
-	// and first-time image pulls. Prefer using clusterReadinessTimeout() rather
-	// than hard-coding this value.
-	DefaultClusterReadinessTimeout = 5 * time.Minute
-	ClusterReadinessInterval       = 10 * time.Second
-	EdgeNodeProviderTimeout        = 10 * time.Minute
+	// The cluster readiness budget is wait.ClusterReadyBudget, CLUSTER_READINESS_TIMEOUT in the environment.
+	ClusterReadinessInterval = 10 * time.Second
+	EdgeNodeProviderTimeout  = 10 * time.Minute
 	// Prefer using podReadinessTimeout() rather than hard-coding this value.
 	DefaultPodReadinessTimeout = 5 * time.Minute
 	PodReadinessInterval       = 10 * time.Second
 	PortForwardTimeout         = 1 * time.Minute
 	PortForwardInterval        = 5 * time.Second
-	PortForwardDelay           = 5 * time.Second
+	// NetworkPolicyTimeout leaves the policy controller time to program a new policy.
+	NetworkPolicyTimeout  = 1 * time.Minute
+	NetworkPolicyInterval = 5 * time.Second
+	// TestWorkloadTimeout leaves the service endpoints and the cluster DNS time to pick up the nginx workload.
+	TestWorkloadTimeout  = 1 * time.Minute
+	TestWorkloadInterval = 5 * time.Second
 )
 
-func clusterReadinessTimeout() time.Duration {
-	// Allow overriding from the environment for slow/loaded CI hosts.
-	if val := strings.TrimSpace(os.Getenv("CLUSTER_READINESS_TIMEOUT")); val != "" {
-		if d, err := time.ParseDuration(val); err == nil {
-			return d
-		}
-	}
-
-	// vEN provisioning can take longer than the in-kind ENiC flow.
-	if utils.GetEdgeNodeProvider() == utils.EdgeNodeProviderVEN {
-		return EdgeNodeProviderTimeout
-	}
-	return DefaultClusterReadinessTimeout
-}
-
 func podReadinessTimeout() time.Duration {
 	if val := strings.TrimSpace(os.Getenv("POD_READINESS_TIMEOUT")); val != "" {
 		if d, err := time.ParseDuration(val); err == nil {
@@ -67,70 +56,36 @@ func podReadinessTimeout() time.Duration {
 	return DefaultPodReadinessTimeout
 }
 
-// function to check if cluster components are ready
-func checkClusterComponentsReady(namespace string) bool {
-	cmd := exec.Command("clusterctl", "describe", "cluster", utils.ClusterName, "-n", namespace)
-	output, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	fmt.Printf("Cluster components status:\n%s\n", string(output))
-	return utils.CheckAllComponentsReady(string(output))
-}
-
 // function to wait for Intel machines to exist
 func waitForIntelMachines(namespace string) {
 	By("Waiting for IntelMachine to exist")
+	client, err := utils.ManagementKubeClient()
+	Expect(err).NotTo(HaveOccurred())
 	Eventually(func() bool {
-		cmd := exec.Command("sh", "-c", fmt.Sprintf("kubectl -n %s get intelmachine -o yaml | yq '.items | length'", namespace))
-		output, err := cmd.Output()
-		if err != nil {
-			return false
-		}
-		n, err := strconv.Atoi(strings.TrimSpace(string(output)))
-		return err == nil && n > 0
-	}, PortForwardTimeout, PortForwardInterval).Should(BeTrue())
+		machines, err := client.ListIntelMachines(namespace, "")
+		return err == nil && len(machines) > 0
+	}, PortForwardTimeout, PortForwardInterval).Should(BeTrue(), func() string {
+		return utils.TriageCluster(namespace, utils.ClusterName)
+	})
 }
 
 // function to wait for cluster components to be ready
 func waitForClusterComponentsReady(namespace string) {
 	By("Waiting for all components to be ready")
-	Eventually(func() bool {
-		return checkClusterComponentsReady(namespace)
-	}, clusterReadinessTimeout(), ClusterReadinessInterval).Should(BeTrue())
+	Expect(wait.WaitForClusterReady(namespace, utils.ClusterName)).To(Succeed())
 }
 
 func TestClusterApiTest(t *testing.T) {
 	RegisterFailHandler(Fail)
 	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch api tests\n")
-	RunSpecs(t, "cluster orch api test suite")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch api test suite", suiteConfig, reporterConfig)
 }
 
-// setupPortForwarding sets up port forwarding for any service
-func setupPortForwarding(serviceName, serviceIdentifier, localPort, remotePort string) (*exec.Cmd, error) {
-	By(fmt.Sprintf("Port forwarding to the %s service", serviceName))
-	if err := utils.EnsureTCPPortAvailable(localPort, fmt.Sprintf("kubectl port-forward %s", serviceIdentifier)); err != nil {
-		return nil, err
-	}
-	portForwardCmd := exec.Command("kubectl", "port-forward", serviceIdentifier,
-		fmt.Sprintf("%s:%s", localPort, remotePort), "--address", utils.PortForwardAddress)
-	err := portForwardCmd.Start()
-	if err != nil {
-		return nil, err
-	}
-	time.Sleep(PortForwardDelay)
-	return portForwardCmd, nil
-}
-
-// cleanupPortForwarding safely kills port forwarding processes
-func cleanupPortForwarding(portForwardCmd, gatewayPortForward *exec.Cmd) {
-	if portForwardCmd != nil && portForwardCmd.Process != nil {
-		portForwardCmd.Process.Kill()
-	}
-	if gatewayPortForward != nil && gatewayPortForward.Process != nil {
-		gatewayPortForward.Process.Kill()
-	}
-}
+var _ = diagnostics.RegisterSuiteHooks()
+var _ = utils.RegisterCapabilityGating()
+var _ = utils.RegisterSharedClusters()
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
 
 // performClusterOperation executes a cluster operation with conditional authentication
 func performClusterOperation(operationType string, authDisabled bool, authContext *auth.TestAuthContext,
@@ -180,7 +135,7 @@ func validateJWTWorkflow(authContext *auth.TestAuthContext, namespace string) {
 		"   - Cluster creation\n" +
 		"   - Cluster management APIs\n" +
 		"   - Kubeconfig retrieval\n" +
-		"   - Cluster deletion (in AfterEach)\n")
+		"   - Cluster deletion (at the end of the suite)\n")
 
 	By("Verifying JWT token structure and claims")
 	parts := strings.Split(authContext.Token, ".")
@@ -190,6 +145,14 @@ func validateJWTWorkflow(authContext *auth.TestAuthContext, namespace string) {
 	Expect(authContext.Issuer).To(Equal("cluster-tests"))
 	Expect(authContext.Audience).To(ContainElement("cluster-manager"))
 
+	if auth.FIPSMode() {
+		By("Verifying the token only uses FIPS-approved parameters and cluster-manager accepts it")
+		Expect(auth.CheckFIPSToken(authContext.Token)).To(Succeed())
+		status, err := utils.ClusterTemplatesStatus(authContext, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusOK), "cluster-manager should accept tokens signed in FIPS mode")
+	}
+
 	By("Testing cluster-manager API authentication")
 	err := utils.TestClusterManagerAuthentication(authContext)
 	if err != nil {
@@ -273,8 +236,8 @@ func processSuccessfulKubeconfigResponse(resp *http.Response) {
 	fmt.Printf("COMPLETE JWT WORKFLOW SUCCESSFUL: Token → API → Kubeconfig → Downstream K3s Cluster Access\n")
 }
 
-// waitForClusterReady performs common cluster readiness validation
-func waitForClusterReady(namespace string, clusterCreateStartTime time.Time) time.Time {
+// waitForClusterReady performs common cluster readiness validation and reports the per-phase provisioning timings
+func waitForClusterReady(namespace string, clusterCreateStartTime time.Time, phaseTracker *utils.PhaseTracker) time.Time {
 	waitForIntelMachines(namespace)
 	waitForClusterComponentsReady(namespace)
 
@@ -287,55 +250,60 @@ func waitForClusterReady(namespace string, clusterCreateStartTime time.Time) tim
 		defer metrics.Close()
 		ok, err := utils.ParseMetrics(metrics)
 		return err == nil && ok
-	}, clusterReadinessTimeout(), ClusterReadinessInterval).Should(BeTrue())
+	}, wait.ClusterReadyBudget(), ClusterReadinessInterval).Should(BeTrue())
 
 	clusterCreateEndTime := time.Now()
 	totalTime := clusterCreateEndTime.Sub(clusterCreateStartTime)
 	fmt.Printf("\033[32mTotal time from cluster creation to fully active: %v 🚀 ✅\033[0m\n", totalTime)
 
+	phaseTracker.Stop()
+	phaseTracker.PrintBreakdown()
+	if path, err := phaseTracker.WriteReport(); err != nil {
+		fmt.Printf("Failed to write provisioning phase report: %v\n", err)
+	} else {
+		fmt.Printf("Provisioning phase report written to %s\n", path)
+	}
+
 	return clusterCreateEndTime
 }
 
 // validateKubeconfigAndClusterAccess performs kubeconfig validation and cluster access testing
 func validateKubeconfigAndClusterAccess() {
-	By("Getting kubeconfig")
-	cmd := exec.Command("clusterctl", "get", "kubeconfig", utils.ClusterName, "--namespace", utils.DefaultNamespace)
-	output, err := cmd.Output()
-	Expect(err).NotTo(HaveOccurred())
-
-	kubeConfigName := KubeconfigFileName
-	err = os.WriteFile(kubeConfigName, output, 0644)
+	By("Getting kubeconfig pointed at the cluster connect gateway")
+	kubeConfigName, _, err := utils.GetDownstreamKubeconfig(utils.ClusterName, utils.DownstreamKubeconfigOptions{
+		Namespace: utils.DefaultNamespace,
+		Source:    utils.KubeconfigSourceClusterctl,
+		Server:    LocalGatewayURL,
+		Path:      KubeconfigFileName,
+	})
 	Expect(err).NotTo(HaveOccurred())
 
-	By("Setting in kubeconfig server to cluster connect gateway")
-	cmd = exec.Command("sed", "-i", fmt.Sprintf("s|http://[[:alnum:].-]*:8080/|%s|", LocalGatewayURL), kubeConfigName)
-	_, err = cmd.Output()
+	client, err := utils.NewKubeClient(kubeConfigName)
 	Expect(err).NotTo(HaveOccurred())
 
 	By("Getting list of pods")
-	cmd = exec.Command("kubectl", "--kubeconfig", kubeConfigName, "get", "pods", "-A")
-	output, err = cmd.Output()
+	pods, err := utils.ListDownstreamPods(kubeConfigName)
 	Expect(err).NotTo(HaveOccurred())
-	fmt.Printf("List of pods:\n%s\n", string(output))
+	fmt.Println("List of pods:")
+	for _, pod := range pods {
+		fmt.Printf("  %s/%s %s\n", pod.Namespace, pod.Name, pod.Phase)
+	}
 	fmt.Println("NOTE: kubeconfig fetched via clusterctl" +
-		" To use kubeconfig from cluster-manager REST API., run with DISABLE_AUTH=false.")
+		" To use kubeconfig from cluster-manager REST API., enable authentication in cluster-manager or run with DISABLE_AUTH=false.")
 
-	By("Dumping kubectl client and server version")
-	cmd = exec.Command("kubectl", "version", "--kubeconfig", kubeConfigName)
-	output, err = cmd.Output()
+	By("Dumping the server version")
+	serverVersion, err := client.ServerVersion()
 	Expect(err).NotTo(HaveOccurred())
-	fmt.Printf("kubectl client and server version:\n%s\n", string(output))
+	fmt.Printf("Server version: %s\n", serverVersion.GitVersion)
 
 	By("Waiting for all pods to be running")
 	Eventually(func() bool {
-		cmd := exec.Command("kubectl", "--kubeconfig", kubeConfigName, "get", "pods", "-A", "-o", "jsonpath={.items[*].status.phase}")
-		output, err := cmd.Output()
+		pods, err := utils.ListDownstreamPods(kubeConfigName)
 		if err != nil {
 			return false
 		}
-		podStatuses := strings.Fields(string(output))
-		for _, status := range podStatuses {
-			if status != "Running" && status != "Completed" && status != "Succeeded" {
+		for _, pod := range pods {
+			if pod.Phase != "Running" && pod.Phase != "Succeeded" {
 				return false
 			}
 		}
@@ -343,40 +311,196 @@ func validateKubeconfigAndClusterAccess() {
 	}, podReadinessTimeout(), PodReadinessInterval).Should(BeTrue(), "Not all pods are in Running or Completed state")
 
 	By("Getting the local-path-provisioner pod name")
-	cmd = exec.Command("kubectl", "get", "pods", "-n", "kube-system", "-l", "app=local-path-provisioner",
-		"-o", "jsonpath={.items[0].metadata.name}", "--kubeconfig", kubeConfigName)
-	output, err = cmd.Output()
+	podName, err := utils.LocalPathProvisionerPod(client)
 	Expect(err).NotTo(HaveOccurred(), "Failed to get the local-path-provisioner pod name")
-	fmt.Printf("Local-path-provisioner pod name: %s\n", string(output))
-
-	podName := strings.TrimSpace(string(output))
-	Expect(podName).NotTo(BeEmpty(), "Pod name should not be empty")
+	fmt.Printf("Local-path-provisioner pod name: %s\n", podName)
 
 	By("Executing the `ls` command in the local-path-provisioner pod")
-	cmd = exec.Command("kubectl", "exec", "-it", podName, "-n", "kube-system", "--kubeconfig", kubeConfigName, "--", "ls")
-	output, err = cmd.Output()
+	output, err := client.Exec("kube-system", podName, "ls")
 	Expect(err).NotTo(HaveOccurred(), "Failed to execute the `ls` command in the pod")
 
-	fmt.Printf("Output of `ls` command:\n%s\n", string(output))
+	fmt.Printf("Output of `ls` command:\n%s\n", output)
+}
+
+// validateDownstreamCertificate checks the SANs and validity period of the downstream API server certificate
+func validateDownstreamCertificate() {
+	By("Verifying the downstream API server certificate")
+	cert, err := utils.GetDownstreamServingCertificate(KubeconfigFileName)
+	Expect(err).NotTo(HaveOccurred())
+	fmt.Printf("Downstream API server certificate: SANs %v %v, valid %v - %v\n",
+		cert.DNSNames, cert.IPAddresses, cert.NotBefore, cert.NotAfter)
+
+	expectations, err := utils.DownstreamCertificateExpectations()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(utils.CertificateProblems(cert, expectations, time.Now())).To(BeEmpty())
+}
+
+// validateRenderedObjectsSnapshot compares the CAPI objects rendered from the baseline template against
+// the committed golden file, so changes in cluster-manager's rendering are noticed
+func validateRenderedObjectsSnapshot(namespace, nodeGUID string) {
+	By("Comparing the rendered cluster objects against the golden snapshot")
+	snapshot, err := utils.CaptureRenderedClusterObjects(namespace, utils.ClusterName, nodeGUID)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = utils.CompareGolden(utils.GoldenDir, utils.TemplateTypeK3sBaseline, snapshot)
+	if errors.Is(err, utils.ErrGoldenMissing) {
+		fmt.Printf("Skipping snapshot comparison: %v\n", err)
+		return
+	}
+	Expect(err).NotTo(HaveOccurred())
+}
+
+// validateEdgeNodePlatform checks that the cluster was created on the OS and architecture of EDGE_NODE_OS and
+// EDGE_NODE_ARCH, so a combination of the edge node matrix is not reported on the wrong image
+func validateEdgeNodePlatform() {
+	By("Checking the OS and architecture of the edge node")
+	platform, err := utils.DetectEdgeNodePlatform()
+	Expect(err).NotTo(HaveOccurred())
+	fmt.Printf("Edge node platform: %s\n", platform)
+
+	expectedOS, expectedArch := utils.ExpectedEdgeNodePlatform()
+	Expect(platform.Matches(expectedOS, expectedArch)).To(BeTrue(),
+		"the edge node runs %s, expected %s=%q and %s=%q", platform,
+		utils.EdgeNodeOSEnvVar, expectedOS, utils.EdgeNodeArchEnvVar, expectedArch)
+}
+
+// validateTestWorkload deploys the nginx test workload to the downstream cluster and requests it through
+// its service from within the cluster, so a cluster whose system pods run but cannot serve workloads is noticed
+func validateTestWorkload() {
+	By("Deploying nginx with its service to the downstream cluster")
+	workload, err := utils.DeployTestWorkload(KubeconfigFileName, utils.NginxTestWorkload())
+	if workload != nil {
+		defer func() {
+			if err := workload.Delete(); err != nil {
+				fmt.Printf("Failed to delete the nginx workload: %v\n", err)
+			}
+		}()
+	}
+	Expect(err).NotTo(HaveOccurred())
+
+	By("Requesting nginx through its service from within the cluster")
+	Eventually(func() error {
+		return utils.ValidateWorkload(workload)
+	}, TestWorkloadTimeout, TestWorkloadInterval).Should(Succeed())
+}
+
+// validateNetworkPolicyEnforcement applies a deny-all NetworkPolicy and then a selective allow to the
+// downstream cluster, so a CNI of the baseline template that ignores policies is noticed
+func validateNetworkPolicyEnforcement() {
+	By("Verifying NetworkPolicy enforcement on the downstream cluster")
+	Expect(utils.CreateNetworkPolicyWorkloads(KubeconfigFileName)).To(Succeed())
+	defer func() {
+		if err := utils.DeleteNetworkPolicyWorkloads(KubeconfigFileName); err != nil {
+			fmt.Printf("Failed to delete the network policy workloads: %v\n", err)
+		}
+	}()
+
+	checkReachability := func(description string, allowedReachable, deniedReachable bool) {
+		By(description)
+		Eventually(func() ([]string, error) {
+			return utils.NetworkPolicyViolations(KubeconfigFileName, []utils.NetworkPolicyExpectation{
+				{Client: utils.NetworkPolicyAllowedClient, Reachable: allowedReachable},
+				{Client: utils.NetworkPolicyDeniedClient, Reachable: deniedReachable},
+			})
+		}, NetworkPolicyTimeout, NetworkPolicyInterval).Should(BeEmpty())
+	}
+
+	// Without policies both clients must get through, or a blocked client would prove nothing.
+	checkReachability("Checking that both clients reach the server without policies", true, true)
+
+	Expect(utils.ApplyNetworkPolicy(KubeconfigFileName, utils.NetworkPolicyDenyAll)).To(Succeed())
+	checkReachability("Checking that the deny-all policy blocks both clients", false, false)
+
+	Expect(utils.ApplyNetworkPolicy(KubeconfigFileName, utils.NetworkPolicyAllowClient)).To(Succeed())
+	checkReachability("Checking that the allow policy lets only the selected client through", true, false)
+}
+
+// validateClusterLookupByNode resolves the cluster from the UID of its node the way the edge-node agent
+// does, and checks that unknown nodes and nodes of another project are not resolved
+func validateClusterLookupByNode(authContext *auth.TestAuthContext, namespace string) {
+	By("Resolving the cluster details by node ID")
+	nodeUIDs, err := utils.ListClusterNodeUIDs(namespace, utils.ClusterName)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(nodeUIDs).NotTo(BeEmpty(), "the Machines of the cluster should reference their Node")
+	for _, nodeUID := range nodeUIDs {
+		cluster, err := utils.GetClusterInfoByNodeID(authContext, namespace, nodeUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.Name).To(HaveValue(Equal(utils.ClusterName)), "node %s", nodeUID)
+	}
+
+	By("Looking up the cluster of an unknown node ID")
+	_, err = utils.GetClusterInfoByNodeID(authContext, namespace, utils.NewProjectID())
+	Expect(err).To(utils.HaveAPIStatus(http.StatusNotFound))
+
+	// A fresh project has no Machines, and with authentication the token is only valid for the project of
+	// the cluster.
+	By("Looking up the node ID in another project")
+	_, err = utils.GetClusterInfoByNodeID(authContext, utils.NewProjectID(), nodeUIDs[0])
+	Expect(err).To(Or(utils.HaveAPIStatus(http.StatusForbidden), utils.HaveAPIStatus(http.StatusNotFound)))
+}
+
+// validateCISLiteHardening runs the optional CIS-lite probes against the downstream cluster
+func validateCISLiteHardening() {
+	By("Running CIS-lite hardening checks against the downstream cluster")
+	results, err := utils.RunCISLiteChecks(KubeconfigFileName)
+	Expect(err).NotTo(HaveOccurred())
+
+	for _, r := range results {
+		status := "PASS"
+		if r.Skipped {
+			status = "SKIP"
+		} else if !r.Passed {
+			status = "FAIL"
+		}
+		fmt.Printf("  [%s] CIS %s %s (%s)\n", status, r.ID, r.Description, r.Detail)
+	}
+	Expect(utils.FailedCISChecks(results)).To(BeEmpty(), "downstream cluster should pass the CIS-lite checks")
+}
+
+// scanDownstreamImages runs the optional trivy scan of the images running on the downstream cluster
+func scanDownstreamImages() {
+	By("Scanning the images running on the downstream cluster for vulnerabilities")
+	report, err := utils.ScanDownstreamImages(KubeconfigFileName)
+	Expect(err).NotTo(HaveOccurred())
+	report.Print()
+	path, err := report.WriteReport()
+	Expect(err).NotTo(HaveOccurred())
+	fmt.Printf("Image scan reports written to %s\n", filepath.Dir(path))
+
+	Expect(report.Unscanned()).To(BeEmpty(), "every downstream image should be scanned")
+	if utils.ImageScanFailOnCritical() {
+		Expect(report.CriticalFindings()).To(BeEmpty(), "downstream images should have no critical vulnerabilities")
+	}
 }
 
 var _ = Describe("Single Node K3s Cluster Create and Delete using Cluster Manager APIs with baseline template",
 	Ordered, Label(utils.ClusterOrchClusterApiSmokeTest, utils.ClusterOrchClusterApiAllTest), func() {
 		var (
 			authContext            *auth.TestAuthContext
-			gatewayPortForward     *exec.Cmd
 			namespace              string
 			nodeGUID               string
-			portForwardCmd         *exec.Cmd
 			clusterCreateStartTime time.Time
+			phaseTracker           *utils.PhaseTracker
+			edgeNodeSampler        *utils.EdgeNodeSampler
 			authDisabled           bool
 		)
 
-		BeforeEach(func() {
+		// The specs of the container share one cluster instead of creating one each.
+		sharedCluster := utils.NewSharedCluster(utils.ClusterName, func() error {
+			return performClusterOperation("create", authDisabled, authContext, namespace, nodeGUID, utils.K3sTemplateName)
+		}, func() error {
+			if err := performClusterOperation("delete", authDisabled, authContext, namespace, "", ""); err != nil {
+				return err
+			}
+			By("Verifying that the cluster is deleted")
+			return wait.WaitForClusterDeleted(namespace, utils.ClusterName)
+		})
+
+		BeforeAll(func() {
 			namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
 			nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
 
-			authDisabled = os.Getenv("DISABLE_AUTH") == "true"
+			authDisabled = utils.AuthDisabled()
 
 			if !authDisabled {
 				By("Setting up JWT authentication")
@@ -387,7 +511,7 @@ var _ = Describe("Single Node K3s Cluster Create and Delete using Cluster Manage
 				Expect(authContext.Token).NotTo(BeEmpty())
 			} else {
 				By("Authentication disabled - skipping JWT setup")
-				fmt.Printf("  Authentication disabled (DISABLE_AUTH=true)\n")
+				fmt.Printf("  Authentication disabled in cluster-manager\n")
 			}
 
 			By("Ensuring the namespace exists")
@@ -395,81 +519,68 @@ var _ = Describe("Single Node K3s Cluster Create and Delete using Cluster Manage
 			err = utils.EnsureNamespaceExists(namespace)
 			Expect(err).NotTo(HaveOccurred())
 
-			portForwardCmd, err = setupPortForwarding("cluster manager", utils.PortForwardService,
-				utils.PortForwardLocalPort, utils.PortForwardRemotePort)
-			Expect(err).NotTo(HaveOccurred())
+			phaseTracker = utils.NewPhaseTracker(namespace, utils.ClusterName)
 
 			err = performClusterOperation("import", authDisabled, authContext, namespace, "", utils.TemplateTypeK3sBaseline)
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Waiting for the cluster template to be ready")
-			Eventually(func() bool {
-				return utils.IsClusterTemplateReady(namespace, utils.K3sTemplateName)
-			}, 2*time.Minute, 2*time.Second).Should(BeTrue())
+			Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+			phaseTracker.MarkNow(utils.PhaseTemplateReady)
 
 			clusterCreateStartTime = time.Now()
 
-			err = performClusterOperation("create", authDisabled, authContext, namespace, nodeGUID, utils.K3sTemplateName)
-			Expect(err).NotTo(HaveOccurred())
-
-			gatewayPortForward, err = setupPortForwarding("cluster gateway", utils.PortForwardGatewayService,
-				utils.PortForwardGatewayLocalPort, utils.PortForwardGatewayRemotePort)
-			Expect(err).NotTo(HaveOccurred())
-		})
+			edgeNodeSampler = nil
+			if utils.EdgeNodeMetricsEnabled() {
+				By("Sampling the resource usage of the edge node")
+				edgeNodeSampler = utils.NewEdgeNodeSampler(utils.ClusterName)
+				edgeNodeSampler.Start(utils.EdgeNodeMetricsInterval())
+			}
 
-		AfterEach(func() {
-			defer cleanupPortForwarding(portForwardCmd, gatewayPortForward)
+			Expect(sharedCluster.Acquire()).To(Succeed())
 
-			if !utils.SkipDeleteCluster {
-				var err error
-				err = performClusterOperation("delete", authDisabled, authContext, namespace, "", "")
-				Expect(err).NotTo(HaveOccurred())
+			phaseTracker.Start(ClusterReadinessInterval)
+		})
 
-				By("Verifying that the cluster is deleted")
-				Eventually(func() bool {
-					cmd := exec.Command("kubectl", "-n", namespace, "get", "cluster", utils.ClusterName)
-					err := cmd.Run()
-					return err != nil
-				}, PortForwardTimeout, PortForwardInterval).Should(BeTrue())
-			}
+		AfterAll(func() {
+			phaseTracker.Stop()
+			edgeNodeSampler.StopAndReport()
+			sharedCluster.Release()
 		})
 
 		It("should verify that the cluster is fully active", func() {
-			waitForClusterReady(namespace, clusterCreateStartTime)
+			waitForClusterReady(namespace, clusterCreateStartTime, phaseTracker)
+			validateEdgeNodePlatform()
 			validateKubeconfigAndClusterAccess()
+			validateDownstreamCertificate()
+			validateRenderedObjectsSnapshot(namespace, nodeGUID)
+			validateNetworkPolicyEnforcement()
+			validateClusterLookupByNode(authContext, namespace)
+
+			if utils.CISLiteChecksEnabled() {
+				validateCISLiteHardening()
+			}
+
+			if utils.ImageScanEnabled() {
+				scanDownstreamImages()
+			}
 
 			if !authDisabled {
 				validateJWTWorkflow(authContext, namespace)
 			} else {
 				By("Authentication disabled - skipping JWT-specific tests")
-				fmt.Printf("  DISABLE_AUTH=true - JWT kubeconfig API test skipped\n")
+				fmt.Printf("  Authentication disabled - JWT kubeconfig API test skipped\n")
 			}
 		})
 
+		It("should serve an nginx workload deployed through the connect gateway", func() {
+			validateTestWorkload()
+		})
+
 		It("should verify that a cluster template cannot be deleted if there is a cluster using it", func() {
 			By("Trying to delete the cluster template")
 			err := utils.DeleteTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("in use"))
-		})
-
-		JustAfterEach(func() {
-			if CurrentSpecReport().Failed() {
-				// Provider-agnostic diagnostics: use the downstream kubeconfig (via connect-gateway)
-				// rather than exec'ing into an edge node implementation detail.
-				if _, statErr := os.Stat(KubeconfigFileName); statErr == nil {
-					if out, err := exec.Command("kubectl", "--kubeconfig", KubeconfigFileName, "get", "pods", "-A", "-o", "wide").CombinedOutput(); err == nil {
-						fmt.Printf("Downstream pods snapshot:\n%s\n", string(out))
-					}
-					if out, err := exec.Command("kubectl", "--kubeconfig", KubeconfigFileName, "get", "pods", "-A").CombinedOutput(); err == nil {
-						// Quick visibility for connect-agent without assuming a fixed pod name/namespace.
-						for _, line := range strings.Split(string(out), "\n") {
-							if strings.Contains(line, "connect-agent") {
-								fmt.Printf("connect-agent pod line: %s\n", line)
-							}
-						}
-					}
-				}
-			}
+			Expect(err).To(utils.HaveAPIErrorCode(utils.APIErrorConflict), "a template in use should be a conflict")
 		})
 	})
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_api_test_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+// MultiNodeClusterName keeps the multi-node cluster apart from the shared single-node cluster.
+const MultiNodeClusterName = utils.ClusterName + "-multi-node"
+
+var _ = Describe("Three Node K3s Cluster Create and Delete using Cluster Manager APIs with baseline template",
+	Ordered, Label(utils.ClusterOrchClusterApiAllTest), utils.RequiresCapabilities(utils.CapabilityMultiNode), func() {
+		var (
+			authContext *auth.TestAuthContext
+			namespace   string
+		)
+
+		BeforeAll(func() {
+			namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+			nodes, err := utils.MultiNodeClusterNodes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(HaveLen(utils.MultiNodeClusterSize))
+
+			if !utils.AuthDisabled() {
+				By("Setting up JWT authentication")
+				authContext, err = utils.SetupTestAuthentication("test-user")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("Ensuring the namespace exists")
+			Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+			By("Importing the cluster template")
+			if authContext != nil {
+				Expect(utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+			} else {
+				Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+			}
+			Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+			By(fmt.Sprintf("Creating the k3s cluster %s with %d nodes", MultiNodeClusterName, len(nodes)))
+			Expect(utils.CreateMultiNodeCluster(authContext, namespace, MultiNodeClusterName, nodes,
+				utils.K3sTemplateName, utils.ClusterConfigOptions{})).To(Succeed())
+			DeferCleanup(func() {
+				By("Deleting the multi-node cluster")
+				Expect(utils.DeleteNamedClusterAuthenticated(authContext, namespace, MultiNodeClusterName)).To(Succeed())
+				Expect(wait.WaitForClusterDeleted(namespace, MultiNodeClusterName)).To(Succeed())
+			})
+		})
+
+		It("should bring every IntelMachine of the cluster to Ready", func() {
+			Expect(wait.WaitForIntelMachinesReady(namespace, MultiNodeClusterName, utils.MultiNodeClusterSize)).To(Succeed())
+			Expect(wait.WaitForClusterReady(namespace, MultiNodeClusterName)).To(Succeed())
+		})
+	})
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_api_test_test
+
+import (
+	"fmt"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+// NodeScalingClusterName keeps the cluster nodes are added to and removed from apart from the other clusters.
+const NodeScalingClusterName = utils.ClusterName + "-node-scaling"
+
+var _ = Describe("K3s Cluster Node Add and Remove using Cluster Manager APIs with baseline template",
+	Ordered, Label(utils.ClusterOrchClusterApiAllTest), utils.RequiresCapabilities(utils.CapabilityMultiNode), func() {
+		var (
+			authContext  *auth.TestAuthContext
+			namespace    string
+			firstNode    api.NodeSpec
+			addedNode    api.NodeSpec
+			addedMachine string
+		)
+
+		BeforeAll(func() {
+			namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+			nodes, err := utils.MultiNodeClusterNodes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(nodes)).To(BeNumerically(">=", 2), "%s must list at least two edge nodes", utils.MultiNodeGUIDsEnvVar)
+			firstNode = api.NodeSpec{Id: nodes[0].Id, Role: api.All}
+			addedNode = api.NodeSpec{Id: nodes[1].Id, Role: api.Worker}
+
+			if !utils.AuthDisabled() {
+				By("Setting up JWT authentication")
+				authContext, err = utils.SetupTestAuthentication("test-user")
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			By("Ensuring the namespace exists")
+			Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+			By("Importing the cluster template")
+			if authContext != nil {
+				Expect(utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+			} else {
+				Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+			}
+			Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+			By(fmt.Sprintf("Creating the k3s cluster %s on node %s", NodeScalingClusterName, firstNode.Id))
+			Expect(utils.CreateMultiNodeCluster(authContext, namespace, NodeScalingClusterName, []api.NodeSpec{firstNode},
+				utils.K3sTemplateName, utils.ClusterConfigOptions{})).To(Succeed())
+			DeferCleanup(func() {
+				By("Deleting the node scaling cluster")
+				Expect(utils.DeleteNamedClusterAuthenticated(authContext, namespace, NodeScalingClusterName)).To(Succeed())
+				Expect(wait.WaitForClusterDeleted(namespace, NodeScalingClusterName)).To(Succeed())
+			})
+			Expect(wait.WaitForIntelMachinesReady(namespace, NodeScalingClusterName, 1)).To(Succeed())
+			Expect(wait.WaitForClusterReady(namespace, NodeScalingClusterName)).To(Succeed())
+		})
+
+		It("should add a node through the nodes API and bring its IntelMachine to Ready", func() {
+			machines, err := utils.ClusterMachineNames(namespace, NodeScalingClusterName)
+			Expect(err).NotTo(HaveOccurred())
+
+			By(fmt.Sprintf("Adding node %s to the cluster as a %s", addedNode.Id, addedNode.Role))
+			Expect(utils.AddNodeToCluster(authContext, namespace, NodeScalingClusterName, addedNode)).To(Succeed())
+			Expect(wait.WaitForIntelMachinesReady(namespace, NodeScalingClusterName, 2)).To(Succeed())
+			Expect(wait.WaitForClusterReady(namespace, NodeScalingClusterName)).To(Succeed())
+
+			nodes, err := utils.GetClusterNodes(authContext, namespace, NodeScalingClusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(ContainElement(HaveField("Id", addedNode.Id)))
+
+			after, err := utils.ClusterMachineNames(namespace, NodeScalingClusterName)
+			Expect(err).NotTo(HaveOccurred())
+			added := slices.DeleteFunc(after, func(machine string) bool { return slices.Contains(machines, machine) })
+			Expect(added).To(HaveLen(1), "expected one new Machine, had %v", machines)
+			addedMachine = added[0]
+		})
+
+		It("should drain and delete the Machine of a removed node while the cluster stays Ready", func() {
+			Expect(addedMachine).NotTo(BeEmpty(), "the node should have been added")
+
+			By(fmt.Sprintf("Removing node %s from the cluster", addedNode.Id))
+			Expect(utils.DeleteNode(authContext, namespace, NodeScalingClusterName, addedNode.Id)).To(Succeed())
+			Expect(wait.WaitForMachinesGone(namespace, NodeScalingClusterName, addedMachine)).To(Succeed())
+			Expect(wait.WaitForIntelMachinesReady(namespace, NodeScalingClusterName, 1)).To(Succeed())
+			Expect(wait.WaitForClusterReady(namespace, NodeScalingClusterName)).To(Succeed())
+
+			nodes, err := utils.GetClusterNodes(authContext, namespace, NodeScalingClusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(ConsistOf(HaveField("Id", firstNode.Id)))
+		})
+	})
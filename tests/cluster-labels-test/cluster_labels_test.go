@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package cluster_labels_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	labelsClusterName = "labels-cluster"
+
+	// clusterObjectTimeout leaves cluster-manager time to create or update the Cluster object.
+	clusterObjectTimeout  = time.Minute
+	clusterObjectInterval = 2 * time.Second
+)
+
+func TestClusterLabelsTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch cluster labels tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch cluster labels test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+// withSystemLabels returns labels with the system labels cluster-manager sets on every cluster.
+func withSystemLabels(labels map[string]string, namespace string) map[string]string {
+	merged := map[string]string{
+		utils.ClusterNameLabel: labelsClusterName,
+		utils.ProjectIDLabel:   namespace,
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	return merged
+}
+
+var _ = Describe("Cluster label propagation", Ordered, Label(utils.ClusterOrchClusterLabelsTest), func() {
+	var (
+		namespace      string
+		project        *utils.TenantProject
+		authContext    *auth.TestAuthContext
+		tenancyCmd     *utils.PortForwarder
+		clusterCreated bool
+		initialLabels  map[string]string
+	)
+
+	BeforeAll(func() {
+		if !utils.InventoryDisabled() {
+			Skip("the cluster of this suite has no host record; run cluster-manager without inventory")
+		}
+
+		// A throwaway project, so the labelled cluster goes away with it whatever happens.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "labels-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("labels-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Importing the cluster template k3s baseline")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+		if namespace == "" {
+			return
+		}
+
+		if clusterCreated {
+			By("Deleting the cluster")
+			status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodDelete,
+				fmt.Sprintf("%s/%s", utils.ClusterCreateURL, labelsClusterName), nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Or(Equal(http.StatusNoContent), Equal(http.StatusNotFound)), body)
+		}
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	It("should report the labels a cluster is created with, without the system labels", func() {
+		opts := utils.ClusterConfigOptions{Labels: map[string]string{
+			"env":                            "ci",
+			utils.LimitLabelPrefix + "/team": "co",
+		}}
+		data, err := utils.RenderClusterConfig(labelsClusterName, utils.NewProjectID(), utils.K3sTemplateName, opts)
+		Expect(err).NotTo(HaveOccurred())
+		status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, utils.ClusterCreateURL, data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusCreated), body)
+		clusterCreated = true
+
+		initialLabels = map[string]string{}
+		for key, value := range utils.DefaultClusterLabels {
+			initialLabels[key] = value
+		}
+		for key, value := range opts.Labels {
+			initialLabels[key] = value
+		}
+
+		Eventually(func() (map[string]string, error) {
+			return utils.GetClusterLabels(authContext, namespace, labelsClusterName)
+		}, clusterObjectTimeout, clusterObjectInterval).Should(Equal(initialLabels))
+	})
+
+	It("should set the labels on the Cluster object along with the system labels", func() {
+		Expect(initialLabels).NotTo(BeEmpty(), "the cluster was not created")
+		Eventually(func() ([]string, error) {
+			labels, err := utils.GetClusterCRLabels(namespace, labelsClusterName)
+			return utils.LabelDifferences(labels, withSystemLabels(initialLabels, namespace)), err
+		}, clusterObjectTimeout, clusterObjectInterval).Should(BeEmpty())
+	})
+
+	It("should replace the user labels on update and keep the system labels", func() {
+		Expect(initialLabels).NotTo(BeEmpty(), "the cluster was not created")
+		updated := map[string]string{"env": "staging", "zone": "a"}
+		Expect(utils.UpdateClusterLabels(authContext, namespace, labelsClusterName, updated)).To(Succeed())
+
+		By("Reading the labels back from the API")
+		labels, err := utils.GetClusterLabels(authContext, namespace, labelsClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(Equal(updated))
+
+		By("Checking the labels of the Cluster object")
+		var removed []string
+		for key := range initialLabels {
+			if _, ok := updated[key]; !ok {
+				removed = append(removed, key)
+			}
+		}
+		Eventually(func() ([]string, error) {
+			labels, err := utils.GetClusterCRLabels(namespace, labelsClusterName)
+			return utils.LabelDifferences(labels, withSystemLabels(updated, namespace), removed...), err
+		}, clusterObjectTimeout, clusterObjectInterval).Should(BeEmpty())
+	})
+
+	It("should remove every user label with an empty update", func() {
+		Expect(initialLabels).NotTo(BeEmpty(), "the cluster was not created")
+		Expect(utils.UpdateClusterLabels(authContext, namespace, labelsClusterName, map[string]string{})).To(Succeed())
+
+		labels, err := utils.GetClusterLabels(authContext, namespace, labelsClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(labels).To(BeEmpty())
+
+		Eventually(func() (map[string]string, error) {
+			labels, err := utils.GetClusterCRLabels(namespace, labelsClusterName)
+			return utils.UserLabels(labels), err
+		}, clusterObjectTimeout, clusterObjectInterval).Should(BeEmpty())
+		crLabels, err := utils.GetClusterCRLabels(namespace, labelsClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utils.LabelDifferences(crLabels, withSystemLabels(nil, namespace))).To(BeEmpty(),
+			"the system labels should survive the update")
+	})
+})
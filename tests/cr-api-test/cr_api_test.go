@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package cr_api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	crClusterName = "demo-cluster-cr"
+
+	clusterReadinessTimeout = 10 * time.Minute
+	clusterDeletionTimeout  = 5 * time.Minute
+	clusterDeletionInterval = 5 * time.Second
+)
+
+func TestCRApiTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch CR api tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch CR api test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+func waitForClusterReady(namespace, clusterName string) {
+	By(fmt.Sprintf("Waiting for all components of %s to be ready", clusterName))
+	ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+	defer cancel()
+	Expect(wait.ClusterReady(ctx, namespace, clusterName)).To(Succeed())
+}
+
+func waitForIntelMachineBindingGone(client *utils.KubeClient, namespace, name string) {
+	Eventually(func() (bool, error) {
+		return client.IntelMachineBindingExists(namespace, name)
+	}, clusterDeletionTimeout, clusterDeletionInterval).Should(BeFalse(), "IntelMachineBinding %s/%s should be deleted", namespace, name)
+}
+
+var _ = Describe("Cluster creation through ClusterClass and Cluster CRs", Ordered, Label(utils.ClusterOrchCRApiTest), func() {
+	var (
+		namespace   string
+		nodeGUID    string
+		apiSnapshot *utils.ClusterSpecSnapshot
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Importing the cluster template that provides the ClusterClass")
+		err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		if !utils.SkipDeleteCluster {
+			By("Deleting any cluster left behind by a failed spec")
+			for _, name := range []string{utils.ClusterName, crClusterName} {
+				if utils.ManagedClusterExists("", namespace, name) {
+					Expect(utils.DeleteClusterCR(namespace, name)).To(Succeed())
+					Expect(wait.WaitForClusterDeleted(namespace, name)).To(Succeed())
+				}
+			}
+		}
+	})
+
+	It("should record the spec of a cluster created through the cluster-manager API", func() {
+		By("Creating the cluster through the cluster-manager API")
+		err := utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+
+		apiSnapshot, err = utils.GetClusterSpecSnapshot(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("API-created cluster spec: %+v\n", *apiSnapshot)
+
+		// Let provisioning settle so the node is released cleanly before it is reused.
+		waitForClusterReady(namespace, utils.ClusterName)
+
+		By("Deleting the API-created cluster")
+		Expect(utils.DeleteCluster(namespace)).To(Succeed())
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should create a cluster from the ClusterClass and Cluster CRs", func() {
+		Expect(apiSnapshot).NotTo(BeNil(), "API-created cluster spec should be recorded")
+
+		By("Applying the Cluster and IntelMachineBinding CRs")
+		err := utils.CreateClusterFromCR(namespace, crClusterName, nodeGUID, utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+
+		waitForClusterReady(namespace, crClusterName)
+	})
+
+	It("should produce the same cluster spec as the cluster-manager API", func() {
+		crSnapshot, err := utils.GetClusterSpecSnapshot(namespace, crClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("CR-created cluster spec: %+v\n", *crSnapshot)
+
+		Expect(crSnapshot.Class).To(Equal(apiSnapshot.Class))
+		Expect(crSnapshot.Version).To(Equal(apiSnapshot.Version))
+		Expect(crSnapshot.PodCIDRs).To(Equal(apiSnapshot.PodCIDRs))
+		Expect(crSnapshot.ServiceCIDRs).To(Equal(apiSnapshot.ServiceCIDRs))
+		Expect(crSnapshot.ControlPlaneReplicas).To(Equal(apiSnapshot.ControlPlaneReplicas))
+		Expect(crSnapshot.Template).To(Equal(apiSnapshot.Template))
+		Expect(crSnapshot.Labels).To(Equal(apiSnapshot.Labels))
+	})
+
+	It("should expose the CR-created cluster through the cluster-manager API", func() {
+		resp, err := utils.GetClusterInfo(namespace, crClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should clean up the cluster when the Cluster CR is deleted", func() {
+		if utils.SkipDeleteCluster {
+			Skip("SKIP_DELETE_CLUSTER=true")
+		}
+
+		By("Deleting the Cluster CR")
+		Expect(utils.DeleteClusterCR(namespace, crClusterName)).To(Succeed())
+		Expect(wait.WaitForClusterDeleted(namespace, crClusterName)).To(Succeed())
+
+		By("Verifying dependent CRs are garbage collected")
+		client, err := utils.ManagementKubeClient()
+		Expect(err).NotTo(HaveOccurred())
+		waitForIntelMachineBindingGone(client, namespace, crClusterName+"-"+nodeGUID)
+		Eventually(func() ([]unstructured.Unstructured, error) {
+			return client.ListIntelMachines(namespace, crClusterName)
+		}, clusterDeletionTimeout, clusterDeletionInterval).Should(BeEmpty())
+	})
+
+	It("should delete the template and its CAPI objects once no cluster uses it", func() {
+		if utils.SkipDeleteCluster {
+			Skip("SKIP_DELETE_CLUSTER=true")
+		}
+
+		By("Recording the objects created for the template")
+		objects, err := utils.ListClusterTemplateObjects(namespace, utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("Template objects: %v\n", objects)
+
+		By("Deleting the template through the cluster-manager API")
+		Expect(utils.DeleteTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)).To(Succeed())
+
+		By("Verifying no object of the template is left behind")
+		Eventually(func() ([]utils.TemplateObject, error) {
+			return utils.RemainingObjects(namespace, objects)
+		}, clusterDeletionTimeout, clusterDeletionInterval).Should(BeEmpty())
+
+		_, err = utils.GetClusterTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)
+		Expect(err).To(HaveOccurred(), "the deleted template should not be served anymore")
+	})
+
+	It("should re-import the deleted template name and version cleanly", func() {
+		if utils.SkipDeleteCluster {
+			Skip("SKIP_DELETE_CLUSTER=true")
+		}
+
+		By("Importing the template again")
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		By("Verifying the ClusterClass belongs to the new template")
+		templateUID, _, err := utils.ObjectUID(namespace, utils.TemplateObject{Resource: utils.ClusterTemplateResource, Name: utils.K3sTemplateName})
+		Expect(err).NotTo(HaveOccurred())
+		_, ownerUID, err := utils.ObjectUID(namespace, utils.TemplateObject{Resource: utils.ClusterClassResource, Name: utils.K3sTemplateName})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ownerUID).To(Equal(templateUID), "the ClusterClass should be recreated for the new template, not left over from the old one")
+
+		template, err := utils.GetClusterTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(template.Name + "-" + template.Version).To(Equal(utils.K3sTemplateName))
+	})
+})
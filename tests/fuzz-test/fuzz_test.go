@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package fuzz_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	fuzzClusterName = "fuzz-cluster"
+
+	// maxReportedBody keeps echoed 64KiB values out of the failure message.
+	maxReportedBody = 300
+)
+
+func TestFuzzTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch fuzz tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch fuzz test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+func truncateBody(body string) string {
+	if len(body) > maxReportedBody {
+		return body[:maxReportedBody] + "..."
+	}
+	return body
+}
+
+var _ = Describe("Cluster manager request payload fuzzing", Ordered, Label(utils.ClusterOrchFuzzTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+	)
+
+	// sendPayloads posts every payload to url and fails with all the responses that are server errors or
+	// expose internals, rather than stopping at the first one.
+	sendPayloads := func(url string, payloads []utils.FuzzPayload) {
+		var failures []string
+		for _, payload := range payloads {
+			status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, url, payload.Body)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", payload.Name, err))
+				continue
+			}
+			fmt.Printf("  %-45s HTTP %d\n", payload.Name, status)
+			if status >= http.StatusInternalServerError {
+				failures = append(failures, fmt.Sprintf("%s: HTTP %d: %s", payload.Name, status, truncateBody(body)))
+			}
+			if leaks := utils.LeakedInternals(body); len(leaks) > 0 {
+				failures = append(failures, fmt.Sprintf("%s: HTTP %d exposes %q: %s", payload.Name, status, leaks, truncateBody(body)))
+			}
+		}
+		Expect(failures).To(BeEmpty(), "%d of %d payloads were not handled cleanly", len(failures), len(payloads))
+	}
+
+	BeforeAll(func() {
+		// A throwaway project, so whatever the fuzzed requests create goes away with it.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "fuzz-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("fuzz-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Importing the cluster template k3s baseline the fuzzed clusters refer to")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	It("should answer fuzzed template imports without server errors or leaked internals", func() {
+		payloads, err := utils.TemplateFuzzPayloads()
+		Expect(err).NotTo(HaveOccurred())
+		By(fmt.Sprintf("Importing %d fuzzed templates", len(payloads)))
+		sendPayloads(utils.ClusterTemplateURL, payloads)
+	})
+
+	It("should answer fuzzed cluster creations without server errors or leaked internals", func() {
+		payloads, err := utils.ClusterFuzzPayloads(fuzzClusterName, utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+		By(fmt.Sprintf("Creating %d fuzzed clusters", len(payloads)))
+		sendPayloads(utils.ClusterCreateURL, payloads)
+	})
+
+	It("should keep serving valid requests after the fuzzing", func() {
+		status, err := utils.ClusterTemplatesStatus(authContext, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusOK))
+	})
+})
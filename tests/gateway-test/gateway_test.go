@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// clusterReadinessTimeout covers the vEN installing k3s and pulling images.
+	clusterReadinessTimeout  = 10 * time.Minute
+	clusterReadinessInterval = 10 * time.Second
+
+	// streamTimeout bounds how long a streaming request may take to deliver through the gateway.
+	streamTimeout    = 30 * time.Second
+	streamedLogLines = 5
+
+	// largeResponseTimeout bounds the single request that lists the large-response ConfigMaps.
+	largeResponseTimeout = 2 * time.Minute
+
+	// watchHeartbeatInterval is longer than the 60s idle timeout common to proxies.
+	watchHeartbeatInterval = 75 * time.Second
+	watchTimeoutMargin     = 2 * time.Minute
+	resumedHeartbeats      = 3
+
+	// The connect agent resource limit spec runs agentLimitSessions sessions for agentLimitLoadDuration per
+	// limit, and the agent has to be reachable again within agentReconnectTimeout after a change or an OOM kill.
+	agentLimitSessions     = 5
+	agentLimitLoadDuration = time.Minute
+	agentReconnectTimeout  = 3 * time.Minute
+
+	// maxGatewayErrorRate is the share of failed requests the gateway may have under load.
+	maxGatewayErrorRate = 0.01
+
+	downstreamRequestTimeout = 30 * time.Second
+)
+
+func TestGatewayTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch gateway tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch gateway test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+var _ = Describe("Downstream access through the connect gateway", Ordered, Label(utils.ClusterOrchGatewayTest), func() {
+	var (
+		namespace      string
+		kubeconfigPath string
+		downstream     *utils.KubeClient
+		execTarget     utils.GatewayLoadTarget
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID := utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+		kubeconfigPath = filepath.Join(GinkgoT().TempDir(), "kubeconfig.yaml")
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Importing the cluster template k3s baseline")
+		err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		By("Creating the cluster")
+		err = utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+		By("Writing the kubeconfig that goes through the gateway")
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigPath)).To(Succeed())
+		downstream, err = utils.NewKubeClient(kubeconfigPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for a pod to exec into")
+		Eventually(func() (string, error) {
+			pod, err := utils.LocalPathProvisionerPod(downstream)
+			execTarget = utils.GatewayLoadTarget{Namespace: "kube-system", Pod: pod}
+			return pod, err
+		}, clusterReadinessTimeout, clusterReadinessInterval).ShouldNot(BeEmpty())
+	})
+
+	AfterAll(func() {
+		if namespace == "" || utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		Expect(utils.DeleteCluster(namespace)).To(Succeed())
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	// logs -f, exec with stdin and port-forward each use their own streaming protocol through the gateway,
+	// so one of them can break while a plain list or a one-shot exec still works.
+	Context("streaming requests", func() {
+		BeforeAll(func() {
+			By("Creating a pod that logs, reads stdin and serves HTTP")
+			Expect(utils.CreateStreamPod(kubeconfigPath)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			Expect(utils.DeleteStreamPod(kubeconfigPath)).To(Succeed())
+		})
+
+		It("should stream logs in follow mode", func() {
+			lines, err := utils.FollowStreamPodLogs(kubeconfigPath, streamedLogLines, streamTimeout)
+			Expect(err).NotTo(HaveOccurred(), "log lines received so far: %v", lines)
+			fmt.Printf("Followed log lines: %v\n", lines)
+			for _, line := range lines {
+				Expect(line).To(HavePrefix("tick "))
+			}
+		})
+
+		It("should exec with stdin attached", func() {
+			out, err := utils.ExecStreamPodWithStdin(kubeconfigPath, "hello\nfrom stdin\n", `read first; echo "got:$first"; cat`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(ContainSubstring("got:hello"))
+			Expect(out).To(ContainSubstring("from stdin"), "the rest of stdin should have been echoed by cat")
+		})
+
+		It("should port-forward to a downstream pod", func() {
+			forwarder, port, err := utils.StartStreamPodPortForward(kubeconfigPath, streamTimeout)
+			Expect(err).NotTo(HaveOccurred())
+			defer utils.StopPortForwards(forwarder)
+
+			Eventually(func() (string, error) {
+				resp, err := utils.NewHTTPClient().Get("http://127.0.0.1:" + port + "/")
+				if err != nil {
+					return "", err
+				}
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				return string(body), err
+			}, streamTimeout, 2*time.Second).Should(ContainSubstring(utils.StreamPodHTTPBody))
+		})
+	})
+
+	// Responses of tens of MiB are relayed in many chunks, so a buffer-size regression in the gateway or
+	// the agent shows up as a truncated body or a request that never completes.
+	Context("large responses", func() {
+		var configMaps int
+
+		BeforeAll(func() {
+			configMaps = utils.LargeResponseConfigMaps()
+			By(fmt.Sprintf("Creating %d ConfigMaps of 512KiB each", configMaps))
+			Expect(utils.CreateLargeResponseConfigMaps(kubeconfigPath, configMaps)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			Expect(utils.DeleteLargeResponseConfigMaps(kubeconfigPath)).To(Succeed())
+		})
+
+		It("should stream a large list completely", func() {
+			body, elapsed, err := utils.FetchLargeResponse(kubeconfigPath, largeResponseTimeout)
+			Expect(err).NotTo(HaveOccurred())
+			fmt.Printf("\033[32mListed %.1f MiB through the gateway in %v 📦\033[0m\n", float64(len(body))/(1024*1024), elapsed.Round(time.Millisecond))
+			Expect(utils.VerifyLargeResponse(body, configMaps)).To(Succeed())
+		})
+	})
+
+	Context("long-lived watch", func() {
+		var (
+			watch               *utils.DownstreamWatch
+			lastResourceVersion string
+			heartbeat           int
+			lastSeen            int
+		)
+
+		// receive consumes the events delivered so far and returns the last heartbeat seen.
+		receive := func() (int, error) {
+			for {
+				select {
+				case event, ok := <-watch.Events():
+					if !ok {
+						return lastSeen, fmt.Errorf("the watch ended prematurely: %v", watch.Err())
+					}
+					if event.Type == utils.WatchEventError {
+						return lastSeen, fmt.Errorf("the watch failed: %s", event.Message)
+					}
+					if event.ResourceVersion != "" {
+						lastResourceVersion = event.ResourceVersion
+					}
+					if event.Heartbeat > lastSeen {
+						lastSeen = event.Heartbeat
+					}
+				default:
+					return lastSeen, nil
+				}
+			}
+		}
+
+		expectHeartbeat := func() {
+			heartbeat++
+			Expect(utils.SendHeartbeat(kubeconfigPath, heartbeat)).To(Succeed())
+			Eventually(receive, streamTimeout, time.Second).Should(Equal(heartbeat), "heartbeat %d was not delivered by the watch", heartbeat)
+		}
+
+		BeforeAll(func() {
+			Expect(utils.EnsureHeartbeatConfigMap(kubeconfigPath)).To(Succeed())
+		})
+
+		AfterAll(func() {
+			watch.Stop()
+			Expect(utils.DeleteHeartbeatConfigMap(kubeconfigPath)).To(Succeed())
+		})
+
+		It("should keep a watch open for several minutes", func() {
+			duration := utils.GatewayWatchDuration()
+
+			By(fmt.Sprintf("Watching the heartbeat ConfigMap for %v", duration))
+			var err error
+			watch, err = utils.StartHeartbeatWatch(kubeconfigPath, "", duration+watchTimeoutMargin)
+			Expect(err).NotTo(HaveOccurred())
+
+			// The heartbeats are further apart than common proxy idle timeouts, so only the bookmarks
+			// keep the connection busy in between.
+			start := time.Now()
+			for {
+				expectHeartbeat()
+				if time.Since(start)+watchHeartbeatInterval > duration {
+					break
+				}
+				time.Sleep(watchHeartbeatInterval)
+			}
+			Expect(watch.Done()).NotTo(BeClosed(), "the watch ended after %v: %v", time.Since(start), watch.Err())
+			// A reconnect of the agent in between may go unnoticed by the watch, not by the Ready condition.
+			Expect(utils.CheckReadyFlapping(namespace, utils.ClusterName, start)).To(Succeed())
+			fmt.Printf("\033[32mWatch delivered %d heartbeats over %v 👀 ✅\033[0m\n", heartbeat, time.Since(start).Round(time.Second))
+		})
+
+		It("should deliver events again after the gateway restarts", func() {
+			By("Restarting the gateway pods")
+			Expect(utils.RestartGateway()).To(Succeed())
+			restartTime := time.Now()
+			watch.Stop()
+
+			By("Waiting for the port-forward to reconnect to the new gateway pod")
+			Expect(utils.WaitForGatewayReady(utils.ComponentReadyTimeout)).To(Succeed())
+
+			By("Waiting for the connect agent to reconnect")
+			Eventually(func() error {
+				_, err := downstream.GetRaw("/readyz", downstreamRequestTimeout)
+				return err
+			}, clusterReadinessTimeout, clusterReadinessInterval).Should(Succeed())
+			fmt.Printf("\033[32mDownstream API reachable again %v after the gateway restart 🔁\033[0m\n", time.Since(restartTime).Round(time.Second))
+
+			By("Resuming the watch from the last resource version " + lastResourceVersion)
+			var err error
+			watch, err = utils.StartHeartbeatWatch(kubeconfigPath, lastResourceVersion, 2*streamTimeout*resumedHeartbeats)
+			Expect(err).NotTo(HaveOccurred())
+			for i := 0; i < resumedHeartbeats; i++ {
+				expectHeartbeat()
+			}
+		})
+	})
+
+	It("should serve many concurrent sessions without errors", Label(utils.ClusterOrchGatewayPerfTest), func() {
+		sessions, duration := utils.GatewayLoadSessions(), utils.GatewayLoadDuration()
+
+		By(fmt.Sprintf("Running %d concurrent list/watch/exec sessions for %v", sessions, duration))
+		report, err := utils.RunGatewayLoad(kubeconfigPath, execTarget, sessions, duration)
+		Expect(err).NotTo(HaveOccurred())
+		report.Print()
+		if path, err := report.WriteReport(); err != nil {
+			fmt.Printf("Failed to write gateway load report: %v\n", err)
+		} else {
+			fmt.Printf("Gateway load report written to %s\n", path)
+		}
+
+		Expect(report.Results).To(HaveLen(3), "every operation should have been sent")
+		Expect(report.ErrorRate()).To(BeNumerically("<=", maxGatewayErrorRate), "too many failed requests through the gateway")
+		Expect(report.Samples).NotTo(BeEmpty(), "the gateway metrics should have been sampled")
+
+		By("Checking the gateway still serves the cluster after the load")
+		_, err = downstream.ListNodes()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should keep the connect agent connected under tight resource limits", Label(utils.ClusterOrchGatewayPerfTest), func() {
+		agent, err := utils.FindConnectAgentWorkload(kubeconfigPath)
+		Expect(err).NotTo(HaveOccurred())
+		original, err := utils.GetConnectAgentResources(kubeconfigPath, agent)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			By("Restoring the resources of the connect agent to " + original)
+			Expect(utils.RestoreConnectAgentResources(kubeconfigPath, agent, original)).To(Succeed())
+			_, err := utils.WaitForConnectAgentRollout(kubeconfigPath, agent, agentReconnectTimeout)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		report := &utils.AgentLimitsReport{Sessions: agentLimitSessions, Duration: agentLimitLoadDuration}
+		for _, limits := range utils.ConnectAgentLimits() {
+			By(fmt.Sprintf("Limiting %s to %s under %d sessions", agent, limits, agentLimitSessions))
+			result := utils.AgentLimitResult{Limits: limits}
+			err := utils.SetConnectAgentLimits(kubeconfigPath, agent, limits)
+			if err == nil {
+				result.Rollout, err = utils.WaitForConnectAgentRollout(kubeconfigPath, agent, agentReconnectTimeout)
+			}
+			if err == nil {
+				var load *utils.GatewayLoadReport
+				if load, err = utils.RunGatewayLoad(kubeconfigPath, execTarget, agentLimitSessions, agentLimitLoadDuration); err == nil {
+					result.ErrorRate = load.ErrorRate()
+				}
+			}
+			if err == nil {
+				// An agent that was OOM killed under the load has to be back within the same timeout.
+				_, err = utils.WaitForConnectAgentRollout(kubeconfigPath, agent, agentReconnectTimeout)
+			}
+			result.Connected = err == nil
+			if err != nil {
+				result.Error = err.Error()
+			}
+			if pods, err := utils.GetConnectAgentPodStatus(kubeconfigPath, agent); err == nil {
+				result.Pods = pods
+			}
+			report.Add(result)
+			if !result.Connected {
+				// Tighter limits will not do better.
+				break
+			}
+		}
+
+		report.Print()
+		if path, err := report.WriteReport(); err != nil {
+			fmt.Printf("Failed to write connect agent limits report: %v\n", err)
+		} else {
+			fmt.Printf("Connect agent limits report written to %s\n", path)
+		}
+		Expect(report.Results).NotTo(BeEmpty())
+		Expect(report.Results[0].Connected).To(BeTrue(), "the connect agent did not stay connected with limits %s: %s",
+			report.Results[0].Limits, report.Results[0].Error)
+	})
+})
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package helm_values_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// helmValuesConfigEnvVar names the chart configuration under test; set by test:HelmValuesMatrixTest.
+	helmValuesConfigEnvVar = "HELM_VALUES_CONFIG"
+	// expectTokenRejectedEnvVar expects the test tokens to be rejected by the configured OIDC issuer.
+	expectTokenRejectedEnvVar = "HELM_VALUES_EXPECT_TOKEN_REJECTED"
+)
+
+func TestHelmValuesTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting helm values tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "helm values test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("Cluster-manager smoke per chart configuration", Ordered, Label(utils.ClusterOrchHelmValuesTest), func() {
+	var (
+		namespace           string
+		authDisabled        bool
+		expectTokenRejected bool
+		authContext         *auth.TestAuthContext
+		setUp               bool
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		authDisabled = os.Getenv(utils.DisableAuthEnvVar) == "true"
+		expectTokenRejected = os.Getenv(expectTokenRejectedEnvVar) == "true"
+		fmt.Printf("Chart configuration: %s (auth disabled: %t, tokens rejected: %t)\n",
+			utils.GetEnv(helmValuesConfigEnvVar, "as deployed"), authDisabled, expectTokenRejected)
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		if !authDisabled {
+			var err error
+			authContext, err = utils.SetupTestAuthentication("helm-values-user")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp {
+			return
+		}
+		// Deleted as custom resources as the API may not accept any credentials in this configuration.
+		By("Deleting all templates in the namespace")
+		Expect(utils.DeleteAllClusterTemplateCRs(namespace)).To(Succeed())
+	})
+
+	It("should enforce the configured auth mode on unauthenticated requests", func() {
+		status, err := utils.ClusterTemplatesStatus(nil, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		if authDisabled {
+			Expect(status).To(Equal(http.StatusOK))
+		} else {
+			Expect(status).To(Equal(http.StatusUnauthorized))
+		}
+	})
+
+	It("should verify tokens against the configured issuer", func() {
+		if authDisabled {
+			Skip("authentication is disabled in this configuration")
+		}
+		status, err := utils.ClusterTemplatesStatus(authContext, namespace)
+		Expect(err).NotTo(HaveOccurred())
+		if expectTokenRejected {
+			Expect(status).To(Equal(http.StatusUnauthorized), "tokens of another issuer should be rejected")
+		} else {
+			Expect(status).To(Equal(http.StatusOK))
+		}
+	})
+
+	It("should import a template and report it ready", func() {
+		if !authDisabled && expectTokenRejected {
+			Skip("no accepted credentials in this configuration")
+		}
+		if authDisabled {
+			Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		} else {
+			Expect(utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		}
+
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	Context("request bursts", func() {
+		// minRetryAfter is the shortest wait the 429s of the plain bursts asked for, zero if there were none.
+		var minRetryAfter time.Duration
+
+		It("should answer a burst of list requests with results or 429 and Retry-After", func() {
+			if !authDisabled && expectTokenRejected {
+				Skip("no accepted credentials in this configuration")
+			}
+			for _, url := range []string{utils.ClusterTemplateURL, utils.ClusterCreateURL} {
+				By(fmt.Sprintf("Sending %d list requests to %s at once", utils.RateLimitBurst(), url))
+				result := utils.SendListBurst(authContext, namespace, url, utils.RateLimitBurst(), false)
+				fmt.Printf("  %s\n", result)
+
+				Expect(result.Errors).To(BeEmpty())
+				Expect(result.ServerErrors()).To(BeZero(), "a burst should not cause server errors")
+				Expect(result.Statuses[http.StatusOK]+result.Statuses[http.StatusTooManyRequests]).To(Equal(utils.RateLimitBurst()),
+					"every request should either be served or rate limited")
+				Expect(result.MissingRetryAfter).To(BeZero(), "every 429 should tell the client when to retry")
+				if utils.RateLimitExpected() {
+					Expect(result.Statuses[http.StatusTooManyRequests]).To(BeNumerically(">", 0),
+						"%s=true but no request was rate limited", utils.RateLimitedEnvVar)
+				}
+				for _, wait := range result.RetryAfters {
+					if minRetryAfter == 0 || wait < minRetryAfter {
+						minRetryAfter = wait
+					}
+				}
+			}
+		})
+
+		It("should serve a whole burst through the retry layer", func() {
+			if !authDisabled && expectTokenRejected {
+				Skip("no accepted credentials in this configuration")
+			}
+			By(fmt.Sprintf("Sending %d list requests at once through the retry layer", utils.RateLimitBurst()))
+			result := utils.SendListBurst(authContext, namespace, utils.ClusterTemplateURL, utils.RateLimitBurst(), true)
+			fmt.Printf("  %s\n", result)
+
+			Expect(result.Errors).To(BeEmpty())
+			Expect(result.Statuses[http.StatusOK]).To(Equal(utils.RateLimitBurst()), "the retry layer should get every request served")
+			if utils.RateLimitExpected() && minRetryAfter > 0 {
+				Expect(result.Elapsed).To(BeNumerically(">=", minRetryAfter), "the retries should wait for Retry-After")
+			}
+		})
+	})
+})
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package http_client_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+)
+
+const (
+	// requestsPerNewConnection bounds the connections a sequential loop may open: the server may close an
+	// idle connection now and then, but not one per request.
+	requestsPerNewConnection = 100
+	// maxLatencyGrowth and latencySlack bound how much slower the end of the loop may be than its start.
+	maxLatencyGrowth = 3
+	latencySlack     = 20 * time.Millisecond
+)
+
+func TestHTTPClientTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch HTTP client tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch HTTP client test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("HTTP client connection reuse", Ordered, Label(utils.ClusterOrchHTTPClientTest), func() {
+	var (
+		namespace   string
+		authContext *auth.TestAuthContext
+	)
+
+	BeforeAll(func() {
+		var err error
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication")
+			authContext, err = utils.SetupTestAuthentication("http-client-user")
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("should reuse connections through a sustained request loop without degrading", func() {
+		client := utils.NewHTTPClient()
+		if authContext != nil {
+			client = utils.AuthenticatedHTTPClient(authContext)
+		}
+		newRequest := func() (*http.Request, error) {
+			req, err := http.NewRequest(http.MethodGet, utils.ClusterTemplateURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			utils.SetNamespaceHeader(req, namespace)
+			req.Header.Set("Accept", "application/json")
+			return req, nil
+		}
+
+		count := utils.RequestLoopCount()
+		By(fmt.Sprintf("Listing the templates %d times in a row", count))
+		result := utils.RunRequestLoop(client, newRequest, count)
+		fmt.Printf("Request loop: %s\n", result)
+
+		Expect(result.Failures).To(BeEmpty())
+		Expect(result.NewConnections).To(BeNumerically("<=", 1+count/requestsPerNewConnection),
+			"the loop should reuse its connections, see %s", utils.HTTPMaxIdleConnsPerHostEnvVar)
+		Expect(result.Degraded(maxLatencyGrowth, latencySlack)).To(BeFalse(),
+			"the latency should not grow over the loop: %s", result)
+	})
+	It("should send the project in the headers cluster-manager requires", func() {
+		client := utils.NewHTTPClient()
+		if authContext != nil {
+			client = utils.AuthenticatedHTTPClient(authContext)
+		}
+		strategy := utils.CurrentNamespaceHeaderStrategy()
+		By(fmt.Sprintf("Probing the project headers of the endpoints with the strategy %s", strategy))
+		for _, endpoint := range []string{utils.ClusterTemplateURL, utils.ClusterCreateURL, utils.ClusterCreateURL + "/summary"} {
+			probes, err := utils.ProbeNamespaceHeaders(client, endpoint, namespace)
+			Expect(err).NotTo(HaveOccurred())
+			for _, probe := range probes {
+				fmt.Printf("Namespace header contract: %s\n", probe)
+				switch {
+				case probe.Header == "":
+					Expect(probe.Honored()).To(BeFalse(), "%s should require a project header", endpoint)
+				case slices.Contains(strategy.HeadersFor(urlPath(endpoint)), probe.Header):
+					Expect(probe.Honored()).To(BeTrue(), "%s should honor the %s header of the strategy, see %s",
+						endpoint, probe.Header, utils.NamespaceHeaderOverridesEnvVar)
+				}
+			}
+		}
+	})
+})
+
+// urlPath returns the path of a URL of the suite.
+func urlPath(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	Expect(err).NotTo(HaveOccurred())
+	return parsed.Path
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	unknownHostClusterName     = "demo-cluster-unknown-host"
+	registeredClusterName      = "demo-cluster-registered-host"
+	doubleAllocatedClusterName = "demo-cluster-allocated-host"
+
+	// allocationReportTimeout bounds how long the second cluster on a host may look healthy.
+	allocationReportTimeout  = 5 * time.Minute
+	allocationReportInterval = 10 * time.Second
+)
+
+func TestInventoryTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch inventory tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch inventory test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+func clusterStatusCode(namespace, clusterName string) (int, error) {
+	resp, err := utils.GetClusterInfo(namespace, clusterName)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+var _ = Describe("Node GUID validation against the inventory", Ordered, Label(utils.ClusterOrchInventoryTest), func() {
+	var (
+		namespace string
+		hostGUID  string
+		infraCmd  *utils.PortForwarder
+	)
+
+	BeforeAll(func() {
+		if utils.InventoryDisabled() {
+			Skip("cluster-manager runs without inventory; enable the infra-core component of .test-dependencies.yaml")
+		}
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		// A host of its own, so the specs cannot collide with the edge node of the other suites.
+		hostGUID = utils.NewProjectID()
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Port forwarding to the infra manager API")
+		infraCmd, err = utils.StartInfraAPIPortForward()
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Importing the cluster template k3s baseline")
+		err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(infraCmd)
+		if namespace == "" {
+			return
+		}
+
+		By("Deleting the clusters and the host")
+		for _, name := range []string{unknownHostClusterName, registeredClusterName, doubleAllocatedClusterName} {
+			// Rejected clusters were never created.
+			if status, err := clusterStatusCode(namespace, name); err == nil && status == http.StatusNotFound {
+				continue
+			}
+			Expect(utils.DeleteNamedCluster(namespace, name)).To(Succeed())
+		}
+		for _, name := range []string{unknownHostClusterName, registeredClusterName, doubleAllocatedClusterName} {
+			Eventually(func() (int, error) {
+				return clusterStatusCode(namespace, name)
+			}, 5*time.Minute, 5*time.Second).Should(Equal(http.StatusNotFound), "cluster %s should be deleted", name)
+		}
+		Expect(utils.DeleteHost(namespace, hostGUID)).To(Succeed())
+	})
+
+	It("should reject a cluster on a node GUID without a host record", func() {
+		unknownGUID := utils.NewProjectID()
+		_, found, err := utils.FindHost(namespace, unknownGUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		By("Creating a cluster on the unknown node")
+		err = utils.CreateNamedCluster(namespace, unknownHostClusterName, unknownGUID, utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).To(HaveOccurred())
+		fmt.Printf("Cluster on an unknown node rejected: %v\n", err)
+
+		By("Checking that nothing was left behind")
+		Expect(clusterStatusCode(namespace, unknownHostClusterName)).To(Equal(http.StatusNotFound))
+	})
+
+	It("should accept a cluster on a registered and provisioned host", func() {
+		By("Registering the host in the inventory")
+		host, err := utils.RegisterHost(namespace, "cluster-tests-"+hostGUID[:8], hostGUID)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Provisioning an operating system instance on the host")
+		_, err = utils.ProvisionHost(namespace, host)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Creating a cluster on the host")
+		err = utils.CreateNamedCluster(namespace, registeredClusterName, hostGUID, utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterStatusCode(namespace, registeredClusterName)).To(Equal(http.StatusOK))
+	})
+
+	It("should not allocate a host that already belongs to a cluster", func() {
+		By("Creating a second cluster on the same host")
+		err := utils.CreateNamedCluster(namespace, doubleAllocatedClusterName, hostGUID, utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		if err != nil {
+			fmt.Printf("Second cluster on the host rejected: %v\n", err)
+			Expect(clusterStatusCode(namespace, doubleAllocatedClusterName)).To(Equal(http.StatusNotFound))
+			return
+		}
+
+		// Accepted by cluster-manager: binding the machine must then fail visibly rather than time out.
+		By("Waiting for the second cluster to report the host allocation")
+		Eventually(func() (string, error) {
+			cluster, err := utils.GetClusterDetail(namespace, doubleAllocatedClusterName)
+			if err != nil {
+				return "", err
+			}
+			messages := strings.Join(utils.ClusterStatusMessages(cluster), "; ")
+			fmt.Printf("Cluster %s status: %s\n", doubleAllocatedClusterName, messages)
+			return strings.ToLower(messages), nil
+		}, allocationReportTimeout, allocationReportInterval).Should(Or(
+			ContainSubstring("allocated"), ContainSubstring("in use"), ContainSubstring("already"), ContainSubstring(strings.ToLower(hostGUID))),
+			"the second cluster on host %s does not report the host is taken", hostGUID)
+	})
+})
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package name_validation_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// noObjectsPeriod leaves cluster-manager time to create objects it should not have created.
+	noObjectsPeriod   = 10 * time.Second
+	noObjectsInterval = 2 * time.Second
+)
+
+func TestNameValidationTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch name validation tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch name validation test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+// tableEntries returns a table entry per request, described by the request.
+func tableEntries(requests []utils.InvalidClusterRequest) []TableEntry {
+	entries := make([]TableEntry, 0, len(requests))
+	for _, request := range requests {
+		entries = append(entries, Entry(request.Description, request))
+	}
+	return entries
+}
+
+var _ = Describe("Cluster and node name validation", Ordered, Label(utils.ClusterOrchNameValidationTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+	)
+
+	BeforeAll(func() {
+		// A throwaway project, so whatever a wrongly accepted request creates goes away with it.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "validation-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("validation-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		// The template exists, so a request is only invalid because of its name or node.
+		By("Importing the cluster template k3s baseline")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+		if namespace == "" {
+			return
+		}
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	// expectRejected sends the request and checks that it gets a 400 naming the invalid field and that no
+	// CAPI object was created for it.
+	expectRejected := func(request utils.InvalidClusterRequest) {
+		data, err := request.Body(utils.K3sTemplateName)
+		Expect(err).NotTo(HaveOccurred())
+		status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, utils.ClusterCreateURL, data)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("  %s: HTTP %d\n", request.Description, status)
+
+		Expect(status).To(Equal(http.StatusBadRequest), body)
+		apiErr := utils.NewAPIError(request.Description, status, []byte(body))
+		Expect(apiErr).To(utils.HaveAPIErrorMessage(Not(BeEmpty())), "the rejection has no message")
+		Expect(utils.NamesField(apiErr.Message, request.Field)).To(BeTrue(),
+			"the rejection %q does not name the %s field", apiErr.Message, request.Field)
+		Expect(utils.LeakedInternals(body)).To(BeEmpty(), body)
+
+		By("Checking that no CAPI object was created for the request")
+		Consistently(func() ([]string, error) {
+			return utils.ClusterObjects(namespace)
+		}, noObjectsPeriod, noObjectsInterval).Should(BeEmpty())
+	}
+
+	DescribeTable("should reject an invalid cluster name", expectRejected,
+		tableEntries(utils.InvalidClusterNameRequests()))
+
+	DescribeTable("should reject a malformed node GUID", expectRejected,
+		tableEntries(utils.MalformedNodeGUIDRequests()))
+})
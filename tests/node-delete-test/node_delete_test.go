@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package node_delete_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// clusterGoneTimeout leaves CAPI time to delete the objects of a cluster without an edge node.
+	clusterGoneTimeout  = 3 * time.Minute
+	clusterGoneInterval = 5 * time.Second
+)
+
+func TestNodeDeleteTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch node deletion tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch node deletion test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("Node deletion", Ordered, Label(utils.ClusterOrchNodeDeleteTest), func() {
+	var (
+		namespace   string
+		project     *utils.TenantProject
+		authContext *auth.TestAuthContext
+		tenancyCmd  *utils.PortForwarder
+	)
+
+	// createCluster creates a cluster with a node per GUID and returns the status of the request.
+	createCluster := func(clusterName string, nodeGUIDs ...string) (int, string) {
+		data, err := utils.RenderMultiNodeClusterConfig(clusterName, utils.AllRoleNodes(nodeGUIDs...), utils.K3sTemplateName,
+			utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, utils.ClusterCreateURL, data)
+		Expect(err).NotTo(HaveOccurred())
+		return status, body
+	}
+
+	clusterStatus := func(clusterName string) (int, error) {
+		status, _, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodGet,
+			fmt.Sprintf("%s/%s", utils.ClusterCreateURL, clusterName), nil)
+		return status, err
+	}
+
+	BeforeAll(func() {
+		if !utils.InventoryDisabled() {
+			Skip("the clusters of this suite have no host record; run cluster-manager without inventory")
+		}
+
+		// A throwaway project, so the clusters a failed spec leaves behind go away with it.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "nodes-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication for the project")
+			authContext, err = auth.SetupProjectAuthentication("nodes-user", namespace)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Importing the cluster template k3s baseline")
+		if authContext != nil {
+			err = utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)
+		} else {
+			err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		}
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+		if namespace == "" {
+			return
+		}
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Deleting the project namespace")
+		Expect(utils.DeleteProjectNamespace(namespace)).To(Succeed())
+	})
+
+	DescribeTable("should delete a single node cluster with its last node",
+		func(clusterName string, options ...utils.DeleteNodeOption) {
+			nodeGUID := utils.NewProjectID()
+			status, body := createCluster(clusterName, nodeGUID)
+			Expect(status).To(Equal(http.StatusCreated), body)
+
+			Expect(utils.DeleteNode(authContext, namespace, clusterName, nodeGUID, options...)).To(Succeed())
+
+			By("Waiting for the cluster to be gone")
+			Eventually(func() (int, error) {
+				return clusterStatus(clusterName)
+			}, clusterGoneTimeout, clusterGoneInterval).Should(Equal(http.StatusNotFound))
+		},
+		Entry("without options", "last-node-default"),
+		Entry("with force=false", "last-node-graceful", utils.DeleteNodeNoForce),
+		Entry("with force=true", "last-node-force", utils.DeleteNodeForce),
+	)
+
+	Context("with invalid query values", Ordered, func() {
+		const clusterName = "invalid-query"
+		var nodeGUID string
+
+		BeforeAll(func() {
+			nodeGUID = utils.NewProjectID()
+			status, body := createCluster(clusterName, nodeGUID)
+			Expect(status).To(Equal(http.StatusCreated), body)
+		})
+
+		DescribeTable("should reject the value and keep the cluster",
+			func(option utils.DeleteNodeOption) {
+				err := utils.DeleteNode(authContext, namespace, clusterName, nodeGUID, option)
+				Expect(err).To(utils.HaveAPIStatus(http.StatusBadRequest))
+				Expect(err).To(utils.HaveAPIErrorMessage(ContainSubstring("force")))
+
+				Consistently(func() (int, error) {
+					return clusterStatus(clusterName)
+				}, 5*time.Second, time.Second).Should(Equal(http.StatusOK))
+			},
+			Entry("force=maybe", utils.DeleteNodeOption("force=maybe")),
+			Entry("force=yes", utils.DeleteNodeOption("force=yes")),
+			Entry("force=2", utils.DeleteNodeOption("force=2")),
+		)
+	})
+
+	It("should report an unknown cluster as not found, with and without force", func() {
+		for _, options := range [][]utils.DeleteNodeOption{nil, {utils.DeleteNodeForce}} {
+			err := utils.DeleteNode(authContext, namespace, "no-such-cluster", utils.NewProjectID(), options...)
+			Expect(err).To(utils.HaveAPIStatus(http.StatusNotFound), "options %v", options)
+		}
+	})
+
+	It("should delete a node of a multi-node cluster and keep the cluster", func() {
+		const clusterName = "multi-node"
+		nodeGUIDs := []string{utils.NewProjectID(), utils.NewProjectID()}
+		status, body := createCluster(clusterName, nodeGUIDs...)
+		if status == http.StatusBadRequest {
+			Skip("cluster-manager only supports single node clusters: " + body)
+		}
+		Expect(status).To(Equal(http.StatusCreated), body)
+
+		Expect(utils.DeleteNode(authContext, namespace, clusterName, nodeGUIDs[1])).To(Succeed())
+
+		Eventually(func() (int, error) {
+			status, body, err := utils.ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodGet,
+				fmt.Sprintf("%s/%s", utils.ClusterCreateURL, clusterName), nil)
+			if err != nil || status != http.StatusOK {
+				return 0, err
+			}
+			var cluster api.ClusterDetailInfo
+			if err := json.Unmarshal([]byte(body), &cluster); err != nil || cluster.Nodes == nil {
+				return 0, err
+			}
+			return len(*cluster.Nodes), nil
+		}, clusterGoneTimeout, clusterGoneInterval).Should(Equal(1), "the cluster should keep the other node")
+	})
+})
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package onboarding_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	onboardingClusterName = "demo-cluster-onboarding"
+
+	// agentHoldDuration bounds how long the edge node goes without cluster-agent if the suite is lost.
+	agentHoldDuration = 15 * time.Minute
+	// waitingForNodeDuration is how long the cluster has to stay unprovisioned while the agent is down.
+	waitingForNodeDuration = 2 * time.Minute
+	// onboardingTimeout covers the vEN installing k3s and pulling images after the agent comes up.
+	onboardingTimeout  = 10 * time.Minute
+	onboardingInterval = 10 * time.Second
+)
+
+func TestOnboardingTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch onboarding tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch onboarding test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+func lifecyclePhase(namespace string) (string, error) {
+	cluster, err := utils.GetClusterDetail(namespace, onboardingClusterName)
+	if err != nil {
+		return "", err
+	}
+	if cluster.LifecyclePhase == nil || cluster.LifecyclePhase.Message == nil {
+		return utils.StatusNotObserved, nil
+	}
+	return *cluster.LifecyclePhase.Message, nil
+}
+
+func clusterComponentsReady(namespace string) bool {
+	status, err := utils.GetClusterComponents("", namespace, onboardingClusterName)
+	if err != nil {
+		return false
+	}
+	fmt.Printf("Cluster components status:\n%s", status)
+	return status.AllReady()
+}
+
+// The other suites create their clusters on a node whose agent is already running. This one walks the
+// edge node story in order: the host is registered, the cluster is created against its GUID, and only
+// then does the agent on the node come up and provision it.
+var _ = Describe("Edge node onboarding", Ordered, Label(utils.ClusterOrchOnboardingTest), func() {
+	var (
+		namespace       string
+		nodeGUID        string
+		hostRegistered  bool
+		agentHeld       bool
+		clusterCreated  bool
+		infraCmd        *utils.PortForwarder
+		onboardingStart time.Time
+	)
+
+	BeforeAll(func() {
+		if utils.InventoryDisabled() {
+			Skip("cluster-manager runs without inventory; enable the infra-core component of .test-dependencies.yaml")
+		}
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Port forwarding to the infra manager API")
+		infraCmd, err = utils.StartInfraAPIPortForward()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(infraCmd)
+
+		if agentHeld {
+			By("Starting cluster-agent on the edge node again")
+			Expect(utils.ReleaseEdgeNodeAgent()).To(Succeed())
+		}
+		if clusterCreated && !utils.SkipDeleteCluster {
+			By("Deleting the cluster")
+			Expect(utils.DeleteNamedCluster(namespace, onboardingClusterName)).To(Succeed())
+			Expect(wait.WaitForClusterDeleted(namespace, onboardingClusterName)).To(Succeed())
+		}
+		if hostRegistered {
+			By("Removing the host from the inventory")
+			Expect(utils.DeleteHost(namespace, nodeGUID)).To(Succeed())
+		}
+	})
+
+	It("should take the edge node back to a host that is not onboarded", func() {
+		By("Stopping cluster-agent on the edge node")
+		Expect(utils.HoldEdgeNodeAgent(agentHoldDuration)).To(Succeed())
+		agentHeld = true
+
+		active, err := utils.EdgeNodeAgentActive()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeFalse(), "cluster-agent is still running on the edge node")
+	})
+
+	It("should register the edge node in the inventory", func() {
+		var (
+			host *utils.Host
+			err  error
+		)
+		host, hostRegistered, err = utils.EnsureHostOnboardable(namespace, "cluster-tests-"+nodeGUID[:8], nodeGUID)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("Host %s (%s) registered by the suite: %t\n", host.ResourceID, nodeGUID, hostRegistered)
+
+		_, found, err := utils.FindHost(namespace, nodeGUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+	})
+
+	It("should accept a cluster on the registered node and wait for it", func() {
+		By("Importing the cluster template k3s baseline")
+		err := utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		By("Creating the cluster against the node GUID")
+		err = utils.CreateNamedCluster(namespace, onboardingClusterName, nodeGUID, utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		clusterCreated = true
+
+		By("Checking that the cluster is not provisioned without the agent")
+		Consistently(func() (string, error) {
+			return lifecyclePhase(namespace)
+		}, waitingForNodeDuration, onboardingInterval).ShouldNot(Equal(utils.LifecycleActive))
+		Expect(clusterComponentsReady(namespace)).To(BeFalse(), "the cluster got ready without an agent on its node")
+	})
+
+	It("should provision the cluster once the agent on the node comes up", func() {
+		By("Starting cluster-agent on the edge node")
+		Expect(utils.ReleaseEdgeNodeAgent()).To(Succeed())
+		agentHeld = false
+		onboardingStart = time.Now()
+
+		By("Waiting for all components to be ready")
+		Eventually(func() bool {
+			return clusterComponentsReady(namespace)
+		}, onboardingTimeout, onboardingInterval).Should(BeTrue(), func() string {
+			return utils.TriageCluster(namespace, onboardingClusterName)
+		})
+		fmt.Printf("\033[32mTotal time from agent start to cluster ready: %v 🚀 ✅\033[0m\n", time.Since(onboardingStart))
+	})
+
+	It("should report the onboarded cluster active", func() {
+		Eventually(func() (string, error) {
+			return lifecyclePhase(namespace)
+		}, 2*time.Minute, onboardingInterval).Should(Equal(utils.LifecycleActive))
+
+		resp, err := utils.GetClusterInfo(namespace, onboardingClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		host, found, err := utils.FindHost(namespace, nodeGUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue(), "the host of the cluster disappeared from the inventory")
+		fmt.Printf("Host %s is %s\n", host.ResourceID, host.CurrentState)
+	})
+
+	// cluster-manager copies the metadata of a host to the labels of the Machine whose node is the host.
+	It("should propagate the metadata of the host to the labels of its machine", func() {
+		host, found, err := utils.FindHost(namespace, nodeGUID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue(), "the host of the cluster disappeared from the inventory")
+
+		metadata := map[string]string{"cluster-tests-zone": "lab-a", "cluster-tests-rack": "r1"}
+		Expect(utils.SetHostMetadata(namespace, host, metadata)).To(Succeed())
+
+		Eventually(func() ([]string, error) {
+			machines, err := utils.GetClusterMachineLabels(namespace, onboardingClusterName)
+			if err != nil {
+				return nil, err
+			}
+			if len(machines) == 0 {
+				return []string{"the cluster has no machine"}, nil
+			}
+			var differences []string
+			for name, labels := range machines {
+				for _, difference := range utils.LabelDifferences(labels, metadata) {
+					differences = append(differences, name+": "+difference)
+				}
+			}
+			return differences, nil
+		}, 2*time.Minute, onboardingInterval).Should(BeEmpty())
+	})
+	// A node whose cluster is deleted goes back to the pool and must take the next cluster as is, without
+	// its agent being reset or restarted by hand.
+	It("should re-onboard the node into a new cluster right after the old one is deleted", func() {
+		restartsBefore, err := utils.EdgeNodeAgentRestarts()
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Deleting the cluster")
+		deleteStart := time.Now()
+		Expect(utils.DeleteNamedCluster(namespace, onboardingClusterName)).To(Succeed())
+		Expect(wait.WaitForClusterDeleted(namespace, onboardingClusterName)).To(Succeed())
+		clusterCreated = false
+		deleted := time.Since(deleteStart)
+
+		By("Creating a new cluster against the same node GUID")
+		createStart := time.Now()
+		err = utils.CreateNamedCluster(namespace, onboardingClusterName, nodeGUID, utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		clusterCreated = true
+
+		By("Waiting for all components to be ready without touching the agent")
+		Eventually(func() bool {
+			return clusterComponentsReady(namespace)
+		}, onboardingTimeout, onboardingInterval).Should(BeTrue(), func() string {
+			return utils.TriageCluster(namespace, onboardingClusterName)
+		})
+		Eventually(func() (string, error) {
+			return lifecyclePhase(namespace)
+		}, 2*time.Minute, onboardingInterval).Should(Equal(utils.LifecycleActive))
+
+		active, err := utils.EdgeNodeAgentActive()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeTrue(), "cluster-agent should still run on the edge node")
+		restartsAfter, err := utils.EdgeNodeAgentRestarts()
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("\033[32mRe-onboarding turnaround: %v to delete the cluster, %v from create to ready, %v in total; cluster-agent restarted %d time(s) 🔁\033[0m\n",
+			deleted.Round(time.Second), time.Since(createStart).Round(time.Second), time.Since(deleteStart).Round(time.Second),
+			restartsAfter-restartsBefore)
+	})
+})
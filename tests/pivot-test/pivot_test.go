@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package pivot_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	clusterReadinessTimeout = 15 * time.Minute
+	pivotSettleTimeout      = 10 * time.Minute
+	pivotSettleInterval     = 10 * time.Second
+
+	// pivotLabel is set on the control plane through the topology once the cluster is moved, to prove the
+	// controllers of the target management cluster reconcile it.
+	pivotLabel = "cluster-tests/pivoted"
+)
+
+func TestPivotTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting pivot tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "pivot test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+var _ = Describe("Moving a live cluster to another management cluster", Ordered, Label(utils.ClusterOrchPivotTest), func() {
+	var (
+		namespace        string
+		nodeGUID         string
+		sourceKubeconfig string
+		targetKubeconfig string
+		target           *utils.KubeClient
+		movedToTarget    bool
+		setUp            bool
+	)
+
+	// waitForManagedCluster waits until the management cluster of kubeconfig reports all components of
+	// the cluster ready and the connect agent connected.
+	waitForManagedCluster := func(kubeconfig string) {
+		ctx, cancel := context.WithTimeout(context.Background(), pivotSettleTimeout)
+		defer cancel()
+		Expect(wait.ManagedClusterReady(ctx, kubeconfig, namespace, utils.ClusterName)).To(Succeed())
+		lost, err := utils.CheckLostConnection(kubeconfig, namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lost).To(BeFalse(), "the connect agent should be connected")
+	}
+
+	BeforeAll(func() {
+		targetKubeconfig = utils.PivotTargetKubeconfig()
+		if targetKubeconfig == "" {
+			Skip(fmt.Sprintf("no target management cluster; set %s", utils.PivotTargetKubeconfigEnvVar))
+		}
+
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Saving the kubeconfig of the source management cluster")
+		sourceKubeconfig = filepath.Join(GinkgoT().TempDir(), "source-kubeconfig.yaml")
+		Expect(utils.WriteCurrentKubeconfig(sourceKubeconfig)).To(Succeed())
+
+		By("Ensuring the namespace exists on both management clusters")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+		var err error
+		target, err = utils.NewKubeClient(targetKubeconfig)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(target.EnsureNamespace(namespace)).To(Succeed())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp {
+			return
+		}
+		if movedToTarget {
+			By("Moving the cluster back to the source management cluster")
+			if _, err := utils.MoveClusterAPIObjects(namespace, targetKubeconfig, sourceKubeconfig, false); err != nil {
+				fmt.Printf("Failed to move the cluster back: %v\n", err)
+				if !utils.SkipDeleteCluster {
+					_ = target.DeleteResource(utils.CAPIClusterGVR.GroupResource().String(), namespace, utils.ClusterName)
+				}
+				return
+			}
+		}
+		if utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		if err := utils.DeleteCluster(namespace); err != nil {
+			fmt.Printf("Failed to delete cluster: %v\n", err)
+			return
+		}
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should create a cluster on the source management cluster", func() {
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)).To(Succeed())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should move the CAPI objects to the target management cluster", func() {
+		By("Planning the move")
+		plan, err := utils.MoveClusterAPIObjects(namespace, "", targetKubeconfig, true)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("clusterctl move plan:\n%s\n", plan)
+		Expect(plan).To(ContainSubstring(utils.ClusterName))
+
+		By("Moving the objects")
+		_, err = utils.MoveClusterAPIObjects(namespace, "", targetKubeconfig, false)
+		Expect(err).NotTo(HaveOccurred())
+		movedToTarget = true
+
+		Expect(utils.ManagedClusterExists(targetKubeconfig, namespace, utils.ClusterName)).To(BeTrue(),
+			"the cluster should exist on the target management cluster")
+		Expect(utils.ManagedClusterExists("", namespace, utils.ClusterName)).To(BeFalse(),
+			"the cluster should be gone from the source management cluster")
+	})
+
+	It("should keep the cluster connected after the move", func() {
+		waitForManagedCluster(targetKubeconfig)
+	})
+
+	It("should manage the cluster from the target management cluster", func() {
+		By("Labelling the control plane through the cluster topology")
+		patch := fmt.Sprintf(`{"spec":{"topology":{"controlPlane":{"metadata":{"labels":{%q:"true"}}}}}}`, pivotLabel)
+		Expect(target.PatchCluster(namespace, utils.ClusterName, types.MergePatchType, []byte(patch))).To(Succeed())
+
+		cluster, err := target.GetCluster(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		apiVersion, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneRef", "apiVersion")
+		kind, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneRef", "kind")
+		name, _, _ := unstructured.NestedString(cluster.Object, "spec", "controlPlaneRef", "name")
+		Expect(kind).NotTo(BeEmpty(), "the cluster should reference its control plane")
+
+		By(fmt.Sprintf("Waiting for the target controllers to reconcile %s/%s", kind, name))
+		Eventually(func() (string, error) {
+			controlPlane, err := target.GetObject(apiVersion, kind, namespace, name)
+			if err != nil {
+				return "", err
+			}
+			return controlPlane.GetLabels()[pivotLabel], nil
+		}, pivotSettleTimeout, pivotSettleInterval).Should(Equal("true"))
+	})
+
+	It("should move the cluster back to the source management cluster", func() {
+		_, err := utils.MoveClusterAPIObjects(namespace, targetKubeconfig, sourceKubeconfig, false)
+		Expect(err).NotTo(HaveOccurred())
+		movedToTarget = false
+
+		waitForManagedCluster(sourceKubeconfig)
+	})
+})
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package project_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	projectClusterName = "demo-cluster-project"
+
+	// namespaceDeletionTimeout covers CAPI tearing down a cluster whose machine was never provisioned.
+	namespaceDeletionTimeout  = 10 * time.Minute
+	namespaceDeletionInterval = 10 * time.Second
+)
+
+func TestProjectTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch project tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch project test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("Project namespace deletion", Ordered, Label(utils.ClusterOrchProjectTest), func() {
+	var (
+		namespace  string
+		project    *utils.TenantProject
+		tenancyCmd *utils.PortForwarder
+	)
+
+	BeforeAll(func() {
+		// A throwaway project, so deleting it cannot affect the shared test namespace or the edge node.
+		var err error
+		namespace = utils.NewProjectID()
+		if utils.MultiTenancyEnabled() {
+			By("Creating a project through the tenancy API")
+			tenancyCmd, err = utils.StartTenancyAPIPortForward()
+			Expect(err).NotTo(HaveOccurred())
+			project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "project-deletion-"+namespace[:8])
+			Expect(err).NotTo(HaveOccurred())
+			namespace = project.ID
+		}
+		fmt.Printf("Using project namespace %s\n", namespace)
+
+		By("Ensuring the project namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+
+		if project != nil {
+			By("Deleting the project through the tenancy API")
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+
+		By("Making sure the project namespace is gone")
+		err := utils.DeleteProjectNamespace(namespace)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should populate the project with a template and a cluster", func() {
+		By("Importing the cluster template k3s baseline")
+		err := utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for the cluster template to be ready")
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+
+		// The node does not exist, the cluster only has to be there when the project goes away.
+		By("Creating a cluster on a node that is never provisioned")
+		err = utils.CreateNamedCluster(namespace, projectClusterName, utils.NewProjectID(), utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(func() (int, error) {
+			resp, err := utils.GetClusterInfo(namespace, projectClusterName)
+			if err != nil {
+				return 0, err
+			}
+			defer resp.Body.Close()
+			return resp.StatusCode, nil
+		}, time.Minute, 2*time.Second).Should(Equal(http.StatusOK))
+	})
+
+	It("should cascade the project deletion to its clusters and templates", func() {
+		By("Deleting the project namespace while the cluster and template exist")
+		err := utils.DeleteProjectNamespace(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Namespace deletion is never blocked up front: finalizers on the cluster and template must let
+		// the cascade finish, otherwise the project is stuck terminating with half of its objects gone.
+		By("Waiting for the namespace and everything in it to be gone")
+		var residue []string
+		Eventually(func() (bool, error) {
+			exists, err := utils.NamespaceExists(namespace)
+			if err != nil || !exists {
+				return exists, err
+			}
+			residue, err = utils.ProjectResidue(namespace)
+			fmt.Printf("Project %s is still terminating: %v\n", namespace, residue)
+			return true, err
+		}, namespaceDeletionTimeout, namespaceDeletionInterval).Should(BeFalse(),
+			"project namespace %s is stuck half-deleted, remaining: %v", namespace, residue)
+	})
+
+	It("should answer requests for the vanished project without server errors", func() {
+		for _, url := range []string{utils.ClusterCreateURL, utils.ClusterTemplateURL} {
+			By("Listing " + url + " of the deleted project")
+			status, body, err := utils.ProjectAPIResponse(namespace, http.MethodGet, url, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Or(Equal(http.StatusOK), Equal(http.StatusNotFound)), "unexpected response: %s", body)
+			Expect(body).NotTo(ContainSubstring(projectClusterName))
+			Expect(body).NotTo(ContainSubstring(utils.K3sTemplateOnlyName))
+		}
+
+		By("Getting the deleted cluster")
+		status, body, err := utils.ProjectAPIResponse(namespace, http.MethodGet, utils.ClusterCreateURL+"/"+projectClusterName, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusNotFound), "unexpected response: %s", body)
+
+		By("Getting the deleted template")
+		status, body, err = utils.ProjectAPIResponse(namespace, http.MethodGet,
+			fmt.Sprintf("%s/%s/%s", utils.ClusterTemplateURL, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusNotFound), "unexpected response: %s", body)
+
+		// Writes cannot succeed: nothing recreates the namespace behind the API's back.
+		By("Importing a template into the deleted project")
+		err = utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(utils.HaveAPIErrorMessage(Not(BeEmpty())), "the error should explain why")
+
+		By("Creating a cluster in the deleted project")
+		err = utils.CreateNamedCluster(namespace, projectClusterName, utils.NewProjectID(), utils.K3sTemplateName, utils.ClusterConfigOptions{})
+		Expect(err).To(HaveOccurred())
+
+		exists, err := utils.NamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse(), "a request for the deleted project recreated its namespace")
+	})
+})
+
+var _ = Describe("Project lifecycle through the tenancy API", Ordered, Label(utils.ClusterOrchProjectTest), func() {
+	var (
+		project    *utils.TenantProject
+		tenancyCmd *utils.PortForwarder
+	)
+
+	BeforeAll(func() {
+		if !utils.MultiTenancyEnabled() {
+			Skip("multi-tenancy is disabled; enable the tenancy-manager component of .test-dependencies.yaml")
+		}
+
+		var err error
+		tenancyCmd, err = utils.StartTenancyAPIPortForward()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterAll(func() {
+		defer utils.StopPortForwards(tenancyCmd)
+
+		if project != nil {
+			Expect(utils.DeleteProject(project)).To(Succeed())
+		}
+	})
+
+	It("should set up a project created through the tenancy API", func() {
+		By("Creating the project")
+		var err error
+		project, err = utils.CreateProject(utils.GetEnv(utils.TenancyOrgEnvVar, utils.DefaultTenancyOrg), "project-lifecycle-"+utils.NewProjectID()[:8])
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("Project %s/%s has namespace %s\n", project.Org, project.Name, project.ID)
+
+		By("Waiting for cluster-manager to set up the project")
+		Expect(utils.WaitForProjectSetup(project.ID, utils.ProjectSetupTimeout)).To(Succeed())
+
+		By("Listing the default templates cluster-manager created in the project")
+		status, body, err := utils.ProjectAPIResponse(project.ID, http.MethodGet, utils.ClusterTemplateURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Equal(http.StatusOK), "unexpected response: %s", body)
+		Expect(body).To(ContainSubstring(`"name"`), "the project has no default templates")
+	})
+
+	It("should only let tokens bound to the project use it", func() {
+		if utils.AuthDisabled() {
+			Skip("authentication is disabled")
+		}
+
+		By("Listing templates with a token that has roles in the project")
+		authContext, err := auth.SetupProjectAuthentication("project-user", project.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utils.ClusterTemplatesStatus(authContext, project.ID)).To(Equal(http.StatusOK))
+
+		By("Listing templates with a token that has roles in another project only")
+		authContext, err = auth.SetupProjectAuthentication("project-user", utils.DefaultNamespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utils.ClusterTemplatesStatus(authContext, project.ID)).To(Or(Equal(http.StatusUnauthorized), Equal(http.StatusForbidden)))
+	})
+
+	It("should tear the project down when it is deleted through the tenancy API", func() {
+		By("Deleting the project")
+		Expect(utils.DeleteProject(project)).To(Succeed())
+		deleted := project
+		project = nil
+
+		By("Waiting for the project namespace to be gone")
+		Eventually(func() (bool, error) {
+			return utils.NamespaceExists(deleted.ID)
+		}, namespaceDeletionTimeout, namespaceDeletionInterval).Should(BeFalse())
+
+		By("Listing the templates of the deleted project")
+		status, body, err := utils.ProjectAPIResponse(deleted.ID, http.MethodGet, utils.ClusterTemplateURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status).To(Or(Equal(http.StatusOK), Equal(http.StatusNotFound)), "unexpected response: %s", body)
+		Expect(body).NotTo(ContainSubstring(`"name"`))
+	})
+})
@@ -4,111 +4,117 @@
 package functional_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
-	"os/exec"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
 	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/faults"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+	corev1 "k8s.io/api/core/v1"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
+// networkDegradationWindow is how long each degradation level is held while the connection is watched.
+const networkDegradationWindow = 3 * time.Minute
+
+// dnsOutageWindow bounds how long DNS stays broken on the edge node.
+const dnsOutageWindow = 5 * time.Minute
+
+// nodeUnhealthyMargin is how long after the MachineHealthCheck timeout remediation may take to start.
+const nodeUnhealthyMargin = 5 * time.Minute
+
+// statusPollInterval is how often the status recorder polls cluster-manager.
+const statusPollInterval = 2 * time.Second
+
+// diskPressureWindow bounds how long the edge node disk stays full; diskPressureDetectionTimeout is how
+// long the node health may take to report it.
+const (
+	diskPressureWindow           = 10 * time.Minute
+	diskPressureDetectionTimeout = 5 * time.Minute
+)
+
+// k3sTokenMismatchWindow bounds how long k3s runs with a token that does not match its datastore.
+const k3sTokenMismatchWindow = 10 * time.Minute
+
+// probeCadenceWindow is how long the connection probe timestamp is sampled, at least probeCadenceIntervals
+// probe intervals, every probeSamplePeriod.
+const (
+	probeCadenceWindow    = 3 * time.Minute
+	probeCadenceIntervals = 6
+	probeSamplePeriod     = 5 * time.Second
+)
+
+// execSessionOutputTimeout bounds how long an exec session through the gateway may take to stream its first
+// lines; execSessionEndTimeout is how long it may stay open once the gateway pods have been replaced.
+const (
+	execSessionOutputTimeout = 1 * time.Minute
+	execSessionLines         = 3
+	execSessionEndTimeout    = 1 * time.Minute
+)
+
+// correlationSamplePeriod is how often the connection sources are sampled together; correlationTimeout bounds
+// how long each of them may take to report the broken and the restored agent.
+const (
+	correlationSamplePeriod = 5 * time.Second
+	correlationTimeout      = 10 * time.Minute
+)
+
+// imagePullOutageWindow is how long the registry of the connect-agent image stays unreachable.
+const imagePullOutageWindow = 6 * time.Minute
+
 func TestClusterOrchRobustnessTest(t *testing.T) {
 	RegisterFailHandler(Fail)
 	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch robustness tests\n")
-	RunSpecs(t, "cluster orch robustness test suite")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch robustness test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+// nodeConditionStatuses returns the status of a condition of every node of a cluster, separated by spaces, or
+// why the nodes could not be listed.
+func nodeConditionStatuses(client *utils.KubeClient, conditionType corev1.NodeConditionType) string {
+	nodes, err := client.ListNodes()
+	if err != nil {
+		return err.Error()
+	}
+	var statuses []string
+	for _, node := range nodes {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == conditionType {
+				statuses = append(statuses, string(condition.Status))
+			}
+		}
+	}
+	return strings.Join(statuses, " ")
 }
 
 var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOrchRobustnessTest), func() {
 	var (
 		namespace              string
 		nodeGUID               string
-		portForwardCmd         *exec.Cmd
-		gatewayPortForward     *exec.Cmd
 		clusterCreateStartTime time.Time
 		clusterCreateEndTime   time.Time
 		downstreamKubeconfig   string
-		connectAgentKind       string
-		connectAgentNamespace  string
-		connectAgentName       string
+		downstream             *utils.KubeClient
 		connectAgentImage      string
+		restoreConnectAgent    faults.Restore
+		statusRecorder         *utils.ClusterStatusRecorder
+		edgeNodeSampler        *utils.EdgeNodeSampler
+		clusterDeleted         bool
 	)
 
-	getConnectAgentWorkload := func(kubeconfigPath string) (kind, ns, name string, err error) {
-		// Prefer a DaemonSet if present, otherwise fall back to a Deployment.
-		// We avoid hard-coding namespace/name because they can vary by environment.
-		list := func(resource string) ([]string, error) {
-			cmd := exec.Command(
-				"kubectl", "--kubeconfig", kubeconfigPath, "get", resource, "-A", "-o", "jsonpath={range .items[*]}{.metadata.namespace}{\"/\"}{.metadata.name}{\"\\n\"}{end}",)
-			out, err := cmd.Output()
-			if err != nil {
-				return nil, err
-			}
-			lines := []string{}
-			for _, line := range strings.Split(string(out), "\n") {
-				line = strings.TrimSpace(line)
-				if line != "" {
-					lines = append(lines, line)
-				}
-			}
-			return lines, nil
-		}
-
-		pick := func(lines []string) (string, string, bool) {
-			for _, line := range lines {
-				// line format: namespace/name
-				if strings.Contains(line, "connect-agent") {
-					parts := strings.SplitN(line, "/", 2)
-					if len(parts) == 2 {
-						return parts[0], parts[1], true
-					}
-				}
-			}
-			return "", "", false
-		}
-
-		if lines, e := list("daemonset"); e == nil {
-			if ns, name, ok := pick(lines); ok {
-				return "daemonset", ns, name, nil
-			}
-		}
-		if lines, e := list("deployment"); e == nil {
-			if ns, name, ok := pick(lines); ok {
-				return "deployment", ns, name, nil
-			}
-		}
-
-		return "", "", "", fmt.Errorf("connect-agent workload not found in downstream cluster")
-	}
-
-	getWorkloadImage := func(kubeconfigPath, kind, ns, name string) (string, error) {
-		cmd := exec.Command("kubectl", "--kubeconfig", kubeconfigPath, "-n", ns, "get", kind, name, "-o", "jsonpath={.spec.template.spec.containers[0].image}",)
-		out, err := cmd.Output()
-		if err != nil {
-			return "", err
-		}
-		return strings.TrimSpace(string(out)), nil
-	}
-
-	setWorkloadImage := func(kubeconfigPath, kind, ns, name, image string) error {
-		cmd := exec.Command("kubectl", "--kubeconfig", kubeconfigPath, "-n", ns, "set", "image", fmt.Sprintf("%s/%s", kind, name), "*="+image,)
-		out, err := cmd.CombinedOutput()
-		if err != nil {
-			trim := strings.TrimSpace(string(out))
-			if trim == "" {
-				return err
-			}
-			return fmt.Errorf("%w: %s", err, trim)
-		}
-		return nil
-	}
-
 	BeforeAll(func() {
 		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
 		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
@@ -118,44 +124,25 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 		err := utils.EnsureNamespaceExists(namespace)
 		Expect(err).NotTo(HaveOccurred())
 
-		By("Port forwarding to the cluster manager service")
-		err = utils.EnsureTCPPortAvailable(utils.PortForwardLocalPort, fmt.Sprintf("kubectl port-forward %s", utils.PortForwardService))
-		Expect(err).NotTo(HaveOccurred())
-
-		portForwardCmd = exec.Command("kubectl", "port-forward", utils.PortForwardService, fmt.Sprintf("%s:%s", utils.PortForwardLocalPort, utils.PortForwardRemotePort), "--address", utils.PortForwardAddress)
-		err = portForwardCmd.Start()
-		Expect(err).NotTo(HaveOccurred())
-		time.Sleep(5 * time.Second) // Give some time for port-forwarding to establish
-
-		By("Port forwarding to the cluster gateway service")
-		err = utils.EnsureTCPPortAvailable(utils.PortForwardGatewayLocalPort, fmt.Sprintf("kubectl port-forward %s", utils.PortForwardGatewayService))
-		Expect(err).NotTo(HaveOccurred())
-
-		gatewayPortForward = exec.Command("kubectl", "port-forward", utils.PortForwardGatewayService, fmt.Sprintf("%s:%s", utils.PortForwardGatewayLocalPort, utils.PortForwardGatewayRemotePort), "--address", utils.PortForwardAddress)
-		err = gatewayPortForward.Start()
-		Expect(err).NotTo(HaveOccurred())
-		time.Sleep(5 * time.Second) // Give some time for port-forwarding to establish
-
+		if version, err := utils.GetGatewayVersion(); err == nil {
+			fmt.Printf("cluster-connect-gateway %s\n", version)
+		} else {
+			fmt.Printf("Unable to read the cluster-connect-gateway version: %v\n", err)
+		}
+		fmt.Printf("%s\n", utils.GetGatewayHealth())
 	})
 
 	AfterAll(func() {
-		defer func() {
-			if portForwardCmd != nil && portForwardCmd.Process != nil {
-				portForwardCmd.Process.Kill()
-			}
-		}()
+		statusRecorder.Stop()
+		edgeNodeSampler.StopAndReport()
 
-		if !utils.SkipDeleteCluster {
+		if !utils.SkipDeleteCluster && !clusterDeleted {
 			By("Deleting the cluster")
 			err := utils.DeleteCluster(namespace)
 			Expect(err).NotTo(HaveOccurred())
 
 			By("Verifying that the cluster is deleted")
-			Eventually(func() bool {
-				cmd := exec.Command("kubectl", "-n", namespace, "get", "cluster", utils.ClusterName)
-				err := cmd.Run()
-				return err != nil
-			}, 1*time.Minute, 5*time.Second).Should(BeTrue())
+			Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
 		}
 	})
 
@@ -165,15 +152,23 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 		Expect(err).NotTo(HaveOccurred())
 
 		By("Waiting for the cluster template to be ready")
-		Eventually(func() bool {
-			return utils.IsClusterTemplateReady(namespace, utils.K3sTemplateName)
-		}, 1*time.Minute, 2*time.Second).Should(BeTrue())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
 	})
 
 	It("Test prerequisite: Should verify that cluster create API should succeed for k3s cluster", func() {
 		// Record the start time before creating the cluster
 		clusterCreateStartTime = time.Now()
 
+		By("Recording the status transitions of the cluster")
+		statusRecorder = utils.NewClusterStatusRecorder(namespace, utils.ClusterName)
+		statusRecorder.Start(statusPollInterval)
+
+		if utils.EdgeNodeMetricsEnabled() {
+			By("Sampling the resource usage of the edge node for the rest of the suite")
+			edgeNodeSampler = utils.NewEdgeNodeSampler(utils.ClusterName + "-robustness")
+			edgeNodeSampler.Start(utils.EdgeNodeMetricsInterval())
+		}
+
 		By("Creating the cluster")
 		err := utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)
 		Expect(err).NotTo(HaveOccurred())
@@ -181,29 +176,15 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 
 	It("Test prerequisite: Should verify that the cluster is fully active", func() {
 		By("Waiting for IntelMachine to exist")
+		client, err := utils.ManagementKubeClient()
+		Expect(err).NotTo(HaveOccurred())
 		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "-n", namespace, "get", "intelmachine", "-o", "jsonpath={.items[*].metadata.name}")
-			output, err := cmd.Output()
-			if err != nil {
-				return false
-			}
-			count := 0
-			if len(output) > 0 {
-				count = len(strings.Fields(string(output)))
-			}
-			return count > 0
+			machines, err := client.ListIntelMachines(namespace, "")
+			return err == nil && len(machines) > 0
 		}, 1*time.Minute, 5*time.Second).Should(BeTrue())
 
 		By("Waiting for all components to be ready")
-		Eventually(func() bool {
-			cmd := exec.Command("clusterctl", "describe", "cluster", utils.ClusterName, "-n", namespace)
-			output, err := cmd.Output()
-			if err != nil {
-				return false
-			}
-			fmt.Printf("Cluster components status:\n%s\n", string(output))
-			return utils.CheckAllComponentsReady(string(output))
-		}, 5*time.Minute, 10*time.Second).Should(BeTrue())
+		Expect(wait.WaitForClusterReady(namespace, utils.ClusterName)).To(Succeed())
 		// Record the end time after the cluster is fully active
 		clusterCreateEndTime = time.Now()
 
@@ -224,100 +205,247 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 
 	It("Test prerequisite: Should verify that the connect gateway allow access to k8s api", func() {
 		// cmd := exec.Command("curl", "-X", "GET", fmt.Sprintf("127.0.0.1:%v/kubernetes/%v-%v/api/v1/namespaces/default/pods", portForwardGatewayLocalPort, namespace, clusterName))
-		By("Getting kubeconfig")
+		By("Getting kubeconfig pointed at the cluster connect gateway")
 		fmt.Println(utils.ClusterName)
-		cmd := exec.Command("clusterctl", "get", "kubeconfig", utils.ClusterName, "--namespace", utils.DefaultNamespace) // ">", "kubeconfig.yaml")
-		output, err := cmd.Output()
-		Expect(err).NotTo(HaveOccurred())
-
-		kubeConfigName := "kubeconfig.yaml"
-		err = os.WriteFile(kubeConfigName, output, 0644)
+		kubeConfigName, _, err := utils.GetDownstreamKubeconfig(utils.ClusterName, utils.DownstreamKubeconfigOptions{
+			Namespace: utils.DefaultNamespace,
+			Source:    utils.KubeconfigSourceClusterctl,
+			Path:      "kubeconfig.yaml",
+		})
 		Expect(err).NotTo(HaveOccurred())
 		downstreamKubeconfig = kubeConfigName
 
-		By("Setting in kubeconfig server to cluster connect gateway")
-		cmd = exec.Command("sed", "-i", "s|http://[[:alnum:].-]*:8080/|http://127.0.0.1:8081/|", "kubeconfig.yaml")
-		_, err = cmd.Output()
+		downstream, err = utils.NewKubeClient(kubeConfigName)
 		Expect(err).NotTo(HaveOccurred())
 
 		By("Getting list of pods")
-		cmd = exec.Command("kubectl", "--kubeconfig", "kubeconfig.yaml", "get", "pods")
-		_, err = cmd.Output()
+		_, err = downstream.ListPods(downstream.Namespace, "")
 		Expect(err).NotTo(HaveOccurred())
 
 		// Exec into a pod in the kube-system namespace on the edge node cluster.
 		// Note: in k3s, control-plane components like scheduler are not necessarily exposed as pods.
 		By("Executing command in local-path-provisioner pod")
-		cmd = exec.Command("kubectl", "get", "pods", "-n", "kube-system", "-l", "app=local-path-provisioner",
-			"-o", "jsonpath={.items[0].metadata.name}", "--kubeconfig", "kubeconfig.yaml")
-		podNameBytes, err := cmd.Output()
+		podName, err := utils.LocalPathProvisionerPod(downstream)
 		Expect(err).NotTo(HaveOccurred())
-		podName := strings.TrimSpace(string(podNameBytes))
-		Expect(podName).NotTo(BeEmpty(), "local-path-provisioner pod name should not be empty")
 
-		cmd = exec.Command("kubectl", "exec", "--kubeconfig", "kubeconfig.yaml", "-it", "-n", "kube-system", podName, "--", "ls")
-		output, err = cmd.Output()
+		output, err := downstream.Exec("kube-system", podName, "ls")
 		Expect(err).NotTo(HaveOccurred())
 		By("Printing the output of the command")
-		fmt.Printf("Output of `ls` command:\n%s\n", string(output))
+		fmt.Printf("Output of `ls` command:\n%s\n", output)
 	})
 
 	It("Should verify that clusterConnect gateway probes the connection to cluster", func() {
 		By("Checking the clusterConnect's LastProbeSuccessTimestamp is not zero")
 		Eventually(func() bool {
 			// get all clusterconnects - there should be only one, pick its name
-			cmd := exec.Command("kubectl", "get", "clusterconnect", "-o", "jsonpath={.items[0].metadata.name}")
-			output, err := cmd.Output()
+			clusterConnectName, err := utils.ClusterConnectName()
 			if err != nil {
 				return false
 			}
-			clusterConnectName := string(output)
 			fmt.Printf("ClusterConnect Name: %s\n", clusterConnectName)
 
-			cmd = exec.Command("kubectl", "get", "clusterconnect", clusterConnectName, "-o", "jsonpath={.status.connectionProbe.lastProbeSuccessTimestamp}")
-			output, err = cmd.Output()
+			lastProbeSuccess, err := utils.LastProbeSuccess(clusterConnectName)
 			if err != nil {
 				return false
 			}
-			lastProbeSuccessTimestamp := string(output)
-			if lastProbeSuccessTimestamp == "" {
+			if lastProbeSuccess.IsZero() {
 				fmt.Println("LastProbeSuccessTimestamp is not set yet")
 				return false
 			}
-			fmt.Printf("LastProbeSuccessTimestamp: %s\n", lastProbeSuccessTimestamp)
-			return lastProbeSuccessTimestamp != ""
+			fmt.Printf("LastProbeSuccessTimestamp: %s\n", lastProbeSuccess.Format(time.RFC3339))
+			return true
 		}, 5*time.Minute, 10*time.Second).Should(BeTrue())
 	})
 
+	It("Should verify that clusterConnect keeps probing the connection at the configured cadence", func() {
+		interval, source := utils.ConnectionProbeInterval()
+		fmt.Printf("Connection probe interval: %v (%s)\n", interval, source)
+		window := probeCadenceWindow
+		if minWindow := probeCadenceIntervals * interval; window < minWindow {
+			window = minWindow
+		}
+
+		clusterConnectName, err := utils.ClusterConnectName()
+		Expect(err).NotTo(HaveOccurred())
+
+		By(fmt.Sprintf("Sampling the lastProbeSuccessTimestamp of %s for %v", clusterConnectName, window))
+		start := time.Now()
+		cadence := utils.SampleConnectionProbes(clusterConnectName, window, probeSamplePeriod)
+		fmt.Printf("\033[32mConnection probe cadence: %s\033[0m\n", cadence)
+
+		By("Verifying the Ready condition of the cluster did not flap meanwhile")
+		Expect(utils.CheckReadyFlapping(namespace, utils.ClusterName, start)).To(Succeed())
+
+		Expect(cadence.Updates).To(BeNumerically(">=", int(window/interval)/2), "the probe stopped succeeding: %s", cadence)
+		Expect(cadence.MedianGap).To(BeNumerically("~", interval, interval/2), "the probe does not run every %v: %s", interval, cadence)
+		Expect(cadence.MaxStaleness).To(BeNumerically("<=", 2*interval+probeSamplePeriod),
+			"the last successful probe fell behind: %s", cadence)
+	})
+
+	It("Should end an active exec session cleanly when the gateway restarts and accept a new one", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+
+		By("Creating a pod to exec into")
+		Expect(utils.CreateStreamPod(downstreamKubeconfig)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(utils.DeleteStreamPod(downstreamKubeconfig)).To(Succeed())
+		})
+
+		By("Starting a long-running exec session through the gateway")
+		session, err := utils.StartStreamPodExecSession(downstreamKubeconfig)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(session.Stop)
+		Eventually(session.Lines, execSessionOutputTimeout, time.Second).Should(BeNumerically(">=", execSessionLines),
+			"the exec session should stream its output")
+
+		By("Restarting the gateway pods mid-stream")
+		restartTime := time.Now()
+		Expect(utils.RestartGateway()).To(Succeed())
+
+		By("Verifying the exec session ends with an error rather than hanging")
+		err = session.Wait(execSessionEndTimeout)
+		Expect(errors.Is(err, utils.ErrStreamSessionHung)).To(BeFalse(), "the exec session should not hang: %v", err)
+		Expect(err).To(HaveOccurred(), "the exec session should fail rather than end as if it completed")
+		fmt.Printf("Exec session ended %v after the gateway restart: %v\n", time.Since(restartTime).Round(time.Second), err)
+
+		By("Waiting for the port-forward to reconnect to the new gateway pod")
+		Expect(utils.WaitForGatewayReady(utils.ComponentReadyTimeout)).To(Succeed())
+		// A failing exec below is then the agent reconnecting late, not the gateway still starting.
+		health := utils.GetGatewayHealth()
+		Expect(health.Serving()).To(BeTrue(), "%s", health)
+
+		By(fmt.Sprintf("Establishing a new exec session within the reconnect budget of %v", utils.ReconnectRecoveryBudget()))
+		remaining := utils.ReconnectRecoveryBudget() - time.Since(restartTime)
+		Expect(remaining).To(BeNumerically(">", 0), "the gateway restart took longer than the reconnect budget")
+		Eventually(func() (string, error) {
+			return utils.ExecStreamPodWithStdin(downstreamKubeconfig, "", "echo reconnected")
+		}, remaining, 5*time.Second).Should(ContainSubstring("reconnected"),
+			"see %s", utils.ReconnectRecoveryBudgetEnvVar)
+		fmt.Printf("\033[32mNew exec session established %v after the gateway restart 🔁\033[0m\n", time.Since(restartTime).Round(time.Second))
+	})
+
+	for _, degradation := range utils.DefaultNetworkDegradationLevels {
+		It(fmt.Sprintf("Should keep the connect agent connected under %s network degradation", degradation.Name), func() {
+			if supported, reason := utils.EdgeNodeSupportsNetem(); !supported {
+				Skip("network degradation cannot be injected on the edge node: " + reason)
+			}
+			Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+
+			By(fmt.Sprintf("Degrading the agent to gateway path: %s", degradation))
+			start := time.Now()
+			Expect(utils.ApplyNetworkDegradation(degradation, networkDegradationWindow)).To(Succeed())
+			DeferCleanup(utils.ClearNetworkDegradation)
+
+			By("Verifying the cluster does not report the connect agent as disconnected")
+			Consistently(func() bool {
+				// A failing lookup says nothing about the connection; only a reported loss counts.
+				lost, err := utils.CheckLostConnection("", namespace, utils.ClusterName)
+				return err == nil && lost
+			}, networkDegradationWindow, 15*time.Second).Should(BeFalse())
+
+			By("Verifying the Ready condition of the cluster did not flap between the polls")
+			Expect(utils.CheckReadyFlapping(namespace, utils.ClusterName, start)).To(Succeed())
+
+			By("Verifying the downstream API is still reachable through the gateway")
+			Eventually(func() error {
+				_, err := downstream.ListPods("kube-system", "")
+				return err
+			}, 2*time.Minute, 10*time.Second).Should(Succeed())
+		})
+	}
+
+	It("Should verify that the connect agent recovers from a DNS outage on the edge node", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+		agent, err := utils.FindConnectAgentWorkload(downstreamKubeconfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Breaking DNS resolution on the edge node")
+		Expect(utils.BreakEdgeNodeDNS(dnsOutageWindow)).To(Succeed())
+		DeferCleanup(utils.RestoreEdgeNodeDNS)
+		dnsOutageStartTime := time.Now()
+		Eventually(utils.EdgeNodeResolvesNames, 1*time.Minute, 5*time.Second).Should(BeFalse())
+
+		By("Restarting the connect agent so it has to resolve the gateway again")
+		Expect(downstream.RestartWorkload(agent.Namespace, agent.Kind, agent.Name)).To(Succeed())
+
+		By("Waiting for the connection loss to be detected within the detection budget")
+		lostCondition, err := wait.WaitForConnectionLost(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		detection := time.Since(dnsOutageStartTime)
+		fmt.Printf("Connection loss reported by %s\n", lostCondition)
+		fmt.Printf("\033[32mTotal time from breaking DNS to detect connection lost: %v 🚨🛜\033[0m\n", detection)
+		Expect(detection).To(BeNumerically("<=", utils.DisconnectDetectionBudget()), "the cluster reported the connection loss after %v", detection)
+
+		By("Restoring DNS resolution on the edge node")
+		Expect(utils.RestoreEdgeNodeDNS()).To(Succeed())
+		dnsRestoredTime := time.Now()
+		Eventually(utils.EdgeNodeResolvesNames, 1*time.Minute, 5*time.Second).Should(BeTrue())
+
+		By("Waiting for all components to be ready again within the recovery budget, without recreating the cluster")
+		recoveryBudget := utils.ReconnectRecoveryBudget()
+		ctx, cancel := context.WithTimeout(context.Background(), recoveryBudget)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		Eventually(func() error {
+			_, err := downstream.ListPods("kube-system", "")
+			return err
+		}, 2*time.Minute, 10*time.Second).Should(Succeed())
+		recovery := time.Since(dnsRestoredTime)
+		fmt.Printf("\033[32mTotal time from restoring DNS to recover: %v 🚨🛜 ✅\033[0m\n", recovery)
+		Expect(recovery).To(BeNumerically("<=", recoveryBudget), "the cluster recovered after %v", recovery)
+	})
+
 	It("Should verify that a cluster shows connection lost status when connect agent stops working", func() {
 		By("Breaking the connect agent via downstream Kubernetes (patch workload image)")
 		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
-		var err error
-		connectAgentKind, connectAgentNamespace, connectAgentName, err = getConnectAgentWorkload(downstreamKubeconfig)
+		connectAgent, err := utils.FindConnectAgentWorkload(downstreamKubeconfig)
 		Expect(err).NotTo(HaveOccurred())
-		connectAgentImage, err = getWorkloadImage(downstreamKubeconfig, connectAgentKind, connectAgentNamespace, connectAgentName)
+		// The image pull outage spec needs the registry of the agent after this cluster is gone.
+		connectAgentImage, err = faults.GetWorkloadImage(downstreamKubeconfig, connectAgent)
 		Expect(err).NotTo(HaveOccurred())
-		// Set a clearly invalid image to force the workload to fail pulling/starting.
-		err = setWorkloadImage(downstreamKubeconfig, connectAgentKind, connectAgentNamespace, connectAgentName, "invalid.invalid/connect-agent:does-not-exist")
+		restoreConnectAgent, err = faults.BreakWorkloadImage(downstreamKubeconfig, connectAgent)
 		Expect(err).NotTo(HaveOccurred())
 		connectionLostStartTime := time.Now()
 
 		By("Waiting for intel infra provider to detect connection lost")
-		Eventually(func() bool {
-			cmd := exec.Command("clusterctl", "describe", "cluster", utils.ClusterName, "-n", namespace)
-			output, err := cmd.Output()
-			if err != nil {
-				return false
-			}
-			fmt.Printf("Cluster components status:\n%s\n", string(output))
-			return utils.CheckLostConnection(string(output))
-		}, 10*time.Minute, 10*time.Second).Should(BeTrue())
+		lostCondition, err := wait.WaitForProviderCondition(namespace, utils.ClusterName, "", "False", utils.ConnectAgentDisconnectedReason, 10*time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("Connection loss reported by %s\n", lostCondition)
+		gatewayDown, diagnosis := utils.DiagnoseConnectionLoss()
+		fmt.Printf("Connection loss diagnosis: %s\n", diagnosis)
+		Expect(gatewayDown).To(BeFalse(), "the connect agent was broken, but the gateway is not serving")
 		// Record the end time after the cluster is fully active
 		connectionLostEndTime := time.Now()
 
 		// Calculate and print the total time taken to detect connection lost
 		totalTime := connectionLostEndTime.Sub(connectionLostStartTime)
 		fmt.Printf("\033[32mTotal time from breaking connect-agent to detect connection lost: %v 🚨🛜\033[0m\n", totalTime)
+		if timeline := utils.CurrentConditionTimeline(); timeline != nil {
+			if entry, ok := timeline.FirstChange("Cluster", "Ready", "False"); ok && !entry.LastTransitionTime.IsZero() {
+				fmt.Printf("Cluster Ready condition turned %s %v after breaking connect-agent\n", entry.To, entry.LastTransitionTime.Sub(connectionLostStartTime).Round(time.Second))
+			}
+		}
+
+		By("Verifying the connection loss was reported within the detection budget")
+		detectionBudget := utils.DisconnectDetectionBudget()
+		Expect(totalTime).To(BeNumerically("<=", detectionBudget), "the cluster reported the connection loss after %v", totalTime)
+		var providerStatusLost utils.StatusTransition
+		Eventually(func() bool {
+			var found bool
+			providerStatusLost, found = utils.FirstTransitionSince(statusRecorder.Transitions(), utils.FieldProviderStatus, connectionLostStartTime,
+				func(state string) bool { return state != string(api.STATUSINDICATIONIDLE) })
+			return found
+		}, 2*time.Minute, statusPollInterval).Should(BeTrue(), "cluster-manager never reported the providerStatus as not idle")
+		providerStatusDetection := providerStatusLost.At.Sub(connectionLostStartTime)
+		fmt.Printf("providerStatus turned %s %v after breaking connect-agent\n", providerStatusLost.To, providerStatusDetection.Round(time.Second))
+		Expect(providerStatusDetection).To(BeNumerically("<=", detectionBudget), "the providerStatus reported the connection loss after %v", providerStatusDetection)
+		if timeline := utils.CurrentConditionTimeline(); timeline != nil {
+			entry, ok := timeline.FirstChangeSince("ClusterConnect", "False", connectionLostStartTime)
+			Expect(ok).To(BeTrue(), "no ClusterConnect condition turned False after breaking connect-agent")
+			clusterConnectDetection := entry.At.Sub(connectionLostStartTime)
+			fmt.Printf("ClusterConnect %s condition turned %s %v after breaking connect-agent\n", entry.Condition, entry.To, clusterConnectDetection.Round(time.Second))
+			Expect(clusterConnectDetection).To(BeNumerically("<=", detectionBudget), "ClusterConnect reported the connection loss after %v", clusterConnectDetection)
+		}
 
 		By("Getting the cluster information about lost connection")
 		resp, err := utils.GetClusterInfo(namespace, utils.ClusterName)
@@ -342,24 +470,12 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 	It("Should verify that cluster mark infrastructure as ready when connect-agent is fixed", func() {
 		By("Fixing the connect agent by restoring its workload image")
 		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
-		Expect(connectAgentKind).NotTo(BeEmpty(), "connect-agent workload kind should be known")
-		Expect(connectAgentNamespace).NotTo(BeEmpty(), "connect-agent workload namespace should be known")
-		Expect(connectAgentName).NotTo(BeEmpty(), "connect-agent workload name should be known")
-		Expect(connectAgentImage).NotTo(BeEmpty(), "connect-agent original image should be known")
-		err := setWorkloadImage(downstreamKubeconfig, connectAgentKind, connectAgentNamespace, connectAgentName, connectAgentImage)
-		Expect(err).NotTo(HaveOccurred())
+		Expect(restoreConnectAgent).NotTo(BeNil(), "the connect agent should have been broken")
+		Expect(restoreConnectAgent()).To(Succeed())
 		connectionRecoveredStartTime := time.Now()
 
 		By("Waiting for all components to be ready again")
-		Eventually(func() bool {
-			cmd := exec.Command("clusterctl", "describe", "cluster", utils.ClusterName, "-n", namespace)
-			output, err := cmd.Output()
-			if err != nil {
-				return false
-			}
-			fmt.Printf("Cluster components status:\n%s\n", string(output))
-			return utils.CheckAllComponentsReady(string(output))
-		}, 5*time.Minute, 10*time.Second).Should(BeTrue())
+		Expect(wait.WaitForClusterReady(namespace, utils.ClusterName)).To(Succeed())
 
 		connectionRecoveredEndTime := time.Now()
 
@@ -367,5 +483,327 @@ var _ = Describe("Cluster Orch Robustness tests", Ordered, Label(utils.ClusterOr
 		totalTime := connectionRecoveredEndTime.Sub(connectionRecoveredStartTime)
 		fmt.Printf("\033[32mTotal time from breaking connect-agent to recover from connection lost: %v 🚨🛜 ✅\033[0m\n", totalTime)
 
+		By("Verifying the connection was reported again within the recovery budget")
+		recoveryBudget := utils.ReconnectRecoveryBudget()
+		Expect(totalTime).To(BeNumerically("<=", recoveryBudget), "the cluster became ready again after %v", totalTime)
+		var providerStatusRecovered utils.StatusTransition
+		Eventually(func() bool {
+			var found bool
+			providerStatusRecovered, found = utils.FirstTransitionSince(statusRecorder.Transitions(), utils.FieldProviderStatus, connectionRecoveredStartTime,
+				func(state string) bool { return state == string(api.STATUSINDICATIONIDLE) })
+			return found
+		}, 2*time.Minute, statusPollInterval).Should(BeTrue(), "cluster-manager never reported the providerStatus as idle again")
+		providerStatusRecovery := providerStatusRecovered.At.Sub(connectionRecoveredStartTime)
+		fmt.Printf("providerStatus turned %s %v after fixing connect-agent\n", providerStatusRecovered.To, providerStatusRecovery.Round(time.Second))
+		Expect(providerStatusRecovery).To(BeNumerically("<=", recoveryBudget), "the providerStatus recovered after %v", providerStatusRecovery)
+		if timeline := utils.CurrentConditionTimeline(); timeline != nil {
+			entry, ok := timeline.FirstChangeSince("ClusterConnect", "True", connectionRecoveredStartTime)
+			Expect(ok).To(BeTrue(), "no ClusterConnect condition turned True after fixing connect-agent")
+			clusterConnectRecovery := entry.At.Sub(connectionRecoveredStartTime)
+			fmt.Printf("ClusterConnect %s condition turned %s %v after fixing connect-agent\n", entry.Condition, entry.To, clusterConnectRecovery.Round(time.Second))
+			Expect(clusterConnectRecovery).To(BeNumerically("<=", recoveryBudget), "ClusterConnect recovered after %v", clusterConnectRecovery)
+		}
+
+	})
+
+	It("Should report a broken and restored connect agent consistently in the gateway metrics, ClusterConnect and providerStatus", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+		agent, err := utils.FindConnectAgentWorkload(downstreamKubeconfig)
+		Expect(err).NotTo(HaveOccurred())
+		clusterConnectName, err := utils.ClusterConnectName()
+		Expect(err).NotTo(HaveOccurred())
+		probeInterval, _ := utils.ConnectionProbeInterval()
+		window := utils.ConnectionCorrelationWindow()
+
+		By("Sampling the gateway metrics, the ClusterConnect probe and the providerStatus together")
+		correlator := utils.NewConnectionCorrelator(namespace, utils.ClusterName, clusterConnectName, probeInterval)
+		correlator.Start(correlationSamplePeriod)
+		defer correlator.Stop()
+
+		By("Breaking the connect agent")
+		restore, err := faults.BreakWorkloadImage(downstreamKubeconfig, agent)
+		Expect(err).NotTo(HaveOccurred())
+		restored := false
+		defer func() {
+			if !restored {
+				Expect(restore()).To(Succeed())
+			}
+		}()
+		brokenAt := time.Now()
+		var loss utils.ConnectionChange
+		Eventually(func() bool {
+			loss = utils.ConnectionLoss(correlator.Snapshots(), brokenAt)
+			return !loss.ProbeStatus.IsZero() && !loss.ProviderStatus.IsZero()
+		}, correlationTimeout, correlationSamplePeriod).Should(BeTrue(), "the connection loss was not reported: %s", &loss)
+		fmt.Printf("Connection loss reported by %s\n", loss)
+		Expect(utils.LossDisagreements(loss, window)).To(BeEmpty())
+
+		By("Restoring the connect agent")
+		Expect(restore()).To(Succeed())
+		restored = true
+		restoredAt := time.Now()
+		var recovery utils.ConnectionChange
+		Eventually(func() bool {
+			recovery = utils.ConnectionRecovery(correlator.Snapshots(), restoredAt)
+			return !recovery.GatewayMetrics.IsZero() && !recovery.ProbeStatus.IsZero() && !recovery.ProviderStatus.IsZero()
+		}, correlationTimeout, correlationSamplePeriod).Should(BeTrue(), "the connection recovery was not reported: %s", &recovery)
+		fmt.Printf("Connection recovery reported by %s\n", recovery)
+		Expect(utils.RecoveryDisagreements(recovery, window)).To(BeEmpty())
+
+		Expect(wait.WaitForClusterReady(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("Should remediate or report an unhealthy node through the MachineHealthCheck", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+
+		By("Reading the MachineHealthCheck of the cluster")
+		timeout, found, err := utils.GetMachineHealthCheckTimeout(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		if !found {
+			Skip("the cluster has no MachineHealthCheck")
+		}
+		machinesBefore, err := utils.ListClusterMachineUIDs(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinesBefore).NotTo(BeEmpty(), "the cluster should have machines")
+
+		By("Making the edge node NotReady")
+		Expect(utils.MakeEdgeNodeNotReady(timeout + nodeUnhealthyMargin)).To(Succeed())
+		DeferCleanup(utils.RestoreEdgeNodeReadiness)
+		notReadyStartTime := time.Now()
+
+		By("Waiting for the downstream node to report NotReady")
+		Eventually(func() string {
+			return nodeConditionStatuses(downstream, corev1.NodeReady)
+		}, 3*time.Minute, 10*time.Second).Should(Or(ContainSubstring("False"), ContainSubstring("Unknown")))
+
+		By(fmt.Sprintf("Waiting for the MachineHealthCheck to act on its %v timeout", timeout))
+		var outcome utils.MachineHealthOutcome
+		Eventually(func() utils.MachineHealthOutcome {
+			outcome, err = utils.GetMachineHealthOutcome(namespace, utils.ClusterName, machinesBefore)
+			if err != nil {
+				fmt.Printf("Failed to check the machines: %v\n", err)
+			}
+			return outcome
+		}, timeout+nodeUnhealthyMargin, 15*time.Second).ShouldNot(Equal(utils.MachineHealthPending))
+		fmt.Printf("\033[32mMachine %s %v after the node turned NotReady 🩺\033[0m\n", outcome, time.Since(notReadyStartTime).Round(time.Second))
+
+		if outcome == utils.MachineHealthUnhealthy {
+			By("Verifying cluster-manager reports the node as not healthy")
+			Eventually(func() string {
+				cluster, err := utils.GetClusterDetail(namespace, utils.ClusterName)
+				if err != nil || cluster.NodeHealth == nil || cluster.NodeHealth.Indicator == nil {
+					return ""
+				}
+				return string(*cluster.NodeHealth.Indicator)
+			}, 2*time.Minute, 10*time.Second).Should(SatisfyAll(Not(BeEmpty()), Not(Equal(string(api.STATUSINDICATIONIDLE)))))
+		}
+
+		By("Restoring the edge node readiness")
+		Expect(utils.RestoreEdgeNodeReadiness()).To(Succeed())
+		readinessRestoredTime := time.Now()
+
+		By("Waiting for all components to be ready again")
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		fmt.Printf("\033[32mTotal time from restoring the node to recover: %v 🩺 ✅\033[0m\n", time.Since(readinessRestoredTime).Round(time.Second))
+	})
+
+	It("Should report disk pressure on the edge node and recover once space is freed", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+		machinesBefore, err := utils.ListClusterMachineUIDs(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		nodeHealth := func() string {
+			cluster, err := utils.GetClusterDetail(namespace, utils.ClusterName)
+			if err != nil || cluster.NodeHealth == nil || cluster.NodeHealth.Indicator == nil {
+				return ""
+			}
+			return string(*cluster.NodeHealth.Indicator)
+		}
+		diskPressure := func() string {
+			return nodeConditionStatuses(downstream, corev1.NodeDiskPressure)
+		}
+
+		By("Filling the disk of the edge node data directory")
+		Expect(utils.FillEdgeNodeDisk(diskPressureWindow)).To(Succeed())
+		DeferCleanup(utils.FreeEdgeNodeDisk)
+		diskFilledTime := time.Now()
+		free, err := utils.EdgeNodeDiskFreePercent()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(free).To(BeNumerically("<=", utils.DiskPressureFreePercent), "the disk of the edge node was not filled")
+
+		By("Verifying cluster-manager reports the node as degraded")
+		// The kubelet evicts pods under disk pressure, the connect agent included, so the downstream
+		// condition is only printed while the cluster status is asserted.
+		Eventually(nodeHealth, diskPressureDetectionTimeout, 10*time.Second).Should(SatisfyAll(Not(BeEmpty()), Not(Equal(string(api.STATUSINDICATIONIDLE)))))
+		fmt.Printf("\033[32mNode health reported as degraded %v after filling the disk 💾\033[0m\n", time.Since(diskFilledTime).Round(time.Second))
+		fmt.Printf("Downstream DiskPressure condition: %s\n", diskPressure())
+
+		By("Freeing the disk space")
+		Expect(utils.FreeEdgeNodeDisk()).To(Succeed())
+		diskFreedTime := time.Now()
+
+		By("Waiting for the cluster to be ready again without recreating it")
+		// The kubelet keeps DiskPressure for its 5m eviction pressure transition period after space is freed.
+		Eventually(diskPressure, 15*time.Minute, 15*time.Second).Should(Equal("False"))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		Eventually(nodeHealth, 5*time.Minute, 10*time.Second).Should(Equal(string(api.STATUSINDICATIONIDLE)))
+		machinesAfter, err := utils.ListClusterMachineUIDs(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinesAfter).To(Equal(machinesBefore), "the machines of the cluster should not have been replaced")
+		fmt.Printf("\033[32mTotal time from freeing the disk to recover: %v 💾 ✅\033[0m\n", time.Since(diskFreedTime).Round(time.Second))
+	})
+
+	It("Should recover a cluster whose k3s is started with a different token than its datastore", func() {
+		Expect(downstreamKubeconfig).NotTo(BeEmpty(), "downstream kubeconfig should be available")
+		runsK3s, err := utils.EdgeNodeRunsK3s()
+		Expect(err).NotTo(HaveOccurred())
+		if !runsK3s {
+			Skip("the edge node does not run k3s")
+		}
+		machinesBefore, err := utils.ListClusterMachineUIDs(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Restarting k3s with a different token")
+		mismatchTime := time.Now()
+		Expect(utils.MismatchEdgeNodeK3sToken(k3sTokenMismatchWindow)).To(Succeed())
+		DeferCleanup(utils.RestoreEdgeNodeK3sToken)
+
+		By("Waiting for k3s to refuse the token")
+		// The edge node clock may lag behind, so the journal is searched from a bit earlier.
+		Eventually(func() (bool, error) {
+			return utils.EdgeNodeK3sTokenMismatchLogged(mismatchTime.Add(-30 * time.Second))
+		}, 3*time.Minute, 10*time.Second).Should(BeTrue(), "k3s did not log %q", utils.K3sTokenMismatchMessage)
+		Eventually(func() error {
+			_, err := downstream.GetRaw("/readyz", 15*time.Second)
+			return err
+		}, 3*time.Minute, 10*time.Second).ShouldNot(Succeed(), "the downstream API should be down while k3s cannot start")
+		fmt.Printf("\033[32mk3s refused the mismatched token %v after the restart 🔑\033[0m\n", time.Since(mismatchTime).Round(time.Second))
+
+		By("Starting k3s with the token its datastore was encrypted with")
+		Expect(utils.RestoreEdgeNodeK3sToken()).To(Succeed())
+		tokenRestoredTime := time.Now()
+
+		By("Waiting for the cluster to be ready again without recreating it")
+		Eventually(func() error {
+			_, err := downstream.GetRaw("/readyz", 30*time.Second)
+			return err
+		}, 10*time.Minute, 10*time.Second).Should(Succeed())
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		machinesAfter, err := utils.ListClusterMachineUIDs(namespace, utils.ClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinesAfter).To(Equal(machinesBefore), "the machines of the cluster should not have been replaced")
+		fmt.Printf("\033[32mTotal time from restoring the k3s token to recover: %v 🔑 ✅\033[0m\n", time.Since(tokenRestoredTime).Round(time.Second))
+	})
+
+	It("Should only walk the documented status state machine from create to delete", func() {
+		Expect(statusRecorder).NotTo(BeNil(), "status recorder should have been started with the cluster")
+
+		if !utils.SkipDeleteCluster {
+			By("Deleting the cluster")
+			Expect(utils.DeleteCluster(namespace)).To(Succeed())
+			clusterDeleted = true
+
+			By("Waiting for cluster-manager to stop reporting the cluster")
+			Eventually(func() string {
+				return statusRecorder.State(utils.FieldLifecyclePhase)
+			}, 5*time.Minute, 5*time.Second).Should(Equal(utils.StatusDeleted))
+			Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+		}
+		statusRecorder.Stop()
+		statusRecorder.PrintTransitions()
+		transitions := statusRecorder.Transitions()
+
+		By("Verifying no illegal transitions or flapping were observed")
+		Expect(utils.CheckStatusTransitions(transitions, utils.StatusFlapWindow)).To(BeEmpty())
+
+		By("Verifying the disconnect and reconnect were reported")
+		Expect(utils.StatusPathObserved(transitions, utils.FieldLifecyclePhase,
+			utils.LifecycleActive, utils.LifecycleProvisioned, utils.LifecycleActive)).To(BeTrue(),
+			"lifecyclePhase should go from active to provisioned and back while the connect agent is down")
+		Expect(utils.StatusPathObserved(transitions, utils.FieldProviderStatus,
+			string(api.STATUSINDICATIONIDLE), string(api.STATUSINDICATIONERROR), string(api.STATUSINDICATIONIDLE))).To(BeTrue(),
+			"providerStatus should report the disconnected agent as an error and recover")
+
+		if !utils.SkipDeleteCluster {
+			Expect(utils.StatusPathObserved(transitions, utils.FieldLifecyclePhase, utils.LifecycleActive, utils.StatusDeleted)).To(BeTrue(),
+				"lifecyclePhase should end with the cluster deleted")
+		}
+	})
+
+	It("Should create a cluster whose connect-agent image cannot be pulled at first", func() {
+		if utils.SkipDeleteCluster {
+			Skip("the cluster of the previous specs is kept, the edge node cannot host another one")
+		}
+		Expect(clusterDeleted).To(BeTrue(), "the previous cluster should have been deleted")
+		Expect(connectAgentImage).NotTo(BeEmpty(), "connect-agent original image should be known")
+		registry := utils.ImageRegistryHost(connectAgentImage)
+		if registry == "docker.io" {
+			Skip("connect-agent is pulled from docker.io, breaking it would break the k3s system images too")
+		}
+
+		By(fmt.Sprintf("Making registry %s unreachable from the edge node", registry))
+		Expect(utils.BreakEdgeNodeRegistry(registry, imagePullOutageWindow)).To(Succeed())
+		DeferCleanup(utils.RestoreEdgeNodeRegistry)
+
+		By("Creating the cluster")
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)).To(Succeed())
+		clusterDeleted = false
+		outageStartTime := time.Now()
+
+		// The status may go through several reasons, but while the agent cannot start it has to say why.
+		By("Waiting for the cluster status to report the image pull problem")
+		seen := map[string]bool{}
+		Eventually(func() bool {
+			cluster, err := utils.GetClusterDetail(namespace, utils.ClusterName)
+			if err != nil {
+				return false
+			}
+			for _, message := range utils.ClusterStatusMessages(cluster) {
+				if !seen[message] {
+					seen[message] = true
+					fmt.Printf("Cluster status message after %v: %s\n", time.Since(outageStartTime).Round(time.Second), message)
+				}
+				if utils.IsImagePullProblem(message) {
+					return true
+				}
+			}
+			return false
+		}, imagePullOutageWindow, 10*time.Second).Should(BeTrue(),
+			"no status message mentions the failing connect-agent image pull, seen: %v", seen)
+		fmt.Printf("\033[32mImage pull problem reported %v after creating the cluster 🚨📦\033[0m\n", time.Since(outageStartTime).Round(time.Second))
+
+		By("Keeping the registry unreachable for the rest of the outage")
+		time.Sleep(time.Until(outageStartTime.Add(imagePullOutageWindow)))
+
+		By("Making the registry reachable again")
+		Expect(utils.RestoreEdgeNodeRegistry()).To(Succeed())
+		registryRestoredTime := time.Now()
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+		fmt.Printf("\033[32mTotal time from restoring the registry to cluster ready: %v 📦 ✅\033[0m\n", time.Since(registryRestoredTime).Round(time.Second))
+
+		By("Verifying the recovered cluster no longer reports the image pull problem")
+		Eventually(func() []string {
+			cluster, err := utils.GetClusterDetail(namespace, utils.ClusterName)
+			if err != nil {
+				return []string{err.Error()}
+			}
+			var problems []string
+			for _, message := range utils.ClusterStatusMessages(cluster) {
+				if utils.IsImagePullProblem(message) {
+					problems = append(problems, message)
+				}
+			}
+			return problems
+		}, 2*time.Minute, 10*time.Second).Should(BeEmpty())
 	})
 })
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package scale_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+func TestScaleTests(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting cluster orch scale tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "cluster orch scale test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+var _ = utils.RegisterSharedClusters()
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+// The clusters are created at once, one per edge node of utils.ScaleNodeGUIDs, and the p95 of their
+// time-to-ready must stay under utils.ScaleReadyP95.
+var _ = Describe("Parallel cluster creation at scale", Ordered, Label(utils.ClusterOrchScaleTest), func() {
+	var (
+		authContext *auth.TestAuthContext
+		namespace   string
+		nodeGUIDs   []string
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+		var err error
+		nodeGUIDs, err = utils.ScaleNodeGUIDs(utils.ScaleClusterCount())
+		Expect(err).NotTo(HaveOccurred())
+
+		if !utils.AuthDisabled() {
+			By("Setting up JWT authentication")
+			authContext, err = utils.SetupTestAuthentication("test-user")
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		By("Importing the cluster template")
+		if authContext != nil {
+			Expect(utils.ImportClusterTemplateAuthenticated(authContext, namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		} else {
+			Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		}
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	It("should bring every cluster to ready within the p95 budget", func() {
+		DeferCleanup(func() {
+			By("Deleting the clusters")
+			for i := range nodeGUIDs {
+				clusterName := fmt.Sprintf("%s%d", utils.ScaleClusterPrefix, i)
+				if err := utils.DeleteNamedClusterAuthenticated(authContext, namespace, clusterName); err != nil {
+					Expect(err).To(utils.HaveAPIErrorCode(utils.APIErrorNotFound))
+				}
+			}
+			for i := range nodeGUIDs {
+				Expect(wait.WaitForClusterDeleted(namespace, fmt.Sprintf("%s%d", utils.ScaleClusterPrefix, i))).To(Succeed())
+			}
+		})
+
+		By(fmt.Sprintf("Creating %d clusters at once", len(nodeGUIDs)))
+		creations := utils.CreateClusterN(authContext, namespace, utils.K3sTemplateName, nodeGUIDs,
+			func(clusterName string) error {
+				return wait.WaitForClusterReady(namespace, clusterName)
+			})
+
+		report := utils.NewScaleReport(creations)
+		report.Print()
+		if path, err := report.WriteReport(); err != nil {
+			fmt.Printf("Failed to write scale report: %v\n", err)
+		} else {
+			fmt.Printf("Scale report written to %s\n", path)
+		}
+
+		Expect(report.Failures).To(BeZero(), "every cluster should become ready")
+		Expect(report.P95).To(BeNumerically("<=", utils.ScaleReadyP95()),
+			"the p95 time-to-ready should stay within %s", utils.ScaleReadyP95EnvVar)
+	})
+})
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package slow_registry_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// slowRegistryTimeoutEnvVar overrides how long a cluster may take to become ready behind the throttled proxy.
+	slowRegistryTimeoutEnvVar  = "THROTTLED_REGISTRY_TIMEOUT"
+	defaultSlowRegistryTimeout = 45 * time.Minute
+
+	clusterReadinessInterval = 15 * time.Second
+)
+
+func TestSlowRegistryTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting slow registry tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "slow registry test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+// followLifecyclePhase prints every new lifecycle phase message of the cluster until ctx ends. Once the cluster
+// reports an error it calls stop, ending the wait for its readiness, and returns the error.
+func followLifecyclePhase(ctx context.Context, stop context.CancelFunc, namespace string, start time.Time) error {
+	var lastMessage string
+	ticker := time.NewTicker(clusterReadinessInterval)
+	defer ticker.Stop()
+	for {
+		if cluster, err := utils.GetClusterDetail(namespace, utils.ClusterName); err == nil && cluster.LifecyclePhase != nil {
+			phase := cluster.LifecyclePhase
+			message := ""
+			if phase.Message != nil {
+				message = *phase.Message
+			}
+			if message != lastMessage {
+				fmt.Printf("[%v] lifecycle phase: %s\n", time.Since(start).Round(time.Second), message)
+				lastMessage = message
+			}
+			if phase.Indicator != nil && *phase.Indicator == api.STATUSINDICATIONERROR {
+				stop()
+				return fmt.Errorf("cluster reported an error while images were still being pulled: %s", message)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+var _ = Describe("Cluster creation with bandwidth-throttled image pulls", Ordered, Label(utils.ClusterOrchSlowRegistryTest), func() {
+	var (
+		namespace        string
+		nodeGUID         string
+		readinessTimeout time.Duration
+		proxyStarted     bool
+		mirrorConfigured bool
+		setUp            bool
+	)
+
+	BeforeAll(func() {
+		if !utils.ThrottledRegistryEnabled() {
+			Skip(fmt.Sprintf("slow registry mode is disabled; run with %s=true", utils.ThrottledRegistryEnvVar))
+		}
+
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		var err error
+		readinessTimeout, err = time.ParseDuration(utils.GetEnv(slowRegistryTimeoutEnvVar, defaultSlowRegistryTimeout.String()))
+		Expect(err).NotTo(HaveOccurred(), "invalid %s", slowRegistryTimeoutEnvVar)
+
+		By("Starting the throttled registry proxy")
+		rate := utils.GetEnv(utils.ThrottledRegistryRateEnvVar, utils.DefaultThrottledRegistryRate)
+		Expect(utils.StartThrottledRegistryProxy(rate)).To(Succeed())
+		proxyStarted = true
+
+		By("Pointing the edge node at the registry proxy")
+		hostAddr, err := utils.EdgeNodeSSHClientAddress()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utils.ConfigureEdgeNodeRegistryMirror(fmt.Sprintf("http://%s:%s", hostAddr, utils.ThrottledRegistryPort))).To(Succeed())
+		mirrorConfigured = true
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		defer func() {
+			if mirrorConfigured {
+				if err := utils.RemoveEdgeNodeRegistryMirror(); err != nil {
+					fmt.Printf("Failed to remove registry mirror: %v\n", err)
+				}
+			}
+			if proxyStarted {
+				if err := utils.StopThrottledRegistryProxy(); err != nil {
+					fmt.Printf("Failed to stop registry proxy: %v\n", err)
+				}
+			}
+		}()
+
+		if !setUp || utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		if err := utils.DeleteCluster(namespace); err != nil {
+			fmt.Printf("Failed to delete cluster: %v\n", err)
+			return
+		}
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+	})
+
+	It("should import the cluster template", func() {
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	It("should create a cluster while reporting progress instead of errors", func() {
+		start := time.Now()
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.K3sTemplateName)).To(Succeed())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), readinessTimeout)
+		defer cancel()
+		phaseErr := make(chan error, 1)
+		go func() {
+			phaseErr <- followLifecyclePhase(ctx, cancel, namespace, start)
+		}()
+		err := wait.ClusterReady(ctx, namespace, utils.ClusterName)
+		cancel()
+		Expect(<-phaseErr).NotTo(HaveOccurred())
+		Expect(err).NotTo(HaveOccurred())
+
+		fmt.Printf("\033[32mTotal time from cluster creation to fully active behind the throttled registry: %v 🐢 ✅\033[0m\n", time.Since(start))
+	})
+
+	It("should have pulled the images through the throttled proxy", func() {
+		pulls, err := utils.ThrottledRegistryBlobPulls()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pulls).To(BeNumerically(">", 0), "the edge node did not pull any image through the registry proxy")
+	})
+})
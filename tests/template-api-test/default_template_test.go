@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_api_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	defaultTemplateClusterName = "default-template-cluster"
+
+	// noClusterPeriod leaves cluster-manager time to create a cluster it should not have created.
+	noClusterPeriod   = 10 * time.Second
+	noClusterInterval = 2 * time.Second
+)
+
+var _ = Describe("Cluster creation with the default template", Ordered, Label(utils.ClusterOrchTemplateApiAllTest), func() {
+	var (
+		namespace      string
+		nodeGUID       string
+		clusterCreated bool
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Deleting the templates also drops the default, whichever spec set it.
+		By("Importing the cluster template k3s baseline")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		if clusterCreated {
+			By("Deleting the cluster created from the default template")
+			Expect(utils.DeleteNamedCluster(namespace, defaultTemplateClusterName)).To(Succeed())
+			Expect(wait.WaitForClusterDeleted(namespace, defaultTemplateClusterName)).To(Succeed())
+		}
+
+		By("Deleting all templates in the namespace")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+	})
+
+	It("should reject a cluster without a template when no default template is set", func() {
+		defaultTemplateInfo, err := utils.GetDefaultTemplate(namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(defaultTemplateInfo).To(BeNil(), "no default template should be set")
+
+		By("Creating a cluster without a template")
+		err = utils.CreateNamedCluster(namespace, defaultTemplateClusterName, nodeGUID, "", utils.ClusterConfigOptions{})
+		Expect(err).To(HaveOccurred(), "a cluster without a template and no default should be rejected")
+		Expect(err).To(utils.HaveAPIErrorCode(utils.APIErrorBadRequest))
+		Expect(err).To(utils.HaveAPIErrorMessage(ContainSubstring("template")))
+
+		By("Checking that no cluster was created")
+		Consistently(func() bool {
+			return utils.ManagedClusterExists("", namespace, defaultTemplateClusterName)
+		}, noClusterPeriod, noClusterInterval).Should(BeFalse())
+	})
+
+	It("should create a cluster from the default template when no template is given", func() {
+		By("Setting the k3s baseline template as the default")
+		Expect(utils.SetDefaultTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)).To(Succeed())
+
+		By("Creating a cluster without a template")
+		err := utils.CreateNamedCluster(namespace, defaultTemplateClusterName, nodeGUID, "", utils.ClusterConfigOptions{})
+		clusterCreated = err == nil
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Checking the template cluster-manager reports for the cluster")
+		cluster, err := utils.GetClusterDetail(namespace, defaultTemplateClusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.Template).NotTo(BeNil(), "the cluster should report its template")
+		fmt.Printf("Cluster %s was created from template %s\n", defaultTemplateClusterName, *cluster.Template)
+		Expect(*cluster.Template).To(Equal(utils.K3sTemplateName), "the default template should be applied")
+	})
+})
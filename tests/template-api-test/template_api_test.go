@@ -4,27 +4,30 @@
 package template_api_test
 
 import (
+	"errors"
 	"fmt"
-	"os/exec"
+	"net/http"
 	"testing"
-	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
 )
 
 func TestTemplateApiTests(t *testing.T) {
 	RegisterFailHandler(Fail)
 	_, _ = fmt.Fprintf(GinkgoWriter, "Starting template api tests\n")
-	RunSpecs(t, "template api test suite")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "template api test suite", suiteConfig, reporterConfig)
 }
 
+var _ = diagnostics.RegisterSuiteHooks()
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
 var _ = Describe("Template API Tests", Ordered, func() {
-	var (
-		namespace      string
-		portForwardCmd *exec.Cmd
-	)
+	var namespace string
 	BeforeAll(func() {
 		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
 
@@ -32,27 +35,12 @@ var _ = Describe("Template API Tests", Ordered, func() {
 		err := utils.EnsureNamespaceExists(namespace)
 		Expect(err).NotTo(HaveOccurred())
 
-		By("Port forwarding to the cluster manager service")
-		err = utils.EnsureTCPPortAvailable(utils.PortForwardLocalPort, fmt.Sprintf("kubectl port-forward %s", utils.PortForwardService))
-		Expect(err).NotTo(HaveOccurred())
-
-		portForwardCmd = exec.Command("kubectl", "port-forward", utils.PortForwardService, fmt.Sprintf("%s:%s", utils.PortForwardLocalPort, utils.PortForwardRemotePort), "--address", utils.PortForwardAddress)
-		err = portForwardCmd.Start()
-		Expect(err).NotTo(HaveOccurred())
-		time.Sleep(5 * time.Second)
-
 		By("Deleting all templates in the namespace")
 		err = utils.DeleteAllTemplate(namespace)
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	AfterAll(func() {
-		defer func() {
-			if portForwardCmd != nil && portForwardCmd.Process != nil {
-				portForwardCmd.Process.Kill()
-			}
-		}()
-
 		By("Deleting all templates in the namespace")
 		err := utils.DeleteAllTemplate(namespace)
 		Expect(err).NotTo(HaveOccurred())
@@ -64,9 +52,7 @@ var _ = Describe("Template API Tests", Ordered, func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		By("Waiting for the cluster template to be ready")
-		Eventually(func() bool {
-			return utils.IsClusterTemplateReady(namespace, utils.K3sTemplateName)
-		}, 2*time.Minute, 2*time.Second).Should(BeTrue())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
 	})
 
 	It("Should be able to retrieve a template", Label(utils.ClusterOrchTemplateApiSmokeTest, utils.ClusterOrchTemplateApiAllTest), func() {
@@ -108,6 +94,28 @@ var _ = Describe("Template API Tests", Ordered, func() {
 
 	})
 
+	It("Should propagate the W3C trace context of requests", Label(utils.ClusterOrchTemplateApiAllTest), func() {
+		By("Listing the templates with a known trace ID")
+		traceID := utils.NewTraceID()
+		req, err := http.NewRequest(http.MethodGet, utils.ClusterTemplateURL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		utils.SetNamespaceHeader(req, namespace)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set(utils.TraceParentHeader, utils.NewTraceParent(traceID))
+
+		resp, err := utils.NewHTTPClient().Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		By("Checking the trace ID reported back by cluster-manager")
+		echoed := utils.TraceIDFromResponse(resp)
+		if echoed == "" {
+			Skip("cluster-manager does not report the trace context in its responses")
+		}
+		Expect(echoed).To(Equal(traceID), "cluster-manager should continue the trace of the request")
+	})
+
 	It("Should return templates matching a filter", Label(utils.ClusterOrchTemplateApiAllTest), func() {
 		By("Retrieving templates with a filter")
 			templates, err := utils.GetClusterTemplatesWithFilter(namespace, "version="+utils.K3sTemplateOnlyVersion)
@@ -116,4 +124,21 @@ var _ = Describe("Template API Tests", Ordered, func() {
 		Expect(templates.TemplateInfoList).ToNot(BeNil())
 		Expect(*templates.TemplateInfoList).To(HaveLen(1), "There should be one template matching the filter - k3s")
 	})
+
+	It("Should serve the CRD schemas the tests are written against", Label(utils.ClusterOrchTemplateApiAllTest), func() {
+		compared := 0
+		for _, crd := range utils.ContractCRDs {
+			By(fmt.Sprintf("Comparing the installed %s CRD against the vendored copy", crd))
+			err := utils.CompareCRDSchema(utils.CRDSchemaDir, crd)
+			if errors.Is(err, utils.ErrGoldenMissing) {
+				fmt.Printf("Skipping schema comparison: %v\n", err)
+				continue
+			}
+			Expect(err).NotTo(HaveOccurred())
+			compared++
+		}
+		if compared == 0 {
+			Skip("no vendored CRDs to compare against")
+		}
+	})
 })
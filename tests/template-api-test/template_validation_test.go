@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+// invalidTemplateEntries returns a table entry per request, described by the request.
+func invalidTemplateEntries(requests []utils.InvalidTemplateRequest) []TableEntry {
+	entries := make([]TableEntry, 0, len(requests))
+	for _, request := range requests {
+		entries = append(entries, Entry(request.Description, request))
+	}
+	return entries
+}
+
+var _ = Describe("Template import validation", Ordered, Label(utils.ClusterOrchTemplateApiAllTest), func() {
+	var namespace string
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		// The baseline template exists, so importing it again conflicts.
+		By("Importing the cluster template k3s baseline")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		By("Deleting all templates in the namespace")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+	})
+
+	// expectRejected imports the template and checks that it gets the status of the request with a message
+	// naming the invalid field, and that a malformed template was not stored.
+	expectRejected := func(request utils.InvalidTemplateRequest) {
+		data, err := request.Body()
+		Expect(err).NotTo(HaveOccurred())
+		status, body, err := utils.ImportClusterTemplateRaw(nil, namespace, data)
+		Expect(err).NotTo(HaveOccurred())
+		fmt.Printf("  %s: HTTP %d\n", request.Description, status)
+
+		Expect(status).To(Equal(request.Status), body)
+		apiErr := utils.NewAPIError(request.Description, status, []byte(body))
+		Expect(apiErr).To(utils.HaveAPIErrorMessage(Not(BeEmpty())), "the rejection has no message")
+		if request.Field != "" {
+			Expect(utils.NamesField(apiErr.Message, request.Field)).To(BeTrue(),
+				"the rejection %q does not name the %s field", apiErr.Message, request.Field)
+		}
+		Expect(utils.LeakedInternals(body)).To(BeEmpty(), body)
+
+		if request.Status == http.StatusConflict {
+			return
+		}
+		By("Checking that the template was not stored")
+		var template api.TemplateInfo
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		_, err = utils.GetClusterTemplate(namespace, template.Name, template.Version)
+		Expect(err).To(HaveOccurred(), "the malformed template %s-%s should not be stored", template.Name, template.Version)
+	}
+
+	DescribeTable("should reject a malformed template", expectRejected,
+		invalidTemplateEntries(utils.InvalidTemplateRequests()))
+})
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_api_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	invalidTemplateName      = "webhook-invalid-v0.0.1"
+	invalidKubernetesVersion = "v1.33.5+k3s1"
+)
+
+var _ = Describe("ClusterTemplate admission", Ordered, Label(utils.ClusterOrchTemplateApiAllTest), func() {
+	var namespace string
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Importing the cluster template k3s baseline")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+		Expect(utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sBaseline)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.K3sTemplateName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		if err := utils.DeleteClusterTemplateCR(namespace, invalidTemplateName); err != nil {
+			fmt.Printf("Failed to delete %s: %v\n", invalidTemplateName, err)
+		}
+		By("Deleting all templates in the namespace")
+		Expect(utils.DeleteAllTemplate(namespace)).To(Succeed())
+	})
+
+	It("should reject a cluster configuration that is not valid JSON", func() {
+		manifest, err := utils.ClusterTemplateCR(namespace, invalidTemplateName, invalidKubernetesVersion, "{not json")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = utils.CreateClusterTemplateCR(manifest)
+		Expect(err).To(HaveOccurred(), "the webhook should deny the template")
+		Expect(err.Error()).To(ContainSubstring("failed to convert cluster configuration"))
+	})
+
+	It("should reject a cluster configuration that does not match the control plane provider", func() {
+		manifest, err := utils.ClusterTemplateCR(namespace, invalidTemplateName, invalidKubernetesVersion,
+			`{"kind":"KThreesControlPlaneTemplate","spec":{"template":"not an object"}}`)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = utils.CreateClusterTemplateCR(manifest)
+		Expect(err).To(HaveOccurred(), "the webhook should deny the template")
+		Expect(err.Error()).To(ContainSubstring("failed to convert cluster configuration"))
+	})
+
+	It("should reject updates to the spec of a template", func() {
+		err := utils.PatchClusterTemplateCR(namespace, utils.K3sTemplateName, `{"spec":{"kubernetesVersion":"v1.30.0+k3s1"}}`)
+		Expect(err).To(HaveOccurred(), "the webhook should deny the update")
+		Expect(err.Error()).To(ContainSubstring("clusterTemplate spec immutable"))
+
+		By("Checking that metadata updates are still allowed")
+		Expect(utils.PatchClusterTemplateCR(namespace, utils.K3sTemplateName,
+			`{"metadata":{"labels":{"cluster-tests/webhook":"true"}}}`)).To(Succeed())
+	})
+
+	It("should reject template versions not in the vX.Y.Z format", func() {
+		for _, version := range []string{"1.0.0", "v1.0", "v1.0.0-beta"} {
+			By(fmt.Sprintf("Importing a template with version %q", version))
+			data, err := utils.ClusterTemplateVariant(utils.TemplateTypeK3sBaseline, map[string]any{"version": version})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = utils.ImportClusterTemplateData(namespace, data)
+			Expect(err).To(HaveOccurred(), "version %q should be rejected", version)
+			Expect(err).To(utils.HaveAPIErrorCode(utils.APIErrorBadRequest))
+		}
+	})
+
+	It("should not leave a dangling default when the default template is deleted", func() {
+		By("Setting the baseline template as default")
+		Expect(utils.SetDefaultTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)).To(Succeed())
+
+		By("Deleting the default template")
+		err := utils.DeleteTemplate(namespace, utils.K3sTemplateOnlyName, utils.K3sTemplateOnlyVersion)
+		if err != nil {
+			Expect(err).To(utils.HaveAPIErrorMessage(ContainSubstring("default")), "a denied deletion should name the default template as the reason")
+			return
+		}
+
+		Eventually(func() (bool, error) {
+			defaultTemplate, err := utils.GetDefaultTemplate(namespace)
+			return defaultTemplate == nil, err
+		}, time.Minute, 2*time.Second).Should(BeTrue(), "no default template should be reported once it is deleted")
+	})
+})
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_mix_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	// secondaryNodeGUIDEnvVar is the host backing the rke2 cluster; the primary NODEGUID hosts the k3s one.
+	secondaryNodeGUIDEnvVar = "SECONDARY_NODEGUID"
+
+	k3sClusterName  = "mix-k3s-cluster"
+	rke2ClusterName = "mix-rke2-cluster"
+
+	clusterReadinessTimeout = 15 * time.Minute
+)
+
+type mixedCluster struct {
+	name         string
+	distro       string
+	templateType string
+	templateName string
+	nodeGUID     string
+}
+
+func TestTemplateMixTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting template mix tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "template mix test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward)
+
+var _ = Describe("k3s and rke2 templates coexisting in one project", Ordered, Label(utils.ClusterOrchTemplateMixTest), func() {
+	var (
+		namespace   string
+		authContext *auth.TestAuthContext
+		clusters    []mixedCluster
+		setUp       bool
+	)
+
+	BeforeAll(func() {
+		By("Checking that an rke2 template and a second edge node are available")
+		rke2TemplateName, err := utils.ClusterTemplateFullName(utils.TemplateTypeRke2Baseline)
+		if os.IsNotExist(err) {
+			Skip(fmt.Sprintf("rke2 template not available; set %s", utils.Rke2TemplatePathEnvVar))
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		secondaryNodeGUID := os.Getenv(secondaryNodeGUIDEnvVar)
+		if secondaryNodeGUID == "" {
+			Skip(fmt.Sprintf("a second edge node is required for the rke2 cluster; set %s", secondaryNodeGUIDEnvVar))
+		}
+
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		clusters = []mixedCluster{
+			{
+				name:         k3sClusterName,
+				distro:       "k3s",
+				templateType: utils.TemplateTypeK3sBaseline,
+				templateName: utils.K3sTemplateName,
+				nodeGUID:     utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID),
+			},
+			{
+				name:         rke2ClusterName,
+				distro:       "rke2",
+				templateType: utils.TemplateTypeRke2Baseline,
+				templateName: rke2TemplateName,
+				nodeGUID:     secondaryNodeGUID,
+			},
+		}
+
+		By("Ensuring the namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Setting up JWT authentication for kubeconfig retrieval")
+		authContext, err = utils.SetupTestAuthentication("test-user")
+		Expect(err).NotTo(HaveOccurred())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp || utils.SkipDeleteCluster {
+			return
+		}
+
+		for _, c := range clusters {
+			By(fmt.Sprintf("Deleting the %s cluster", c.distro))
+			if err := utils.DeleteNamedCluster(namespace, c.name); err != nil {
+				fmt.Printf("Failed to delete cluster %s: %v\n", c.name, err)
+			}
+		}
+		for _, c := range clusters {
+			Expect(wait.WaitForClusterDeleted(namespace, c.name)).To(Succeed())
+		}
+
+		for _, c := range clusters {
+			By(fmt.Sprintf("Deleting the %s template", c.distro))
+			name, version, err := utils.ClusterTemplateNameVersion(c.templateType)
+			Expect(err).NotTo(HaveOccurred())
+			if err := utils.DeleteTemplate(namespace, name, version); err != nil {
+				fmt.Printf("Failed to delete template %s: %v\n", c.templateName, err)
+			}
+		}
+	})
+
+	It("should keep both templates imported side by side", func() {
+		for _, c := range clusters {
+			By(fmt.Sprintf("Importing the %s template", c.distro))
+			Expect(utils.ImportClusterTemplate(namespace, c.templateType)).To(Succeed())
+
+			Expect(wait.WaitForTemplateReady(namespace, c.templateName)).To(Succeed())
+		}
+	})
+
+	It("should create one cluster per distro", func() {
+		for _, c := range clusters {
+			By(fmt.Sprintf("Creating the %s cluster", c.distro))
+			Expect(utils.CreateNamedCluster(namespace, c.name, c.nodeGUID, c.templateName, utils.ClusterConfigOptions{})).To(Succeed())
+		}
+
+		// The clusters come up side by side, so they share the readiness budget.
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		for _, c := range clusters {
+			By(fmt.Sprintf("Waiting for all components of the %s cluster to be ready", c.distro))
+			Expect(wait.ClusterReady(ctx, namespace, c.name)).To(Succeed())
+		}
+	})
+
+	It("should list both clusters with their distro", func() {
+		listed, err := utils.ListClusters(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		byName := map[string]api.ClusterInfo{}
+		for _, info := range listed {
+			if info.Name != nil {
+				byName[*info.Name] = info
+			}
+		}
+
+		for _, c := range clusters {
+			info, ok := byName[c.name]
+			Expect(ok).To(BeTrue(), "cluster %s should be listed", c.name)
+			Expect(info.KubernetesVersion).NotTo(BeNil())
+			Expect(*info.KubernetesVersion).To(ContainSubstring(c.distro), "cluster %s should run %s", c.name, c.distro)
+		}
+	})
+
+	It("should count both clusters in the summary", func() {
+		listed, err := utils.ListClusters(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		summary, err := utils.GetClustersSummary(namespace)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(int(summary.TotalClusters)).To(Equal(len(listed)))
+		Expect(summary.Ready).To(BeNumerically(">=", len(clusters)))
+	})
+
+	It("should return a distinct kubeconfig for each cluster", func() {
+		registry := utils.NewKubeconfigRegistry(GinkgoT().TempDir())
+		seen := map[string]string{}
+		for _, c := range clusters {
+			By(fmt.Sprintf("Retrieving the kubeconfig of the %s cluster", c.distro))
+			kubeconfig, err := registry.FetchFromAPI(authContext, namespace, c.name)
+			Expect(err).NotTo(HaveOccurred())
+			data, err := os.ReadFile(kubeconfig.Path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(c.name))
+
+			for other, otherKubeconfig := range seen {
+				Expect(strings.Contains(otherKubeconfig, c.name)).To(BeFalse(), "kubeconfig of %s should not reference %s", other, c.name)
+			}
+			seen[c.name] = string(data)
+		}
+		Expect(registry.Clusters()).To(HaveLen(len(clusters)))
+	})
+})
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_profile_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+var _ = Describe("Cluster template kubernetes knobs", Ordered, Label(utils.ClusterOrchTemplateProfileTest), func() {
+	var (
+		namespace string
+		knobs     utils.TemplateKnobs
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID := utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		var err error
+		knobs, err = utils.TemplateKnobsFromEnv()
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Ensuring the namespace exists")
+		Expect(utils.EnsureNamespaceExists(namespace)).To(Succeed())
+
+		By("Importing the cluster template with the knobs")
+		data, err := utils.KnobsTemplate(knobs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(utils.ImportClusterTemplateData(namespace, data)).To(Succeed())
+		Expect(wait.WaitForTemplateReady(namespace, utils.KnobsTemplateName)).To(Succeed())
+
+		By("Creating the cluster")
+		Expect(utils.CreateCluster(namespace, nodeGUID, utils.KnobsTemplateName)).To(Succeed())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+		By("Getting the downstream kubeconfig")
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+	})
+
+	AfterAll(func() {
+		if utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		Expect(utils.DeleteCluster(namespace)).To(Succeed())
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+
+		By("Deleting the cluster template")
+		Expect(utils.DeleteTemplate(namespace, utils.KnobsTemplateOnlyName, utils.K3sTemplateOnlyVersion)).To(Succeed())
+	})
+
+	It("should run the API server with the feature gates of the template", func() {
+		Expect(utils.CheckAPIServerFeatureGates(kubeconfigFileName, knobs)).To(Succeed())
+	})
+
+	It("should serve the API versions of the runtime config of the template", func() {
+		Expect(utils.CheckRuntimeConfig(kubeconfigFileName, knobs)).To(Succeed())
+	})
+
+	It("should enforce the service node port range of the template", func() {
+		Expect(utils.CheckNodePortRange(kubeconfigFileName, knobs)).To(Succeed())
+	})
+
+	It("should run the kubelets with the feature gates and pod capacity of the template", func() {
+		Expect(utils.CheckKubeletKnobs(kubeconfigFileName, knobs)).To(Succeed())
+	})
+})
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package template_profile_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	kubeconfigFileName = "kubeconfig-profile.yaml"
+
+	clusterReadinessTimeout = 10 * time.Minute
+
+	// privilegedPodOverrides requests a privileged container, rejected by the restricted and baseline PSA levels.
+	privilegedPodOverrides = `{"spec":{"containers":[{"name":"psa-probe","image":"busybox","securityContext":{"privileged":true}}]}}`
+	// restrictedPodOverrides satisfies the restricted PSA level, so it must be admitted by every profile.
+	restrictedPodOverrides = `{"spec":{"securityContext":{"runAsNonRoot":true,"runAsUser":1000,"seccompProfile":{"type":"RuntimeDefault"}},` +
+		`"containers":[{"name":"psa-probe","image":"busybox","securityContext":{"allowPrivilegeEscalation":false,"capabilities":{"drop":["ALL"]}}}]}}`
+)
+
+func TestTemplateProfileTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting template profile tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "template profile test suite", suiteConfig, reporterConfig)
+}
+
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+// admitPod asks the downstream API server to admit a pod made of overrides without creating it.
+func admitPod(name, overrides string) error {
+	var pod corev1.Pod
+	if err := json.Unmarshal([]byte(overrides), &pod); err != nil {
+		return err
+	}
+	pod.Name, pod.Namespace = name, "default"
+	pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+	client, err := utils.NewKubeClient(kubeconfigFileName)
+	if err != nil {
+		return err
+	}
+	return client.DryRunCreatePod(&pod)
+}
+
+type templateProfile struct {
+	name              string
+	templateType      string
+	templateOnlyName  string
+	templateName      string
+	privilegedAllowed bool
+}
+
+var _ = Describe("Cluster template pod-security profiles", Ordered, Label(utils.ClusterOrchTemplateProfileTest), func() {
+	var (
+		namespace string
+		nodeGUID  string
+	)
+
+	BeforeAll(func() {
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err := utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	profiles := []templateProfile{
+		{name: "privileged", templateType: utils.TemplateTypeK3sPrivileged, templateOnlyName: "privileged-k3s", templateName: utils.K3sPrivilegedTemplateName, privilegedAllowed: true},
+		{name: "restricted", templateType: utils.TemplateTypeK3sRestricted, templateOnlyName: "restricted-k3s", templateName: utils.K3sRestrictedTemplateName, privilegedAllowed: false},
+	}
+
+	for _, profile := range profiles {
+		profile := profile
+
+		Context(fmt.Sprintf("with the %s template", profile.name), Ordered, func() {
+			BeforeAll(func() {
+				By(fmt.Sprintf("Importing the %s cluster template", profile.name))
+				err := utils.ImportClusterTemplate(namespace, profile.templateType)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(wait.WaitForTemplateReady(namespace, profile.templateName)).To(Succeed())
+
+				By("Creating the cluster")
+				err = utils.CreateCluster(namespace, nodeGUID, profile.templateName)
+				Expect(err).NotTo(HaveOccurred())
+
+				By("Waiting for all components to be ready")
+				ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+				defer cancel()
+				Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+				By("Getting the downstream kubeconfig")
+				Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+			})
+
+			AfterAll(func() {
+				if utils.SkipDeleteCluster {
+					return
+				}
+
+				By("Deleting the cluster")
+				Expect(utils.DeleteCluster(namespace)).To(Succeed())
+				Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+
+				By("Deleting the cluster template")
+				Expect(utils.DeleteTemplate(namespace, profile.templateOnlyName, utils.K3sTemplateOnlyVersion)).To(Succeed())
+			})
+
+			It("should admit a pod that satisfies the restricted pod-security level", func() {
+				Expect(admitPod("psa-probe-restricted", restrictedPodOverrides)).To(Succeed())
+			})
+
+			if profile.privilegedAllowed {
+				It("should admit a privileged pod", func() {
+					Expect(admitPod("psa-probe-privileged", privilegedPodOverrides)).To(Succeed())
+				})
+			} else {
+				It("should reject a privileged pod", func() {
+					err := admitPod("psa-probe-privileged", privilegedPodOverrides)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("violates PodSecurity"))
+				})
+			}
+		})
+	}
+})
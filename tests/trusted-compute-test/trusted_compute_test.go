@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package trusted_compute_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/diagnostics"
+	"github.com/open-edge-platform/cluster-tests/tests/utils/wait"
+)
+
+const (
+	kubeconfigFileName = "kubeconfig-trusted-compute.yaml"
+
+	// expectedWorkloadsEnvVar lists name fragments of the trusted-compute workloads, comma-separated.
+	expectedWorkloadsEnvVar  = "TRUSTED_COMPUTE_EXPECTED_WORKLOADS"
+	defaultExpectedWorkloads = "trusted-workload,attestation"
+
+	clusterReadinessTimeout = 10 * time.Minute
+	workloadTimeout         = 10 * time.Minute
+	workloadInterval        = 15 * time.Second
+)
+
+func TestTrustedComputeTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	_, _ = fmt.Fprintf(GinkgoWriter, "Starting trusted compute tests\n")
+	suiteConfig, reporterConfig := utils.VersionAwareSuiteConfig()
+	RunSpecs(t, "trusted compute test suite", suiteConfig, reporterConfig)
+}
+
+var _ = utils.RegisterCapabilityGating()
+var _ = diagnostics.RegisterSuiteHooks()
+
+var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)
+
+var _ = Describe("Trusted-compute template extension", Ordered, Label(utils.ClusterOrchTrustedComputeTest), utils.RequiresCapabilities(utils.CapabilityTPM), func() {
+	var (
+		namespace    string
+		nodeGUID     string
+		templateName string
+		setUp        bool
+	)
+
+	BeforeAll(func() {
+		By("Checking that the trusted-compute template is available")
+		var err error
+		templateName, err = utils.ClusterTemplateFullName(utils.TemplateTypeK3sTrustedCompute)
+		if os.IsNotExist(err) {
+			Skip(fmt.Sprintf("trusted-compute template not available; set %s", utils.TrustedComputeTemplatePathEnvVar))
+		}
+		Expect(err).NotTo(HaveOccurred())
+
+		namespace = utils.GetEnv(utils.NamespaceEnvVar, utils.DefaultNamespace)
+		nodeGUID = utils.GetEnv(utils.NodeGUIDEnvVar, utils.DefaultNodeGUID)
+
+		By("Ensuring the namespace exists")
+		err = utils.EnsureNamespaceExists(namespace)
+		Expect(err).NotTo(HaveOccurred())
+
+		setUp = true
+	})
+
+	AfterAll(func() {
+		if !setUp || utils.SkipDeleteCluster {
+			return
+		}
+
+		By("Deleting the cluster")
+		if err := utils.DeleteCluster(namespace); err != nil {
+			fmt.Printf("Failed to delete cluster: %v\n", err)
+			return
+		}
+		Expect(wait.WaitForClusterDeleted(namespace, utils.ClusterName)).To(Succeed())
+
+		By("Deleting the trusted-compute template")
+		name, version, err := utils.ClusterTemplateNameVersion(utils.TemplateTypeK3sTrustedCompute)
+		Expect(err).NotTo(HaveOccurred())
+		if err := utils.DeleteTemplate(namespace, name, version); err != nil {
+			fmt.Printf("Failed to delete template %s: %v\n", templateName, err)
+		}
+	})
+
+	It("should import the trusted-compute template", func() {
+		err := utils.ImportClusterTemplate(namespace, utils.TemplateTypeK3sTrustedCompute)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(wait.WaitForTemplateReady(namespace, templateName)).To(Succeed())
+	})
+
+	It("should create a cluster from the trusted-compute template", func() {
+		err := utils.CreateCluster(namespace, nodeGUID, templateName)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Waiting for all components to be ready")
+		ctx, cancel := context.WithTimeout(context.Background(), clusterReadinessTimeout)
+		defer cancel()
+		Expect(wait.ClusterReady(ctx, namespace, utils.ClusterName)).To(Succeed())
+
+		Expect(utils.WriteDownstreamKubeconfig(namespace, utils.ClusterName, kubeconfigFileName)).To(Succeed())
+	})
+
+	It("should deploy the trusted-compute extension workloads downstream", func() {
+		expected := strings.Split(utils.GetEnv(expectedWorkloadsEnvVar, defaultExpectedWorkloads), ",")
+
+		for _, fragment := range expected {
+			fragment = strings.TrimSpace(fragment)
+			if fragment == "" {
+				continue
+			}
+
+			By(fmt.Sprintf("Waiting for a running %q workload", fragment))
+			Eventually(func() bool {
+				pods, err := utils.ListDownstreamPods(kubeconfigFileName)
+				if err != nil {
+					return false
+				}
+				for _, pod := range pods {
+					if strings.Contains(pod.Name, fragment) && pod.Phase == "Running" {
+						fmt.Printf("Found %s/%s\n", pod.Namespace, pod.Name)
+						return true
+					}
+				}
+				return false
+			}, workloadTimeout, workloadInterval).Should(BeTrue(), "trusted-compute workload %q should be running", fragment)
+		}
+	})
+})
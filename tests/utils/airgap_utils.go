@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// AirGappedEnvVar enables the air-gapped mode: bootstrap blocks egress from the kind nodes once
+	// all images are in place, and the air-gapped suite runs against that setup.
+	AirGappedEnvVar = "AIR_GAPPED"
+	// AirGappedAllowedCIDRsEnvVar lists extra destinations (e.g. a local registry or proxy) kept reachable.
+	AirGappedAllowedCIDRsEnvVar = "AIR_GAPPED_ALLOWED_CIDRS"
+	// AirGappedPreloadImagesEnvVar lists images to load into the kind nodes before egress is blocked.
+	AirGappedPreloadImagesEnvVar = "AIR_GAPPED_PRELOAD_IMAGES"
+
+	// AirGappedProbeURL is an external endpoint that must be unreachable from the kind nodes.
+	AirGappedProbeURL = "https://registry-1.docker.io/v2/"
+
+	airGapChain = "CLUSTER-TESTS-AIRGAP"
+)
+
+// airGapDefaultAllowedCIDRs keep loopback, the docker/kind networks and the vEN reachable.
+var airGapDefaultAllowedCIDRs = []string{"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10"}
+
+// AirGappedModeEnabled reports whether the tests run in air-gapped mode.
+func AirGappedModeEnabled() bool {
+	return os.Getenv(AirGappedEnvVar) == "true"
+}
+
+// KindNodes returns the container names of the nodes of the kind cluster.
+func KindNodes() ([]string, error) {
+	out, err := CommandCombinedOutput(exec.Command("kind", "get", "nodes"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kind nodes: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	nodes := strings.Fields(string(out))
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no kind nodes found")
+	}
+	return nodes, nil
+}
+
+// PreloadKindImages pulls the given images on the host and loads them into the kind nodes.
+func PreloadKindImages(images []string) error {
+	for _, image := range images {
+		if out, err := CommandCombinedOutput(exec.Command("docker", "pull", image)); err != nil {
+			return fmt.Errorf("failed to pull %s: %w: %s", image, err, strings.TrimSpace(string(out)))
+		}
+		if out, err := CommandCombinedOutput(exec.Command("kind", "load", "docker-image", image)); err != nil {
+			return fmt.Errorf("failed to load %s into kind: %w: %s", image, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// BlockKindEgress rejects all traffic from the kind nodes and their pods to destinations outside the
+// allowed CIDRs. It is idempotent.
+func BlockKindEgress(extraAllowedCIDRs []string) error {
+	return execOnKindNodes(airGapBlockScript(append(append([]string{}, airGapDefaultAllowedCIDRs...), extraAllowedCIDRs...)))
+}
+
+// RestoreKindEgress removes the rules installed by BlockKindEgress.
+func RestoreKindEgress() error {
+	return execOnKindNodes(airGapRestoreScript())
+}
+
+// KindEgressBlocked reports whether every kind node fails to reach AirGappedProbeURL.
+func KindEgressBlocked() (bool, error) {
+	nodes, err := KindNodes()
+	if err != nil {
+		return false, err
+	}
+	for _, node := range nodes {
+		if RunCommand(exec.Command("docker", "exec", node, "curl", "-sS", "-m", "5", "-o", "/dev/null", AirGappedProbeURL)) == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func execOnKindNodes(script string) error {
+	nodes, err := KindNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if out, err := CommandCombinedOutput(exec.Command("docker", "exec", node, "sh", "-c", script)); err != nil {
+			return fmt.Errorf("failed to update egress rules on %s: %w: %s", node, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+func airGapBlockScript(allowedCIDRs []string) string {
+	lines := []string{
+		"set -e",
+		fmt.Sprintf("iptables -N %[1]s 2>/dev/null || iptables -F %[1]s", airGapChain),
+	}
+	for _, cidr := range allowedCIDRs {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			lines = append(lines, fmt.Sprintf("iptables -A %s -d %s -j RETURN", airGapChain, cidr))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("iptables -A %s -j REJECT", airGapChain))
+	for _, hook := range []string{"OUTPUT", "FORWARD"} {
+		lines = append(lines, fmt.Sprintf("iptables -C %[1]s -j %[2]s 2>/dev/null || iptables -I %[1]s -j %[2]s", hook, airGapChain))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func airGapRestoreScript() string {
+	lines := make([]string, 0, 4)
+	for _, hook := range []string{"OUTPUT", "FORWARD"} {
+		lines = append(lines, fmt.Sprintf("while iptables -D %s -j %s 2>/dev/null; do :; done", hook, airGapChain))
+	}
+	lines = append(lines,
+		fmt.Sprintf("iptables -F %s 2>/dev/null || true", airGapChain),
+		fmt.Sprintf("iptables -X %s 2>/dev/null || true", airGapChain))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAirGapBlockScript(t *testing.T) {
+	script := airGapBlockScript([]string{"10.0.0.0/8", " ", "203.0.113.7/32"})
+	lines := strings.Split(script, "\n")
+
+	expectedOrder := []string{
+		"-A CLUSTER-TESTS-AIRGAP -d 10.0.0.0/8 -j RETURN",
+		"-A CLUSTER-TESTS-AIRGAP -d 203.0.113.7/32 -j RETURN",
+		"-A CLUSTER-TESTS-AIRGAP -j REJECT",
+		"-I OUTPUT -j CLUSTER-TESTS-AIRGAP",
+		"-I FORWARD -j CLUSTER-TESTS-AIRGAP",
+	}
+	idx := 0
+	for _, line := range lines {
+		if idx < len(expectedOrder) && strings.Contains(line, expectedOrder[idx]) {
+			idx++
+		}
+	}
+	if idx != len(expectedOrder) {
+		t.Errorf("Expected rule %q in order, script:\n%s", expectedOrder[idx], script)
+	}
+	if strings.Contains(script, "-d  ") || strings.Contains(script, "-d -j") {
+		t.Errorf("Expected blank CIDRs to be skipped, script:\n%s", script)
+	}
+}
+
+func TestAirGapRestoreScript(t *testing.T) {
+	script := airGapRestoreScript()
+	for _, want := range []string{"-D OUTPUT -j CLUSTER-TESTS-AIRGAP", "-D FORWARD -j CLUSTER-TESTS-AIRGAP", "-X CLUSTER-TESTS-AIRGAP"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in restore script:\n%s", want, script)
+		}
+	}
+}
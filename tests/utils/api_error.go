@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/gcustom"
+	"github.com/onsi/gomega/types"
+)
+
+// APIErrorCode classifies an API error. It is the code the body carries when there is one, and otherwise
+// derived from the HTTP status, with the names the Kubernetes API uses for its status reasons.
+type APIErrorCode string
+
+const (
+	APIErrorBadRequest         APIErrorCode = "BadRequest"
+	APIErrorUnauthorized       APIErrorCode = "Unauthorized"
+	APIErrorForbidden          APIErrorCode = "Forbidden"
+	APIErrorNotFound           APIErrorCode = "NotFound"
+	APIErrorConflict           APIErrorCode = "Conflict"
+	APIErrorInvalid            APIErrorCode = "Invalid"
+	APIErrorTooManyRequests    APIErrorCode = "TooManyRequests"
+	APIErrorInternalError      APIErrorCode = "InternalError"
+	APIErrorNotImplemented     APIErrorCode = "NotImplemented"
+	APIErrorServiceUnavailable APIErrorCode = "ServiceUnavailable"
+	APIErrorUnknown            APIErrorCode = "Unknown"
+)
+
+var apiErrorCodes = map[int]APIErrorCode{
+	http.StatusBadRequest:          APIErrorBadRequest,
+	http.StatusUnauthorized:        APIErrorUnauthorized,
+	http.StatusForbidden:           APIErrorForbidden,
+	http.StatusNotFound:            APIErrorNotFound,
+	http.StatusConflict:            APIErrorConflict,
+	http.StatusUnprocessableEntity: APIErrorInvalid,
+	http.StatusTooManyRequests:     APIErrorTooManyRequests,
+	http.StatusInternalServerError: APIErrorInternalError,
+	http.StatusNotImplemented:      APIErrorNotImplemented,
+	http.StatusServiceUnavailable:  APIErrorServiceUnavailable,
+}
+
+// APIError is an unexpected response of cluster-manager, the tenancy API or the infra API. The body is
+// parsed as problem details: cluster-manager sends {"message"}, RFC 7807 {"title", "detail"}, gRPC
+// gateways {"code", "message", "details"} and the Kubernetes API {"reason", "message", "details"}.
+type APIError struct {
+	// Op is what failed, e.g. "import cluster template".
+	Op         string
+	StatusCode int
+	Code       APIErrorCode
+	Message    string
+	// Details are the fields of the body other than the code and the message.
+	Details map[string]interface{}
+	// Body is the raw response body.
+	Body string
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = e.Body
+	}
+	return fmt.Sprintf("failed to %s: unexpected status %d: %s", e.Op, e.StatusCode, message)
+}
+
+// NewAPIError parses the body of an unexpected response into an APIError.
+func NewAPIError(op string, statusCode int, body []byte) *APIError {
+	// The body ends up in failure messages and logs, so its secrets are redacted before anything else.
+	body = Redact(body)
+	apiErr := &APIError{Op: op, StatusCode: statusCode, Body: strings.TrimSpace(string(body))}
+	apiErr.Code = apiErrorCodes[statusCode]
+	if apiErr.Code == "" {
+		apiErr.Code = APIErrorUnknown
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		apiErr.Message = apiErr.Body
+		return apiErr
+	}
+	for _, key := range []string{"code", "reason"} {
+		// gRPC gateways send numeric codes, which stay in the details.
+		if code, ok := fields[key].(string); ok && code != "" {
+			apiErr.Code = APIErrorCode(code)
+			delete(fields, key)
+			break
+		}
+	}
+	for _, key := range []string{"message", "detail", "title", "error"} {
+		if message, ok := fields[key].(string); ok && message != "" {
+			apiErr.Message = message
+			delete(fields, key)
+			break
+		}
+	}
+	if len(fields) > 0 {
+		apiErr.Details = fields
+	}
+	return apiErr
+}
+
+// readAPIError reads the body of an unexpected response into an APIError.
+func readAPIError(op string, resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	return NewAPIError(op, resp.StatusCode, body)
+}
+
+// AsAPIError returns the APIError in the chain of err.
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// HaveAPIErrorCode succeeds when the actual error is an APIError with the given code.
+func HaveAPIErrorCode(code APIErrorCode) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := AsAPIError(err)
+		if !ok {
+			return false, nil
+		}
+		return apiErr.Code == code, nil
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error with code {{.Data}}", code)
+}
+
+// HaveAPIStatus succeeds when the actual error is an APIError with the given HTTP status.
+func HaveAPIStatus(statusCode int) types.GomegaMatcher {
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := AsAPIError(err)
+		if !ok {
+			return false, nil
+		}
+		return apiErr.StatusCode == statusCode, nil
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error with status {{.Data}}", statusCode)
+}
+
+// HaveAPIErrorMessage succeeds when the actual error is an APIError whose message matches expected, a
+// string or a matcher.
+func HaveAPIErrorMessage(expected interface{}) types.GomegaMatcher {
+	matcher, ok := expected.(types.GomegaMatcher)
+	if !ok {
+		matcher = gomega.Equal(expected)
+	}
+	return gcustom.MakeMatcher(func(err error) (bool, error) {
+		apiErr, ok := AsAPIError(err)
+		if !ok {
+			return false, nil
+		}
+		return matcher.Match(apiErr.Message)
+	}).WithTemplate("Expected\n{{.FormattedActual}}\n{{.To}} be an API error whose message matches {{format .Data 1}}", matcher)
+}
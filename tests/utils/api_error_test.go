@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		code    APIErrorCode
+		message string
+		details []string
+	}{
+		{"cluster-manager", http.StatusConflict, `{"message":"Template 'baseline-k3s-v0.0.10' is in use"}`,
+			APIErrorConflict, "Template 'baseline-k3s-v0.0.10' is in use", nil},
+		{"problem details", http.StatusBadRequest, `{"type":"about:blank","title":"Bad Request","detail":"invalid version"}`,
+			APIErrorBadRequest, "invalid version", []string{"type", "title"}},
+		{"grpc gateway", http.StatusNotFound, `{"code":5,"message":"host not found","details":[]}`,
+			APIErrorNotFound, "host not found", []string{"code", "details"}},
+		{"kubernetes status", http.StatusUnprocessableEntity, `{"kind":"Status","reason":"Invalid","message":"spec is immutable"}`,
+			APIErrorInvalid, "spec is immutable", []string{"kind"}},
+		{"string code", http.StatusBadRequest, `{"code":"TEMPLATE_INVALID","message":"bad template"}`,
+			"TEMPLATE_INVALID", "bad template", nil},
+		{"plain text", http.StatusBadGateway, " upstream connect error \n", APIErrorUnknown, "upstream connect error", nil},
+		{"empty body", http.StatusInternalServerError, "", APIErrorInternalError, "", nil},
+	}
+	for _, tt := range tests {
+		apiErr := NewAPIError("do something", tt.status, []byte(tt.body))
+		if apiErr.StatusCode != tt.status || apiErr.Code != tt.code || apiErr.Message != tt.message {
+			t.Errorf("%s: Expected %d/%s/%q, got %d/%s/%q", tt.name, tt.status, tt.code, tt.message,
+				apiErr.StatusCode, apiErr.Code, apiErr.Message)
+		}
+		if len(apiErr.Details) != len(tt.details) {
+			t.Errorf("%s: Expected details %v, got %v", tt.name, tt.details, apiErr.Details)
+		}
+		for _, key := range tt.details {
+			if _, ok := apiErr.Details[key]; !ok {
+				t.Errorf("%s: Expected the detail %q, got %v", tt.name, key, apiErr.Details)
+			}
+		}
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	err := NewAPIError("delete template", http.StatusConflict, []byte(`{"message":"in use"}`))
+	if err.Error() != "failed to delete template: unexpected status 409: in use" {
+		t.Errorf("Expected the message in the error, got %q", err.Error())
+	}
+	err = NewAPIError("delete template", http.StatusConflict, []byte(`{"other":"field"}`))
+	if err.Error() != `failed to delete template: unexpected status 409: {"other":"field"}` {
+		t.Errorf("Expected the body in the error without a message, got %q", err.Error())
+	}
+}
+
+func TestAPIErrorMatchers(t *testing.T) {
+	g := NewWithT(t)
+	err := fmt.Errorf("failed to get cluster demo: %w",
+		NewAPIError("get cluster", http.StatusNotFound, []byte(`{"message":"cluster 'demo' not found"}`)))
+
+	g.Expect(err).To(HaveAPIErrorCode(APIErrorNotFound))
+	g.Expect(err).NotTo(HaveAPIErrorCode(APIErrorConflict))
+	g.Expect(err).To(HaveAPIStatus(http.StatusNotFound))
+	g.Expect(err).To(HaveAPIErrorMessage("cluster 'demo' not found"))
+	g.Expect(err).To(HaveAPIErrorMessage(ContainSubstring("not found")))
+
+	g.Expect(errors.New("plain error")).NotTo(HaveAPIErrorCode(APIErrorNotFound))
+	g.Expect(nil).NotTo(HaveAPIStatus(http.StatusNotFound))
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FuzzPayload is a request body derived from a valid one by a single mutation.
+type FuzzPayload struct {
+	// Name describes the mutation, e.g. "name: 64KiB string".
+	Name string
+	Body []byte
+}
+
+// fuzzLongString is well past any name or description limit of cluster-manager.
+var fuzzLongString = strings.Repeat("a", 64*1024)
+
+const (
+	fuzzCJKString   = "クラスター測試"
+	fuzzEmojiString = "cluster-🚀🔥"
+)
+
+// fuzzUnicodeStrings cover multi-byte, combining, right-to-left, zero-width and control characters.
+var fuzzUnicodeStrings = []struct{ kind, value string }{
+	{"CJK", fuzzCJKString},
+	{"emoji", fuzzEmojiString},
+	{"combining", "cluste\u0301r"},
+	{"right-to-left", "\u202ecluster\u202c"},
+	{"zero-width", "clu\u200bster"},
+	{"control", "clu\x00ster\x1b[31m"},
+}
+
+// fuzzWrongTypes replace a value of any type by one of another type.
+var fuzzWrongTypes = []struct {
+	kind  string
+	value interface{}
+}{
+	{"number", 12345},
+	{"boolean", true},
+	{"string", "not-the-right-type"},
+	{"array", []interface{}{"a", 1, nil}},
+	{"object", map[string]interface{}{"unexpected": map[string]interface{}{}}},
+}
+
+// fuzzMutation changes one field of a decoded request body.
+type fuzzMutation struct {
+	name  string
+	field string
+	apply func(doc map[string]interface{})
+}
+
+// fuzzMutations returns the mutations for the top-level fields of doc, plus labels and nodes mutations.
+func fuzzMutations(doc map[string]interface{}) []fuzzMutation {
+	fields := make([]string, 0, len(doc))
+	for field := range doc {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	set := func(field string, value interface{}) func(map[string]interface{}) {
+		return func(d map[string]interface{}) { d[field] = value }
+	}
+	var mutations []fuzzMutation
+	for _, field := range fields {
+		mutations = append(mutations, fuzzMutation{field + ": null", field, set(field, nil)})
+		mutations = append(mutations, fuzzMutation{field + ": missing", field, func(d map[string]interface{}) { delete(d, field) }})
+		for _, wrong := range fuzzWrongTypes {
+			if sameJSONKind(doc[field], wrong.value) {
+				continue
+			}
+			mutations = append(mutations, fuzzMutation{field + ": " + wrong.kind, field, set(field, wrong.value)})
+		}
+		if _, isString := doc[field].(string); !isString {
+			continue
+		}
+		mutations = append(mutations, fuzzMutation{field + ": empty string", field, set(field, "")})
+		mutations = append(mutations, fuzzMutation{field + ": 64KiB string", field, set(field, fuzzLongString)})
+		for _, unicode := range fuzzUnicodeStrings {
+			mutations = append(mutations, fuzzMutation{field + ": " + unicode.kind + " string", field, set(field, unicode.value)})
+		}
+	}
+
+	mutations = append(mutations,
+		fuzzMutation{"labels: nested 64 levels deep", "labels", set("labels", nestedLabels(64))},
+		fuzzMutation{"labels: 1000 entries", "labels", set("labels", manyLabels(1000))},
+		fuzzMutation{"labels: 64KiB key", "labels", set("labels", map[string]interface{}{fuzzLongString: "value"})},
+		fuzzMutation{"labels: 64KiB value", "labels", set("labels", map[string]interface{}{"key": fuzzLongString})},
+		fuzzMutation{"labels: unicode key and value", "labels", set("labels", map[string]interface{}{fuzzCJKString: fuzzEmojiString})},
+		fuzzMutation{"labels: null value", "labels", set("labels", map[string]interface{}{"key": nil})},
+		fuzzMutation{"labels: numeric value", "labels", set("labels", map[string]interface{}{"key": 1})},
+	)
+	if _, ok := doc["nodes"]; ok {
+		mutations = append(mutations,
+			fuzzMutation{"nodes: empty", "nodes", set("nodes", []interface{}{})},
+			fuzzMutation{"nodes: null entry", "nodes", set("nodes", []interface{}{nil})},
+			fuzzMutation{"nodes: entry without fields", "nodes", set("nodes", []interface{}{map[string]interface{}{}})},
+			fuzzMutation{"nodes: null id", "nodes", set("nodes", []interface{}{map[string]interface{}{"id": nil, "role": "all"}})},
+			fuzzMutation{"nodes: unknown role", "nodes", set("nodes", []interface{}{map[string]interface{}{"id": NewProjectID(), "role": "🚀"}})},
+			fuzzMutation{"nodes: 64KiB id", "nodes", set("nodes", []interface{}{map[string]interface{}{"id": fuzzLongString, "role": "all"}})},
+			fuzzMutation{"nodes: 1000 entries", "nodes", set("nodes", manyNodes(1000))},
+		)
+	}
+	return mutations
+}
+
+// FuzzPayloads derives boundary-pushing but well-formed JSON bodies from a valid request body. Every body
+// gets a unique value in uniqueField, unless the mutation targets it, so a body that is accepted does not
+// turn the ones after it into conflicts.
+func FuzzPayloads(valid []byte, uniqueField string) ([]FuzzPayload, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(valid, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the valid payload: %w", err)
+	}
+	original, _ := doc[uniqueField].(string)
+
+	var payloads []FuzzPayload
+	for i, mutation := range fuzzMutations(doc) {
+		var mutated map[string]interface{}
+		if err := json.Unmarshal(valid, &mutated); err != nil {
+			return nil, err
+		}
+		if mutation.field != uniqueField && original != "" {
+			mutated[uniqueField] = fmt.Sprintf("%s-%d", original, i)
+		}
+		mutation.apply(mutated)
+		body, err := json.Marshal(mutated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render payload %q: %w", mutation.name, err)
+		}
+		payloads = append(payloads, FuzzPayload{Name: mutation.name, Body: body})
+	}
+	return payloads, nil
+}
+
+// TemplateFuzzPayloads returns fuzzed template import bodies derived from the k3s baseline template.
+func TemplateFuzzPayloads() ([]FuzzPayload, error) {
+	data, err := readClusterTemplate(TemplateTypeK3sBaseline)
+	if err != nil {
+		return nil, err
+	}
+	return FuzzPayloads(data, "version")
+}
+
+// ClusterFuzzPayloads returns fuzzed cluster create bodies for templateName on a node that does not exist.
+func ClusterFuzzPayloads(clusterName, templateName string) ([]FuzzPayload, error) {
+	data, err := RenderClusterConfig(clusterName, NewProjectID(), templateName, ClusterConfigOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return FuzzPayloads(data, "name")
+}
+
+// internalLeakPatterns match what an API error must not expose: stack traces, source locations, Go
+// package paths, in-cluster addresses and raw errors of the Kubernetes API server.
+var internalLeakPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`goroutine \d+ \[`),
+	regexp.MustCompile(`panic:|runtime error`),
+	regexp.MustCompile(`\w\.go:\d+`),
+	regexp.MustCompile(`(?:^|[\s"'(])(?:github\.com|sigs\.k8s\.io|k8s\.io)/[\w.-]+/`),
+	regexp.MustCompile(`\.svc\.cluster\.local|\.svc:\d+`),
+	regexp.MustCompile(`dial tcp|connection refused`),
+	regexp.MustCompile(`Internal error occurred|failed calling webhook`),
+}
+
+// LeakedInternals returns the parts of an API response body that expose internals.
+func LeakedInternals(body string) []string {
+	var leaks []string
+	for _, pattern := range internalLeakPatterns {
+		if match := pattern.FindString(body); match != "" {
+			leaks = append(leaks, strings.TrimSpace(match))
+		}
+	}
+	return leaks
+}
+
+// sameJSONKind reports whether two decoded JSON values have the same type.
+func sameJSONKind(a, b interface{}) bool {
+	switch a.(type) {
+	case string:
+		_, ok := b.(string)
+		return ok
+	case float64, int:
+		switch b.(type) {
+		case float64, int:
+			return true
+		}
+		return false
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	case []interface{}:
+		_, ok := b.([]interface{})
+		return ok
+	case map[string]interface{}:
+		_, ok := b.(map[string]interface{})
+		return ok
+	}
+	return false
+}
+
+func nestedLabels(depth int) map[string]interface{} {
+	labels := map[string]interface{}{"leaf": "value"}
+	for i := 0; i < depth; i++ {
+		labels = map[string]interface{}{fmt.Sprintf("level-%d", depth-i): labels}
+	}
+	return labels
+}
+
+func manyLabels(count int) map[string]interface{} {
+	labels := make(map[string]interface{}, count)
+	for i := 0; i < count; i++ {
+		labels[fmt.Sprintf("fuzz-label-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	return labels
+}
+
+func manyNodes(count int) []interface{} {
+	nodes := make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		nodes = append(nodes, map[string]interface{}{"id": NewProjectID(), "role": "all"})
+	}
+	return nodes
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFuzzPayloads(t *testing.T) {
+	valid := []byte(`{"name":"demo","template":"baseline-k3s-v0.0.10","nodes":[{"id":"guid","role":"all"}],"labels":{"a":"b"}}`)
+	payloads, err := FuzzPayloads(valid, "name")
+	if err != nil {
+		t.Fatalf("Failed to derive payloads: %v", err)
+	}
+
+	names := map[string]bool{}
+	seen := map[string]map[string]interface{}{}
+	for _, payload := range payloads {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(payload.Body, &doc); err != nil {
+			t.Fatalf("Payload %q is not valid JSON: %v", payload.Name, err)
+		}
+		if seen[payload.Name] != nil {
+			t.Errorf("Duplicate payload %q", payload.Name)
+		}
+		seen[payload.Name] = doc
+		if name, ok := doc["name"].(string); ok {
+			if names[name] {
+				t.Errorf("Payload %q reuses the name %q", payload.Name, name)
+			}
+			names[name] = true
+		}
+	}
+
+	for _, expected := range []string{"name: null", "name: number", "name: 64KiB string", "template: emoji string",
+		"nodes: string", "nodes: 1000 entries", "labels: nested 64 levels deep", "labels: missing"} {
+		if seen[expected] == nil {
+			t.Errorf("Expected a payload %q", expected)
+		}
+	}
+	if _, ok := seen["name: string"]; ok {
+		t.Error("Expected no wrong-type payload of the type the field already has")
+	}
+	if seen["name: null"]["name"] != nil {
+		t.Errorf("Expected the name to be null, got %v", seen["name: null"]["name"])
+	}
+	if _, ok := seen["labels: missing"]["labels"]; ok {
+		t.Error("Expected the labels to be removed")
+	}
+	if got := seen["template: emoji string"]["template"]; got != fuzzEmojiString {
+		t.Errorf("Expected the emoji template name, got %v", got)
+	}
+	if nodes := seen["nodes: 1000 entries"]["nodes"].([]interface{}); len(nodes) != 1000 {
+		t.Errorf("Expected 1000 nodes, got %d", len(nodes))
+	}
+}
+
+func TestFuzzPayloadsRejectsInvalidBase(t *testing.T) {
+	if _, err := FuzzPayloads([]byte("[]"), "name"); err == nil {
+		t.Error("Expected an error for a payload that is not an object")
+	}
+}
+
+func TestLeakedInternals(t *testing.T) {
+	leaking := map[string]string{
+		"stack trace":     "goroutine 42 [running]:\nmain.main()",
+		"panic":           `{"message":"panic: runtime error: index out of range"}`,
+		"source location": `{"message":"failed at handlers.go:123"}`,
+		"package path":    `{"message":"github.com/open-edge-platform/cluster-manager/internal/k8s: failed"}`,
+		"service address": `{"message":"Post https://webhook.default.svc:443/validate"}`,
+		"dial error":      `{"message":"dial tcp 10.96.0.1:443: connect: connection refused"}`,
+		"api server":      `{"message":"Internal error occurred: failed calling webhook"}`,
+	}
+	for name, body := range leaking {
+		if len(LeakedInternals(body)) == 0 {
+			t.Errorf("Expected the %s in %q to be reported", name, body)
+		}
+	}
+
+	clean := []string{
+		`{"message":"invalid cluster name: must be at most 63 characters"}`,
+		`{"message":"template baseline-k3s-v0.0.10 not found"}`,
+		`{"message":"clusterconfiguration apiVersion controlplane.cluster.x-k8s.io/v1beta2 is not supported"}`,
+	}
+	for _, body := range clean {
+		if leaks := LeakedInternals(body); len(leaks) != 0 {
+			t.Errorf("Expected no leaks in %q, got %v", body, leaks)
+		}
+	}
+}
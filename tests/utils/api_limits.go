@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+// Limits documented by the cluster-manager OpenAPI spec and the Kubernetes label syntax it refers to.
+const (
+	MaxClusterNameLength         = 63
+	MaxClusterNodes              = 1000
+	MaxTemplateDescriptionLength = 4096
+	// MaxLabelPrefixLength is what cluster-manager enforces, one short of the 253 of a DNS subdomain.
+	MaxLabelPrefixLength = 252
+	MaxLabelNameLength   = 63
+	MaxLabelValueLength  = 63
+
+	// TemplateConfigLimitEnvVar is the clusterconfiguration padding, in KiB, a template import must accept.
+	TemplateConfigLimitEnvVar     = "TEMPLATE_CONFIG_LIMIT_KIB"
+	DefaultTemplateConfigLimitKiB = 512
+	// OversizedTemplateConfigBytes is past the 1.5MiB etcd accepts for a single object, so a template this
+	// large can never be stored and must be refused up front.
+	OversizedTemplateConfigBytes = 2 * 1024 * 1024
+
+	// LimitLabelPrefix is a valid label prefix for keys testing the name segment.
+	LimitLabelPrefix = "cluster-tests.example.com"
+	// limitPaddingPath is the kthreesConfigSpec file that pads a template to a given size.
+	limitPaddingPath = "/etc/cluster-tests/limit-padding.txt"
+)
+
+// TemplateConfigLimit returns the clusterconfiguration padding in bytes a template import must accept,
+// from TEMPLATE_CONFIG_LIMIT_KIB or the default.
+func TemplateConfigLimit() int {
+	if kib, err := strconv.Atoi(os.Getenv(TemplateConfigLimitEnvVar)); err == nil && kib > 0 {
+		return kib * 1024
+	}
+	return DefaultTemplateConfigLimitKiB * 1024
+}
+
+// LimitString returns a string of length characters that is a valid cluster name and label segment.
+func LimitString(length int) string {
+	return strings.Repeat("a", length)
+}
+
+// LimitLabelPrefixOf returns a DNS subdomain of exactly length characters, made of labels of at most 63
+// characters, to be used as a label key prefix.
+func LimitLabelPrefixOf(length int) string {
+	var segments []string
+	for remaining := length; remaining > 0; {
+		size := 63
+		if remaining < size {
+			size = remaining
+		}
+		if remaining-size == 1 {
+			// A single character left would need a dot and an empty label.
+			size--
+		}
+		segments = append(segments, LimitString(size))
+		remaining -= size + 1
+	}
+	return strings.Join(segments, ".")
+}
+
+// LimitTemplate returns a k3s baseline template import body with the given version, a description of
+// descriptionLength characters and a kthreesConfigSpec file of paddingBytes characters, or no padding file
+// when paddingBytes is 0.
+func LimitTemplate(version string, descriptionLength, paddingBytes int) ([]byte, error) {
+	data, err := readClusterTemplate(TemplateTypeK3sBaseline)
+	if err != nil {
+		return nil, err
+	}
+	var template map[string]interface{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse the baseline template: %w", err)
+	}
+	template["version"] = version
+	template["description"] = LimitString(descriptionLength)
+
+	if paddingBytes > 0 {
+		spec, err := kthreesConfigSpec(template["clusterconfiguration"])
+		if err != nil {
+			return nil, err
+		}
+		files, _ := spec["files"].([]interface{})
+		spec["files"] = append(files, map[string]interface{}{
+			"path":        limitPaddingPath,
+			"content":     LimitString(paddingBytes),
+			"permissions": "0644",
+		})
+	}
+	return json.Marshal(template)
+}
+
+// TemplatePaddingLength returns the length of the padding file LimitTemplate added to a template, or -1
+// when the template has none.
+func TemplatePaddingLength(template *api.TemplateInfo) int {
+	if template == nil || template.Clusterconfiguration == nil {
+		return -1
+	}
+	spec, err := kthreesConfigSpec(*template.Clusterconfiguration)
+	if err != nil {
+		return -1
+	}
+	files, _ := spec["files"].([]interface{})
+	for _, file := range files {
+		entry, _ := file.(map[string]interface{})
+		if entry["path"] == limitPaddingPath {
+			content, _ := entry["content"].(string)
+			return len(content)
+		}
+	}
+	return -1
+}
+
+// kthreesConfigSpec returns spec.template.spec.kthreesConfigSpec of a decoded KThreesControlPlaneTemplate.
+func kthreesConfigSpec(clusterConfiguration interface{}) (map[string]interface{}, error) {
+	node, _ := clusterConfiguration.(map[string]interface{})
+	for _, field := range []string{"spec", "template", "spec", "kthreesConfigSpec"} {
+		next, ok := node[field].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("clusterconfiguration has no %s", field)
+		}
+		node = next
+	}
+	return node, nil
+}
+
+// LimitCluster returns a cluster create body for templateName with nodes nodes that do not exist and
+// the given labels.
+func LimitCluster(name, templateName string, nodes int, labels map[string]string) ([]byte, error) {
+	cluster := map[string]interface{}{
+		"name":     name,
+		"template": templateName,
+		"nodes":    manyNodes(nodes),
+		"labels":   labels,
+	}
+	return json.Marshal(cluster)
+}
+
+// LimitLabels returns labels whose keys and values are exactly at the documented limits.
+func LimitLabels() map[string]string {
+	return map[string]string{
+		LimitLabelPrefix + "/" + LimitString(MaxLabelNameLength):                         LimitString(MaxLabelValueLength),
+		LimitLabelPrefixOf(MaxLabelPrefixLength) + "/" + LimitString(MaxLabelNameLength): "prefix",
+	}
+}
+
+// OverLimitLabels returns label sets that each go one character over a documented limit.
+func OverLimitLabels() map[string]map[string]string {
+	return map[string]map[string]string{
+		"label name over the limit": {
+			LimitLabelPrefix + "/" + LimitString(MaxLabelNameLength+1): "value",
+		},
+		"label value over the limit": {
+			LimitLabelPrefix + "/value": LimitString(MaxLabelValueLength + 1),
+		},
+		"label prefix over the limit": {
+			LimitLabelPrefixOf(MaxLabelPrefixLength+1) + "/name": "value",
+		},
+	}
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+// dnsSubdomain is the Kubernetes DNS subdomain syntax label prefixes must follow.
+var dnsSubdomain = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+func TestLimitLabelPrefixOf(t *testing.T) {
+	for _, length := range []int{1, 63, 64, 65, 127, 128, MaxLabelPrefixLength, MaxLabelPrefixLength + 1} {
+		prefix := LimitLabelPrefixOf(length)
+		if len(prefix) != length {
+			t.Errorf("Expected a prefix of %d characters, got %d", length, len(prefix))
+		}
+		if !dnsSubdomain.MatchString(prefix) {
+			t.Errorf("Expected a DNS subdomain of %d characters, got %q", length, prefix)
+		}
+		for _, segment := range strings.Split(prefix, ".") {
+			if len(segment) > 63 {
+				t.Errorf("Expected segments of at most 63 characters, got %d in the %d prefix", len(segment), length)
+			}
+		}
+	}
+}
+
+func TestLimitLabels(t *testing.T) {
+	for key, value := range LimitLabels() {
+		prefix, name, _ := strings.Cut(key, "/")
+		if len(name) != MaxLabelNameLength || len(prefix) > MaxLabelPrefixLength || len(value) > MaxLabelValueLength {
+			t.Errorf("Expected the label %q=%q to be at the limits", key, value)
+		}
+	}
+	for name, labels := range OverLimitLabels() {
+		if len(labels) != 1 {
+			t.Errorf("Expected %s to hold a single label, got %d", name, len(labels))
+		}
+	}
+}
+
+func TestLimitTemplate(t *testing.T) {
+	body, err := LimitTemplate("v9.9.9", MaxTemplateDescriptionLength, 4096)
+	if err != nil {
+		t.Fatalf("Failed to render the template: %v", err)
+	}
+	var template api.TemplateInfo
+	if err := json.Unmarshal(body, &template); err != nil {
+		t.Fatalf("Failed to parse the template: %v", err)
+	}
+	if template.Version != "v9.9.9" || template.Description == nil || len(*template.Description) != MaxTemplateDescriptionLength {
+		t.Errorf("Expected version v9.9.9 and a 4096 character description, got %s", template.Version)
+	}
+	if got := TemplatePaddingLength(&template); got != 4096 {
+		t.Errorf("Expected 4096 bytes of padding, got %d", got)
+	}
+
+	body, err = LimitTemplate("v9.9.8", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to render the template: %v", err)
+	}
+	template = api.TemplateInfo{}
+	if err := json.Unmarshal(body, &template); err != nil {
+		t.Fatalf("Failed to parse the template: %v", err)
+	}
+	if got := TemplatePaddingLength(&template); got != -1 {
+		t.Errorf("Expected no padding, got %d", got)
+	}
+}
+
+func TestLimitCluster(t *testing.T) {
+	body, err := LimitCluster(LimitString(MaxClusterNameLength), K3sTemplateName, MaxClusterNodes+1, LimitLabels())
+	if err != nil {
+		t.Fatalf("Failed to render the cluster: %v", err)
+	}
+	var cluster api.ClusterSpec
+	if err := json.Unmarshal(body, &cluster); err != nil {
+		t.Fatalf("Failed to parse the cluster: %v", err)
+	}
+	if cluster.Name == nil || len(*cluster.Name) != MaxClusterNameLength {
+		t.Errorf("Expected a %d character name, got %v", MaxClusterNameLength, cluster.Name)
+	}
+	if len(cluster.Nodes) != MaxClusterNodes+1 {
+		t.Errorf("Expected %d nodes, got %d", MaxClusterNodes+1, len(cluster.Nodes))
+	}
+}
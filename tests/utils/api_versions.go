@@ -0,0 +1,261 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const (
+	// ClusterManagerAPIV2 is the cluster-manager API version every other helper of this package talks.
+	ClusterManagerAPIV2 = "v2"
+
+	// ClusterManagerAPIVersionsEnvVar are the comma-separated API versions cluster-manager serves, e.g.
+	// "v2,v3alpha1". Specs that use a version it does not list are skipped.
+	ClusterManagerAPIVersionsEnvVar  = "CLUSTER_MANAGER_API_VERSIONS"
+	DefaultClusterManagerAPIVersions = ClusterManagerAPIV2
+
+	apiVersionLabelPrefix = "api:"
+	unservedAPIPrefix     = "cluster-manager does not serve API "
+
+	// DeprecationHeader and SunsetHeader announce the retirement of an endpoint (RFC 9745, RFC 8594).
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// ClusterManagerAPI builds the requests of one version of the cluster-manager API, so that a spec written
+// against it runs unchanged against another version.
+type ClusterManagerAPI interface {
+	Version() string
+	TemplatesURL() string
+	TemplateURL(name, version string) string
+	ClustersURL() string
+	ClusterURL(name string) string
+	ClustersSummaryURL() string
+	ClusterKubeconfigURL(name string) string
+	// ClusterBody returns the create body of a cluster of templateName with a node per GUID.
+	ClusterBody(name, templateName string, nodeGUIDs ...string) ([]byte, error)
+}
+
+// pathVersionedAPI is a version whose endpoints and bodies are those of v2 under another path prefix, which is
+// how new versions start out. A version that diverges registers its own ClusterManagerAPI.
+type pathVersionedAPI struct {
+	version string
+}
+
+func (a pathVersionedAPI) Version() string { return a.version }
+
+func (a pathVersionedAPI) base() string {
+	return GetClusterManagerEndpoint() + "/" + a.version
+}
+
+func (a pathVersionedAPI) TemplatesURL() string { return a.base() + "/templates" }
+
+func (a pathVersionedAPI) TemplateURL(name, version string) string {
+	return fmt.Sprintf("%s/%s/%s", a.TemplatesURL(), name, version)
+}
+
+func (a pathVersionedAPI) ClustersURL() string { return a.base() + "/clusters" }
+
+func (a pathVersionedAPI) ClusterURL(name string) string { return a.ClustersURL() + "/" + name }
+
+func (a pathVersionedAPI) ClustersSummaryURL() string { return a.ClustersURL() + "/summary" }
+
+func (a pathVersionedAPI) ClusterKubeconfigURL(name string) string {
+	return a.ClusterURL(name) + "/kubeconfigs"
+}
+
+func (a pathVersionedAPI) ClusterBody(name, templateName string, nodeGUIDs ...string) ([]byte, error) {
+	return RenderMultiNodeClusterConfig(name, AllRoleNodes(nodeGUIDs...), templateName, ClusterConfigOptions{})
+}
+
+var (
+	clusterManagerAPIs   = map[string]ClusterManagerAPI{ClusterManagerAPIV2: pathVersionedAPI{version: ClusterManagerAPIV2}}
+	clusterManagerAPIsMu sync.Mutex
+)
+
+// RegisterClusterManagerAPI makes ClusterManagerAPIFor return api for its version, for versions whose
+// endpoints or bodies differ from v2.
+func RegisterClusterManagerAPI(api ClusterManagerAPI) {
+	clusterManagerAPIsMu.Lock()
+	defer clusterManagerAPIsMu.Unlock()
+	clusterManagerAPIs[api.Version()] = api
+}
+
+// ClusterManagerAPIFor returns the client of an API version, shaped like v2 unless the version registered its
+// own with RegisterClusterManagerAPI.
+func ClusterManagerAPIFor(version string) ClusterManagerAPI {
+	clusterManagerAPIsMu.Lock()
+	defer clusterManagerAPIsMu.Unlock()
+	if api, ok := clusterManagerAPIs[version]; ok {
+		return api
+	}
+	return pathVersionedAPI{version: version}
+}
+
+// ClusterManagerAPIVersions returns the versions of CLUSTER_MANAGER_API_VERSIONS, always including v2.
+func ClusterManagerAPIVersions() []string {
+	versions := []string{ClusterManagerAPIV2}
+	for _, version := range splitList(GetEnv(ClusterManagerAPIVersionsEnvVar, DefaultClusterManagerAPIVersions)) {
+		if !slices.Contains(versions, version) {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// NewerClusterManagerAPIVersions returns the served versions other than v2.
+func NewerClusterManagerAPIVersions() []string {
+	return ClusterManagerAPIVersions()[1:]
+}
+
+// UsesClusterManagerAPI is a Ginkgo decorator selecting the API version of a container or spec, see
+// CurrentClusterManagerAPI. Suites must call RegisterClusterManagerAPIGating for specs of versions that are
+// not served to be skipped.
+func UsesClusterManagerAPI(version string) ginkgo.Labels {
+	return ginkgo.Labels{apiVersionLabelPrefix + version}
+}
+
+// apiVersionOf returns the version the labels select, the innermost one when several do, and v2 otherwise.
+func apiVersionOf(labels []string) string {
+	version := ClusterManagerAPIV2
+	for _, label := range labels {
+		if v, ok := strings.CutPrefix(label, apiVersionLabelPrefix); ok {
+			version = v
+		}
+	}
+	return version
+}
+
+// CurrentClusterManagerAPI returns the client of the API version the running spec selects with
+// UsesClusterManagerAPI, v2 by default.
+func CurrentClusterManagerAPI() ClusterManagerAPI {
+	return ClusterManagerAPIFor(apiVersionOf(ginkgo.CurrentSpecReport().Labels()))
+}
+
+// RegisterClusterManagerAPIGating installs a suite-wide hook that skips the specs of API versions missing from
+// CLUSTER_MANAGER_API_VERSIONS. Call it once per suite: var _ = utils.RegisterClusterManagerAPIGating()
+func RegisterClusterManagerAPIGating() bool {
+	ginkgo.BeforeEach(func() {
+		if version := apiVersionOf(ginkgo.CurrentSpecReport().Labels()); !slices.Contains(ClusterManagerAPIVersions(), version) {
+			ginkgo.Skip(fmt.Sprintf("%s%s, set %s to run it", unservedAPIPrefix, version, ClusterManagerAPIVersionsEnvVar))
+		}
+	})
+	return true
+}
+
+// APIResponse is what cluster-manager answered, whatever the status.
+type APIResponse struct {
+	Status int
+	Body   string
+	Header http.Header
+}
+
+// ClusterManagerRequest sends a request to cluster-manager on behalf of a project, with the token of
+// authContext when it is not nil, and returns the response with its headers.
+func ClusterManagerRequest(authContext *auth.TestAuthContext, namespace, method, url string, body []byte) (APIResponse, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return APIResponse{}, err
+	}
+	SetNamespaceHeader(req, namespace)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := NewHTTPClient()
+	if authContext != nil {
+		client = AuthenticatedHTTPClient(authContext)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return APIResponse{}, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return APIResponse{Status: resp.StatusCode, Body: string(data), Header: resp.Header}, err
+}
+
+// Deprecation describes the retirement an endpoint announces in its response headers.
+type Deprecation struct {
+	Deprecated bool
+	// Sunset is when the endpoint stops being served, zero when it is not announced.
+	Sunset time.Time
+}
+
+// DeprecationOf reads the Deprecation and Sunset headers of a response.
+func DeprecationOf(header http.Header) Deprecation {
+	var d Deprecation
+	if value := strings.TrimSpace(header.Get(DeprecationHeader)); value != "" && value != "false" {
+		d.Deprecated = true
+	}
+	if sunset, err := http.ParseTime(header.Get(SunsetHeader)); err == nil {
+		d.Sunset = sunset
+	}
+	return d
+}
+
+// CheckDeprecation fails for an endpoint past its announced sunset, which must no longer answer, or about to
+// be removed without having been marked deprecated. Deprecated endpoints before their sunset are fine.
+func CheckDeprecation(endpoint string, d Deprecation, now time.Time) error {
+	if d.Sunset.IsZero() {
+		return nil
+	}
+	if !d.Deprecated {
+		return fmt.Errorf("%s announces a sunset on %s without being deprecated", endpoint, d.Sunset.Format(time.RFC1123))
+	}
+	if now.After(d.Sunset) {
+		return fmt.Errorf("%s is still served after its sunset on %s", endpoint, d.Sunset.Format(time.RFC1123))
+	}
+	return nil
+}
+
+var (
+	deprecatedEndpoints   = map[string]Deprecation{}
+	deprecatedEndpointsMu sync.Mutex
+)
+
+// RecordDeprecation remembers the deprecation of an endpoint for ReportDeprecatedEndpoints.
+func RecordDeprecation(endpoint string, d Deprecation) {
+	if !d.Deprecated {
+		return
+	}
+	deprecatedEndpointsMu.Lock()
+	defer deprecatedEndpointsMu.Unlock()
+	deprecatedEndpoints[endpoint] = d
+}
+
+// ReportDeprecatedEndpoints prints the deprecated endpoints the suite exercised, so that the migrations they
+// call for show up in the run.
+func ReportDeprecatedEndpoints() {
+	deprecatedEndpointsMu.Lock()
+	defer deprecatedEndpointsMu.Unlock()
+	if len(deprecatedEndpoints) == 0 {
+		return
+	}
+	endpoints := make([]string, 0, len(deprecatedEndpoints))
+	for endpoint := range deprecatedEndpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	fmt.Printf("\n\033[33mDeprecated cluster-manager endpoints still in use:\033[0m\n")
+	for _, endpoint := range endpoints {
+		sunset := "no sunset announced"
+		if s := deprecatedEndpoints[endpoint].Sunset; !s.IsZero() {
+			sunset = "sunset on " + s.Format(time.RFC1123)
+		}
+		fmt.Printf("  - %s: %s\n", endpoint, sunset)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClusterManagerAPIVersions(t *testing.T) {
+	for value, expected := range map[string][]string{
+		"":                 {"v2"},
+		"v2":               {"v2"},
+		"v3alpha1":         {"v2", "v3alpha1"},
+		"v3alpha1, v2, v3": {"v2", "v3alpha1", "v3"},
+	} {
+		t.Setenv(ClusterManagerAPIVersionsEnvVar, value)
+		if versions := ClusterManagerAPIVersions(); !reflect.DeepEqual(versions, expected) {
+			t.Errorf("Expected %v for %q, got %v", expected, value, versions)
+		}
+	}
+}
+
+func TestClusterManagerAPIFor(t *testing.T) {
+	v2 := ClusterManagerAPIFor(ClusterManagerAPIV2)
+	if url := v2.ClusterKubeconfigURL("demo"); url != ClusterCreateURL+"/demo/kubeconfigs" {
+		t.Errorf("Expected the v2 kubeconfig URL, got %s", url)
+	}
+	if url := v2.TemplatesURL(); url != ClusterTemplateURL {
+		t.Errorf("Expected %s, got %s", ClusterTemplateURL, url)
+	}
+	if url := ClusterManagerAPIFor("v3alpha1").TemplateURL("baseline-k3s", "v0.0.10"); !strings.HasSuffix(url, "/v3alpha1/templates/baseline-k3s/v0.0.10") {
+		t.Errorf("Expected an unregistered version to be shaped like v2, got %s", url)
+	}
+
+	RegisterClusterManagerAPI(testAPI{pathVersionedAPI{version: "v9test"}})
+	if _, ok := ClusterManagerAPIFor("v9test").(testAPI); !ok {
+		t.Error("Expected the registered client of v9test")
+	}
+}
+
+type testAPI struct {
+	pathVersionedAPI
+}
+
+func TestAPIVersionOf(t *testing.T) {
+	if version := apiVersionOf([]string{ClusterOrchApiVersionTest}); version != ClusterManagerAPIV2 {
+		t.Errorf("Expected v2 without a decorator, got %s", version)
+	}
+	labels := append(append([]string{}, UsesClusterManagerAPI("v3")...), UsesClusterManagerAPI("v3alpha1")...)
+	if version := apiVersionOf(labels); version != "v3alpha1" {
+		t.Errorf("Expected the innermost version, got %s", version)
+	}
+	if !IsSpecLabel(UsesClusterManagerAPI("v3alpha1")[0]) {
+		t.Error("Expected the label of UsesClusterManagerAPI to be a spec label")
+	}
+}
+
+func TestCheckDeprecation(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	header := http.Header{}
+	header.Set(DeprecationHeader, "@1767225600")
+	header.Set(SunsetHeader, "Tue, 01 Dec 2026 00:00:00 GMT")
+	deprecation := DeprecationOf(header)
+	if !deprecation.Deprecated || deprecation.Sunset.Month() != time.December {
+		t.Fatalf("Expected a deprecation with a sunset in December, got %+v", deprecation)
+	}
+	if err := CheckDeprecation("GET /v2/clusters", deprecation, now); err != nil {
+		t.Errorf("Expected a deprecated endpoint before its sunset to pass, got %v", err)
+	}
+	if err := CheckDeprecation("GET /v2/clusters", deprecation, now.AddDate(1, 0, 0)); err == nil {
+		t.Error("Expected an error for an endpoint served after its sunset")
+	}
+	if err := CheckDeprecation("GET /v2/clusters", Deprecation{Sunset: deprecation.Sunset}, now); err == nil {
+		t.Error("Expected an error for a sunset without a deprecation")
+	}
+	if d := DeprecationOf(http.Header{}); d.Deprecated || !d.Sunset.IsZero() {
+		t.Errorf("Expected no deprecation without headers, got %+v", d)
+	}
+}
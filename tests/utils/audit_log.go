@@ -0,0 +1,242 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AuditLogEnvVar enables the audit trail checks when "true".
+	AuditLogEnvVar = "AUDIT_LOG"
+	// AuditLogDeploymentsEnvVar lists the deployments whose logs hold the audit trail, comma separated.
+	AuditLogDeploymentsEnvVar  = "AUDIT_LOG_DEPLOYMENTS"
+	DefaultAuditLogDeployments = "cluster-manager"
+	// AuditSubjectFieldsEnvVar lists the log fields that may carry the subject of a request, comma separated.
+	AuditSubjectFieldsEnvVar  = "AUDIT_SUBJECT_FIELDS"
+	DefaultAuditSubjectFields = "sub,subject,user,username,preferred_username"
+)
+
+// AuditLogEnabled reports whether AUDIT_LOG asks for the audit trail checks.
+func AuditLogEnabled() bool {
+	return os.Getenv(AuditLogEnvVar) == "true"
+}
+
+// AuditRecord is a structured log line of a component: the fields of a JSON or a key=value line.
+type AuditRecord struct {
+	At         time.Time
+	Deployment string
+	Fields     map[string]string
+}
+
+// AuditOperation describes how an operation shows up in the logs: a request log line with its method and
+// path, or a line carrying all of Fields, such as the message and the name of the cluster.
+type AuditOperation struct {
+	Name   string
+	Method string
+	Path   string
+	Fields map[string]string
+}
+
+// ClusterAuditOperations returns the cluster operations whose subject must be traceable: creating and
+// deleting clusterName and fetching its kubeconfig.
+func ClusterAuditOperations(clusterName string) []AuditOperation {
+	return []AuditOperation{
+		{
+			Name: "create cluster", Method: "POST", Path: "/v2/clusters",
+			Fields: map[string]string{"msg": "Cluster created", "name": clusterName},
+		},
+		{
+			Name: "delete cluster", Method: "DELETE", Path: "/v2/clusters/" + clusterName,
+			Fields: map[string]string{"msg": "cluster deleted", "name": clusterName},
+		},
+		{
+			Name: "access kubeconfig", Method: "GET", Path: "/v2/clusters/" + clusterName + "/kubeconfigs",
+		},
+	}
+}
+
+// Matches reports whether record logs the operation.
+func (o AuditOperation) Matches(record AuditRecord) bool {
+	if o.Method != "" && strings.EqualFold(record.Fields["method"], o.Method) && record.Fields["path"] == o.Path {
+		return true
+	}
+	if len(o.Fields) == 0 {
+		return false
+	}
+	for key, value := range o.Fields {
+		if record.Fields[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseAuditRecord parses a log line written by slog, as JSON or as key=value pairs. Lines without any
+// field are not records.
+func ParseAuditRecord(line string) (AuditRecord, bool) {
+	line = strings.TrimSpace(line)
+	fields := map[string]string{}
+	if strings.HasPrefix(line, "{") {
+		var raw map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &raw); err != nil {
+			return AuditRecord{}, false
+		}
+		for key, value := range raw {
+			if text, ok := value.(string); ok {
+				fields[key] = text
+			} else {
+				fields[key] = fmt.Sprint(value)
+			}
+		}
+	} else {
+		fields = parseKeyValues(line)
+	}
+	if len(fields) == 0 {
+		return AuditRecord{}, false
+	}
+	return AuditRecord{Fields: fields}, true
+}
+
+// parseKeyValues parses key=value pairs, where values may be double-quoted with escapes.
+func parseKeyValues(line string) map[string]string {
+	fields := map[string]string{}
+	for line != "" {
+		line = strings.TrimLeft(line, " ")
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 || strings.ContainsAny(line[:eq], " \"") {
+			// Not a key=value pair: skip the word.
+			if space := strings.IndexByte(line, ' '); space >= 0 {
+				line = line[space:]
+				continue
+			}
+			break
+		}
+		key, rest := line[:eq], line[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(rest) {
+				break
+			}
+			unquoted, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				break
+			}
+			value, line = unquoted, rest[end+1:]
+		} else if space := strings.IndexByte(rest, ' '); space >= 0 {
+			value, line = rest[:space], rest[space:]
+		} else {
+			value, line = rest, ""
+		}
+		fields[key] = value
+	}
+	return fields
+}
+
+// AuditSubjectFields returns the fields that may carry the subject, from AUDIT_SUBJECT_FIELDS.
+func AuditSubjectFields() []string {
+	var fields []string
+	for _, field := range strings.Split(GetEnv(AuditSubjectFieldsEnvVar, DefaultAuditSubjectFields), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Subject returns the subject a record attributes its operation to, or "" when it has none.
+func (r AuditRecord) Subject(subjectFields []string) string {
+	for _, field := range subjectFields {
+		if subject := r.Fields[field]; subject != "" {
+			return subject
+		}
+	}
+	return ""
+}
+
+// FetchAuditRecords returns the structured log lines written since the given time by the deployments of
+// AUDIT_LOG_DEPLOYMENTS.
+func FetchAuditRecords(since time.Time) ([]AuditRecord, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	namespace := ComponentNamespace()
+	var records []AuditRecord
+	for _, deployment := range strings.Split(GetEnv(AuditLogDeploymentsEnvVar, DefaultAuditLogDeployments), ",") {
+		deployment = strings.TrimSpace(deployment)
+		if deployment == "" {
+			continue
+		}
+		out, err := client.DeploymentLogsSince(namespace, deployment, since)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the logs of %s: %w", deployment, err)
+		}
+		scanner := bufio.NewScanner(strings.NewReader(string(out)))
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line, ok := parseComponentLogLine(scanner.Text())
+			if !ok {
+				continue
+			}
+			// The text starts with the [pod/<pod>/<container>] the line was logged by.
+			_, message, _ := strings.Cut(line.text, "] ")
+			record, ok := ParseAuditRecord(message)
+			if !ok {
+				continue
+			}
+			record.At, record.Deployment = line.at, deployment
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// VerifyAuditTrail returns the problems with the audit trail of the operations: an operation that is not
+// logged, or only logged without a subject or with another subject than the expected one.
+func VerifyAuditTrail(records []AuditRecord, operations []AuditOperation, subject string, subjectFields []string) []string {
+	var problems []string
+	for _, operation := range operations {
+		var others []string
+		found, attributed := false, false
+		for _, record := range records {
+			if !operation.Matches(record) {
+				continue
+			}
+			found = true
+			switch recorded := record.Subject(subjectFields); recorded {
+			case subject:
+				attributed = true
+			case "":
+			default:
+				others = append(others, recorded)
+			}
+		}
+		switch {
+		case attributed:
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s is not logged", operation.Name))
+		case len(others) > 0:
+			problems = append(problems, fmt.Sprintf("%s is attributed to %s instead of %s", operation.Name,
+				strings.Join(others, ", "), subject))
+		default:
+			problems = append(problems, fmt.Sprintf("%s is logged without a subject in any of %s", operation.Name,
+				strings.Join(subjectFields, ", ")))
+		}
+	}
+	return problems
+}
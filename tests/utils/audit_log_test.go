@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAuditRecord(t *testing.T) {
+	text, ok := ParseAuditRecord(`time=2026-01-02T03:04:05.000Z level=INFO source=postv2clusters.go:127 PostV2Clusters msg="Cluster created" namespace=demo name=audit-cluster user="jane \"jd\" doe"`)
+	if !ok {
+		t.Fatal("Expected a record from a key=value line")
+	}
+	expected := map[string]string{"level": "INFO", "msg": "Cluster created", "name": "audit-cluster", "user": `jane "jd" doe`}
+	for key, value := range expected {
+		if text.Fields[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, text.Fields[key])
+		}
+	}
+
+	jsonRecord, ok := ParseAuditRecord(`{"time":"2026-01-02T03:04:05.000Z","level":"DEBUG","msg":"received request","method":"GET","path":"/v2/clusters","status":200}`)
+	if !ok {
+		t.Fatal("Expected a record from a JSON line")
+	}
+	if jsonRecord.Fields["method"] != "GET" || jsonRecord.Fields["status"] != "200" {
+		t.Errorf("Expected the method and the status, got %v", jsonRecord.Fields)
+	}
+
+	for _, line := range []string{"", "plain text without fields", "{not json"} {
+		if _, ok := ParseAuditRecord(line); ok {
+			t.Errorf("Expected no record from %q", line)
+		}
+	}
+}
+
+func TestVerifyAuditTrail(t *testing.T) {
+	record := func(line string) AuditRecord {
+		r, ok := ParseAuditRecord(line)
+		if !ok {
+			t.Fatalf("Failed to parse %q", line)
+		}
+		return r
+	}
+	records := []AuditRecord{
+		record(`level=DEBUG msg="received request" method=POST path=/v2/clusters sub=audit-user`),
+		record(`level=INFO msg="cluster deleted" namespace=demo name=audit-cluster user=someone-else`),
+		record(`level=DEBUG msg="received request" method=GET path=/v2/clusters/audit-cluster/kubeconfigs`),
+	}
+	fields := []string{"sub", "user"}
+
+	problems := VerifyAuditTrail(records, ClusterAuditOperations("audit-cluster"), "audit-user", fields)
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", problems)
+	}
+	if !strings.Contains(problems[0], "delete cluster is attributed to someone-else") {
+		t.Errorf("Expected the delete to be attributed to someone else, got %q", problems[0])
+	}
+	if !strings.Contains(problems[1], "access kubeconfig is logged without a subject") {
+		t.Errorf("Expected the kubeconfig access to have no subject, got %q", problems[1])
+	}
+
+	problems = VerifyAuditTrail(nil, ClusterAuditOperations("audit-cluster"), "audit-user", fields)
+	if len(problems) != 3 || !strings.HasSuffix(problems[0], "is not logged") {
+		t.Errorf("Expected every operation to be missing, got %v", problems)
+	}
+}
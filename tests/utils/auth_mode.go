@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DisableAuthEnvVar overrides the detected auth mode of cluster-manager when set to "true" or "false".
+const DisableAuthEnvVar = "DISABLE_AUTH"
+
+var (
+	authDisabled     bool
+	authDisabledOnce sync.Once
+)
+
+// AuthDisabled reports whether cluster-manager serves its API without authentication. DISABLE_AUTH takes
+// precedence; otherwise the -disable-auth flag of the cluster-manager deployment decides, and as a last
+// resort an unauthenticated request through the port-forward (401 means auth is enabled). The result is
+// detected once per suite.
+func AuthDisabled() bool {
+	authDisabledOnce.Do(func() {
+		disabled, source := detectAuthDisabled()
+		authDisabled = disabled
+		fmt.Printf("Authentication %s (%s)\n", map[bool]string{true: "disabled", false: "enabled"}[disabled], source)
+	})
+	return authDisabled
+}
+
+func detectAuthDisabled() (bool, string) {
+	if value := os.Getenv(DisableAuthEnvVar); value != "" {
+		if disabled, err := strconv.ParseBool(value); err == nil {
+			return disabled, DisableAuthEnvVar + "=" + value
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", DisableAuthEnvVar, value)
+	}
+
+	args, err := clusterManagerArgs()
+	if err == nil {
+		if disabled, found := parseDisableAuthArg(args); found {
+			return disabled, "cluster-manager deployment flags"
+		}
+		// The flag defaults to false in cluster-manager.
+		return false, "cluster-manager deployment without -disable-auth"
+	}
+	fmt.Printf("Unable to read the cluster-manager deployment: %v\n", err)
+
+	status, err := ClusterTemplatesStatus(nil, GetEnv(NamespaceEnvVar, DefaultNamespace))
+	if err == nil {
+		if disabled, err := authDisabledFromStatus(status); err == nil {
+			return disabled, fmt.Sprintf("unauthenticated request answered with %d", status)
+		}
+	}
+	return true, "detection failed, assuming the test environment default"
+}
+
+func clusterManagerArgs() ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := client.GetDeployment(componentReleaseNamespace, "cluster-manager")
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "cluster-manager" {
+			return container.Args, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseDisableAuthArg looks for the -disable-auth flag, in any of the forms accepted by the flag package.
+func parseDisableAuthArg(args []string) (disabled bool, found bool) {
+	return parseBoolFlag(args, "disable-auth")
+}
+
+// parseBoolFlag looks for a boolean flag of the cluster-manager container args.
+func parseBoolFlag(args []string, flag string) (value bool, found bool) {
+	for _, arg := range args {
+		name, raw, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != flag || !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if !hasValue {
+			return true, true
+		}
+		if parsed, err := strconv.ParseBool(raw); err == nil {
+			value, found = parsed, true
+		}
+	}
+	return value, found
+}
+
+func authDisabledFromStatus(status int) (bool, error) {
+	switch status {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status %d", status)
+	}
+}
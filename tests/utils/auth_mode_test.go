@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseDisableAuthArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		disabled bool
+		found    bool
+	}{
+		{"chart rendering", []string{"-loglevel=0", "-disable-auth=true", "-disable-inventory=true"}, true, true},
+		{"double dash", []string{"--disable-auth=false"}, false, true},
+		{"bare flag", []string{"-disable-auth"}, true, true},
+		{"last one wins", []string{"-disable-auth=true", "-disable-auth=false"}, false, true},
+		{"absent", []string{"-disable-multi-tenancy=true"}, false, false},
+		{"not a flag", []string{"disable-auth=true"}, false, false},
+		{"invalid value", []string{"-disable-auth=maybe"}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			disabled, found := parseDisableAuthArg(tt.args)
+			if disabled != tt.disabled || found != tt.found {
+				t.Errorf("Expected (%t, %t), got (%t, %t)", tt.disabled, tt.found, disabled, found)
+			}
+		})
+	}
+}
+
+func TestAuthDisabledFromStatus(t *testing.T) {
+	tests := []struct {
+		status   int
+		disabled bool
+		wantErr  bool
+	}{
+		{http.StatusOK, true, false},
+		{http.StatusUnauthorized, false, false},
+		{http.StatusForbidden, false, false},
+		{http.StatusInternalServerError, false, true},
+	}
+
+	for _, tt := range tests {
+		disabled, err := authDisabledFromStatus(tt.status)
+		if disabled != tt.disabled || (err != nil) != tt.wantErr {
+			t.Errorf("Status %d: expected (%t, error %t), got (%t, %v)", tt.status, tt.disabled, tt.wantErr, disabled, err)
+		}
+	}
+}
+
+func TestDetectAuthDisabledEnvOverride(t *testing.T) {
+	t.Setenv(DisableAuthEnvVar, "false")
+	if disabled, source := detectAuthDisabled(); disabled || source != "DISABLE_AUTH=false" {
+		t.Errorf("Expected the env var to decide, got (%t, %q)", disabled, source)
+	}
+}
@@ -7,12 +7,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"strings"
-	"text/template"
 	"time"
 
 	"github.com/open-edge-platform/cluster-tests/tests/auth"
@@ -25,26 +23,25 @@ const (
 	TempKubeconfigPattern         = "kubeconfig-*.yaml"
 	LocalKubeconfigPattern        = "kubeconfig-local-*.yaml"
 	ConnectGatewayPort            = 8081
-	PortForwardStartupDelay       = 2 * time.Second
 )
 
-// SetupTestAuthentication initializes JWT generation and returns auth context
+// SetupTestAuthentication initializes JWT generation and returns auth context with roles in the
+// project under test, which is a tenancy-created project when NAMESPACE points at one. The deployed OIDC mock is
+// first synchronized with the key of the shared auth state, see SyncOIDCMock.
 func SetupTestAuthentication(subject string) (*auth.TestAuthContext, error) {
-	// Use the simple SetupTestAuthentication from auth package
-	return auth.SetupTestAuthentication(subject)
+	if err := syncOIDCMockOnce(); err != nil {
+		return nil, err
+	}
+	return auth.SetupProjectAuthentication(subject, GetEnv(NamespaceEnvVar, DefaultNamespace))
 }
 
 // AuthenticatedHTTPClient creates an HTTP client with JWT authentication
 func AuthenticatedHTTPClient(authContext *auth.TestAuthContext) *http.Client {
-	client := &http.Client{
-		Timeout: 30 * time.Second,
-	}
+	client := NewHTTPClient()
+	client.Timeout = 30 * time.Second
 
 	// Add JWT token to requests
 	originalTransport := client.Transport
-	if originalTransport == nil {
-		originalTransport = http.DefaultTransport
-	}
 
 	client.Transport = &AuthTransport{
 		Transport: originalTransport,
@@ -112,8 +109,8 @@ func GetClusterKubeconfigFromAPI(authContext *auth.TestAuthContext, namespace, c
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Add namespace header as used by cluster-manager
-	req.Header.Set("Activeprojectid", namespace)
+	// Add the namespace header(s) cluster-manager reads the project from
+	SetNamespaceHeader(req, namespace)
 
 	client := AuthenticatedHTTPClient(authContext)
 	return client.Do(req)
@@ -151,7 +148,7 @@ func GetClusterInfoWithAuth(authContext *auth.TestAuthContext, namespace, cluste
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 
 	client := AuthenticatedHTTPClient(authContext)
 	return client.Do(req)
@@ -159,15 +156,7 @@ func GetClusterInfoWithAuth(authContext *auth.TestAuthContext, namespace, cluste
 
 // ImportClusterTemplateAuthenticated imports a cluster template using JWT authentication
 func ImportClusterTemplateAuthenticated(authContext *auth.TestAuthContext, namespace string, templateType string) error {
-	var data []byte
-	var err error
-	switch templateType {
-	case TemplateTypeK3sBaseline:
-		data, err = os.ReadFile(BaselineClusterTemplatePathK3s)
-	default:
-		return fmt.Errorf("unsupported template type: %s", templateType)
-	}
-
+	data, err := readClusterTemplate(templateType)
 	if err != nil {
 		return err
 	}
@@ -179,7 +168,7 @@ func ImportClusterTemplateAuthenticated(authContext *auth.TestAuthContext, names
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -190,8 +179,7 @@ func ImportClusterTemplateAuthenticated(authContext *auth.TestAuthContext, names
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to import cluster template: %s", string(body))
+		return readAPIError("import cluster template", resp)
 	}
 
 	return nil
@@ -199,38 +187,29 @@ func ImportClusterTemplateAuthenticated(authContext *auth.TestAuthContext, names
 
 // CreateClusterAuthenticated creates a cluster using JWT authentication
 func CreateClusterAuthenticated(authContext *auth.TestAuthContext, namespace, nodeGUID, templateName string) error {
-	templateData, err := os.ReadFile(ClusterConfigTemplatePath)
-	if err != nil {
-		return err
-	}
+	return CreateClusterAuthenticatedWithOptions(authContext, namespace, nodeGUID, templateName, ClusterConfigOptions{})
+}
 
-	tmpl, err := template.New("clusterConfig").Parse(string(templateData))
+// CreateClusterAuthenticatedWithOptions creates a customized cluster using JWT authentication
+func CreateClusterAuthenticatedWithOptions(authContext *auth.TestAuthContext, namespace, nodeGUID, templateName string, opts ClusterConfigOptions) error {
+	opts, err := resolveClusterConfigOptions(opts)
 	if err != nil {
 		return err
 	}
 
-	var configBuffer bytes.Buffer
-	err = tmpl.Execute(&configBuffer, struct {
-		ClusterName  string
-		TemplateName string
-		NodeGUID     string
-	}{
-		NodeGUID:     nodeGUID,
-		TemplateName: templateName,
-		ClusterName:  ClusterName,
-	})
+	data, err := RenderClusterConfig(ClusterName, nodeGUID, templateName, opts)
 	if err != nil {
 		return err
 	}
 
 	client := AuthenticatedHTTPClient(authContext)
 
-	req, err := http.NewRequest("POST", ClusterCreateURL, &configBuffer)
+	req, err := http.NewRequest("POST", ClusterCreateURL, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -241,17 +220,12 @@ func CreateClusterAuthenticated(authContext *auth.TestAuthContext, namespace, no
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create cluster: %s", string(body))
+		return readAPIError("create cluster", resp)
 	}
 
 	// Keep behavior consistent with non-auth flow: ensure the Cluster is unpaused so
 	// ClusterClass topology reconciliation can proceed.
-	if err := UnpauseCluster(namespace, ClusterName); err != nil {
-		return err
-	}
-
-	return nil
+	return finalizeClusterCreation(namespace, ClusterName, opts)
 }
 
 // TestDownstreamClusterAccess tests accessing the downstream cluster using the provided kubeconfig
@@ -286,29 +260,27 @@ func TestDownstreamClusterAccess(kubeconfigContent string) error {
 
 	// Set up port-forward to connect-gateway if not already running
 	if !isPortForwardRunning(ConnectGatewayPort) {
-		cmd := exec.Command("kubectl", "port-forward", "svc/cluster-connect-gateway", fmt.Sprintf("%d:8080", ConnectGatewayPort))
-		err := cmd.Start()
-		if err != nil {
-			return fmt.Errorf("failed to start port-forward to connect-gateway: %w", err)
+		if _, err := StartGatewayPortForward(); err != nil {
+			return err
 		}
-		// Give port-forward a moment to establish
-		time.Sleep(PortForwardStartupDelay)
 	}
 
 	// Test accessing the downstream cluster - get nodes
-	cmd := exec.Command("kubectl", "--kubeconfig", tmpFileModified.Name(), "get", "nodes", "-o", "wide")
-	nodeOutput, err := cmd.Output()
+	client, err := NewKubeClient(tmpFileModified.Name())
+	if err != nil {
+		return err
+	}
+	nodes, err := client.ListNodes()
 	if err != nil {
 		return fmt.Errorf("failed to access downstream cluster nodes: %w", err)
 	}
 
-	if len(nodeOutput) == 0 {
+	if len(nodes) == 0 {
 		return fmt.Errorf("no nodes found in downstream cluster")
 	}
 
 	// Test accessing the downstream cluster - get all pods
-	cmd = exec.Command("kubectl", "--kubeconfig", tmpFileModified.Name(), "get", "pods", "-A", "-o", "wide")
-	podOutput, err := cmd.Output()
+	pods, err := ListDownstreamPods(tmpFileModified.Name())
 	if err != nil {
 		return fmt.Errorf("failed to get pods from downstream cluster: %w", err)
 	}
@@ -316,8 +288,14 @@ func TestDownstreamClusterAccess(kubeconfigContent string) error {
 	// Display the complete downstream cluster information
 	fmt.Printf("\n✅ DOWNSTREAM K3S CLUSTER ACCESS SUCCESSFUL!\n")
 	fmt.Printf("==========================================\n")
-	fmt.Printf("NODES:\n%s\n", string(nodeOutput))
-	fmt.Printf("PODS (ALL NAMESPACES):\n%s\n", string(podOutput))
+	fmt.Printf("NODES:\n")
+	for _, node := range nodes {
+		fmt.Printf("  %s ready=%t %s\n", node.Name, NodeReady(&node), node.Status.NodeInfo.KubeletVersion)
+	}
+	fmt.Printf("PODS (ALL NAMESPACES):\n")
+	for _, pod := range pods {
+		fmt.Printf("  %s/%s %s\n", pod.Namespace, pod.Name, pod.Phase)
+	}
 	fmt.Printf("==========================================\n")
 
 	return nil
@@ -326,6 +304,28 @@ func TestDownstreamClusterAccess(kubeconfigContent string) error {
 // isPortForwardRunning checks if a port-forward is already running on the specified port
 func isPortForwardRunning(port int) bool {
 	cmd := exec.Command("lsof", "-i", fmt.Sprintf(":%d", port))
-	err := cmd.Run()
+	err := RunCommand(cmd)
 	return err == nil
 }
+
+// ClusterTemplatesStatus lists the templates of a namespace and returns the HTTP status of the response.
+// The request carries no token when authContext is nil.
+func ClusterTemplatesStatus(authContext *auth.TestAuthContext, namespace string) (int, error) {
+	req, err := http.NewRequest("GET", ClusterTemplateURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	SetNamespaceHeader(req, namespace)
+	req.Header.Set("Accept", "application/json")
+
+	client := NewHTTPClient()
+	if authContext != nil {
+		client = AuthenticatedHTTPClient(authContext)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
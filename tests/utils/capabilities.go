@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+// Capability is an environment feature that some specs depend on.
+type Capability string
+
+const (
+	CapabilityGPU Capability = "gpu"
+	CapabilityTPM Capability = "tpm"
+	CapabilitySGX Capability = "sgx"
+	CapabilityVEN Capability = "ven"
+	// CapabilityMultiNode is available when MultiNodeGUIDsEnvVar lists MultiNodeClusterSize edge nodes.
+	CapabilityMultiNode Capability = "multi-node"
+
+	capabilityLabelPrefix      = "requires:"
+	missingCapabilityPrefix    = "missing capabilities: "
+	missingCapabilitySeparator = "; "
+)
+
+type capabilityResult struct {
+	available bool
+	reason    string
+}
+
+var (
+	capabilityCache   = map[Capability]capabilityResult{}
+	capabilityCacheMu sync.Mutex
+)
+
+// HasCapability detects whether the environment provides a capability. Results are cached
+// for the lifetime of the test process; the reason explains a missing capability.
+func HasCapability(c Capability) (bool, string) {
+	capabilityCacheMu.Lock()
+	defer capabilityCacheMu.Unlock()
+
+	if r, ok := capabilityCache[c]; ok {
+		return r.available, r.reason
+	}
+	r := detectCapability(c)
+	capabilityCache[c] = r
+	return r.available, r.reason
+}
+
+func detectCapability(c Capability) capabilityResult {
+	if c == CapabilityVEN {
+		if GetEdgeNodeProvider() != EdgeNodeProviderVEN {
+			return capabilityResult{reason: EdgeNodeProviderEnvVar + " is not " + EdgeNodeProviderVEN}
+		}
+		for _, k := range []string{VENSSHHostEnvVar, VENSSHKeyEnvVar} {
+			if strings.TrimSpace(os.Getenv(k)) == "" {
+				return capabilityResult{reason: k + " is not set"}
+			}
+		}
+		return capabilityResult{available: true}
+	}
+	if c == CapabilityMultiNode {
+		nodes, err := MultiNodeClusterNodes()
+		if err != nil {
+			return capabilityResult{reason: err.Error()}
+		}
+		if len(nodes) < MultiNodeClusterSize {
+			return capabilityResult{reason: fmt.Sprintf("%s lists %d of %d nodes", MultiNodeGUIDsEnvVar, len(nodes), MultiNodeClusterSize)}
+		}
+		return capabilityResult{available: true}
+	}
+
+	// Everything else is probed on the edge node, which needs a configured vEN.
+	if ok, reason := HasCapability(CapabilityVEN); !ok {
+		return capabilityResult{reason: "edge node unreachable: " + reason}
+	}
+
+	var probe string
+	switch c {
+	case CapabilityTPM:
+		hasTPM, err := EdgeNodeHasTPM()
+		if err != nil {
+			return capabilityResult{reason: err.Error()}
+		}
+		if !hasTPM {
+			return capabilityResult{reason: "no TPM device found on the edge node"}
+		}
+		return capabilityResult{available: true}
+	case CapabilityGPU:
+		probe = "ls /dev/dri/renderD* /dev/nvidia0 2>/dev/null | head -n1"
+	case CapabilitySGX:
+		probe = "ls /dev/sgx_enclave /dev/sgx/enclave 2>/dev/null | head -n1"
+	default:
+		return capabilityResult{reason: fmt.Sprintf("unknown capability %q", c)}
+	}
+
+	out, err := ExecOnEdgeNode(probe)
+	if err != nil {
+		return capabilityResult{reason: err.Error()}
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return capabilityResult{reason: "no device found on the edge node"}
+	}
+	return capabilityResult{available: true}
+}
+
+// RequiresCapabilities is a Ginkgo decorator marking a container or spec as depending on capabilities.
+// Suites must call RegisterCapabilityGating for the requirement to be enforced.
+func RequiresCapabilities(caps ...Capability) ginkgo.Labels {
+	labels := ginkgo.Labels{}
+	for _, c := range caps {
+		labels = append(labels, capabilityLabelPrefix+string(c))
+	}
+	return labels
+}
+
+// requiredCapabilities extracts the capabilities encoded in spec labels.
+func requiredCapabilities(labels []string) []Capability {
+	var caps []Capability
+	for _, l := range labels {
+		if c, ok := strings.CutPrefix(l, capabilityLabelPrefix); ok {
+			caps = append(caps, Capability(c))
+		}
+	}
+	return caps
+}
+
+// RegisterCapabilityGating installs a suite-wide hook that skips specs whose required capabilities
+// are missing, and a report listing every skipped capability at the end of the run.
+// Call it once per suite at the top level: var _ = utils.RegisterCapabilityGating()
+func RegisterCapabilityGating() bool {
+	ginkgo.BeforeEach(func() {
+		var missing []string
+		for _, c := range requiredCapabilities(ginkgo.CurrentSpecReport().Labels()) {
+			if ok, reason := HasCapability(c); !ok {
+				missing = append(missing, fmt.Sprintf("%s (%s)", c, reason))
+			}
+		}
+		if len(missing) > 0 {
+			ginkgo.Skip(missingCapabilityPrefix + strings.Join(missing, missingCapabilitySeparator))
+		}
+	})
+
+	ginkgo.ReportAfterSuite("capability gating summary", func(report ginkgo.Report) {
+		skipped := map[string]int{}
+		for _, spec := range report.SpecReports {
+			if spec.State != types.SpecStateSkipped {
+				continue
+			}
+			if msg, ok := strings.CutPrefix(spec.Failure.Message, missingCapabilityPrefix); ok {
+				for _, entry := range strings.Split(msg, missingCapabilitySeparator) {
+					c, _, _ := strings.Cut(entry, " (")
+					skipped[c]++
+				}
+			}
+		}
+		if len(skipped) == 0 {
+			return
+		}
+
+		caps := make([]string, 0, len(skipped))
+		for c := range skipped {
+			caps = append(caps, c)
+		}
+		sort.Strings(caps)
+
+		fmt.Printf("\n\033[33mSpecs skipped due to missing capabilities (coverage gap):\033[0m\n")
+		for _, c := range caps {
+			fmt.Printf("  - %s: %d spec(s)\n", c, skipped[c])
+		}
+	})
+
+	return true
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRequiredCapabilities(t *testing.T) {
+	labels := append([]string{"cluster-orch-smoke-test"}, RequiresCapabilities(CapabilityGPU, CapabilitySGX)...)
+
+	caps := requiredCapabilities(labels)
+	if want := []Capability{CapabilityGPU, CapabilitySGX}; !reflect.DeepEqual(caps, want) {
+		t.Errorf("Expected %v, got %v", want, caps)
+	}
+
+	if caps := requiredCapabilities([]string{"cluster-orch-smoke-test"}); len(caps) != 0 {
+		t.Errorf("Expected no capabilities, got %v", caps)
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// BackedUpObject identifies an object saved by BackupClusterAPIObjects.
+type BackedUpObject struct {
+	APIVersion string
+	Kind       string
+	Namespace  string
+	Name       string
+}
+
+// resource returns the kubectl resource name of the object, e.g. "clusters.v1beta2.cluster.x-k8s.io".
+func (o BackedUpObject) resource() string {
+	group, version, found := strings.Cut(o.APIVersion, "/")
+	if !found {
+		return strings.ToLower(o.Kind)
+	}
+	return fmt.Sprintf("%s.%s.%s", strings.ToLower(o.Kind), version, group)
+}
+
+func (o BackedUpObject) String() string {
+	return fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name)
+}
+
+// BackupClusterAPIObjects writes the CAPI objects of a namespace and their dependencies to dir with
+// clusterctl move --to-directory. The objects are left in place.
+func BackupClusterAPIObjects(namespace, dir string) error {
+	cmd := exec.Command("clusterctl", "move", "--namespace", namespace, "--to-directory", dir)
+	if out, err := CommandCombinedOutput(cmd); err != nil {
+		return fmt.Errorf("failed to back up namespace %s: %w: %s", namespace, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// RestoreClusterAPIObjects recreates the objects saved in dir with clusterctl move --from-directory on the
+// management cluster of kubeconfig ("" for the current one).
+func RestoreClusterAPIObjects(namespace, dir, kubeconfig string) error {
+	args := []string{"move", "--namespace", namespace, "--from-directory", dir}
+	if kubeconfig != "" {
+		args = append(args, "--to-kubeconfig", kubeconfig)
+	}
+	if out, err := CommandCombinedOutput(exec.Command("clusterctl", args...)); err != nil {
+		return fmt.Errorf("failed to restore namespace %s: %w: %s", namespace, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ListBackedUpObjects returns the objects saved in a backup directory, sorted by kind and name.
+func ListBackedUpObjects(dir string) ([]BackedUpObject, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []BackedUpObject
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		var obj struct {
+			APIVersion string `yaml:"apiVersion"`
+			Kind       string `yaml:"kind"`
+			Metadata   struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(data, &obj); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if obj.Kind == "" || obj.Metadata.Name == "" {
+			continue
+		}
+		objects = append(objects, BackedUpObject{
+			APIVersion: obj.APIVersion,
+			Kind:       obj.Kind,
+			Namespace:  obj.Metadata.Namespace,
+			Name:       obj.Metadata.Name,
+		})
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no objects found in %s", dir)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].String() < objects[j].String() })
+	return objects, nil
+}
+
+// SetClusterPaused pauses or resumes the reconciliation of a cluster by all CAPI controllers.
+func SetClusterPaused(namespace, clusterName string, paused bool) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"spec":{"paused":%t}}`, paused)
+	if err := client.PatchCluster(namespace, clusterName, types.MergePatchType, []byte(patch)); err != nil {
+		return fmt.Errorf("failed to set paused=%t on cluster %s: %w", paused, clusterName, err)
+	}
+	return nil
+}
+
+// DeleteObjectsOrphaningInfrastructure deletes the given management-side objects without letting their
+// controllers tear anything down: finalizers are removed from all objects before any of them is deleted,
+// the way clusterctl move cleans up the source cluster.
+func DeleteObjectsOrphaningInfrastructure(objects []BackedUpObject) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		err := client.PatchResource(obj.resource(), obj.Namespace, obj.Name, types.MergePatchType, []byte(`{"metadata":{"finalizers":null}}`))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove finalizers of %s: %w", obj, err)
+		}
+	}
+	for _, obj := range objects {
+		if err := client.DeleteResource(obj.resource(), obj.Namespace, obj.Name); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", obj, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestListBackedUpObjects(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Cluster_demo_demo-cluster.yaml":   "apiVersion: cluster.x-k8s.io/v1beta2\nkind: Cluster\nmetadata:\n  name: demo-cluster\n  namespace: demo\n",
+		"Secret_demo_demo-cluster-ca.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: demo-cluster-ca\n  namespace: demo\n",
+		"IntelMachine_demo_demo-im.yaml":   "apiVersion: infrastructure.cluster.x-k8s.io/v1alpha1\nkind: IntelMachine\nmetadata:\n  name: demo-im\n  namespace: demo\n",
+		"notes.txt":                        "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := ListBackedUpObjects(dir)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(objects) != 3 {
+		t.Fatalf("Expected 3 objects, got %v", objects)
+	}
+
+	expected := []struct {
+		str      string
+		resource string
+	}{
+		{"Cluster demo/demo-cluster", "cluster.v1beta2.cluster.x-k8s.io"},
+		{"IntelMachine demo/demo-im", "intelmachine.v1alpha1.infrastructure.cluster.x-k8s.io"},
+		{"Secret demo/demo-cluster-ca", "secret"},
+	}
+	for i, want := range expected {
+		if objects[i].String() != want.str || objects[i].resource() != want.resource {
+			t.Errorf("Expected %s (%s), got %s (%s)", want.str, want.resource, objects[i], objects[i].resource())
+		}
+	}
+}
+
+func TestListBackedUpObjectsEmpty(t *testing.T) {
+	if _, err := ListBackedUpObjects(t.TempDir()); err == nil {
+		t.Errorf("Expected an error for an empty backup directory")
+	}
+}
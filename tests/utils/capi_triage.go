@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	triageLogWindow = 15 * time.Minute
+	triageLogLines  = 40
+)
+
+// summaryConditions only aggregate the other conditions of an object, so they are never the root cause
+// while a more specific condition is False.
+var summaryConditions = map[string]bool{"Ready": true, "Available": true}
+
+// capiControllers maps the kinds in the provisioning chain to the controller reconciling them.
+var capiControllers = map[string]string{
+	"Cluster":             "capi-system/capi-controller-manager",
+	"Machine":             "capi-system/capi-controller-manager",
+	"KThreesControlPlane": "capk-system/capi-k3s-control-plane-controller-manager",
+	"RKE2ControlPlane":    "capr-system/rke2-control-plane-controller-manager",
+	"IntelMachine":        componentReleaseNamespace + "/intel-infra-provider-manager",
+	"IntelCluster":        componentReleaseNamespace + "/intel-infra-provider-manager",
+}
+
+// TriageFinding is the first False condition found in the provisioning chain.
+type TriageFinding struct {
+	Kind      string
+	Name      string
+	Condition capiCondition
+}
+
+func (f TriageFinding) String() string {
+	s := fmt.Sprintf("%s/%s %s=%s", f.Kind, f.Name, f.Condition.Type, f.Condition.Status)
+	if f.Condition.Reason != "" {
+		s += " (" + f.Condition.Reason + ")"
+	}
+	if f.Condition.Message != "" {
+		s += ": " + f.Condition.Message
+	}
+	return s
+}
+
+// TriageCluster walks Cluster → ControlPlane → Machines → IntelMachines and returns a focused diagnosis
+// of why the cluster is not ready: the state of each object in the chain, the first False condition and
+// the recent logs of the controller owning it. It never fails; problems fetching objects are reported
+// in the diagnosis.
+func TriageCluster(namespace, clusterName string) string {
+	chain, err := capiChain(namespace, clusterName)
+	var b strings.Builder
+	fmt.Fprintf(&b, "CAPI triage for cluster %s/%s:\n", namespace, clusterName)
+	for _, obj := range chain {
+		fmt.Fprintf(&b, "  %s/%s: %s\n", obj.Kind, obj.Metadata.Name, conditionSummary(obj.conditions()))
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "  (chain incomplete: %v)\n", err)
+	}
+
+	finding, ok := firstFalseCondition(chain)
+	if !ok {
+		b.WriteString("No False condition found in the chain.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "First failing condition: %s\n", finding)
+
+	if controller, ok := capiControllers[finding.Kind]; ok {
+		logs, err := controllerLogs(controller, finding.Name)
+		if err != nil {
+			fmt.Fprintf(&b, "Failed to get logs of %s: %v\n", controller, err)
+		} else {
+			fmt.Fprintf(&b, "Recent logs of %s:\n%s", controller, logs)
+		}
+	}
+	return b.String()
+}
+
+// conditions merges the v1beta1 and v1beta2 condition lists, preferring the v1beta2 entry of a type.
+func (o *capiObject) conditions() []capiCondition {
+	seen := map[string]bool{}
+	var merged []capiCondition
+	for _, conditions := range [][]capiCondition{o.Status.V1Beta2.Conditions, o.Status.Conditions} {
+		for _, c := range conditions {
+			if !seen[c.Type] {
+				seen[c.Type] = true
+				merged = append(merged, c)
+			}
+		}
+	}
+	return merged
+}
+
+func conditionSummary(conditions []capiCondition) string {
+	if len(conditions) == 0 {
+		return "no conditions reported"
+	}
+	parts := make([]string, 0, len(conditions))
+	for _, c := range conditions {
+		parts = append(parts, c.Type+"="+c.Status)
+	}
+	return strings.Join(parts, " ")
+}
+
+// firstFalseCondition returns the first False condition in chain order, skipping summary conditions
+// unless an object has nothing more specific to report.
+func firstFalseCondition(chain []capiObject) (TriageFinding, bool) {
+	for _, obj := range chain {
+		var summary *capiCondition
+		for _, c := range obj.conditions() {
+			if c.Status != "False" {
+				continue
+			}
+			if summaryConditions[c.Type] {
+				if summary == nil {
+					summary = &c
+				}
+				continue
+			}
+			return TriageFinding{Kind: obj.Kind, Name: obj.Metadata.Name, Condition: c}, true
+		}
+		if summary != nil {
+			return TriageFinding{Kind: obj.Kind, Name: obj.Metadata.Name, Condition: *summary}, true
+		}
+	}
+	return TriageFinding{}, false
+}
+
+// capiChain fetches the objects of the provisioning chain in order. The objects fetched before an
+// error are returned along with it.
+func capiChain(namespace, clusterName string) ([]capiObject, error) {
+	cluster, err := getCAPIObject(namespace, "clusters.cluster.x-k8s.io", clusterName)
+	if err != nil {
+		return nil, err
+	}
+	chain := []capiObject{*cluster}
+
+	if ref := cluster.Spec.ControlPlaneRef; ref != nil {
+		cp, err := getCAPIObject(namespace, ref.resource(), ref.Name)
+		if err != nil {
+			return chain, err
+		}
+		chain = append(chain, *cp)
+	}
+
+	out, err := managementResourceJSON("machines.cluster.x-k8s.io", namespace, "cluster.x-k8s.io/cluster-name="+clusterName)
+	if err != nil {
+		return chain, fmt.Errorf("failed to list machines: %w", err)
+	}
+	var machines struct {
+		Items []capiObject `json:"items"`
+	}
+	if err := json.Unmarshal(out, &machines); err != nil {
+		return chain, fmt.Errorf("failed to parse machines: %w", err)
+	}
+	chain = append(chain, machines.Items...)
+	for _, machine := range machines.Items {
+		ref := machine.Spec.InfrastructureRef
+		if ref == nil {
+			continue
+		}
+		infra, err := getCAPIObject(namespace, ref.resource(), ref.Name)
+		if err != nil {
+			return chain, err
+		}
+		chain = append(chain, *infra)
+	}
+	return chain, nil
+}
+
+// resource returns the kubectl resource name of the reference, qualified by its API group.
+func (r capiObjectRef) resource() string {
+	group := r.APIGroup
+	if group == "" {
+		group, _, _ = strings.Cut(r.APIVersion, "/")
+	}
+	if group == "" {
+		return strings.ToLower(r.Kind)
+	}
+	return strings.ToLower(r.Kind) + "." + group
+}
+
+// controllerLogs returns the recent log lines of a controller ("namespace/deployment") mentioning the
+// object, or its last lines when none does.
+func controllerLogs(controller, objectName string) (string, error) {
+	namespace, deployment, _ := strings.Cut(controller, "/")
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", err
+	}
+	out, err := client.DeploymentLogsSince(namespace, deployment, time.Now().Add(-triageLogWindow))
+	if err != nil {
+		return "", err
+	}
+	return relevantLogLines(string(out), objectName, triageLogLines), nil
+}
+
+func relevantLogLines(logs, objectName string, limit int) string {
+	lines := strings.Split(strings.TrimRight(logs, "\n"), "\n")
+	var matching []string
+	for _, line := range lines {
+		if strings.Contains(line, objectName) {
+			matching = append(matching, line)
+		}
+	}
+	if len(matching) == 0 {
+		matching = lines
+	}
+	if len(matching) > limit {
+		matching = matching[len(matching)-limit:]
+	}
+	var b strings.Builder
+	for _, line := range matching {
+		b.WriteString("    " + line + "\n")
+	}
+	return b.String()
+}
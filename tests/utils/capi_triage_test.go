@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFirstFalseCondition(t *testing.T) {
+	chain := []capiObject{}
+	for _, raw := range []string{
+		`{"kind": "Cluster", "metadata": {"name": "demo"}, "status": {"conditions": [
+			{"type": "Ready", "status": "False", "reason": "WaitingForControlPlane"}]}}`,
+		`{"kind": "KThreesControlPlane", "metadata": {"name": "demo-cp"}, "status": {"conditions": [
+			{"type": "Ready", "status": "False"},
+			{"type": "Available", "status": "False"},
+			{"type": "MachinesReady", "status": "False", "reason": "Provisioning", "message": "waiting for machine"}]}}`,
+		`{"kind": "IntelMachine", "metadata": {"name": "demo-im"}, "status": {"conditions": [
+			{"type": "HostProvisioned", "status": "False"}]}}`,
+	} {
+		obj, err := parseCAPIObject([]byte(raw))
+		if err != nil {
+			t.Fatalf("Failed to parse %s: %v", raw, err)
+		}
+		chain = append(chain, *obj)
+	}
+
+	finding, ok := firstFalseCondition(chain)
+	if !ok {
+		t.Fatalf("Expected a finding")
+	}
+	// The Cluster only reports a summary condition, so it is its own first failing condition.
+	if finding.Kind != "Cluster" || finding.Condition.Type != "Ready" {
+		t.Errorf("Expected Cluster Ready, got %s", finding)
+	}
+
+	finding, _ = firstFalseCondition(chain[1:])
+	if got, want := finding.String(), "KThreesControlPlane/demo-cp MachinesReady=False (Provisioning): waiting for machine"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if _, ok := firstFalseCondition(nil); ok {
+		t.Errorf("Expected no finding for an empty chain")
+	}
+}
+
+func TestCAPIObjectConditionsPreferV1Beta2(t *testing.T) {
+	obj, err := parseCAPIObject([]byte(`{"kind": "Machine", "status": {
+		"conditions": [{"type": "Ready", "status": "False"}, {"type": "BootstrapReady", "status": "True"}],
+		"v1beta2": {"conditions": [{"type": "Ready", "status": "True"}]}}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if got := conditionSummary(obj.conditions()); got != "Ready=True BootstrapReady=True" {
+		t.Errorf("Unexpected conditions %q", got)
+	}
+}
+
+func TestCAPIObjectRefResource(t *testing.T) {
+	tests := []struct {
+		ref      capiObjectRef
+		expected string
+	}{
+		{capiObjectRef{APIVersion: "controlplane.cluster.x-k8s.io/v1beta2", Kind: "KThreesControlPlane"}, "kthreescontrolplane.controlplane.cluster.x-k8s.io"},
+		{capiObjectRef{APIGroup: "infrastructure.cluster.x-k8s.io", Kind: "IntelMachine"}, "intelmachine.infrastructure.cluster.x-k8s.io"},
+		{capiObjectRef{Kind: "IntelMachine"}, "intelmachine"},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.resource(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestRelevantLogLines(t *testing.T) {
+	logs := "a demo-im 1\nother\na demo-im 2\na demo-im 3\n"
+	if got := relevantLogLines(logs, "demo-im", 2); got != "    a demo-im 2\n    a demo-im 3\n" {
+		t.Errorf("Unexpected lines %q", got)
+	}
+	if got := relevantLogLines("x\ny\n", "missing", 1); !strings.Contains(got, "y") || strings.Contains(got, "x") {
+		t.Errorf("Expected the last line when nothing matches, got %q", got)
+	}
+}
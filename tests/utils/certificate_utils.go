@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DownstreamCertValidityEnvVar overrides the expected lifetime of the downstream API server certificate.
+	DownstreamCertValidityEnvVar = "DOWNSTREAM_CERT_VALIDITY"
+	// DefaultDownstreamCertValidity is the lifetime k3s and rke2 give their serving certificates.
+	DefaultDownstreamCertValidity = 365 * 24 * time.Hour
+
+	downstreamCertValidityTolerance = 24 * time.Hour
+	downstreamCertMinRemaining      = 30 * 24 * time.Hour
+)
+
+// DefaultDownstreamCertSANs are the in-cluster names every downstream API server certificate must carry.
+var DefaultDownstreamCertSANs = []string{
+	"kubernetes",
+	"kubernetes.default",
+	"kubernetes.default.svc",
+	"kubernetes.default.svc.cluster.local",
+	"localhost",
+}
+
+// servingCertSecrets are the kube-system secrets in which k3s and rke2 persist their API server certificate.
+var servingCertSecrets = []string{"k3s-serving", "rke2-serving"}
+
+// CertificateExpectations describe what a downstream API server certificate must satisfy.
+type CertificateExpectations struct {
+	SANs []string
+	// Validity is the expected NotAfter-NotBefore lifetime, matched within Tolerance.
+	Validity  time.Duration
+	Tolerance time.Duration
+	// MinRemaining is the least validity the certificate must have left.
+	MinRemaining time.Duration
+}
+
+// DownstreamCertificateExpectations returns the expectations for the test cluster, including the
+// extra SANs requested through CLUSTER_ADDITIONAL_SANS.
+func DownstreamCertificateExpectations() (CertificateExpectations, error) {
+	exp := CertificateExpectations{
+		SANs:         append([]string{}, DefaultDownstreamCertSANs...),
+		Validity:     DefaultDownstreamCertValidity,
+		Tolerance:    downstreamCertValidityTolerance,
+		MinRemaining: downstreamCertMinRemaining,
+	}
+
+	if val := strings.TrimSpace(os.Getenv(DownstreamCertValidityEnvVar)); val != "" {
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return exp, fmt.Errorf("invalid %s %q: %w", DownstreamCertValidityEnvVar, val, err)
+		}
+		exp.Validity = d
+	}
+
+	opts, err := ClusterConfigOptionsFromEnv()
+	if err != nil {
+		return exp, err
+	}
+	exp.SANs = append(exp.SANs, opts.AdditionalSANs...)
+	return exp, nil
+}
+
+// GetDownstreamServingCertificate reads the API server certificate of a downstream cluster through
+// its (gateway) kubeconfig. The TLS handshake happens between the gateway and the agent, so the
+// certificate is read from the secret k3s/rke2 keep it in.
+func GetDownstreamServingCertificate(kubeconfigPath string) (*x509.Certificate, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	var errs []string
+	for _, secret := range servingCertSecrets {
+		object, err := client.GetSecret("kube-system", secret)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return parsePEMCertificate(object.Data[corev1.TLSCertKey])
+	}
+	return nil, fmt.Errorf("no serving certificate secret found: %s", strings.Join(errs, "; "))
+}
+
+func parseBase64Certificate(data string) (*x509.Certificate, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	return parsePEMCertificate(raw)
+}
+
+func parsePEMCertificate(raw []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// CertificateProblems lists how a certificate deviates from the expectations; nil means it conforms.
+func CertificateProblems(cert *x509.Certificate, exp CertificateExpectations, now time.Time) []string {
+	var problems []string
+
+	present := map[string]bool{}
+	for _, name := range cert.DNSNames {
+		present[name] = true
+	}
+	for _, ip := range cert.IPAddresses {
+		present[ip.String()] = true
+	}
+	for _, san := range exp.SANs {
+		if !present[san] {
+			problems = append(problems, fmt.Sprintf("missing SAN %q", san))
+		}
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	if diff := lifetime - exp.Validity; diff > exp.Tolerance || diff < -exp.Tolerance {
+		problems = append(problems, fmt.Sprintf("validity period %v does not match the expected %v", lifetime, exp.Validity))
+	}
+	if now.Before(cert.NotBefore) {
+		problems = append(problems, fmt.Sprintf("certificate is not valid before %v", cert.NotBefore))
+	}
+	if remaining := cert.NotAfter.Sub(now); remaining < exp.MinRemaining {
+		problems = append(problems, fmt.Sprintf("certificate expires in %v, less than %v", remaining.Round(time.Hour), exp.MinRemaining))
+	}
+	return problems
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+func newTestCertificate(t *testing.T, dnsNames []string, notBefore time.Time, validity time.Duration) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "k3s"},
+		DNSNames:     dnsNames,
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(validity),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return cert
+}
+
+func TestCertificateProblems(t *testing.T) {
+	now := time.Now()
+	exp := CertificateExpectations{
+		SANs:         append(append([]string{}, DefaultDownstreamCertSANs...), "127.0.0.1"),
+		Validity:     DefaultDownstreamCertValidity,
+		Tolerance:    downstreamCertValidityTolerance,
+		MinRemaining: downstreamCertMinRemaining,
+	}
+
+	good := newTestCertificate(t, DefaultDownstreamCertSANs, now.Add(-time.Hour), DefaultDownstreamCertValidity)
+	if problems := CertificateProblems(good, exp, now); len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+
+	testCases := []struct {
+		name string
+		cert *x509.Certificate
+	}{
+		{"missing SAN", newTestCertificate(t, []string{"kubernetes", "localhost"}, now.Add(-time.Hour), DefaultDownstreamCertValidity)},
+		{"short lifetime", newTestCertificate(t, DefaultDownstreamCertSANs, now.Add(-time.Hour), 90*24*time.Hour)},
+		{"about to expire", newTestCertificate(t, DefaultDownstreamCertSANs, now.Add(-DefaultDownstreamCertValidity+24*time.Hour), DefaultDownstreamCertValidity)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if problems := CertificateProblems(tc.cert, exp, now); len(problems) == 0 {
+				t.Error("Expected problems to be reported")
+			}
+		})
+	}
+}
+
+func TestParseBase64Certificate(t *testing.T) {
+	cert := newTestCertificate(t, DefaultDownstreamCertSANs, time.Now(), time.Hour)
+	encoded := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+	parsed, err := parseBase64Certificate(encoded + "\n")
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	if parsed.Subject.CommonName != "k3s" {
+		t.Errorf("Expected CN k3s, got %q", parsed.Subject.CommonName)
+	}
+
+	if _, err := parseBase64Certificate("bm90IGEgY2VydA=="); err == nil {
+		t.Error("Expected an error for non-PEM data")
+	}
+}
@@ -0,0 +1,267 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// readinessConditions are the condition types telling whether a CAPI object is ready, in order of
+// preference: Ready in v1beta1 and for most v1beta2 objects, Available for v1beta2 Clusters and control planes.
+var readinessConditions = []string{"Ready", "Available"}
+
+// ComponentStatus is the readiness of a CAPI object of a cluster and of the objects below it: the
+// Cluster holds its infrastructure cluster, control plane and Machines, a Machine its infrastructure machine.
+type ComponentStatus struct {
+	Kind  string
+	Name  string
+	Ready bool
+	// Reason is the reason and message of the readiness condition, if any.
+	Reason string
+	// Age is how long the component has been in its state: since the last transition of its readiness
+	// condition, or since its creation when it reports none.
+	Age      time.Duration
+	Children []*ComponentStatus
+}
+
+// Component returns the Kind/Name of the component.
+func (s *ComponentStatus) Component() string {
+	return s.Kind + "/" + s.Name
+}
+
+// AllReady reports whether the component and every component below it are ready.
+func (s *ComponentStatus) AllReady() bool {
+	return s.Blocking() == nil
+}
+
+// Blocking returns the component that keeps the cluster from being ready: the first one not ready whose
+// own components are all ready, which is the root cause rather than a component summarizing it. It
+// returns nil when everything is ready.
+func (s *ComponentStatus) Blocking() *ComponentStatus {
+	for _, child := range s.Children {
+		if blocking := child.Blocking(); blocking != nil {
+			return blocking
+		}
+	}
+	if !s.Ready {
+		return s
+	}
+	return nil
+}
+
+// String renders the tree, one component per line.
+func (s *ComponentStatus) String() string {
+	var b strings.Builder
+	s.write(&b, "")
+	return b.String()
+}
+
+func (s *ComponentStatus) write(b *strings.Builder, indent string) {
+	state := "Ready"
+	if !s.Ready {
+		state = "NotReady"
+	}
+	fmt.Fprintf(b, "%s%s %s %v", indent, s.Component(), state, s.Age.Round(time.Second))
+	if s.Reason != "" {
+		fmt.Fprintf(b, " %s", s.Reason)
+	}
+	b.WriteString("\n")
+	for _, child := range s.Children {
+		child.write(b, indent+"  ")
+	}
+}
+
+// GetClusterComponents returns the component tree of a cluster from the management cluster of kubeconfig
+// ("" for the current one).
+func GetClusterComponents(kubeconfig, namespace, clusterName string) (*ComponentStatus, error) {
+	client, err := managementKubeClientFor(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return clusterComponents(client, namespace, clusterName)
+}
+
+func clusterComponents(client *KubeClient, namespace, clusterName string) (*ComponentStatus, error) {
+	now := time.Now()
+	cluster, err := getCAPICluster(client, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	// A Cluster reporting no readiness condition yet is not ready, unlike the objects below it.
+	root := newComponentStatus(cluster, true, now)
+
+	for _, ref := range []*capiObjectRef{cluster.Spec.InfrastructureRef, cluster.Spec.ControlPlaneRef} {
+		if ref == nil {
+			continue
+		}
+		obj, err := getReferencedCAPIObject(client, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		root.Children = append(root.Children, newComponentStatus(obj, false, now))
+	}
+
+	machines, err := listCAPIObjects(client.ListMachines(namespace, clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the machines of %s/%s: %w", namespace, clusterName, err)
+	}
+	for i := range machines {
+		machine := newComponentStatus(&machines[i], false, now)
+		if ref := machines[i].Spec.InfrastructureRef; ref != nil {
+			obj, err := getReferencedCAPIObject(client, namespace, ref)
+			if err != nil {
+				return nil, err
+			}
+			machine.Children = append(machine.Children, newComponentStatus(obj, false, now))
+		}
+		root.Children = append(root.Children, machine)
+	}
+	return root, nil
+}
+
+// ClusterComponentsReady returns nil when every component of a cluster is ready, and otherwise an error
+// naming the component that blocks it and for how long, followed by the component tree.
+func ClusterComponentsReady(kubeconfig, namespace, clusterName string) error {
+	status, err := GetClusterComponents(kubeconfig, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+	return status.Err()
+}
+
+// RemainingClusterObjects returns the Kind/Name of the Cluster object, Machines and IntelMachines of a
+// cluster that still exist, none once its deletion is complete.
+func RemainingClusterObjects(namespace, clusterName string) ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return remainingClusterObjects(client, namespace, clusterName)
+}
+
+func remainingClusterObjects(client *KubeClient, namespace, clusterName string) ([]string, error) {
+	var remaining []string
+	if _, err := client.GetCluster(namespace, clusterName); err == nil {
+		remaining = append(remaining, "Cluster/"+clusterName)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	for _, list := range []func(string, string) ([]unstructured.Unstructured, error){client.ListMachines, client.ListIntelMachines} {
+		objects, err := list(namespace, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			remaining = append(remaining, object.GetKind()+"/"+object.GetName())
+		}
+	}
+	return remaining, nil
+}
+
+// Err returns nil when every component is ready, and otherwise an error naming the blocking component.
+func (s *ComponentStatus) Err() error {
+	blocking := s.Blocking()
+	if blocking == nil {
+		return nil
+	}
+	message := fmt.Sprintf("%s not ready for %v", blocking.Component(), blocking.Age.Round(time.Second))
+	if blocking.Reason != "" {
+		message += ": " + blocking.Reason
+	}
+	return fmt.Errorf("%s\n%s", message, strings.TrimRight(s.String(), "\n"))
+}
+
+// newComponentStatus reads the readiness of a CAPI object. Without a readiness condition the object is
+// only considered not ready when required is set.
+func newComponentStatus(obj *capiObject, required bool, now time.Time) *ComponentStatus {
+	status := &ComponentStatus{Kind: obj.Kind, Name: obj.Metadata.Name, Ready: !required}
+	since := obj.Metadata.CreationTimestamp
+	if condition, ok := readinessCondition(obj.conditions()); ok {
+		status.Ready = condition.Status == "True"
+		status.Reason = conditionReason(condition)
+		if !condition.LastTransitionTime.IsZero() {
+			since = condition.LastTransitionTime
+		}
+	} else if required {
+		status.Reason = "no readiness condition reported"
+	}
+	if !since.IsZero() && now.After(since) {
+		status.Age = now.Sub(since)
+	}
+	return status
+}
+
+func readinessCondition(conditions []capiCondition) (capiCondition, bool) {
+	for _, conditionType := range readinessConditions {
+		for _, c := range conditions {
+			if c.Type == conditionType {
+				return c, true
+			}
+		}
+	}
+	return capiCondition{}, false
+}
+
+func conditionReason(c capiCondition) string {
+	message := strings.Join(strings.Fields(c.Message), " ")
+	switch {
+	case c.Reason != "" && message != "":
+		return c.Reason + ": " + message
+	case c.Reason != "":
+		return c.Reason
+	default:
+		return message
+	}
+}
+
+// getCAPICluster gets the CAPI Cluster object of a cluster.
+func getCAPICluster(client *KubeClient, namespace, clusterName string) (*capiObject, error) {
+	object, err := client.GetCluster(namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return toCAPIObject(object)
+}
+
+// getReferencedCAPIObject gets the object a CAPI object references.
+func getReferencedCAPIObject(client *KubeClient, namespace string, ref *capiObjectRef) (*capiObject, error) {
+	apiVersion := ref.APIVersion
+	if apiVersion == "" {
+		apiVersion = ref.APIGroup
+	}
+	object, err := client.GetObject(apiVersion, ref.Kind, namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toCAPIObject(object)
+}
+
+// listCAPIObjects decodes the result of a list of a KubeClient.
+func listCAPIObjects(objects []unstructured.Unstructured, err error) ([]capiObject, error) {
+	if err != nil {
+		return nil, err
+	}
+	decoded := make([]capiObject, 0, len(objects))
+	for i := range objects {
+		obj, err := toCAPIObject(&objects[i])
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, *obj)
+	}
+	return decoded, nil
+}
+
+func toCAPIObject(object *unstructured.Unstructured) (*capiObject, error) {
+	var obj capiObject
+	if err := fromUnstructured(object.Object, &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode %s %s: %w", object.GetKind(), object.GetName(), err)
+	}
+	return &obj, nil
+}
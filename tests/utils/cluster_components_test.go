@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func componentStatusOf(t *testing.T, raw string, required bool, now time.Time) *ComponentStatus {
+	t.Helper()
+	obj, err := parseCAPIObject([]byte(raw))
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", raw, err)
+	}
+	return newComponentStatus(obj, required, now)
+}
+
+func TestComponentStatusBlocking(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	cluster := componentStatusOf(t, `{"kind": "Cluster", "metadata": {"name": "demo"}, "status": {"conditions": [
+		{"type": "Ready", "status": "False", "reason": "WaitingForControlPlane", "lastTransitionTime": "2026-05-04T09:50:00Z"}]}}`, true, now)
+	controlPlane := componentStatusOf(t, `{"kind": "KThreesControlPlane", "metadata": {"name": "demo-cp"}, "status": {"conditions": [
+		{"type": "Ready", "status": "True", "lastTransitionTime": "2026-05-04T09:55:00Z"}]}}`, false, now)
+	machine := componentStatusOf(t, `{"kind": "Machine", "metadata": {"name": "demo-m"}, "status": {"conditions": [
+		{"type": "Ready", "status": "False", "reason": "WaitingForInfrastructure"}]}}`, false, now)
+	intelMachine := componentStatusOf(t, `{"kind": "IntelMachine", "metadata": {"name": "demo-im"}, "status": {"conditions": [
+		{"type": "Ready", "status": "False", "reason": "HostNotProvisioned", "message": "waiting for\n the node",
+		 "lastTransitionTime": "2026-05-04T09:57:00Z"}]}}`, false, now)
+	machine.Children = []*ComponentStatus{intelMachine}
+	cluster.Children = []*ComponentStatus{controlPlane, machine}
+
+	if cluster.AllReady() {
+		t.Fatal("Expected the cluster not to be ready")
+	}
+	if blocking := cluster.Blocking(); blocking != intelMachine {
+		t.Errorf("Expected the IntelMachine to block, got %v", blocking)
+	}
+	err := cluster.Err()
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if got, want := strings.Split(err.Error(), "\n")[0], "IntelMachine/demo-im not ready for 3m0s: HostNotProvisioned: waiting for the node"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if !strings.Contains(err.Error(), "  KThreesControlPlane/demo-cp Ready 5m0s") {
+		t.Errorf("Expected the tree in the error, got:\n%s", err)
+	}
+
+	intelMachine.Ready, machine.Ready, cluster.Ready = true, true, true
+	if !cluster.AllReady() || cluster.Err() != nil {
+		t.Errorf("Expected the cluster to be ready, got %v", cluster.Err())
+	}
+}
+
+func TestComponentStatusWithoutReadinessCondition(t *testing.T) {
+	now := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	raw := `{"kind": "Cluster", "metadata": {"name": "demo", "creationTimestamp": "2026-05-04T09:59:00Z"}, "status": {}}`
+	if cluster := componentStatusOf(t, raw, true, now); cluster.Ready || cluster.Age != time.Minute {
+		t.Errorf("Expected a Cluster without conditions to be not ready for 1m, got %+v", cluster)
+	}
+	if obj := componentStatusOf(t, `{"kind": "IntelCluster", "metadata": {"name": "demo"}}`, false, now); !obj.Ready {
+		t.Error("Expected an object below the Cluster without conditions to be ready")
+	}
+
+	// v1beta2 Clusters report Available rather than Ready.
+	raw = `{"kind": "Cluster", "metadata": {"name": "demo"}, "status": {"v1beta2": {"conditions": [
+		{"type": "Available", "status": "True"}]}}}`
+	if cluster := componentStatusOf(t, raw, true, now); !cluster.Ready {
+		t.Error("Expected an Available Cluster to be ready")
+	}
+}
+
+func TestClusterComponentsFromKubeClient(t *testing.T) {
+	intelClusterGVR := schema.GroupVersionResource{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Resource: "intelclusters"}
+	ready := map[string]interface{}{"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}}}
+	cluster := testObject(CAPIClusterGVR, "Cluster", "ns", "demo", map[string]interface{}{
+		"spec": map[string]interface{}{"infrastructureRef": map[string]interface{}{
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha1", "kind": "IntelCluster", "name": "demo"}},
+		"status": ready,
+	})
+	machine := testObject(MachineGVR, "Machine", "ns", "demo-0", map[string]interface{}{
+		"spec": map[string]interface{}{"infrastructureRef": map[string]interface{}{
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1alpha1", "kind": "IntelMachine", "name": "demo-0"}},
+		"status": ready,
+	})
+	intelMachine := testObject(IntelMachineGVR, "IntelMachine", "ns", "demo-0", map[string]interface{}{
+		"status": map[string]interface{}{"conditions": []interface{}{
+			map[string]interface{}{"type": "Ready", "status": "False", "reason": "HostNotProvisioned"}}},
+	})
+	machine.SetLabels(map[string]string{"cluster.x-k8s.io/cluster-name": "demo"})
+	intelMachine.SetLabels(map[string]string{"cluster.x-k8s.io/cluster-name": "demo"})
+	client := testKubeClient(nil, cluster, machine, intelMachine,
+		testObject(intelClusterGVR, "IntelCluster", "ns", "demo", map[string]interface{}{"status": ready}))
+
+	status, err := clusterComponents(client, "ns", "demo")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if blocking := status.Blocking(); blocking == nil || blocking.Kind != "IntelMachine" {
+		t.Errorf("Expected the IntelMachine to block, got %+v", blocking)
+	}
+
+	remaining, err := remainingClusterObjects(client, "ns", "demo")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := strings.Join(remaining, ","); got != "Cluster/demo,Machine/demo-0,IntelMachine/demo-0" {
+		t.Errorf("Expected the Cluster, Machine and IntelMachine to remain, got %s", got)
+	}
+	if remaining, err := remainingClusterObjects(testKubeClient(nil), "ns", "demo"); err != nil || len(remaining) != 0 {
+		t.Errorf("Expected nothing to remain of a deleted cluster, got %v, %v", remaining, err)
+	}
+}
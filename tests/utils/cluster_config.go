@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ClusterLabelsEnvVar injects extra cluster labels, formatted as "key1=value1,key2=value2".
+	ClusterLabelsEnvVar = "CLUSTER_LABELS"
+	// ClusterAdditionalSANsEnvVar injects extra API server certificate SANs, comma-separated.
+	ClusterAdditionalSANsEnvVar = "CLUSTER_ADDITIONAL_SANS"
+	// ClusterPodCIDRsEnvVar overrides the template pod CIDR blocks, comma-separated.
+	ClusterPodCIDRsEnvVar = "CLUSTER_POD_CIDRS"
+	// ClusterServiceCIDRsEnvVar overrides the template service CIDR blocks, comma-separated.
+	ClusterServiceCIDRsEnvVar = "CLUSTER_SERVICE_CIDRS"
+
+	kthreesControlPlaneResource = "kthreescontrolplanes.controlplane.cluster.x-k8s.io"
+
+	controlPlaneCreationTimeout  = 2 * time.Minute
+	controlPlaneCreationInterval = 5 * time.Second
+)
+
+// DefaultClusterLabels are the labels every test cluster carries unless overridden.
+var DefaultClusterLabels = map[string]string{
+	"users-label": "user-value",
+}
+
+// configTemplateFuncs are the helpers available to the configuration templates under configs/.
+var configTemplateFuncs = template.FuncMap{
+	"toJSON": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ClusterConfigOptions customizes a cluster created from ClusterConfigTemplatePath.
+// Zero-valued fields keep the defaults of the configuration and the cluster template.
+type ClusterConfigOptions struct {
+	// Labels are merged on top of DefaultClusterLabels.
+	Labels map[string]string
+	// AdditionalSANs are added to the k3s API server certificate.
+	AdditionalSANs []string
+	// PodCIDRs replace the pod CIDR blocks inherited from the cluster template.
+	PodCIDRs []string
+	// ServiceCIDRs replace the service CIDR blocks inherited from the cluster template.
+	ServiceCIDRs []string
+}
+
+// ClusterConfigOptionsFromEnv builds ClusterConfigOptions from the CLUSTER_* environment variables.
+func ClusterConfigOptionsFromEnv() (ClusterConfigOptions, error) {
+	labels, err := parseLabels(os.Getenv(ClusterLabelsEnvVar))
+	if err != nil {
+		return ClusterConfigOptions{}, fmt.Errorf("invalid %s: %w", ClusterLabelsEnvVar, err)
+	}
+
+	return ClusterConfigOptions{
+		Labels:         labels,
+		AdditionalSANs: splitList(os.Getenv(ClusterAdditionalSANsEnvVar)),
+		PodCIDRs:       splitList(os.Getenv(ClusterPodCIDRsEnvVar)),
+		ServiceCIDRs:   splitList(os.Getenv(ClusterServiceCIDRsEnvVar)),
+	}, nil
+}
+
+// mergeOver returns o with every non-empty field of override applied on top of it.
+func (o ClusterConfigOptions) mergeOver(override ClusterConfigOptions) ClusterConfigOptions {
+	merged := o
+	if len(override.Labels) > 0 {
+		merged.Labels = map[string]string{}
+		for k, v := range o.Labels {
+			merged.Labels[k] = v
+		}
+		for k, v := range override.Labels {
+			merged.Labels[k] = v
+		}
+	}
+	if len(override.AdditionalSANs) > 0 {
+		merged.AdditionalSANs = override.AdditionalSANs
+	}
+	if len(override.PodCIDRs) > 0 {
+		merged.PodCIDRs = override.PodCIDRs
+	}
+	if len(override.ServiceCIDRs) > 0 {
+		merged.ServiceCIDRs = override.ServiceCIDRs
+	}
+	return merged
+}
+
+// resolveClusterConfigOptions layers explicit options on top of the environment.
+func resolveClusterConfigOptions(opts ClusterConfigOptions) (ClusterConfigOptions, error) {
+	envOpts, err := ClusterConfigOptionsFromEnv()
+	if err != nil {
+		return ClusterConfigOptions{}, err
+	}
+	return envOpts.mergeOver(opts), nil
+}
+
+// RenderClusterConfig renders ClusterConfigTemplatePath into a cluster-manager create request body. An empty
+// templateName leaves the template out, so cluster-manager uses the default template of the project.
+func RenderClusterConfig(clusterName, nodeGUID, templateName string, opts ClusterConfigOptions) ([]byte, error) {
+	return RenderMultiNodeClusterConfig(clusterName, AllRoleNodes(nodeGUID), templateName, opts)
+}
+
+// RenderMultiNodeClusterConfig renders a create request body with the given nodes, see ValidateClusterNodes.
+func RenderMultiNodeClusterConfig(clusterName string, nodes []api.NodeSpec, templateName string, opts ClusterConfigOptions) ([]byte, error) {
+	if err := ValidateClusterNodes(nodes); err != nil {
+		return nil, err
+	}
+
+	templateData, err := os.ReadFile(ClusterConfigTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("clusterConfig").Funcs(configTemplateFuncs).Parse(string(templateData))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	for k, v := range DefaultClusterLabels {
+		labels[k] = v
+	}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+
+	var configBuffer bytes.Buffer
+	err = tmpl.Execute(&configBuffer, struct {
+		ClusterName  string
+		TemplateName string
+		Nodes        []api.NodeSpec
+		Labels       map[string]string
+	}{
+		Nodes:        nodes,
+		TemplateName: templateName,
+		ClusterName:  clusterName,
+		Labels:       labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return configBuffer.Bytes(), nil
+}
+
+// applyClusterNetworkOptions overrides the cluster network CIDRs of a still-paused CAPI Cluster.
+// cluster-manager copies the network from the template, so this must happen before unpausing.
+func applyClusterNetworkOptions(namespace, clusterName string, opts ClusterConfigOptions) error {
+	network := map[string]interface{}{}
+	if len(opts.PodCIDRs) > 0 {
+		network["pods"] = map[string][]string{"cidrBlocks": opts.PodCIDRs}
+	}
+	if len(opts.ServiceCIDRs) > 0 {
+		network["services"] = map[string][]string{"cidrBlocks": opts.ServiceCIDRs}
+	}
+	if len(network) == 0 {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"clusterNetwork": network},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal cluster network patch: %w", err)
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	if err := client.PatchCluster(namespace, clusterName, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("failed to patch cluster network of %s/%s: %w", namespace, clusterName, err)
+	}
+	return nil
+}
+
+// applyAdditionalSANs adds extra SANs to the k3s control plane of a cluster.
+// The KThreesControlPlane only exists once topology reconciliation ran, so this waits for it.
+func applyAdditionalSANs(namespace, clusterName string, sans []string) error {
+	if len(sans) == 0 {
+		return nil
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	var controlPlaneName string
+	deadline := time.Now().Add(controlPlaneCreationTimeout)
+	for {
+		list, err := client.ListResource(kthreesControlPlaneResource, namespace, "cluster.x-k8s.io/cluster-name="+clusterName)
+		if err == nil && len(list.Items) > 0 {
+			controlPlaneName = list.Items[0].GetName()
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for KThreesControlPlane of %s/%s to add SANs", namespace, clusterName)
+		}
+		time.Sleep(controlPlaneCreationInterval)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"kthreesConfigSpec": map[string]interface{}{
+				"serverConfig": map[string]interface{}{"tlsSan": sans},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal SAN patch: %w", err)
+	}
+
+	if err := client.PatchResource(kthreesControlPlaneResource, namespace, controlPlaneName, types.MergePatchType, patch); err != nil {
+		return fmt.Errorf("failed to add SANs to %s/%s: %w", namespace, controlPlaneName, err)
+	}
+	return nil
+}
+
+// parseLabels parses "key1=value1,key2=value2" into a map.
+func parseLabels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, pair := range splitList(value) {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("label %q is not in key=value form", pair)
+		}
+		labels[k] = strings.TrimSpace(v)
+	}
+	return labels, nil
+}
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
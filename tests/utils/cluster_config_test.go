@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+func TestClusterConfigOptionsFromEnv(t *testing.T) {
+	t.Setenv(ClusterLabelsEnvVar, "env=ci, team = co ")
+	t.Setenv(ClusterAdditionalSANsEnvVar, "api.example.com,,10.0.0.1")
+	t.Setenv(ClusterPodCIDRsEnvVar, "10.42.0.0/16")
+	t.Setenv(ClusterServiceCIDRsEnvVar, "")
+
+	opts, err := ClusterConfigOptionsFromEnv()
+	if err != nil {
+		t.Fatalf("Failed to parse options: %v", err)
+	}
+
+	if !reflect.DeepEqual(opts.Labels, map[string]string{"env": "ci", "team": "co"}) {
+		t.Errorf("Unexpected labels: %v", opts.Labels)
+	}
+	if !reflect.DeepEqual(opts.AdditionalSANs, []string{"api.example.com", "10.0.0.1"}) {
+		t.Errorf("Unexpected SANs: %v", opts.AdditionalSANs)
+	}
+	if !reflect.DeepEqual(opts.PodCIDRs, []string{"10.42.0.0/16"}) {
+		t.Errorf("Unexpected pod CIDRs: %v", opts.PodCIDRs)
+	}
+	if opts.ServiceCIDRs != nil {
+		t.Errorf("Expected no service CIDRs, got %v", opts.ServiceCIDRs)
+	}
+}
+
+func TestClusterConfigOptionsFromEnvRejectsMalformedLabels(t *testing.T) {
+	t.Setenv(ClusterLabelsEnvVar, "novalue")
+
+	if _, err := ClusterConfigOptionsFromEnv(); err == nil {
+		t.Error("Expected an error for a label without '='")
+	}
+}
+
+func TestClusterConfigOptionsMergeOver(t *testing.T) {
+	base := ClusterConfigOptions{
+		Labels:   map[string]string{"a": "1", "b": "2"},
+		PodCIDRs: []string{"10.42.0.0/16"},
+	}
+	merged := base.mergeOver(ClusterConfigOptions{
+		Labels:       map[string]string{"b": "3"},
+		ServiceCIDRs: []string{"10.43.0.0/16"},
+	})
+
+	if !reflect.DeepEqual(merged.Labels, map[string]string{"a": "1", "b": "3"}) {
+		t.Errorf("Unexpected merged labels: %v", merged.Labels)
+	}
+	if !reflect.DeepEqual(merged.PodCIDRs, base.PodCIDRs) {
+		t.Errorf("Expected pod CIDRs to be kept, got %v", merged.PodCIDRs)
+	}
+	if !reflect.DeepEqual(merged.ServiceCIDRs, []string{"10.43.0.0/16"}) {
+		t.Errorf("Expected service CIDRs to be overridden, got %v", merged.ServiceCIDRs)
+	}
+	if base.Labels["b"] != "2" {
+		t.Error("Merging must not mutate the receiver labels")
+	}
+}
+
+func TestRenderClusterConfig(t *testing.T) {
+	data, err := RenderClusterConfig("my-cluster", "node-guid", "tpl-v1", ClusterConfigOptions{
+		Labels: map[string]string{"quote": `a"b`},
+	})
+	if err != nil {
+		t.Fatalf("Failed to render cluster config: %v", err)
+	}
+
+	var rendered struct {
+		Name     string `json:"name"`
+		Template string `json:"template"`
+		Nodes    []struct {
+			ID string `json:"id"`
+		} `json:"nodes"`
+		Labels map[string]string `json:"labels"`
+	}
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("Rendered config is not valid JSON: %v\n%s", err, data)
+	}
+
+	if rendered.Name != "my-cluster" || rendered.Template != "tpl-v1" {
+		t.Errorf("Unexpected name/template: %s/%s", rendered.Name, rendered.Template)
+	}
+	if len(rendered.Nodes) != 1 || rendered.Nodes[0].ID != "node-guid" {
+		t.Errorf("Unexpected nodes: %+v", rendered.Nodes)
+	}
+	if rendered.Labels["users-label"] != "user-value" || rendered.Labels["quote"] != `a"b` {
+		t.Errorf("Unexpected labels: %v", rendered.Labels)
+	}
+}
+
+func TestRenderMultiNodeClusterConfig(t *testing.T) {
+	nodes := []api.NodeSpec{
+		{Id: "cp", Role: api.Controlplane},
+		{Id: "w1", Role: api.Worker},
+		{Id: "w2", Role: api.Worker},
+	}
+	data, err := RenderMultiNodeClusterConfig("multi", nodes, "tpl-v1", ClusterConfigOptions{})
+	if err != nil {
+		t.Fatalf("Failed to render cluster config: %v", err)
+	}
+
+	var rendered struct {
+		Nodes []api.NodeSpec `json:"nodes"`
+	}
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("Rendered config is not valid JSON: %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(rendered.Nodes, nodes) {
+		t.Errorf("Expected nodes %v, got %v", nodes, rendered.Nodes)
+	}
+
+	if _, err := RenderMultiNodeClusterConfig("multi", nodes[1:], "tpl-v1", ClusterConfigOptions{}); err == nil {
+		t.Error("Expected an error for nodes without a control plane")
+	}
+}
+
+func TestRenderClusterConfigWithoutTemplate(t *testing.T) {
+	data, err := RenderClusterConfig("my-cluster", "node-guid", "", ClusterConfigOptions{})
+	if err != nil {
+		t.Fatalf("Failed to render cluster config: %v", err)
+	}
+
+	var rendered map[string]interface{}
+	if err := json.Unmarshal(data, &rendered); err != nil {
+		t.Fatalf("Rendered config is not valid JSON: %v\n%s", err, data)
+	}
+	if _, ok := rendered["template"]; ok {
+		t.Errorf("Expected no template field, got %s", data)
+	}
+	if rendered["name"] != "my-cluster" {
+		t.Errorf("Unexpected name: %v", rendered["name"])
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	ClusterCRTemplatePath = "../../configs/cluster-cr.yaml"
+)
+
+// ClusterSpecSnapshot captures the parts of a CAPI Cluster that must not depend on
+// whether the cluster was created through cluster-manager or directly through CRs.
+type ClusterSpecSnapshot struct {
+	Class                string
+	Version              string
+	PodCIDRs             []string
+	ServiceCIDRs         []string
+	ControlPlaneReplicas int
+	Template             string
+	Labels               map[string]string
+}
+
+type clusterTemplateCR struct {
+	Spec struct {
+		KubernetesVersion string `json:"kubernetesVersion"`
+		ClusterNetwork    struct {
+			Pods struct {
+				CIDRBlocks []string `json:"cidrBlocks"`
+			} `json:"pods"`
+			Services struct {
+				CIDRBlocks []string `json:"cidrBlocks"`
+			} `json:"services"`
+		} `json:"clusterNetwork"`
+	} `json:"spec"`
+	Status struct {
+		Ready           bool `json:"ready"`
+		ClusterClassRef *struct {
+			Name string `json:"name"`
+		} `json:"clusterClassRef"`
+	} `json:"status"`
+}
+
+// RenderClusterCR renders ClusterCRTemplatePath for a cluster backed by the ClusterClass of templateName.
+func RenderClusterCR(namespace, clusterName, nodeGUID, templateName string) ([]byte, error) {
+	out, err := managementObjectJSON(ClusterTemplateResource, namespace, templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster template %s/%s: %w", namespace, templateName, err)
+	}
+
+	var ct clusterTemplateCR
+	if err := json.Unmarshal(out, &ct); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster template %s/%s: %w", namespace, templateName, err)
+	}
+	if !ct.Status.Ready || ct.Status.ClusterClassRef == nil {
+		return nil, fmt.Errorf("cluster template %s/%s has no ready ClusterClass", namespace, templateName)
+	}
+
+	templateData, err := os.ReadFile(ClusterCRTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New("clusterCR").Funcs(configTemplateFuncs).Parse(string(templateData))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, struct {
+		Namespace         string
+		ClusterName       string
+		NodeGUID          string
+		TemplateName      string
+		ClusterClass      string
+		KubernetesVersion string
+		PodCIDRs          []string
+		ServiceCIDRs      []string
+		Labels            map[string]string
+	}{
+		Namespace:         namespace,
+		ClusterName:       clusterName,
+		NodeGUID:          nodeGUID,
+		TemplateName:      templateName,
+		ClusterClass:      ct.Status.ClusterClassRef.Name,
+		KubernetesVersion: ct.Spec.KubernetesVersion,
+		PodCIDRs:          ct.Spec.ClusterNetwork.Pods.CIDRBlocks,
+		ServiceCIDRs:      ct.Spec.ClusterNetwork.Services.CIDRBlocks,
+		Labels:            DefaultClusterLabels,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// CreateClusterFromCR creates a cluster by applying CAPI CRs directly, without cluster-manager.
+// The IntelMachineBinding is owned by the Cluster, as cluster-manager does, so deleting the
+// Cluster CR garbage-collects it.
+func CreateClusterFromCR(namespace, clusterName, nodeGUID, templateName string) error {
+	manifest, err := RenderClusterCR(namespace, clusterName, nodeGUID, templateName)
+	if err != nil {
+		return err
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	if err := client.ApplyManifest(string(manifest)); err != nil {
+		return fmt.Errorf("failed to apply cluster CRs for %s/%s: %w", namespace, clusterName, err)
+	}
+
+	cluster, err := client.GetCluster(namespace, clusterName)
+	if err != nil {
+		return fmt.Errorf("failed to get uid of cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"ownerReferences":[{"apiVersion":"cluster.x-k8s.io/v1beta1","kind":"Cluster","name":%q,"uid":%q}]}}`,
+		clusterName, cluster.GetUID())
+	if err := client.PatchResource(IntelMachineBindingGVR.GroupResource().String(), namespace, clusterName+"-"+nodeGUID,
+		types.MergePatchType, []byte(patch)); err != nil {
+		return fmt.Errorf("failed to set owner of machine binding for %s/%s: %w", namespace, clusterName, err)
+	}
+
+	return nil
+}
+
+// DeleteClusterCR deletes a cluster by deleting its CAPI Cluster CR.
+func DeleteClusterCR(namespace, clusterName string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	return client.DeleteResource(CAPIClusterGVR.GroupResource().String(), namespace, clusterName)
+}
+
+// GetClusterSpecSnapshot reads the comparable spec fields of a CAPI Cluster.
+func GetClusterSpecSnapshot(namespace, clusterName string) (*ClusterSpecSnapshot, error) {
+	out, err := managementObjectJSON(CAPIClusterGVR.GroupResource().String(), namespace, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	var c struct {
+		Metadata struct {
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			ClusterNetwork struct {
+				Pods struct {
+					CIDRBlocks []string `json:"cidrBlocks"`
+				} `json:"pods"`
+				Services struct {
+					CIDRBlocks []string `json:"cidrBlocks"`
+				} `json:"services"`
+			} `json:"clusterNetwork"`
+			Topology struct {
+				Class        string `json:"class"`
+				Version      string `json:"version"`
+				ControlPlane struct {
+					Replicas int `json:"replicas"`
+				} `json:"controlPlane"`
+			} `json:"topology"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(out, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	// Drop labels added by controllers; only user-facing labels are comparable.
+	labels := map[string]string{}
+	for k, v := range c.Metadata.Labels {
+		if !strings.Contains(k, "/") {
+			labels[k] = v
+		}
+	}
+
+	return &ClusterSpecSnapshot{
+		Class:                c.Spec.Topology.Class,
+		Version:              c.Spec.Topology.Version,
+		PodCIDRs:             c.Spec.ClusterNetwork.Pods.CIDRBlocks,
+		ServiceCIDRs:         c.Spec.ClusterNetwork.Services.CIDRBlocks,
+		ControlPlaneReplicas: c.Spec.Topology.ControlPlane.Replicas,
+		Template:             c.Metadata.Annotations["edge-orchestrator.intel.com/template"],
+		Labels:               labels,
+	}, nil
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const (
+	// PlatformLabelPrefix prefixes the system labels cluster-manager sets on the clusters it creates.
+	PlatformLabelPrefix = "edge-orchestrator.intel.com"
+	// ClusterNameLabel and ProjectIDLabel are system labels of every cluster created through the API.
+	ClusterNameLabel = PlatformLabelPrefix + "/clustername"
+	ProjectIDLabel   = PlatformLabelPrefix + "/project-id"
+)
+
+// systemLabelPrefixes are the label prefixes cluster-manager treats as its own: they are kept when the
+// user labels are replaced and left out of the labels of the API.
+var systemLabelPrefixes = []string{PlatformLabelPrefix, "cluster.x-k8s.io", "topology.cluster.x-k8s.io", "prometheusMetricsURL"}
+
+// IsSystemLabel reports whether a label key belongs to cluster-manager or CAPI rather than to the user.
+func IsSystemLabel(key string) bool {
+	for _, prefix := range systemLabelPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserLabels returns the labels that are not system labels.
+func UserLabels(labels map[string]string) map[string]string {
+	user := map[string]string{}
+	for key, value := range labels {
+		if !IsSystemLabel(key) {
+			user[key] = value
+		}
+	}
+	return user
+}
+
+// GetClusterLabels returns the labels of a cluster as reported by cluster-manager, with the token of
+// authContext when it is not nil.
+func GetClusterLabels(authContext *auth.TestAuthContext, namespace, clusterName string) (map[string]string, error) {
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodGet,
+		fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, NewAPIError(fmt.Sprintf("get cluster %s", clusterName), status, []byte(body))
+	}
+	var cluster api.ClusterDetailInfo
+	if err := json.Unmarshal([]byte(body), &cluster); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster %s: %w", clusterName, err)
+	}
+	if cluster.Labels == nil {
+		return map[string]string{}, nil
+	}
+	return stringLabels(*cluster.Labels)
+}
+
+// stringLabels converts the labels of the API, which are typed as arbitrary values, to strings.
+func stringLabels(labels map[string]interface{}) (map[string]string, error) {
+	converted := make(map[string]string, len(labels))
+	for key, value := range labels {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("label %s has the non-string value %v", key, value)
+		}
+		converted[key] = text
+	}
+	return converted, nil
+}
+
+// UpdateClusterLabels replaces the user labels of a cluster, with the token of authContext when it is
+// not nil.
+func UpdateClusterLabels(authContext *auth.TestAuthContext, namespace, clusterName string, labels map[string]string) error {
+	data, err := json.Marshal(api.ClusterLabels{Labels: &labels})
+	if err != nil {
+		return err
+	}
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPut,
+		fmt.Sprintf("%s/%s/labels", ClusterCreateURL, clusterName), data)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return NewAPIError(fmt.Sprintf("update labels of cluster %s", clusterName), status, []byte(body))
+	}
+	return nil
+}
+
+// GetClusterCRLabels returns the labels of the CAPI Cluster object of a cluster.
+func GetClusterCRLabels(namespace, clusterName string) (map[string]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := client.GetCluster(namespace, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Cluster %s/%s: %w", namespace, clusterName, err)
+	}
+	labels := cluster.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return labels, nil
+}
+
+// GetClusterMachineLabels returns the labels of the CAPI Machines of a cluster by Machine name.
+func GetClusterMachineLabels(namespace, clusterName string) (map[string]map[string]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.ListMachines(namespace, clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the Machines of %s/%s: %w", namespace, clusterName, err)
+	}
+	machines := map[string]map[string]string{}
+	for _, machine := range list {
+		machines[machine.GetName()] = machine.GetLabels()
+	}
+	return machines, nil
+}
+
+// LabelDifferences returns how actual differs from expected for the keys of expected, and lists the
+// keys of absent that are present in actual. It is empty when actual carries the expected labels.
+func LabelDifferences(actual, expected map[string]string, absent ...string) []string {
+	var differences []string
+	for key, value := range expected {
+		switch got, ok := actual[key]; {
+		case !ok:
+			differences = append(differences, fmt.Sprintf("%s is missing", key))
+		case got != value:
+			differences = append(differences, fmt.Sprintf("%s is %q instead of %q", key, got, value))
+		}
+	}
+	for _, key := range absent {
+		if got, ok := actual[key]; ok {
+			differences = append(differences, fmt.Sprintf("%s is still set to %q", key, got))
+		}
+	}
+	sort.Strings(differences)
+	return differences
+}
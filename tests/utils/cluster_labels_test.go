@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserLabels(t *testing.T) {
+	labels := map[string]string{
+		ClusterNameLabel:                 "demo-cluster",
+		ProjectIDLabel:                   "53cd37b9",
+		"cluster.x-k8s.io/cluster-name":  "demo-cluster",
+		"topology.cluster.x-k8s.io/name": "baseline",
+		"prometheusMetricsURL":           "metrics-node.kind.internal",
+		"env":                            "ci",
+		"example.com/team":               "co",
+	}
+	user := UserLabels(labels)
+	if len(user) != 2 || user["env"] != "ci" || user["example.com/team"] != "co" {
+		t.Errorf("Expected only the env and team labels, got %v", user)
+	}
+}
+
+func TestStringLabels(t *testing.T) {
+	labels, err := stringLabels(map[string]interface{}{"env": "ci"})
+	if err != nil || labels["env"] != "ci" {
+		t.Errorf("Expected env=ci, got %v, %v", labels, err)
+	}
+	if _, err := stringLabels(map[string]interface{}{"replicas": 3.0}); err == nil {
+		t.Error("Expected an error for a non-string label value")
+	}
+}
+
+func TestLabelDifferences(t *testing.T) {
+	actual := map[string]string{"env": "ci", "team": "edge", "old": "label"}
+
+	if differences := LabelDifferences(actual, map[string]string{"env": "ci"}, "gone"); len(differences) != 0 {
+		t.Errorf("Expected no differences, got %v", differences)
+	}
+
+	differences := LabelDifferences(actual, map[string]string{"env": "ci", "team": "co", "zone": "a"}, "old")
+	expected := []string{`old is still set to "label"`, `team is "edge" instead of "co"`, "zone is missing"}
+	if strings.Join(differences, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, differences)
+	}
+}
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+const (
+	// ClusterManagerMetricsCheckEnvVar checks the server-side error counters of cluster-manager and its
+	// template controller over the whole suite run.
+	ClusterManagerMetricsCheckEnvVar = "CLUSTER_MANAGER_METRICS_CHECK"
+	// ClusterManagerMaxErrorRateEnvVar is the highest share of the cluster-manager API requests of a suite run
+	// that may be answered with a 5xx status.
+	ClusterManagerMaxErrorRateEnvVar  = "CLUSTER_MANAGER_MAX_ERROR_RATE"
+	DefaultClusterManagerMaxErrorRate = 0.01
+
+	// clusterManagerMetricsService and templateControllerMetricsService are scraped through the service proxy
+	// of the API server, so that the check does not depend on the port-forwards of the specs.
+	clusterManagerMetricsService     = "cluster-manager:8080"
+	templateControllerMetricsService = "templates-metrics:8080"
+
+	clusterManagerResponsesMetric = "cluster_manager_http_response_codes_counter"
+	reconcileErrorsMetric         = "controller_runtime_reconcile_errors_total"
+)
+
+// MetricSample is a sample of the Prometheus text format.
+type MetricSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Metrics are the samples of a metrics endpoint by series, i.e. name{labels} as exposed.
+type Metrics map[string]MetricSample
+
+// ParsePrometheusMetrics reads the samples of the Prometheus text format. Comments and timestamps are ignored.
+func ParsePrometheusMetrics(r io.Reader) (Metrics, error) {
+	metrics := Metrics{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		series, rest := line, ""
+		sample := MetricSample{Labels: map[string]string{}}
+		if open := strings.IndexByte(line, '{'); open >= 0 {
+			labels, end, err := parseMetricLabels(line[open+1:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %q: %w", line, err)
+			}
+			sample.Name, sample.Labels = line[:open], labels
+			series, rest = line[:open+1+end+1], line[open+1+end+1:]
+		} else if fields := strings.Fields(line); len(fields) > 1 {
+			sample.Name, series, rest = fields[0], fields[0], strings.Join(fields[1:], " ")
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("no value in %q", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the value of %q: %w", line, err)
+		}
+		sample.Value = value
+		metrics[series] = sample
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading metrics: %v", err)
+	}
+	return metrics, nil
+}
+
+// parseMetricLabels parses the labels following the opening brace of a series and returns them with the
+// index of the closing brace.
+func parseMetricLabels(s string) (map[string]string, int, error) {
+	labels := map[string]string{}
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '}':
+			return labels, i, nil
+		case ',', ' ':
+			i++
+			continue
+		}
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 || i+eq+1 >= len(s) || s[i+eq+1] != '"' {
+			return nil, 0, fmt.Errorf("malformed label at %q", s[i:])
+		}
+		name := s[i : i+eq]
+		var value strings.Builder
+		j := i + eq + 2
+		for ; j < len(s) && s[j] != '"'; j++ {
+			if s[j] == '\\' && j+1 < len(s) {
+				j++
+				if s[j] == 'n' {
+					value.WriteByte('\n')
+					continue
+				}
+			}
+			value.WriteByte(s[j])
+		}
+		if j >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated value of label %s", name)
+		}
+		labels[name] = value.String()
+		i = j + 1
+	}
+	return nil, 0, fmt.Errorf("unterminated labels")
+}
+
+// Increase returns how much the counters name of m rose since before, by the value of label. A counter lower
+// than before was reset by a restart, so all of it counts.
+func (m Metrics) Increase(before Metrics, name, label string) map[string]float64 {
+	increase := map[string]float64{}
+	for series, sample := range m {
+		if sample.Name != name {
+			continue
+		}
+		if delta := counterIncrease(before, series, sample); delta > 0 {
+			increase[sample.Labels[label]] += delta
+		}
+	}
+	return increase
+}
+
+func counterIncrease(before Metrics, series string, sample MetricSample) float64 {
+	if previous, ok := before[series]; ok && previous.Value <= sample.Value {
+		return sample.Value - previous.Value
+	}
+	return sample.Value
+}
+
+// ScrapeServiceMetrics fetches /metrics of a component service, e.g. cluster-manager:8080, through the
+// service proxy of the API server.
+func ScrapeServiceMetrics(service string) (Metrics, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/services/%s/proxy/metrics", componentServiceNamespace(), service)
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.GetRaw(path, kubeClientTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape %s: %w", service, err)
+	}
+	return ParsePrometheusMetrics(bytes.NewReader(out))
+}
+
+// ClusterManagerMetrics are the metrics of the cluster-manager API and of its template controller.
+type ClusterManagerMetrics struct {
+	API        Metrics
+	Controller Metrics
+}
+
+// ScrapeClusterManagerMetrics scrapes cluster-manager and its template controller. Both need the metrics
+// enabled in the chart, as .test-dependencies.yaml does.
+func ScrapeClusterManagerMetrics() (ClusterManagerMetrics, error) {
+	api, err := ScrapeServiceMetrics(clusterManagerMetricsService)
+	if err != nil {
+		return ClusterManagerMetrics{}, err
+	}
+	controller, err := ScrapeServiceMetrics(templateControllerMetricsService)
+	if err != nil {
+		return ClusterManagerMetrics{}, err
+	}
+	return ClusterManagerMetrics{API: api, Controller: controller}, nil
+}
+
+// ClusterManagerErrorReport is what cluster-manager counted between two scrapes. The server errors are
+// counted even when the retries of NewHTTPClient turned them into a success for the specs.
+type ClusterManagerErrorReport struct {
+	Requests float64
+	// ServerErrors are the 5xx responses by "METHOD path status".
+	ServerErrors map[string]float64
+	// ReconcileErrors are the reconcile errors of the template controller by controller.
+	ReconcileErrors map[string]float64
+}
+
+// NewClusterManagerErrorReport compares the metrics of cluster-manager after a run with the ones before it.
+func NewClusterManagerErrorReport(before, after ClusterManagerMetrics) ClusterManagerErrorReport {
+	report := ClusterManagerErrorReport{
+		ServerErrors:    map[string]float64{},
+		ReconcileErrors: after.Controller.Increase(before.Controller, reconcileErrorsMetric, "controller"),
+	}
+	for series, sample := range after.API {
+		if sample.Name != clusterManagerResponsesMetric {
+			continue
+		}
+		delta := counterIncrease(before.API, series, sample)
+		report.Requests += delta
+		if strings.HasPrefix(sample.Labels["code"], "5") && delta > 0 {
+			report.ServerErrors[fmt.Sprintf("%s %s %s", sample.Labels["method"], sample.Labels["path"], sample.Labels["code"])] += delta
+		}
+	}
+	return report
+}
+
+// ErrorRate returns the share of the requests answered with a 5xx status.
+func (r ClusterManagerErrorReport) ErrorRate() float64 {
+	if r.Requests == 0 {
+		return 0
+	}
+	return sumCounts(r.ServerErrors) / r.Requests
+}
+
+func sumCounts(counts map[string]float64) float64 {
+	var sum float64
+	for _, count := range counts {
+		sum += count
+	}
+	return sum
+}
+
+// Check fails when the template controller failed a reconcile or more than maxErrorRate of the requests
+// were answered with a 5xx status.
+func (r ClusterManagerErrorReport) Check(maxErrorRate float64) error {
+	var problems []string
+	if rate := r.ErrorRate(); rate > maxErrorRate {
+		problems = append(problems, fmt.Sprintf("%.2f%% of %.0f cluster-manager requests failed with a server error, more than %.2f%%:%s",
+			rate*100, r.Requests, maxErrorRate*100, formatCounts(r.ServerErrors)))
+	}
+	if len(r.ReconcileErrors) > 0 {
+		problems = append(problems, "the template controller failed to reconcile:"+formatCounts(r.ReconcileErrors))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+func formatCounts(counts map[string]float64) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\n  %s: %.0f", key, counts[key])
+	}
+	return b.String()
+}
+
+// ClusterManagerMetricsCheckEnabled reports whether suites check the error counters of cluster-manager, see
+// ClusterManagerMetricsCheckEnvVar.
+func ClusterManagerMetricsCheckEnabled() bool {
+	return os.Getenv(ClusterManagerMetricsCheckEnvVar) == "true"
+}
+
+// ClusterManagerMaxErrorRate returns CLUSTER_MANAGER_MAX_ERROR_RATE, a share between 0 and 1.
+func ClusterManagerMaxErrorRate() float64 {
+	value := os.Getenv(ClusterManagerMaxErrorRateEnvVar)
+	if value == "" {
+		return DefaultClusterManagerMaxErrorRate
+	}
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil || rate < 0 || rate > 1 || math.IsNaN(rate) {
+		fmt.Printf("Ignoring invalid %s=%q\n", ClusterManagerMaxErrorRateEnvVar, value)
+		return DefaultClusterManagerMaxErrorRate
+	}
+	return rate
+}
+
+// RegisterClusterManagerMetricsCheck scrapes cluster-manager and its template controller before and after the
+// suite when ClusterManagerMetricsCheckEnabled, and fails the suite when the report does not pass Check.
+// diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterClusterManagerMetricsCheck() bool {
+	var before ClusterManagerMetrics
+	ginkgo.ReportBeforeSuite(func(ginkgo.Report) {
+		if !ClusterManagerMetricsCheckEnabled() {
+			return
+		}
+		metrics, err := ScrapeClusterManagerMetrics()
+		if err != nil {
+			fmt.Printf("Failed to scrape cluster-manager before the suite, counting from the start of its pods: %v\n", err)
+			return
+		}
+		before = metrics
+	})
+	ginkgo.ReportAfterSuite("cluster-manager metrics check", func(ginkgo.Report) {
+		if !ClusterManagerMetricsCheckEnabled() {
+			return
+		}
+		after, err := ScrapeClusterManagerMetrics()
+		if err != nil {
+			ginkgo.Fail(fmt.Sprintf("Failed to scrape cluster-manager after the suite: %v", err))
+		}
+		report := NewClusterManagerErrorReport(before, after)
+		fmt.Printf("cluster-manager served %.0f requests, %.2f%% with a server error, and its template controller failed %.0f reconciles\n",
+			report.Requests, report.ErrorRate()*100, sumCounts(report.ReconcileErrors))
+		if err := report.Check(ClusterManagerMaxErrorRate()); err != nil {
+			ginkgo.Fail(err.Error())
+		}
+	})
+	return true
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func mustParsePrometheusMetrics(t *testing.T, text string) Metrics {
+	t.Helper()
+	metrics, err := ParsePrometheusMetrics(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("Failed to parse metrics: %v", err)
+	}
+	return metrics
+}
+
+func TestParsePrometheusMetrics(t *testing.T) {
+	metrics := mustParsePrometheusMetrics(t, `# HELP cluster_manager_http_response_codes_counter Count of HTTP response codes per endpoint
+# TYPE cluster_manager_http_response_codes_counter counter
+cluster_manager_http_response_codes_counter{code="200",method="GET",path="/v2/clusters"} 12
+cluster_manager_http_response_codes_counter{code="500",method="GET",path="/v2/clusters/a\"b,c}"} 2 1700000000000
+go_goroutines 31
+`)
+	if len(metrics) != 3 {
+		t.Fatalf("Expected 3 samples, got %v", metrics)
+	}
+	sample := metrics[`cluster_manager_http_response_codes_counter{code="500",method="GET",path="/v2/clusters/a\"b,c}"}`]
+	if sample.Name != clusterManagerResponsesMetric || sample.Value != 2 || sample.Labels["path"] != `/v2/clusters/a"b,c}` {
+		t.Errorf("Expected the escaped label and the value without the timestamp, got %+v", sample)
+	}
+	if sample := metrics["go_goroutines"]; sample.Value != 31 || len(sample.Labels) != 0 {
+		t.Errorf("Expected go_goroutines without labels, got %+v", sample)
+	}
+
+	for _, malformed := range []string{"go_goroutines", `up{job="a} 1`, "up abc"} {
+		if _, err := ParsePrometheusMetrics(strings.NewReader(malformed)); err == nil {
+			t.Errorf("Expected an error for %q", malformed)
+		}
+	}
+}
+
+func TestClusterManagerErrorReport(t *testing.T) {
+	before := ClusterManagerMetrics{
+		API: mustParsePrometheusMetrics(t, `cluster_manager_http_response_codes_counter{code="200",method="GET",path="/v2/clusters"} 100
+cluster_manager_http_response_codes_counter{code="503",method="POST",path="/v2/clusters"} 1
+`),
+		Controller: mustParsePrometheusMetrics(t, `controller_runtime_reconcile_errors_total{controller="clustertemplate"} 3
+controller_runtime_reconcile_errors_total{controller="clusterconnect"} 5
+`),
+	}
+	after := ClusterManagerMetrics{
+		API: mustParsePrometheusMetrics(t, `cluster_manager_http_response_codes_counter{code="200",method="GET",path="/v2/clusters"} 295
+cluster_manager_http_response_codes_counter{code="404",method="GET",path="/v2/clusters/missing"} 3
+cluster_manager_http_response_codes_counter{code="503",method="POST",path="/v2/clusters"} 3
+`),
+		// The clusterconnect counter went down: its controller restarted and failed twice since.
+		Controller: mustParsePrometheusMetrics(t, `controller_runtime_reconcile_errors_total{controller="clustertemplate"} 3
+controller_runtime_reconcile_errors_total{controller="clusterconnect"} 2
+`),
+	}
+
+	report := NewClusterManagerErrorReport(before, after)
+	if report.Requests != 200 || report.ServerErrors["POST /v2/clusters 503"] != 2 || len(report.ServerErrors) != 1 {
+		t.Errorf("Expected 200 requests with 2 server errors, got %+v", report)
+	}
+	if rate := report.ErrorRate(); rate != 0.01 {
+		t.Errorf("Expected a 1%% error rate, got %v", rate)
+	}
+	if len(report.ReconcileErrors) != 1 || report.ReconcileErrors["clusterconnect"] != 2 {
+		t.Errorf("Expected 2 reconcile errors of clusterconnect, got %v", report.ReconcileErrors)
+	}
+
+	err := report.Check(0.05)
+	if err == nil || strings.Contains(err.Error(), "server error") || !strings.Contains(err.Error(), "clusterconnect: 2") {
+		t.Errorf("Expected only the reconcile errors to fail the check, got %v", err)
+	}
+	report.ReconcileErrors = nil
+	if err := report.Check(0.005); err == nil || !strings.Contains(err.Error(), "POST /v2/clusters 503: 2") {
+		t.Errorf("Expected the error rate to fail the check, got %v", err)
+	}
+	if err := report.Check(0.01); err != nil {
+		t.Errorf("Expected an error rate at the threshold to pass, got %v", err)
+	}
+}
+
+func TestClusterManagerMaxErrorRate(t *testing.T) {
+	for value, want := range map[string]float64{"": DefaultClusterManagerMaxErrorRate, "0": 0, "0.2": 0.2, "2": DefaultClusterManagerMaxErrorRate, "x": DefaultClusterManagerMaxErrorRate} {
+		t.Setenv(ClusterManagerMaxErrorRateEnvVar, value)
+		if rate := ClusterManagerMaxErrorRate(); rate != want {
+			t.Errorf("Expected %v for %q, got %v", want, value, rate)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// MultiNodeGUIDsEnvVar lists the edge nodes of the multi-node cluster specs, see ParseClusterNodes.
+	MultiNodeGUIDsEnvVar = "MULTI_NODE_GUIDS"
+	// MultiNodeClusterSize is the number of nodes the multi-node cluster specs need.
+	MultiNodeClusterSize = 3
+)
+
+// DeleteNodeOption is a query option of the node deletion API, e.g. DeleteNodeForce.
+type DeleteNodeOption string
+
+// Query options documented for DELETE /v2/clusters/{name}/nodes/{nodeId}.
+const (
+	// DeleteNodeForce removes the host cleanup finalizer of the IntelMachines of the cluster and deletes
+	// without a grace period, for nodes that are gone and can no longer be cleaned up.
+	DeleteNodeForce DeleteNodeOption = "force=true"
+	// DeleteNodeNoForce is the default, graceful deletion.
+	DeleteNodeNoForce DeleteNodeOption = "force=false"
+)
+
+// DeleteNodeURL returns the URL deleting a node from a cluster with the given query options.
+func DeleteNodeURL(clusterName, nodeID string, options ...DeleteNodeOption) string {
+	url := fmt.Sprintf("%s/%s/nodes/%s", ClusterCreateURL, clusterName, nodeID)
+	query := make([]string, 0, len(options))
+	for _, option := range options {
+		query = append(query, string(option))
+	}
+	if len(query) > 0 {
+		url += "?" + strings.Join(query, "&")
+	}
+	return url
+}
+
+// DeleteNode deletes a node from a cluster, with the token of authContext when it is not nil. Deleting the
+// last node of a cluster deletes the cluster.
+func DeleteNode(authContext *auth.TestAuthContext, namespace, clusterName, nodeID string, options ...DeleteNodeOption) error {
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodDelete,
+		DeleteNodeURL(clusterName, nodeID, options...), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return NewAPIError(fmt.Sprintf("delete node %s of cluster %s", nodeID, clusterName), status, []byte(body))
+	}
+	return nil
+}
+
+// ClusterNodesURL returns the URL of the nodes of a cluster.
+func ClusterNodesURL(clusterName string) string {
+	return fmt.Sprintf("%s/%s/nodes", ClusterCreateURL, clusterName)
+}
+
+// GetClusterNodes returns the nodes of a cluster as reported by cluster-manager, with the token of
+// authContext when it is not nil.
+func GetClusterNodes(authContext *auth.TestAuthContext, namespace, clusterName string) ([]api.NodeSpec, error) {
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodGet,
+		fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, NewAPIError(fmt.Sprintf("get cluster %s", clusterName), status, []byte(body))
+	}
+	var cluster api.ClusterDetailInfo
+	if err := json.Unmarshal([]byte(body), &cluster); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster %s: %w", clusterName, err)
+	}
+	if cluster.Nodes == nil {
+		return nil, nil
+	}
+	return nodeSpecs(*cluster.Nodes), nil
+}
+
+// nodeSpecs converts the nodes of a cluster as reported by cluster-manager to the specs the nodes API takes.
+func nodeSpecs(nodes []api.NodeInfo) []api.NodeSpec {
+	specs := make([]api.NodeSpec, 0, len(nodes))
+	for _, node := range nodes {
+		if node.Id == nil {
+			continue
+		}
+		spec := api.NodeSpec{Id: *node.Id, Role: api.All}
+		if node.Role != nil && *node.Role != "" {
+			spec.Role = api.NodeSpecRole(*node.Role)
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// AddNodeToCluster adds a node to a cluster through the nodes API, with the token of authContext when it is
+// not nil. The API replaces the nodes of the cluster, so the node is added to those cluster-manager reports.
+func AddNodeToCluster(authContext *auth.TestAuthContext, namespace, clusterName string, node api.NodeSpec) error {
+	nodes, err := GetClusterNodes(authContext, namespace, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, existing := range nodes {
+		if existing.Id == node.Id {
+			return fmt.Errorf("node %s is already a node of cluster %s", node.Id, clusterName)
+		}
+	}
+	data, err := json.Marshal(append(nodes, node))
+	if err != nil {
+		return err
+	}
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPut, ClusterNodesURL(clusterName), data)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return NewAPIError(fmt.Sprintf("add node %s to cluster %s", node.Id, clusterName), status, []byte(body))
+	}
+	return nil
+}
+
+// AllRoleNodes returns a node per GUID, each running both the control plane and the workloads.
+func AllRoleNodes(nodeGUIDs ...string) []api.NodeSpec {
+	nodes := make([]api.NodeSpec, 0, len(nodeGUIDs))
+	for _, guid := range nodeGUIDs {
+		nodes = append(nodes, api.NodeSpec{Id: guid, Role: api.All})
+	}
+	return nodes
+}
+
+// ParseClusterNodes parses a comma separated list of node GUIDs, each optionally suffixed with ":<role>". Nodes
+// without a role are the control plane when they come first and workers otherwise.
+func ParseClusterNodes(value string) ([]api.NodeSpec, error) {
+	var nodes []api.NodeSpec
+	for i, entry := range splitList(value) {
+		guid, role, hasRole := strings.Cut(entry, ":")
+		node := api.NodeSpec{Id: strings.TrimSpace(guid), Role: api.Worker}
+		if i == 0 {
+			node.Role = api.Controlplane
+		}
+		if hasRole {
+			node.Role = api.NodeSpecRole(strings.TrimSpace(role))
+		}
+		if node.Id == "" {
+			return nil, fmt.Errorf("invalid node %q: the GUID is empty", entry)
+		}
+		switch node.Role {
+		case api.All, api.Controlplane, api.Worker:
+		default:
+			return nil, fmt.Errorf("invalid node %q: the role must be %s, %s or %s", entry, api.All, api.Controlplane, api.Worker)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// MultiNodeClusterNodes returns the nodes of MultiNodeGUIDsEnvVar, see ParseClusterNodes.
+func MultiNodeClusterNodes() ([]api.NodeSpec, error) {
+	nodes, err := ParseClusterNodes(os.Getenv(MultiNodeGUIDsEnvVar))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", MultiNodeGUIDsEnvVar, err)
+	}
+	return nodes, nil
+}
+
+// ValidateClusterNodes checks that nodes can form a cluster: at least one node runs the control plane and no
+// node is listed twice.
+func ValidateClusterNodes(nodes []api.NodeSpec) error {
+	seen := map[string]bool{}
+	controlPlane := false
+	for _, node := range nodes {
+		if seen[node.Id] {
+			return fmt.Errorf("node %s is listed more than once", node.Id)
+		}
+		seen[node.Id] = true
+		controlPlane = controlPlane || node.Role == api.All || node.Role == api.Controlplane
+	}
+	if !controlPlane {
+		return fmt.Errorf("none of the %d nodes has the %s or %s role", len(nodes), api.Controlplane, api.All)
+	}
+	return nil
+}
+
+// CreateMultiNodeCluster creates a cluster called clusterName from nodes, with the token of authContext when
+// it is not nil. Options are applied as by CreateNamedCluster.
+func CreateMultiNodeCluster(authContext *auth.TestAuthContext, namespace, clusterName string, nodes []api.NodeSpec,
+	templateName string, opts ClusterConfigOptions) error {
+	opts, err := resolveClusterConfigOptions(opts)
+	if err != nil {
+		return err
+	}
+	data, err := RenderMultiNodeClusterConfig(clusterName, nodes, templateName, opts)
+	if err != nil {
+		return err
+	}
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, ClusterCreateURL, data)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusCreated {
+		return NewAPIError(fmt.Sprintf("create cluster %s with %d nodes", clusterName, len(nodes)), status, []byte(body))
+	}
+	return finalizeClusterCreation(namespace, clusterName, opts)
+}
+
+// IntelMachinesReady returns the names of the IntelMachines of a cluster, split by whether their Ready
+// condition is True.
+func IntelMachinesReady(namespace, clusterName string) (ready, notReady []string, err error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	machines, err := client.ListIntelMachines(namespace, clusterName)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, machine := range machines {
+		var obj capiObject
+		if err := fromUnstructured(machine.Object, &obj); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode IntelMachine %s: %w", machine.GetName(), err)
+		}
+		if _, ok := obj.conditionTrue("Ready"); ok {
+			ready = append(ready, machine.GetName())
+		} else {
+			notReady = append(notReady, machine.GetName())
+		}
+	}
+	return ready, notReady, nil
+}
+
+// ClusterMachineNames returns the names of the CAPI Machines of a cluster, sorted.
+func ClusterMachineNames(namespace, clusterName string) ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	machines, err := client.ListMachines(namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(machines))
+	for _, machine := range machines {
+		names = append(names, machine.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ClusterDetailByNodeURL returns the URL the edge-node agent uses to look up the cluster of a node.
+func ClusterDetailByNodeURL(nodeID string) string {
+	return fmt.Sprintf("%s/%s/clusterdetail", ClusterCreateURL, nodeID)
+}
+
+// GetClusterInfoByNodeID returns the cluster a node belongs to, with the token of authContext when it is
+// not nil. cluster-manager matches nodeID against the UID of the downstream Node the Machines of the
+// project reference, see ListClusterNodeUIDs.
+func GetClusterInfoByNodeID(authContext *auth.TestAuthContext, namespace, nodeID string) (*api.ClusterDetailInfo, error) {
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodGet, ClusterDetailByNodeURL(nodeID), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, NewAPIError(fmt.Sprintf("get cluster of node %s", nodeID), status, []byte(body))
+	}
+	var cluster api.ClusterDetailInfo
+	if err := json.Unmarshal([]byte(body), &cluster); err != nil {
+		return nil, fmt.Errorf("failed to parse the cluster of node %s: %w", nodeID, err)
+	}
+	return &cluster, nil
+}
+
+// ListClusterNodeUIDs returns the UIDs of the downstream Nodes the Machines of a cluster reference. Only
+// the v1beta1 Machines carry them, which is the version cluster-manager reads.
+func ListClusterNodeUIDs(namespace, clusterName string) ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	machines, err := client.ListMachines(namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return machineNodeUIDs(machines), nil
+}
+
+// machineNodeUIDs returns the Node UIDs of the Machines, skipping a Machine without a Node.
+func machineNodeUIDs(machines []unstructured.Unstructured) []string {
+	var uids []string
+	for _, machine := range machines {
+		if uid, _, _ := unstructured.NestedString(machine.Object, "status", "nodeRef", "uid"); uid != "" {
+			uids = append(uids, uid)
+		}
+	}
+	return uids
+}
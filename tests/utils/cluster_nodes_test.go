@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDeleteNodeURL(t *testing.T) {
+	base := ClusterCreateURL + "/demo/nodes/a1b2"
+	cases := []struct {
+		options  []DeleteNodeOption
+		expected string
+	}{
+		{nil, base},
+		{[]DeleteNodeOption{DeleteNodeForce}, base + "?force=true"},
+		{[]DeleteNodeOption{DeleteNodeNoForce, "dryRun=true"}, base + "?force=false&dryRun=true"},
+	}
+	for _, c := range cases {
+		if url := DeleteNodeURL("demo", "a1b2", c.options...); url != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, url)
+		}
+	}
+}
+
+func TestClusterNodesURL(t *testing.T) {
+	if url := ClusterNodesURL("demo"); url != ClusterCreateURL+"/demo/nodes" {
+		t.Errorf("Expected the nodes URL of the cluster, got %s", url)
+	}
+}
+
+func TestNodeSpecs(t *testing.T) {
+	id1, id2, worker, empty := "a1b2", "c3d4", string(api.Worker), ""
+	specs := nodeSpecs([]api.NodeInfo{{Id: &id1, Role: &worker}, {Role: &worker}, {Id: &id2, Role: &empty}})
+	expected := []api.NodeSpec{{Id: "a1b2", Role: api.Worker}, {Id: "c3d4", Role: api.All}}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("Expected %v, got %v", expected, specs)
+	}
+}
+
+func TestAllRoleNodes(t *testing.T) {
+	nodes := AllRoleNodes("a1b2", "c3d4")
+	expected := []api.NodeSpec{{Id: "a1b2", Role: api.All}, {Id: "c3d4", Role: api.All}}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Errorf("Expected %v, got %v", expected, nodes)
+	}
+	if err := ValidateClusterNodes(nodes); err != nil {
+		t.Errorf("Expected the nodes to form a cluster, got %v", err)
+	}
+}
+
+func TestClusterDetailByNodeURL(t *testing.T) {
+	url := ClusterDetailByNodeURL("a1b2")
+	if url != ClusterCreateURL+"/a1b2/clusterdetail" {
+		t.Errorf("Expected the clusterdetail URL of the node, got %s", url)
+	}
+}
+
+func TestMachineNodeUIDs(t *testing.T) {
+	machine := func(name, uid string) unstructured.Unstructured {
+		status := map[string]interface{}{}
+		if uid != "" {
+			status["nodeRef"] = map[string]interface{}{"uid": uid}
+		}
+		return *testObject(MachineGVR, "Machine", "ns", name, map[string]interface{}{"status": status})
+	}
+	uids := machineNodeUIDs([]unstructured.Unstructured{
+		machine("demo-0", "5f0e6a4c-1d5e-4b8e-9c1a-2b3c4d5e6f70"),
+		machine("demo-1", ""),
+		machine("demo-2", "9a8b7c6d-0000-4000-8000-000000000001"),
+	})
+	expected := "5f0e6a4c-1d5e-4b8e-9c1a-2b3c4d5e6f70|9a8b7c6d-0000-4000-8000-000000000001"
+	if strings.Join(uids, "|") != expected {
+		t.Errorf("Expected %s, got %v", expected, uids)
+	}
+	if uids := machineNodeUIDs(nil); len(uids) != 0 {
+		t.Errorf("Expected no UIDs, got %v", uids)
+	}
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	nodes, err := ParseClusterNodes(" a1b2, c3d4 ,e5f6:controlplane,0708:all")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []api.NodeSpec{
+		{Id: "a1b2", Role: api.Controlplane},
+		{Id: "c3d4", Role: api.Worker},
+		{Id: "e5f6", Role: api.Controlplane},
+		{Id: "0708", Role: api.All},
+	}
+	if !reflect.DeepEqual(nodes, expected) {
+		t.Errorf("Expected %v, got %v", expected, nodes)
+	}
+
+	for _, invalid := range []string{"a1b2:master", ":worker"} {
+		if _, err := ParseClusterNodes(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestValidateClusterNodes(t *testing.T) {
+	if err := ValidateClusterNodes([]api.NodeSpec{{Id: "a1b2", Role: api.Worker}}); err == nil {
+		t.Error("Expected an error for a cluster without a control plane node")
+	}
+	if err := ValidateClusterNodes([]api.NodeSpec{{Id: "a1b2", Role: api.All}, {Id: "a1b2", Role: api.Worker}}); err == nil {
+		t.Error("Expected an error for a node listed twice")
+	}
+	if err := ValidateClusterNodes([]api.NodeSpec{{Id: "a1b2", Role: api.Controlplane}, {Id: "c3d4", Role: api.Worker}}); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+func TestMultiNodeCapability(t *testing.T) {
+	t.Setenv(MultiNodeGUIDsEnvVar, "a1b2,c3d4")
+	if r := detectCapability(CapabilityMultiNode); r.available {
+		t.Error("Expected two nodes not to provide the multi-node capability")
+	}
+	t.Setenv(MultiNodeGUIDsEnvVar, "a1b2,c3d4,e5f6")
+	if r := detectCapability(CapabilityMultiNode); !r.available {
+		t.Errorf("Expected three nodes to provide the multi-node capability, got %s", r.reason)
+	}
+}
@@ -12,12 +12,12 @@ import (
 	"net"
 	"net/http"
 	"os"
-	"os/exec"
 	"strings"
-	"text/template"
 
 	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
 	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -27,14 +27,6 @@ const (
 	NodeGUIDEnvVar   = "NODEGUID"
 	ClusterName      = "demo-cluster"
 
-	ClusterOrchFunctionalTest       = "cluster-orch-functional-test"
-	ClusterOrchSmokeTest            = "cluster-orch-smoke-test"
-	ClusterOrchRobustnessTest       = "cluster-orch-robustness-test"
-	ClusterOrchClusterApiAllTest    = "cluster-orch-cluster-api-all-test"
-	ClusterOrchClusterApiSmokeTest  = "cluster-orch-cluster-api-smoke-test"
-	ClusterOrchTemplateApiSmokeTest = "cluster-orch-template-api-smoke-test"
-	ClusterOrchTemplateApiAllTest   = "cluster-orch-template-api-all-test"
-
 	PortForwardAddress           = "0.0.0.0"
 	PortForwardService           = "svc/cluster-manager"
 	PortForwardGatewayService    = "svc/cluster-connect-gateway"
@@ -47,15 +39,36 @@ const (
 	K3sTemplateOnlyVersion = "v0.0.10"
 	K3sTemplateName        = "baseline-k3s-v0.0.10"
 
+	K3sPrivilegedTemplateName = "privileged-k3s-v0.0.10"
+	K3sRestrictedTemplateName = "restricted-k3s-v0.0.10"
+
 	ClusterTemplateURL = "http://127.0.0.1:8080/v2/templates"
 	ClusterCreateURL   = "http://127.0.0.1:8080/v2/clusters"
 
-	ClusterConfigTemplatePath      = "../../configs/cluster-config.json"
-	BaselineClusterTemplatePathK3s = "../../configs/baseline-cluster-template-k3s.json"
+	ClusterConfigTemplatePath        = "../../configs/cluster-config.json"
+	BaselineClusterTemplatePathK3s   = "../../configs/baseline-cluster-template-k3s.json"
+	PrivilegedClusterTemplatePathK3s = "../../configs/privileged-cluster-template-k3s.json"
+	RestrictedClusterTemplatePathK3s = "../../configs/restricted-cluster-template-k3s.json"
+	// TrustedComputeClusterTemplatePathK3s is not shipped with the repo; see TrustedComputeTemplatePathEnvVar.
+	TrustedComputeClusterTemplatePathK3s = "../../configs/trusted-compute-cluster-template-k3s.json"
+
+	// BaselineClusterTemplatePathRke2 is not shipped with the repo; see Rke2TemplatePathEnvVar.
+	BaselineClusterTemplatePathRke2 = "../../configs/baseline-cluster-template-rke2.json"
+
+	// TrustedComputeTemplatePathEnvVar points at the trusted-compute flavored template, when available.
+	TrustedComputeTemplatePathEnvVar = "TRUSTED_COMPUTE_TEMPLATE_PATH"
+	// Rke2TemplatePathEnvVar points at an rke2 cluster template, when available.
+	Rke2TemplatePathEnvVar = "RKE2_TEMPLATE_PATH"
 )
 
 const (
-	TemplateTypeK3sBaseline = "k3s-baseline"
+	TemplateTypeK3sBaseline   = "k3s-baseline"
+	TemplateTypeK3sPrivileged = "k3s-privileged"
+	TemplateTypeK3sRestricted = "k3s-restricted"
+	// TemplateTypeK3sTrustedCompute is read from TrustedComputeTemplatePathEnvVar.
+	TemplateTypeK3sTrustedCompute = "k3s-trusted-compute"
+	// TemplateTypeRke2Baseline is read from Rke2TemplatePathEnvVar.
+	TemplateTypeRke2Baseline = "rke2-baseline"
 	// Add more template types as needed
 )
 
@@ -71,16 +84,20 @@ func GetEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
-// EnsureNamespaceExists ensures that the specified namespace exists in the cluster.
+// EnsureNamespaceExists ensures that the specified namespace exists in the cluster. Without multi-tenancy
+// the project is faked by creating the namespace; with it, the namespace must be a project created
+// through the tenancy API (see CreateProject) and this waits for cluster-manager to set it up.
 func EnsureNamespaceExists(namespace string) error {
-	cmd := exec.Command("kubectl", "get", "namespace", namespace)
-	err := cmd.Run()
+	recordDebugNamespace(namespace)
+	if MultiTenancyEnabled() {
+		return WaitForProjectSetup(namespace, ProjectSetupTimeout)
+	}
+
+	client, err := ManagementKubeClient()
 	if err != nil {
-		// Namespace does not exist, create it
-		cmd = exec.Command("kubectl", "create", "namespace", namespace)
-		return cmd.Run()
+		return err
 	}
-	return nil
+	return client.EnsureNamespace(namespace)
 }
 
 // EnsureTCPPortAvailable fails fast if a local TCP port is already occupied.
@@ -100,31 +117,68 @@ func EnsureTCPPortAvailable(port, purpose string) error {
 	return fmt.Errorf("local TCP port %s is already in use before starting %s; stop stale port-forwards/processes and retry", port, purpose)
 }
 
-// ImportClusterTemplate imports a cluster template into the specified namespace.
-func ImportClusterTemplate(namespace string, templateType string) error {
-	var data []byte
-	var err error
+// readClusterTemplate returns the cluster template definition for the given template type.
+func readClusterTemplate(templateType string) ([]byte, error) {
 	switch templateType {
 	case TemplateTypeK3sBaseline:
-		data, err = os.ReadFile(BaselineClusterTemplatePathK3s)
+		return os.ReadFile(BaselineClusterTemplatePathK3s)
+	case TemplateTypeK3sPrivileged:
+		return os.ReadFile(PrivilegedClusterTemplatePathK3s)
+	case TemplateTypeK3sRestricted:
+		return os.ReadFile(RestrictedClusterTemplatePathK3s)
+	case TemplateTypeK3sTrustedCompute:
+		return os.ReadFile(GetEnv(TrustedComputeTemplatePathEnvVar, TrustedComputeClusterTemplatePathK3s))
+	case TemplateTypeRke2Baseline:
+		return os.ReadFile(GetEnv(Rke2TemplatePathEnvVar, BaselineClusterTemplatePathRke2))
 	default:
-		return fmt.Errorf("unsupported template type: %s", templateType)
+		return nil, fmt.Errorf("unsupported template type: %s", templateType)
 	}
+}
 
+// ClusterTemplateFullName returns the "<name>-<version>" identifier of the template of the given type.
+func ClusterTemplateFullName(templateType string) (string, error) {
+	name, version, err := ClusterTemplateNameVersion(templateType)
+	if err != nil {
+		return "", err
+	}
+	return name + "-" + version, nil
+}
+
+// ClusterTemplateNameVersion returns the name and the version of the template of the given type.
+func ClusterTemplateNameVersion(templateType string) (string, string, error) {
+	data, err := readClusterTemplate(templateType)
+	if err != nil {
+		return "", "", err
+	}
+
+	var templateInfo api.TemplateInfo
+	if err := json.Unmarshal(data, &templateInfo); err != nil {
+		return "", "", fmt.Errorf("failed to parse %s template: %w", templateType, err)
+	}
+	return templateInfo.Name, templateInfo.Version, nil
+}
+
+// ImportClusterTemplate imports a cluster template into the specified namespace.
+func ImportClusterTemplate(namespace string, templateType string) error {
+	data, err := readClusterTemplate(templateType)
 	if err != nil {
 		return err
 	}
+	return ImportClusterTemplateData(namespace, data)
+}
 
+// ImportClusterTemplateData imports the given template definition into the specified namespace.
+func ImportClusterTemplateData(namespace string, data []byte) error {
 	req, err := http.NewRequest("POST", ClusterTemplateURL, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -132,8 +186,7 @@ func ImportClusterTemplate(namespace string, templateType string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusConflict {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to import cluster template: %s", string(body))
+		return readAPIError("import cluster template", resp)
 	}
 
 	return nil
@@ -148,11 +201,11 @@ func GetClusterTemplate(namespace, templateName, templateVersion string) (*api.T
 		return nil, err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -160,8 +213,7 @@ func GetClusterTemplate(namespace, templateName, templateVersion string) (*api.T
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get template: %s", string(body))
+		return nil, readAPIError("get template", resp)
 	}
 
 	var templateInfo api.TemplateInfo
@@ -179,11 +231,11 @@ func GetClusterTemplatesWithFilter(namespace, filter string) (*api.TemplateInfoL
 		return nil, err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -191,8 +243,7 @@ func GetClusterTemplatesWithFilter(namespace, filter string) (*api.TemplateInfoL
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get templates: %s", string(body))
+		return nil, readAPIError("get templates", resp)
 	}
 	var templateInfoList api.TemplateInfoList
 	if err := json.NewDecoder(resp.Body).Decode(&templateInfoList); err != nil {
@@ -209,11 +260,11 @@ func DeleteTemplate(namespace, templateName, templateVersion string) error {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -221,8 +272,7 @@ func DeleteTemplate(namespace, templateName, templateVersion string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete template: %s", string(body))
+		return readAPIError("delete template", resp)
 	}
 
 	return nil
@@ -234,11 +284,11 @@ func DeleteAllTemplate(namespace string) error {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -246,8 +296,7 @@ func DeleteAllTemplate(namespace string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to get templates: %s", string(body))
+		return readAPIError("get templates", resp)
 	}
 	var templateInfoList api.TemplateInfoList
 	if err := json.NewDecoder(resp.Body).Decode(&templateInfoList); err != nil {
@@ -272,11 +321,11 @@ func GetDefaultTemplate(namespace string) (*api.DefaultTemplateInfo, error) {
 		return nil, err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
@@ -284,8 +333,7 @@ func GetDefaultTemplate(namespace string) (*api.DefaultTemplateInfo, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get templates: %s", string(body))
+		return nil, readAPIError("get templates", resp)
 	}
 	var templateInfoList api.TemplateInfoList
 	if err := json.NewDecoder(resp.Body).Decode(&templateInfoList); err != nil {
@@ -315,11 +363,11 @@ func SetDefaultTemplate(namespace, name, version string) error {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -327,8 +375,7 @@ func SetDefaultTemplate(namespace, name, version string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to set default template: %s, code: %v", string(body), resp.StatusCode)
+		return readAPIError("set default template", resp)
 	}
 
 	return nil
@@ -337,77 +384,49 @@ func SetDefaultTemplate(namespace, name, version string) error {
 
 // IsClusterTemplateReady checks if the cluster template is ready.
 func IsClusterTemplateReady(namespace, templateName string) bool {
-	cmd := exec.Command("kubectl", "get", "clustertemplates.edge-orchestrator.intel.com", templateName, "-n", namespace, "-o", "yaml")
-	output, err := cmd.Output()
+	client, err := ManagementKubeClient()
 	if err != nil {
 		return false
 	}
-
-	// Use yq to parse the YAML and check the .status.ready field
-	cmd = exec.Command("yq", "eval", ".status.ready", "-")
-	cmd.Stdin = bytes.NewReader(output)
-	readyOutput, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-
-	// Check if the ready status is true
-	return strings.TrimSpace(string(readyOutput)) == "true"
-}
-
-// CheckLostConnection verifies if ControlPlane reports connection lost.
-func CheckLostConnection(output string) bool {
-	lines := strings.Split(output, "\n")
-	for _, line := range lines {
-		// Skip the header line
-		if strings.Contains(line, "NAME") && strings.Contains(line, "READY") {
-			continue
-		}
-		// Check if the line contains a "False" status in the "READY" column and "ConnectAgentDisconnected" in the "REASON" column
-		fields := strings.Fields(line)
-		if len(fields) > 1 && strings.Contains(fields[0], "ClusterInfrastructure") && fields[3] == "False" && fields[5] == "ConnectAgentDisconnected" {
-			return true
-		}
-	}
-	return false
+	status, err := client.GetClusterTemplateStatus(namespace, templateName)
+	return err == nil && status.Ready
 }
 
 // CreateCluster creates a cluster using the provided configuration.
+// Customizations from the CLUSTER_* environment variables are applied, see ClusterConfigOptionsFromEnv.
+// An empty templateName creates the cluster from the default template of the project.
 func CreateCluster(namespace, nodeGUID, templateName string) error {
-	templateData, err := os.ReadFile(ClusterConfigTemplatePath)
-	if err != nil {
-		return err
-	}
+	return CreateClusterWithOptions(namespace, nodeGUID, templateName, ClusterConfigOptions{})
+}
 
-	tmpl, err := template.New("clusterConfig").Parse(string(templateData))
+// CreateClusterWithOptions creates a cluster with labels, SANs and network CIDRs customized by opts.
+// Explicit options take precedence over the CLUSTER_* environment variables.
+func CreateClusterWithOptions(namespace, nodeGUID, templateName string, opts ClusterConfigOptions) error {
+	return CreateNamedCluster(namespace, ClusterName, nodeGUID, templateName, opts)
+}
+
+// CreateNamedCluster creates a cluster called clusterName, for specs that need more than one cluster.
+func CreateNamedCluster(namespace, clusterName, nodeGUID, templateName string, opts ClusterConfigOptions) error {
+	opts, err := resolveClusterConfigOptions(opts)
 	if err != nil {
 		return err
 	}
 
-	var configBuffer bytes.Buffer
-	err = tmpl.Execute(&configBuffer, struct {
-		ClusterName  string
-		TemplateName string
-		NodeGUID     string
-	}{
-		NodeGUID:     nodeGUID,
-		TemplateName: templateName,
-		ClusterName:  ClusterName,
-	})
+	data, err := RenderClusterConfig(clusterName, nodeGUID, templateName, opts)
 	if err != nil {
 		return err
 	}
 
-	req, err := http.NewRequest("POST", ClusterCreateURL, &configBuffer)
+	req, err := http.NewRequest("POST", ClusterCreateURL, bytes.NewBuffer(data))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -415,18 +434,26 @@ func CreateCluster(namespace, nodeGUID, templateName string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to create cluster: %s", string(body))
+		return readAPIError("create cluster", resp)
+	}
+
+	return finalizeClusterCreation(namespace, clusterName, opts)
+}
+
+// finalizeClusterCreation applies the options that cluster-manager cannot express and unpauses the cluster.
+func finalizeClusterCreation(namespace, clusterName string, opts ClusterConfigOptions) error {
+	if err := applyClusterNetworkOptions(namespace, clusterName, opts); err != nil {
+		return err
 	}
 
 	// Cluster Manager may create clusters with spec.paused=true.
 	// If left paused, ClusterClass topology reconciliation will not create the infra
 	// objects (IntelCluster/IntelMachine), which can later lead to stuck finalizers.
-	if err := UnpauseCluster(namespace, ClusterName); err != nil {
+	if err := UnpauseCluster(namespace, clusterName); err != nil {
 		return err
 	}
 
-	return nil
+	return applyAdditionalSANs(namespace, clusterName, opts.AdditionalSANs)
 }
 
 // UnpauseCluster sets spec.paused=false for a CAPI Cluster.
@@ -444,42 +471,38 @@ func UnpauseCluster(namespace, clusterName string) error {
 		return err
 	}
 
-	cmd := exec.Command("kubectl", "-n", namespace, "patch", "cluster", clusterName, "--type=merge", "-p", `{"spec":{"paused":false}}`)
-	out, err := cmd.CombinedOutput()
+	client, err := ManagementKubeClient()
 	if err != nil {
-		return fmt.Errorf("failed to unpause cluster %s/%s: %w: %s", namespace, clusterName, err, strings.TrimSpace(string(out)))
+		return err
+	}
+	if err := client.PatchCluster(namespace, clusterName, types.MergePatchType, []byte(`{"spec":{"paused":false}}`)); err != nil {
+		return fmt.Errorf("failed to unpause cluster %s/%s: %w", namespace, clusterName, err)
 	}
 	return nil
 }
 
 func removeClusterTopologyVariable(namespace, clusterName, variableName string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
 	// Fetch the current Cluster spec so we can remove by array index.
-	cmd := exec.Command("kubectl", "-n", namespace, "get", "cluster", clusterName, "-o", "json")
-	out, err := cmd.Output()
+	cluster, err := client.GetCluster(namespace, clusterName)
 	if err != nil {
 		// If we can't read the Cluster, preserve the existing behavior by failing.
 		return fmt.Errorf("failed to get cluster %s/%s to remove topology variable %q: %w", namespace, clusterName, variableName, err)
 	}
 
-	type clusterTopologyVariable struct {
+	var variables []struct {
 		Name string `json:"name"`
 	}
-	type clusterSpec struct {
-		Topology struct {
-			Variables []clusterTopologyVariable `json:"variables"`
-		} `json:"topology"`
-	}
-	type cluster struct {
-		Spec clusterSpec `json:"spec"`
-	}
-
-	var c cluster
-	if err := json.Unmarshal(out, &c); err != nil {
-		return fmt.Errorf("failed to parse cluster %s/%s JSON to remove topology variable %q: %w", namespace, clusterName, variableName, err)
+	found, _, _ := unstructured.NestedFieldNoCopy(cluster.Object, "spec", "topology", "variables")
+	if err := fromUnstructured(found, &variables); err != nil {
+		return fmt.Errorf("failed to parse cluster %s/%s to remove topology variable %q: %w", namespace, clusterName, variableName, err)
 	}
 
 	var idxs []int
-	for i, v := range c.Spec.Topology.Variables {
+	for i, v := range variables {
 		if v.Name == variableName {
 			idxs = append(idxs, i)
 		}
@@ -492,30 +515,33 @@ func removeClusterTopologyVariable(namespace, clusterName, variableName string)
 	for i := len(idxs) - 1; i >= 0; i-- {
 		idx := idxs[i]
 		patch := fmt.Sprintf(`[{"op":"remove","path":"/spec/topology/variables/%d"}]`, idx)
-		pcmd := exec.Command("kubectl", "-n", namespace, "patch", "cluster", clusterName, "--type=json", "-p", patch)
-		pout, perr := pcmd.CombinedOutput()
-		if perr != nil {
-			return fmt.Errorf("failed to remove cluster topology variable %q from %s/%s: %w: %s", variableName, namespace, clusterName, perr, strings.TrimSpace(string(pout)))
+		if err := client.PatchCluster(namespace, clusterName, types.JSONPatchType, []byte(patch)); err != nil {
+			return fmt.Errorf("failed to remove cluster topology variable %q from %s/%s: %w", variableName, namespace, clusterName, err)
 		}
 	}
 
 	return nil
 }
 
-// DeleteCluster deletes a cluster by name.
+// DeleteCluster deletes the default test cluster.
 func DeleteCluster(namespace string) error {
-	url := fmt.Sprintf("%s/%s", ClusterCreateURL, ClusterName)
+	return DeleteNamedCluster(namespace, ClusterName)
+}
+
+// DeleteNamedCluster deletes a cluster by name.
+func DeleteNamedCluster(namespace, clusterName string) error {
+	url := fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName)
 
 	req, err := http.NewRequest("DELETE", url, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -523,8 +549,7 @@ func DeleteCluster(namespace string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete cluster: %s", string(body))
+		return readAPIError("delete cluster", resp)
 	}
 
 	return nil
@@ -539,12 +564,12 @@ func DeleteClusterAuthenticated(authContext *auth.TestAuthContext, namespace str
 		return err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", authContext.Token))
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	resp, err := client.Do(req)
 	if err != nil {
 		return err
@@ -552,13 +577,25 @@ func DeleteClusterAuthenticated(authContext *auth.TestAuthContext, namespace str
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete cluster with JWT authentication: %s", string(body))
+		return readAPIError("delete cluster with JWT authentication", resp)
 	}
 
 	return nil
 }
 
+// DeleteNamedClusterAuthenticated deletes a cluster by name, with the token of authContext when it is not nil.
+func DeleteNamedClusterAuthenticated(authContext *auth.TestAuthContext, namespace, clusterName string) error {
+	status, body, err := ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodDelete,
+		fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusNoContent {
+		return NewAPIError("delete cluster "+clusterName, status, []byte(body))
+	}
+	return nil
+}
+
 func GetClusterInfo(namespace, clusterName string) (*http.Response, error) {
 	url := fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName)
 	req, err := http.NewRequest("GET", url, nil)
@@ -566,58 +603,77 @@ func GetClusterInfo(namespace, clusterName string) (*http.Response, error) {
 		return nil, err
 	}
 
-	req.Header.Set("Activeprojectid", namespace)
+	SetNamespaceHeader(req, namespace)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
-	client := &http.Client{}
+	client := NewHTTPClient()
 	return client.Do(req)
 }
 
-// CheckAllComponentsReady verifies if all components in the cluster are ready.
-// Compatible with clusterctl describe output from CAPI ≤v1.10 and v1.11+.
-//
-// CAPI ≤v1.10: second field per row is "True"/"False" (the READY condition).
-// CAPI v1.11+: STATUS column contains "ConditionName: True" or "ConditionName: False".
-// The top-level Cluster row must show a True value before the cluster is considered ready.
-func CheckAllComponentsReady(output string) bool {
-	lines := strings.Split(output, "\n")
-	foundCluster := false
-	for _, line := range lines {
-		// Skip the header line
-		if strings.Contains(line, "NAME") && strings.Contains(line, "READY") {
-			continue
-		}
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
+// GetClusterDetail returns a cluster and its status as reported by cluster-manager.
+func GetClusterDetail(namespace, clusterName string) (*api.ClusterDetailInfo, error) {
+	var cluster api.ClusterDetailInfo
+	if err := getClusterManagerJSON(namespace, fmt.Sprintf("%s/%s", ClusterCreateURL, clusterName), &cluster); err != nil {
+		return nil, fmt.Errorf("failed to get cluster %s: %w", clusterName, err)
+	}
+	return &cluster, nil
+}
 
-		// Any component explicitly reporting False means not ready (covers both
-		// CAPI ≤v1.10 " False" in column 2 and v1.11+ "Condition: False" in STATUS).
-		if strings.Contains(line, ": False") || strings.Contains(line, " False") {
-			return false
-		}
+// ListClusters returns the clusters of the project as reported by cluster-manager.
+func ListClusters(namespace string) ([]api.ClusterInfo, error) {
+	var list api.GetV2Clusters200JSONResponse
+	if err := getClusterManagerJSON(namespace, ClusterCreateURL, &list); err != nil {
+		return nil, fmt.Errorf("failed to list clusters: %w", err)
+	}
+	if list.Clusters == nil {
+		return nil, nil
+	}
+	return *list.Clusters, nil
+}
 
-		// The top-level Cluster object's name begins with "Cluster/" (no tree prefix).
-		// It must have at least one True condition before we consider the cluster ready.
-		fields := strings.Fields(line)
-		if len(fields) > 0 && strings.HasPrefix(fields[0], "Cluster/") {
-			foundCluster = true
-			if !strings.Contains(line, ": True") && !strings.Contains(line, " True") {
-				return false
-			}
-		}
+// GetClustersSummary returns the cluster counts of the project as reported by cluster-manager.
+func GetClustersSummary(namespace string) (*api.ClusterSummary, error) {
+	var summary api.ClusterSummary
+	if err := getClusterManagerJSON(namespace, ClusterCreateURL+"/summary", &summary); err != nil {
+		return nil, fmt.Errorf("failed to get cluster summary: %w", err)
 	}
+	return &summary, nil
+}
 
-	// No top-level Cluster row seen yet — objects haven't been created.
-	return foundCluster
+func getClusterManagerJSON(namespace, url string, out any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	SetNamespaceHeader(req, namespace)
+	req.Header.Set("Accept", "application/json")
+
+	client := NewHTTPClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readAPIError("get "+url, resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
 }
 
-// FetchMetrics fetches the metrics from the /metrics endpoint.
+// FetchMetrics fetches the metrics of the cluster-connect-gateway from GatewayMetricsURL. A status other
+// than 200 is an error, so that an endpoint that moved does not read as metrics without samples.
 func FetchMetrics() (io.ReadCloser, error) {
-	resp, err := http.Get("http://127.0.0.1:8081/metrics")
+	url := GatewayMetricsURL()
+	resp, err := NewHTTPClient().Get(url)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching metrics: %v", err)
+		return nil, fmt.Errorf("error fetching metrics from %s: %v", url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error fetching metrics from %s: status %d", url, resp.StatusCode)
 	}
 	return resp.Body, nil
 }
@@ -642,12 +698,3 @@ func ParseMetrics(metrics io.Reader) (bool, error) {
 
 	return false, nil
 }
-
-func LogCommandOutput(command string, args []string) {
-	cmd := exec.Command(command, args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		fmt.Printf("Error executing command: %v\n", err)
-	}
-	fmt.Printf("Command output:\n%s\n", string(output))
-}
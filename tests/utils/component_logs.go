@@ -0,0 +1,251 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const (
+	// ComponentLogsNamespaceEnvVar selects the namespace the orchestration components run in.
+	ComponentLogsNamespaceEnvVar = "COMPONENT_LOGS_NAMESPACE"
+
+	// maxComponentLogLines bounds the memory used per followed deployment.
+	maxComponentLogLines = 100000
+)
+
+// componentLogDeploymentPrefixes select the deployments whose logs are followed.
+var componentLogDeploymentPrefixes = []string{"cluster-manager", "cluster-connect-gateway", "intel-infra-provider"}
+
+type componentLogLine struct {
+	at   time.Time
+	text string
+}
+
+type componentLogStream struct {
+	deployment string
+	cancel     context.CancelFunc
+	done       chan struct{}
+
+	mu    sync.Mutex
+	lines []componentLogLine
+}
+
+// ComponentLogCollector follows the logs of the orchestration components in the background, so the
+// part relevant to a failure can be written out afterwards.
+type ComponentLogCollector struct {
+	streams []*componentLogStream
+}
+
+// ComponentNamespace returns the namespace the orchestration components run in, COMPONENT_LOGS_NAMESPACE when set.
+func ComponentNamespace() string {
+	return GetEnv(ComponentLogsNamespaceEnvVar, componentReleaseNamespace)
+}
+
+// ComponentDeployments returns the deployments of cluster-manager, the connect gateway and the infra provider
+// in ComponentNamespace.
+func ComponentDeployments() ([]string, error) {
+	namespace := ComponentNamespace()
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := client.ListDeployments(namespace)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+	return componentDeployments(names), nil
+}
+
+// StartComponentLogCollector starts following the logs of every component deployment from now on.
+func StartComponentLogCollector() (*ComponentLogCollector, error) {
+	namespace := ComponentNamespace()
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := ComponentDeployments()
+	if err != nil {
+		return nil, err
+	}
+
+	collector := &ComponentLogCollector{}
+	for _, deployment := range deployments {
+		ctx, cancel := context.WithCancel(context.Background())
+		logs, err := client.FollowDeploymentLogs(ctx, namespace, deployment, time.Second)
+		if err != nil {
+			cancel()
+			collector.Stop()
+			return nil, fmt.Errorf("failed to follow logs of %s: %w", deployment, err)
+		}
+		stream := &componentLogStream{deployment: deployment, cancel: cancel, done: make(chan struct{})}
+		go stream.read(logs)
+		collector.streams = append(collector.streams, stream)
+	}
+	return collector, nil
+}
+
+func componentDeployments(names []string) []string {
+	var deployments []string
+	for _, name := range names {
+		for _, prefix := range componentLogDeploymentPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				deployments = append(deployments, name)
+				break
+			}
+		}
+	}
+	return deployments
+}
+
+func (s *componentLogStream) read(r io.Reader) {
+	defer close(s.done)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line, ok := parseComponentLogLine(scanner.Text())
+		if !ok {
+			continue
+		}
+		s.mu.Lock()
+		if len(s.lines) == maxComponentLogLines {
+			s.lines = s.lines[1:]
+		}
+		s.lines = append(s.lines, line)
+		s.mu.Unlock()
+	}
+}
+
+// parseComponentLogLine splits a KubeClient.DeploymentLogs line into its timestamp and
+// the remaining "[pod/container] message" text.
+func parseComponentLogLine(raw string) (componentLogLine, bool) {
+	prefix := ""
+	rest := raw
+	if strings.HasPrefix(raw, "[") {
+		end := strings.Index(raw, "] ")
+		if end < 0 {
+			return componentLogLine{}, false
+		}
+		prefix, rest = raw[:end+1], raw[end+2:]
+	}
+	ts, message, _ := strings.Cut(rest, " ")
+	at, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return componentLogLine{}, false
+	}
+	return componentLogLine{at: at, text: strings.TrimSpace(prefix + " " + message)}, true
+}
+
+// Stop ends following the logs. It is safe to call on a nil collector.
+func (c *ComponentLogCollector) Stop() {
+	if c == nil {
+		return
+	}
+	for _, stream := range c.streams {
+		stream.cancel()
+	}
+	for _, stream := range c.streams {
+		<-stream.done
+	}
+}
+
+// WriteWindow writes the log lines between from and to into one file per component under dir, with
+// timestamps in the local time and format of the Ginkgo output. It returns the files written.
+func (c *ComponentLogCollector) WriteWindow(dir string, from, to time.Time) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %w", dir, err)
+	}
+
+	var (
+		written []string
+		errs    []error
+	)
+	for _, stream := range c.streams {
+		var b strings.Builder
+		stream.mu.Lock()
+		for _, line := range stream.lines {
+			if line.at.Before(from) || line.at.After(to) {
+				continue
+			}
+			fmt.Fprintf(&b, "%s %s\n", line.at.Local().Format(types.GINKGO_TIME_FORMAT), line.text)
+		}
+		stream.mu.Unlock()
+		if b.Len() == 0 {
+			continue
+		}
+
+		path := filepath.Join(dir, stream.deployment+".log")
+		if err := WriteArtifact(path, []byte(b.String())); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write %s: %w", path, err))
+			continue
+		}
+		written = append(written, path)
+	}
+	return written, errors.Join(errs...)
+}
+
+// RegisterComponentLogCollection follows the component logs during every spec and, when a spec fails,
+// writes the window between its start and the failure to FailureArtifactsDir.
+// diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterComponentLogCollection() bool {
+	ginkgo.BeforeEach(func() {
+		collector, err := StartComponentLogCollector()
+		if err != nil {
+			fmt.Printf("Component logs will not be collected: %v\n", err)
+			return
+		}
+		ginkgo.DeferCleanup(func() {
+			defer collector.Stop()
+			report := ginkgo.CurrentSpecReport()
+			if !report.Failed() {
+				return
+			}
+			// Give the streams a moment to catch up with what happened right before the failure.
+			time.Sleep(2 * time.Second)
+			dir := filepath.Join(FailureArtifactsDir(), "component-logs", specArtifactName(report))
+			files, err := collector.WriteWindow(dir, report.StartTime, time.Now())
+			if err != nil {
+				fmt.Printf("Failed to write some component logs: %v\n", err)
+			}
+			fmt.Printf("Component logs of the failed spec written to %s (%d files)\n", dir, len(files))
+		})
+	})
+	return true
+}
+
+// specArtifactName turns a spec's text into a file system friendly name.
+func specArtifactName(report ginkgo.SpecReport) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, report.LeafNodeText)
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+	name = strings.Trim(name, "-")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+	return name
+}
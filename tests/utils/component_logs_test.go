@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+func TestComponentDeployments(t *testing.T) {
+	names := []string{
+		"cert-manager",
+		"cluster-connect-gateway-controller",
+		"cluster-manager",
+		"cluster-manager-template-controller",
+		"intel-infra-provider-manager",
+		"intel-infra-provider-southbound",
+	}
+	expected := []string{
+		"cluster-connect-gateway-controller",
+		"cluster-manager",
+		"cluster-manager-template-controller",
+		"intel-infra-provider-manager",
+		"intel-infra-provider-southbound",
+	}
+	if got := componentDeployments(names); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestParseComponentLogLine(t *testing.T) {
+	line, ok := parseComponentLogLine("[pod/cluster-manager-abc/cluster-manager] 2026-10-16T10:00:01.123456789Z level=info msg=created")
+	if !ok {
+		t.Fatalf("Expected the line to be parsed")
+	}
+	if want := time.Date(2026, 10, 16, 10, 0, 1, 123456789, time.UTC); !line.at.Equal(want) {
+		t.Errorf("Expected timestamp %v, got %v", want, line.at)
+	}
+	if line.text != "[pod/cluster-manager-abc/cluster-manager] level=info msg=created" {
+		t.Errorf("Unexpected text %q", line.text)
+	}
+
+	if _, ok := parseComponentLogLine("2026-10-16T10:00:01Z plain"); !ok {
+		t.Errorf("Expected a line without prefix to be parsed")
+	}
+	if _, ok := parseComponentLogLine("[pod/x/y] not-a-timestamp message"); ok {
+		t.Errorf("Expected a line without timestamp to be rejected")
+	}
+}
+
+func TestComponentLogCollectorWriteWindow(t *testing.T) {
+	base := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	collector := &ComponentLogCollector{streams: []*componentLogStream{
+		{deployment: "cluster-manager", lines: []componentLogLine{
+			{at: base.Add(-time.Second), text: "before"},
+			{at: base.Add(time.Second), text: "inside"},
+			{at: base.Add(time.Minute), text: "after"},
+		}},
+		{deployment: "cluster-connect-gateway", lines: []componentLogLine{
+			{at: base.Add(time.Hour), text: "after"},
+		}},
+	}}
+
+	dir := t.TempDir()
+	files, err := collector.WriteWindow(dir, base, base.Add(30*time.Second))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(files) != 1 || files[0] != filepath.Join(dir, "cluster-manager.log") {
+		t.Fatalf("Expected only the cluster-manager log to be written, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", files[0], err)
+	}
+	if strings.Count(string(data), "\n") != 1 || !strings.HasSuffix(string(data), " inside\n") {
+		t.Errorf("Expected only the line inside the window, got %q", string(data))
+	}
+}
+
+func TestSpecArtifactName(t *testing.T) {
+	report := ginkgo.SpecReport{LeafNodeText: "should verify that the cluster is fully active!"}
+	if got := specArtifactName(report); got != "should-verify-that-the-cluster-is-fully-active" {
+		t.Errorf("Unexpected artifact name %q", got)
+	}
+}
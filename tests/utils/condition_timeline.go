@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// ConditionTimelineDirEnvVar selects where per-spec condition timelines are written.
+	ConditionTimelineDirEnvVar  = "CONDITION_TIMELINE_DIR"
+	DefaultConditionTimelineDir = "condition-timelines"
+
+	// TimelinePhase and TimelineDeleted are pseudo conditions for status.phase and object deletion.
+	TimelinePhase   = "Phase"
+	TimelineDeleted = "Deleted"
+)
+
+// timelineResources are the objects whose status changes end up in the timeline.
+var timelineResources = []string{
+	"clusters.cluster.x-k8s.io",
+	"machines.cluster.x-k8s.io",
+	"clusterconnects.cluster.edge-orchestrator.intel.com",
+}
+
+// TimelineEntry is one condition change of an object.
+type TimelineEntry struct {
+	// At is when the change was observed; LastTransitionTime is when the controller says it happened.
+	At                 time.Time `json:"at"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
+	Kind               string    `json:"kind"`
+	Object             string    `json:"object"`
+	Condition          string    `json:"condition"`
+	From               string    `json:"from"`
+	To                 string    `json:"to"`
+	Message            string    `json:"message,omitempty"`
+}
+
+func (e TimelineEntry) String() string {
+	from := e.From
+	if from == "" {
+		from = "(none)"
+	}
+	line := fmt.Sprintf("%s %s %s %s: %s -> %s", e.At.Format("15:04:05.000"), e.Kind, e.Object, e.Condition, from, e.To)
+	if e.Message != "" {
+		line += " (" + e.Message + ")"
+	}
+	return line
+}
+
+type timelineEvent struct {
+	Type   string `json:"type"`
+	Object struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Namespace         string    `json:"namespace"`
+			Name              string    `json:"name"`
+			CreationTimestamp time.Time `json:"creationTimestamp"`
+		} `json:"metadata"`
+		Status struct {
+			Phase      string          `json:"phase"`
+			Conditions []capiCondition `json:"conditions"`
+			V1Beta2    struct {
+				Conditions []capiCondition `json:"conditions"`
+			} `json:"v1beta2"`
+		} `json:"status"`
+	} `json:"object"`
+}
+
+// conditionState is what an entry's From/To shows, e.g. "False(WaitingForControlPlane)".
+type conditionState struct {
+	value   string
+	message string
+	at      time.Time
+}
+
+// ConditionTimeline watches Cluster, Machine and ClusterConnect objects and records every change of
+// their conditions and phase.
+type ConditionTimeline struct {
+	start  time.Time
+	cancel context.CancelFunc
+	done   []chan struct{}
+
+	mu      sync.Mutex
+	states  map[string]map[string]conditionState
+	entries []TimelineEntry
+}
+
+// StartConditionTimeline starts watching the timeline resources in all namespaces. Objects that exist
+// already are taken as the baseline; resources whose CRD is not installed are ignored.
+func StartConditionTimeline() (*ConditionTimeline, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &ConditionTimeline{start: time.Now(), cancel: cancel, states: map[string]map[string]conditionState{}}
+	for _, resource := range timelineResources {
+		watcher, err := client.WatchResource(ctx, resource, "")
+		if meta.IsNoMatchError(err) {
+			continue
+		} else if err != nil {
+			t.Stop()
+			return nil, err
+		}
+		done := make(chan struct{})
+		go t.read(ctx, client, resource, watcher, done)
+		t.done = append(t.done, done)
+	}
+	return t, nil
+}
+
+// read observes the events of a watch until ctx ends. A watch the API server ends is started again; the
+// objects come back as ADDED events and only their changes are recorded.
+func (t *ConditionTimeline) read(ctx context.Context, client *KubeClient, resource string, watcher watch.Interface, done chan struct{}) {
+	defer close(done)
+	for {
+		for event := range watcher.ResultChan() {
+			object, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			parsed, err := parseTimelineEvent(event.Type, object)
+			if err != nil {
+				continue
+			}
+			t.observe(parsed, time.Now())
+		}
+		watcher.Stop()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(kubeClientPollInterval):
+		}
+		var err error
+		if watcher, err = client.WatchResource(ctx, resource, ""); err != nil {
+			fmt.Printf("Failed to watch %s again, the condition timeline misses its changes: %v\n", resource, err)
+			return
+		}
+	}
+}
+
+// parseTimelineEvent reads the kind, metadata and status of the object of a watch event.
+func parseTimelineEvent(eventType watch.EventType, object *unstructured.Unstructured) (timelineEvent, error) {
+	event := timelineEvent{Type: string(eventType)}
+	data, err := object.MarshalJSON()
+	if err != nil {
+		return event, err
+	}
+	if err := json.Unmarshal(data, &event.Object); err != nil {
+		return event, fmt.Errorf("failed to parse %s %s: %w", object.GetKind(), object.GetName(), err)
+	}
+	return event, nil
+}
+
+// observe diffs an object against its last seen state and records the changes.
+func (t *ConditionTimeline) observe(event timelineEvent, now time.Time) {
+	obj := event.Object
+	object := obj.Metadata.Name
+	if obj.Metadata.Namespace != "" {
+		object = obj.Metadata.Namespace + "/" + obj.Metadata.Name
+	}
+	key := obj.Kind + " " + object
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, known := t.states[key]
+	if event.Type == "DELETED" {
+		delete(t.states, key)
+		t.entries = append(t.entries, TimelineEntry{At: now, Kind: obj.Kind, Object: object, Condition: TimelineDeleted, From: "False", To: "True"})
+		return
+	}
+
+	current := map[string]conditionState{}
+	if obj.Status.Phase != "" {
+		current[TimelinePhase] = conditionState{value: obj.Status.Phase}
+	}
+	// v1beta2 conditions are prefixed so they do not collide with the v1beta1 conditions of the same type.
+	for prefix, conditions := range map[string][]capiCondition{"": obj.Status.Conditions, "v1beta2/": obj.Status.V1Beta2.Conditions} {
+		for _, c := range conditions {
+			value := c.Status
+			if c.Reason != "" {
+				value += "(" + c.Reason + ")"
+			}
+			current[prefix+c.Type] = conditionState{value: value, message: c.Message, at: c.LastTransitionTime}
+		}
+	}
+	t.states[key] = current
+
+	if !known && obj.Metadata.CreationTimestamp.Before(t.start) {
+		// Baseline of an object that existed before the timeline started.
+		return
+	}
+
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		state := current[name]
+		if old, ok := previous[name]; ok && old.value == state.value {
+			continue
+		}
+		t.entries = append(t.entries, TimelineEntry{
+			At:                 now,
+			LastTransitionTime: state.at,
+			Kind:               obj.Kind,
+			Object:             object,
+			Condition:          name,
+			From:               previous[name].value,
+			To:                 state.value,
+			Message:            state.message,
+		})
+	}
+}
+
+// Stop ends the watches. It is safe to call on a nil timeline.
+func (t *ConditionTimeline) Stop() {
+	if t == nil {
+		return
+	}
+	t.cancel()
+	for _, done := range t.done {
+		<-done
+	}
+	t.done = nil
+}
+
+// Entries returns the recorded changes, oldest first.
+func (t *ConditionTimeline) Entries() []TimelineEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TimelineEntry(nil), t.entries...)
+}
+
+// FirstChange returns the first change of a kind's condition to a value starting with to, e.g.
+// ("Cluster", "Ready", "False") matches "False(SecureTunnelNotEstablished)". It lets robustness specs
+// measure detection times from the controllers' point of view.
+func (t *ConditionTimeline) FirstChange(kind, condition, to string) (TimelineEntry, bool) {
+	for _, entry := range t.Entries() {
+		if entry.Kind == kind && entry.Condition == condition && strings.HasPrefix(entry.To, to) {
+			return entry, true
+		}
+	}
+	return TimelineEntry{}, false
+}
+
+// FirstChangeSince returns the first change of any condition of a kind to a value starting with to that
+// was observed at or after since.
+func (t *ConditionTimeline) FirstChangeSince(kind, to string, since time.Time) (TimelineEntry, bool) {
+	for _, entry := range t.Entries() {
+		if entry.Kind == kind && strings.HasPrefix(entry.To, to) && !entry.At.Before(since) {
+			return entry, true
+		}
+	}
+	return TimelineEntry{}, false
+}
+
+// WriteTimeline writes the timeline as text and JSON to dir under the given name and returns the
+// path of the text file.
+func (t *ConditionTimeline) WriteTimeline(dir, name string) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create timeline directory %s: %w", dir, err)
+	}
+
+	entries := t.Entries()
+	var b strings.Builder
+	for _, entry := range entries {
+		b.WriteString(entry.String())
+		b.WriteString("\n")
+	}
+	path := filepath.Join(dir, name+".txt")
+	if err := WriteArtifact(path, []byte(b.String())); err != nil {
+		return "", fmt.Errorf("failed to write timeline %s: %w", path, err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	jsonPath := filepath.Join(dir, name+".json")
+	if err := WriteArtifact(jsonPath, data); err != nil {
+		return "", fmt.Errorf("failed to write timeline %s: %w", jsonPath, err)
+	}
+	return path, nil
+}
+
+var currentTimeline struct {
+	mu       sync.Mutex
+	timeline *ConditionTimeline
+}
+
+// CurrentConditionTimeline returns the timeline of the running spec, or nil when
+// RegisterConditionTimeline is not used or the watches could not be started.
+func CurrentConditionTimeline() *ConditionTimeline {
+	currentTimeline.mu.Lock()
+	defer currentTimeline.mu.Unlock()
+	return currentTimeline.timeline
+}
+
+// RegisterConditionTimeline records a condition timeline during every spec and writes it to
+// ConditionTimelineDirEnvVar when anything changed. diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterConditionTimeline() bool {
+	ginkgo.BeforeEach(func() {
+		timeline, err := StartConditionTimeline()
+		if err != nil {
+			fmt.Printf("Condition timeline will not be recorded: %v\n", err)
+			return
+		}
+		currentTimeline.mu.Lock()
+		currentTimeline.timeline = timeline
+		currentTimeline.mu.Unlock()
+
+		ginkgo.DeferCleanup(func() {
+			currentTimeline.mu.Lock()
+			currentTimeline.timeline = nil
+			currentTimeline.mu.Unlock()
+
+			timeline.Stop()
+			if len(timeline.Entries()) == 0 {
+				return
+			}
+			path, err := timeline.WriteTimeline(GetEnv(ConditionTimelineDirEnvVar, DefaultConditionTimelineDir), specArtifactName(ginkgo.CurrentSpecReport()))
+			if err != nil {
+				fmt.Printf("Failed to write the condition timeline: %v\n", err)
+				return
+			}
+			fmt.Printf("Condition timeline of the spec written to %s\n", path)
+		})
+	})
+	return true
+}
@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func timelineTestEvent(t *testing.T, raw string) timelineEvent {
+	t.Helper()
+	var event timelineEvent
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		t.Fatalf("Failed to parse event: %v", err)
+	}
+	return event
+}
+
+func TestConditionTimelineRecordsChanges(t *testing.T) {
+	timeline := &ConditionTimeline{start: time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC), states: map[string]map[string]conditionState{}}
+	now := timeline.start.Add(time.Minute)
+
+	// An object from before the start is only the baseline.
+	timeline.observe(timelineTestEvent(t, `{"type":"ADDED","object":{"kind":"Machine","metadata":{"namespace":"ns","name":"old","creationTimestamp":"2026-01-01T00:00:00Z"},
+		"status":{"phase":"Running","conditions":[{"type":"Ready","status":"True"}]}}}`), now)
+	if entries := timeline.Entries(); len(entries) != 0 {
+		t.Fatalf("Expected no entries for the baseline, got %v", entries)
+	}
+
+	timeline.observe(timelineTestEvent(t, `{"type":"ADDED","object":{"kind":"Cluster","metadata":{"namespace":"ns","name":"demo","creationTimestamp":"2026-01-02T03:00:30Z"},
+		"status":{"phase":"Provisioning","conditions":[{"type":"Ready","status":"False","reason":"WaitingForControlPlane"}]}}}`), now)
+	timeline.observe(timelineTestEvent(t, `{"type":"MODIFIED","object":{"kind":"Cluster","metadata":{"namespace":"ns","name":"demo","creationTimestamp":"2026-01-02T03:00:30Z"},
+		"status":{"phase":"Provisioned","conditions":[{"type":"Ready","status":"True","lastTransitionTime":"2026-01-02T03:08:00Z"}]}}}`), now.Add(9*time.Minute))
+	timeline.observe(timelineTestEvent(t, `{"type":"MODIFIED","object":{"kind":"Machine","metadata":{"namespace":"ns","name":"old","creationTimestamp":"2026-01-01T00:00:00Z"},
+		"status":{"phase":"Running","conditions":[{"type":"Ready","status":"False","reason":"NodeNotReady","message":"kubelet stopped"}]}}}`), now.Add(10*time.Minute))
+	timeline.observe(timelineTestEvent(t, `{"type":"DELETED","object":{"kind":"Cluster","metadata":{"namespace":"ns","name":"demo"}}}`), now.Add(11*time.Minute))
+
+	var got []string
+	for _, entry := range timeline.Entries() {
+		got = append(got, strings.SplitN(entry.String(), " ", 2)[1])
+	}
+	expected := []string{
+		"Cluster ns/demo Phase: (none) -> Provisioning",
+		"Cluster ns/demo Ready: (none) -> False(WaitingForControlPlane)",
+		"Cluster ns/demo Phase: Provisioning -> Provisioned",
+		"Cluster ns/demo Ready: False(WaitingForControlPlane) -> True",
+		"Machine ns/old Ready: True -> False(NodeNotReady) (kubelet stopped)",
+		"Cluster ns/demo Deleted: False -> True",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected timeline:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	ready, ok := timeline.FirstChange("Cluster", "Ready", "True")
+	if !ok || ready.LastTransitionTime.Sub(timeline.start) != 8*time.Minute {
+		t.Errorf("Expected the Ready transition at +8m, got %+v", ready)
+	}
+	if _, ok := timeline.FirstChange("Machine", "Ready", "Unknown"); ok {
+		t.Errorf("Expected no Unknown Ready condition on the machine")
+	}
+
+	if entry, ok := timeline.FirstChangeSince("Cluster", "True", now.Add(10*time.Minute)); !ok || entry.Condition != "Deleted" {
+		t.Errorf("Expected the deletion to be the first Cluster change to True since +10m, got %+v", entry)
+	}
+	if _, ok := timeline.FirstChangeSince("Machine", "False", now.Add(11*time.Minute)); ok {
+		t.Errorf("Expected no Machine change since +11m")
+	}
+}
+
+func TestWriteConditionTimeline(t *testing.T) {
+	timeline := &ConditionTimeline{entries: []TimelineEntry{{At: time.Now(), Kind: "Cluster", Object: "ns/demo", Condition: "Ready", To: "True"}}}
+	dir := t.TempDir()
+	path, err := timeline.WriteTimeline(dir, "spec")
+	if err != nil {
+		t.Fatalf("Failed to write timeline: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "Cluster ns/demo Ready: (none) -> True") {
+		t.Errorf("Expected the text timeline to contain the entry, got %q (%v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "spec.json")); err != nil {
+		t.Errorf("Expected a JSON timeline next to the text one: %v", err)
+	}
+}
+
+func TestParseTimelineEvent(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "cluster.x-k8s.io/v1beta1",
+		"kind":       "Cluster",
+		"metadata":   map[string]interface{}{"namespace": "ns", "name": "demo", "creationTimestamp": "2026-01-02T03:00:30Z"},
+		"status": map[string]interface{}{
+			"phase":      "Provisioned",
+			"conditions": []interface{}{map[string]interface{}{"type": "Ready", "status": "True"}},
+		},
+	}}
+	event, err := parseTimelineEvent(watch.Modified, object)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if event.Type != "MODIFIED" || event.Object.Kind != "Cluster" || event.Object.Metadata.Name != "demo" ||
+		event.Object.Status.Phase != "Provisioned" || len(event.Object.Status.Conditions) != 1 {
+		t.Errorf("Unexpected event %+v", event)
+	}
+}
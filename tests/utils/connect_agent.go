@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ConnectAgentLimitsEnvVar is the comma separated list of cpu/memory limits the connect agent resource
+	// limit spec tries, from the most generous to the tightest (e.g. "200m/128Mi,100m/64Mi").
+	ConnectAgentLimitsEnvVar = "CONNECT_AGENT_LIMITS"
+	// ConnectAgentLimitsReportDirEnvVar selects where the limits report is written; defaults to the working directory.
+	ConnectAgentLimitsReportDirEnvVar = "CONNECT_AGENT_LIMITS_REPORT_DIR"
+	DefaultConnectAgentLimits         = "200m/128Mi,100m/64Mi,50m/32Mi"
+
+	connectAgentResourcesPath = "/spec/template/spec/containers/0/resources"
+)
+
+// edgeNodeKubectl runs kubectl against the cluster from the edge node itself, which works while the
+// connect agent is down. k3s ships kubectl as a subcommand, rke2 as a separate binary.
+const edgeNodeKubectl = `if command -v k3s >/dev/null 2>&1; then KUBECTL="$SUDO k3s kubectl"; ` +
+	`else KUBECTL="$SUDO /var/lib/rancher/rke2/bin/kubectl --kubeconfig /etc/rancher/rke2/rke2.yaml"; fi
+`
+
+// ConnectAgentWorkload is the DaemonSet or Deployment that runs the connect agent in a downstream cluster.
+type ConnectAgentWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+func (w ConnectAgentWorkload) String() string {
+	return fmt.Sprintf("%s %s/%s", w.Kind, w.Namespace, w.Name)
+}
+
+// FindConnectAgentWorkload looks up the connect agent in a downstream cluster. A DaemonSet is preferred
+// over a Deployment; namespace and name are not hard-coded because they vary by environment.
+func FindConnectAgentWorkload(kubeconfigPath string) (ConnectAgentWorkload, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return ConnectAgentWorkload{}, err
+	}
+	for _, kind := range []string{"daemonset", "deployment"} {
+		workloads, err := client.ListWorkloads("", kind)
+		if err != nil {
+			continue
+		}
+		for _, workload := range workloads {
+			if strings.Contains(workload.GetName(), "connect-agent") {
+				return ConnectAgentWorkload{Kind: kind, Namespace: workload.GetNamespace(), Name: workload.GetName()}, nil
+			}
+		}
+	}
+	return ConnectAgentWorkload{}, fmt.Errorf("connect-agent workload not found in downstream cluster")
+}
+
+// AgentResourceLimits are the CPU and memory limits of the connect agent container, e.g. "100m" and "64Mi".
+type AgentResourceLimits struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+func (l AgentResourceLimits) String() string {
+	return l.CPU + "/" + l.Memory
+}
+
+// ConnectAgentLimits returns the limits of CONNECT_AGENT_LIMITS, or the defaults when it is unset or invalid.
+func ConnectAgentLimits() []AgentResourceLimits {
+	if value := os.Getenv(ConnectAgentLimitsEnvVar); value != "" {
+		if limits, err := parseAgentResourceLimits(value); err == nil {
+			return limits
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", ConnectAgentLimitsEnvVar, value)
+	}
+	limits, _ := parseAgentResourceLimits(DefaultConnectAgentLimits)
+	return limits
+}
+
+func parseAgentResourceLimits(value string) ([]AgentResourceLimits, error) {
+	var limits []AgentResourceLimits
+	for _, entry := range strings.Split(value, ",") {
+		cpu, memory, ok := strings.Cut(strings.TrimSpace(entry), "/")
+		if !ok || cpu == "" || memory == "" {
+			return nil, fmt.Errorf("invalid connect agent limits %q, expected cpu/memory", entry)
+		}
+		limits = append(limits, AgentResourceLimits{CPU: cpu, Memory: memory})
+	}
+	return limits, nil
+}
+
+// GetConnectAgentResources returns the resources of the connect agent container as JSON.
+func GetConnectAgentResources(kubeconfigPath string, agent ConnectAgentWorkload) (string, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return "", err
+	}
+	spec, err := client.GetWorkloadSpec(agent.Namespace, agent.Kind, agent.Name)
+	if err != nil {
+		return "", err
+	}
+	if len(spec.Template.Spec.Containers) == 0 {
+		return "", fmt.Errorf("%s has no container", agent)
+	}
+	resources, err := json.Marshal(spec.Template.Spec.Containers[0].Resources)
+	if err != nil {
+		return "", err
+	}
+	return string(resources), nil
+}
+
+// SetConnectAgentLimits sets the requests and limits of the connect agent container to limits, which
+// rolls out new agent pods.
+func SetConnectAgentLimits(kubeconfigPath string, agent ConnectAgentWorkload, limits AgentResourceLimits) error {
+	resources, err := json.Marshal(map[string]AgentResourceLimits{"limits": limits, "requests": limits})
+	if err != nil {
+		return err
+	}
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.PatchWorkload(agent.Namespace, agent.Kind, agent.Name, types.JSONPatchType,
+		[]byte(connectAgentResourcesPatch(string(resources))))
+}
+
+// RestoreConnectAgentResources sets the resources of the connect agent container back to the JSON returned
+// by GetConnectAgentResources. When the agent is too starved to relay the request, the workload is patched
+// from the edge node instead.
+func RestoreConnectAgentResources(kubeconfigPath string, agent ConnectAgentWorkload, resources string) error {
+	patch := connectAgentResourcesPatch(resources)
+	client, err := NewKubeClient(kubeconfigPath)
+	if err == nil {
+		if err = client.PatchWorkload(agent.Namespace, agent.Kind, agent.Name, types.JSONPatchType, []byte(patch)); err == nil {
+			return nil
+		}
+	}
+	fmt.Printf("Failed to restore the connect agent resources through the gateway, patching from the edge node: %v\n", err)
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeKubectl +
+		fmt.Sprintf("$KUBECTL -n %s patch %s %s --type=json -p '%s'", agent.Namespace, agent.Kind, agent.Name, patch)); err != nil {
+		return fmt.Errorf("failed to restore the resources of %s: %w", agent, err)
+	}
+	return nil
+}
+
+// connectAgentResourcesPatch returns a JSON patch that sets the resources of the first container. "add"
+// replaces the field, whether or not it is set.
+func connectAgentResourcesPatch(resources string) string {
+	return fmt.Sprintf(`[{"op":"add","path":"%s","value":%s}]`, connectAgentResourcesPath, resources)
+}
+
+// ConnectAgentPodStatus is what happened to the pods of the connect agent.
+type ConnectAgentPodStatus struct {
+	Pods      int  `json:"pods"`
+	Ready     int  `json:"ready"`
+	Restarts  int  `json:"restarts"`
+	OOMKilled bool `json:"oomKilled"`
+}
+
+// GetConnectAgentPodStatus returns the restarts and OOM kills of the pods of the connect agent.
+func GetConnectAgentPodStatus(kubeconfigPath string, agent ConnectAgentWorkload) (ConnectAgentPodStatus, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return ConnectAgentPodStatus{}, err
+	}
+	pods, err := client.ListPods(agent.Namespace, "")
+	if err != nil {
+		return ConnectAgentPodStatus{}, err
+	}
+	return connectAgentPodStatus(pods, agent.Name), nil
+}
+
+// connectAgentPodStatus sums up the pods whose name starts with the workload name, which holds for the pods
+// of both DaemonSets and Deployments.
+func connectAgentPodStatus(pods []corev1.Pod, workloadName string) ConnectAgentPodStatus {
+	var status ConnectAgentPodStatus
+	for _, pod := range pods {
+		if !strings.HasPrefix(pod.Name, workloadName+"-") {
+			continue
+		}
+		status.Pods++
+		ready := len(pod.Status.ContainerStatuses) > 0
+		for _, container := range pod.Status.ContainerStatuses {
+			ready = ready && container.Ready
+			status.Restarts += int(container.RestartCount)
+			if container.LastTerminationState.Terminated != nil && container.LastTerminationState.Terminated.Reason == "OOMKilled" {
+				status.OOMKilled = true
+			}
+		}
+		if ready {
+			status.Ready++
+		}
+	}
+	return status
+}
+
+// AgentLimitResult is how the connect agent fared under one set of limits.
+type AgentLimitResult struct {
+	Limits AgentResourceLimits `json:"limits"`
+	// Connected is whether the cluster was reachable through the gateway after the load.
+	Connected bool `json:"connected"`
+	// Rollout is how long the agent took to be reachable again after the limits were applied.
+	Rollout   time.Duration         `json:"rolloutNs"`
+	ErrorRate float64               `json:"errorRate"`
+	Pods      ConnectAgentPodStatus `json:"pods"`
+	Error     string                `json:"error,omitempty"`
+}
+
+// AgentLimitsReport is the outcome of the connect agent resource limit spec.
+type AgentLimitsReport struct {
+	Sessions int                `json:"sessions"`
+	Duration time.Duration      `json:"durationNs"`
+	Results  []AgentLimitResult `json:"results"`
+	// MinimumViable is the tightest limits under which the agent stayed connected.
+	MinimumViable *AgentResourceLimits `json:"minimumViable,omitempty"`
+}
+
+// Add records a result and updates the minimum viable limits. Results are added from the most generous
+// limits to the tightest, so the last connected one is the minimum.
+func (r *AgentLimitsReport) Add(result AgentLimitResult) {
+	r.Results = append(r.Results, result)
+	if result.Connected {
+		limits := result.Limits
+		r.MinimumViable = &limits
+	}
+}
+
+// Print writes a human readable summary of the report to stdout.
+func (r *AgentLimitsReport) Print() {
+	fmt.Printf("Connect agent limits under %d sessions for %v:\n", r.Sessions, r.Duration)
+	for _, result := range r.Results {
+		fmt.Printf("  %-12s connected=%t rollout=%v errors=%.2f%% restarts=%d oomKilled=%t %s\n", result.Limits, result.Connected,
+			result.Rollout.Round(time.Second), 100*result.ErrorRate, result.Pods.Restarts, result.Pods.OOMKilled, result.Error)
+	}
+	if r.MinimumViable != nil {
+		fmt.Printf("  minimum viable limits: %s\n", r.MinimumViable)
+	}
+}
+
+// WriteReport writes the report as JSON to CONNECT_AGENT_LIMITS_REPORT_DIR and returns its path.
+func (r *AgentLimitsReport) WriteReport() (string, error) {
+	data, err := MarshalReport(r)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(GetEnv(ConnectAgentLimitsReportDirEnvVar, "."), "connect-agent-limits.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write connect agent limits report %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// WaitForConnectAgentRollout waits until the connect agent rolled out and the cluster is reachable through
+// it again, and returns how long that took.
+func WaitForConnectAgentRollout(kubeconfigPath string, agent ConnectAgentWorkload, timeout time.Duration) (time.Duration, error) {
+	start := time.Now()
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return 0, err
+	}
+	// The requests that follow the rollout go through the agent being replaced; WaitForRollout retries them.
+	if err := client.WaitForRollout(agent.Namespace, agent.Kind, agent.Name, timeout); err != nil {
+		return time.Since(start), err
+	}
+	return time.Since(start), nil
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestParseAgentResourceLimits(t *testing.T) {
+	limits, err := parseAgentResourceLimits("200m/128Mi, 50m/32Mi")
+	if err != nil {
+		t.Fatalf("Failed to parse limits: %v", err)
+	}
+	if len(limits) != 2 || limits[0] != (AgentResourceLimits{CPU: "200m", Memory: "128Mi"}) || limits[1].String() != "50m/32Mi" {
+		t.Errorf("Expected 200m/128Mi and 50m/32Mi, got %v", limits)
+	}
+	for _, invalid := range []string{"200m", "200m/", "/64Mi,100m/64Mi"} {
+		if _, err := parseAgentResourceLimits(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestConnectAgentResourcesPatch(t *testing.T) {
+	patch := connectAgentResourcesPatch(`{"limits":{"cpu":"100m"}}`)
+	expected := `[{"op":"add","path":"/spec/template/spec/containers/0/resources","value":{"limits":{"cpu":"100m"}}}]`
+	if patch != expected {
+		t.Errorf("Expected %s, got %s", expected, patch)
+	}
+}
+
+func TestConnectAgentPodStatus(t *testing.T) {
+	pods := []byte(`{"items":[
+		{"metadata":{"name":"connect-agent-7c9f-abcde"},"status":{"containerStatuses":[{"ready":true,"restartCount":2,"lastState":{"terminated":{"reason":"OOMKilled"}}}]}},
+		{"metadata":{"name":"connect-agent-7c9f-fghij"},"status":{"containerStatuses":[{"ready":false,"restartCount":0,"lastState":{}}]}},
+		{"metadata":{"name":"coredns-5d78c9869d-xyz12"},"status":{"containerStatuses":[{"ready":true,"restartCount":7,"lastState":{"terminated":{"reason":"OOMKilled"}}}]}}
+	]}`)
+	var list corev1.PodList
+	if err := json.Unmarshal(pods, &list); err != nil {
+		t.Fatalf("Failed to parse pods: %v", err)
+	}
+	status := connectAgentPodStatus(list.Items, "connect-agent")
+	if status != (ConnectAgentPodStatus{Pods: 2, Ready: 1, Restarts: 2, OOMKilled: true}) {
+		t.Errorf("Expected 2 agent pods, 1 ready, 2 restarts and an OOM kill, got %+v", status)
+	}
+}
+
+func TestAgentLimitsReportMinimumViable(t *testing.T) {
+	report := &AgentLimitsReport{}
+	report.Add(AgentLimitResult{Limits: AgentResourceLimits{CPU: "200m", Memory: "128Mi"}, Connected: true})
+	report.Add(AgentLimitResult{Limits: AgentResourceLimits{CPU: "100m", Memory: "64Mi"}, Connected: true})
+	report.Add(AgentLimitResult{Limits: AgentResourceLimits{CPU: "50m", Memory: "32Mi"}, Connected: false})
+	if report.MinimumViable == nil || report.MinimumViable.String() != "100m/64Mi" {
+		t.Errorf("Expected 100m/64Mi to be the minimum viable limits, got %v", report.MinimumViable)
+	}
+}
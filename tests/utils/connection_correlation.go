@@ -0,0 +1,300 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+const (
+	// ConnectionCorrelationWindowEnvVar is how far apart the gateway metrics, the ClusterConnect probe and the
+	// providerStatus may report the same connection change.
+	ConnectionCorrelationWindowEnvVar  = "CONNECTION_CORRELATION_WINDOW"
+	DefaultConnectionCorrelationWindow = 2 * time.Minute
+
+	// websocketConnectionsSucceeded counts the connect-agent websockets the gateway accepted.
+	websocketConnectionsSucceeded = `websocket_connections_total{status="succeeded"}`
+)
+
+// ConnectionCorrelationWindow returns CONNECTION_CORRELATION_WINDOW, or DefaultConnectionCorrelationWindow.
+func ConnectionCorrelationWindow() time.Duration {
+	if value := os.Getenv(ConnectionCorrelationWindowEnvVar); value != "" {
+		if window, err := time.ParseDuration(value); err == nil && window > 0 {
+			return window
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", ConnectionCorrelationWindowEnvVar, value)
+	}
+	return DefaultConnectionCorrelationWindow
+}
+
+// ConnectionSnapshot is the connection of a cluster as the three sources reported it at one time. A source
+// that could not be read is not Known.
+type ConnectionSnapshot struct {
+	At time.Time
+	// AcceptedWebsockets is the gateway count of accepted connect-agent websockets.
+	AcceptedWebsockets      float64
+	AcceptedWebsocketsKnown bool
+	// ProbeFresh is whether the last successful ClusterConnect probe is recent.
+	ProbeFresh      bool
+	ProbeFreshKnown bool
+	// ProviderIdle is whether the providerStatus of cluster-manager is idle, i.e. the cluster is connected.
+	ProviderIdle      bool
+	ProviderIdleKnown bool
+}
+
+// ConnectionCorrelator samples the gateway websocket metrics, the ClusterConnect probe status and the
+// providerStatus of a cluster at the same times, so that their reports of a connection change can be compared.
+type ConnectionCorrelator struct {
+	namespace          string
+	clusterName        string
+	clusterConnectName string
+	// probeStaleAfter is how old the last successful probe may be and still count as fresh.
+	probeStaleAfter time.Duration
+
+	mu        sync.Mutex
+	snapshots []ConnectionSnapshot
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewConnectionCorrelator creates a correlator for a cluster; a probe older than two probe intervals is stale.
+// Call Start to begin sampling.
+func NewConnectionCorrelator(namespace, clusterName, clusterConnectName string, probeInterval time.Duration) *ConnectionCorrelator {
+	return &ConnectionCorrelator{
+		namespace:          namespace,
+		clusterName:        clusterName,
+		clusterConnectName: clusterConnectName,
+		probeStaleAfter:    2 * probeInterval,
+	}
+}
+
+// Observe takes one snapshot of the three sources.
+func (c *ConnectionCorrelator) Observe() {
+	snapshot := ConnectionSnapshot{At: time.Now()}
+	if accepted, err := acceptedWebsockets(); err == nil {
+		snapshot.AcceptedWebsockets, snapshot.AcceptedWebsocketsKnown = accepted, true
+	}
+	if lastSuccess, err := LastProbeSuccess(c.clusterConnectName); err == nil {
+		snapshot.ProbeFresh = !lastSuccess.IsZero() && snapshot.At.Sub(lastSuccess) <= c.probeStaleAfter
+		snapshot.ProbeFreshKnown = true
+	}
+	if indicator, err := providerStatusIndicator(c.namespace, c.clusterName); err == nil {
+		snapshot.ProviderIdle, snapshot.ProviderIdleKnown = indicator == string(api.STATUSINDICATIONIDLE), true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshots = append(c.snapshots, snapshot)
+}
+
+// Snapshots returns the snapshots taken so far, oldest first.
+func (c *ConnectionCorrelator) Snapshots() []ConnectionSnapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ConnectionSnapshot(nil), c.snapshots...)
+}
+
+// Start samples the sources in the background until Stop is called.
+func (c *ConnectionCorrelator) Start(interval time.Duration) {
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			c.Observe()
+			select {
+			case <-c.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends the sampling and waits for the last snapshot. It is a no-op on a correlator that was not started.
+func (c *ConnectionCorrelator) Stop() {
+	if c == nil || c.stop == nil {
+		return
+	}
+	close(c.stop)
+	<-c.done
+	c.stop = nil
+}
+
+// acceptedWebsockets reads the count of accepted connect-agent websockets from the gateway metrics.
+func acceptedWebsockets() (float64, error) {
+	body, err := FetchMetrics()
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	metrics, err := ParsePrometheusMetrics(body)
+	if err != nil {
+		return 0, err
+	}
+	return metrics[websocketConnectionsSucceeded].Value, nil
+}
+
+// providerStatusIndicator returns the providerStatus indicator cluster-manager reports for a cluster.
+func providerStatusIndicator(namespace, clusterName string) (string, error) {
+	resp, err := GetClusterInfo(namespace, clusterName)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get cluster %s: status %d", clusterName, resp.StatusCode)
+	}
+	var cluster api.ClusterDetailInfo
+	if err := json.NewDecoder(resp.Body).Decode(&cluster); err != nil {
+		return "", err
+	}
+	indicator, _ := statusState(cluster.ProviderStatus, true)
+	return indicator, nil
+}
+
+// ConnectionChange is when each source first reported a connection change, zero when it never did.
+type ConnectionChange struct {
+	GatewayMetrics time.Time
+	ProbeStatus    time.Time
+	ProviderStatus time.Time
+}
+
+func (c ConnectionChange) String() string {
+	at := func(t time.Time, since time.Time) string {
+		if t.IsZero() {
+			return "never"
+		}
+		return t.Sub(since).Round(time.Second).String()
+	}
+	first := c.first()
+	return fmt.Sprintf("gateway metrics %s, ClusterConnect probe %s, providerStatus %s (relative to the first)",
+		at(c.GatewayMetrics, first), at(c.ProbeStatus, first), at(c.ProviderStatus, first))
+}
+
+func (c ConnectionChange) first() time.Time {
+	var first time.Time
+	for _, t := range []time.Time{c.GatewayMetrics, c.ProbeStatus, c.ProviderStatus} {
+		if !t.IsZero() && (first.IsZero() || t.Before(first)) {
+			first = t
+		}
+	}
+	return first
+}
+
+// ConnectionLoss returns when the ClusterConnect probe went stale and the providerStatus left idle after since.
+// The gateway accepts no websocket while the agent is broken, so GatewayMetrics is when the count of accepted
+// websockets increased before both other sources reported the loss, zero if it did not.
+func ConnectionLoss(snapshots []ConnectionSnapshot, since time.Time) ConnectionChange {
+	var change ConnectionChange
+	accepted, acceptedKnown := 0.0, false
+	for _, s := range snapshots {
+		if s.At.Before(since) {
+			continue
+		}
+		if s.ProbeFreshKnown && !s.ProbeFresh && change.ProbeStatus.IsZero() {
+			change.ProbeStatus = s.At
+		}
+		if s.ProviderIdleKnown && !s.ProviderIdle && change.ProviderStatus.IsZero() {
+			change.ProviderStatus = s.At
+		}
+		if s.AcceptedWebsocketsKnown {
+			if acceptedKnown && s.AcceptedWebsockets > accepted && change.GatewayMetrics.IsZero() &&
+				(change.ProbeStatus.IsZero() || change.ProviderStatus.IsZero()) {
+				change.GatewayMetrics = s.At
+			}
+			accepted, acceptedKnown = s.AcceptedWebsockets, true
+		}
+	}
+	return change
+}
+
+// ConnectionRecovery returns when, after since, the gateway accepted a new websocket, the ClusterConnect probe
+// became fresh again and the providerStatus went back to idle.
+func ConnectionRecovery(snapshots []ConnectionSnapshot, since time.Time) ConnectionChange {
+	var change ConnectionChange
+	accepted, acceptedKnown := 0.0, false
+	for _, s := range snapshots {
+		if s.At.Before(since) {
+			continue
+		}
+		if s.ProbeFreshKnown && s.ProbeFresh && change.ProbeStatus.IsZero() {
+			change.ProbeStatus = s.At
+		}
+		if s.ProviderIdleKnown && s.ProviderIdle && change.ProviderStatus.IsZero() {
+			change.ProviderStatus = s.At
+		}
+		if s.AcceptedWebsocketsKnown {
+			if acceptedKnown && s.AcceptedWebsockets > accepted && change.GatewayMetrics.IsZero() {
+				change.GatewayMetrics = s.At
+			}
+			accepted, acceptedKnown = s.AcceptedWebsockets, true
+		}
+	}
+	return change
+}
+
+// LossDisagreements describes how the sources disagree on a connection loss: the probe and the providerStatus
+// have to report it within window of each other, and the gateway may not accept a websocket meanwhile.
+func LossDisagreements(change ConnectionChange, window time.Duration) []string {
+	var problems []string
+	if change.ProbeStatus.IsZero() {
+		problems = append(problems, "the ClusterConnect probe never went stale")
+	}
+	if change.ProviderStatus.IsZero() {
+		problems = append(problems, "the providerStatus never left idle")
+	}
+	if !change.GatewayMetrics.IsZero() {
+		problems = append(problems, "the gateway metrics count a new websocket before the connection loss was reported")
+	}
+	return append(problems, spreadProblems(window, change.ProbeStatus, change.ProviderStatus)...)
+}
+
+// RecoveryDisagreements describes how the sources disagree on a connection recovery: all three have to report it
+// within window of each other.
+func RecoveryDisagreements(change ConnectionChange, window time.Duration) []string {
+	var problems []string
+	if change.GatewayMetrics.IsZero() {
+		problems = append(problems, "the gateway metrics never counted a new websocket")
+	}
+	if change.ProbeStatus.IsZero() {
+		problems = append(problems, "the ClusterConnect probe never became fresh")
+	}
+	if change.ProviderStatus.IsZero() {
+		problems = append(problems, "the providerStatus never went back to idle")
+	}
+	return append(problems, spreadProblems(window, change.GatewayMetrics, change.ProbeStatus, change.ProviderStatus)...)
+}
+
+// spreadProblems reports times that are further apart than window; zero times are ignored.
+func spreadProblems(window time.Duration, times ...time.Time) []string {
+	var first, last time.Time
+	for _, t := range times {
+		if t.IsZero() {
+			continue
+		}
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	if spread := last.Sub(first); !first.IsZero() && spread > window {
+		return []string{fmt.Sprintf("the sources reported the change %v apart, more than %v", spread.Round(time.Second), window)}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// connectionSnapshots returns snapshots every ten seconds from start with every source known.
+func connectionSnapshots(start time.Time, states ...[3]float64) []ConnectionSnapshot {
+	var snapshots []ConnectionSnapshot
+	for i, state := range states {
+		snapshots = append(snapshots, ConnectionSnapshot{
+			At:                 start.Add(time.Duration(i) * 10 * time.Second),
+			AcceptedWebsockets: state[0], AcceptedWebsocketsKnown: true,
+			ProbeFresh: state[1] == 1, ProbeFreshKnown: true,
+			ProviderIdle: state[2] == 1, ProviderIdleKnown: true,
+		})
+	}
+	return snapshots
+}
+
+func TestConnectionLossAgreement(t *testing.T) {
+	start := time.Now()
+	// websockets, probe fresh, provider idle
+	snapshots := connectionSnapshots(start, [3]float64{1, 1, 1}, [3]float64{1, 0, 1}, [3]float64{1, 0, 0}, [3]float64{1, 0, 0})
+
+	change := ConnectionLoss(snapshots, start)
+	if !change.ProbeStatus.Equal(start.Add(10*time.Second)) || !change.ProviderStatus.Equal(start.Add(20*time.Second)) || !change.GatewayMetrics.IsZero() {
+		t.Errorf("Unexpected loss %+v", change)
+	}
+	if problems := LossDisagreements(change, time.Minute); len(problems) != 0 {
+		t.Errorf("Expected the sources to agree, got %v", problems)
+	}
+	if problems := LossDisagreements(change, 5*time.Second); len(problems) != 1 {
+		t.Errorf("Expected the sources to be too far apart, got %v", problems)
+	}
+
+	// A websocket accepted while the agent is broken contradicts the other sources.
+	snapshots = connectionSnapshots(start, [3]float64{1, 1, 1}, [3]float64{2, 0, 1}, [3]float64{2, 0, 0})
+	if problems := LossDisagreements(ConnectionLoss(snapshots, start), time.Minute); len(problems) != 1 {
+		t.Errorf("Expected the accepted websocket to be reported, got %v", problems)
+	}
+
+	snapshots = connectionSnapshots(start, [3]float64{1, 1, 1}, [3]float64{1, 0, 1})
+	if problems := LossDisagreements(ConnectionLoss(snapshots, start), time.Minute); len(problems) != 1 {
+		t.Errorf("Expected the idle providerStatus to be reported, got %v", problems)
+	}
+}
+
+func TestConnectionRecoveryAgreement(t *testing.T) {
+	start := time.Now()
+	snapshots := connectionSnapshots(start, [3]float64{1, 0, 0}, [3]float64{2, 0, 0}, [3]float64{2, 1, 0}, [3]float64{2, 1, 1})
+
+	change := ConnectionRecovery(snapshots, start)
+	if !change.GatewayMetrics.Equal(start.Add(10*time.Second)) || !change.ProviderStatus.Equal(start.Add(30*time.Second)) {
+		t.Errorf("Unexpected recovery %+v", change)
+	}
+	if problems := RecoveryDisagreements(change, time.Minute); len(problems) != 0 {
+		t.Errorf("Expected the sources to agree, got %v", problems)
+	}
+	if problems := RecoveryDisagreements(change, 10*time.Second); len(problems) != 1 {
+		t.Errorf("Expected the sources to be too far apart, got %v", problems)
+	}
+
+	// Snapshots before the recovery started and unknown sources are ignored.
+	snapshots = append(connectionSnapshots(start.Add(-time.Minute), [3]float64{0, 1, 1}), connectionSnapshots(start, [3]float64{1, 0, 0})...)
+	snapshots = append(snapshots, ConnectionSnapshot{At: start.Add(time.Minute)})
+	if problems := RecoveryDisagreements(ConnectionRecovery(snapshots, start), time.Minute); len(problems) != 3 {
+		t.Errorf("Expected no source to report the recovery, got %v", problems)
+	}
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CRDSchemaDir holds the vendored CRDs the tests are written against, relative to a suite directory.
+const CRDSchemaDir = "../testdata/crds"
+
+// crdResource is the resource of CustomResourceDefinitions.
+const crdResource = "customresourcedefinitions.apiextensions.k8s.io"
+
+// ContractCRDs are the CRDs whose schema the tests depend on. A component bump that changes one of them
+// must come with an update of the tests and of the vendored copy.
+var ContractCRDs = []string{
+	"clustertemplates.edge-orchestrator.intel.com",
+	"clusterconnects.cluster.edge-orchestrator.intel.com",
+	"intelmachines.infrastructure.cluster.x-k8s.io",
+}
+
+// CompareCRDSchema compares the schema of an installed CRD against its vendored copy <name>.yaml in dir
+// and returns an error with a diff when they differ. With UPDATE_GOLDEN=true the vendored copy is
+// replaced by the installed schema instead.
+func CompareCRDSchema(dir, name string) error {
+	out, err := managementObjectJSON(crdResource, "", name)
+	if err != nil {
+		return fmt.Errorf("failed to get CRD %s: %w", name, err)
+	}
+	actual, err := CRDContract(out)
+	if err != nil {
+		return fmt.Errorf("installed CRD %s: %w", name, err)
+	}
+
+	path := filepath.Join(dir, name+".yaml")
+	if os.Getenv(UpdateGoldenEnvVar) == "true" {
+		return CompareGolden(dir, name, actual)
+	}
+
+	vendored, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s; run with %s=true to vendor the installed CRD", ErrGoldenMissing, path, UpdateGoldenEnvVar)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read vendored CRD %s: %w", path, err)
+	}
+	expected, err := CRDContract(vendored)
+	if err != nil {
+		return fmt.Errorf("vendored CRD %s: %w", path, err)
+	}
+	if string(expected) == string(actual) {
+		return nil
+	}
+	return fmt.Errorf("installed CRD %s no longer matches %s; adapt the tests to the new schema and run with %s=true to update the vendored copy:\n%s",
+		name, path, UpdateGoldenEnvVar, lineDiff(string(expected), string(actual), goldenDiffContext))
+}
+
+// CRDContract reduces a CRD manifest to the parts the tests rely on: group, kind, scope and the served
+// versions with their schemas. Descriptions and printer columns are dropped as they do not change the contract.
+func CRDContract(manifest []byte) ([]byte, error) {
+	var crd struct {
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Group string `yaml:"group"`
+			Names struct {
+				Kind   string `yaml:"kind"`
+				Plural string `yaml:"plural"`
+			} `yaml:"names"`
+			Scope    string `yaml:"scope"`
+			Versions []struct {
+				Name    string `yaml:"name"`
+				Served  bool   `yaml:"served"`
+				Storage bool   `yaml:"storage"`
+				Schema  struct {
+					OpenAPIV3Schema map[string]any `yaml:"openAPIV3Schema"`
+				} `yaml:"schema"`
+			} `yaml:"versions"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(manifest, &crd); err != nil {
+		return nil, fmt.Errorf("failed to parse CRD: %w", err)
+	}
+	if crd.Spec.Group == "" || len(crd.Spec.Versions) == 0 {
+		return nil, fmt.Errorf("manifest %q is not a CustomResourceDefinition", crd.Metadata.Name)
+	}
+
+	versions := make([]map[string]any, 0, len(crd.Spec.Versions))
+	for _, v := range crd.Spec.Versions {
+		stripSchemaDescriptions(v.Schema.OpenAPIV3Schema)
+		versions = append(versions, map[string]any{
+			"name":    v.Name,
+			"served":  v.Served,
+			"storage": v.Storage,
+			"schema":  v.Schema.OpenAPIV3Schema,
+		})
+	}
+	contract := map[string]any{
+		"name":     crd.Metadata.Name,
+		"group":    crd.Spec.Group,
+		"kind":     crd.Spec.Names.Kind,
+		"plural":   crd.Spec.Names.Plural,
+		"scope":    crd.Spec.Scope,
+		"versions": versions,
+	}
+	data, err := yaml.Marshal(contract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render CRD contract: %w", err)
+	}
+	return data, nil
+}
+
+// stripSchemaDescriptions removes the descriptions of a schema and its subschemas, leaving properties
+// that happen to be named "description" alone.
+func stripSchemaDescriptions(schema map[string]any) {
+	if schema == nil {
+		return
+	}
+	delete(schema, "description")
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		if sub, ok := schema[key].(map[string]any); ok {
+			stripSchemaDescriptions(sub)
+		}
+	}
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		for _, property := range properties {
+			if sub, ok := property.(map[string]any); ok {
+				stripSchemaDescriptions(sub)
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := schema[key].([]any); ok {
+			for _, item := range list {
+				if sub, ok := item.(map[string]any); ok {
+					stripSchemaDescriptions(sub)
+				}
+			}
+		}
+	}
+}
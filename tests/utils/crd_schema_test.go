@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.17.0
+spec:
+  group: example.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    additionalPrinterColumns:
+    - jsonPath: .status.ready
+      name: Ready
+      type: boolean
+    schema:
+      openAPIV3Schema:
+        description: Widget is a test resource.
+        type: object
+        properties:
+          spec:
+            description: WidgetSpec is the desired state.
+            type: object
+            properties:
+              description:
+                description: A free-form description of the widget.
+                type: string
+              sizes:
+                type: array
+                items:
+                  description: A size.
+                  type: integer
+`
+
+func TestCRDContract(t *testing.T) {
+	out, err := CRDContract([]byte(testCRD))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	contract := string(out)
+
+	for _, want := range []string{"group: example.com", "kind: Widget", "scope: Namespaced", "name: v1", "storage: true", "description:\n"} {
+		if !strings.Contains(contract, want) {
+			t.Errorf("Expected contract to contain %q, got:\n%s", want, contract)
+		}
+	}
+	for _, unwanted := range []string{"Widget is a test resource", "free-form", "A size", "Ready", "controller-gen"} {
+		if strings.Contains(contract, unwanted) {
+			t.Errorf("Expected contract not to contain %q, got:\n%s", unwanted, contract)
+		}
+	}
+
+	// Changing a description does not change the contract, changing a type does.
+	same, err := CRDContract([]byte(strings.Replace(testCRD, "A size.", "The size.", 1)))
+	if err != nil || string(same) != contract {
+		t.Errorf("Expected description changes to be ignored, got %v:\n%s", err, same)
+	}
+	changed, err := CRDContract([]byte(strings.Replace(testCRD, "type: integer", "type: string", 1)))
+	if err != nil || string(changed) == contract {
+		t.Errorf("Expected type changes to change the contract, got %v", err)
+	}
+}
+
+func TestCRDContractRejectsOtherManifests(t *testing.T) {
+	if _, err := CRDContract([]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: demo\n")); err == nil {
+		t.Errorf("Expected an error for a manifest that is not a CRD")
+	}
+}
+
+func TestVendoredCRDsParse(t *testing.T) {
+	for _, name := range ContractCRDs {
+		data, err := os.ReadFile(filepath.Join(CRDSchemaDir, name+".yaml"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Expected to read vendored CRD %s, got %v", name, err)
+		}
+		if _, err := CRDContract(data); err != nil {
+			t.Errorf("Expected vendored CRD %s to parse, got %v", name, err)
+		}
+	}
+}
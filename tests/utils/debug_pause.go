@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+const (
+	// DebugPauseOnFailureEnvVar makes a failed spec wait, before any cleanup runs, until it is told to resume so
+	// the broken state can be inspected live.
+	DebugPauseOnFailureEnvVar = "DEBUG_PAUSE_ON_FAILURE"
+	// DebugPauseResumeFileEnvVar is the file whose creation resumes a paused spec.
+	DebugPauseResumeFileEnvVar  = "DEBUG_PAUSE_RESUME_FILE"
+	DefaultDebugPauseResumeFile = "/tmp/cluster-tests-resume"
+
+	debugPausePollInterval = 2 * time.Second
+)
+
+var (
+	debugDetailsMu sync.Mutex
+	// debugPortForwards are the local endpoints of the services port-forwarded by the process.
+	debugPortForwards = map[string]bool{}
+	debugNamespaces   = map[string]bool{}
+	debugKubeconfigs  = map[string]bool{}
+)
+
+// DebugPauseEnabled reports whether failed specs pause before their cleanup, see DebugPauseOnFailureEnvVar.
+func DebugPauseEnabled() bool {
+	return strings.EqualFold(os.Getenv(DebugPauseOnFailureEnvVar), "true")
+}
+
+// RecordDebugKubeconfig adds a kubeconfig written by a suite to the details printed by a paused spec. The
+// kubeconfigs written by the utilities are recorded already.
+func RecordDebugKubeconfig(path string) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	debugDetailsMu.Lock()
+	defer debugDetailsMu.Unlock()
+	debugKubeconfigs[path] = true
+}
+
+func recordDebugPortForward(service, localPort string) {
+	debugDetailsMu.Lock()
+	defer debugDetailsMu.Unlock()
+	debugPortForwards[fmt.Sprintf("http://127.0.0.1:%s -> %s", localPort, service)] = true
+}
+
+func recordDebugNamespace(namespace string) {
+	debugDetailsMu.Lock()
+	defer debugDetailsMu.Unlock()
+	debugNamespaces[namespace] = true
+}
+
+// DebugEnvironment describes what a paused spec leaves to inspect: the port-forward endpoints, the
+// namespaces and the kubeconfigs of the run.
+func DebugEnvironment() string {
+	debugDetailsMu.Lock()
+	defer debugDetailsMu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("Port-forward endpoints:\n")
+	for _, endpoint := range sortedKeys(debugPortForwards) {
+		fmt.Fprintf(&b, "  %s\n", endpoint)
+	}
+	b.WriteString("Namespaces:\n")
+	for _, namespace := range sortedKeys(debugNamespaces) {
+		fmt.Fprintf(&b, "  %s\n", namespace)
+	}
+	b.WriteString("Kubeconfigs:\n")
+	kubeconfigs := map[string]bool{}
+	for path := range debugKubeconfigs {
+		kubeconfigs[path] = true
+	}
+	management := os.Getenv("KUBECONFIG")
+	if management == "" {
+		management = "~/.kube/config"
+	}
+	kubeconfigs[management+" (management cluster)"] = true
+	if target := PivotTargetKubeconfig(); target != "" {
+		kubeconfigs[target+" (pivot target)"] = true
+	}
+	for _, path := range sortedKeys(kubeconfigs) {
+		fmt.Fprintf(&b, "  %s\n", path)
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RegisterDebugPause makes failed specs pause before their cleanup when DEBUG_PAUSE_ON_FAILURE=true. A
+// paused spec prints the environment details and resumes once DEBUG_PAUSE_RESUME_FILE exists or the process
+// gets SIGUSR1. diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterDebugPause() bool {
+	// A top-level JustAfterEach runs right after the spec, before any AfterEach, DeferCleanup or AfterAll.
+	ginkgo.JustAfterEach(func() {
+		report := ginkgo.CurrentSpecReport()
+		if !DebugPauseEnabled() || !report.Failed() {
+			return
+		}
+		resumeFile := GetEnv(DebugPauseResumeFileEnvVar, DefaultDebugPauseResumeFile)
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGUSR1)
+		defer signal.Stop(signals)
+
+		fmt.Printf("\n=== Paused after the failure of %q ===\n%s\n%s", report.FullText(),
+			strings.TrimSpace(report.Failure.Message), DebugEnvironment())
+		fmt.Printf("Resume the cleanup with: touch %s, or kill -USR1 %d\n", resumeFile, os.Getpid())
+		fmt.Printf("Resumed by %s\n", WaitForResume(resumeFile, signals, debugPausePollInterval))
+	})
+	return true
+}
+
+// WaitForResume blocks until resumeFile exists, which it then removes so the next pause waits again, or a
+// signal arrives. It returns what resumed it.
+func WaitForResume(resumeFile string, signals <-chan os.Signal, interval time.Duration) string {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := os.Stat(resumeFile); err == nil {
+			if err := os.Remove(resumeFile); err != nil {
+				fmt.Printf("Failed to remove %s: %v\n", resumeFile, err)
+			}
+			return resumeFile
+		}
+		select {
+		case sig := <-signals:
+			return sig.String()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestWaitForResumeFile(t *testing.T) {
+	resumeFile := filepath.Join(t.TempDir(), "resume")
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = os.WriteFile(resumeFile, nil, 0600)
+	}()
+	if got := WaitForResume(resumeFile, make(chan os.Signal), time.Millisecond); got != resumeFile {
+		t.Errorf("Expected to be resumed by %s, got %s", resumeFile, got)
+	}
+	if _, err := os.Stat(resumeFile); !os.IsNotExist(err) {
+		t.Errorf("Expected the resume file to be removed, got %v", err)
+	}
+}
+
+func TestWaitForResumeSignal(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	signals <- syscall.SIGUSR1
+	if got := WaitForResume(filepath.Join(t.TempDir(), "resume"), signals, time.Hour); got != syscall.SIGUSR1.String() {
+		t.Errorf("Expected to be resumed by SIGUSR1, got %s", got)
+	}
+}
+
+func TestDebugEnvironment(t *testing.T) {
+	t.Setenv("KUBECONFIG", "/tmp/management.yaml")
+	recordDebugPortForward("svc/cluster-manager", "8080")
+	recordDebugNamespace("53cd37b9-66b2-4cc8-b080-3722ed7af64a")
+	RecordDebugKubeconfig("/tmp/downstream.yaml")
+
+	details := DebugEnvironment()
+	for _, want := range []string{
+		"127.0.0.1:8080 -> svc/cluster-manager",
+		"53cd37b9-66b2-4cc8-b080-3722ed7af64a",
+		"/tmp/downstream.yaml",
+		"/tmp/management.yaml (management cluster)",
+	} {
+		if !strings.Contains(details, want) {
+			t.Errorf("Expected %q in:\n%s", want, details)
+		}
+	}
+}
+
+func TestDebugPauseEnabled(t *testing.T) {
+	t.Setenv(DebugPauseOnFailureEnvVar, "TRUE")
+	if !DebugPauseEnabled() {
+		t.Error("Expected the pause to be enabled")
+	}
+	t.Setenv(DebugPauseOnFailureEnvVar, "1")
+	if DebugPauseEnabled() {
+		t.Error("Expected only true to enable the pause")
+	}
+}
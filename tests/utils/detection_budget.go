@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	// DisconnectDetectionBudgetEnvVar is how long ClusterConnect and the providerStatus may take to report a
+	// broken connect agent (e.g. "3m").
+	DisconnectDetectionBudgetEnvVar  = "DISCONNECT_DETECTION_BUDGET"
+	DefaultDisconnectDetectionBudget = 3 * time.Minute
+
+	// ReconnectRecoveryBudgetEnvVar is how long the cluster may take to report the connection again once the
+	// connect agent is fixed (e.g. "5m").
+	ReconnectRecoveryBudgetEnvVar  = "RECONNECT_RECOVERY_BUDGET"
+	DefaultReconnectRecoveryBudget = 5 * time.Minute
+)
+
+// DisconnectDetectionBudget returns DISCONNECT_DETECTION_BUDGET, or the default when it is unset or invalid.
+func DisconnectDetectionBudget() time.Duration {
+	if budget, err := time.ParseDuration(os.Getenv(DisconnectDetectionBudgetEnvVar)); err == nil && budget > 0 {
+		return budget
+	}
+	return DefaultDisconnectDetectionBudget
+}
+
+// ReconnectRecoveryBudget returns RECONNECT_RECOVERY_BUDGET, or the default when it is unset or invalid.
+func ReconnectRecoveryBudget() time.Duration {
+	if budget, err := time.ParseDuration(os.Getenv(ReconnectRecoveryBudgetEnvVar)); err == nil && budget > 0 {
+		return budget
+	}
+	return DefaultReconnectRecoveryBudget
+}
@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diagnostics collects the state of cluster orchestration into one tarball when a spec fails: the logs
+// of cluster-manager, the connect gateway and the infra provider, the CAPI objects, the ClusterConnects, the
+// events and the k3s journal of the edge node. Everything is redacted before it is archived.
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BundleDir is the directory of utils.FailureArtifactsDir the bundles are written to.
+	BundleDir = "diagnostics"
+
+	componentLogLines = 5000
+	bundleTimeFormat  = "20060102T150405Z"
+)
+
+// capiResources are the objects of the clusters written to the bundle as YAML, from all namespaces.
+var capiResources = []string{
+	"clusters.cluster.x-k8s.io",
+	"machines.cluster.x-k8s.io",
+	"kthreescontrolplanes.controlplane.cluster.x-k8s.io",
+	"rke2controlplanes.controlplane.cluster.x-k8s.io",
+	"intelclusters.infrastructure.cluster.x-k8s.io",
+	"intelmachines.infrastructure.cluster.x-k8s.io",
+	"intelmachinebindings.infrastructure.cluster.x-k8s.io",
+	utils.ClusterTemplateResource,
+}
+
+// clusterConnectResource is cluster-scoped, one ClusterConnect per downstream cluster.
+const clusterConnectResource = "clusterconnects.cluster.edge-orchestrator.intel.com"
+
+// bundle holds the files of a diagnostics bundle by their path in the tarball, in the order they were added,
+// and what could not be collected.
+type bundle struct {
+	names  []string
+	files  map[string][]byte
+	errors []string
+}
+
+func newBundle() *bundle {
+	return &bundle{files: map[string][]byte{}}
+}
+
+// add redacts data and adds it as name.
+func (b *bundle) add(name string, data []byte) {
+	if _, ok := b.files[name]; !ok {
+		b.names = append(b.names, name)
+	}
+	b.files[name] = utils.Redact(data)
+}
+
+func (b *bundle) failed(what string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", what, err))
+}
+
+// addResource adds the objects of a resource of all namespaces as YAML, as kubectl get -o yaml lists them.
+func (b *bundle) addResource(client *utils.KubeClient, name, resource string) {
+	list, err := client.ListResource(resource, "", "")
+	if err != nil {
+		b.failed(resource, err)
+		return
+	}
+	data, err := yaml.Marshal(list.UnstructuredContent())
+	if err != nil {
+		b.failed(resource, err)
+		return
+	}
+	b.add(name, data)
+}
+
+// formatEvents returns events as lines of their last time, namespace, type, reason, object and message, the
+// oldest first.
+func formatEvents(events []corev1.Event) []byte {
+	lastSeen := func(event corev1.Event) time.Time {
+		if !event.LastTimestamp.IsZero() {
+			return event.LastTimestamp.Time
+		}
+		return event.EventTime.Time
+	}
+	sort.SliceStable(events, func(i, j int) bool { return lastSeen(events[i]).Before(lastSeen(events[j])) })
+	var out strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&out, "%s %s %s %s %s/%s %s\n", lastSeen(event).UTC().Format(time.RFC3339), event.Namespace,
+			event.Type, event.Reason, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name,
+			strings.TrimSpace(event.Message))
+	}
+	return []byte(out.String())
+}
+
+// CollectClusterOrchDiagnostics writes the diagnostics bundle of the current state of cluster orchestration to
+// a timestamped tarball under utils.FailureArtifactsDir and returns its path. Parts that cannot be collected,
+// e.g. the journal of an edge node that is gone, are listed in errors.txt of the bundle rather than failing it.
+func CollectClusterOrchDiagnostics() (string, error) {
+	b := newBundle()
+	if client, err := utils.ManagementKubeClient(); err != nil {
+		b.failed("management cluster", err)
+	} else {
+		b.collectComponentLogs(client)
+		b.collectClusterObjects(client)
+	}
+	b.collectEdgeNode()
+
+	path := filepath.Join(utils.FailureArtifactsDir(), BundleDir,
+		fmt.Sprintf("cluster-orch-%s.tar.gz", time.Now().UTC().Format(bundleTimeFormat)))
+	if err := b.write(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// collectComponentLogs adds the recent logs of every component deployment as logs/<deployment>.log.
+func (b *bundle) collectComponentLogs(client *utils.KubeClient) {
+	deployments, err := utils.ComponentDeployments()
+	if err != nil {
+		b.failed("component logs", err)
+		return
+	}
+	namespace := utils.ComponentNamespace()
+	for _, deployment := range deployments {
+		logs, err := client.DeploymentLogs(namespace, deployment, componentLogLines)
+		if err != nil {
+			b.failed("logs of "+deployment, err)
+		}
+		if len(logs) > 0 {
+			b.add("logs/"+deployment+".log", logs)
+		}
+	}
+}
+
+// collectClusterObjects adds the CAPI objects, the ClusterConnects and the events of all namespaces.
+func (b *bundle) collectClusterObjects(client *utils.KubeClient) {
+	for _, resource := range capiResources {
+		b.addResource(client, "capi/"+resource+".yaml", resource)
+	}
+	b.addResource(client, "clusterconnects.yaml", clusterConnectResource)
+	events, err := client.ListEvents()
+	if err != nil {
+		b.failed("events", err)
+		return
+	}
+	b.add("events.txt", formatEvents(events))
+}
+
+// collectEdgeNode adds the k3s, cluster-agent and cloud-init logs of the edge node and the pods of the
+// downstream cluster as seen from the node, under edge-node/.
+func (b *bundle) collectEdgeNode() {
+	dir, err := os.MkdirTemp("", "edge-node-logs-")
+	if err != nil {
+		b.failed("edge node logs", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := utils.FetchEdgeNodeBootstrapLogs(dir)
+	if err != nil {
+		b.failed("edge node logs", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			b.failed("edge node logs", err)
+			continue
+		}
+		b.add("edge-node/"+filepath.Base(file), data)
+	}
+
+	out, err := utils.ExecOnEdgeNode(`SUDO=""; if [ "$(id -u)" != "0" ]; then SUDO="sudo -n"; fi; ` +
+		`$SUDO k3s kubectl get pods -A -o wide 2>&1 || $SUDO rke2 kubectl get pods -A -o wide 2>&1`)
+	if err != nil {
+		b.failed("downstream pods", err)
+		return
+	}
+	b.add("edge-node/pods.txt", out)
+}
+
+// write archives the files of the bundle, and errors.txt when something could not be collected, to path.
+func (b *bundle) write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	add := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: now}); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	for _, name := range b.names {
+		if err := add(name, b.files[name]); err != nil {
+			return fmt.Errorf("failed to write %s to %s: %w", name, path, err)
+		}
+	}
+	if len(b.errors) > 0 {
+		if err := add("errors.txt", utils.Redact([]byte(strings.Join(b.errors, "\n")+"\n"))); err != nil {
+			return fmt.Errorf("failed to write errors.txt to %s: %w", path, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// RegisterDiagnosticsBundle collects the diagnostics bundle of every failed spec once it has ended.
+// RegisterSuiteHooks registers it for every suite.
+func RegisterDiagnosticsBundle() bool {
+	ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+		if !report.Failed() {
+			return
+		}
+		path, err := CollectClusterOrchDiagnostics()
+		if err != nil {
+			fmt.Printf("Failed to write the diagnostics bundle of %q: %v\n", report.LeafNodeText, err)
+			return
+		}
+		fmt.Printf("Diagnostics bundle of %q written to %s\n", report.LeafNodeText, path)
+	})
+	return true
+}
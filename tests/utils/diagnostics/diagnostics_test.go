@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("Expected a gzipped tarball, got %v", err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(data)
+	}
+}
+
+func TestBundleWrite(t *testing.T) {
+	b := newBundle()
+	b.add("logs/cluster-manager.log", []byte("Authorization: Bearer abc.def\n"))
+	b.add("events.txt", []byte("the events\n"))
+	b.failed("edge node logs", errors.New("ssh: connect to host 10.0.0.1 port 22: Connection refused"))
+
+	path := filepath.Join(t.TempDir(), BundleDir, "bundle.tar.gz")
+	if err := b.write(path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	files := readBundle(t, path)
+	if len(files) != 3 {
+		t.Errorf("Expected the two files and errors.txt, got %v", files)
+	}
+	if log := files["logs/cluster-manager.log"]; strings.Contains(log, "abc.def") || !strings.Contains(log, "Bearer REDACTED") {
+		t.Errorf("Expected the bearer token to be redacted, got %q", log)
+	}
+	if files["events.txt"] != "the events\n" {
+		t.Errorf("Expected the events, got %q", files["events.txt"])
+	}
+	if !strings.Contains(files["errors.txt"], "edge node logs: ssh: connect to host") {
+		t.Errorf("Expected the collection failure in errors.txt, got %q", files["errors.txt"])
+	}
+}
+
+func TestBundleWithoutErrors(t *testing.T) {
+	b := newBundle()
+	b.add("events.txt", []byte("first\n"))
+	b.add("events.txt", []byte("second\n"))
+
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := b.write(path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	files := readBundle(t, path)
+	if len(files) != 1 || files["events.txt"] != "second\n" {
+		t.Errorf("Expected only the last events.txt, got %v", files)
+	}
+}
+
+func TestFormatEvents(t *testing.T) {
+	at := func(minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2026, 1, 2, 3, minute, 0, 0, time.UTC))
+	}
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}, LastTimestamp: at(5), Type: "Warning", Reason: "Failed",
+			InvolvedObject: corev1.ObjectReference{Kind: "Machine", Name: "demo-0"}, Message: "no node\n"},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}, EventTime: metav1.NewMicroTime(at(1).Time), Type: "Normal", Reason: "Created",
+			InvolvedObject: corev1.ObjectReference{Kind: "Cluster", Name: "demo"}, Message: "created"},
+	}
+	want := "2026-01-02T03:01:00Z ns Normal Created cluster/demo created\n" +
+		"2026-01-02T03:05:00Z ns Warning Failed machine/demo-0 no node\n"
+	if got := string(formatEvents(events)); got != want {
+		t.Errorf("Expected events\n%s, got\n%s", want, got)
+	}
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package diagnostics
+
+import "github.com/open-edge-platform/cluster-tests/tests/utils"
+
+// RegisterSuiteHooks registers the hooks every suite runs: telemetry, component log collection, the diagnostics
+// bundle, the debug pause, HTTP recording, condition timelines, the environment fingerprint, the cluster-manager
+// metrics check and the quarantine report. Call it once from a suite file as
+// `var _ = diagnostics.RegisterSuiteHooks()`; a hook every suite needs is added here rather than to each suite.
+// It lives outside utils because the diagnostics bundle depends on utils.
+func RegisterSuiteHooks() bool {
+	utils.RegisterTelemetry()
+	utils.RegisterComponentLogCollection()
+	RegisterDiagnosticsBundle()
+	utils.RegisterDebugPause()
+	utils.RegisterHTTPRecording()
+	utils.RegisterConditionTimeline()
+	utils.RegisterEnvironmentFingerprint()
+	utils.RegisterClusterManagerMetricsCheck()
+	utils.RegisterQuarantine()
+	return true
+}
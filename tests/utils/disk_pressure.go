@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EdgeNodeDataDir is the data directory of k3s and rke2 on the edge node, where images and pod data live.
+	EdgeNodeDataDir = "/var/lib/rancher"
+	// DiskPressureFreePercent is how much of the data filesystem is left free, below the 10% nodefs and 15%
+	// imagefs eviction thresholds of the kubelet.
+	DiskPressureFreePercent = 5
+
+	diskFillFile          = EdgeNodeDataDir + "/cluster-tests-disk-pressure.fill"
+	diskPressurePIDFile   = "/tmp/cluster-tests-disk-pressure-revert.pid"
+	diskFreePercentScript = "df -Pk " + EdgeNodeDataDir + " | awk 'NR==2 {print $2, $4}'"
+)
+
+// FillEdgeNodeDisk fills the filesystem of the k3s/rke2 data directory on the edge node until only
+// DiskPressureFreePercent of it is free, so the kubelet reports DiskPressure. The edge node deletes the
+// fill file by itself once maxDuration (plus a safety margin) has elapsed, or earlier through FreeEdgeNodeDisk.
+func FillEdgeNodeDisk(maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(diskFillScript(maxDuration + faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to fill the disk of the edge node: %w", err)
+	}
+	return nil
+}
+
+// FreeEdgeNodeDisk undoes FillEdgeNodeDisk.
+func FreeEdgeNodeDisk() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(diskPressurePIDFile) + "\n" + diskFreeCommand()); err != nil {
+		return fmt.Errorf("failed to free the disk of the edge node: %w", err)
+	}
+	return nil
+}
+
+// EdgeNodeDiskFreePercent returns the share of the data filesystem of the edge node that is available.
+func EdgeNodeDiskFreePercent() (int, error) {
+	out, err := ExecOnEdgeNode(diskFreePercentScript)
+	if err != nil {
+		return 0, err
+	}
+	return parseDiskFreePercent(string(out))
+}
+
+// parseDiskFreePercent parses the "<total KiB> <available KiB>" printed by diskFreePercentScript.
+func parseDiskFreePercent(out string) (int, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("unexpected df output %q", strings.TrimSpace(out))
+	}
+	total, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil || total <= 0 {
+		return 0, fmt.Errorf("unexpected filesystem size %q", fields[0])
+	}
+	available, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected available space %q", fields[1])
+	}
+	return int(available * 100 / total), nil
+}
+
+// diskFillScript schedules the revert before filling, so a fill that leaves the node unusable is still undone.
+// fallocate is instant; dd is the fallback for filesystems without it.
+func diskFillScript(revertAfter time.Duration) string {
+	return strings.Join([]string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(diskPressurePIDFile),
+		diskFreeCommand(),
+		fmt.Sprintf("[ -d %[1]s ] || { echo '%[1]s does not exist'; exit 1; }", EdgeNodeDataDir),
+		fmt.Sprintf("set -- $(%s)", diskFreePercentScript),
+		fmt.Sprintf("fill=$(( $2 - $1 * %d / 100 ))", DiskPressureFreePercent),
+		edgeNodeScheduleRevertScript(diskPressurePIDFile, revertAfter, diskFreeCommand()),
+		fmt.Sprintf(`if [ "$fill" -gt 0 ]; then $SUDO fallocate -l "${fill}K" %[1]s 2>/dev/null || $SUDO dd if=/dev/zero of=%[1]s bs=1M count=$(( fill / 1024 )) status=none; fi`, diskFillFile),
+	}, "\n")
+}
+
+// diskFreeCommand is a single line without double quotes or variables other than $SUDO,
+// so it can also run from the background revert timer.
+func diskFreeCommand() string {
+	return fmt.Sprintf("$SUDO rm -f %s; true", diskFillFile)
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiskFillScript(t *testing.T) {
+	script := diskFillScript(12 * time.Minute)
+	for _, want := range []string{
+		"df -Pk /var/lib/rancher",
+		"fill=$(( $2 - $1 * 5 / 100 ))",
+		"fallocate -l \"${fill}K\" /var/lib/rancher/cluster-tests-disk-pressure.fill",
+		"dd if=/dev/zero of=/var/lib/rancher/cluster-tests-disk-pressure.fill",
+		"sleep 720;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in fill script:\n%s", want, script)
+		}
+	}
+	if strings.Index(script, "sleep 720;") > strings.Index(script, "fallocate") {
+		t.Errorf("Expected the revert to be scheduled before the disk is filled:\n%s", script)
+	}
+}
+
+func TestDiskFreeCommand(t *testing.T) {
+	cmd := diskFreeCommand()
+	if strings.Contains(cmd, "\n") || strings.Contains(cmd, `"`) {
+		t.Errorf("Expected a single line restore command without double quotes, got:\n%s", cmd)
+	}
+	if !strings.HasSuffix(cmd, "true") {
+		t.Errorf("Expected restore command to always succeed, got %q", cmd)
+	}
+}
+
+func TestParseDiskFreePercent(t *testing.T) {
+	free, err := parseDiskFreePercent("41152736 2057636\n")
+	if err != nil || free != 4 {
+		t.Errorf("Expected 4%% free, got %d (%v)", free, err)
+	}
+	if _, err := parseDiskFreePercent("df: /var/lib/rancher: No such file or directory"); err == nil {
+		t.Errorf("Expected an error for unexpected df output")
+	}
+}
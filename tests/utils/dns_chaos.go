@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dnsChaosChain      = "CLUSTER-TESTS-DNS"
+	dnsRevertPIDFile   = "/tmp/cluster-tests-dns-revert.pid"
+	dnsResolutionProbe = "getent hosts kubernetes.io >/dev/null 2>&1 && echo resolved || echo failed"
+)
+
+// BreakEdgeNodeDNS rejects all DNS traffic leaving the edge node and its pods, so neither the node
+// resolver nor coredns can resolve names. The edge node restores DNS by itself once maxDuration
+// (plus a safety margin) has elapsed, or earlier through RestoreEdgeNodeDNS.
+func BreakEdgeNodeDNS(maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(dnsBreakScript(maxDuration + faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to break DNS on the edge node: %w", err)
+	}
+	return nil
+}
+
+// RestoreEdgeNodeDNS removes the rules installed by BreakEdgeNodeDNS.
+func RestoreEdgeNodeDNS() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(dnsRevertPIDFile) + "\n" + dnsRestoreCommand()); err != nil {
+		return fmt.Errorf("failed to restore DNS on the edge node: %w", err)
+	}
+	return nil
+}
+
+// EdgeNodeResolvesNames reports whether the edge node can currently resolve a public name.
+func EdgeNodeResolvesNames() (bool, error) {
+	out, err := ExecOnEdgeNode(dnsResolutionProbe)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "resolved", nil
+}
+
+func dnsBreakScript(revertAfter time.Duration) string {
+	lines := []string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(dnsRevertPIDFile),
+		fmt.Sprintf("$SUDO iptables -N %[1]s 2>/dev/null || $SUDO iptables -F %[1]s", dnsChaosChain),
+	}
+	for _, proto := range []string{"udp", "tcp"} {
+		lines = append(lines, fmt.Sprintf("$SUDO iptables -A %s -p %s --dport 53 -j REJECT", dnsChaosChain, proto))
+	}
+	for _, hook := range []string{"OUTPUT", "FORWARD"} {
+		lines = append(lines, fmt.Sprintf("$SUDO iptables -C %[1]s -j %[2]s 2>/dev/null || $SUDO iptables -I %[1]s -j %[2]s", hook, dnsChaosChain))
+	}
+	lines = append(lines, edgeNodeScheduleRevertScript(dnsRevertPIDFile, revertAfter, dnsRestoreCommand()))
+	return strings.Join(lines, "\n")
+}
+
+// dnsRestoreCommand is a single line so it can also run from the background revert timer.
+func dnsRestoreCommand() string {
+	var cmds []string
+	for _, hook := range []string{"OUTPUT", "FORWARD"} {
+		cmds = append(cmds, fmt.Sprintf("while $SUDO iptables -D %s -j %s 2>/dev/null; do :; done", hook, dnsChaosChain))
+	}
+	cmds = append(cmds,
+		fmt.Sprintf("$SUDO iptables -F %s 2>/dev/null", dnsChaosChain),
+		fmt.Sprintf("$SUDO iptables -X %s 2>/dev/null", dnsChaosChain),
+		"true")
+	return strings.Join(cmds, "; ")
+}
@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDNSBreakScript(t *testing.T) {
+	script := dnsBreakScript(7 * time.Minute)
+	for _, want := range []string{
+		"-A CLUSTER-TESTS-DNS -p udp --dport 53 -j REJECT",
+		"-A CLUSTER-TESTS-DNS -p tcp --dport 53 -j REJECT",
+		"-I OUTPUT -j CLUSTER-TESTS-DNS",
+		"-I FORWARD -j CLUSTER-TESTS-DNS",
+		"sleep 420;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in break script:\n%s", want, script)
+		}
+	}
+}
+
+func TestDNSRestoreCommand(t *testing.T) {
+	cmd := dnsRestoreCommand()
+	if strings.Contains(cmd, "\n") {
+		t.Errorf("Expected a single line restore command, got:\n%s", cmd)
+	}
+	if !strings.HasSuffix(cmd, "true") {
+		t.Errorf("Expected restore command to always succeed, got %q", cmd)
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DownstreamKubeconfigOptions selects where GetDownstreamKubeconfig retrieves a kubeconfig from and where it
+// points it at.
+type DownstreamKubeconfigOptions struct {
+	// Namespace is the project of the cluster, DefaultNamespace when empty.
+	Namespace string
+	// Source is KubeconfigSourceAPI, KubeconfigSourceSecret or KubeconfigSourceClusterctl; the API when empty.
+	Source KubeconfigSource
+	// AuthContext authenticates the API request, nil for an unauthenticated one.
+	AuthContext *auth.TestAuthContext
+	// Server replaces the in-cluster connect-gateway address, LocalGatewayKubeconfigServer when empty.
+	Server string
+	// Path is the file the kubeconfig is written to, a new temporary file when empty.
+	Path string
+}
+
+// GetDownstreamKubeconfig retrieves the kubeconfig of a cluster, points it at the locally port-forwarded
+// connect-gateway, writes it and returns its path and the rest.Config of its current context.
+func GetDownstreamKubeconfig(clusterName string, opts DownstreamKubeconfigOptions) (string, *rest.Config, error) {
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+	source := opts.Source
+	if source == "" {
+		source = KubeconfigSourceAPI
+	}
+	server := opts.Server
+	if server == "" {
+		server = LocalGatewayKubeconfigServer
+	}
+
+	data, err := fetchDownstreamKubeconfig(source, opts.AuthContext, namespace, clusterName)
+	if err != nil {
+		return "", nil, err
+	}
+	local, err := pointKubeconfigAt(data, server)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid kubeconfig of cluster %s/%s from %s: %w", namespace, clusterName, source, err)
+	}
+	config, err := clientcmd.RESTConfigFromKubeConfig(local)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid kubeconfig of cluster %s/%s from %s: %w", namespace, clusterName, source, err)
+	}
+
+	path := opts.Path
+	if path == "" {
+		f, err := os.CreateTemp("", clusterName+"-kubeconfig-*.yaml")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create a kubeconfig file: %w", err)
+		}
+		path = f.Name()
+		_ = f.Close()
+	}
+	if err := os.WriteFile(path, local, 0600); err != nil {
+		return "", nil, fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	RecordDebugKubeconfig(path)
+	return path, config, nil
+}
+
+// fetchDownstreamKubeconfig retrieves the kubeconfig of a cluster as the management cluster hands it out,
+// pointed at the in-cluster connect-gateway.
+func fetchDownstreamKubeconfig(source KubeconfigSource, authContext *auth.TestAuthContext, namespace, clusterName string) ([]byte, error) {
+	switch source {
+	case KubeconfigSourceAPI:
+		resp, err := GetClusterKubeconfigFromAPI(authContext, namespace, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, readAPIError("get kubeconfig of "+clusterName, resp)
+		}
+		var info api.KubeconfigInfo
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			return nil, fmt.Errorf("failed to decode the kubeconfig of %s/%s: %w", namespace, clusterName, err)
+		}
+		if info.Kubeconfig == nil || *info.Kubeconfig == "" {
+			return nil, fmt.Errorf("cluster-manager returned no kubeconfig for %s/%s", namespace, clusterName)
+		}
+		return []byte(*info.Kubeconfig), nil
+	case KubeconfigSourceSecret:
+		client, err := ManagementKubeClient()
+		if err != nil {
+			return nil, err
+		}
+		secret, err := client.GetSecret(namespace, clusterName+"-kubeconfig")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the kubeconfig secret of %s/%s: %w", namespace, clusterName, err)
+		}
+		data, ok := secret.Data["value"]
+		if !ok {
+			return nil, fmt.Errorf("the kubeconfig secret of %s/%s has no value", namespace, clusterName)
+		}
+		return data, nil
+	case KubeconfigSourceClusterctl:
+		out, err := CommandOutput(exec.Command("clusterctl", "get", "kubeconfig", clusterName, "--namespace", namespace))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get kubeconfig of cluster %s/%s: %w", namespace, clusterName, err)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unknown kubeconfig source %q", source)
+	}
+}
+
+// pointKubeconfigAt replaces the scheme and host of every cluster of a kubeconfig served by the in-cluster
+// connect-gateway, plain HTTP on PortForwardGatewayRemotePort, with those of server, keeping the
+// /kubernetes/<namespace>-<cluster> path. Clusters served elsewhere are left alone.
+func pointKubeconfigAt(data []byte, server string) ([]byte, error) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	target, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server %q: %w", server, err)
+	}
+	for name, cluster := range config.Clusters {
+		u, err := url.Parse(cluster.Server)
+		if err != nil {
+			return nil, fmt.Errorf("invalid server of cluster %s: %w", name, err)
+		}
+		if u.Scheme != "http" || u.Port() != PortForwardGatewayRemotePort {
+			continue
+		}
+		u.Scheme, u.Host = target.Scheme, target.Host
+		u.Path = strings.TrimSuffix(target.Path, "/") + "/" + strings.TrimPrefix(u.Path, "/")
+		cluster.Server = u.String()
+	}
+	return clientcmd.Write(*config)
+}
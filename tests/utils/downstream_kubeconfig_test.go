@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestPointKubeconfigAt(t *testing.T) {
+	data := []byte(`apiVersion: v1
+kind: Config
+clusters:
+  - name: gateway
+    cluster:
+      server: http://cluster-connect-gateway.default.svc:8080/kubernetes/ns-demo
+  - name: direct
+    cluster:
+      server: https://10.0.0.1:6443
+contexts:
+  - name: demo
+    context:
+      cluster: gateway
+      user: demo
+current-context: demo
+users:
+  - name: demo
+    user:
+      token: not-a-jwt
+`)
+	for _, c := range []struct {
+		server string
+		want   string
+	}{
+		{LocalGatewayKubeconfigServer, "http://127.0.0.1:8081/kubernetes/ns-demo"},
+		{"http://orch-gateway.example:9443/prefix/", "http://orch-gateway.example:9443/prefix/kubernetes/ns-demo"},
+	} {
+		local, err := pointKubeconfigAt(data, c.server)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		config, err := clientcmd.Load(local)
+		if err != nil {
+			t.Fatalf("Expected a valid kubeconfig, got %v", err)
+		}
+		if got := config.Clusters["gateway"].Server; got != c.want {
+			t.Errorf("Expected the gateway cluster at %s, got %s", c.want, got)
+		}
+		if got := config.Clusters["direct"].Server; got != "https://10.0.0.1:6443" {
+			t.Errorf("Expected a cluster outside the gateway to be left alone, got %s", got)
+		}
+		if got := config.AuthInfos["demo"].Token; got != "not-a-jwt" {
+			t.Errorf("Expected the credentials to be kept, got %q", got)
+		}
+		if _, err := clientcmd.RESTConfigFromKubeConfig(local); err != nil {
+			t.Errorf("Expected a rest.Config of the current context, got %v", err)
+		}
+	}
+
+	if _, err := pointKubeconfigAt([]byte("clusters: ["), LocalGatewayKubeconfigServer); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestGetDownstreamKubeconfigUnknownSource(t *testing.T) {
+	if _, _, err := GetDownstreamKubeconfig("demo", DownstreamKubeconfigOptions{Source: "ftp"}); err == nil {
+		t.Error("Expected an error for an unknown kubeconfig source")
+	}
+}
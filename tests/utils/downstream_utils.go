@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LocalGatewayKubeconfigServer is the connect-gateway URL reachable through the local port-forward.
+	LocalGatewayKubeconfigServer = "http://127.0.0.1:" + PortForwardGatewayLocalPort + "/"
+)
+
+// WriteDownstreamKubeconfig fetches the kubeconfig of a cluster with clusterctl, points it at the
+// locally port-forwarded connect-gateway and writes it to path.
+func WriteDownstreamKubeconfig(namespace, clusterName, path string) error {
+	_, _, err := GetDownstreamKubeconfig(clusterName, DownstreamKubeconfigOptions{
+		Namespace: namespace,
+		Source:    KubeconfigSourceClusterctl,
+		Path:      path,
+	})
+	return err
+}
+
+// DownstreamPod is the subset of pod state the tests assert on.
+type DownstreamPod struct {
+	Namespace string
+	Name      string
+	Phase     string
+}
+
+// ListDownstreamPods lists the pods of all namespaces of a downstream cluster.
+func ListDownstreamPods(kubeconfigPath string) ([]DownstreamPod, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	items, err := client.ListPods("", "")
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]DownstreamPod, 0, len(items))
+	for _, pod := range items {
+		pods = append(pods, DownstreamPod{Namespace: pod.Namespace, Name: pod.Name, Phase: string(pod.Status.Phase)})
+	}
+	return pods, nil
+}
+
+// LocalPathProvisionerPod returns the name of a running local-path-provisioner pod, which k3s runs in
+// kube-system, of the cluster of client.
+func LocalPathProvisionerPod(client *KubeClient) (string, error) {
+	pods, err := client.ListPods("kube-system", "app=local-path-provisioner")
+	if err != nil {
+		return "", err
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodRunning {
+			return pod.Name, nil
+		}
+	}
+	return "", errors.New("no running local-path-provisioner pod in kube-system")
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// FailureArtifactsDirEnvVar selects where diagnostics of failed specs are written.
+	FailureArtifactsDirEnvVar  = "FAILURE_ARTIFACTS_DIR"
+	DefaultFailureArtifactsDir = "failure-artifacts"
+
+	edgeNodeLogLines = 2000
+)
+
+type edgeNodeLogSource struct {
+	file    string
+	command string
+}
+
+// edgeNodeLogSources are collected best-effort: a source that does not exist on the node yields an empty log.
+var edgeNodeLogSources = []edgeNodeLogSource{
+	{"k3s.log", fmt.Sprintf("$SUDO journalctl -u k3s -u k3s-agent --no-pager -n %d", edgeNodeLogLines)},
+	{"rke2.log", fmt.Sprintf("$SUDO journalctl -u rke2-server -u rke2-agent --no-pager -n %d", edgeNodeLogLines)},
+	{"cloud-init.log", fmt.Sprintf("$SUDO tail -n %d /var/log/cloud-init-output.log", edgeNodeLogLines)},
+	{"cluster-agent.log", fmt.Sprintf("$SUDO journalctl -u cluster-agent --no-pager -n %d", edgeNodeLogLines)},
+	{"agent-containers.log", fmt.Sprintf(`for id in $($SUDO k3s crictl ps -a -q --name agent 2>/dev/null || $SUDO crictl ps -a -q --name agent); do
+		echo "=== container $id ==="; $SUDO k3s crictl logs --tail %d "$id" 2>&1 || $SUDO crictl logs --tail %d "$id" 2>&1
+	done`, edgeNodeLogLines, edgeNodeLogLines)},
+}
+
+// FailureArtifactsDir returns the directory where diagnostics of failed specs are written.
+func FailureArtifactsDir() string {
+	return GetEnv(FailureArtifactsDirEnvVar, DefaultFailureArtifactsDir)
+}
+
+// FetchEdgeNodeBootstrapLogs collects the k3s/rke2 service logs, cloud-init output and cluster-agent logs
+// from the edge node into dir and returns the files written. It works for every provider reachable
+// through ExecOnEdgeNode; sources missing on the node are skipped.
+func FetchEdgeNodeBootstrapLogs(dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory %s: %w", dir, err)
+	}
+
+	var (
+		written []string
+		errs    []error
+	)
+	for _, source := range edgeNodeLogSources {
+		// Only a failure to reach the node is an error; the source's own failures end up in its log.
+		out, err := ExecOnEdgeNode(`SUDO=""; if [ "$(id -u)" != "0" ]; then SUDO="sudo -n"; fi; { ` + source.command + `; } 2>&1 || true`)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to collect %s: %w", source.file, err))
+			continue
+		}
+		if strings.TrimSpace(string(out)) == "" || strings.Contains(string(out), "-- No entries --") {
+			continue
+		}
+
+		path := filepath.Join(dir, source.file)
+		if err := WriteArtifact(path, out); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write %s: %w", path, err))
+			continue
+		}
+		written = append(written, path)
+	}
+	return written, errors.Join(errs...)
+}
@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EdgeNodeMetricsEnvVar enables sampling the CPU, memory, disk and containers of the edge node while
+	// clusters are created and during the long-running suites.
+	EdgeNodeMetricsEnvVar = "EDGE_NODE_METRICS"
+	// EdgeNodeMetricsIntervalEnvVar is the time between two samples.
+	EdgeNodeMetricsIntervalEnvVar  = "EDGE_NODE_METRICS_INTERVAL"
+	DefaultEdgeNodeMetricsInterval = 15 * time.Second
+	// EdgeNodeMetricsDirEnvVar selects where the series are written; defaults to <FAILURE_ARTIFACTS_DIR>/edge-node-metrics.
+	EdgeNodeMetricsDirEnvVar = "EDGE_NODE_METRICS_DIR"
+)
+
+// edgeNodeMetricsCommand prints two /proc/stat CPU lines a second apart, the memory, the disk usage of the
+// k3s/rke2 data directory, or of / before it exists, and the number of running containers, which is 0
+// until the distribution is installed.
+const edgeNodeMetricsCommand = `SUDO=""; if [ "$(id -u)" != "0" ]; then SUDO="sudo -n"; fi
+head -n1 /proc/stat; sleep 1; head -n1 /proc/stat
+grep -E '^(MemTotal|MemAvailable):' /proc/meminfo
+dir=/var/lib/rancher; [ -d "$dir" ] || dir=/
+df -P -k "$dir" | awk -v dir="$dir" 'NR==2 {print "disk", dir, $2, $3}'
+echo "containers $( { $SUDO k3s crictl ps -q 2>/dev/null || $SUDO crictl ps -q 2>/dev/null; } | grep -c .)"`
+
+// EdgeNodeMetricsEnabled reports whether the edge node should be sampled.
+func EdgeNodeMetricsEnabled() bool {
+	return os.Getenv(EdgeNodeMetricsEnvVar) == "true"
+}
+
+// EdgeNodeMetricsInterval returns the sampling interval from EDGE_NODE_METRICS_INTERVAL or the default.
+func EdgeNodeMetricsInterval() time.Duration {
+	return positiveDurationEnv(EdgeNodeMetricsIntervalEnvVar, DefaultEdgeNodeMetricsInterval)
+}
+
+// EdgeNodeMetricsDir returns the directory the series are written to.
+func EdgeNodeMetricsDir() string {
+	return GetEnv(EdgeNodeMetricsDirEnvVar, filepath.Join(FailureArtifactsDir(), "edge-node-metrics"))
+}
+
+// EdgeNodeSample is the resource usage of the edge node at one point in time. A sample that could not be
+// taken only has its time and error.
+type EdgeNodeSample struct {
+	At time.Time `json:"at"`
+	// CPUPercent is the busy time of all CPUs over the second the sample took, IOWaitPercent the time
+	// they sat idle waiting for I/O.
+	CPUPercent       float64 `json:"cpuPercent"`
+	IOWaitPercent    float64 `json:"ioWaitPercent"`
+	MemoryUsedBytes  int64   `json:"memoryUsedBytes"`
+	MemoryTotalBytes int64   `json:"memoryTotalBytes"`
+	DiskPath         string  `json:"diskPath,omitempty"`
+	DiskUsedBytes    int64   `json:"diskUsedBytes"`
+	DiskTotalBytes   int64   `json:"diskTotalBytes"`
+	Containers       int     `json:"containers"`
+	Error            string  `json:"error,omitempty"`
+}
+
+// DiskPercent returns the used share of the disk.
+func (s EdgeNodeSample) DiskPercent() float64 {
+	if s.DiskTotalBytes == 0 {
+		return 0
+	}
+	return 100 * float64(s.DiskUsedBytes) / float64(s.DiskTotalBytes)
+}
+
+// parseEdgeNodeSample parses the output of edgeNodeMetricsCommand.
+func parseEdgeNodeSample(output []byte, at time.Time) (EdgeNodeSample, error) {
+	sample := EdgeNodeSample{At: at}
+	var (
+		cpu       [][]int64
+		available int64
+	)
+	seen := map[string]bool{}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		values, err := parseInts(fields[1:])
+		switch fields[0] {
+		case "cpu":
+			if err != nil || len(values) < 5 {
+				return sample, fmt.Errorf("malformed CPU line %q", scanner.Text())
+			}
+			cpu = append(cpu, values)
+		case "MemTotal:", "MemAvailable:":
+			// The values are in kB, followed by the unit.
+			if len(values) == 0 {
+				return sample, fmt.Errorf("malformed memory line %q", scanner.Text())
+			}
+			if fields[0] == "MemTotal:" {
+				sample.MemoryTotalBytes = values[0] * 1024
+			} else {
+				available = values[0] * 1024
+			}
+		case "disk":
+			if len(fields) != 4 {
+				return sample, fmt.Errorf("malformed disk line %q", scanner.Text())
+			}
+			values, err = parseInts(fields[2:])
+			if err != nil {
+				return sample, fmt.Errorf("malformed disk line %q", scanner.Text())
+			}
+			sample.DiskPath = fields[1]
+			sample.DiskTotalBytes, sample.DiskUsedBytes = values[0]*1024, values[1]*1024
+		case "containers":
+			if err != nil || len(values) != 1 {
+				return sample, fmt.Errorf("malformed container count %q", scanner.Text())
+			}
+			sample.Containers = int(values[0])
+		default:
+			continue
+		}
+		seen[fields[0]] = true
+	}
+
+	for _, key := range []string{"cpu", "MemTotal:", "MemAvailable:", "disk", "containers"} {
+		if !seen[key] {
+			return sample, fmt.Errorf("no %s in the edge node metrics", strings.TrimSuffix(key, ":"))
+		}
+	}
+	if len(cpu) != 2 {
+		return sample, fmt.Errorf("expected 2 CPU lines in the edge node metrics, got %d", len(cpu))
+	}
+	sample.MemoryUsedBytes = sample.MemoryTotalBytes - available
+	sample.CPUPercent, sample.IOWaitPercent = cpuUsage(cpu[0], cpu[1])
+	return sample, nil
+}
+
+// parseInts parses fields as integers, returning the ones before the first that is not.
+func parseInts(fields []string) ([]int64, error) {
+	values := make([]int64, 0, len(fields))
+	for _, field := range fields {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// cpuUsage returns the busy and iowait percentages between two /proc/stat cpu lines. Only the first 8
+// columns count towards the total, as guest time is already part of user time.
+func cpuUsage(before, after []int64) (float64, float64) {
+	var total int64
+	for i := 0; i < len(after) && i < len(before) && i < 8; i++ {
+		total += after[i] - before[i]
+	}
+	if total <= 0 {
+		return 0, 0
+	}
+	idle := after[3] - before[3]
+	iowait := after[4] - before[4]
+	return 100 * float64(total-idle-iowait) / float64(total), 100 * float64(iowait) / float64(total)
+}
+
+// EdgeNodeSampler samples the resource usage of the edge node through ExecOnEdgeNode, so that slow
+// provisioning can be told apart from a node running out of CPU, memory or disk.
+type EdgeNodeSampler struct {
+	name  string
+	start time.Time
+	exec  func(string) ([]byte, error)
+
+	mu      sync.Mutex
+	samples []EdgeNodeSample
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewEdgeNodeSampler creates a sampler whose series is named after name, e.g. the cluster or the suite,
+// and the time it is created; call Start to begin sampling.
+func NewEdgeNodeSampler(name string) *EdgeNodeSampler {
+	return &EdgeNodeSampler{name: name, start: time.Now(), exec: ExecOnEdgeNode}
+}
+
+// Sample takes one sample. A failure to reach the node is kept in the series as a sample with an error,
+// as the node being unreachable is part of the story.
+func (s *EdgeNodeSampler) Sample() {
+	now := time.Now()
+	out, err := s.exec(edgeNodeMetricsCommand)
+	var sample EdgeNodeSample
+	if err == nil {
+		sample, err = parseEdgeNodeSample(out, now)
+	}
+	if err != nil {
+		sample = EdgeNodeSample{At: now, Error: err.Error()}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, sample)
+}
+
+// Samples returns the samples taken so far, oldest first.
+func (s *EdgeNodeSampler) Samples() []EdgeNodeSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]EdgeNodeSample(nil), s.samples...)
+}
+
+// Start samples the edge node in the background until Stop is called.
+func (s *EdgeNodeSampler) Start(interval time.Duration) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.Sample()
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends background sampling started with Start.
+func (s *EdgeNodeSampler) Stop() {
+	if s == nil || s.stop == nil {
+		return
+	}
+	select {
+	case <-s.done:
+	default:
+		close(s.stop)
+		<-s.done
+	}
+	s.stop = nil
+}
+
+// EdgeNodeMetricsSummary is the peak usage over a series.
+type EdgeNodeMetricsSummary struct {
+	Samples             int     `json:"samples"`
+	Failed              int     `json:"failed"`
+	PeakCPUPercent      float64 `json:"peakCpuPercent"`
+	PeakIOWaitPercent   float64 `json:"peakIoWaitPercent"`
+	PeakMemoryUsedBytes int64   `json:"peakMemoryUsedBytes"`
+	MemoryTotalBytes    int64   `json:"memoryTotalBytes"`
+	PeakDiskPercent     float64 `json:"peakDiskPercent"`
+	PeakContainers      int     `json:"peakContainers"`
+}
+
+func (s EdgeNodeMetricsSummary) String() string {
+	return fmt.Sprintf("%d samples (%d failed), peak CPU %.1f%% (iowait %.1f%%), peak memory %.1f of %.1f MiB, peak disk %.1f%%, peak %d containers",
+		s.Samples, s.Failed, s.PeakCPUPercent, s.PeakIOWaitPercent, float64(s.PeakMemoryUsedBytes)/(1<<20),
+		float64(s.MemoryTotalBytes)/(1<<20), s.PeakDiskPercent, s.PeakContainers)
+}
+
+// Summary returns the peak usage over the samples taken so far.
+func (s *EdgeNodeSampler) Summary() EdgeNodeMetricsSummary {
+	samples := s.Samples()
+	summary := EdgeNodeMetricsSummary{Samples: len(samples)}
+	for _, sample := range samples {
+		if sample.Error != "" {
+			summary.Failed++
+			continue
+		}
+		if sample.CPUPercent > summary.PeakCPUPercent {
+			summary.PeakCPUPercent = sample.CPUPercent
+		}
+		if sample.IOWaitPercent > summary.PeakIOWaitPercent {
+			summary.PeakIOWaitPercent = sample.IOWaitPercent
+		}
+		if sample.MemoryUsedBytes > summary.PeakMemoryUsedBytes {
+			summary.PeakMemoryUsedBytes = sample.MemoryUsedBytes
+			summary.MemoryTotalBytes = sample.MemoryTotalBytes
+		}
+		if sample.DiskPercent() > summary.PeakDiskPercent {
+			summary.PeakDiskPercent = sample.DiskPercent()
+		}
+		if sample.Containers > summary.PeakContainers {
+			summary.PeakContainers = sample.Containers
+		}
+	}
+	return summary
+}
+
+// WriteSeries stores the samples and their summary as a JSON artifact in EdgeNodeMetricsDir and returns its path.
+func (s *EdgeNodeSampler) WriteSeries() (string, error) {
+	series := struct {
+		Name    string                 `json:"name"`
+		Start   time.Time              `json:"start"`
+		Summary EdgeNodeMetricsSummary `json:"summary"`
+		Samples []EdgeNodeSample       `json:"samples"`
+	}{
+		Name:    s.name,
+		Start:   s.start,
+		Summary: s.Summary(),
+		Samples: s.Samples(),
+	}
+
+	data, err := MarshalReport(series)
+	if err != nil {
+		return "", err
+	}
+	dir := EdgeNodeMetricsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create edge node metrics directory %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("edge-node-metrics-%s-%s.json", s.name, s.start.Format("20060102-150405")))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write edge node metrics %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// StopAndReport stops the sampler, prints its summary and writes its series. It is a no-op on a nil
+// sampler, so suites can call it whether or not EDGE_NODE_METRICS is set.
+func (s *EdgeNodeSampler) StopAndReport() {
+	if s == nil {
+		return
+	}
+	s.Stop()
+	fmt.Printf("Edge node resource usage for %s: %v\n", s.name, s.Summary())
+	if path, err := s.WriteSeries(); err != nil {
+		fmt.Printf("Failed to write edge node metrics: %v\n", err)
+	} else {
+		fmt.Printf("Edge node metrics written to %s\n", path)
+	}
+}
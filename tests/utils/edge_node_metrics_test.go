@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const edgeNodeMetricsOutput = `cpu  1000 0 500 8000 500 0 0 0 0 0
+cpu  1100 0 550 8250 600 0 0 0 0 0
+MemTotal:        8000000 kB
+MemAvailable:    6000000 kB
+disk /var/lib/rancher 1000000 250000
+containers 12
+`
+
+func TestParseEdgeNodeSample(t *testing.T) {
+	at := time.Now()
+	sample, err := parseEdgeNodeSample([]byte(edgeNodeMetricsOutput), at)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !sample.At.Equal(at) {
+		t.Errorf("Expected the sample at %v, got %v", at, sample.At)
+	}
+	// 150 busy and 100 iowait jiffies out of 500.
+	if sample.CPUPercent != 30 || sample.IOWaitPercent != 20 {
+		t.Errorf("Expected 30%% CPU and 20%% iowait, got %.1f%% and %.1f%%", sample.CPUPercent, sample.IOWaitPercent)
+	}
+	if sample.MemoryTotalBytes != 8000000*1024 || sample.MemoryUsedBytes != 2000000*1024 {
+		t.Errorf("Expected 2000000 of 8000000 kB of memory used, got %d of %d bytes", sample.MemoryUsedBytes, sample.MemoryTotalBytes)
+	}
+	if sample.DiskPath != "/var/lib/rancher" || sample.DiskPercent() != 25 {
+		t.Errorf("Expected 25%% of /var/lib/rancher used, got %.1f%% of %s", sample.DiskPercent(), sample.DiskPath)
+	}
+	if sample.Containers != 12 {
+		t.Errorf("Expected 12 containers, got %d", sample.Containers)
+	}
+}
+
+func TestParseEdgeNodeSampleRejectsIncompleteOutput(t *testing.T) {
+	for _, line := range []string{"MemAvailable:", "disk", "containers", "cpu  1100"} {
+		var kept []string
+		for _, l := range strings.Split(edgeNodeMetricsOutput, "\n") {
+			if !strings.HasPrefix(l, line) {
+				kept = append(kept, l)
+			}
+		}
+		if _, err := parseEdgeNodeSample([]byte(strings.Join(kept, "\n")), time.Now()); err == nil {
+			t.Errorf("Expected an error without %q", line)
+		}
+	}
+	if _, err := parseEdgeNodeSample([]byte(strings.Replace(edgeNodeMetricsOutput, "containers 12", "containers many", 1)), time.Now()); err == nil {
+		t.Error("Expected an error for a malformed container count")
+	}
+}
+
+func TestEdgeNodeSampler(t *testing.T) {
+	t.Setenv(EdgeNodeMetricsDirEnvVar, t.TempDir())
+	outputs := []string{edgeNodeMetricsOutput, "", strings.Replace(edgeNodeMetricsOutput, "containers 12", "containers 20", 1)}
+	calls := 0
+	sampler := NewEdgeNodeSampler("edge-metrics")
+	sampler.exec = func(command string) ([]byte, error) {
+		defer func() { calls++ }()
+		if calls == 1 {
+			return nil, errors.New("ssh: connection refused")
+		}
+		return []byte(outputs[calls]), nil
+	}
+	for range outputs {
+		sampler.Sample()
+	}
+
+	samples := sampler.Samples()
+	if len(samples) != 3 || samples[1].Error != "ssh: connection refused" {
+		t.Fatalf("Expected 3 samples with the failed one in the middle, got %+v", samples)
+	}
+	summary := sampler.Summary()
+	if summary.Samples != 3 || summary.Failed != 1 || summary.PeakContainers != 20 || summary.PeakCPUPercent != 30 {
+		t.Errorf("Expected 3 samples, 1 failed, 20 containers and 30%% CPU at peak, got %v", summary)
+	}
+
+	path, err := sampler.WriteSeries()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "edge-node-metrics-edge-metrics-" + sampler.start.Format("20060102-150405") + ".json"; filepath.Base(path) != want {
+		t.Errorf("Expected the series to be written to %s, got %s", want, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected the series to be written, got %v", err)
+	}
+	var series struct {
+		Name    string           `json:"name"`
+		Samples []EdgeNodeSample `json:"samples"`
+	}
+	if err := json.Unmarshal(data, &series); err != nil || series.Name != "edge-metrics" || len(series.Samples) != 3 {
+		t.Errorf("Expected the named series of 3 samples, got %s (%v)", data, err)
+	}
+}
+
+func TestEdgeNodeSamplerStopAndReportNil(t *testing.T) {
+	var sampler *EdgeNodeSampler
+	sampler.StopAndReport()
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// EdgeNodeOSEnvVar is the OS the edge node is expected to run, as the ID or ID_LIKE of its
+	// /etc/os-release, e.g. "ubuntu" or "emt". Set per combination by test:EdgeNodeMatrixTest.
+	EdgeNodeOSEnvVar = "EDGE_NODE_OS"
+	// EdgeNodeArchEnvVar is the architecture the edge node is expected to run, "amd64" or "arm64".
+	EdgeNodeArchEnvVar = "EDGE_NODE_ARCH"
+)
+
+// EdgeNodePlatform is the OS and architecture of an edge node.
+type EdgeNodePlatform struct {
+	// OS is the ID of /etc/os-release, and OSLike its ID_LIKE.
+	OS     string
+	OSLike []string
+	// Version is the VERSION_ID of /etc/os-release.
+	Version string
+	Arch    string
+}
+
+func (p EdgeNodePlatform) String() string {
+	if p.Version == "" {
+		return p.OS + "/" + p.Arch
+	}
+	return p.OS + " " + p.Version + "/" + p.Arch
+}
+
+// Matches reports whether the platform runs osID on arch; an empty osID or arch matches any.
+func (p EdgeNodePlatform) Matches(osID, arch string) bool {
+	osID, arch = strings.ToLower(osID), normalizeArch(arch)
+	if osID != "" && osID != p.OS && !slices.Contains(p.OSLike, osID) {
+		return false
+	}
+	return arch == "" || arch == p.Arch
+}
+
+// ExpectedEdgeNodePlatform returns the OS and architecture of EDGE_NODE_OS and EDGE_NODE_ARCH, empty when
+// the run does not expect a particular platform.
+func ExpectedEdgeNodePlatform() (osID, arch string) {
+	return strings.TrimSpace(os.Getenv(EdgeNodeOSEnvVar)), strings.TrimSpace(os.Getenv(EdgeNodeArchEnvVar))
+}
+
+// DetectEdgeNodePlatform reads the OS and architecture of the edge node.
+func DetectEdgeNodePlatform() (EdgeNodePlatform, error) {
+	out, err := ExecOnEdgeNode("cat /etc/os-release; echo ARCH=$(uname -m)")
+	if err != nil {
+		return EdgeNodePlatform{}, fmt.Errorf("failed to read the platform of the edge node: %w", err)
+	}
+	return parseEdgeNodePlatform(string(out))
+}
+
+// parseEdgeNodePlatform reads /etc/os-release followed by an ARCH= line with the output of uname -m.
+func parseEdgeNodePlatform(out string) (EdgeNodePlatform, error) {
+	var platform EdgeNodePlatform
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		value = strings.ToLower(strings.Trim(value, `"'`))
+		switch key {
+		case "ID":
+			platform.OS = value
+		case "ID_LIKE":
+			platform.OSLike = strings.Fields(value)
+		case "VERSION_ID":
+			platform.Version = value
+		case "ARCH":
+			platform.Arch = normalizeArch(value)
+		}
+	}
+	if platform.OS == "" || platform.Arch == "" {
+		return EdgeNodePlatform{}, fmt.Errorf("no OS ID or architecture in %q", strings.TrimSpace(out))
+	}
+	return platform, nil
+}
+
+// normalizeArch returns the Go name of an architecture reported by uname -m.
+func normalizeArch(arch string) string {
+	switch arch = strings.ToLower(strings.TrimSpace(arch)); arch {
+	case "x86_64":
+		return "amd64"
+	case "aarch64", "arm64":
+		return "arm64"
+	}
+	return arch
+}
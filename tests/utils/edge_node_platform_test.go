@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "testing"
+
+func TestParseEdgeNodePlatform(t *testing.T) {
+	platform, err := parseEdgeNodePlatform(`PRETTY_NAME="Ubuntu 24.04.1 LTS"
+NAME="Ubuntu"
+VERSION_ID="24.04"
+ID=ubuntu
+ID_LIKE=debian
+ARCH=aarch64
+`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if platform.String() != "ubuntu 24.04/arm64" {
+		t.Errorf("Expected ubuntu 24.04/arm64, got %s", platform)
+	}
+	if !platform.Matches("ubuntu", "arm64") || !platform.Matches("debian", "aarch64") || !platform.Matches("", "") {
+		t.Errorf("Expected %s to match ubuntu and debian on arm64", platform)
+	}
+	if platform.Matches("emt", "") || platform.Matches("ubuntu", "amd64") {
+		t.Errorf("Expected %s not to match another OS or architecture", platform)
+	}
+
+	if _, err := parseEdgeNodePlatform("ARCH=x86_64\n"); err == nil {
+		t.Error("Expected an error without an OS ID")
+	}
+}
+
+func TestNormalizeArch(t *testing.T) {
+	for arch, expected := range map[string]string{"x86_64": "amd64", "amd64": "amd64", "aarch64": "arm64", "ARM64": "arm64", "riscv64": "riscv64"} {
+		if got := normalizeArch(arch); got != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, arch, got)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 )
 
 const (
@@ -74,7 +75,7 @@ func execOnVEN(shellCommand string) ([]byte, error) {
 		"sh", "-lc", shellCommand,
 	}
 	cmd := exec.Command("ssh", sshArgs...)
-	out, err := cmd.CombinedOutput()
+	out, err := CommandCombinedOutput(cmd)
 	if err != nil {
 		trim := strings.TrimSpace(string(out))
 		if trim == "" {
@@ -84,3 +85,31 @@ func execOnVEN(shellCommand string) ([]byte, error) {
 	}
 	return out, nil
 }
+
+// EdgeNodeHasTPM reports whether the edge node exposes a (possibly emulated) TPM device.
+func EdgeNodeHasTPM() (bool, error) {
+	out, err := ExecOnEdgeNode("if [ -e /dev/tpmrm0 ] || [ -e /dev/tpm0 ]; then echo yes; else echo no; fi")
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "yes", nil
+}
+
+// faultSafetyMargin is added to the requested duration before the edge node removes an injected
+// fault by itself, so a lost SSH session never leaves the node broken.
+const faultSafetyMargin = 2 * time.Minute
+
+// edgeNodeSudoPreamble sets $SUDO for scripts that need root on the edge node.
+const edgeNodeSudoPreamble = `SUDO=""; if [ "$(id -u)" != "0" ]; then SUDO="sudo -n"; fi
+`
+
+// edgeNodeScheduleRevertScript runs revertCommand on the edge node in the background after the given
+// delay, so a fault injected by the tests is undone even when the SSH session or the suite is lost.
+// The timer is recorded in pidFile and can be cancelled with edgeNodeCancelRevertScript.
+func edgeNodeScheduleRevertScript(pidFile string, after time.Duration, revertCommand string) string {
+	return fmt.Sprintf(`nohup sh -c "sleep %d; %s" >/dev/null 2>&1 & echo $! > %s`, int(after.Seconds()), revertCommand, pidFile)
+}
+
+func edgeNodeCancelRevertScript(pidFile string) string {
+	return fmt.Sprintf(`if [ -f %[1]s ]; then kill "$(cat %[1]s)" 2>/dev/null || true; rm -f %[1]s; fi`, pidFile)
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const (
+	// EnvironmentFingerprintDirEnvVar selects where the fingerprint of each suite run is written.
+	EnvironmentFingerprintDirEnvVar = "ENVIRONMENT_FINGERPRINT_DIR"
+
+	// environmentReportKey is the field reports carry their fingerprint in.
+	environmentReportKey = "environment"
+)
+
+// EnvironmentFingerprint is what a run was tested against. Runs with the same Digest ran against the same
+// environment; DiffEnvironmentFingerprints tells what changed otherwise.
+type EnvironmentFingerprint struct {
+	CapturedAt time.Time `json:"capturedAt"`
+	Digest     string    `json:"digest"`
+	// Components are the versions of the orchestration components, see ComponentVersions.
+	Components map[string]string `json:"components"`
+	// KubernetesVersion is the version of the kind management cluster.
+	KubernetesVersion string `json:"kubernetesVersion"`
+	Provider          string `json:"provider"`
+	// AuthMode is "disabled", "enabled" or "enabled (FIPS)".
+	AuthMode string `json:"authMode"`
+}
+
+var (
+	environmentFingerprint     EnvironmentFingerprint
+	environmentFingerprintOnce sync.Once
+)
+
+// CurrentEnvironmentFingerprint returns the fingerprint of the environment, taken once per suite. What cannot
+// be detected is left empty, so the fingerprint never fails a run.
+func CurrentEnvironmentFingerprint() EnvironmentFingerprint {
+	environmentFingerprintOnce.Do(func() {
+		authMode := "disabled"
+		if !AuthDisabled() {
+			authMode = "enabled"
+			if auth.FIPSMode() {
+				authMode = "enabled (FIPS)"
+			}
+		}
+		version, err := kubernetesServerVersion()
+		if err != nil {
+			fmt.Printf("Unable to detect the Kubernetes version: %v\n", err)
+		}
+		environmentFingerprint = newEnvironmentFingerprint(ComponentVersions(), version, GetEdgeNodeProvider(), authMode, time.Now())
+	})
+	return environmentFingerprint
+}
+
+func newEnvironmentFingerprint(components map[string]string, kubernetesVersion, provider, authMode string, at time.Time) EnvironmentFingerprint {
+	fingerprint := EnvironmentFingerprint{
+		CapturedAt:        at.UTC(),
+		Components:        components,
+		KubernetesVersion: kubernetesVersion,
+		Provider:          provider,
+		AuthMode:          authMode,
+	}
+	fingerprint.Digest = fingerprint.digest()
+	return fingerprint
+}
+
+// digest hashes everything but the capture time.
+func (f EnvironmentFingerprint) digest() string {
+	hash := sha256.New()
+	for _, line := range f.fields() {
+		fmt.Fprintln(hash, line)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// fields returns "name: value" for every property of the environment, in a stable order.
+func (f EnvironmentFingerprint) fields() []string {
+	fields := []string{
+		"kubernetes: " + f.KubernetesVersion,
+		"provider: " + f.Provider,
+		"auth: " + f.AuthMode,
+	}
+	components := make([]string, 0, len(f.Components))
+	for component, version := range f.Components {
+		components = append(components, component+": "+version)
+	}
+	sort.Strings(components)
+	return append(fields, components...)
+}
+
+// kubernetesServerVersion returns the version of the management cluster.
+func kubernetesServerVersion() (string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", err
+	}
+	version, err := client.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the server version: %w", err)
+	}
+	return version.GitVersion, nil
+}
+
+// MarshalReport marshals a report object as indented JSON with the environment fingerprint in its
+// "environment" field, so that every report tells what it was measured against.
+func MarshalReport(report any) ([]byte, error) {
+	return marshalReport(report, CurrentEnvironmentFingerprint())
+}
+
+func marshalReport(report any, fingerprint EnvironmentFingerprint) ([]byte, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("a report has to be a JSON object: %w", err)
+	}
+	if fields[environmentReportKey], err = json.Marshal(fingerprint); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(fields, "", "  ")
+}
+
+// LoadEnvironmentFingerprint reads a fingerprint file, or the fingerprint embedded in a report.
+func LoadEnvironmentFingerprint(path string) (EnvironmentFingerprint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EnvironmentFingerprint{}, err
+	}
+	var report struct {
+		Environment *EnvironmentFingerprint `json:"environment"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return EnvironmentFingerprint{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if report.Environment != nil {
+		return *report.Environment, nil
+	}
+	var fingerprint EnvironmentFingerprint
+	if err := json.Unmarshal(data, &fingerprint); err != nil || fingerprint.Digest == "" {
+		return EnvironmentFingerprint{}, fmt.Errorf("%s holds no environment fingerprint", path)
+	}
+	return fingerprint, nil
+}
+
+// DiffEnvironmentFingerprints returns "name: old -> new" for every property that differs between two runs.
+func DiffEnvironmentFingerprints(base, head EnvironmentFingerprint) []string {
+	values := func(f EnvironmentFingerprint) map[string]string {
+		values := map[string]string{}
+		for _, field := range f.fields() {
+			name, value, _ := strings.Cut(field, ": ")
+			values[name] = value
+		}
+		return values
+	}
+	baseValues, headValues := values(base), values(head)
+	names := map[string]bool{}
+	for name := range baseValues {
+		names[name] = true
+	}
+	for name := range headValues {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var diff []string
+	for _, name := range sorted {
+		old, ok := baseValues[name]
+		if !ok {
+			old = "(none)"
+		}
+		current, ok := headValues[name]
+		if !ok {
+			current = "(none)"
+		}
+		if old != current {
+			diff = append(diff, fmt.Sprintf("%s: %s -> %s", name, old, current))
+		}
+	}
+	return diff
+}
+
+// RegisterEnvironmentFingerprint writes the fingerprint of the environment to ENVIRONMENT_FINGERPRINT_DIR (or
+// FAILURE_ARTIFACTS_DIR) at the end of the suite, whether it passed or not, and prints it.
+// diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterEnvironmentFingerprint() bool {
+	ginkgo.ReportAfterSuite("environment fingerprint", func(report ginkgo.Report) {
+		fingerprint := CurrentEnvironmentFingerprint()
+		fmt.Printf("Environment %s: %s\n", fingerprint.Digest, strings.Join(fingerprint.fields(), ", "))
+
+		data, err := json.MarshalIndent(fingerprint, "", "  ")
+		if err != nil {
+			fmt.Printf("Failed to marshal the environment fingerprint: %v\n", err)
+			return
+		}
+		dir := GetEnv(EnvironmentFingerprintDirEnvVar, FailureArtifactsDir())
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fmt.Printf("Failed to create %s: %v\n", dir, err)
+			return
+		}
+		suite := specArtifactName(ginkgo.SpecReport{LeafNodeText: report.SuiteDescription})
+		path := filepath.Join(dir, "environment-"+suite+".json")
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			fmt.Printf("Failed to write the environment fingerprint: %v\n", err)
+			return
+		}
+		fmt.Printf("Environment fingerprint written to %s\n", path)
+	})
+	return true
+}
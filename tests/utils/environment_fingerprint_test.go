@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFingerprint(clusterManager string, at time.Time) EnvironmentFingerprint {
+	return newEnvironmentFingerprint(map[string]string{ComponentClusterManager: clusterManager, ComponentGateway: "1.2.0"},
+		"v1.31.0", EdgeNodeProviderVEN, "enabled", at)
+}
+
+func TestEnvironmentFingerprintDigest(t *testing.T) {
+	today := testFingerprint("2.2.11", time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+	yesterday := testFingerprint("2.2.11", time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC))
+	if today.Digest == "" || today.Digest != yesterday.Digest {
+		t.Errorf("Expected the same digest for the same environment at different times, got %s and %s", today.Digest, yesterday.Digest)
+	}
+	if drifted := testFingerprint("2.2.12", today.CapturedAt); drifted.Digest == today.Digest {
+		t.Error("Expected another digest for another cluster-manager version")
+	}
+}
+
+func TestDiffEnvironmentFingerprints(t *testing.T) {
+	base := testFingerprint("2.2.11", time.Now())
+	head := testFingerprint("2.2.12", time.Now())
+	delete(head.Components, ComponentGateway)
+	head.AuthMode = "disabled"
+
+	expected := []string{
+		"auth: enabled -> disabled",
+		"cluster-connect-gateway: 1.2.0 -> (none)",
+		"cluster-manager: 2.2.11 -> 2.2.12",
+	}
+	if diff := DiffEnvironmentFingerprints(base, head); !reflect.DeepEqual(diff, expected) {
+		t.Errorf("Expected %v, got %v", expected, diff)
+	}
+	if diff := DiffEnvironmentFingerprints(base, base); len(diff) != 0 {
+		t.Errorf("Expected no difference to itself, got %v", diff)
+	}
+}
+
+func TestMarshalReportEmbedsTheFingerprint(t *testing.T) {
+	fingerprint := testFingerprint("2.2.11", time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC))
+	data, err := marshalReport(struct {
+		Sessions int `json:"sessions"`
+	}{Sessions: 4}, fingerprint)
+	if err != nil {
+		t.Fatalf("Failed to marshal report: %v", err)
+	}
+	if !strings.Contains(string(data), `"sessions": 4`) {
+		t.Errorf("Expected the fields of the report, got %s", data)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	loaded, err := LoadEnvironmentFingerprint(path)
+	if err != nil {
+		t.Fatalf("Failed to load the fingerprint of the report: %v", err)
+	}
+	if loaded.Digest != fingerprint.Digest || !loaded.CapturedAt.Equal(fingerprint.CapturedAt) {
+		t.Errorf("Expected %+v, got %+v", fingerprint, loaded)
+	}
+
+	if _, err := marshalReport([]string{"a"}, fingerprint); err == nil {
+		t.Error("Expected an error for a report that is not an object")
+	}
+}
+
+func TestLoadEnvironmentFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	fingerprintPath := filepath.Join(dir, "environment.json")
+	if err := os.WriteFile(fingerprintPath, []byte(`{"digest":"abc","kubernetesVersion":"v1.31.0"}`), 0o644); err != nil {
+		t.Fatalf("Failed to write fingerprint: %v", err)
+	}
+	if fingerprint, err := LoadEnvironmentFingerprint(fingerprintPath); err != nil || fingerprint.KubernetesVersion != "v1.31.0" {
+		t.Errorf("Expected the fingerprint file to load, got %+v, %v", fingerprint, err)
+	}
+
+	reportPath := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(reportPath, []byte(`{"sessions":4}`), 0o644); err != nil {
+		t.Fatalf("Failed to write report: %v", err)
+	}
+	if _, err := LoadEnvironmentFingerprint(reportPath); err == nil {
+		t.Error("Expected an error for a report without a fingerprint")
+	}
+}
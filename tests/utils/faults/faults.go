@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faults breaks the workloads of a downstream cluster, typically the connect agent, through its
+// kubeconfig. Every fault that changes an object returns the Restore that undoes it, to hand to DeferCleanup.
+package faults
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// InvalidImage cannot be pulled, the registry does not resolve.
+	InvalidImage = "invalid.invalid/connect-agent:does-not-exist"
+	// CorruptedSecretValue replaces the value of a corrupted secret key.
+	CorruptedSecretValue = "corrupted-by-cluster-tests"
+
+	// scaledToZeroLabel is the node selector no node matches, which scales a DaemonSet to zero.
+	scaledToZeroLabel = "cluster-tests.open-edge-platform/scaled-to-zero"
+)
+
+// Workload is a DaemonSet or Deployment of a downstream cluster, as found by utils.FindConnectAgentWorkload.
+type Workload = utils.ConnectAgentWorkload
+
+// Restore undoes a fault.
+type Restore func() error
+
+// GetWorkloadImage returns the image of the first container of a workload.
+func GetWorkloadImage(kubeconfigPath string, workload Workload) (string, error) {
+	spec, err := workloadSpec(kubeconfigPath, workload)
+	if err != nil {
+		return "", err
+	}
+	if len(spec.Template.Spec.Containers) == 0 {
+		return "", fmt.Errorf("%s has no container", workload)
+	}
+	return spec.Template.Spec.Containers[0].Image, nil
+}
+
+// SetWorkloadImage sets the image of every container of a workload, which rolls out new pods.
+func SetWorkloadImage(kubeconfigPath string, workload Workload, image string) error {
+	client, err := utils.NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	spec, err := workloadSpec(kubeconfigPath, workload)
+	if err != nil {
+		return err
+	}
+	return client.PatchWorkload(workload.Namespace, workload.Kind, workload.Name, types.StrategicMergePatchType,
+		containerImagePatch(spec.Template.Spec.Containers, image))
+}
+
+// containerImagePatch sets the image of every container; a strategic merge patch merges the containers by
+// name.
+func containerImagePatch(containers []corev1.Container, image string) []byte {
+	patched := make([]map[string]string, 0, len(containers))
+	for _, container := range containers {
+		patched = append(patched, map[string]string{"name": container.Name, "image": image})
+	}
+	patch, _ := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{"containers": patched}}},
+	})
+	return patch
+}
+
+// BreakWorkloadImage sets an image that cannot be pulled, so the new pods of the workload never start.
+// The Restore sets the image back.
+func BreakWorkloadImage(kubeconfigPath string, workload Workload) (Restore, error) {
+	image, err := GetWorkloadImage(kubeconfigPath, workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the image of %s: %w", workload, err)
+	}
+	if err := SetWorkloadImage(kubeconfigPath, workload, InvalidImage); err != nil {
+		return nil, fmt.Errorf("failed to break the image of %s: %w", workload, err)
+	}
+	return func() error {
+		return SetWorkloadImage(kubeconfigPath, workload, image)
+	}, nil
+}
+
+// ScaleWorkloadToZero stops every pod of a workload. A Deployment is scaled to zero replicas; a DaemonSet,
+// which has no replicas, gets a node selector no node matches. The Restore brings the pods back.
+func ScaleWorkloadToZero(kubeconfigPath string, workload Workload) (Restore, error) {
+	client, err := utils.NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	patch := func(patch string) error {
+		return client.PatchWorkload(workload.Namespace, workload.Kind, workload.Name, types.MergePatchType, []byte(patch))
+	}
+
+	if strings.EqualFold(workload.Kind, "daemonset") {
+		if err := patch(scaledToZeroPatch(true)); err != nil {
+			return nil, fmt.Errorf("failed to scale %s to zero: %w", workload, err)
+		}
+		return func() error {
+			return patch(scaledToZeroPatch(false))
+		}, nil
+	}
+
+	spec, err := client.GetWorkloadSpec(workload.Namespace, workload.Kind, workload.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the replicas of %s: %w", workload, err)
+	}
+	replicas := int32(1)
+	if spec.Replicas != nil {
+		replicas = *spec.Replicas
+	}
+	if err := patch(replicasPatch(0)); err != nil {
+		return nil, fmt.Errorf("failed to scale %s to zero: %w", workload, err)
+	}
+	return func() error {
+		return patch(replicasPatch(replicas))
+	}, nil
+}
+
+func replicasPatch(replicas int32) string {
+	return fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas)
+}
+
+// scaledToZeroPatch adds, or removes, the node selector that matches no node. A null removes the key in a
+// merge patch without touching the other node selectors.
+func scaledToZeroPatch(scaledToZero bool) string {
+	value := "null"
+	if scaledToZero {
+		value = strconv.Quote("true")
+	}
+	return fmt.Sprintf(`{"spec":{"template":{"spec":{"nodeSelector":{%q:%s}}}}}`, scaledToZeroLabel, value)
+}
+
+// DeleteWorkloadPods deletes the pods of a workload without waiting, which its controller recreates.
+func DeleteWorkloadPods(kubeconfigPath string, workload Workload) error {
+	client, err := utils.NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	spec, err := client.GetWorkloadSpec(workload.Namespace, workload.Kind, workload.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get the selector of %s: %w", workload, err)
+	}
+	selector, err := labelSelector(spec.Selector)
+	if err != nil {
+		return fmt.Errorf("unexpected selector of %s: %w", workload, err)
+	}
+	return client.DeletePods(workload.Namespace, selector)
+}
+
+// labelSelector turns the selector of a workload into a label selector, which must select something.
+func labelSelector(selector *metav1.LabelSelector) (string, error) {
+	if selector == nil {
+		return "", fmt.Errorf("no selector")
+	}
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Empty() {
+		return "", fmt.Errorf("no matchLabels")
+	}
+	return parsed.String(), nil
+}
+
+// CorruptSecret replaces the value of a key of a secret with CorruptedSecretValue. The Restore puts the
+// original value back; the pods that read the secret may need a restart to pick up either change.
+func CorruptSecret(kubeconfigPath, namespace, name, key string) (Restore, error) {
+	client, err := utils.NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := client.GetSecret(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	original, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %s", namespace, name, key)
+	}
+	if err := client.PatchSecret(namespace, name, types.MergePatchType, secretPatch(key, []byte(CorruptedSecretValue))); err != nil {
+		return nil, fmt.Errorf("failed to corrupt secret %s/%s: %w", namespace, name, err)
+	}
+	return func() error {
+		return client.PatchSecret(namespace, name, types.MergePatchType, secretPatch(key, original))
+	}, nil
+}
+
+func secretPatch(key string, value []byte) []byte {
+	patch, _ := json.Marshal(map[string]map[string][]byte{"data": {key: value}})
+	return patch
+}
+
+func workloadSpec(kubeconfigPath string, workload Workload) (*utils.WorkloadSpec, error) {
+	client, err := utils.NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetWorkloadSpec(workload.Namespace, workload.Kind, workload.Name)
+}
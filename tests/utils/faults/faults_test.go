@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package faults
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLabelSelector(t *testing.T) {
+	selector, err := labelSelector(&metav1.LabelSelector{
+		MatchLabels: map[string]string{"app": "connect-agent", "app.kubernetes.io/instance": "agent"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := "app=connect-agent,app.kubernetes.io/instance=agent"; selector != want {
+		t.Errorf("Expected %q, got %q", want, selector)
+	}
+	for _, empty := range []*metav1.LabelSelector{nil, {}} {
+		if _, err := labelSelector(empty); err == nil {
+			t.Errorf("Expected an error for %v", empty)
+		}
+	}
+}
+
+func TestContainerImagePatch(t *testing.T) {
+	containers := []corev1.Container{{Name: "agent", Image: "agent:1"}, {Name: "proxy", Image: "proxy:1"}}
+	want := `{"spec":{"template":{"spec":{"containers":[{"image":"broken","name":"agent"},{"image":"broken","name":"proxy"}]}}}}`
+	if got := string(containerImagePatch(containers, "broken")); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestScaledToZeroPatch(t *testing.T) {
+	for scaledToZero, want := range map[bool]interface{}{true: "true", false: nil} {
+		var patch struct {
+			Spec struct {
+				Template struct {
+					Spec struct {
+						NodeSelector map[string]interface{} `json:"nodeSelector"`
+					} `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		}
+		if err := json.Unmarshal([]byte(scaledToZeroPatch(scaledToZero)), &patch); err != nil {
+			t.Fatalf("Expected a JSON patch, got %v", err)
+		}
+		value, ok := patch.Spec.Template.Spec.NodeSelector[scaledToZeroLabel]
+		if !ok || value != want {
+			t.Errorf("Expected the node selector %v for scaledToZero=%v, got %v", want, scaledToZero, value)
+		}
+	}
+}
+
+func TestReplicasPatch(t *testing.T) {
+	if got, want := replicasPatch(3), `{"spec":{"replicas":3}}`; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestSecretPatch(t *testing.T) {
+	if got, want := string(secretPatch("token", []byte("token"))), `{"data":{"token":"dG9rZW4="}}`; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
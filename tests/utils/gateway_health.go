@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// GatewayHealthPathEnvVar is the path of the health endpoint on the API port of the gateway.
+	GatewayHealthPathEnvVar  = "GATEWAY_HEALTH_PATH"
+	DefaultGatewayHealthPath = "/healthz"
+
+	gatewayHealthTimeout = 5 * time.Second
+	goInfoMetric         = "go_info"
+	buildInfoSuffix      = "_build_info"
+)
+
+// GatewayHealth is the state of the cluster-connect-gateway as seen from the tests. The status of an endpoint
+// is 0 when it could not be reached at all.
+type GatewayHealth struct {
+	ReadyReplicas int
+	Replicas      int
+	// HealthStatus is the status of the health endpoint, and MetricsStatus the one of the metrics endpoint.
+	HealthStatus  int
+	MetricsStatus int
+	// Problems describe why the gateway or one of its endpoints could not be read.
+	Problems []string
+}
+
+// Serving reports whether the gateway has a ready replica that answers on its health or metrics endpoint. A
+// gateway without a health endpoint is judged by its metrics.
+func (h GatewayHealth) Serving() bool {
+	return h.ReadyReplicas > 0 && (h.HealthStatus == http.StatusOK || h.MetricsStatus == http.StatusOK)
+}
+
+func (h GatewayHealth) String() string {
+	state := "down"
+	if h.Serving() {
+		state = "serving"
+	}
+	description := fmt.Sprintf("gateway %s: %d/%d replicas ready, health %s, metrics %s", state, h.ReadyReplicas, h.Replicas,
+		statusText(h.HealthStatus), statusText(h.MetricsStatus))
+	if len(h.Problems) > 0 {
+		description += " (" + strings.Join(h.Problems, "; ") + ")"
+	}
+	return description
+}
+
+func statusText(status int) string {
+	if status == 0 {
+		return "unreachable"
+	}
+	return strconv.Itoa(status)
+}
+
+// GetGatewayHealth reads the replicas of the cluster-connect-gateway deployment and probes its health endpoint,
+// GATEWAY_HEALTH_PATH on the API port-forward, and its metrics endpoint. It never fails: what cannot be read is
+// reported in Problems.
+func GetGatewayHealth() GatewayHealth {
+	var health GatewayHealth
+	ready, replicas, err := gatewayReplicas()
+	if err != nil {
+		health.Problems = append(health.Problems, err.Error())
+	}
+	health.ReadyReplicas, health.Replicas = ready, replicas
+
+	client := &http.Client{Timeout: gatewayHealthTimeout}
+	for _, probe := range []struct {
+		url    string
+		status *int
+	}{
+		{LocalGatewayAddress + GetEnv(GatewayHealthPathEnvVar, DefaultGatewayHealthPath), &health.HealthStatus},
+		{GatewayMetricsURL(), &health.MetricsStatus},
+	} {
+		resp, err := client.Get(probe.url)
+		if err != nil {
+			health.Problems = append(health.Problems, err.Error())
+			continue
+		}
+		resp.Body.Close()
+		*probe.status = resp.StatusCode
+	}
+	return health
+}
+
+// gatewayReplicas returns the ready and desired replicas of the cluster-connect-gateway deployment.
+func gatewayReplicas() (int, int, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return 0, 0, err
+	}
+	deployment, err := client.GetDeployment(componentReleaseNamespace, ComponentGateway)
+	if err != nil {
+		return 0, 0, err
+	}
+	replicas := 1
+	if deployment.Spec.Replicas != nil {
+		replicas = int(*deployment.Spec.Replicas)
+	}
+	return int(deployment.Status.ReadyReplicas), replicas, nil
+}
+
+// GatewayVersion is the build of the running cluster-connect-gateway.
+type GatewayVersion struct {
+	Version   string
+	Revision  string
+	GoVersion string
+	// Source tells where Version comes from.
+	Source string
+}
+
+func (v GatewayVersion) String() string {
+	description := v.Version
+	if v.Revision != "" {
+		description += " (" + v.Revision + ")"
+	}
+	if v.GoVersion != "" {
+		description += ", " + v.GoVersion
+	}
+	return description + " from " + v.Source
+}
+
+// GetGatewayVersion reads the version of the running gateway from a *_build_info metric, falling back to the
+// version of its helm release or image tag, see DetectComponentVersion.
+func GetGatewayVersion() (GatewayVersion, error) {
+	var version GatewayVersion
+	var metricsErr error
+	if body, err := FetchMetrics(); err != nil {
+		metricsErr = err
+	} else {
+		metrics, err := ParsePrometheusMetrics(body)
+		body.Close()
+		if err != nil {
+			metricsErr = err
+		} else {
+			version = gatewayVersionFromMetrics(metrics)
+		}
+	}
+	if version.Version != "" {
+		return version, nil
+	}
+
+	detected, err := DetectComponentVersion(ComponentGateway)
+	if err != nil {
+		return GatewayVersion{}, fmt.Errorf("no build info in the gateway metrics (%v) and %w", metricsErr, err)
+	}
+	version.Version, version.Source = detected, "deployment"
+	return version, nil
+}
+
+// gatewayVersionFromMetrics reads the version and revision labels of a *_build_info metric and the Go version
+// of go_info. Version is empty when the gateway exposes no build info.
+func gatewayVersionFromMetrics(metrics Metrics) GatewayVersion {
+	var version GatewayVersion
+	for _, sample := range metrics {
+		switch {
+		case sample.Name == goInfoMetric:
+			version.GoVersion = sample.Labels["version"]
+		case strings.HasSuffix(sample.Name, buildInfoSuffix) && sample.Labels["version"] != "" && version.Version == "":
+			version.Version = normalizeVersion(sample.Labels["version"])
+			version.Revision = sample.Labels["revision"]
+			version.Source = sample.Name + " metric"
+		}
+	}
+	return version
+}
+
+// DiagnoseConnectionLoss tells whether a lost connection to a cluster is the gateway being down or, when the
+// gateway is serving, the agent of the cluster.
+func DiagnoseConnectionLoss() (gatewayDown bool, diagnosis string) {
+	health := GetGatewayHealth()
+	if !health.Serving() {
+		return true, "gateway down: " + health.String()
+	}
+	return false, "agent down: " + health.String()
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGatewayHealthServing(t *testing.T) {
+	for _, tc := range []struct {
+		health  GatewayHealth
+		serving bool
+	}{
+		{GatewayHealth{ReadyReplicas: 1, Replicas: 1, HealthStatus: http.StatusOK, MetricsStatus: http.StatusOK}, true},
+		{GatewayHealth{ReadyReplicas: 1, Replicas: 1, HealthStatus: http.StatusNotFound, MetricsStatus: http.StatusOK}, true},
+		{GatewayHealth{ReadyReplicas: 0, Replicas: 1, Problems: []string{"connection refused"}}, false},
+		{GatewayHealth{ReadyReplicas: 1, Replicas: 1, HealthStatus: http.StatusServiceUnavailable}, false},
+	} {
+		if tc.health.Serving() != tc.serving {
+			t.Errorf("Expected serving %t for %s", tc.serving, tc.health)
+		}
+	}
+	if s := (GatewayHealth{Replicas: 1, Problems: []string{"refused"}}).String(); !strings.Contains(s, "gateway down") || !strings.Contains(s, "unreachable") {
+		t.Errorf("Unexpected description %q", s)
+	}
+}
+
+func TestGatewayVersionFromMetrics(t *testing.T) {
+	metrics, err := ParsePrometheusMetrics(strings.NewReader(`# HELP go_info Information about the Go environment.
+go_info{version="go1.24.1"} 1
+cluster_connect_gateway_build_info{revision="abc123",version="v1.2.3"} 1
+websocket_connections_total{status="succeeded"} 2
+`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	version := gatewayVersionFromMetrics(metrics)
+	if version.Version != "1.2.3" || version.Revision != "abc123" || version.GoVersion != "go1.24.1" {
+		t.Errorf("Unexpected version %+v", version)
+	}
+
+	metrics, _ = ParsePrometheusMetrics(strings.NewReader(`go_info{version="go1.24.1"} 1` + "\n"))
+	if version := gatewayVersionFromMetrics(metrics); version.Version != "" {
+		t.Errorf("Expected no version without build info, got %+v", version)
+	}
+}
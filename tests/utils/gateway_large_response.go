@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LargeResponseSizeEnvVar is the total size in MiB of the ConfigMaps the large-response spec lists.
+	LargeResponseSizeEnvVar  = "GATEWAY_LARGE_RESPONSE_MIB"
+	DefaultLargeResponseSize = 32
+
+	LargeResponseNamespace = "default"
+	largeResponseLabel     = "cluster-tests/large-response"
+	largeResponseKey       = "payload"
+	// largeResponseConfigMapSize stays below the 1MiB limit of a ConfigMap, metadata included.
+	largeResponseConfigMapSize = 512 * 1024
+)
+
+// LargeResponseConfigMaps returns how many ConfigMaps make up GATEWAY_LARGE_RESPONSE_MIB, or the default
+// when it is unset or invalid.
+func LargeResponseConfigMaps() int {
+	size := DefaultLargeResponseSize
+	if mib, err := strconv.Atoi(os.Getenv(LargeResponseSizeEnvVar)); err == nil && mib > 0 {
+		size = mib
+	}
+	return size * 1024 * 1024 / largeResponseConfigMapSize
+}
+
+// largeResponsePayload returns the data of the ConfigMap with the given index. Every line carries the
+// index and the line number, so a truncated or spliced response does not match.
+func largeResponsePayload(index, size int) string {
+	var b strings.Builder
+	b.Grow(size)
+	for line := 0; b.Len() < size; line++ {
+		fmt.Fprintf(&b, "configmap %05d line %08d %s\n", index, line, strings.Repeat("x", 64))
+	}
+	return b.String()[:size]
+}
+
+func largeResponseConfigMapName(index int) string {
+	return fmt.Sprintf("cluster-tests-large-response-%05d", index)
+}
+
+// largeResponseConfigMaps returns count ConfigMaps with size bytes of data each.
+func largeResponseConfigMaps(count, size int) []corev1.ConfigMap {
+	configMaps := make([]corev1.ConfigMap, 0, count)
+	for i := 0; i < count; i++ {
+		configMaps = append(configMaps, corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      largeResponseConfigMapName(i),
+				Namespace: LargeResponseNamespace,
+				Labels:    map[string]string{largeResponseLabel: "true"},
+			},
+			Data: map[string]string{largeResponseKey: largeResponsePayload(i, size)},
+		})
+	}
+	return configMaps
+}
+
+// CreateLargeResponseConfigMaps creates count ConfigMaps of about 512KiB each in a downstream cluster.
+// They are created rather than applied, the last-applied annotation would not fit.
+func CreateLargeResponseConfigMaps(kubeconfigPath string, count int) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	for _, configMap := range largeResponseConfigMaps(count, largeResponseConfigMapSize) {
+		if err := client.CreateConfigMap(&configMap); err != nil {
+			return fmt.Errorf("failed to create the large-response ConfigMaps: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteLargeResponseConfigMaps deletes the large-response ConfigMaps from a downstream cluster.
+func DeleteLargeResponseConfigMaps(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.DeleteConfigMapsWithLabels(LargeResponseNamespace, largeResponseLabel+"=true")
+}
+
+// FetchLargeResponse lists the large-response ConfigMaps in one request and returns the raw response body
+// and how long it took. The request fails if it does not complete within timeout.
+func FetchLargeResponse(kubeconfigPath string, timeout time.Duration) ([]byte, time.Duration, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/configmaps?labelSelector=%s%%3Dtrue", LargeResponseNamespace, largeResponseLabel)
+	start := time.Now()
+	body, err := client.GetRaw(path, timeout)
+	elapsed := time.Since(start)
+	if err != nil {
+		return body, elapsed, fmt.Errorf("failed to list the large-response ConfigMaps after %v: %w", elapsed, err)
+	}
+	return body, elapsed, nil
+}
+
+// VerifyLargeResponse checks that body lists all count large-response ConfigMaps with their full data.
+func VerifyLargeResponse(body []byte, count int) error {
+	return verifyLargeResponse(body, count, largeResponseConfigMapSize)
+}
+
+func verifyLargeResponse(body []byte, count, size int) error {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Data map[string]string `json:"data"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return fmt.Errorf("the response of %d bytes is not a complete list: %w", len(body), err)
+	}
+	if len(list.Items) != count {
+		return fmt.Errorf("expected %d ConfigMaps, got %d", count, len(list.Items))
+	}
+	seen := make(map[string]bool, count)
+	for _, item := range list.Items {
+		seen[item.Metadata.Name] = true
+	}
+	for i := 0; i < count; i++ {
+		if !seen[largeResponseConfigMapName(i)] {
+			return fmt.Errorf("ConfigMap %s is missing from the response", largeResponseConfigMapName(i))
+		}
+	}
+	for _, item := range list.Items {
+		var index int
+		if _, err := fmt.Sscanf(item.Metadata.Name, "cluster-tests-large-response-%05d", &index); err != nil {
+			return fmt.Errorf("unexpected ConfigMap %s in the response", item.Metadata.Name)
+		}
+		data := item.Data[largeResponseKey]
+		if len(data) != size {
+			return fmt.Errorf("ConfigMap %s has %d bytes of data, expected %d", item.Metadata.Name, len(data), size)
+		}
+		if data != largeResponsePayload(index, size) {
+			return fmt.Errorf("the data of ConfigMap %s does not match what was created", item.Metadata.Name)
+		}
+	}
+	return nil
+}
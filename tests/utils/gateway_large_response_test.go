@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestLargeResponsePayload(t *testing.T) {
+	payload := largeResponsePayload(7, 1000)
+	if len(payload) != 1000 {
+		t.Errorf("Expected 1000 bytes, got %d", len(payload))
+	}
+	if !strings.HasPrefix(payload, "configmap 00007 line 00000000 ") {
+		t.Errorf("Expected the payload to start with the index and line, got %q", payload[:40])
+	}
+	if payload == largeResponsePayload(8, 1000) {
+		t.Errorf("Expected the payloads of different ConfigMaps to differ")
+	}
+}
+
+func TestVerifyLargeResponse(t *testing.T) {
+	body, err := json.Marshal(corev1.ConfigMapList{Items: largeResponseConfigMaps(3, 2000)})
+	if err != nil {
+		t.Fatalf("Failed to render ConfigMaps: %v", err)
+	}
+	if err := verifyLargeResponse(body, 3, 2000); err != nil {
+		t.Errorf("Expected the rendered list to verify, got %v", err)
+	}
+
+	if err := verifyLargeResponse(body[:len(body)/2], 3, 2000); err == nil {
+		t.Errorf("Expected a truncated response to fail")
+	}
+	if err := verifyLargeResponse(body, 4, 2000); err == nil {
+		t.Errorf("Expected a response missing a ConfigMap to fail")
+	}
+
+	corrupted := strings.Replace(string(body), "configmap 00001 line 00000005", "configmap 00001 line 00000006", 1)
+	if err := verifyLargeResponse([]byte(corrupted), 3, 2000); err == nil {
+		t.Errorf("Expected a response with altered data to fail")
+	}
+}
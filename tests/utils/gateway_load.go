@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// GatewaySessionsEnvVar is the number of concurrent sessions the gateway load spec opens.
+	GatewaySessionsEnvVar = "GATEWAY_SESSIONS"
+	// GatewayLoadDurationEnvVar is how long the sessions keep sending requests (e.g. "2m").
+	GatewayLoadDurationEnvVar = "GATEWAY_LOAD_DURATION"
+	// GatewayLoadReportDirEnvVar selects where gateway load reports are written; defaults to the working directory.
+	GatewayLoadReportDirEnvVar = "GATEWAY_LOAD_REPORT_DIR"
+
+	DefaultGatewaySessions     = 20
+	DefaultGatewayLoadDuration = 2 * time.Minute
+
+	gatewayDeployment        = "cluster-connect-gateway-gateway"
+	gatewaySampleInterval    = 5 * time.Second
+	gatewayWatchSeconds      = 10
+	gatewayRequestTimeout    = 60 * time.Second
+	gatewayLatencyPercentile = 0.95
+)
+
+// GatewayOperation is a kind of request a load session sends through the gateway.
+type GatewayOperation string
+
+const (
+	GatewayOpList  GatewayOperation = "list"
+	GatewayOpWatch GatewayOperation = "watch"
+	GatewayOpExec  GatewayOperation = "exec"
+)
+
+// gatewayOperations is the order every session cycles through, each session starting at its own offset.
+var gatewayOperations = []GatewayOperation{GatewayOpList, GatewayOpWatch, GatewayOpExec}
+
+// GatewayOperationResult summarises the requests of one operation.
+type GatewayOperationResult struct {
+	Operation GatewayOperation `json:"operation"`
+	Requests  int              `json:"requests"`
+	Errors    int              `json:"errors"`
+	P50       time.Duration    `json:"p50Ns"`
+	P95       time.Duration    `json:"p95Ns"`
+	Max       time.Duration    `json:"maxNs"`
+	// FirstError is kept so a failing run says why without digging through logs.
+	FirstError string `json:"firstError,omitempty"`
+}
+
+// GatewayResourceSample is the resource usage of the gateway replica at one point in time.
+type GatewayResourceSample struct {
+	At                  time.Time `json:"at"`
+	ResidentMemoryBytes float64   `json:"residentMemoryBytes"`
+	CPUSeconds          float64   `json:"cpuSeconds"`
+	Goroutines          float64   `json:"goroutines"`
+}
+
+// GatewayLoadReport is the outcome of RunGatewayLoad.
+type GatewayLoadReport struct {
+	Sessions int                      `json:"sessions"`
+	Replicas int                      `json:"replicas"`
+	Duration time.Duration            `json:"durationNs"`
+	Results  []GatewayOperationResult `json:"results"`
+	Samples  []GatewayResourceSample  `json:"samples"`
+}
+
+// GatewayLoadSessions returns GATEWAY_SESSIONS, or the default when it is unset or invalid.
+func GatewayLoadSessions() int {
+	if sessions, err := strconv.Atoi(os.Getenv(GatewaySessionsEnvVar)); err == nil && sessions > 0 {
+		return sessions
+	}
+	return DefaultGatewaySessions
+}
+
+// GatewayLoadDuration returns GATEWAY_LOAD_DURATION, or the default when it is unset or invalid.
+func GatewayLoadDuration() time.Duration {
+	if duration, err := time.ParseDuration(os.Getenv(GatewayLoadDurationEnvVar)); err == nil && duration > 0 {
+		return duration
+	}
+	return DefaultGatewayLoadDuration
+}
+
+// GatewayLoadTarget is the pod exec sessions run in.
+type GatewayLoadTarget struct {
+	Namespace string
+	Pod       string
+}
+
+type gatewayRequest struct {
+	operation GatewayOperation
+	latency   time.Duration
+	err       error
+}
+
+// RunGatewayLoad opens sessions concurrent sessions through the gateway with the given
+// kubeconfig for duration. Each session keeps cycling through a pod list, a short watch and an exec into
+// target. The resource usage of the gateway is sampled from its metrics endpoint meanwhile. Both the
+// sessions and the metrics go through the local port-forward, i.e. they hit the same gateway replica.
+func RunGatewayLoad(kubeconfigPath string, target GatewayLoadTarget, sessions int, duration time.Duration) (*GatewayLoadReport, error) {
+	replicas, err := GatewayReplicas()
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &GatewayLoadReport{Sessions: sessions, Replicas: replicas, Duration: duration}
+	deadline := time.Now().Add(duration)
+
+	stopSampling := make(chan struct{})
+	samplingDone := make(chan struct{})
+	go func() {
+		defer close(samplingDone)
+		ticker := time.NewTicker(gatewaySampleInterval)
+		defer ticker.Stop()
+		for {
+			if sample, err := sampleGatewayResources(); err == nil {
+				report.Samples = append(report.Samples, sample)
+			} else {
+				fmt.Printf("Failed to sample gateway resources: %v\n", err)
+			}
+			select {
+			case <-stopSampling:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var (
+		mu       sync.Mutex
+		requests []gatewayRequest
+		wg       sync.WaitGroup
+	)
+	for session := 0; session < sessions; session++ {
+		wg.Add(1)
+		go func(session int) {
+			defer wg.Done()
+			for i := session; time.Now().Before(deadline); i++ {
+				operation := gatewayOperations[i%len(gatewayOperations)]
+				start := time.Now()
+				err := runGatewayOperation(client, target, operation)
+				mu.Lock()
+				requests = append(requests, gatewayRequest{operation: operation, latency: time.Since(start), err: err})
+				mu.Unlock()
+			}
+		}(session)
+	}
+	wg.Wait()
+
+	close(stopSampling)
+	<-samplingDone
+	if sample, err := sampleGatewayResources(); err == nil {
+		report.Samples = append(report.Samples, sample)
+	}
+
+	report.Results = summarizeGatewayRequests(requests)
+	return report, nil
+}
+
+func runGatewayOperation(client *KubeClient, target GatewayLoadTarget, operation GatewayOperation) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gatewayRequestTimeout)
+	defer cancel()
+	switch operation {
+	case GatewayOpList:
+		_, err := client.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		return err
+	case GatewayOpWatch:
+		// The API server ends the watch after timeoutSeconds, so a healthy watch ends cleanly.
+		stream, err := client.StreamRaw(ctx, fmt.Sprintf("/api/v1/pods?watch=true&timeoutSeconds=%d", gatewayWatchSeconds))
+		if err != nil {
+			return err
+		}
+		defer stream.Close()
+		_, err = io.Copy(io.Discard, stream)
+		return err
+	case GatewayOpExec:
+		var stderr strings.Builder
+		if err := client.ExecStream(ctx, target.Namespace, target.Pod, nil, io.Discard, &stderr, "ls", "/"); err != nil {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown gateway operation %q", operation)
+	}
+}
+
+func summarizeGatewayRequests(requests []gatewayRequest) []GatewayOperationResult {
+	latencies := map[GatewayOperation][]time.Duration{}
+	results := map[GatewayOperation]*GatewayOperationResult{}
+	for _, request := range requests {
+		result, ok := results[request.operation]
+		if !ok {
+			result = &GatewayOperationResult{Operation: request.operation}
+			results[request.operation] = result
+		}
+		result.Requests++
+		if request.err != nil {
+			result.Errors++
+			if result.FirstError == "" {
+				result.FirstError = request.err.Error()
+			}
+			continue
+		}
+		latencies[request.operation] = append(latencies[request.operation], request.latency)
+	}
+
+	var summary []GatewayOperationResult
+	for _, operation := range gatewayOperations {
+		result, ok := results[operation]
+		if !ok {
+			continue
+		}
+		sorted := latencies[operation]
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		if len(sorted) > 0 {
+			result.P50 = sorted[(len(sorted)-1)/2]
+			result.P95 = sorted[int(float64(len(sorted)-1)*gatewayLatencyPercentile)]
+			result.Max = sorted[len(sorted)-1]
+		}
+		summary = append(summary, *result)
+	}
+	return summary
+}
+
+// ErrorRate returns the share of failed requests over all operations.
+func (r *GatewayLoadReport) ErrorRate() float64 {
+	requests, errors := 0, 0
+	for _, result := range r.Results {
+		requests += result.Requests
+		errors += result.Errors
+	}
+	if requests == 0 {
+		return 0
+	}
+	return float64(errors) / float64(requests)
+}
+
+// PeakMemoryBytes returns the highest resident memory of the gateway seen during the run.
+func (r *GatewayLoadReport) PeakMemoryBytes() float64 {
+	peak := 0.0
+	for _, sample := range r.Samples {
+		if sample.ResidentMemoryBytes > peak {
+			peak = sample.ResidentMemoryBytes
+		}
+	}
+	return peak
+}
+
+// CPUCores returns the average number of cores the gateway used between the first and the last sample.
+func (r *GatewayLoadReport) CPUCores() float64 {
+	if len(r.Samples) < 2 {
+		return 0
+	}
+	first, last := r.Samples[0], r.Samples[len(r.Samples)-1]
+	elapsed := last.At.Sub(first.At).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return (last.CPUSeconds - first.CPUSeconds) / elapsed
+}
+
+// Print writes a human readable summary of the run to stdout.
+func (r *GatewayLoadReport) Print() {
+	fmt.Printf("Gateway load: %d sessions for %v against 1 of %d gateway replicas\n", r.Sessions, r.Duration, r.Replicas)
+	for _, result := range r.Results {
+		fmt.Printf("  %-5s requests=%d errors=%d p50=%v p95=%v max=%v\n", result.Operation, result.Requests, result.Errors,
+			result.P50.Round(time.Millisecond), result.P95.Round(time.Millisecond), result.Max.Round(time.Millisecond))
+		if result.FirstError != "" {
+			fmt.Printf("        first error: %s\n", result.FirstError)
+		}
+	}
+	fmt.Printf("  error rate %.2f%%, peak memory %.1f MiB, %.2f CPU cores\n", 100*r.ErrorRate(), r.PeakMemoryBytes()/(1<<20), r.CPUCores())
+}
+
+// WriteReport writes the report as JSON to GATEWAY_LOAD_REPORT_DIR and returns its path.
+func (r *GatewayLoadReport) WriteReport() (string, error) {
+	data, err := MarshalReport(r)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(GetEnv(GatewayLoadReportDirEnvVar, "."), fmt.Sprintf("gateway-load-%d-sessions.json", r.Sessions))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write gateway load report %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// GatewayReplicas returns the number of ready gateway replicas.
+func GatewayReplicas() (int, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return 0, err
+	}
+	deployment, err := client.GetDeployment("default", gatewayDeployment)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the gateway replicas: %w", err)
+	}
+	return int(deployment.Status.ReadyReplicas), nil
+}
+
+func sampleGatewayResources() (GatewayResourceSample, error) {
+	metrics, err := FetchMetrics()
+	if err != nil {
+		return GatewayResourceSample{}, err
+	}
+	defer metrics.Close()
+	sample, err := ParseGatewayResources(metrics)
+	sample.At = time.Now()
+	return sample, err
+}
+
+// ParseGatewayResources reads the process metrics of the gateway from its Prometheus metrics.
+func ParseGatewayResources(metrics io.Reader) (GatewayResourceSample, error) {
+	var sample GatewayResourceSample
+	found := false
+	scanner := bufio.NewScanner(metrics)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		var target *float64
+		switch fields[0] {
+		case "process_resident_memory_bytes":
+			target = &sample.ResidentMemoryBytes
+		case "process_cpu_seconds_total":
+			target = &sample.CPUSeconds
+		case "go_goroutines":
+			target = &sample.Goroutines
+		default:
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return sample, fmt.Errorf("failed to parse %s: %w", fields[0], err)
+		}
+		*target = value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return sample, fmt.Errorf("error reading metrics: %v", err)
+	}
+	if !found {
+		return sample, fmt.Errorf("no process metrics in the gateway metrics")
+	}
+	return sample, nil
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseGatewayResources(t *testing.T) {
+	metrics := `# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+process_cpu_seconds_total 12.5
+process_resident_memory_bytes 5.24288e+07
+websocket_connections_total{status="succeeded"} 1
+`
+	sample, err := ParseGatewayResources(strings.NewReader(metrics))
+	if err != nil {
+		t.Fatalf("Failed to parse metrics: %v", err)
+	}
+	if sample.Goroutines != 42 || sample.CPUSeconds != 12.5 || sample.ResidentMemoryBytes != 52428800 {
+		t.Errorf("Expected 42 goroutines, 12.5s CPU and 50MiB, got %+v", sample)
+	}
+
+	if _, err := ParseGatewayResources(strings.NewReader("websocket_connections_total 1\n")); err == nil {
+		t.Errorf("Expected an error without process metrics")
+	}
+}
+
+func TestSummarizeGatewayRequests(t *testing.T) {
+	var requests []gatewayRequest
+	for i := 1; i <= 20; i++ {
+		requests = append(requests, gatewayRequest{operation: GatewayOpList, latency: time.Duration(i) * time.Millisecond})
+	}
+	requests = append(requests,
+		gatewayRequest{operation: GatewayOpExec, err: errors.New("stream reset")},
+		gatewayRequest{operation: GatewayOpExec, latency: time.Second})
+
+	results := summarizeGatewayRequests(requests)
+	if len(results) != 2 || results[0].Operation != GatewayOpList || results[1].Operation != GatewayOpExec {
+		t.Fatalf("Expected list and exec results in operation order, got %+v", results)
+	}
+	if results[0].P50 != 10*time.Millisecond || results[0].P95 != 19*time.Millisecond || results[0].Max != 20*time.Millisecond {
+		t.Errorf("Expected p50=10ms p95=19ms max=20ms, got %+v", results[0])
+	}
+	if results[1].Errors != 1 || results[1].FirstError != "stream reset" || results[1].Max != time.Second {
+		t.Errorf("Expected one exec error and a 1s max, got %+v", results[1])
+	}
+
+	report := &GatewayLoadReport{Results: results}
+	if rate := report.ErrorRate(); rate != 1.0/22 {
+		t.Errorf("Expected an error rate of 1/22, got %v", rate)
+	}
+}
+
+func TestGatewayLoadReportResources(t *testing.T) {
+	start := time.Now()
+	report := &GatewayLoadReport{Samples: []GatewayResourceSample{
+		{At: start, ResidentMemoryBytes: 100, CPUSeconds: 10},
+		{At: start.Add(10 * time.Second), ResidentMemoryBytes: 300, CPUSeconds: 15},
+		{At: start.Add(20 * time.Second), ResidentMemoryBytes: 200, CPUSeconds: 20},
+	}}
+	if peak := report.PeakMemoryBytes(); peak != 300 {
+		t.Errorf("Expected a peak of 300 bytes, got %v", peak)
+	}
+	if cores := report.CPUCores(); cores != 0.5 {
+		t.Errorf("Expected 0.5 CPU cores, got %v", cores)
+	}
+}
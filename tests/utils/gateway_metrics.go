@@ -0,0 +1,179 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// GatewayMetricsURLEnvVar is the metrics endpoint of the gateway when it is not reached through the
+	// service, e.g. through an ingress: http://metrics.kind.internal/cluster-connect-gateway/metrics.
+	GatewayMetricsURLEnvVar = "GATEWAY_METRICS_URL"
+	// GatewayMetricsLocalPortEnvVar is the local port forwarded to the metrics port of the gateway service
+	// when it is not the port of its API.
+	GatewayMetricsLocalPortEnvVar  = "GATEWAY_METRICS_LOCAL_PORT"
+	DefaultGatewayMetricsLocalPort = "8082"
+	DefaultGatewayMetricsPath      = "/metrics"
+
+	prometheusPortAnnotation = "prometheus.io/port"
+	prometheusPathAnnotation = "prometheus.io/path"
+)
+
+var (
+	gatewayMetricsMu sync.Mutex
+	// gatewayMetrics is the discovered endpoint. Only successful discoveries are kept, so that a gateway
+	// that was not deployed yet is looked up again.
+	gatewayMetrics *GatewayMetricsEndpoint
+
+	gatewayPortForwardsMu sync.Mutex
+	// gatewayMetricsPortForwards are the metrics port-forwards by the API port-forward they were started
+	// with, so that StopPortForwards stops both.
+	gatewayMetricsPortForwards = map[*PortForwarder]*PortForwarder{}
+)
+
+// GatewayMetricsEndpoint is the port and path of the gateway service that serve its metrics.
+type GatewayMetricsEndpoint struct {
+	// URL is set when GATEWAY_METRICS_URL overrides the service port and path.
+	URL  string
+	Port string
+	Path string
+	// Source tells where the endpoint comes from.
+	Source string
+}
+
+// DiscoverGatewayMetricsEndpoint returns where the gateway serves its metrics. GATEWAY_METRICS_URL takes
+// precedence, then the prometheus.io annotations and the port names of the cluster-connect-gateway service,
+// then the port of its API.
+func DiscoverGatewayMetricsEndpoint() GatewayMetricsEndpoint {
+	if url := os.Getenv(GatewayMetricsURLEnvVar); url != "" {
+		return GatewayMetricsEndpoint{URL: url, Source: GatewayMetricsURLEnvVar}
+	}
+
+	gatewayMetricsMu.Lock()
+	defer gatewayMetricsMu.Unlock()
+	if gatewayMetrics != nil {
+		return *gatewayMetrics
+	}
+	endpoint, err := readGatewayMetricsEndpoint()
+	if err != nil {
+		fmt.Printf("Unable to discover the gateway metrics endpoint, assuming port %s: %v\n", PortForwardGatewayRemotePort, err)
+		return GatewayMetricsEndpoint{Port: PortForwardGatewayRemotePort, Path: DefaultGatewayMetricsPath, Source: "default"}
+	}
+	gatewayMetrics = &endpoint
+	return endpoint
+}
+
+// componentServiceNamespace returns the namespace of the services of the orchestrator components.
+func componentServiceNamespace() string {
+	if InClusterMode() {
+		return GetEnv(InClusterServiceNamespaceEnvVar, DefaultInClusterServiceNamespace)
+	}
+	return componentReleaseNamespace
+}
+
+func readGatewayMetricsEndpoint() (GatewayMetricsEndpoint, error) {
+	out, err := managementObjectJSON("services", componentServiceNamespace(), strings.TrimPrefix(PortForwardGatewayService, "svc/"))
+	if err != nil {
+		return GatewayMetricsEndpoint{}, err
+	}
+	return parseGatewayMetricsEndpoint(out)
+}
+
+// parseGatewayMetricsEndpoint picks the metrics port of a service: the one of its prometheus.io/port
+// annotation, else the first port named after metrics, else the port of the gateway API.
+func parseGatewayMetricsEndpoint(service []byte) (GatewayMetricsEndpoint, error) {
+	var svc struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Spec struct {
+			Ports []struct {
+				Name       string          `json:"name"`
+				Port       int             `json:"port"`
+				TargetPort json.RawMessage `json:"targetPort"`
+			} `json:"ports"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(service, &svc); err != nil {
+		return GatewayMetricsEndpoint{}, fmt.Errorf("failed to parse the gateway service: %w", err)
+	}
+	if len(svc.Spec.Ports) == 0 {
+		return GatewayMetricsEndpoint{}, fmt.Errorf("the gateway service has no ports")
+	}
+
+	endpoint := GatewayMetricsEndpoint{Path: DefaultGatewayMetricsPath}
+	if path := svc.Metadata.Annotations[prometheusPathAnnotation]; path != "" {
+		endpoint.Path = "/" + strings.TrimPrefix(path, "/")
+	}
+	// The annotation names the container port, which the service may expose under another number.
+	if annotated := svc.Metadata.Annotations[prometheusPortAnnotation]; annotated != "" {
+		for _, port := range svc.Spec.Ports {
+			if strconv.Itoa(port.Port) == annotated || strings.Trim(string(port.TargetPort), `"`) == annotated {
+				endpoint.Port, endpoint.Source = strconv.Itoa(port.Port), prometheusPortAnnotation+" annotation"
+				return endpoint, nil
+			}
+		}
+	}
+	for _, port := range svc.Spec.Ports {
+		if strings.Contains(strings.ToLower(port.Name), "metrics") {
+			endpoint.Port, endpoint.Source = strconv.Itoa(port.Port), "service port "+port.Name
+			return endpoint, nil
+		}
+	}
+	for _, port := range svc.Spec.Ports {
+		if strconv.Itoa(port.Port) == PortForwardGatewayRemotePort {
+			endpoint.Port, endpoint.Source = PortForwardGatewayRemotePort, "gateway API port"
+			return endpoint, nil
+		}
+	}
+	endpoint.Port, endpoint.Source = strconv.Itoa(svc.Spec.Ports[0].Port), "first service port"
+	return endpoint, nil
+}
+
+// separatePort reports whether the metrics are served on a service port of their own, which needs a
+// port-forward besides the one of the gateway API.
+func (e GatewayMetricsEndpoint) separatePort() bool {
+	return e.URL == "" && !InClusterMode() && e.Port != PortForwardGatewayRemotePort
+}
+
+// LocalURL returns the URL the suites fetch the metrics from: GATEWAY_METRICS_URL, the in-cluster address of
+// the service in InClusterMode, or else the local port forwarded to the metrics port.
+func (e GatewayMetricsEndpoint) LocalURL() string {
+	switch {
+	case e.URL != "":
+		return e.URL
+	case InClusterMode():
+		return "http://" + InClusterServiceAddress(PortForwardGatewayService, e.Port) + e.Path
+	case e.separatePort():
+		return "http://127.0.0.1:" + GetEnv(GatewayMetricsLocalPortEnvVar, DefaultGatewayMetricsLocalPort) + e.Path
+	default:
+		return "http://127.0.0.1:" + PortForwardGatewayLocalPort + e.Path
+	}
+}
+
+func (e GatewayMetricsEndpoint) String() string {
+	return fmt.Sprintf("%s (from %s)", e.LocalURL(), e.Source)
+}
+
+// GatewayMetricsURL returns the URL of the gateway metrics, see DiscoverGatewayMetricsEndpoint.
+func GatewayMetricsURL() string {
+	return DiscoverGatewayMetricsEndpoint().LocalURL()
+}
+
+// startGatewayMetricsPortForward forwards GATEWAY_METRICS_LOCAL_PORT to the metrics port of the gateway
+// when it is not the port of its API, and returns nil otherwise.
+func startGatewayMetricsPortForward() (*PortForwarder, error) {
+	endpoint := DiscoverGatewayMetricsEndpoint()
+	if !endpoint.separatePort() {
+		return nil, nil
+	}
+	fmt.Printf("Gateway metrics served on %s\n", endpoint)
+	return StartPortForward(PortForwardGatewayService, GetEnv(GatewayMetricsLocalPortEnvVar, DefaultGatewayMetricsLocalPort), endpoint.Port)
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseGatewayMetricsEndpoint(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		service string
+		want    GatewayMetricsEndpoint
+	}{
+		{
+			"annotated target port",
+			`{"metadata":{"annotations":{"prometheus.io/port":"9090","prometheus.io/path":"internal/metrics"}},
+			  "spec":{"ports":[{"name":"http","port":8080,"targetPort":8080},{"name":"monitoring","port":9443,"targetPort":9090}]}}`,
+			GatewayMetricsEndpoint{Port: "9443", Path: "/internal/metrics", Source: "prometheus.io/port annotation"},
+		},
+		{
+			"metrics port name",
+			`{"spec":{"ports":[{"name":"http","port":8080,"targetPort":"http"},{"name":"http-metrics","port":8443,"targetPort":"metrics"}]}}`,
+			GatewayMetricsEndpoint{Port: "8443", Path: "/metrics", Source: "service port http-metrics"},
+		},
+		{
+			"API port",
+			`{"spec":{"ports":[{"name":"grpc","port":9000},{"name":"http","port":8080}]}}`,
+			GatewayMetricsEndpoint{Port: "8080", Path: "/metrics", Source: "gateway API port"},
+		},
+		{
+			"first port",
+			`{"spec":{"ports":[{"name":"http","port":80}]}}`,
+			GatewayMetricsEndpoint{Port: "80", Path: "/metrics", Source: "first service port"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoint, err := parseGatewayMetricsEndpoint([]byte(tc.service))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if endpoint != tc.want {
+				t.Errorf("Expected %+v, got %+v", tc.want, endpoint)
+			}
+		})
+	}
+
+	if _, err := parseGatewayMetricsEndpoint([]byte(`{"spec":{"ports":[]}}`)); err == nil {
+		t.Error("Expected an error for a service without ports")
+	}
+}
+
+func TestGatewayMetricsEndpointLocalURL(t *testing.T) {
+	api := GatewayMetricsEndpoint{Port: PortForwardGatewayRemotePort, Path: "/metrics"}
+	separate := GatewayMetricsEndpoint{Port: "9443", Path: "/internal/metrics"}
+
+	t.Setenv(InClusterEnvVar, "false")
+	if url := api.LocalURL(); url != "http://127.0.0.1:8081/metrics" {
+		t.Errorf("Expected the gateway port-forward, got %s", url)
+	}
+	t.Setenv(GatewayMetricsLocalPortEnvVar, "9999")
+	if url := separate.LocalURL(); url != "http://127.0.0.1:9999/internal/metrics" || !separate.separatePort() {
+		t.Errorf("Expected a port-forward of its own, got %s", url)
+	}
+
+	t.Setenv(InClusterEnvVar, "true")
+	t.Setenv(InClusterServiceNamespaceEnvVar, "orch-cluster")
+	if url := separate.LocalURL(); url != "http://cluster-connect-gateway.orch-cluster.svc.cluster.local:9443/internal/metrics" || separate.separatePort() {
+		t.Errorf("Expected the in-cluster address of the service, got %s", url)
+	}
+
+	t.Setenv(GatewayMetricsURLEnvVar, "https://metrics.kind.internal/gateway/metrics")
+	if url := GatewayMetricsURL(); url != "https://metrics.kind.internal/gateway/metrics" {
+		t.Errorf("Expected %s to win, got %s", GatewayMetricsURLEnvVar, url)
+	}
+}
+
+func TestFetchMetricsFailsOnErrorStatus(t *testing.T) {
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, `websocket_connections_total{status="succeeded"} 1`)
+	}))
+	defer server.Close()
+	t.Setenv(GatewayMetricsURLEnvVar, server.URL+"/metrics")
+
+	if _, err := FetchMetrics(); err == nil || !strings.Contains(err.Error(), server.URL) {
+		t.Errorf("Expected an error naming the endpoint, got %v", err)
+	}
+
+	status = http.StatusOK
+	metrics, err := FetchMetrics()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer metrics.Close()
+	if ok, err := ParseMetrics(metrics); err != nil || !ok {
+		t.Errorf("Expected the connection metric, got %v (%v)", ok, err)
+	}
+}
@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// StreamPodName is the downstream pod the gateway streaming specs log from, exec into and port-forward to.
+	StreamPodName      = "cluster-tests-streams"
+	StreamPodNamespace = "default"
+	StreamPodHTTPPort  = "8080"
+	// StreamPodHTTPBody is what the pod serves on StreamPodHTTPPort.
+	StreamPodHTTPBody = "cluster-tests-streams"
+	// StreamPodImageEnvVar overrides the busybox image of the pod, e.g. for a registry mirror.
+	StreamPodImageEnvVar  = "STREAM_POD_IMAGE"
+	DefaultStreamPodImage = "busybox:1.36"
+
+	streamPodReadyTimeout = 3 * time.Minute
+)
+
+// ErrStreamSessionHung is returned by StreamSession.Wait when the session neither ends nor fails in time.
+var ErrStreamSessionHung = errors.New("stream session still open")
+
+// renderStreamPod returns the manifest of a pod that serves StreamPodHTTPBody over HTTP and logs a
+// numbered tick every second.
+func renderStreamPod(image string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Pod
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+  labels:
+    app: %[1]s
+spec:
+  containers:
+    - name: streams
+      image: %[3]s
+      command:
+        - sh
+        - -c
+        - mkdir -p /www && echo %[4]s > /www/index.html && httpd -p %[5]s -h /www && i=0; while true; do i=$((i+1)); echo "tick $i"; sleep 1; done
+      ports:
+        - containerPort: %[5]s
+`, StreamPodName, StreamPodNamespace, image, StreamPodHTTPBody, StreamPodHTTPPort)
+}
+
+// CreateStreamPod creates the streaming pod in a downstream cluster and waits until it is ready.
+func CreateStreamPod(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	if err := client.ApplyManifest(renderStreamPod(GetEnv(StreamPodImageEnvVar, DefaultStreamPodImage))); err != nil {
+		return fmt.Errorf("failed to create pod %s: %w", StreamPodName, err)
+	}
+	return client.WaitForPodReady(StreamPodNamespace, StreamPodName, streamPodReadyTimeout)
+}
+
+// DeleteStreamPod deletes the streaming pod from a downstream cluster.
+func DeleteStreamPod(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.DeletePod(StreamPodNamespace, StreamPodName)
+}
+
+// FollowStreamPodLogs follows the logs of the streaming pod and returns the first count lines written
+// after the stream was opened. It fails if they do not arrive within timeout, i.e. when the logs are
+// not streamed but buffered or cut off.
+func FollowStreamPodLogs(kubeconfigPath string, count int, timeout time.Duration) ([]string, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.FollowPodLogs(ctx, StreamPodNamespace, StreamPodName)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	lines := make(chan string)
+	streamErr := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stream)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		streamErr <- scanner.Err()
+	}()
+
+	var received []string
+	deadline := time.After(timeout)
+	for len(received) < count {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return received, fmt.Errorf("log stream of %s ended after %d lines: %v", StreamPodName, len(received), <-streamErr)
+			}
+			received = append(received, line)
+		case <-deadline:
+			return received, fmt.Errorf("received %d of %d log lines of %s within %v", len(received), count, StreamPodName, timeout)
+		}
+	}
+	return received, nil
+}
+
+// ExecStreamPodWithStdin runs a shell script in the streaming pod with stdin attached and returns its output.
+func ExecStreamPodWithStdin(kubeconfigPath, stdin, script string) (string, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return "", err
+	}
+	var (
+		mu  sync.Mutex
+		out strings.Builder
+	)
+	output := &lockedWriter{mu: &mu, w: &out}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if err := client.ExecStream(ctx, StreamPodNamespace, StreamPodName, strings.NewReader(stdin), output, output, "sh", "-c", script); err != nil {
+		return out.String(), fmt.Errorf("failed to exec in %s: %w: %s", StreamPodName, err, strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
+}
+
+// StreamSession is a long-running command streaming output through the gateway, e.g. an exec session.
+type StreamSession struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.Mutex
+	lines  int
+	stderr strings.Builder
+	err    error
+}
+
+// StartStreamPodExecSession starts an exec session in the streaming pod that prints a line every second
+// until it is stopped.
+func StartStreamPodExecSession(kubeconfigPath string) (*StreamSession, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	return startStreamSession(func(ctx context.Context, stdout, stderr io.Writer) error {
+		return client.ExecStream(ctx, StreamPodNamespace, StreamPodName, nil, stdout, stderr,
+			"sh", "-c", `i=0; while true; do i=$((i+1)); echo "exec $i"; sleep 1; done`)
+	}), nil
+}
+
+// startStreamSession runs a session until it returns or the session is stopped, counting the lines it
+// writes to stdout.
+func startStreamSession(run func(ctx context.Context, stdout, stderr io.Writer) error) *StreamSession {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &StreamSession{cancel: cancel, done: make(chan struct{})}
+	reader, writer := io.Pipe()
+	result := make(chan error, 1)
+	go func() {
+		err := run(ctx, writer, &lockedWriter{mu: &s.mu, w: &s.stderr})
+		_ = writer.Close()
+		result <- err
+	}()
+
+	go func() {
+		defer close(s.done)
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			s.mu.Lock()
+			s.lines++
+			s.mu.Unlock()
+		}
+		// Keep draining so the session does not block on a line too long to scan.
+		_, _ = io.Copy(io.Discard, reader)
+		err := <-result
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err != nil {
+			s.err = fmt.Errorf("session ended after %d lines: %w: %s", s.lines, err, strings.TrimSpace(s.stderr.String()))
+		}
+	}()
+	return s
+}
+
+// Lines returns the number of lines the session has received so far.
+func (s *StreamSession) Lines() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lines
+}
+
+// Wait waits up to timeout for the session to end and returns why it ended, nil if it exited
+// successfully, or ErrStreamSessionHung if it is still open.
+func (s *StreamSession) Wait(timeout time.Duration) error {
+	select {
+	case <-s.done:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.err
+	case <-time.After(timeout):
+		return fmt.Errorf("%w after %v with %d lines received", ErrStreamSessionHung, timeout, s.Lines())
+	}
+}
+
+// Stop ends the session if it is still open.
+func (s *StreamSession) Stop() {
+	if s == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+}
+
+// lockedWriter serializes concurrent writes, and the writes with the readers of what was written.
+type lockedWriter struct {
+	mu *sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// StartStreamPodPortForward port-forwards a free local port to the HTTP port of the streaming pod and
+// returns the local port once it listens. The caller owns the returned port-forward and should release it
+// with StopPortForwards.
+func StartStreamPodPortForward(kubeconfigPath string, timeout time.Duration) (*PortForwarder, string, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, "", err
+	}
+	forwarder := NewPortForwarder(client, StreamPodNamespace, "pod/"+StreamPodName, "", StreamPodHTTPPort)
+	if err := forwarder.Start(timeout); err != nil {
+		return nil, "", fmt.Errorf("failed to start port-forward to %s: %w", StreamPodName, err)
+	}
+	return forwarder, forwarder.LocalPort(), nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderStreamPod(t *testing.T) {
+	manifest := renderStreamPod("registry.example.com/busybox:1.36")
+	for _, want := range []string{
+		"name: cluster-tests-streams",
+		"image: registry.example.com/busybox:1.36",
+		"httpd -p 8080 -h /www",
+		`echo "tick $i"`,
+		"containerPort: 8080",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("Expected %q in stream pod manifest:\n%s", want, manifest)
+		}
+	}
+}
+
+func TestStreamSession(t *testing.T) {
+	session := startStreamSession(func(ctx context.Context, stdout, stderr io.Writer) error {
+		fmt.Fprintln(stdout, "one")
+		fmt.Fprintln(stdout, "two")
+		fmt.Fprintln(stderr, "broken")
+		return errors.New("command terminated with exit code 3")
+	})
+	err := session.Wait(5 * time.Second)
+	if err == nil || errors.Is(err, ErrStreamSessionHung) || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("Expected the session to end with its error output, got %v", err)
+	}
+	if lines := session.Lines(); lines != 2 {
+		t.Errorf("Expected 2 lines, got %d", lines)
+	}
+
+	session = startStreamSession(func(ctx context.Context, stdout, stderr io.Writer) error {
+		fmt.Fprintln(stdout, "one")
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	defer session.Stop()
+	if err := session.Wait(200 * time.Millisecond); !errors.Is(err, ErrStreamSessionHung) {
+		t.Errorf("Expected the session to hang, got %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// GatewayWatchDurationEnvVar is how long the gateway watch spec keeps its watch open (e.g. "5m").
+	GatewayWatchDurationEnvVar  = "GATEWAY_WATCH_DURATION"
+	DefaultGatewayWatchDuration = 5 * time.Minute
+
+	// HeartbeatConfigMap is the downstream ConfigMap the watch spec changes to produce events.
+	HeartbeatConfigMap          = "cluster-tests-watch-heartbeat"
+	HeartbeatConfigMapNamespace = "default"
+	heartbeatAnnotation         = "cluster-tests/heartbeat"
+
+	WatchEventError    = "ERROR"
+	WatchEventBookmark = "BOOKMARK"
+
+	gatewayRolloutTimeout = 3 * time.Minute
+)
+
+// GatewayWatchDuration returns GATEWAY_WATCH_DURATION, or the default when it is unset or invalid.
+func GatewayWatchDuration() time.Duration {
+	if duration, err := time.ParseDuration(os.Getenv(GatewayWatchDurationEnvVar)); err == nil && duration > 0 {
+		return duration
+	}
+	return DefaultGatewayWatchDuration
+}
+
+// WatchEvent is one event of a downstream watch on the heartbeat ConfigMap.
+type WatchEvent struct {
+	Type            string
+	ResourceVersion string
+	// Heartbeat is the heartbeat number the ConfigMap carried, 0 if none.
+	Heartbeat int
+	// Message is set for ERROR events, e.g. when the resource version to resume from is too old.
+	Message string
+}
+
+func parseWatchEvent(line []byte) (WatchEvent, error) {
+	var raw struct {
+		Type   string `json:"type"`
+		Object struct {
+			Message  string `json:"message"`
+			Metadata struct {
+				ResourceVersion string            `json:"resourceVersion"`
+				Annotations     map[string]string `json:"annotations"`
+			} `json:"metadata"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return WatchEvent{}, fmt.Errorf("failed to parse watch event: %w", err)
+	}
+	event := WatchEvent{Type: raw.Type, ResourceVersion: raw.Object.Metadata.ResourceVersion, Message: raw.Object.Message}
+	if value, ok := raw.Object.Metadata.Annotations[heartbeatAnnotation]; ok {
+		heartbeat, err := strconv.Atoi(value)
+		if err != nil {
+			return event, fmt.Errorf("invalid heartbeat %q: %w", value, err)
+		}
+		event.Heartbeat = heartbeat
+	}
+	return event, nil
+}
+
+// DownstreamWatch is a watch on the heartbeat ConfigMap of a downstream cluster.
+type DownstreamWatch struct {
+	cancel context.CancelFunc
+	events chan WatchEvent
+	done   chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// StartHeartbeatWatch watches the heartbeat ConfigMap through the given kubeconfig, resuming after
+// resourceVersion if it is set. Bookmarks keep the stream alive when nothing changes. The API server
+// ends the watch after timeout.
+func StartHeartbeatWatch(kubeconfigPath, resourceVersion string, timeout time.Duration) (*DownstreamWatch, error) {
+	query := url.Values{}
+	query.Set("watch", "true")
+	query.Set("allowWatchBookmarks", "true")
+	query.Set("fieldSelector", "metadata.name="+HeartbeatConfigMap)
+	query.Set("timeoutSeconds", strconv.Itoa(int(timeout.Seconds())))
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+	path := fmt.Sprintf("/api/v1/namespaces/%s/configmaps?%s", HeartbeatConfigMapNamespace, query.Encode())
+
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.StreamRaw(ctx, path)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start the heartbeat watch: %w", err)
+	}
+	w := &DownstreamWatch{cancel: cancel, events: make(chan WatchEvent, 64), done: make(chan struct{})}
+
+	go func() {
+		defer close(w.done)
+		defer close(w.events)
+		defer stream.Close()
+		scanner := bufio.NewScanner(stream)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			event, err := parseWatchEvent(scanner.Bytes())
+			if err != nil {
+				w.setErr(err)
+				continue
+			}
+			w.events <- event
+		}
+		if err := scanner.Err(); err != nil {
+			w.setErr(fmt.Errorf("watch ended: %w", err))
+		}
+	}()
+	return w, nil
+}
+
+// Events returns the events of the watch; the channel is closed when the watch ends.
+func (w *DownstreamWatch) Events() <-chan WatchEvent {
+	return w.events
+}
+
+// Done is closed when the watch has ended.
+func (w *DownstreamWatch) Done() <-chan struct{} {
+	return w.done
+}
+
+// Err returns why the watch ended or the last event that could not be parsed.
+func (w *DownstreamWatch) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Stop ends the watch.
+func (w *DownstreamWatch) Stop() {
+	if w == nil {
+		return
+	}
+	w.cancel()
+	// Drain so the reader can finish.
+	for range w.events {
+	}
+	<-w.done
+}
+
+func (w *DownstreamWatch) setErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.err = err
+}
+
+// EnsureHeartbeatConfigMap creates the heartbeat ConfigMap in a downstream cluster if it is missing.
+func EnsureHeartbeatConfigMap(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	err = client.CreateConfigMap(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: HeartbeatConfigMap, Namespace: HeartbeatConfigMapNamespace},
+	})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// DeleteHeartbeatConfigMap deletes the heartbeat ConfigMap from a downstream cluster.
+func DeleteHeartbeatConfigMap(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.DeleteConfigMaps(HeartbeatConfigMapNamespace, HeartbeatConfigMap)
+}
+
+// SendHeartbeat sets the heartbeat number on the heartbeat ConfigMap, which produces a watch event.
+func SendHeartbeat(kubeconfigPath string, heartbeat int) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.PatchConfigMap(HeartbeatConfigMapNamespace, HeartbeatConfigMap, types.MergePatchType, heartbeatPatch(heartbeat))
+}
+
+// heartbeatPatch returns the merge patch that sets the heartbeat annotation.
+func heartbeatPatch(heartbeat int) []byte {
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{heartbeatAnnotation: strconv.Itoa(heartbeat)},
+		},
+	})
+	return patch
+}
+
+// RestartGateway restarts the pods of the connect gateway and waits until the new ones are available.
+// Port-forwards to the old pods end with them and have to be started again.
+func RestartGateway() error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	if err := client.RestartWorkload("default", "Deployment", gatewayDeployment); err != nil {
+		return fmt.Errorf("failed to restart the gateway: %w", err)
+	}
+	if err := client.WaitForRollout("default", "Deployment", gatewayDeployment, gatewayRolloutTimeout); err != nil {
+		return fmt.Errorf("failed to wait for the gateway rollout: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseWatchEvent(t *testing.T) {
+	event, err := parseWatchEvent([]byte(`{"type":"MODIFIED","object":{"kind":"ConfigMap","metadata":{"name":"cluster-tests-watch-heartbeat",
+		"resourceVersion":"1234","annotations":{"cluster-tests/heartbeat":"3"}}}}`))
+	if err != nil {
+		t.Fatalf("Failed to parse event: %v", err)
+	}
+	if event.Type != "MODIFIED" || event.ResourceVersion != "1234" || event.Heartbeat != 3 {
+		t.Errorf("Expected MODIFIED at 1234 with heartbeat 3, got %+v", event)
+	}
+
+	event, err = parseWatchEvent([]byte(`{"type":"BOOKMARK","object":{"kind":"ConfigMap","metadata":{"resourceVersion":"1300"}}}`))
+	if err != nil || event.Type != WatchEventBookmark || event.ResourceVersion != "1300" || event.Heartbeat != 0 {
+		t.Errorf("Expected a bookmark at 1300, got %+v (%v)", event, err)
+	}
+
+	event, err = parseWatchEvent([]byte(`{"type":"ERROR","object":{"kind":"Status","status":"Failure","message":"too old resource version: 1 (1234)","code":410}}`))
+	if err != nil || event.Type != WatchEventError || event.Message != "too old resource version: 1 (1234)" {
+		t.Errorf("Expected an error event with its message, got %+v (%v)", event, err)
+	}
+
+	if _, err := parseWatchEvent([]byte(`{"type":"MODIFIED","object":{"metadata":{"annotations":{"cluster-tests/heartbeat":"x"}}}}`)); err == nil {
+		t.Errorf("Expected an error for an invalid heartbeat")
+	}
+}
+
+func TestHeartbeatPatch(t *testing.T) {
+	want := `{"metadata":{"annotations":{"cluster-tests/heartbeat":"7"}}}`
+	if got := string(heartbeatPatch(7)); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
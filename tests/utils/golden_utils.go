@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// GoldenDir holds the committed snapshots, relative to a suite directory.
+	GoldenDir = "../testdata/golden"
+	// UpdateGoldenEnvVar rewrites the golden files with the captured snapshots instead of comparing them.
+	UpdateGoldenEnvVar = "UPDATE_GOLDEN"
+
+	goldenDiffContext = 3
+)
+
+// ErrGoldenMissing is returned by CompareGolden when no golden file has been recorded yet.
+var ErrGoldenMissing = errors.New("golden file missing")
+
+var (
+	uidPattern           = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	generatedNamePattern = regexp.MustCompile(`CLUSTER_NAME[a-z0-9-]*`)
+	// generatedSuffixPattern matches the random suffix Kubernetes appends to generated names; its alphabet
+	// has no vowels, so fixed name segments like "plane" are not mistaken for one.
+	generatedSuffixPattern = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+)
+
+// volatileMetadataFields change on every creation and are dropped from snapshots.
+var volatileMetadataFields = []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "ownerReferences", "finalizers"}
+
+// volatileAnnotationPrefixes select annotations that record controller bookkeeping rather than rendering.
+var volatileAnnotationPrefixes = []string{"kubectl.kubernetes.io/last-applied-configuration", "cluster.x-k8s.io/cloned-from", "topology.cluster.x-k8s.io/"}
+
+// CaptureRenderedClusterObjects snapshots the Cluster, its control plane and its IntelMachineTemplates as
+// rendered from a template, with volatile fields and generated names normalized, as YAML.
+func CaptureRenderedClusterObjects(namespace, clusterName, nodeGUID string) ([]byte, error) {
+	cluster, err := getObjectMap(namespace, "clusters.cluster.x-k8s.io", clusterName)
+	if err != nil {
+		return nil, err
+	}
+	objects := []map[string]any{cluster}
+
+	var ref capiObjectRef
+	if raw, ok := nestedMap(cluster, "spec", "controlPlaneRef"); ok {
+		data, _ := json.Marshal(raw)
+		_ = json.Unmarshal(data, &ref)
+	}
+	if ref.Name == "" {
+		return nil, fmt.Errorf("cluster %s/%s has no control plane yet", namespace, clusterName)
+	}
+	controlPlane, err := getObjectMap(namespace, ref.resource(), ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, controlPlane)
+
+	out, err := managementResourceJSON("intelmachinetemplates", namespace, "cluster.x-k8s.io/cluster-name="+clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list intel machine templates: %w", err)
+	}
+	var templates struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal(out, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse intel machine templates: %w", err)
+	}
+	objects = append(objects, templates.Items...)
+
+	return normalizeSnapshot(objects, map[string]string{
+		clusterName: "CLUSTER_NAME",
+		namespace:   "NAMESPACE",
+		nodeGUID:    "NODE_GUID",
+	})
+}
+
+func getObjectMap(namespace, resource, name string) (map[string]any, error) {
+	out, err := managementObjectJSON(resource, namespace, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", resource, namespace, name, err)
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(out, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse %s %s/%s: %w", resource, namespace, name, err)
+	}
+	return obj, nil
+}
+
+func nestedMap(obj map[string]any, fields ...string) (map[string]any, bool) {
+	current := obj
+	for _, field := range fields {
+		next, ok := current[field].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current = next
+	}
+	return current, true
+}
+
+// normalizeSnapshot strips status and volatile metadata, substitutes the given values with placeholders
+// and renders the objects as YAML sorted by kind and name.
+func normalizeSnapshot(objects []map[string]any, placeholders map[string]string) ([]byte, error) {
+	for _, obj := range objects {
+		delete(obj, "status")
+		metadata, _ := obj["metadata"].(map[string]any)
+		for _, field := range volatileMetadataFields {
+			delete(metadata, field)
+		}
+		if annotations, ok := metadata["annotations"].(map[string]any); ok {
+			for key := range annotations {
+				for _, prefix := range volatileAnnotationPrefixes {
+					if strings.HasPrefix(key, prefix) {
+						delete(annotations, key)
+					}
+				}
+			}
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+		if spec, ok := obj["spec"].(map[string]any); ok {
+			// Filled in by the infrastructure provider once the node is known.
+			delete(spec, "controlPlaneEndpoint")
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		return snapshotKey(objects[i]) < snapshotKey(objects[j])
+	})
+
+	data, err := yaml.Marshal(objects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render snapshot: %w", err)
+	}
+
+	// Replace longer values first so a value containing another one is substituted as a whole.
+	values := make([]string, 0, len(placeholders))
+	for value := range placeholders {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	snapshot := string(data)
+	for _, value := range values {
+		snapshot = strings.ReplaceAll(snapshot, value, placeholders[value])
+	}
+	snapshot = uidPattern.ReplaceAllString(snapshot, "UID")
+	snapshot = generatedNamePattern.ReplaceAllStringFunc(snapshot, func(name string) string {
+		return generatedSuffixPattern.ReplaceAllString(name, "-GENERATED")
+	})
+	return []byte(snapshot), nil
+}
+
+func snapshotKey(obj map[string]any) string {
+	kind, _ := obj["kind"].(string)
+	name := ""
+	if metadata, ok := obj["metadata"].(map[string]any); ok {
+		name, _ = metadata["name"].(string)
+	}
+	return kind + "/" + name
+}
+
+// CompareGolden compares a snapshot against the golden file name.yaml in dir and returns an error with
+// a diff when they differ. With UPDATE_GOLDEN=true the golden file is (re)written instead.
+func CompareGolden(dir, name string, actual []byte) error {
+	path := filepath.Join(dir, name+".yaml")
+	if os.Getenv(UpdateGoldenEnvVar) == "true" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create golden directory %s: %w", dir, err)
+		}
+		if err := os.WriteFile(path, actual, 0o644); err != nil {
+			return fmt.Errorf("failed to write golden file %s: %w", path, err)
+		}
+		fmt.Printf("Golden file %s updated\n", path)
+		return nil
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %s; run with %s=true to record it", ErrGoldenMissing, path, UpdateGoldenEnvVar)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read golden file %s: %w", path, err)
+	}
+	if string(expected) == string(actual) {
+		return nil
+	}
+	return fmt.Errorf("snapshot differs from %s (run with %s=true to accept the change):\n%s",
+		path, UpdateGoldenEnvVar, lineDiff(string(expected), string(actual), goldenDiffContext))
+}
+
+// lineDiff renders a unified-style diff of two texts with the given number of context lines.
+func lineDiff(expected, actual string, context int) string {
+	a := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte
+		text string
+	}
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		default:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		}
+	}
+
+	var out strings.Builder
+	lastPrinted := -1
+	for k, line := range lines {
+		if line.op == ' ' {
+			continue
+		}
+		start := max(k-context, lastPrinted+1)
+		if lastPrinted >= 0 && start > lastPrinted+1 {
+			out.WriteString("...\n")
+		}
+		for c := start; c < k; c++ {
+			fmt.Fprintf(&out, "  %s\n", lines[c].text)
+		}
+		fmt.Fprintf(&out, "%c %s\n", line.op, line.text)
+		lastPrinted = k
+		for c := k + 1; c < len(lines) && c <= k+context && lines[c].op == ' '; c++ {
+			fmt.Fprintf(&out, "  %s\n", lines[c].text)
+			lastPrinted = c
+		}
+	}
+	return out.String()
+}
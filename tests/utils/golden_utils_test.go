@@ -0,0 +1,112 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeSnapshot(t *testing.T) {
+	objects := []map[string]any{
+		{
+			"kind": "KThreesControlPlane",
+			"metadata": map[string]any{
+				"name":              "demo-cluster-control-plane-x7k2p",
+				"namespace":         "53cd37b9-66b2-4cc8-b080-3722ed7af64a",
+				"uid":               "0b9a3c6e-1f2d-4e5a-8b7c-9d0e1f2a3b4c",
+				"resourceVersion":   "12345",
+				"creationTimestamp": "2026-01-01T00:00:00Z",
+				"annotations": map[string]any{
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+			},
+			"spec":   map[string]any{"version": "v1.30.6+k3s1"},
+			"status": map[string]any{"ready": true},
+		},
+		{
+			"kind": "Cluster",
+			"metadata": map[string]any{
+				"name":      "demo-cluster",
+				"namespace": "53cd37b9-66b2-4cc8-b080-3722ed7af64a",
+				"labels":    map[string]any{"node": "12345678-1234-1234-1234-123456789012"},
+			},
+			"spec": map[string]any{
+				"controlPlaneEndpoint": map[string]any{"host": "10.0.0.1", "port": 6443},
+				"owner":                "0b9a3c6e-1f2d-4e5a-8b7c-9d0e1f2a3b4c",
+			},
+		},
+	}
+
+	out, err := normalizeSnapshot(objects, map[string]string{
+		"demo-cluster":                         "CLUSTER_NAME",
+		"53cd37b9-66b2-4cc8-b080-3722ed7af64a": "NAMESPACE",
+		"12345678-1234-1234-1234-123456789012": "NODE_GUID",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	snapshot := string(out)
+
+	if strings.Index(snapshot, "kind: Cluster\n") > strings.Index(snapshot, "kind: KThreesControlPlane") {
+		t.Errorf("Expected objects sorted by kind, got:\n%s", snapshot)
+	}
+	for _, want := range []string{
+		"name: CLUSTER_NAME\n",
+		"name: CLUSTER_NAME-control-plane-GENERATED",
+		"namespace: NAMESPACE",
+		"node: NODE_GUID",
+		"owner: UID",
+		"version: v1.30.6+k3s1",
+	} {
+		if !strings.Contains(snapshot, want) {
+			t.Errorf("Expected snapshot to contain %q, got:\n%s", want, snapshot)
+		}
+	}
+	for _, unwanted := range []string{"status", "resourceVersion", "creationTimestamp", "annotations", "controlPlaneEndpoint", "x7k2p"} {
+		if strings.Contains(snapshot, unwanted) {
+			t.Errorf("Expected snapshot not to contain %q, got:\n%s", unwanted, snapshot)
+		}
+	}
+}
+
+func TestCompareGolden(t *testing.T) {
+	dir := t.TempDir()
+
+	err := CompareGolden(dir, "baseline", []byte("a: 1\n"))
+	if !errors.Is(err, ErrGoldenMissing) {
+		t.Errorf("Expected ErrGoldenMissing, got %v", err)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "true")
+	if err := CompareGolden(dir, "baseline", []byte("a: 1\nb: 2\n")); err != nil {
+		t.Fatalf("Expected no error when updating, got %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "baseline.yaml"))
+	if err != nil || string(data) != "a: 1\nb: 2\n" {
+		t.Fatalf("Expected golden file to be written, got %q, %v", data, err)
+	}
+
+	t.Setenv(UpdateGoldenEnvVar, "")
+	if err := CompareGolden(dir, "baseline", []byte("a: 1\nb: 2\n")); err != nil {
+		t.Errorf("Expected matching snapshot, got %v", err)
+	}
+	err = CompareGolden(dir, "baseline", []byte("a: 1\nb: 3\n"))
+	if err == nil || !strings.Contains(err.Error(), "- b: 2\n+ b: 3\n") {
+		t.Errorf("Expected a diff of the changed line, got %v", err)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	expected := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	actual := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	want := "  1\n  2\n- 3\n+ three\n  4\n  5\n  6\n...\n  8\n  9\n  10\n+ 11\n"
+	if got := lineDiff(expected, actual, 3); got != want {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", want, got)
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// CISLiteChecksEnvVar enables the CIS-lite hardening checks against downstream clusters.
+	CISLiteChecksEnvVar = "CIS_LITE_CHECKS"
+)
+
+// CISCheckResult is the outcome of a single CIS benchmark probe.
+type CISCheckResult struct {
+	ID          string
+	Description string
+	Passed      bool
+	Skipped     bool
+	Detail      string
+}
+
+// CISLiteChecksEnabled reports whether the optional CIS-lite checks should run.
+func CISLiteChecksEnabled() bool {
+	return os.Getenv(CISLiteChecksEnvVar) == "true"
+}
+
+// RunCISLiteChecks runs a small subset of the CIS Kubernetes benchmark against a downstream cluster.
+// Kubelet settings are read through the API server; API server and etcd exposure are probed on the
+// edge node and reported as skipped when the node is not reachable.
+func RunCISLiteChecks(kubeconfigPath string) ([]CISCheckResult, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := client.ListNodes()
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("the downstream cluster has no node")
+	}
+	configz, err := client.GetRaw(fmt.Sprintf("/api/v1/nodes/%s/proxy/configz", nodes[0].Name), kubeClientTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := evaluateKubeletConfig(configz)
+	if err != nil {
+		return nil, err
+	}
+
+	apiServer := CISCheckResult{ID: "1.2.1", Description: "API server rejects anonymous requests"}
+	if out, err := ExecOnEdgeNode("curl -sk -o /dev/null -w '%{http_code}' https://127.0.0.1:6443/version"); err != nil {
+		apiServer.Skipped = true
+		apiServer.Detail = err.Error()
+	} else {
+		code := strings.TrimSpace(string(out))
+		apiServer.Passed = code == "401" || code == "403"
+		apiServer.Detail = "anonymous /version returned HTTP " + code
+	}
+	results = append(results, apiServer)
+
+	if out, err := ExecOnEdgeNode("ss -ltnH"); err != nil {
+		results = append(results, CISCheckResult{ID: "2.x", Description: "etcd is not exposed on external interfaces", Skipped: true, Detail: err.Error()})
+	} else {
+		results = append(results, evaluateEtcdListeners(string(out)))
+	}
+
+	return results, nil
+}
+
+// FailedCISChecks returns the checks that ran and did not pass.
+func FailedCISChecks(results []CISCheckResult) []CISCheckResult {
+	var failed []CISCheckResult
+	for _, r := range results {
+		if !r.Passed && !r.Skipped {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+// evaluateKubeletConfig checks the kubelet configuration returned by the configz endpoint.
+func evaluateKubeletConfig(configz []byte) ([]CISCheckResult, error) {
+	var cfg struct {
+		KubeletConfig struct {
+			Authentication struct {
+				Anonymous struct {
+					Enabled *bool `json:"enabled"`
+				} `json:"anonymous"`
+				X509 struct {
+					ClientCAFile string `json:"clientCAFile"`
+				} `json:"x509"`
+			} `json:"authentication"`
+			Authorization struct {
+				Mode string `json:"mode"`
+			} `json:"authorization"`
+			ReadOnlyPort int `json:"readOnlyPort"`
+		} `json:"kubeletconfig"`
+	}
+	if err := json.Unmarshal(configz, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse kubelet configz: %w", err)
+	}
+	kc := cfg.KubeletConfig
+
+	anonymousEnabled := kc.Authentication.Anonymous.Enabled == nil || *kc.Authentication.Anonymous.Enabled
+	return []CISCheckResult{
+		{
+			ID:          "4.2.1",
+			Description: "kubelet anonymous authentication is disabled",
+			Passed:      !anonymousEnabled,
+			Detail:      fmt.Sprintf("authentication.anonymous.enabled=%t", anonymousEnabled),
+		},
+		{
+			ID:          "4.2.2",
+			Description: "kubelet authorization mode is not AlwaysAllow",
+			Passed:      kc.Authorization.Mode != "" && kc.Authorization.Mode != "AlwaysAllow",
+			Detail:      "authorization.mode=" + kc.Authorization.Mode,
+		},
+		{
+			ID:          "4.2.3",
+			Description: "kubelet client CA file is set",
+			Passed:      kc.Authentication.X509.ClientCAFile != "",
+			Detail:      "authentication.x509.clientCAFile=" + kc.Authentication.X509.ClientCAFile,
+		},
+		{
+			ID:          "4.2.4",
+			Description: "kubelet read-only port is disabled",
+			Passed:      kc.ReadOnlyPort == 0,
+			Detail:      fmt.Sprintf("readOnlyPort=%d", kc.ReadOnlyPort),
+		},
+	}, nil
+}
+
+// evaluateEtcdListeners checks `ss -ltnH` output for etcd ports bound to all interfaces.
+// k3s without embedded etcd has no listener at all, which also passes.
+func evaluateEtcdListeners(ssOutput string) CISCheckResult {
+	result := CISCheckResult{ID: "2.x", Description: "etcd is not exposed on external interfaces", Passed: true}
+	var exposed []string
+	for _, line := range strings.Split(ssOutput, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		local := fields[3]
+		idx := strings.LastIndex(local, ":")
+		if idx < 0 {
+			continue
+		}
+		host, port := local[:idx], local[idx+1:]
+		if port != "2379" && port != "2380" {
+			continue
+		}
+		if host == "0.0.0.0" || host == "*" || host == "[::]" {
+			exposed = append(exposed, local)
+		}
+	}
+	if len(exposed) > 0 {
+		result.Passed = false
+		result.Detail = "etcd listening on " + strings.Join(exposed, ", ")
+	}
+	return result
+}
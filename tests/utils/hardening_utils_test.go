@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "testing"
+
+func TestEvaluateKubeletConfig(t *testing.T) {
+	hardened := `{"kubeletconfig":{"authentication":{"anonymous":{"enabled":false},"x509":{"clientCAFile":"/var/lib/rancher/k3s/agent/client-ca.crt"}},"authorization":{"mode":"Webhook"}}}`
+	results, err := evaluateKubeletConfig([]byte(hardened))
+	if err != nil {
+		t.Fatalf("Failed to evaluate kubelet config: %v", err)
+	}
+	if failed := FailedCISChecks(results); len(failed) != 0 {
+		t.Errorf("Expected hardened kubelet to pass, failed: %+v", failed)
+	}
+
+	insecure := `{"kubeletconfig":{"authentication":{"anonymous":{"enabled":true}},"authorization":{"mode":"AlwaysAllow"},"readOnlyPort":10255}}`
+	results, err = evaluateKubeletConfig([]byte(insecure))
+	if err != nil {
+		t.Fatalf("Failed to evaluate kubelet config: %v", err)
+	}
+	if failed := FailedCISChecks(results); len(failed) != len(results) {
+		t.Errorf("Expected every check to fail for an insecure kubelet, failed: %+v", failed)
+	}
+}
+
+func TestEvaluateKubeletConfigRejectsInvalidJSON(t *testing.T) {
+	if _, err := evaluateKubeletConfig([]byte("not json")); err == nil {
+		t.Error("Expected an error for invalid configz output")
+	}
+}
+
+func TestEvaluateEtcdListeners(t *testing.T) {
+	testCases := []struct {
+		name   string
+		output string
+		passed bool
+	}{
+		{"no etcd", "LISTEN 0 4096 127.0.0.1:6444 0.0.0.0:*\n", true},
+		{"loopback etcd", "LISTEN 0 4096 127.0.0.1:2379 0.0.0.0:*\nLISTEN 0 4096 192.168.1.10:2380 0.0.0.0:*\n", true},
+		{"wildcard etcd", "LISTEN 0 4096 0.0.0.0:2379 0.0.0.0:*\n", false},
+		{"ipv6 wildcard etcd", "LISTEN 0 4096 [::]:2380 [::]:*\n", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := evaluateEtcdListeners(tc.output)
+			if result.Passed != tc.passed {
+				t.Errorf("Expected passed=%t, got %+v", tc.passed, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+const (
+	// HTTPRecordingEnvVar makes the client of NewHTTPClient record its traffic, which is written as a HAR file
+	// of the spec when it fails.
+	HTTPRecordingEnvVar = "HTTP_RECORDING"
+	// HTTPRecordingBodyLimitEnvVar is the number of bytes kept of each request and response body.
+	HTTPRecordingBodyLimitEnvVar  = "HTTP_RECORDING_BODY_LIMIT"
+	DefaultHTTPRecordingBodyLimit = 16 * 1024
+	// HTTPRecordingMaxEntriesEnvVar is the number of exchanges kept per spec; older ones are dropped first.
+	HTTPRecordingMaxEntriesEnvVar  = "HTTP_RECORDING_MAX_ENTRIES"
+	DefaultHTTPRecordingMaxEntries = 500
+
+	redactedHeaderValue = "REDACTED"
+)
+
+// redactedHeaders carry credentials and never make it into a recording.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// httpRecording is the traffic of the running spec.
+var httpRecording = &HTTPRecorder{}
+
+// HTTPRecordingEnabled reports whether the traffic of NewHTTPClient is recorded.
+func HTTPRecordingEnabled() bool {
+	return os.Getenv(HTTPRecordingEnvVar) == "true"
+}
+
+// httpExchange is a recorded request and its response, or the error it got instead.
+type httpExchange struct {
+	Started        time.Time
+	Duration       time.Duration
+	Method         string
+	URL            string
+	RequestHeader  http.Header
+	RequestBody    *recordedBody
+	StatusCode     int
+	Status         string
+	ResponseHeader http.Header
+	ResponseBody   *recordedBody
+	Err            string
+}
+
+// recordedBody keeps the first limit bytes of a body and counts the rest.
+type recordedBody struct {
+	mu    sync.Mutex
+	limit int
+	data  []byte
+	size  int64
+}
+
+func (b *recordedBody) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if room := b.limit - len(b.data); room > 0 {
+		if room > len(p) {
+			room = len(p)
+		}
+		b.data = append(b.data, p[:room]...)
+	}
+	b.size += int64(len(p))
+	return len(p), nil
+}
+
+// content returns the kept bytes, the size of the whole body and whether it was truncated.
+func (b *recordedBody) content() (string, int64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data), b.size, b.size > int64(len(b.data))
+}
+
+// teeReadCloser records what is read of a response body, so streamed responses are recorded as far as the
+// spec reads them without being buffered ahead.
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// HTTPRecorder keeps the exchanges of the running spec.
+type HTTPRecorder struct {
+	mu        sync.Mutex
+	exchanges []*httpExchange
+	dropped   int
+}
+
+// add appends an exchange, dropping the oldest one past HTTP_RECORDING_MAX_ENTRIES.
+func (r *HTTPRecorder) add(exchange *httpExchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = append(r.exchanges, exchange)
+	if max := positiveIntEnv(HTTPRecordingMaxEntriesEnvVar, DefaultHTTPRecordingMaxEntries); len(r.exchanges) > max {
+		r.dropped += len(r.exchanges) - max
+		r.exchanges = append([]*httpExchange(nil), r.exchanges[len(r.exchanges)-max:]...)
+	}
+}
+
+// snapshot returns the recorded exchanges, oldest first, and the number dropped to stay within the limit.
+func (r *HTTPRecorder) snapshot() ([]*httpExchange, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*httpExchange(nil), r.exchanges...), r.dropped
+}
+
+// Reset forgets the recorded exchanges.
+func (r *HTTPRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.exchanges = nil
+	r.dropped = 0
+}
+
+// RecordingTransport records every exchange it sends into a recorder, with the credentials of the headers
+// redacted and the bodies truncated to HTTP_RECORDING_BODY_LIMIT.
+type RecordingTransport struct {
+	Transport http.RoundTripper
+	// Recorder defaults to the recorder of the running spec.
+	Recorder *HTTPRecorder
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := t.Recorder
+	if recorder == nil {
+		recorder = httpRecording
+	}
+	limit := positiveIntEnv(HTTPRecordingBodyLimitEnvVar, DefaultHTTPRecordingBodyLimit)
+
+	exchange := &httpExchange{
+		Started:       time.Now(),
+		Method:        req.Method,
+		URL:           req.URL.String(),
+		RequestHeader: redactHeader(req.Header),
+	}
+	// Only replayable bodies are recorded, as reading any other one would consume it.
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			exchange.RequestBody = &recordedBody{limit: limit}
+			_, _ = io.Copy(exchange.RequestBody, body)
+			body.Close()
+		}
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	exchange.Duration = time.Since(exchange.Started)
+	if err != nil {
+		exchange.Err = err.Error()
+		recorder.add(exchange)
+		return nil, err
+	}
+	exchange.StatusCode = resp.StatusCode
+	exchange.Status = resp.Status
+	exchange.ResponseHeader = redactHeader(resp.Header)
+	exchange.ResponseBody = &recordedBody{limit: limit}
+	resp.Body = teeReadCloser{Reader: io.TeeReader(resp.Body, exchange.ResponseBody), Closer: resp.Body}
+	recorder.add(exchange)
+	return resp, nil
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range redactedHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, redactedHeaderValue)
+		}
+	}
+	return redacted
+}
+
+// harLog is the subset of HAR 1.2 browsers and HAR viewers need to replay the exchanges.
+type harLog struct {
+	Log struct {
+		Version string     `json:"version"`
+		Creator harCreator `json:"creator"`
+		Comment string     `json:"comment,omitempty"`
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	values := []harNameValue{}
+	for _, name := range sortedHeaderNames(header) {
+		for _, value := range header[name] {
+			values = append(values, harNameValue{Name: name, Value: value})
+		}
+	}
+	return values
+}
+
+func sortedHeaderNames(header http.Header) []string {
+	names := make(map[string]bool, len(header))
+	for name := range header {
+		names[name] = true
+	}
+	return sortedKeys(names)
+}
+
+// HAR returns the recorded exchanges as a HAR 1.2 document.
+func (r *HTTPRecorder) HAR() ([]byte, error) {
+	exchanges, dropped := r.snapshot()
+	var har harLog
+	har.Log.Version = "1.2"
+	har.Log.Creator = harCreator{Name: "cluster-tests", Version: "1"}
+	if dropped > 0 {
+		har.Log.Comment = fmt.Sprintf("%d older exchanges were dropped, see %s", dropped, HTTPRecordingMaxEntriesEnvVar)
+	}
+	har.Log.Entries = make([]harEntry, 0, len(exchanges))
+
+	for _, exchange := range exchanges {
+		ms := float64(exchange.Duration) / float64(time.Millisecond)
+		entry := harEntry{
+			StartedDateTime: exchange.Started,
+			Time:            ms,
+			Timings:         harTimings{Send: 0, Wait: ms, Receive: 0},
+			Comment:         exchange.Err,
+			Request: harRequest{
+				Method:      exchange.Method,
+				URL:         exchange.URL,
+				HTTPVersion: "HTTP/1.1",
+				Headers:     harHeaders(exchange.RequestHeader),
+				QueryString: []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+			Response: harResponse{
+				Status:      exchange.StatusCode,
+				StatusText:  exchange.Status,
+				HTTPVersion: "HTTP/1.1",
+				Headers:     harHeaders(exchange.ResponseHeader),
+				HeadersSize: -1,
+				BodySize:    -1,
+			},
+		}
+		if u, err := url.Parse(exchange.URL); err == nil {
+			query := u.Query()
+			for _, name := range sortedHeaderNames(http.Header(query)) {
+				for _, value := range query[name] {
+					entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+				}
+			}
+		}
+		if exchange.RequestBody != nil {
+			text, size, truncated := exchange.RequestBody.content()
+			entry.Request.BodySize = size
+			entry.Request.PostData = &harPostData{MimeType: exchange.RequestHeader.Get("Content-Type"), Text: text}
+			if truncated {
+				entry.Comment = joinComment(entry.Comment, fmt.Sprintf("request body truncated to %d of %d bytes", len(text), size))
+			}
+		}
+		if exchange.ResponseBody != nil {
+			text, size, truncated := exchange.ResponseBody.content()
+			entry.Response.BodySize = size
+			entry.Response.Content = harContent{Size: size, MimeType: exchange.ResponseHeader.Get("Content-Type"), Text: text}
+			if truncated {
+				entry.Response.Content.Comment = fmt.Sprintf("truncated to %d of %d bytes", len(text), size)
+			}
+		}
+		har.Log.Entries = append(har.Log.Entries, entry)
+	}
+	return json.MarshalIndent(har, "", "  ")
+}
+
+func joinComment(comment, more string) string {
+	if comment == "" {
+		return more
+	}
+	return comment + "; " + more
+}
+
+// WriteHAR writes the recorded exchanges as a HAR file in dir and returns its path.
+func (r *HTTPRecorder) WriteHAR(dir, name string) (string, error) {
+	data, err := r.HAR()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create HTTP recording directory %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, name+".har")
+	if err := WriteArtifact(path, data); err != nil {
+		return "", fmt.Errorf("failed to write HTTP recording %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// RegisterHTTPRecording writes the traffic recorded by NewHTTPClient since the previous spec, BeforeAll
+// nodes included, to <FAILURE_ARTIFACTS_DIR>/http-traffic when a spec fails and HTTPRecordingEnabled.
+// diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterHTTPRecording() bool {
+	ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+		defer httpRecording.Reset()
+		if !HTTPRecordingEnabled() || !report.Failed() {
+			return
+		}
+		path, err := httpRecording.WriteHAR(filepath.Join(FailureArtifactsDir(), "http-traffic"), specArtifactName(report))
+		if err != nil {
+			fmt.Printf("Failed to write the HTTP traffic of the spec: %v\n", err)
+			return
+		}
+		fmt.Printf("HTTP traffic of the failed spec written to %s\n", path)
+	})
+	return true
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type recordedHAR struct {
+	Log struct {
+		Comment string `json:"comment"`
+		Entries []struct {
+			Comment string `json:"comment"`
+			Request struct {
+				Method      string         `json:"method"`
+				URL         string         `json:"url"`
+				Headers     []harNameValue `json:"headers"`
+				QueryString []harNameValue `json:"queryString"`
+				PostData    *harPostData   `json:"postData"`
+			} `json:"request"`
+			Response struct {
+				Status  int            `json:"status"`
+				Headers []harNameValue `json:"headers"`
+				Content harContent     `json:"content"`
+			} `json:"response"`
+		} `json:"entries"`
+	} `json:"log"`
+}
+
+func headerValue(headers []harNameValue, name string) string {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return header.Value
+		}
+	}
+	return ""
+}
+
+func TestRecordingTransport(t *testing.T) {
+	t.Setenv(HTTPRecordingBodyLimitEnvVar, "8")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"message":"bad request body"}`)
+	}))
+	defer server.Close()
+
+	recorder := &HTTPRecorder{}
+	client := &http.Client{Transport: &RecordingTransport{Transport: http.DefaultTransport, Recorder: recorder}}
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v2/clusters?filter=a", strings.NewReader(`{"name":"cluster"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"message":"bad request body"}` {
+		t.Errorf("Expected the whole body to reach the caller, got %q", body)
+	}
+
+	data, err := recorder.HAR()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var har recordedHAR
+	if err := json.Unmarshal(data, &har); err != nil {
+		t.Fatalf("Expected a HAR document, got %v", err)
+	}
+	if len(har.Log.Entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %s", data)
+	}
+	entry := har.Log.Entries[0]
+	if entry.Request.Method != http.MethodPost || !strings.HasSuffix(entry.Request.URL, "/v2/clusters?filter=a") {
+		t.Errorf("Expected the request to be recorded, got %s %s", entry.Request.Method, entry.Request.URL)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0] != (harNameValue{Name: "filter", Value: "a"}) {
+		t.Errorf("Expected the query string to be recorded, got %v", entry.Request.QueryString)
+	}
+	if value := headerValue(entry.Request.Headers, "Authorization"); value != redactedHeaderValue {
+		t.Errorf("Expected the Authorization header to be redacted, got %q", value)
+	}
+	if value := headerValue(entry.Response.Headers, "Set-Cookie"); value != redactedHeaderValue {
+		t.Errorf("Expected the Set-Cookie header to be redacted, got %q", value)
+	}
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != `{"name":` || !strings.Contains(entry.Comment, "8 of 18 bytes") {
+		t.Errorf("Expected the request body truncated to 8 bytes, got %+v (%q)", entry.Request.PostData, entry.Comment)
+	}
+	if entry.Response.Status != http.StatusBadRequest || entry.Response.Content.Text != `{"messag` ||
+		entry.Response.Content.Size != 30 || entry.Response.Content.Comment == "" {
+		t.Errorf("Expected the response body truncated to 8 of 30 bytes, got %+v", entry.Response.Content)
+	}
+}
+
+func TestRecordingTransportRecordsErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	recorder := &HTTPRecorder{}
+	client := &http.Client{Transport: &RecordingTransport{Transport: http.DefaultTransport, Recorder: recorder}}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("Expected an error from a closed server")
+	}
+	exchanges, _ := recorder.snapshot()
+	if len(exchanges) != 1 || exchanges[0].Err == "" || exchanges[0].ResponseBody != nil {
+		t.Errorf("Expected one exchange with the error, got %+v", exchanges)
+	}
+}
+
+func TestHTTPRecorderDropsOldestExchanges(t *testing.T) {
+	t.Setenv(HTTPRecordingMaxEntriesEnvVar, "2")
+	recorder := &HTTPRecorder{}
+	for _, url := range []string{"a", "b", "c"} {
+		recorder.add(&httpExchange{URL: url})
+	}
+	exchanges, dropped := recorder.snapshot()
+	if len(exchanges) != 2 || exchanges[0].URL != "b" || dropped != 1 {
+		t.Errorf("Expected the last 2 exchanges and 1 dropped, got %d exchanges and %d dropped", len(exchanges), dropped)
+	}
+
+	dir := t.TempDir()
+	path, err := recorder.WriteHAR(dir, "spec")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if path != filepath.Join(dir, "spec.har") {
+		t.Errorf("Expected the HAR file in %s, got %s", dir, path)
+	}
+	data, _ := os.ReadFile(path)
+	var har recordedHAR
+	if err := json.Unmarshal(data, &har); err != nil || !strings.Contains(har.Log.Comment, "1 older exchanges") {
+		t.Errorf("Expected the dropped exchanges to be noted, got %s", data)
+	}
+
+	recorder.Reset()
+	if exchanges, dropped := recorder.snapshot(); len(exchanges) != 0 || dropped != 0 {
+		t.Errorf("Expected Reset to forget the exchanges, got %d and %d dropped", len(exchanges), dropped)
+	}
+}
+
+func TestNewHTTPClientRecordsWhenEnabled(t *testing.T) {
+	httpRecording.Reset()
+	defer httpRecording.Reset()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	for _, enabled := range []string{"false", "true"} {
+		t.Setenv(HTTPRecordingEnvVar, enabled)
+		resp, err := NewHTTPClient().Get(server.URL)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		resp.Body.Close()
+	}
+	if exchanges, _ := httpRecording.snapshot(); len(exchanges) != 1 {
+		t.Errorf("Expected only the request sent with recording enabled to be recorded, got %d", len(exchanges))
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tuning of the transport shared by the HTTP clients of the suites. Long suites send thousands of requests
+// through port-forwards; without enough idle connections to reuse, each one opens a new local port.
+const (
+	HTTPMaxIdleConnsEnvVar         = "HTTP_MAX_IDLE_CONNS"
+	DefaultHTTPMaxIdleConns        = 100
+	HTTPMaxIdleConnsPerHostEnvVar  = "HTTP_MAX_IDLE_CONNS_PER_HOST"
+	DefaultHTTPMaxIdleConnsPerHost = 32
+	HTTPIdleConnTimeoutEnvVar      = "HTTP_IDLE_CONN_TIMEOUT"
+	DefaultHTTPIdleConnTimeout     = 90 * time.Second
+	HTTPKeepAliveEnvVar            = "HTTP_KEEP_ALIVE"
+	DefaultHTTPKeepAlive           = 30 * time.Second
+	HTTPDisableKeepAlivesEnvVar    = "HTTP_DISABLE_KEEP_ALIVES"
+	// RequestLoopCountEnvVar is the number of requests of the sustained request loop spec.
+	RequestLoopCountEnvVar  = "HTTP_LOOP_REQUESTS"
+	DefaultRequestLoopCount = 2000
+
+	httpDialTimeout         = 30 * time.Second
+	httpTLSHandshakeTimeout = 10 * time.Second
+	// requestLoopWindows splits a request loop into windows whose latencies are compared.
+	requestLoopWindows             = 10
+	maxReportedRequestLoopFailures = 5
+)
+
+var (
+	sharedTransportOnce sync.Once
+	sharedTransport     *http.Transport
+)
+
+// HTTPTransportOptions tunes the connection pooling of an HTTP transport.
+type HTTPTransportOptions struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DisableKeepAlives   bool
+}
+
+// HTTPTransportOptionsFromEnv returns the transport options of the HTTP_* variables, with the defaults for
+// the ones that are not set or not valid.
+func HTTPTransportOptionsFromEnv() HTTPTransportOptions {
+	return HTTPTransportOptions{
+		MaxIdleConns:        positiveIntEnv(HTTPMaxIdleConnsEnvVar, DefaultHTTPMaxIdleConns),
+		MaxIdleConnsPerHost: positiveIntEnv(HTTPMaxIdleConnsPerHostEnvVar, DefaultHTTPMaxIdleConnsPerHost),
+		IdleConnTimeout:     positiveDurationEnv(HTTPIdleConnTimeoutEnvVar, DefaultHTTPIdleConnTimeout),
+		KeepAlive:           positiveDurationEnv(HTTPKeepAliveEnvVar, DefaultHTTPKeepAlive),
+		DisableKeepAlives:   strings.EqualFold(os.Getenv(HTTPDisableKeepAlivesEnvVar), "true"),
+	}
+}
+
+func positiveIntEnv(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func positiveDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// NewHTTPTransport returns a transport with the proxy settings of the environment and the given pooling.
+func NewHTTPTransport(opts HTTPTransportOptions) *http.Transport {
+	dialer := &net.Dialer{Timeout: httpDialTimeout, KeepAlive: opts.KeepAlive}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		DisableKeepAlives:     opts.DisableKeepAlives,
+		TLSHandshakeTimeout:   httpTLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// SharedHTTPTransport returns the transport of NewHTTPClient, tuned by HTTPTransportOptionsFromEnv. Sharing
+// it lets every client of the process reuse the same idle connections.
+func SharedHTTPTransport() *http.Transport {
+	sharedTransportOnce.Do(func() {
+		sharedTransport = NewHTTPTransport(HTTPTransportOptionsFromEnv())
+	})
+	return sharedTransport
+}
+
+// RequestLoopCount returns the number of requests of the sustained request loop spec, from
+// HTTP_LOOP_REQUESTS or the default.
+func RequestLoopCount() int {
+	return positiveIntEnv(RequestLoopCountEnvVar, DefaultRequestLoopCount)
+}
+
+// RequestLoopResult summarizes a sustained loop of requests.
+type RequestLoopResult struct {
+	Requests          int
+	Failures          []string
+	NewConnections    int
+	ReusedConnections int
+	// FirstWindow and LastWindow are the median latencies of the first and the last tenth of the requests.
+	FirstWindow time.Duration
+	LastWindow  time.Duration
+	Elapsed     time.Duration
+}
+
+// Degraded reports whether the latency of the last window grew by more than maxRatio over the first one,
+// ignoring growth under slack that is noise on a local port-forward.
+func (r RequestLoopResult) Degraded(maxRatio float64, slack time.Duration) bool {
+	growth := r.LastWindow - r.FirstWindow
+	return growth > slack && float64(r.LastWindow) > maxRatio*float64(r.FirstWindow)
+}
+
+func (r RequestLoopResult) String() string {
+	return fmt.Sprintf("%d requests in %v, %d failures, %d new and %d reused connections, median latency %v first and %v last",
+		r.Requests, r.Elapsed.Round(time.Millisecond), len(r.Failures), r.NewConnections, r.ReusedConnections,
+		r.FirstWindow, r.LastWindow)
+}
+
+// RunRequestLoop sends count requests one after the other with client, counting the connections it opens
+// and the requests that fail or do not get a 2xx.
+func RunRequestLoop(client *http.Client, newRequest func() (*http.Request, error), count int) RequestLoopResult {
+	result := RequestLoopResult{Requests: count}
+	var mu sync.Mutex
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			mu.Lock()
+			defer mu.Unlock()
+			if info.Reused {
+				result.ReusedConnections++
+			} else {
+				result.NewConnections++
+			}
+		},
+	}
+
+	latencies := make([]time.Duration, 0, count)
+	failures := 0
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		req, err := newRequest()
+		if err != nil {
+			failures++
+			result.addFailure(fmt.Sprintf("request %d: %v", i, err))
+			continue
+		}
+		req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+		sent := time.Now()
+		resp, err := client.Do(req)
+		if err != nil {
+			failures++
+			result.addFailure(fmt.Sprintf("request %d: %v", i, err))
+			continue
+		}
+		// Reading the body to the end is what returns the connection to the pool.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		latencies = append(latencies, time.Since(sent))
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			failures++
+			result.addFailure(fmt.Sprintf("request %d: HTTP %d", i, resp.StatusCode))
+		}
+	}
+	result.Elapsed = time.Since(start)
+	if failures > len(result.Failures) {
+		result.Failures = append(result.Failures, fmt.Sprintf("%d more", failures-len(result.Failures)))
+	}
+
+	window := len(latencies) / requestLoopWindows
+	if window == 0 {
+		window = len(latencies)
+	}
+	if window > 0 {
+		result.FirstWindow = median(latencies[:window])
+		result.LastWindow = median(latencies[len(latencies)-window:])
+	}
+	return result
+}
+
+func (r *RequestLoopResult) addFailure(failure string) {
+	if len(r.Failures) < maxReportedRequestLoopFailures {
+		r.Failures = append(r.Failures, failure)
+	}
+}
+
+// median returns the median of latencies, which it does not modify.
+func median(latencies []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)/2]
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHTTPTransportOptionsFromEnv(t *testing.T) {
+	t.Setenv(HTTPMaxIdleConnsEnvVar, "7")
+	t.Setenv(HTTPMaxIdleConnsPerHostEnvVar, "-1")
+	t.Setenv(HTTPIdleConnTimeoutEnvVar, "15s")
+	t.Setenv(HTTPKeepAliveEnvVar, "soon")
+	t.Setenv(HTTPDisableKeepAlivesEnvVar, "TRUE")
+
+	opts := HTTPTransportOptionsFromEnv()
+	expected := HTTPTransportOptions{
+		MaxIdleConns:        7,
+		MaxIdleConnsPerHost: DefaultHTTPMaxIdleConnsPerHost,
+		IdleConnTimeout:     15 * time.Second,
+		KeepAlive:           DefaultHTTPKeepAlive,
+		DisableKeepAlives:   true,
+	}
+	if opts != expected {
+		t.Errorf("Expected %+v, got %+v", expected, opts)
+	}
+}
+
+func TestRunRequestLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"clusters":[]}`))
+	}))
+	defer server.Close()
+	request := func(path string) func() (*http.Request, error) {
+		return func() (*http.Request, error) { return http.NewRequest(http.MethodGet, server.URL+path, nil) }
+	}
+
+	pooled := &http.Client{Transport: NewHTTPTransport(HTTPTransportOptions{
+		MaxIdleConns:        DefaultHTTPMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultHTTPMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultHTTPIdleConnTimeout,
+		KeepAlive:           DefaultHTTPKeepAlive,
+	})}
+	result := RunRequestLoop(pooled, request("/"), 50)
+	if len(result.Failures) != 0 || result.NewConnections != 1 || result.ReusedConnections != 49 {
+		t.Errorf("Expected one connection reused by every request, got %s: %v", result, result.Failures)
+	}
+	if result.FirstWindow <= 0 || result.LastWindow <= 0 {
+		t.Errorf("Expected the latencies of both windows, got %s", result)
+	}
+
+	unpooled := &http.Client{Transport: NewHTTPTransport(HTTPTransportOptions{DisableKeepAlives: true})}
+	if result := RunRequestLoop(unpooled, request("/"), 10); result.NewConnections != 10 {
+		t.Errorf("Expected a connection per request without keep-alives, got %s", result)
+	}
+
+	result = RunRequestLoop(pooled, request("/missing"), 8)
+	if len(result.Failures) != maxReportedRequestLoopFailures+1 || result.Failures[maxReportedRequestLoopFailures] != "3 more" {
+		t.Errorf("Expected the first failures and a count of the others, got %v", result.Failures)
+	}
+}
+
+func TestRequestLoopResultDegraded(t *testing.T) {
+	slack := 20 * time.Millisecond
+	cases := []struct {
+		first, last time.Duration
+		degraded    bool
+	}{
+		{2 * time.Millisecond, 3 * time.Millisecond, false},
+		{2 * time.Millisecond, 15 * time.Millisecond, false},
+		{10 * time.Millisecond, 100 * time.Millisecond, true},
+		{50 * time.Millisecond, 90 * time.Millisecond, false},
+	}
+	for _, c := range cases {
+		result := RequestLoopResult{FirstWindow: c.first, LastWindow: c.last}
+		if result.Degraded(3, slack) != c.degraded {
+			t.Errorf("Expected degraded=%v for %v then %v", c.degraded, c.first, c.last)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	badRegistryMarker    = "cluster-tests-bad-registry"
+	badRegistryPIDFile   = "/tmp/cluster-tests-bad-registry-revert.pid"
+	badRegistryAddress   = "127.0.0.1"
+	dockerHubRegistry    = "docker.io"
+	dockerHubPullAddress = "registry-1.docker.io"
+)
+
+// imagePullProblemPattern matches the kubelet and containerd wording of a failed image pull.
+var imagePullProblemPattern = regexp.MustCompile(`(?i)errimagepull|imagepullbackoff|image ?pull|pull(ing)? (the )?image|failed to pull`)
+
+// ImageRegistryHost returns the registry an image reference is pulled from, docker.io for references
+// without a registry.
+func ImageRegistryHost(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if !found || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return dockerHubRegistry
+	}
+	return first
+}
+
+// IsImagePullProblem reports whether a status message blames a failed image pull.
+func IsImagePullProblem(message string) bool {
+	return imagePullProblemPattern.MatchString(message)
+}
+
+// BreakEdgeNodeRegistry resolves registry to an address without a registry on the edge node, so every
+// image pull from it fails with a connection error. The edge node repairs the registry by itself once
+// maxDuration (plus a safety margin) has elapsed, or earlier through RestoreEdgeNodeRegistry.
+func BreakEdgeNodeRegistry(registry string, maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(registryBreakScript(registry, maxDuration+faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to break registry %s on the edge node: %w", registry, err)
+	}
+	return nil
+}
+
+// RestoreEdgeNodeRegistry undoes BreakEdgeNodeRegistry.
+func RestoreEdgeNodeRegistry() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(badRegistryPIDFile) + "\n" + registryRestoreCommand()); err != nil {
+		return fmt.Errorf("failed to restore the registry on the edge node: %w", err)
+	}
+	return nil
+}
+
+func registryBreakScript(registry string, revertAfter time.Duration) string {
+	hosts := []string{registry}
+	if registry == dockerHubRegistry {
+		hosts = append(hosts, dockerHubPullAddress)
+	}
+	// Pulls name the registry host, the port does not take part in the lookup.
+	for i, host := range hosts {
+		if name, _, found := strings.Cut(host, ":"); found {
+			hosts[i] = name
+		}
+	}
+	return strings.Join([]string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(badRegistryPIDFile),
+		registryRestoreCommand(),
+		fmt.Sprintf("echo '%s %s # %s' | $SUDO tee -a /etc/hosts >/dev/null", badRegistryAddress, strings.Join(hosts, " "), badRegistryMarker),
+		edgeNodeScheduleRevertScript(badRegistryPIDFile, revertAfter, registryRestoreCommand()),
+	}, "\n")
+}
+
+// registryRestoreCommand is a single line without double quotes or variables other than $SUDO,
+// so it can also run from the background revert timer.
+func registryRestoreCommand() string {
+	return fmt.Sprintf("$SUDO sed -i '/# %s$/d' /etc/hosts; true", badRegistryMarker)
+}
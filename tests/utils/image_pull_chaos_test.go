@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestImageRegistryHost(t *testing.T) {
+	for image, want := range map[string]string{
+		"registry-rs.edgeorchestration.intel.com/edge-orch/cluster/connect-agent:latest": "registry-rs.edgeorchestration.intel.com",
+		"localhost:5000/connect-agent:latest":                                            "localhost:5000",
+		"localhost/connect-agent":                                                        "localhost",
+		"rancher/mirrored-pause:3.6":                                                     "docker.io",
+		"busybox":                                                                        "docker.io",
+	} {
+		if got := ImageRegistryHost(image); got != want {
+			t.Errorf("Expected registry %q for %q, got %q", want, image, got)
+		}
+	}
+}
+
+func TestIsImagePullProblem(t *testing.T) {
+	for _, message := range []string{
+		"Back-off pulling image \"registry.example.com/connect-agent:latest\"",
+		"connect-agent: ErrImagePull",
+		"ImagePullBackOff",
+		"failed to pull and unpack image",
+	} {
+		if !IsImagePullProblem(message) {
+			t.Errorf("Expected %q to be an image pull problem", message)
+		}
+	}
+	for _, message := range []string{"connect agent is disconnected", "timed out waiting for the condition", ""} {
+		if IsImagePullProblem(message) {
+			t.Errorf("Expected %q not to be an image pull problem", message)
+		}
+	}
+}
+
+func TestRegistryBreakScript(t *testing.T) {
+	script := registryBreakScript("docker.io", 6*time.Minute)
+	for _, want := range []string{
+		"echo '127.0.0.1 docker.io registry-1.docker.io # cluster-tests-bad-registry' | $SUDO tee -a /etc/hosts",
+		"sleep 360;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in break script:\n%s", want, script)
+		}
+	}
+
+	if script := registryBreakScript("localhost:5000", time.Minute); !strings.Contains(script, "echo '127.0.0.1 localhost # ") {
+		t.Errorf("Expected the registry port to be dropped, got:\n%s", script)
+	}
+}
+
+func TestRegistryRestoreCommand(t *testing.T) {
+	if cmd := registryRestoreCommand(); strings.ContainsAny(cmd, "\n\"") || !strings.HasSuffix(cmd, "true") {
+		t.Errorf("Expected a single line restore command without double quotes that always succeeds, got %q", cmd)
+	}
+}
@@ -0,0 +1,237 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ImageScanEnvVar enables the vulnerability scan of the images running on the downstream cluster.
+	ImageScanEnvVar = "IMAGE_SCAN"
+	// ImageScanFailOnCriticalEnvVar fails the scan when an image has a CRITICAL vulnerability.
+	ImageScanFailOnCriticalEnvVar = "IMAGE_SCAN_FAIL_ON_CRITICAL"
+	// ImageScanSeverityEnvVar is the comma separated list of severities trivy reports.
+	ImageScanSeverityEnvVar  = "IMAGE_SCAN_SEVERITY"
+	DefaultImageScanSeverity = "HIGH,CRITICAL"
+	// ImageScanReportDirEnvVar selects where the reports are written; defaults to <FAILURE_ARTIFACTS_DIR>/image-scan.
+	ImageScanReportDirEnvVar = "IMAGE_SCAN_REPORT_DIR"
+	// TrivyBinEnvVar overrides the trivy binary.
+	TrivyBinEnvVar = "TRIVY_BIN"
+
+	SeverityCritical = "CRITICAL"
+)
+
+// ImageScanEnabled reports whether the optional image scan should run.
+func ImageScanEnabled() bool {
+	return os.Getenv(ImageScanEnvVar) == "true"
+}
+
+// ImageScanFailOnCritical reports whether CRITICAL vulnerabilities fail the scan.
+func ImageScanFailOnCritical() bool {
+	return os.Getenv(ImageScanFailOnCriticalEnvVar) == "true"
+}
+
+// ImageScanReportDir returns the directory the image scan reports are written to.
+func ImageScanReportDir() string {
+	return GetEnv(ImageScanReportDirEnvVar, filepath.Join(FailureArtifactsDir(), "image-scan"))
+}
+
+// DownstreamImages returns the images of all containers, init containers included, of the pods running
+// on a downstream cluster, sorted and without duplicates.
+func DownstreamImages(kubeconfigPath string) ([]string, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.ListPods("", "")
+	if err != nil {
+		return nil, err
+	}
+	return podImages(pods), nil
+}
+
+func podImages(pods []corev1.Pod) []string {
+	seen := map[string]bool{}
+	var images []string
+	for _, pod := range pods {
+		for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if c.Image != "" && !seen[c.Image] {
+				seen[c.Image] = true
+				images = append(images, c.Image)
+			}
+		}
+	}
+	sort.Strings(images)
+	return images
+}
+
+// Vulnerability is one finding of trivy in an image.
+type Vulnerability struct {
+	ID               string `json:"id"`
+	Package          string `json:"package"`
+	InstalledVersion string `json:"installedVersion"`
+	FixedVersion     string `json:"fixedVersion,omitempty"`
+	Severity         string `json:"severity"`
+}
+
+// ImageScanResult is the outcome of scanning one image.
+type ImageScanResult struct {
+	Image string `json:"image"`
+	// Counts is the number of vulnerabilities per severity.
+	Counts   map[string]int  `json:"counts"`
+	Critical []Vulnerability `json:"critical,omitempty"`
+	// Report is the file the raw trivy report was written to.
+	Report string `json:"report,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// parseTrivyReport summarizes the JSON report of trivy image. A vulnerability found in several targets of
+// the image, e.g. the OS packages and a binary, is counted once.
+func parseTrivyReport(image string, data []byte) (ImageScanResult, error) {
+	var report struct {
+		Results []struct {
+			Vulnerabilities []struct {
+				VulnerabilityID  string `json:"VulnerabilityID"`
+				PkgName          string `json:"PkgName"`
+				InstalledVersion string `json:"InstalledVersion"`
+				FixedVersion     string `json:"FixedVersion"`
+				Severity         string `json:"Severity"`
+			} `json:"Vulnerabilities"`
+		} `json:"Results"`
+	}
+	result := ImageScanResult{Image: image, Counts: map[string]int{}}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return result, fmt.Errorf("failed to parse the trivy report of %s: %w", image, err)
+	}
+	seen := map[string]bool{}
+	for _, target := range report.Results {
+		for _, v := range target.Vulnerabilities {
+			key := v.VulnerabilityID + "/" + v.PkgName + "/" + v.InstalledVersion
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			result.Counts[v.Severity]++
+			if v.Severity == SeverityCritical {
+				result.Critical = append(result.Critical, Vulnerability{ID: v.VulnerabilityID, Package: v.PkgName,
+					InstalledVersion: v.InstalledVersion, FixedVersion: v.FixedVersion, Severity: v.Severity})
+			}
+		}
+	}
+	return result, nil
+}
+
+// imageReportName turns an image reference into a file name.
+func imageReportName(image string) string {
+	return strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(image) + ".json"
+}
+
+// ScanImage runs trivy against an image and writes its raw report to dir.
+func ScanImage(image, dir string) (ImageScanResult, error) {
+	cmd := exec.Command(GetEnv(TrivyBinEnvVar, "trivy"), "image", "--quiet", "--format", "json",
+		"--severity", GetEnv(ImageScanSeverityEnvVar, DefaultImageScanSeverity), image)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := CommandOutput(cmd)
+	if err != nil {
+		return ImageScanResult{Image: image}, fmt.Errorf("failed to scan %s %w: %s", image, err, strings.TrimSpace(stderr.String()))
+	}
+	result, err := parseTrivyReport(image, out)
+	if err != nil {
+		return result, err
+	}
+	path := filepath.Join(dir, imageReportName(image))
+	if err := WriteArtifact(path, out); err != nil {
+		return result, fmt.Errorf("failed to write the trivy report %s: %w", path, err)
+	}
+	result.Report = path
+	return result, nil
+}
+
+// ImageScanReport is the outcome of scanning every image of a downstream cluster.
+type ImageScanReport struct {
+	Severity string            `json:"severity"`
+	Images   []ImageScanResult `json:"images"`
+}
+
+// ScanDownstreamImages scans all images running on a downstream cluster and writes the raw trivy reports and
+// a summary to ImageScanReportDir. Images that cannot be scanned are recorded with their error.
+func ScanDownstreamImages(kubeconfigPath string) (*ImageScanReport, error) {
+	if _, err := exec.LookPath(GetEnv(TrivyBinEnvVar, "trivy")); err != nil {
+		return nil, fmt.Errorf("trivy is required for %s=true: %w", ImageScanEnvVar, err)
+	}
+	images, err := DownstreamImages(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	dir := ImageScanReportDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	report := &ImageScanReport{Severity: GetEnv(ImageScanSeverityEnvVar, DefaultImageScanSeverity)}
+	for _, image := range images {
+		result, err := ScanImage(image, dir)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		report.Images = append(report.Images, result)
+	}
+	return report, nil
+}
+
+// Print writes one line per image to stdout.
+func (r *ImageScanReport) Print() {
+	fmt.Printf("Vulnerabilities (%s) of %d downstream images:\n", r.Severity, len(r.Images))
+	for _, result := range r.Images {
+		if result.Error != "" {
+			fmt.Printf("  %s: not scanned: %s\n", result.Image, result.Error)
+			continue
+		}
+		fmt.Printf("  %s: critical=%d high=%d\n", result.Image, result.Counts[SeverityCritical], result.Counts["HIGH"])
+	}
+}
+
+// Unscanned returns the images that could not be scanned.
+func (r *ImageScanReport) Unscanned() []string {
+	var images []string
+	for _, result := range r.Images {
+		if result.Error != "" {
+			images = append(images, result.Image)
+		}
+	}
+	return images
+}
+
+// CriticalFindings returns "image: CVE (package version)" for every CRITICAL vulnerability.
+func (r *ImageScanReport) CriticalFindings() []string {
+	var findings []string
+	for _, result := range r.Images {
+		for _, v := range result.Critical {
+			findings = append(findings, fmt.Sprintf("%s: %s (%s %s)", result.Image, v.ID, v.Package, v.InstalledVersion))
+		}
+	}
+	return findings
+}
+
+// WriteReport writes the summary as JSON next to the raw trivy reports and returns its path.
+func (r *ImageScanReport) WriteReport() (string, error) {
+	data, err := MarshalReport(r)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(ImageScanReportDir(), "summary.json")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write image scan summary %s: %w", path, err)
+	}
+	return path, nil
+}
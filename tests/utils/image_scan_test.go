@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodImages(t *testing.T) {
+	pods := `{"items":[
+		{"spec":{"initContainers":[{"image":"rancher/mirrored-pause:3.6"}],"containers":[{"image":"rancher/klipper-helm:v0.9.4"}]}},
+		{"spec":{"containers":[{"image":"rancher/mirrored-coredns-coredns:1.12.0"},{"image":"rancher/klipper-helm:v0.9.4"}]}}
+	]}`
+	var list corev1.PodList
+	if err := json.Unmarshal([]byte(pods), &list); err != nil {
+		t.Fatalf("Failed to parse pods: %v", err)
+	}
+	images := podImages(list.Items)
+	expected := []string{"rancher/klipper-helm:v0.9.4", "rancher/mirrored-coredns-coredns:1.12.0", "rancher/mirrored-pause:3.6"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("Expected %v, got %v", expected, images)
+	}
+}
+
+func TestParseTrivyReport(t *testing.T) {
+	report := `{"Results":[
+		{"Target":"alpine","Vulnerabilities":[
+			{"VulnerabilityID":"CVE-2024-0001","PkgName":"openssl","InstalledVersion":"3.1.0","FixedVersion":"3.1.5","Severity":"CRITICAL"},
+			{"VulnerabilityID":"CVE-2024-0002","PkgName":"busybox","InstalledVersion":"1.36.0","Severity":"HIGH"}
+		]},
+		{"Target":"usr/bin/app","Vulnerabilities":[
+			{"VulnerabilityID":"CVE-2024-0001","PkgName":"openssl","InstalledVersion":"3.1.0","FixedVersion":"3.1.5","Severity":"CRITICAL"},
+			{"VulnerabilityID":"CVE-2024-0003","PkgName":"stdlib","InstalledVersion":"1.22.0","Severity":"HIGH"}
+		]},
+		{"Target":"clean"}
+	]}`
+	result, err := parseTrivyReport("example:1.0", []byte(report))
+	if err != nil {
+		t.Fatalf("Failed to parse the report: %v", err)
+	}
+	if result.Counts[SeverityCritical] != 1 || result.Counts["HIGH"] != 2 {
+		t.Errorf("Expected 1 critical and 2 high vulnerabilities, got %v", result.Counts)
+	}
+	if len(result.Critical) != 1 || result.Critical[0].ID != "CVE-2024-0001" || result.Critical[0].FixedVersion != "3.1.5" {
+		t.Errorf("Expected CVE-2024-0001 as the only critical vulnerability, got %+v", result.Critical)
+	}
+
+	if _, err := parseTrivyReport("example:1.0", []byte("not json")); err == nil {
+		t.Error("Expected an error for a malformed report")
+	}
+}
+
+func TestImageScanReportFindings(t *testing.T) {
+	report := &ImageScanReport{Images: []ImageScanResult{
+		{Image: "a:1", Critical: []Vulnerability{{ID: "CVE-1", Package: "openssl", InstalledVersion: "3.1.0"}}},
+		{Image: "b:1", Error: "failed to scan b:1"},
+		{Image: "c:1"},
+	}}
+	if findings := report.CriticalFindings(); !reflect.DeepEqual(findings, []string{"a:1: CVE-1 (openssl 3.1.0)"}) {
+		t.Errorf("Unexpected critical findings %v", findings)
+	}
+	if unscanned := report.Unscanned(); !reflect.DeepEqual(unscanned, []string{"b:1"}) {
+		t.Errorf("Expected b:1 to be unscanned, got %v", unscanned)
+	}
+}
+
+func TestImageReportName(t *testing.T) {
+	name := imageReportName("registry.example.com:5000/rancher/pause@sha256:abc")
+	if name != "registry.example.com_5000_rancher_pause_sha256_abc.json" {
+		t.Errorf("Unexpected report name %s", name)
+	}
+}
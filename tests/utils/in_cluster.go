@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+)
+
+const (
+	// InClusterEnvVar runs the suites from a pod of the management cluster: the services are reached through
+	// their in-cluster DNS names rather than kubectl port-forwards.
+	InClusterEnvVar = "IN_CLUSTER"
+	// InClusterServiceNamespaceEnvVar is the namespace of the services the suites port-forward to.
+	InClusterServiceNamespaceEnvVar  = "IN_CLUSTER_SERVICE_NAMESPACE"
+	DefaultInClusterServiceNamespace = "default"
+	// InClusterDomainEnvVar is the cluster domain of the service DNS names.
+	InClusterDomainEnvVar  = "IN_CLUSTER_DOMAIN"
+	DefaultInClusterDomain = "cluster.local"
+)
+
+var (
+	serviceProxiesMu sync.Mutex
+	// serviceProxies are the running service proxies by local port. They live as long as the test process,
+	// like a port-forward nobody stops.
+	serviceProxies = map[string]string{}
+)
+
+// InClusterMode reports whether the suites run inside the management cluster, see InClusterEnvVar.
+func InClusterMode() bool {
+	return strings.EqualFold(GetEnv(InClusterEnvVar, "false"), "true")
+}
+
+// InClusterServiceAddress returns the in-cluster host:port of a service given the way kubectl port-forward
+// names it, e.g. svc/cluster-manager.
+func InClusterServiceAddress(service, remotePort string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(service, "svc/"), "service/")
+	namespace := GetEnv(InClusterServiceNamespaceEnvVar, DefaultInClusterServiceNamespace)
+	domain := GetEnv(InClusterDomainEnvVar, DefaultInClusterDomain)
+	return net.JoinHostPort(fmt.Sprintf("%s.%s.svc.%s", name, namespace, domain), remotePort)
+}
+
+// startServiceProxy serves the local port of a port-forward by relaying each connection to the in-cluster
+// address of the service, so the 127.0.0.1 endpoints of the suites work unchanged inside the cluster. It
+// is a no-op when the port is already relayed to the same address.
+func startServiceProxy(localPort, address string) error {
+	serviceProxiesMu.Lock()
+	defer serviceProxiesMu.Unlock()
+	if current, ok := serviceProxies[localPort]; ok {
+		if current != address {
+			return fmt.Errorf("local port %s already relays to %s, not %s", localPort, current, address)
+		}
+		return nil
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", localPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on local port %s for %s: %w", localPort, address, err)
+	}
+	serviceProxies[localPort] = address
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go relay(conn, address)
+		}
+	}()
+	return nil
+}
+
+// relay copies between a local connection and a new connection to address until either side closes.
+func relay(local net.Conn, address string) {
+	defer local.Close()
+	remote, err := net.Dial("tcp", address)
+	if err != nil {
+		fmt.Printf("Failed to reach %s: %v\n", address, err)
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInClusterServiceAddress(t *testing.T) {
+	for _, key := range []string{InClusterServiceNamespaceEnvVar, InClusterDomainEnvVar} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+	if address := InClusterServiceAddress(PortForwardService, PortForwardRemotePort); address != "cluster-manager.default.svc.cluster.local:8080" {
+		t.Errorf("Expected the default service address, got %s", address)
+	}
+
+	t.Setenv(InClusterServiceNamespaceEnvVar, "orch-cluster")
+	t.Setenv(InClusterDomainEnvVar, "edge.local")
+	if address := InClusterServiceAddress("service/nexus-api-gw", "8082"); address != "nexus-api-gw.orch-cluster.svc.edge.local:8082" {
+		t.Errorf("Expected the configured service address, got %s", address)
+	}
+}
+
+func TestStartServiceProxy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "relayed")
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected a free port, got %v", err)
+	}
+	_, localPort, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	if err := startServiceProxy(localPort, address); err != nil {
+		t.Fatalf("Expected the proxy to start, got %v", err)
+	}
+	if err := startServiceProxy(localPort, address); err != nil {
+		t.Errorf("Expected starting the same proxy twice to be a no-op, got %v", err)
+	}
+	if err := startServiceProxy(localPort, "elsewhere:80"); err == nil {
+		t.Error("Expected an error relaying the port to another address")
+	}
+
+	resp, err := http.Get("http://127.0.0.1:" + localPort)
+	if err != nil {
+		t.Fatalf("Expected the request to be relayed, got %v", err)
+	}
+	defer resp.Body.Close()
+	if body, _ := io.ReadAll(resp.Body); string(body) != "relayed" {
+		t.Errorf("Expected the response of the service, got %q", body)
+	}
+}
@@ -0,0 +1,254 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DisableInventoryEnvVar overrides the detected inventory mode of cluster-manager when set to "true" or "false".
+	DisableInventoryEnvVar = "DISABLE_INVENTORY"
+	// InfraAPIURLEnvVar is the base URL of the infra manager's API, by default the port-forward of
+	// StartInfraAPIPortForward.
+	InfraAPIURLEnvVar  = "INFRA_API_URL"
+	DefaultInfraAPIURL = "http://127.0.0.1:8083"
+
+	PortForwardInfraService    = "svc/apiv2-proxy"
+	PortForwardInfraLocalPort  = "8083"
+	PortForwardInfraRemotePort = "8080"
+
+	infraAPIPrefix = "/edge-infra.orchestrator.apis/v2"
+)
+
+var (
+	inventoryDisabled     bool
+	inventoryDisabledOnce sync.Once
+)
+
+// InventoryDisabled reports whether cluster-manager runs without the infra manager's inventory, i.e. node
+// GUIDs are not checked against host records. DISABLE_INVENTORY takes precedence; otherwise the
+// -disable-inventory flag of the cluster-manager deployment decides. The result is detected once per suite.
+func InventoryDisabled() bool {
+	inventoryDisabledOnce.Do(func() {
+		disabled, source := detectInventoryDisabled()
+		inventoryDisabled = disabled
+		fmt.Printf("Inventory %s (%s)\n", map[bool]string{true: "disabled", false: "enabled"}[disabled], source)
+	})
+	return inventoryDisabled
+}
+
+func detectInventoryDisabled() (bool, string) {
+	if value := os.Getenv(DisableInventoryEnvVar); value != "" {
+		if disabled, err := strconv.ParseBool(value); err == nil {
+			return disabled, DisableInventoryEnvVar + "=" + value
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", DisableInventoryEnvVar, value)
+	}
+
+	args, err := clusterManagerArgs()
+	if err != nil {
+		return true, fmt.Sprintf("unable to read the cluster-manager deployment: %v", err)
+	}
+	if disabled, found := parseBoolFlag(args, "disable-inventory"); found {
+		return disabled, "cluster-manager deployment flags"
+	}
+	// The flag defaults to false in cluster-manager.
+	return false, "cluster-manager deployment without -disable-inventory"
+}
+
+// Host is a host record of the infra manager's inventory.
+type Host struct {
+	ResourceID   string `json:"resourceId"`
+	Name         string `json:"name"`
+	UUID         string `json:"uuid"`
+	CurrentState string `json:"currentState"`
+	Instance     *struct {
+		ResourceID string `json:"resourceId"`
+	} `json:"instance"`
+}
+
+// StartInfraAPIPortForward port-forwards the infra manager's API.
+func StartInfraAPIPortForward() (*PortForwarder, error) {
+	return StartPortForward(PortForwardInfraService, PortForwardInfraLocalPort, PortForwardInfraRemotePort)
+}
+
+func infraRequest(namespace, method, path string, body any) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(GetEnv(InfraAPIURLEnvVar, DefaultInfraAPIURL), "/")+infraAPIPrefix+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	SetNamespaceHeader(req, namespace)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := NewHTTPClient().Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, data, err
+}
+
+// RegisterHost registers a host with the given UUID in the inventory of a project, the way a host is
+// pre-registered before it is onboarded. It is not onboarded automatically.
+func RegisterHost(namespace, name, uuid string) (*Host, error) {
+	status, body, err := infraRequest(namespace, http.MethodPost, "/hosts/register",
+		map[string]any{"name": name, "uuid": uuid, "autoOnboard": false})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register host %s: %w", uuid, err)
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, NewAPIError(fmt.Sprintf("register host %s", uuid), status, body)
+	}
+	var host Host
+	if err := json.Unmarshal(body, &host); err != nil {
+		return nil, fmt.Errorf("failed to parse host: %w", err)
+	}
+	return &host, nil
+}
+
+// FindHost returns the host record with the given UUID in the inventory of a project.
+func FindHost(namespace, uuid string) (*Host, bool, error) {
+	status, body, err := infraRequest(namespace, http.MethodGet, "/hosts?filter="+url.QueryEscape(fmt.Sprintf("uuid=%q", uuid)), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list hosts: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, false, NewAPIError("list hosts", status, body)
+	}
+	hosts, err := parseHosts(body)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, host := range hosts {
+		if strings.EqualFold(host.UUID, uuid) {
+			return &host, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func parseHosts(data []byte) ([]Host, error) {
+	var list struct {
+		Hosts []Host `json:"hosts"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse hosts: %w", err)
+	}
+	return list.Hosts, nil
+}
+
+// ProvisionHost creates an instance of the first operating system of the project on a registered host,
+// which gives cluster-manager the OS it needs to decide between a read-only and a regular k3s install.
+func ProvisionHost(namespace string, host *Host) (string, error) {
+	status, body, err := infraRequest(namespace, http.MethodGet, "/OSResources", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to list operating systems: %w", err)
+	}
+	if status != http.StatusOK {
+		return "", NewAPIError("list operating systems", status, body)
+	}
+	osID, err := firstOSResourceID(body)
+	if err != nil {
+		return "", err
+	}
+
+	status, body, err = infraRequest(namespace, http.MethodPost, "/instances", map[string]string{
+		"kind": "INSTANCE_KIND_METAL", "name": host.Name, "hostID": host.ResourceID, "osID": osID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create instance on host %s: %w", host.ResourceID, err)
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return "", NewAPIError(fmt.Sprintf("create instance on host %s", host.ResourceID), status, body)
+	}
+	var instance struct {
+		ResourceID string `json:"resourceId"`
+	}
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return "", fmt.Errorf("failed to parse instance: %w", err)
+	}
+	return instance.ResourceID, nil
+}
+
+func firstOSResourceID(data []byte) (string, error) {
+	var list struct {
+		OperatingSystemResources []struct {
+			ResourceID string `json:"resourceId"`
+		} `json:"OperatingSystemResources"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return "", fmt.Errorf("failed to parse operating systems: %w", err)
+	}
+	if len(list.OperatingSystemResources) == 0 || list.OperatingSystemResources[0].ResourceID == "" {
+		return "", fmt.Errorf("no operating system in the inventory")
+	}
+	return list.OperatingSystemResources[0].ResourceID, nil
+}
+
+// DeleteHost deletes a host record and its instance, if any, from the inventory of a project.
+func DeleteHost(namespace, uuid string) error {
+	host, found, err := FindHost(namespace, uuid)
+	if err != nil || !found {
+		return err
+	}
+	paths := []string{"/hosts/" + host.ResourceID}
+	if host.Instance != nil && host.Instance.ResourceID != "" {
+		paths = append([]string{"/instances/" + host.Instance.ResourceID}, paths...)
+	}
+	for _, path := range paths {
+		status, body, err := infraRequest(namespace, http.MethodDelete, path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+		if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+			return NewAPIError(fmt.Sprintf("delete %s", path), status, body)
+		}
+	}
+	return nil
+}
+
+// SetHostMetadata replaces the metadata of a host record. cluster-manager copies the metadata of a host
+// to the labels of the Machine whose node is the host.
+func SetHostMetadata(namespace string, host *Host, metadata map[string]string) error {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]map[string]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, map[string]string{"key": key, "value": metadata[key]})
+	}
+
+	status, body, err := infraRequest(namespace, http.MethodPatch, "/hosts/"+host.ResourceID,
+		map[string]any{"name": host.Name, "metadata": pairs})
+	if err != nil {
+		return fmt.Errorf("failed to update the metadata of host %s: %w", host.ResourceID, err)
+	}
+	if status != http.StatusOK {
+		return NewAPIError(fmt.Sprintf("update the metadata of host %s", host.ResourceID), status, body)
+	}
+	return nil
+}
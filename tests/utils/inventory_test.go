@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseHosts(t *testing.T) {
+	data := `{"hosts":[{"resourceId":"host-1a2b3c4d","name":"edge-1","uuid":"12345678-1234-1234-1234-123456789012",
+		"currentState":"HOST_STATE_UNSPECIFIED","instance":{"resourceId":"inst-5e6f7a8b"}}],"hasNext":false,"totalElements":1}`
+
+	hosts, err := parseHosts([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse hosts: %v", err)
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("Expected 1 host, got %d", len(hosts))
+	}
+	if hosts[0].ResourceID != "host-1a2b3c4d" || hosts[0].UUID != DefaultNodeGUID {
+		t.Errorf("Expected host-1a2b3c4d with UUID %s, got %+v", DefaultNodeGUID, hosts[0])
+	}
+	if hosts[0].Instance == nil || hosts[0].Instance.ResourceID != "inst-5e6f7a8b" {
+		t.Errorf("Expected instance inst-5e6f7a8b, got %+v", hosts[0].Instance)
+	}
+}
+
+func TestFirstOSResourceID(t *testing.T) {
+	id, err := firstOSResourceID([]byte(`{"OperatingSystemResources":[{"resourceId":"os-1234abcd","name":"Edge Microvisor Toolkit"}],"totalElements":1}`))
+	if err != nil || id != "os-1234abcd" {
+		t.Errorf("Expected os-1234abcd, got %q (%v)", id, err)
+	}
+
+	if _, err := firstOSResourceID([]byte(`{"OperatingSystemResources":[],"totalElements":0}`)); err == nil {
+		t.Errorf("Expected an error without operating systems")
+	}
+}
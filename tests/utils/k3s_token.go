@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// K3sTokenMismatchMessage is what k3s logs when its datastore was encrypted with another token than the
+	// one it is started with, e.g. after the node was reused with a new cluster token.
+	K3sTokenMismatchMessage = "bootstrap data already found and encrypted with different token"
+
+	k3sService         = "k3s"
+	k3sConfigFile      = "/etc/rancher/k3s/config.yaml"
+	k3sConfigBackup    = k3sConfigFile + ".cluster-tests"
+	k3sConfigMarker    = "/tmp/cluster-tests-k3s-config-created"
+	k3sTokenRevertPID  = "/tmp/cluster-tests-k3s-token-revert.pid"
+	k3sMismatchedToken = "cluster-tests-mismatched-token"
+	k3sServiceCheck    = "systemctl cat " + k3sService + " >/dev/null 2>&1 && echo k3s || echo none"
+)
+
+// EdgeNodeRunsK3s reports whether the edge node runs k3s as a systemd service.
+func EdgeNodeRunsK3s() (bool, error) {
+	out, err := ExecOnEdgeNode(k3sServiceCheck)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == k3sService, nil
+}
+
+// MismatchEdgeNodeK3sToken restarts k3s on the edge node with another token than the one its datastore is
+// encrypted with, so it fails to start with K3sTokenMismatchMessage. The edge node restores its k3s
+// configuration by itself once maxDuration (plus a safety margin) has elapsed, or earlier through
+// RestoreEdgeNodeK3sToken, which is also the documented recovery: start k3s with the original token again.
+func MismatchEdgeNodeK3sToken(maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(k3sTokenMismatchScript(maxDuration + faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to change the k3s token on the edge node: %w", err)
+	}
+	return nil
+}
+
+// RestoreEdgeNodeK3sToken undoes MismatchEdgeNodeK3sToken and restarts k3s.
+func RestoreEdgeNodeK3sToken() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(k3sTokenRevertPID) + "\n" + k3sTokenRestoreCommand()); err != nil {
+		return fmt.Errorf("failed to restore the k3s token on the edge node: %w", err)
+	}
+	return nil
+}
+
+// EdgeNodeK3sTokenMismatchLogged reports whether k3s logged K3sTokenMismatchMessage since the given time.
+func EdgeNodeK3sTokenMismatchLogged(since time.Time) (bool, error) {
+	out, err := ExecOnEdgeNode(edgeNodeSudoPreamble + fmt.Sprintf("$SUDO journalctl -u %s --since @%d --no-pager 2>/dev/null | grep -c '%s' || true",
+		k3sService, since.Unix(), K3sTokenMismatchMessage))
+	if err != nil {
+		return false, err
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(out)))
+	if err != nil {
+		return false, fmt.Errorf("unexpected output %q: %w", strings.TrimSpace(string(out)), err)
+	}
+	return count > 0, nil
+}
+
+// k3sTokenMismatchScript keeps the original configuration aside and schedules its restore before the
+// token is changed. The token in config.yaml takes precedence over the one k3s stored on first start.
+func k3sTokenMismatchScript(revertAfter time.Duration) string {
+	return strings.Join([]string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(k3sTokenRevertPID),
+		k3sTokenRestoreCommand(),
+		fmt.Sprintf("if [ -f %[1]s ]; then $SUDO cp %[1]s %[2]s; else $SUDO mkdir -p $(dirname %[1]s); $SUDO touch %[1]s; touch %[3]s; fi",
+			k3sConfigFile, k3sConfigBackup, k3sConfigMarker),
+		edgeNodeScheduleRevertScript(k3sTokenRevertPID, revertAfter, k3sTokenRestoreCommand()),
+		fmt.Sprintf("$SUDO sed -i '/^token:/d' %s", k3sConfigFile),
+		fmt.Sprintf("echo 'token: %s' | $SUDO tee -a %s >/dev/null", k3sMismatchedToken, k3sConfigFile),
+		fmt.Sprintf("$SUDO systemctl restart --no-block %s", k3sService),
+	}, "\n")
+}
+
+// k3sTokenRestoreCommand is a single line without double quotes or variables other than $SUDO,
+// so it can also run from the background revert timer. k3s is only restarted if its configuration
+// was changed.
+func k3sTokenRestoreCommand() string {
+	restart := fmt.Sprintf("$SUDO systemctl restart --no-block %s", k3sService)
+	return fmt.Sprintf("if [ -f %[1]s ]; then $SUDO mv %[1]s %[2]s; %[4]s; elif [ -f %[3]s ]; then $SUDO rm -f %[2]s %[3]s; %[4]s; fi; true",
+		k3sConfigBackup, k3sConfigFile, k3sConfigMarker, restart)
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestK3sTokenMismatchScript(t *testing.T) {
+	script := k3sTokenMismatchScript(10 * time.Minute)
+	for _, want := range []string{
+		"$SUDO cp /etc/rancher/k3s/config.yaml /etc/rancher/k3s/config.yaml.cluster-tests",
+		"sed -i '/^token:/d' /etc/rancher/k3s/config.yaml",
+		"echo 'token: cluster-tests-mismatched-token' | $SUDO tee -a /etc/rancher/k3s/config.yaml",
+		"$SUDO systemctl restart --no-block k3s",
+		"sleep 600;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in mismatch script:\n%s", want, script)
+		}
+	}
+	if strings.Index(script, "sleep 600;") > strings.Index(script, "sed -i") {
+		t.Errorf("Expected the restore to be scheduled before the token is changed:\n%s", script)
+	}
+}
+
+func TestK3sTokenRestoreCommand(t *testing.T) {
+	cmd := k3sTokenRestoreCommand()
+	if strings.ContainsAny(cmd, "\n\"") {
+		t.Errorf("Expected a single line restore command without double quotes, got:\n%s", cmd)
+	}
+	if !strings.HasSuffix(cmd, "true") {
+		t.Errorf("Expected restore command to always succeed, got %q", cmd)
+	}
+	if !strings.Contains(cmd, "$SUDO mv /etc/rancher/k3s/config.yaml.cluster-tests /etc/rancher/k3s/config.yaml") {
+		t.Errorf("Expected the original configuration to be moved back, got %q", cmd)
+	}
+}
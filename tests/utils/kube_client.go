@@ -0,0 +1,1140 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const (
+	// kubeClientTimeout bounds every call of a KubeClient that is not a wait.
+	kubeClientTimeout = 30 * time.Second
+	// kubeClientPollInterval is how often the waits of a KubeClient poll.
+	kubeClientPollInterval = 2 * time.Second
+	// kubeClientFieldManager owns the fields ApplyManifest sets.
+	kubeClientFieldManager = "cluster-tests"
+)
+
+// The resources of cluster orchestration the KubeClient reads through the dynamic client.
+var (
+	ClusterTemplateGVR     = schema.GroupVersionResource{Group: "edge-orchestrator.intel.com", Version: "v1alpha1", Resource: "clustertemplates"}
+	CAPIClusterGVR         = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "clusters"}
+	MachineGVR             = schema.GroupVersionResource{Group: "cluster.x-k8s.io", Version: "v1beta1", Resource: "machines"}
+	IntelMachineGVR        = schema.GroupVersionResource{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Resource: "intelmachines"}
+	IntelMachineBindingGVR = schema.GroupVersionResource{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Resource: "intelmachinebindings"}
+	ClusterConnectGVR      = schema.GroupVersionResource{Group: "cluster.edge-orchestrator.intel.com", Version: "v1alpha1", Resource: "clusterconnects"}
+)
+
+// KubeClient talks to a cluster with client-go rather than kubectl, so what goes through it needs neither
+// kubectl nor yq and failures are API errors (see apierrors.IsNotFound) instead of parsed output.
+type KubeClient struct {
+	Clientset kubernetes.Interface
+	Dynamic   dynamic.Interface
+	// Config is what the clients were created from, for the streaming APIs such as port-forwarding.
+	Config *rest.Config
+	// Namespace is the namespace of the current context, where kubectl would look for unqualified objects.
+	Namespace string
+
+	mapperOnce sync.Once
+	mapper     meta.RESTMapper
+}
+
+// NewKubeClient returns a client of the cluster of a kubeconfig file, e.g. one of WriteDownstreamKubeconfig.
+// An empty path loads the configuration kubectl would use: KUBECONFIG, ~/.kube/config or the service account
+// of the pod the suite runs in.
+func NewKubeClient(kubeconfigPath string) (*KubeClient, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfigPath
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig %q: %w", kubeconfigPath, err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the namespace of the kubeconfig %q: %w", kubeconfigPath, err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &KubeClient{Clientset: clientset, Dynamic: dynamicClient, Config: config, Namespace: namespace}, nil
+}
+
+var (
+	managementKubeClient     *KubeClient
+	managementKubeClientErr  error
+	managementKubeClientOnce sync.Once
+)
+
+// ManagementKubeClient returns the client of the management cluster, created once per suite.
+func ManagementKubeClient() (*KubeClient, error) {
+	managementKubeClientOnce.Do(func() {
+		managementKubeClient, managementKubeClientErr = NewKubeClient("")
+	})
+	return managementKubeClient, managementKubeClientErr
+}
+
+// managementKubeClientFor returns the client of the management cluster of kubeconfig, ManagementKubeClient
+// for "".
+func managementKubeClientFor(kubeconfig string) (*KubeClient, error) {
+	if kubeconfig == "" {
+		return ManagementKubeClient()
+	}
+	return NewKubeClient(kubeconfig)
+}
+
+// managementResourceJSON returns the objects of a resource of the management cluster, as for ListResource,
+// as the JSON list kubectl get -o json prints.
+func managementResourceJSON(resource, namespace, labelSelector string) ([]byte, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	list, err := client.ListResource(resource, namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	return list.MarshalJSON()
+}
+
+// managementObjectJSON returns an object of a resource of the management cluster, as for GetResource, as
+// the JSON kubectl get -o json prints.
+func managementObjectJSON(resource, namespace, name string) ([]byte, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	object, err := client.GetResource(resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return object.MarshalJSON()
+}
+
+func kubeClientContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), kubeClientTimeout)
+}
+
+// EnsureNamespace creates a namespace unless it exists.
+func (c *KubeClient) EnsureNamespace(name string) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	_, err := c.Clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// ListNodes returns the nodes of the cluster.
+func (c *KubeClient) ListNodes() ([]corev1.Node, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the nodes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// GetNode returns a node.
+func (c *KubeClient) GetNode(name string) (*corev1.Node, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	node, err := c.Clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+	return node, nil
+}
+
+// NodeReady reports whether a node has the Ready condition.
+func NodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// GetNamespace returns a namespace; apierrors.IsNotFound tells a missing one.
+func (c *KubeClient) GetNamespace(name string) (*corev1.Namespace, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	namespace, err := c.Clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+	return namespace, nil
+}
+
+// DeleteNamespace starts deleting a namespace without waiting for it to be gone; a missing namespace is not
+// an error.
+func (c *KubeClient) DeleteNamespace(name string) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	err := c.Clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete namespace %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetSecret returns a secret; apierrors.IsNotFound tells a missing one.
+func (c *KubeClient) GetSecret(namespace, name string) (*corev1.Secret, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	secret, err := c.Clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+	}
+	return secret, nil
+}
+
+// ClusterTemplateStatus is the status cluster-manager sets on a ClusterTemplate object.
+type ClusterTemplateStatus struct {
+	Ready bool `json:"ready"`
+}
+
+// GetClusterTemplateStatus returns the status of a ClusterTemplate object, e.g. "baseline-k3s-v0.0.10".
+func (c *KubeClient) GetClusterTemplateStatus(namespace, templateName string) (ClusterTemplateStatus, error) {
+	var status ClusterTemplateStatus
+	object, err := c.get(ClusterTemplateGVR, namespace, templateName)
+	if err != nil {
+		return status, err
+	}
+	return status, fromUnstructured(object.Object["status"], &status)
+}
+
+// ListIntelMachines returns the IntelMachines of a project, only those of a cluster when clusterName is set.
+func (c *KubeClient) ListIntelMachines(namespace, clusterName string) ([]unstructured.Unstructured, error) {
+	return c.listClusterObjects(IntelMachineGVR, namespace, clusterName)
+}
+
+// ListMachines returns the CAPI Machines of a project, only those of a cluster when clusterName is set.
+func (c *KubeClient) ListMachines(namespace, clusterName string) ([]unstructured.Unstructured, error) {
+	return c.listClusterObjects(MachineGVR, namespace, clusterName)
+}
+
+// ListClusterConnects returns the ClusterConnects of all clusters.
+func (c *KubeClient) ListClusterConnects() (*unstructured.UnstructuredList, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Dynamic.Resource(ClusterConnectGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the ClusterConnects: %w", err)
+	}
+	return list, nil
+}
+
+// GetClusterConnect returns a ClusterConnect, named after the project and the cluster it connects.
+func (c *KubeClient) GetClusterConnect(name string) (*unstructured.Unstructured, error) {
+	return c.get(ClusterConnectGVR, "", name)
+}
+
+func (c *KubeClient) listClusterObjects(gvr schema.GroupVersionResource, namespace, clusterName string) ([]unstructured.Unstructured, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	opts := metav1.ListOptions{}
+	if clusterName != "" {
+		opts.LabelSelector = "cluster.x-k8s.io/cluster-name=" + clusterName
+	}
+	list, err := c.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %s of %s: %w", gvr.Resource, namespace, err)
+	}
+	return list.Items, nil
+}
+
+// GetObject returns an object of any kind. apiVersion may be just the API group, as in the references of
+// CAPI v1beta2 objects, and the version is then the one the server prefers.
+func (c *KubeClient) GetObject(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, err := c.resourceFor(apiVersion, kind)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(gvr, namespace, name)
+}
+
+func (c *KubeClient) resourceFor(apiVersion, kind string) (schema.GroupVersionResource, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid apiVersion %q of %s: %w", apiVersion, kind, err)
+	}
+	if !strings.Contains(apiVersion, "/") && strings.Contains(apiVersion, ".") {
+		// A bare group, which ParseGroupVersion takes for a version of the core group.
+		gv = schema.GroupVersion{Group: apiVersion}
+		groups, err := c.Clientset.Discovery().ServerGroups()
+		if err != nil {
+			return schema.GroupVersionResource{}, fmt.Errorf("failed to discover the version of %s: %w", apiVersion, err)
+		}
+		for _, group := range groups.Groups {
+			if group.Name != gv.Group {
+				continue
+			}
+			gv.Version = group.PreferredVersion.Version
+			if gv.Version == "" && len(group.Versions) > 0 {
+				gv.Version = group.Versions[0].Version
+			}
+		}
+		if gv.Version == "" {
+			return schema.GroupVersionResource{}, fmt.Errorf("the server does not serve the API group %s", apiVersion)
+		}
+	}
+	gvr, _ := meta.UnsafeGuessKindToResource(gv.WithKind(kind))
+	return gvr, nil
+}
+
+// GetCluster returns the CAPI Cluster object of a cluster.
+func (c *KubeClient) GetCluster(namespace, clusterName string) (*unstructured.Unstructured, error) {
+	return c.get(CAPIClusterGVR, namespace, clusterName)
+}
+
+// ClusterExists reports whether the CAPI Cluster object of a cluster exists.
+func (c *KubeClient) ClusterExists(namespace, clusterName string) (bool, error) {
+	return c.exists(CAPIClusterGVR, namespace, clusterName)
+}
+
+// IntelMachineBindingExists reports whether an IntelMachineBinding exists.
+func (c *KubeClient) IntelMachineBindingExists(namespace, name string) (bool, error) {
+	return c.exists(IntelMachineBindingGVR, namespace, name)
+}
+
+// ListResource returns the objects of a resource given as plural.group or kind.group, e.g.
+// "clusters.cluster.x-k8s.io", as kubectl get takes it, matching a label selector, all for "". An empty
+// namespace lists all namespaces.
+func (c *KubeClient) ListResource(resource, namespace, labelSelector string) (*unstructured.UnstructuredList, error) {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Dynamic.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %s: %w", resource, err)
+	}
+	return list, nil
+}
+
+// WatchResource watches the objects of a resource, in all namespaces for namespace "", until ctx ends or the
+// API server ends the watch. The objects that exist come first as ADDED events, as kubectl get --watch
+// shows them. meta.IsNoMatchError tells a resource the cluster does not serve.
+func (c *KubeClient) WatchResource(ctx context.Context, resource, namespace string) (watch.Interface, error) {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return nil, err
+	}
+	watcher, err := c.Dynamic.Resource(gvr).Namespace(namespace).Watch(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch the %s: %w", resource, err)
+	}
+	return watcher, nil
+}
+
+// GetResource returns an object of a resource given as for ListResource; apierrors.IsNotFound tells a
+// missing one.
+func (c *KubeClient) GetResource(resource, namespace, name string) (*unstructured.Unstructured, error) {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return nil, err
+	}
+	return c.get(gvr, namespace, name)
+}
+
+// ResourceExists reports whether an object of a resource given as for ListResource exists.
+func (c *KubeClient) ResourceExists(resource, namespace, name string) (bool, error) {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return false, err
+	}
+	return c.exists(gvr, namespace, name)
+}
+
+// PatchResource applies a merge or JSON patch to an object of a resource given as for ListResource.
+func (c *KubeClient) PatchResource(resource, namespace, name string, patchType types.PatchType, patch []byte) error {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Dynamic.Resource(gvr).Namespace(namespace).Patch(ctx, name, patchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", resource, namespace, name, err)
+	}
+	return nil
+}
+
+// DeleteResource deletes an object of a resource given as for ListResource without waiting for it to be
+// gone; a missing object is not an error.
+func (c *KubeClient) DeleteResource(resource, namespace, name string) error {
+	gvr, err := c.resourceGVR(resource)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	err = c.Dynamic.Resource(gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %w", resource, namespace, name, err)
+	}
+	return nil
+}
+
+// resourceGVR returns the resource the server serves for resource.group or resource.version.group, the
+// resource plural or singular, as kubectl resolves them.
+func (c *KubeClient) resourceGVR(resource string) (schema.GroupVersionResource, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(resource)
+	if fullySpecified != nil {
+		if gvr, err := c.restMapper().ResourceFor(*fullySpecified); err == nil {
+			return gvr, nil
+		}
+	}
+	gvr, err := c.restMapper().ResourceFor(groupResource.WithVersion(""))
+	if err != nil {
+		return schema.GroupVersionResource{}, fmt.Errorf("failed to find the resource %s: %w", resource, err)
+	}
+	return gvr, nil
+}
+
+// PatchCluster applies a merge or JSON patch to the CAPI Cluster object of a cluster.
+func (c *KubeClient) PatchCluster(namespace, clusterName string, patchType types.PatchType, patch []byte) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Dynamic.Resource(CAPIClusterGVR).Namespace(namespace).Patch(ctx, clusterName, patchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch cluster %s/%s: %w", namespace, clusterName, err)
+	}
+	return nil
+}
+
+// WaitForPodReady waits until a pod has the Ready condition, or fails with its phase once timeout is over.
+func (c *KubeClient) WaitForPodReady(namespace, name string, timeout time.Duration) error {
+	var last string
+	err := wait.PollUntilContextTimeout(context.Background(), kubeClientPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := c.Clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			last = err.Error()
+			return false, nil
+		}
+		last = "phase " + string(pod.Status.Phase)
+		return podReady(pod), nil
+	})
+	if err != nil {
+		return fmt.Errorf("pod %s/%s is not ready after %v, last %s: %w", namespace, name, timeout, last, err)
+	}
+	return nil
+}
+
+// ListPods returns the pods of a namespace, all namespaces for "", matching a label selector, all for "".
+func (c *KubeClient) ListPods(namespace, labelSelector string) ([]corev1.Pod, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pods of %q: %w", namespace, err)
+	}
+	return list.Items, nil
+}
+
+// CreatePod creates a pod running command in image, which is not restarted once command exits.
+func (c *KubeClient) CreatePod(namespace, name, image string, command ...string) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers:    []corev1.Container{{Name: name, Image: image, Command: command}},
+		},
+	}
+	if _, err := c.Clientset.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// DeletePod deletes a pod without waiting for it to be gone; a missing pod is not an error.
+func (c *KubeClient) DeletePod(namespace, name string) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	err := c.Clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pod %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// DeletePods deletes the pods of a namespace matching a non-empty label selector, without waiting for them
+// to be gone.
+func (c *KubeClient) DeletePods(namespace, labelSelector string) error {
+	if labelSelector == "" {
+		return fmt.Errorf("refusing to delete all pods of %s without a label selector", namespace)
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if err := c.Clientset.CoreV1().Pods(namespace).DeleteCollection(ctx, metav1.DeleteOptions{},
+		metav1.ListOptions{LabelSelector: labelSelector}); err != nil {
+		return fmt.Errorf("failed to delete the pods %s of %s: %w", labelSelector, namespace, err)
+	}
+	return nil
+}
+
+// Exec runs command in the first container of a pod, as kubectl exec does, and returns its standard output.
+// The error of a failed command holds its standard error.
+func (c *KubeClient) Exec(namespace, pod string, command ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if err := c.ExecStream(ctx, namespace, pod, nil, &stdout, &stderr, command...); err != nil {
+		return stdout.String(), fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// ExecStream runs command in the first container of a pod until it exits or ctx ends, streaming stdin to it
+// (nil for none) and its output to stdout and stderr as it comes, as kubectl exec -i does.
+func (c *KubeClient) ExecStream(ctx context.Context, namespace, pod string, stdin io.Reader, stdout, stderr io.Writer, command ...string) error {
+	request := c.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(pod).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{Command: command, Stdin: stdin != nil, Stdout: true, Stderr: true}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(c.Config, http.MethodPost, request.URL())
+	if err != nil {
+		return err
+	}
+	if err := executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdin: stdin, Stdout: stdout, Stderr: stderr}); err != nil {
+		return fmt.Errorf("failed to run %q in pod %s/%s: %w", strings.Join(command, " "), namespace, pod, err)
+	}
+	return nil
+}
+
+// FollowPodLogs streams what the first container of a pod logs from now on, as kubectl logs -f --tail=0
+// does, until ctx ends or the caller closes the stream.
+func (c *KubeClient) FollowPodLogs(ctx context.Context, namespace, name string) (io.ReadCloser, error) {
+	tailLines := int64(0)
+	stream, err := c.Clientset.CoreV1().Pods(namespace).GetLogs(name, &corev1.PodLogOptions{Follow: true, TailLines: &tailLines}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to follow the logs of pod %s/%s: %w", namespace, name, err)
+	}
+	return stream, nil
+}
+
+// DeploymentLogs returns the last tailLines lines of every container of the pods of a deployment, each line
+// with its timestamp and prefixed with [pod/<pod>/<container>] as kubectl logs --prefix does.
+func (c *KubeClient) DeploymentLogs(namespace, name string, tailLines int64) ([]byte, error) {
+	return c.deploymentLogs(namespace, name, corev1.PodLogOptions{TailLines: &tailLines})
+}
+
+// DeploymentLogsSince returns the lines logged since a time by every container of the pods of a deployment,
+// as DeploymentLogs does.
+func (c *KubeClient) DeploymentLogsSince(namespace, name string, since time.Time) ([]byte, error) {
+	return c.deploymentLogs(namespace, name, corev1.PodLogOptions{SinceTime: &metav1.Time{Time: since}})
+}
+
+func (c *KubeClient) deploymentLogs(namespace, name string, options corev1.PodLogOptions) ([]byte, error) {
+	pods, err := c.deploymentPods(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	var logs bytes.Buffer
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			options := options
+			options.Container, options.Timestamps = container.Name, true
+			data, err := c.Clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &options).DoRaw(ctx)
+			if err != nil {
+				return logs.Bytes(), fmt.Errorf("failed to get the logs of %s/%s: %w", pod.Name, container.Name, err)
+			}
+			prefix := logPrefix(pod.Name, container.Name)
+			for _, line := range strings.SplitAfter(string(data), "\n") {
+				if line != "" {
+					logs.WriteString(prefix + line)
+				}
+			}
+		}
+	}
+	return logs.Bytes(), nil
+}
+
+// FollowDeploymentLogs streams what every container of the pods of a deployment logs from since ago on, in
+// the format of DeploymentLogs, until ctx ends.
+func (c *KubeClient) FollowDeploymentLogs(ctx context.Context, namespace, name string, since time.Duration) (io.Reader, error) {
+	pods, err := c.deploymentPods(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	sinceSeconds := max(int64(since.Seconds()), 1)
+	reader, writer := io.Pipe()
+	var (
+		mu      sync.Mutex
+		streams sync.WaitGroup
+	)
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			stream, err := c.Clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name, Follow: true, Timestamps: true, SinceSeconds: &sinceSeconds,
+			}).Stream(ctx)
+			if err != nil {
+				fmt.Printf("Failed to follow the logs of %s/%s: %v\n", pod.Name, container.Name, err)
+				continue
+			}
+			prefix := logPrefix(pod.Name, container.Name)
+			streams.Add(1)
+			go func() {
+				defer streams.Done()
+				defer stream.Close()
+				scanner := bufio.NewScanner(stream)
+				scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+				for scanner.Scan() {
+					mu.Lock()
+					_, err := io.WriteString(writer, prefix+scanner.Text()+"\n")
+					mu.Unlock()
+					if err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}
+	go func() {
+		streams.Wait()
+		_ = writer.Close()
+	}()
+	return reader, nil
+}
+
+// deploymentPods returns the pods a deployment selects.
+func (c *KubeClient) deploymentPods(namespace, name string) ([]corev1.Pod, error) {
+	deployment, err := c.GetDeployment(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector of deployment %s/%s: %w", namespace, name, err)
+	}
+	return c.ListPods(namespace, selector.String())
+}
+
+// logPrefix is the prefix kubectl logs --prefix puts in front of the lines of a container.
+func logPrefix(pod, container string) string {
+	return fmt.Sprintf("[pod/%s/%s] ", pod, container)
+}
+
+// ListEvents returns the events of all namespaces.
+func (c *KubeClient) ListEvents() ([]corev1.Event, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the events: %w", err)
+	}
+	return list.Items, nil
+}
+
+// PatchSecret applies a merge or JSON patch to a secret.
+func (c *KubeClient) PatchSecret(namespace, name string, patchType types.PatchType, patch []byte) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Clientset.CoreV1().Secrets(namespace).Patch(ctx, name, patchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch secret %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// GetConfigMap returns a ConfigMap; apierrors.IsNotFound tells a missing one.
+func (c *KubeClient) GetConfigMap(namespace, name string) (*corev1.ConfigMap, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	configMap, err := c.Clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, name, err)
+	}
+	return configMap, nil
+}
+
+// CreateConfigMap creates a ConfigMap; apierrors.IsAlreadyExists tells an existing one.
+func (c *KubeClient) CreateConfigMap(configMap *corev1.ConfigMap) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Clientset.CoreV1().ConfigMaps(configMap.Namespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("failed to create configmap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+	}
+	return nil
+}
+
+// PatchConfigMap patches a ConfigMap.
+func (c *KubeClient) PatchConfigMap(namespace, name string, patchType types.PatchType, patch []byte) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Clientset.CoreV1().ConfigMaps(namespace).Patch(ctx, name, patchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch configmap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// DeleteConfigMapsWithLabels deletes the ConfigMaps of a namespace matching a non-empty label selector.
+func (c *KubeClient) DeleteConfigMapsWithLabels(namespace, labelSelector string) error {
+	if labelSelector == "" {
+		return fmt.Errorf("refusing to delete all configmaps of %s without a label selector", namespace)
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if err := c.Clientset.CoreV1().ConfigMaps(namespace).DeleteCollection(ctx, metav1.DeleteOptions{},
+		metav1.ListOptions{LabelSelector: labelSelector}); err != nil {
+		return fmt.Errorf("failed to delete the configmaps %s of %s: %w", labelSelector, namespace, err)
+	}
+	return nil
+}
+
+// DeleteConfigMaps deletes ConfigMaps of a namespace; missing ones are not an error.
+func (c *KubeClient) DeleteConfigMaps(namespace string, names ...string) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	for _, name := range names {
+		err := c.Clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete configmap %s/%s: %w", namespace, name, err)
+		}
+	}
+	return nil
+}
+
+// GetDeployment returns a deployment.
+func (c *KubeClient) GetDeployment(namespace, name string) (*appsv1.Deployment, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s/%s: %w", namespace, name, err)
+	}
+	return deployment, nil
+}
+
+// ListDeployments returns the deployments of a namespace.
+func (c *KubeClient) ListDeployments(namespace string) ([]appsv1.Deployment, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the deployments of %s: %w", namespace, err)
+	}
+	return list.Items, nil
+}
+
+// WorkloadSpec is the part of the spec Deployments, DaemonSets and StatefulSets share.
+type WorkloadSpec struct {
+	// Replicas is nil for a DaemonSet.
+	Replicas *int32                 `json:"replicas,omitempty"`
+	Selector *metav1.LabelSelector  `json:"selector,omitempty"`
+	Template corev1.PodTemplateSpec `json:"template"`
+}
+
+// GetWorkloadSpec returns the spec of a Deployment, DaemonSet or StatefulSet, kind in any case as kubectl
+// takes it.
+func (c *KubeClient) GetWorkloadSpec(namespace, kind, name string) (*WorkloadSpec, error) {
+	object, err := c.get(workloadGVR(kind), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	var spec WorkloadSpec
+	if err := fromUnstructured(object.Object["spec"], &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec of %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return &spec, nil
+}
+
+// ListWorkloads returns the Deployments, DaemonSets or StatefulSets (kind) of a namespace, all namespaces
+// for "".
+func (c *KubeClient) ListWorkloads(namespace, kind string) ([]unstructured.Unstructured, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	list, err := c.Dynamic.Resource(workloadGVR(kind)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the %s of %q: %w", workloadGVR(kind).Resource, namespace, err)
+	}
+	return list.Items, nil
+}
+
+// PatchWorkload applies a strategic merge, merge or JSON patch to a Deployment, DaemonSet or StatefulSet.
+func (c *KubeClient) PatchWorkload(namespace, kind, name string, patchType types.PatchType, patch []byte) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Dynamic.Resource(workloadGVR(kind)).Namespace(namespace).Patch(ctx, name, patchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to patch %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return nil
+}
+
+// RestartWorkload restarts the pods of a Deployment, DaemonSet or StatefulSet as kubectl rollout restart
+// does, by stamping its pod template; WaitForRollout waits for the new pods.
+func (c *KubeClient) RestartWorkload(namespace, kind, name string) error {
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`,
+		time.Now().Format(time.RFC3339))
+	if err := c.PatchWorkload(namespace, kind, name, types.StrategicMergePatchType, []byte(patch)); err != nil {
+		return fmt.Errorf("failed to restart %s %s/%s: %w", kind, namespace, name, err)
+	}
+	return nil
+}
+
+// workloadGVR returns the resource of the apps API of a workload kind, e.g. "DaemonSet" or "deployment".
+func workloadGVR(kind string) schema.GroupVersionResource {
+	return appsv1.SchemeGroupVersion.WithResource(strings.ToLower(kind) + "s")
+}
+
+// WaitForRollout waits until every replica of a Deployment, DaemonSet or StatefulSet (kind, in any case) runs
+// its current pod template and is available, as kubectl rollout status does, or fails once timeout is over.
+// Each poll is bounded on its own, so a request stuck on a connection being replaced is retried.
+func (c *KubeClient) WaitForRollout(namespace, kind, name string, timeout time.Duration) error {
+	var last string
+	err := wait.PollUntilContextTimeout(context.Background(), kubeClientPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		ctx, cancel := context.WithTimeout(ctx, kubeClientTimeout)
+		defer cancel()
+		var done bool
+		switch strings.ToLower(kind) {
+		case "deployment":
+			deployment, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				last = err.Error()
+				return false, nil
+			}
+			done, last = deploymentRolledOut(deployment)
+		case "daemonset":
+			daemonSet, err := c.Clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				last = err.Error()
+				return false, nil
+			}
+			done, last = daemonSetRolledOut(daemonSet)
+		case "statefulset":
+			statefulSet, err := c.Clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				last = err.Error()
+				return false, nil
+			}
+			done, last = statefulSetRolledOut(statefulSet)
+		default:
+			return false, fmt.Errorf("cannot wait for the rollout of a %s", kind)
+		}
+		return done, nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s %s/%s has not rolled out after %v, last %s: %w", kind, namespace, name, timeout, last, err)
+	}
+	return nil
+}
+
+// deploymentRolledOut reports whether a deployment has rolled out, and what it waits for if not.
+func deploymentRolledOut(deployment *appsv1.Deployment) (bool, string) {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	switch {
+	case status.ObservedGeneration < deployment.Generation:
+		return false, "the new generation to be observed"
+	case status.UpdatedReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	case status.Replicas > status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d of %d updated replicas available", status.AvailableReplicas, status.UpdatedReplicas)
+	}
+	return true, ""
+}
+
+// daemonSetRolledOut reports whether a DaemonSet has rolled out, and what it waits for if not.
+func daemonSetRolledOut(daemonSet *appsv1.DaemonSet) (bool, string) {
+	status := daemonSet.Status
+	switch {
+	case status.ObservedGeneration < daemonSet.Generation:
+		return false, "the new generation to be observed"
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		return false, fmt.Sprintf("%d of %d pods updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		return false, fmt.Sprintf("%d of %d updated pods available", status.NumberAvailable, status.DesiredNumberScheduled)
+	}
+	return true, ""
+}
+
+// statefulSetRolledOut reports whether a StatefulSet has rolled out, and what it waits for if not.
+func statefulSetRolledOut(statefulSet *appsv1.StatefulSet) (bool, string) {
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	status := statefulSet.Status
+	switch {
+	case status.ObservedGeneration < statefulSet.Generation:
+		return false, "the new generation to be observed"
+	case status.ReadyReplicas < replicas:
+		return false, fmt.Sprintf("%d of %d replicas ready", status.ReadyReplicas, replicas)
+	case status.UpdateRevision != status.CurrentRevision:
+		return false, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	}
+	return true, ""
+}
+
+// ApplyManifest applies the objects of a multi-document YAML manifest with server-side apply, as kubectl apply
+// does; objects without a namespace go to the namespace of the client.
+func (c *KubeClient) ApplyManifest(manifest string) error {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		resource, err := c.resourceInterface(object)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := kubeClientContext()
+		_, err = resource.Apply(ctx, object.GetName(), object, metav1.ApplyOptions{FieldManager: kubeClientFieldManager, Force: true})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to apply %s %s: %w", object.GetKind(), object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// CreateManifest creates the objects of a multi-document YAML manifest, as kubectl create does; objects
+// without a namespace go to the namespace of the client. The error of a rejected object carries the message of
+// the API server, e.g. the denial of an admission webhook.
+func (c *KubeClient) CreateManifest(manifest string) error {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		resource, err := c.resourceInterface(object)
+		if err != nil {
+			return err
+		}
+		ctx, cancel := kubeClientContext()
+		_, err = resource.Create(ctx, object, metav1.CreateOptions{})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to create %s %s: %w", object.GetKind(), object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// DeleteManifest deletes the objects of a manifest in reverse order, without waiting for them to be gone;
+// missing objects are not an error.
+func (c *KubeClient) DeleteManifest(manifest string) error {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+	for i := len(objects) - 1; i >= 0; i-- {
+		resource, err := c.resourceInterface(objects[i])
+		if err != nil {
+			return err
+		}
+		ctx, cancel := kubeClientContext()
+		err = resource.Delete(ctx, objects[i].GetName(), metav1.DeleteOptions{})
+		cancel()
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", objects[i].GetKind(), objects[i].GetName(), err)
+		}
+	}
+	return nil
+}
+
+// GetRaw returns the body of a GET of a server relative URI, e.g. "/readyz" or the proxied configz of a node,
+// as kubectl get --raw does. timeout bounds the request as --request-timeout does.
+func (c *KubeClient) GetRaw(uri string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	body, err := c.Clientset.CoreV1().RESTClient().Get().RequestURI(uri).DoRaw(ctx)
+	if err != nil {
+		return body, fmt.Errorf("failed to get %s: %w", uri, err)
+	}
+	return body, nil
+}
+
+// StreamRaw streams the body of a GET of a server relative URI, e.g. a watch, as kubectl get --raw
+// --request-timeout=0 does, until the server ends it, ctx ends or the caller closes the stream.
+func (c *KubeClient) StreamRaw(ctx context.Context, uri string) (io.ReadCloser, error) {
+	stream, err := c.Clientset.CoreV1().RESTClient().Get().RequestURI(uri).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", uri, err)
+	}
+	return stream, nil
+}
+
+// APIVersions returns the group/versions the API server serves, as kubectl api-versions prints them.
+func (c *KubeClient) APIVersions() ([]string, error) {
+	groups, err := c.Clientset.Discovery().ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the API versions: %w", err)
+	}
+	return metav1.ExtractGroupVersions(groups), nil
+}
+
+// DryRunCreateService has the API server admit or reject the creation of a service without creating it, as
+// kubectl create --dry-run=server does.
+func (c *KubeClient) DryRunCreateService(service *corev1.Service) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Clientset.CoreV1().Services(service.Namespace).Create(ctx, service,
+		metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+		return fmt.Errorf("failed to create service %s/%s: %w", service.Namespace, service.Name, err)
+	}
+	return nil
+}
+
+// DryRunCreatePod has the API server admit or reject the creation of a pod without creating it, e.g. to
+// probe its pod security admission.
+func (c *KubeClient) DryRunCreatePod(pod *corev1.Pod) error {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	if _, err := c.Clientset.CoreV1().Pods(pod.Namespace).Create(ctx, pod,
+		metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+		return fmt.Errorf("failed to create pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
+// ServerVersion returns the version of the API server.
+func (c *KubeClient) ServerVersion() (*version.Info, error) {
+	return c.Clientset.Discovery().ServerVersion()
+}
+
+// decodeManifest returns the objects of a multi-document YAML manifest, skipping empty documents.
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(strings.NewReader(manifest)))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		data, err := utilyaml.ToJSON(document)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		if trimmed := bytes.TrimSpace(data); len(trimmed) == 0 || string(trimmed) == "null" {
+			continue
+		}
+		object := &unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(data); err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+		if object.GetName() == "" {
+			return nil, fmt.Errorf("invalid manifest: a %s without a name", object.GetKind())
+		}
+		objects = append(objects, object)
+	}
+}
+
+// resourceInterface returns the client of the resource of an object, in its namespace for a namespaced one.
+func (c *KubeClient) resourceInterface(object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := c.restMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the resource of %s: %w", gvk, err)
+	}
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return c.Dynamic.Resource(mapping.Resource), nil
+	}
+	namespace := object.GetNamespace()
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return c.Dynamic.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// restMapper maps kinds and resources to the ones the server serves, discovered once per client.
+func (c *KubeClient) restMapper() meta.RESTMapper {
+	c.mapperOnce.Do(func() {
+		c.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(c.Clientset.Discovery()))
+	})
+	return c.mapper
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (c *KubeClient) get(gvr schema.GroupVersionResource, namespace, name string) (*unstructured.Unstructured, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	object, err := c.Dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s/%s: %w", gvr.Resource, namespace, name, err)
+	}
+	return object, nil
+}
+
+// exists reports whether an object exists.
+func (c *KubeClient) exists(gvr schema.GroupVersionResource, namespace, name string) (bool, error) {
+	_, err := c.get(gvr, namespace, name)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// fromUnstructured decodes a field of an unstructured object into out; a missing field leaves out alone.
+func fromUnstructured(field interface{}, out interface{}) error {
+	if field == nil {
+		return nil
+	}
+	data, err := json.Marshal(field)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
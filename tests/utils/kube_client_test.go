@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testObject(gvr schema.GroupVersionResource, kind, namespace, name string, fields map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: fields}
+	object.SetAPIVersion(gvr.GroupVersion().String())
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	return object
+}
+
+func testKubeClient(objects []runtime.Object, dynamicObjects ...runtime.Object) *KubeClient {
+	listKinds := map[schema.GroupVersionResource]string{
+		ClusterTemplateGVR: "ClusterTemplateList",
+		CAPIClusterGVR:     "ClusterList",
+		MachineGVR:         "MachineList",
+		IntelMachineGVR:    "IntelMachineList",
+		ClusterConnectGVR:  "ClusterConnectList",
+	}
+	return &KubeClient{
+		Clientset: fake.NewClientset(objects...),
+		Dynamic:   dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, dynamicObjects...),
+	}
+}
+
+func TestKubeClientClusterTemplateStatus(t *testing.T) {
+	client := testKubeClient(nil,
+		testObject(ClusterTemplateGVR, "ClusterTemplate", "ns", "ready-v1", map[string]interface{}{"status": map[string]interface{}{"ready": true}}),
+		testObject(ClusterTemplateGVR, "ClusterTemplate", "ns", "new-v1", map[string]interface{}{}),
+	)
+	if status, err := client.GetClusterTemplateStatus("ns", "ready-v1"); err != nil || !status.Ready {
+		t.Errorf("Expected a ready template, got %+v, %v", status, err)
+	}
+	if status, err := client.GetClusterTemplateStatus("ns", "new-v1"); err != nil || status.Ready {
+		t.Errorf("Expected a template without status not to be ready, got %+v, %v", status, err)
+	}
+	if _, err := client.GetClusterTemplateStatus("ns", "missing-v1"); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestKubeClientListIntelMachines(t *testing.T) {
+	machine := func(name, cluster string) runtime.Object {
+		object := testObject(IntelMachineGVR, "IntelMachine", "ns", name, map[string]interface{}{})
+		object.SetLabels(map[string]string{"cluster.x-k8s.io/cluster-name": cluster})
+		return object
+	}
+	client := testKubeClient(nil, machine("demo-0", "demo"), machine("other-0", "other"))
+
+	machines, err := client.ListIntelMachines("ns", "demo")
+	if err != nil || len(machines) != 1 || machines[0].GetName() != "demo-0" {
+		t.Errorf("Expected the IntelMachine of demo, got %v, %v", machines, err)
+	}
+	if machines, err := client.ListIntelMachines("ns", ""); err != nil || len(machines) != 2 {
+		t.Errorf("Expected the IntelMachines of the project, got %v, %v", machines, err)
+	}
+}
+
+func TestKubeClientListMachines(t *testing.T) {
+	machine := testObject(MachineGVR, "Machine", "ns", "demo-0", map[string]interface{}{})
+	machine.SetLabels(map[string]string{"cluster.x-k8s.io/cluster-name": "demo"})
+	client := testKubeClient(nil, machine)
+
+	if machines, err := client.ListMachines("ns", "demo"); err != nil || len(machines) != 1 {
+		t.Errorf("Expected the Machine of demo, got %v, %v", machines, err)
+	}
+	if machines, err := client.ListMachines("ns", "other"); err != nil || len(machines) != 0 {
+		t.Errorf("Expected no Machines of other, got %v, %v", machines, err)
+	}
+}
+
+func TestKubeClientGetObject(t *testing.T) {
+	intelClusterGVR := schema.GroupVersionResource{Group: "infrastructure.cluster.x-k8s.io", Version: "v1alpha1", Resource: "intelclusters"}
+	client := testKubeClient(nil, testObject(intelClusterGVR, "IntelCluster", "ns", "demo", map[string]interface{}{}))
+	client.Clientset.(*fake.Clientset).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "infrastructure.cluster.x-k8s.io/v1alpha1"},
+	}
+
+	for _, apiVersion := range []string{"infrastructure.cluster.x-k8s.io/v1alpha1", "infrastructure.cluster.x-k8s.io"} {
+		object, err := client.GetObject(apiVersion, "IntelCluster", "ns", "demo")
+		if err != nil || object.GetName() != "demo" {
+			t.Errorf("Expected the IntelCluster for %s, got %v, %v", apiVersion, object, err)
+		}
+	}
+	if _, err := client.GetObject("controlplane.cluster.x-k8s.io", "KThreesControlPlane", "ns", "demo"); err == nil {
+		t.Error("Expected an error for an API group the server does not serve")
+	}
+}
+
+func TestKubeClientSecretsAndNamespaces(t *testing.T) {
+	client := testKubeClient([]runtime.Object{
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "demo-kubeconfig"}, Data: map[string][]byte{"value": []byte("kubeconfig")}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}},
+	})
+	if secret, err := client.GetSecret("ns", "demo-kubeconfig"); err != nil || string(secret.Data["value"]) != "kubeconfig" {
+		t.Errorf("Expected the kubeconfig secret, got %v, %v", secret, err)
+	}
+	if _, err := client.GetSecret("ns", "missing"); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+	for _, namespace := range []string{"ns", "new"} {
+		if err := client.EnsureNamespace(namespace); err != nil {
+			t.Errorf("Expected namespace %s to be ensured, got %v", namespace, err)
+		}
+	}
+
+	if err := client.PatchSecret("ns", "demo-kubeconfig", types.MergePatchType, []byte(`{"data":{"value":"Y29ycnVwdA=="}}`)); err != nil {
+		t.Errorf("Expected the secret to be patched, got %v", err)
+	}
+	if secret, err := client.GetSecret("ns", "demo-kubeconfig"); err != nil || string(secret.Data["value"]) != "corrupt" {
+		t.Errorf("Expected the patched secret, got %v, %v", secret, err)
+	}
+	for _, namespace := range []string{"new", "missing"} {
+		if err := client.DeleteNamespace(namespace); err != nil {
+			t.Errorf("Expected namespace %s to be deleted, got %v", namespace, err)
+		}
+	}
+	if _, err := client.GetNamespace("new"); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+func TestKubeClientNodes(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}}},
+		}
+	}
+	client := testKubeClient([]runtime.Object{node("ready", corev1.ConditionTrue), node("lost", corev1.ConditionUnknown)})
+	nodes, err := client.ListNodes()
+	if err != nil || len(nodes) != 2 {
+		t.Fatalf("Expected two nodes, got %v, %v", nodes, err)
+	}
+	for name, want := range map[string]bool{"ready": true, "lost": false} {
+		got, err := client.GetNode(name)
+		if err != nil {
+			t.Fatalf("Expected node %s, got %v", name, err)
+		}
+		if NodeReady(got) != want {
+			t.Errorf("Expected node %s to be ready: %v", name, want)
+		}
+	}
+}
+
+func TestKubeClientWorkloads(t *testing.T) {
+	replicas := int32(1)
+	client := testKubeClient([]runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "agent", Generation: 2},
+			Status:     appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 1, UpdatedNumberScheduled: 1, NumberAvailable: 1},
+		},
+	}, testObject(workloadGVR("DaemonSet"), "DaemonSet", "kube-system", "agent", map[string]interface{}{
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{map[string]interface{}{"name": "agent", "image": "agent:1.0"}},
+		}}},
+	}))
+
+	if deployments, err := client.ListDeployments("default"); err != nil || len(deployments) != 1 {
+		t.Errorf("Expected the gateway deployment, got %v, %v", deployments, err)
+	}
+	if err := client.WaitForRollout("default", "Deployment", "gateway", time.Second); err != nil {
+		t.Errorf("Expected the deployment to be rolled out, got %v", err)
+	}
+	if err := client.WaitForRollout("kube-system", "DaemonSet", "agent", 10*time.Millisecond); err == nil {
+		t.Error("Expected a timeout for a daemonset whose new generation is not observed yet")
+	}
+	if err := client.WaitForRollout("default", "Job", "gateway", time.Second); err == nil {
+		t.Error("Expected an error for a kind without rollouts")
+	}
+	spec, err := client.GetWorkloadSpec("kube-system", "DaemonSet", "agent")
+	if err != nil || len(spec.Template.Spec.Containers) != 1 || spec.Template.Spec.Containers[0].Image != "agent:1.0" {
+		t.Errorf("Expected the spec of the agent, got %+v, %v", spec, err)
+	}
+}
+
+func TestKubeClientWaitForPodReady(t *testing.T) {
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning, Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: ready},
+			}},
+		}
+	}
+	client := testKubeClient([]runtime.Object{pod("ready", corev1.ConditionTrue), pod("starting", corev1.ConditionFalse)})
+	if err := client.WaitForPodReady("ns", "ready", time.Second); err != nil {
+		t.Errorf("Expected the pod to be ready, got %v", err)
+	}
+	if err := client.WaitForPodReady("ns", "starting", 10*time.Millisecond); err == nil {
+		t.Error("Expected a timeout for a pod that is not ready")
+	}
+}
+
+func TestKubeClientClusterExists(t *testing.T) {
+	client := testKubeClient(nil, testObject(CAPIClusterGVR, "Cluster", "ns", "demo", map[string]interface{}{}))
+	if exists, err := client.ClusterExists("ns", "demo"); err != nil || !exists {
+		t.Errorf("Expected cluster demo to exist, got %v, %v", exists, err)
+	}
+	if exists, err := client.ClusterExists("ns", "gone"); err != nil || exists {
+		t.Errorf("Expected cluster gone not to exist, got %v, %v", exists, err)
+	}
+}
+
+func TestKubeClientPodsAndConfigMaps(t *testing.T) {
+	client := testKubeClient([]runtime.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "local-path-0", Labels: map[string]string{"app": "local-path-provisioner"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns-0", Labels: map[string]string{"app": "coredns"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "oidc", Name: "content"}, Data: map[string]string{"jwks.json": "{}"}},
+	})
+	if pods, err := client.ListPods("kube-system", "app=local-path-provisioner"); err != nil || len(pods) != 1 || pods[0].Name != "local-path-0" {
+		t.Errorf("Expected the local-path-provisioner pod, got %v, %v", pods, err)
+	}
+	if pods, err := client.ListPods("", ""); err != nil || len(pods) != 2 {
+		t.Errorf("Expected the pods of all namespaces, got %v, %v", pods, err)
+	}
+	if configMap, err := client.GetConfigMap("oidc", "content"); err != nil || configMap.Data["jwks.json"] != "{}" {
+		t.Errorf("Expected the content ConfigMap, got %v, %v", configMap, err)
+	}
+	if err := client.DeleteConfigMaps("oidc", "content", "missing"); err != nil {
+		t.Errorf("Expected the ConfigMaps to be deleted, got %v", err)
+	}
+	if _, err := client.GetConfigMap("oidc", "content"); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+
+	heartbeat := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "heartbeat"}}
+	if err := client.CreateConfigMap(heartbeat); err != nil {
+		t.Errorf("Expected the ConfigMap to be created, got %v", err)
+	}
+	if err := client.CreateConfigMap(heartbeat); !apierrors.IsAlreadyExists(err) {
+		t.Errorf("Expected an already exists error, got %v", err)
+	}
+	if err := client.PatchConfigMap("default", "heartbeat", types.MergePatchType, heartbeatPatch(3)); err != nil {
+		t.Errorf("Expected the ConfigMap to be patched, got %v", err)
+	}
+	if configMap, err := client.GetConfigMap("default", "heartbeat"); err != nil || configMap.Annotations[heartbeatAnnotation] != "3" {
+		t.Errorf("Expected the heartbeat annotation, got %v, %v", configMap, err)
+	}
+	if err := client.DeleteConfigMapsWithLabels("default", ""); err == nil {
+		t.Error("Expected an empty label selector to be rejected")
+	}
+	if err := client.DeletePods("kube-system", ""); err == nil {
+		t.Error("Expected an empty label selector to be rejected")
+	}
+}
+
+func TestRolledOut(t *testing.T) {
+	replicas := int32(2)
+	deployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Generation: 2}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}, Status: status}
+	}
+	deployments := []struct {
+		status appsv1.DeploymentStatus
+		done   bool
+	}{
+		{appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}, true},
+		{appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}, false},
+		{appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 2}, false},
+		{appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 1}, false},
+	}
+	for _, tc := range deployments {
+		if done, waiting := deploymentRolledOut(deployment(tc.status)); done != tc.done {
+			t.Errorf("Expected a deployment with %+v to be rolled out: %v, got %v (%s)", tc.status, tc.done, done, waiting)
+		}
+	}
+
+	statefulSet := func(status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Generation: 1}, Spec: appsv1.StatefulSetSpec{Replicas: &replicas}, Status: status}
+	}
+	statefulSets := []struct {
+		status appsv1.StatefulSetStatus
+		done   bool
+	}{
+		{appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "a"}, true},
+		{appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 1, CurrentRevision: "a", UpdateRevision: "a"}, false},
+		{appsv1.StatefulSetStatus{ObservedGeneration: 1, ReadyReplicas: 2, CurrentRevision: "a", UpdateRevision: "b"}, false},
+	}
+	for _, tc := range statefulSets {
+		if done, waiting := statefulSetRolledOut(statefulSet(tc.status)); done != tc.done {
+			t.Errorf("Expected a StatefulSet with %+v to be rolled out: %v, got %v (%s)", tc.status, tc.done, done, waiting)
+		}
+	}
+}
+
+func TestDecodeManifest(t *testing.T) {
+	manifest := `apiVersion: v1
+kind: Namespace
+metadata:
+  name: demo
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: demo
+  namespace: demo
+spec:
+  replicas: 2
+`
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		t.Fatalf("Expected the manifest to decode, got %v", err)
+	}
+	var kinds []string
+	for _, object := range objects {
+		kinds = append(kinds, object.GetKind()+"/"+object.GetName())
+	}
+	if want := []string{"Namespace/demo", "Deployment/demo"}; !slices.Equal(kinds, want) {
+		t.Errorf("Expected objects %v, got %v", want, kinds)
+	}
+	if replicas, _, _ := unstructured.NestedInt64(objects[1].Object, "spec", "replicas"); replicas != 2 {
+		t.Errorf("Expected 2 replicas, got %d", replicas)
+	}
+
+	if objects, err := decodeManifest("---\n\n---\n"); err != nil || len(objects) != 0 {
+		t.Errorf("Expected no objects of empty documents, got %v, %v", objects, err)
+	}
+	if _, err := decodeManifest("kind: ConfigMap\napiVersion: v1\n"); err == nil {
+		t.Error("Expected an error for an object without a name")
+	}
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	"gopkg.in/yaml.v3"
+)
+
+// KubeconfigSource is where a downstream kubeconfig was retrieved from.
+type KubeconfigSource string
+
+const (
+	// KubeconfigSourceAPI is the cluster-manager kubeconfigs endpoint.
+	KubeconfigSourceAPI KubeconfigSource = "api"
+	// KubeconfigSourceSecret is the <cluster>-kubeconfig secret CAPI keeps in the cluster namespace.
+	KubeconfigSourceSecret KubeconfigSource = "secret"
+	// KubeconfigSourceClusterctl is clusterctl get kubeconfig.
+	KubeconfigSourceClusterctl KubeconfigSource = "clusterctl"
+
+	// KubeconfigExpiryMargin is how long before its expiry a registered kubeconfig is no longer handed out,
+	// so a command started with it does not fail halfway.
+	KubeconfigExpiryMargin = time.Minute
+)
+
+// Kubeconfig is a kubeconfig of a downstream cluster held by a KubeconfigRegistry.
+type Kubeconfig struct {
+	Namespace   string
+	ClusterName string
+	Source      KubeconfigSource
+	// Path is the file the kubeconfig was written to, pointed at the local connect-gateway port-forward.
+	Path        string
+	RetrievedAt time.Time
+	// ExpiresAt is when the first of its credentials expires, a bearer token or a client certificate; zero
+	// when none says.
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the kubeconfig expires within margin of now.
+func (k *Kubeconfig) Expired(now time.Time, margin time.Duration) bool {
+	return !k.ExpiresAt.IsZero() && !now.Add(margin).Before(k.ExpiresAt)
+}
+
+func (k *Kubeconfig) String() string {
+	s := fmt.Sprintf("%s/%s from %s at %s", k.Namespace, k.ClusterName, k.Source, k.Path)
+	if !k.ExpiresAt.IsZero() {
+		s += ", expires " + k.ExpiresAt.Format(time.RFC3339)
+	}
+	return s
+}
+
+// KubeconfigRegistry tracks the kubeconfigs of several downstream clusters at a time, keyed by cluster name.
+// It is safe for concurrent use.
+type KubeconfigRegistry struct {
+	dir         string
+	mu          sync.Mutex
+	kubeconfigs map[string]*Kubeconfig
+}
+
+// NewKubeconfigRegistry returns a registry writing its kubeconfigs to dir, typically GinkgoT().TempDir().
+func NewKubeconfigRegistry(dir string) *KubeconfigRegistry {
+	return &KubeconfigRegistry{dir: dir, kubeconfigs: map[string]*Kubeconfig{}}
+}
+
+// Register writes a retrieved kubeconfig of a cluster, pointed at the local connect-gateway port-forward,
+// and replaces the one registered for the cluster before.
+func (r *KubeconfigRegistry) Register(namespace, clusterName string, source KubeconfigSource, data []byte) (*Kubeconfig, error) {
+	expiresAt, err := kubeconfigExpiry(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig of cluster %s/%s from %s: %w", namespace, clusterName, source, err)
+	}
+	path := filepath.Join(r.dir, clusterName+"-kubeconfig.yaml")
+	local, err := pointKubeconfigAt(data, LocalGatewayKubeconfigServer)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig of cluster %s/%s from %s: %w", namespace, clusterName, source, err)
+	}
+	if err := os.WriteFile(path, local, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	RecordDebugKubeconfig(path)
+
+	kubeconfig := &Kubeconfig{
+		Namespace:   namespace,
+		ClusterName: clusterName,
+		Source:      source,
+		Path:        path,
+		RetrievedAt: time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.kubeconfigs[clusterName] = kubeconfig
+	return kubeconfig, nil
+}
+
+// FetchFromAPI retrieves the kubeconfig of a cluster from cluster-manager and registers it.
+func (r *KubeconfigRegistry) FetchFromAPI(authContext *auth.TestAuthContext, namespace, clusterName string) (*Kubeconfig, error) {
+	return r.fetch(KubeconfigSourceAPI, authContext, namespace, clusterName)
+}
+
+// FetchFromSecret reads the kubeconfig of a cluster from its CAPI secret and registers it.
+func (r *KubeconfigRegistry) FetchFromSecret(namespace, clusterName string) (*Kubeconfig, error) {
+	return r.fetch(KubeconfigSourceSecret, nil, namespace, clusterName)
+}
+
+// FetchFromClusterctl retrieves the kubeconfig of a cluster with clusterctl and registers it.
+func (r *KubeconfigRegistry) FetchFromClusterctl(namespace, clusterName string) (*Kubeconfig, error) {
+	return r.fetch(KubeconfigSourceClusterctl, nil, namespace, clusterName)
+}
+
+func (r *KubeconfigRegistry) fetch(source KubeconfigSource, authContext *auth.TestAuthContext, namespace, clusterName string) (*Kubeconfig, error) {
+	data, err := fetchDownstreamKubeconfig(source, authContext, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return r.Register(namespace, clusterName, source, data)
+}
+
+// Get returns the kubeconfig registered for a cluster, expired or not.
+func (r *KubeconfigRegistry) Get(clusterName string) (*Kubeconfig, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kubeconfig, ok := r.kubeconfigs[clusterName]
+	return kubeconfig, ok
+}
+
+// Path returns the file of the kubeconfig of a cluster for kubectl --kubeconfig. It fails when none is
+// registered or it expires within KubeconfigExpiryMargin, so the caller fetches a new one.
+func (r *KubeconfigRegistry) Path(clusterName string) (string, error) {
+	kubeconfig, ok := r.Get(clusterName)
+	if !ok {
+		return "", fmt.Errorf("no kubeconfig registered for cluster %s", clusterName)
+	}
+	if kubeconfig.Expired(time.Now(), KubeconfigExpiryMargin) {
+		return "", fmt.Errorf("kubeconfig of cluster %s expired at %s", clusterName, kubeconfig.ExpiresAt.Format(time.RFC3339))
+	}
+	return kubeconfig.Path, nil
+}
+
+// Clusters returns the names of the clusters with a registered kubeconfig, sorted.
+func (r *KubeconfigRegistry) Clusters() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.kubeconfigs))
+	for name := range r.kubeconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove forgets the kubeconfig of a cluster and deletes its file.
+func (r *KubeconfigRegistry) Remove(clusterName string) {
+	r.mu.Lock()
+	kubeconfig, ok := r.kubeconfigs[clusterName]
+	delete(r.kubeconfigs, clusterName)
+	r.mu.Unlock()
+	if ok {
+		_ = os.Remove(kubeconfig.Path)
+	}
+}
+
+// kubeconfigExpiry returns when the first credential of a kubeconfig expires: the exp claim of a JWT bearer
+// token or the NotAfter of a client certificate. Credentials that do not say are ignored.
+func kubeconfigExpiry(data []byte) (time.Time, error) {
+	var config struct {
+		Users []struct {
+			User struct {
+				Token                 string `yaml:"token"`
+				ClientCertificateData string `yaml:"client-certificate-data"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return time.Time{}, err
+	}
+	var expiry time.Time
+	earliest := func(t time.Time) {
+		if !t.IsZero() && (expiry.IsZero() || t.Before(expiry)) {
+			expiry = t
+		}
+	}
+	for _, user := range config.Users {
+		if user.User.Token != "" {
+			earliest(tokenExpiry(user.User.Token))
+		}
+		if user.User.ClientCertificateData != "" {
+			earliest(certificateExpiry(user.User.ClientCertificateData))
+		}
+	}
+	return expiry, nil
+}
+
+// tokenExpiry returns the exp claim of a JWT, zero for a token that is not a JWT or has none.
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// certificateExpiry returns the NotAfter of base64 PEM certificate data, zero when it is not a certificate.
+func certificateExpiry(data string) time.Time {
+	cert, err := parseBase64Certificate(data)
+	if err != nil {
+		return time.Time{}
+	}
+	return cert.NotAfter
+}
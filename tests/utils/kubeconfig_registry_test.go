@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testKubeconfig(server, user string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+  - name: demo
+    cluster:
+      server: %s
+users:
+  - name: demo
+    user:
+%s
+`, server, user))
+}
+
+func testJWT(exp int64) string {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	return encode(`{"alg":"RS256"}`) + "." + encode(fmt.Sprintf(`{"sub":"demo","exp":%d}`, exp)) + ".c2lnbmF0dXJl"
+}
+
+func TestKubeconfigExpiry(t *testing.T) {
+	tokenExp := time.Date(2026, 5, 4, 12, 0, 0, 0, time.UTC)
+	cert := newTestCertificate(t, nil, tokenExp.Add(-time.Hour), 30*time.Minute)
+	certData := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+
+	for _, c := range []struct {
+		description string
+		user        string
+		want        time.Time
+	}{
+		{"a JWT bearer token", "      token: " + testJWT(tokenExp.Unix()), tokenExp},
+		{"a client certificate", "      client-certificate-data: " + certData, cert.NotAfter},
+		{"both, the earliest wins", "      token: " + testJWT(tokenExp.Unix()) + "\n      client-certificate-data: " + certData, cert.NotAfter},
+		{"an opaque token", "      token: not-a-jwt", time.Time{}},
+	} {
+		got, err := kubeconfigExpiry(testKubeconfig("https://demo:6443", c.user))
+		if err != nil {
+			t.Fatalf("%s: Expected no error, got %v", c.description, err)
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("%s: Expected expiry %v, got %v", c.description, c.want, got)
+		}
+	}
+
+	if _, err := kubeconfigExpiry([]byte("users: [")); err == nil {
+		t.Error("Expected an error for invalid YAML")
+	}
+}
+
+func TestKubeconfigRegistry(t *testing.T) {
+	registry := NewKubeconfigRegistry(t.TempDir())
+	kubeconfig, err := registry.Register("ns", "demo", KubeconfigSourceClusterctl,
+		testKubeconfig("http://connect-gateway.orch-cluster.svc:8080/kubernetes/ns-demo", "      token: not-a-jwt"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, err := os.ReadFile(kubeconfig.Path)
+	if err != nil {
+		t.Fatalf("Expected the kubeconfig to be written, got %v", err)
+	}
+	if !strings.Contains(string(data), LocalGatewayKubeconfigServer+"kubernetes/ns-demo") {
+		t.Errorf("Expected the server to point at the local gateway, got:\n%s", data)
+	}
+	if path, err := registry.Path("demo"); err != nil || path != kubeconfig.Path {
+		t.Errorf("Expected the path %s, got %q, %v", kubeconfig.Path, path, err)
+	}
+
+	expired := testKubeconfig("https://demo:6443", "      token: "+testJWT(time.Now().Add(30*time.Second).Unix()))
+	if _, err := registry.Register("ns", "expiring", KubeconfigSourceAPI, expired); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := registry.Path("expiring"); err == nil {
+		t.Error("Expected a kubeconfig expiring within the margin to be refused")
+	}
+	if got, ok := registry.Get("expiring"); !ok || got.Source != KubeconfigSourceAPI {
+		t.Errorf("Expected the expiring kubeconfig from the API, got %v", got)
+	}
+
+	if got := registry.Clusters(); strings.Join(got, ",") != "demo,expiring" {
+		t.Errorf("Expected demo and expiring, got %v", got)
+	}
+	registry.Remove("demo")
+	if _, err := registry.Path("demo"); err == nil {
+		t.Error("Expected no kubeconfig after Remove")
+	}
+	if _, err := os.Stat(kubeconfig.Path); !os.IsNotExist(err) {
+		t.Errorf("Expected the kubeconfig file to be removed, got %v", err)
+	}
+}
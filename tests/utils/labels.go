@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"slices"
+	"strings"
+)
+
+// The ginkgo labels of the specs. Each is selected by a mage target of mage/test.go, see SpecLabels.
+const (
+	ClusterOrchRobustnessTest       = "cluster-orch-robustness-test"
+	ClusterOrchClusterApiAllTest    = "cluster-orch-cluster-api-all-test"
+	ClusterOrchClusterApiSmokeTest  = "cluster-orch-cluster-api-smoke-test"
+	ClusterOrchTemplateApiSmokeTest = "cluster-orch-template-api-smoke-test"
+	ClusterOrchTemplateApiAllTest   = "cluster-orch-template-api-all-test"
+	ClusterOrchCRApiTest            = "cluster-orch-cr-api-test"
+	ClusterOrchTemplateProfileTest  = "cluster-orch-template-profile-test"
+	ClusterOrchTrustedComputeTest   = "cluster-orch-trusted-compute-test"
+	ClusterOrchTemplateMixTest      = "cluster-orch-template-mix-test"
+	ClusterOrchAirGappedTest        = "cluster-orch-air-gapped-test"
+	ClusterOrchSlowRegistryTest     = "cluster-orch-slow-registry-test"
+	ClusterOrchPivotTest            = "cluster-orch-pivot-test"
+	ClusterOrchBackupRestoreTest    = "cluster-orch-backup-restore-test"
+	ClusterOrchHelmValuesTest       = "cluster-orch-helm-values-test"
+	ClusterOrchProjectTest          = "cluster-orch-project-test"
+	ClusterOrchInventoryTest        = "cluster-orch-inventory-test"
+	ClusterOrchOnboardingTest       = "cluster-orch-onboarding-test"
+	ClusterOrchGatewayTest          = "cluster-orch-gateway-test"
+	ClusterOrchGatewayPerfTest      = "cluster-orch-gateway-perf-test"
+	ClusterOrchFuzzTest             = "cluster-orch-fuzz-test"
+	ClusterOrchApiLimitsTest        = "cluster-orch-api-limits-test"
+	ClusterOrchAuditLogTest         = "cluster-orch-audit-log-test"
+	ClusterOrchClusterLabelsTest    = "cluster-orch-cluster-labels-test"
+	ClusterOrchNameValidationTest   = "cluster-orch-name-validation-test"
+	ClusterOrchNodeDeleteTest       = "cluster-orch-node-delete-test"
+	ClusterOrchHTTPClientTest       = "cluster-orch-http-client-test"
+	ClusterOrchApiVersionTest       = "cluster-orch-api-version-test"
+	ClusterOrchScaleTest            = "cluster-orch-scale-test"
+)
+
+// SpecLabels is the registry of the labels specs may carry, besides the labels of the RequiresCapabilities
+// and SkipWithIssue decorators. labels_test.go checks that every spec only carries registered labels, that
+// every registered label is carried by a spec, and that every spec is selected by a mage target.
+var SpecLabels = []string{
+	ClusterOrchRobustnessTest,
+	ClusterOrchClusterApiAllTest,
+	ClusterOrchClusterApiSmokeTest,
+	ClusterOrchTemplateApiSmokeTest,
+	ClusterOrchTemplateApiAllTest,
+	ClusterOrchCRApiTest,
+	ClusterOrchTemplateProfileTest,
+	ClusterOrchTrustedComputeTest,
+	ClusterOrchTemplateMixTest,
+	ClusterOrchAirGappedTest,
+	ClusterOrchSlowRegistryTest,
+	ClusterOrchPivotTest,
+	ClusterOrchBackupRestoreTest,
+	ClusterOrchHelmValuesTest,
+	ClusterOrchProjectTest,
+	ClusterOrchInventoryTest,
+	ClusterOrchOnboardingTest,
+	ClusterOrchGatewayTest,
+	ClusterOrchGatewayPerfTest,
+	ClusterOrchFuzzTest,
+	ClusterOrchApiLimitsTest,
+	ClusterOrchAuditLogTest,
+	ClusterOrchClusterLabelsTest,
+	ClusterOrchNameValidationTest,
+	ClusterOrchNodeDeleteTest,
+	ClusterOrchHTTPClientTest,
+	ClusterOrchApiVersionTest,
+	ClusterOrchScaleTest,
+}
+
+// IsSpecLabel reports whether label is registered in SpecLabels or is a label of the RequiresCapabilities,
+// SkipWithIssue and UsesClusterManagerAPI decorators.
+func IsSpecLabel(label string) bool {
+	return slices.Contains(SpecLabels, label) || strings.HasPrefix(label, capabilityLabelPrefix) ||
+		strings.HasPrefix(label, quarantineLabelPrefix) || strings.HasPrefix(label, apiVersionLabelPrefix)
+}
@@ -0,0 +1,283 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/onsi/ginkgo/v2/types"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	containerNodes = []string{"Describe", "Context", "When", "DescribeTable"}
+	specNodes      = []string{"It", "Specify", "Entry"}
+)
+
+// labeledSpec is a spec of a suite with its labels and the ones of its containers.
+type labeledSpec struct {
+	suite    string
+	location string
+	labels   []string
+}
+
+// labelSelector is a ginkgo run of a mage target or run profile.
+type labelSelector struct {
+	filter types.LabelFilter
+	suites []string
+}
+
+// labelConsts returns the label constants of labels.go by name.
+func labelConsts(t *testing.T) map[string]string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "labels.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	consts := map[string]string{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				consts[name.Name], _ = strconv.Unquote(value.Values[i].(*ast.BasicLit).Value)
+			}
+		}
+	}
+	return consts
+}
+
+// resolveLabel returns the value of a label argument, a string or a utils constant.
+func resolveLabel(expr ast.Expr, consts map[string]string) (string, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return strconv.Unquote(e.Value)
+	case *ast.SelectorExpr:
+		if value, ok := consts[e.Sel.Name]; ok {
+			return value, nil
+		}
+		return "", fmt.Errorf("%s is not a label constant of labels.go", e.Sel.Name)
+	}
+	return "", fmt.Errorf("label %T is not a constant", expr)
+}
+
+// nodeName returns the ginkgo node a call creates without its F, P or X prefix, or "".
+func nodeName(call *ast.CallExpr) string {
+	var name string
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		name = fun.Name
+	case *ast.SelectorExpr:
+		name = fun.Sel.Name
+	}
+	for _, prefix := range []string{"", "F", "P", "X"} {
+		if trimmed, ok := strings.CutPrefix(name, prefix); ok && (slices.Contains(containerNodes, trimmed) || slices.Contains(specNodes, trimmed)) {
+			return trimmed
+		}
+	}
+	if name == "Label" {
+		return name
+	}
+	return ""
+}
+
+// suiteSpecs returns the specs of the suite directories with their inherited labels, and every label the
+// containers and specs carry.
+func suiteSpecs(t *testing.T, consts map[string]string) ([]labeledSpec, map[string]bool) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join("..", "*-test", "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	var specs []labeledSpec
+	carried := map[string]bool{}
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		suite := filepath.Base(filepath.Dir(path))
+
+		// Specs are only collected inside containers: the entries a helper builds for a table get their labels
+		// from the table, which is not known where they are built.
+		var visit func(node ast.Node, inherited []string, inContainer bool)
+		visit = func(node ast.Node, inherited []string, inContainer bool) {
+			ast.Inspect(node, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				name := nodeName(call)
+				if name == "" || name == "Label" {
+					return true
+				}
+				labels := slices.Clone(inherited)
+				for _, arg := range call.Args {
+					if decorator, ok := arg.(*ast.CallExpr); ok && nodeName(decorator) == "Label" {
+						for _, labelArg := range decorator.Args {
+							label, err := resolveLabel(labelArg, consts)
+							if err != nil {
+								t.Errorf("%s: %v", fset.Position(labelArg.Pos()), err)
+								continue
+							}
+							labels = append(labels, label)
+							carried[label] = true
+						}
+					}
+				}
+				if inContainer && slices.Contains(specNodes, name) {
+					specs = append(specs, labeledSpec{suite: suite, location: fset.Position(call.Pos()).String(), labels: labels})
+				}
+				for _, arg := range call.Args {
+					visit(arg, labels, inContainer || slices.Contains(containerNodes, name))
+				}
+				return false
+			})
+		}
+		visit(file, nil, false)
+	}
+	return specs, carried
+}
+
+// mageSelectors returns the ginkgo runs of the mage targets of mage/test.go: the label filter built with
+// fmt.Sprintf from label constants and the suite directories of each sh.RunV or sh.RunWithV call.
+func mageSelectors(t *testing.T, consts map[string]string) []labelSelector {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join("..", "..", "mage", "test.go"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var selectors []labelSelector
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fun, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || (fun.Sel.Name != "RunV" && fun.Sel.Name != "RunWithV") {
+			return true
+		}
+		var selector labelSelector
+		for _, arg := range call.Args {
+			switch a := arg.(type) {
+			case *ast.BasicLit:
+				if value, _ := strconv.Unquote(a.Value); strings.HasPrefix(value, "./tests/") {
+					selector.suites = append(selector.suites, filepath.Base(value))
+				}
+			case *ast.CallExpr:
+				format, ok := a.Args[0].(*ast.BasicLit)
+				if !ok || !strings.HasPrefix(format.Value, `"--label-filter=`) {
+					continue
+				}
+				var labels []interface{}
+				for _, labelArg := range a.Args[1:] {
+					label, err := resolveLabel(labelArg, consts)
+					if err != nil {
+						t.Errorf("%s: %v", fset.Position(labelArg.Pos()), err)
+					}
+					if !IsSpecLabel(label) {
+						t.Errorf("%s: label %q is not registered in SpecLabels", fset.Position(labelArg.Pos()), label)
+					}
+					labels = append(labels, label)
+				}
+				pattern, _ := strconv.Unquote(format.Value)
+				filter, err := types.ParseLabelFilter(strings.TrimPrefix(fmt.Sprintf(pattern, labels...), "--label-filter="))
+				if err != nil {
+					t.Errorf("%s: %v", fset.Position(a.Pos()), err)
+					continue
+				}
+				selector.filter = filter
+			}
+		}
+		if selector.filter != nil {
+			selectors = append(selectors, selector)
+		}
+		return false
+	})
+	return selectors
+}
+
+// profileSelectors returns the ginkgo runs of the run profiles of configs/run-profiles.yaml.
+func profileSelectors(t *testing.T) []labelSelector {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "configs", "run-profiles.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var profiles []struct {
+		Name   string   `yaml:"name"`
+		Suites []string `yaml:"suites"`
+		Labels []string `yaml:"labels"`
+	}
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		t.Fatal(err)
+	}
+	var selectors []labelSelector
+	for _, profile := range profiles {
+		for _, label := range profile.Labels {
+			if !IsSpecLabel(label) {
+				t.Errorf("run profile %s: label %q is not registered in SpecLabels", profile.Name, label)
+			}
+		}
+		selector := labelSelector{filter: types.MustParseLabelFilter(strings.Join(profile.Labels, " || "))}
+		for _, suite := range profile.Suites {
+			selector.suites = append(selector.suites, filepath.Base(suite))
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors
+}
+
+func TestSpecLabels(t *testing.T) {
+	consts := labelConsts(t)
+	if len(consts) != len(SpecLabels) {
+		t.Errorf("Expected every label constant of labels.go in SpecLabels, got %d constants and %d labels", len(consts), len(SpecLabels))
+	}
+	for _, label := range SpecLabels {
+		if _, err := types.ValidateAndCleanupLabel(label, types.CodeLocation{}); err != nil {
+			t.Errorf("Expected %q to be a valid ginkgo label, got %v", label, err)
+		}
+	}
+
+	specs, carried := suiteSpecs(t, consts)
+	if len(specs) == 0 {
+		t.Fatal("Expected to find the specs of the suites")
+	}
+	for _, spec := range specs {
+		for _, label := range spec.labels {
+			if !IsSpecLabel(label) {
+				t.Errorf("%s: label %q is not registered in SpecLabels", spec.location, label)
+			}
+		}
+	}
+	for _, label := range SpecLabels {
+		if !carried[label] {
+			t.Errorf("Expected a spec labeled %q, remove it from SpecLabels if it is no longer used", label)
+		}
+	}
+
+	selectors := append(mageSelectors(t, consts), profileSelectors(t)...)
+	for _, spec := range specs {
+		selected := slices.ContainsFunc(selectors, func(s labelSelector) bool {
+			return slices.Contains(s.suites, spec.suite) && s.filter(spec.labels)
+		})
+		if !selected {
+			t.Errorf("%s: no mage target runs the spec with the labels %v", spec.location, spec.labels)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMachineHealthCheckTimeout is the NodeReady timeout of the cluster-manager base ClusterClass.
+	DefaultMachineHealthCheckTimeout = 5 * time.Minute
+
+	nodeReadinessRevertPIDFile = "/tmp/cluster-tests-node-readiness-revert.pid"
+	kubeletStoppedMarker       = "/tmp/cluster-tests-kubelet-stopped"
+	cniBackupSuffix            = ".cluster-tests"
+)
+
+// cniConfigDirs are where k3s, rke2 and a plain containerd look for CNI configuration.
+var cniConfigDirs = []string{
+	"/var/lib/rancher/k3s/agent/etc/cni/net.d",
+	"/var/lib/rancher/rke2/agent/etc/cni/net.d",
+	"/etc/cni/net.d",
+}
+
+// MachineHealthOutcome is what the MachineHealthCheck did about an unhealthy node.
+type MachineHealthOutcome string
+
+const (
+	MachineHealthPending    MachineHealthOutcome = ""
+	MachineHealthUnhealthy  MachineHealthOutcome = "unhealthy"
+	MachineHealthRemediated MachineHealthOutcome = "remediated"
+)
+
+// MakeEdgeNodeNotReady makes the kubelet of the edge node report NotReady. A standalone kubelet is
+// stopped. k3s and rke2 run the kubelet inside the same process as the API server, so there the CNI
+// configuration is moved aside instead: the node turns NotReady while its API server stays reachable
+// for the MachineHealthCheck. The edge node undoes this by itself once maxDuration (plus a safety
+// margin) has elapsed, or earlier through RestoreEdgeNodeReadiness.
+func MakeEdgeNodeNotReady(maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(nodeNotReadyScript(maxDuration + faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to make the edge node NotReady: %w", err)
+	}
+	return nil
+}
+
+// RestoreEdgeNodeReadiness undoes MakeEdgeNodeNotReady.
+func RestoreEdgeNodeReadiness() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(nodeReadinessRevertPIDFile) + "\n" + nodeReadinessRestoreCommand()); err != nil {
+		return fmt.Errorf("failed to restore the edge node readiness: %w", err)
+	}
+	return nil
+}
+
+func nodeNotReadyScript(revertAfter time.Duration) string {
+	lines := []string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(nodeReadinessRevertPIDFile),
+		fmt.Sprintf(`if $SUDO systemctl is-active --quiet kubelet 2>/dev/null; then $SUDO systemctl stop kubelet; touch %s; else`, kubeletStoppedMarker),
+	}
+	for _, dir := range cniConfigDirs {
+		backup := dir + cniBackupSuffix
+		lines = append(lines, fmt.Sprintf(`  if [ -n "$($SUDO ls -A %[1]s 2>/dev/null)" ]; then $SUDO mkdir -p %[2]s; $SUDO sh -c 'mv %[1]s/* %[2]s/'; fi`, dir, backup))
+	}
+	lines = append(lines, "fi", edgeNodeScheduleRevertScript(nodeReadinessRevertPIDFile, revertAfter, nodeReadinessRestoreCommand()))
+	return strings.Join(lines, "\n")
+}
+
+// nodeReadinessRestoreCommand is a single line without double quotes or variables other than $SUDO,
+// so it can also run from the background revert timer.
+func nodeReadinessRestoreCommand() string {
+	cmds := []string{fmt.Sprintf("if [ -f %[1]s ]; then $SUDO systemctl start kubelet; rm -f %[1]s; fi", kubeletStoppedMarker)}
+	for _, dir := range cniConfigDirs {
+		backup := dir + cniBackupSuffix
+		cmds = append(cmds, fmt.Sprintf("if [ -d %[2]s ]; then $SUDO mkdir -p %[1]s; $SUDO sh -c 'mv %[2]s/* %[1]s/'; $SUDO rmdir %[2]s; fi", dir, backup))
+	}
+	cmds = append(cmds, "true")
+	return strings.Join(cmds, "; ")
+}
+
+type machineHealthCheck struct {
+	Spec struct {
+		// v1beta1
+		UnhealthyConditions []struct {
+			Timeout string `json:"timeout"`
+		} `json:"unhealthyConditions"`
+		// v1beta2
+		Checks struct {
+			UnhealthyNodeConditions []struct {
+				TimeoutSeconds *int `json:"timeoutSeconds"`
+			} `json:"unhealthyNodeConditions"`
+		} `json:"checks"`
+	} `json:"spec"`
+}
+
+// GetMachineHealthCheckTimeout returns the longest unhealthy node condition timeout of the
+// MachineHealthChecks of a cluster. found is false when the cluster has no MachineHealthCheck.
+func GetMachineHealthCheckTimeout(namespace, clusterName string) (timeout time.Duration, found bool, err error) {
+	out, err := managementResourceJSON("machinehealthchecks.cluster.x-k8s.io", namespace, "cluster.x-k8s.io/cluster-name="+clusterName)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get the MachineHealthChecks of %s/%s: %w", namespace, clusterName, err)
+	}
+	return parseMachineHealthCheckTimeout(out)
+}
+
+func parseMachineHealthCheckTimeout(data []byte) (time.Duration, bool, error) {
+	var list struct {
+		Items []machineHealthCheck `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return 0, false, fmt.Errorf("failed to parse MachineHealthChecks: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return 0, false, nil
+	}
+
+	var longest time.Duration
+	for _, mhc := range list.Items {
+		for _, condition := range mhc.Spec.UnhealthyConditions {
+			timeout, err := time.ParseDuration(condition.Timeout)
+			if err != nil {
+				return 0, true, fmt.Errorf("failed to parse MachineHealthCheck timeout %q: %w", condition.Timeout, err)
+			}
+			longest = max(longest, timeout)
+		}
+		for _, condition := range mhc.Spec.Checks.UnhealthyNodeConditions {
+			if condition.TimeoutSeconds != nil {
+				longest = max(longest, time.Duration(*condition.TimeoutSeconds)*time.Second)
+			}
+		}
+	}
+	if longest == 0 {
+		longest = DefaultMachineHealthCheckTimeout
+	}
+	return longest, true, nil
+}
+
+type healthCheckedMachine struct {
+	Metadata struct {
+		Name string `json:"name"`
+		UID  string `json:"uid"`
+	} `json:"metadata"`
+	Status struct {
+		Conditions []capiCondition `json:"conditions"`
+		V1Beta2    struct {
+			Conditions []capiCondition `json:"conditions"`
+		} `json:"v1beta2"`
+	} `json:"status"`
+}
+
+// ListClusterMachineUIDs returns the UIDs of the Machines of a cluster by name.
+func ListClusterMachineUIDs(namespace, clusterName string) (map[string]string, error) {
+	machines, err := listHealthCheckedMachines(namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	uids := map[string]string{}
+	for _, machine := range machines {
+		uids[machine.Metadata.Name] = machine.Metadata.UID
+	}
+	return uids, nil
+}
+
+// GetMachineHealthOutcome compares the Machines of a cluster with the ones from before a node turned
+// unhealthy: a replaced Machine means it was remediated, a failed health check means the problem is
+// surfaced but not (yet) remediated.
+func GetMachineHealthOutcome(namespace, clusterName string, before map[string]string) (MachineHealthOutcome, error) {
+	machines, err := listHealthCheckedMachines(namespace, clusterName)
+	if err != nil {
+		return MachineHealthPending, err
+	}
+	return machineHealthOutcome(machines, before), nil
+}
+
+func machineHealthOutcome(machines []healthCheckedMachine, before map[string]string) MachineHealthOutcome {
+	if len(machines) != len(before) {
+		return MachineHealthRemediated
+	}
+	outcome := MachineHealthPending
+	for _, machine := range machines {
+		if uid, ok := before[machine.Metadata.Name]; !ok || uid != machine.Metadata.UID {
+			return MachineHealthRemediated
+		}
+		for _, conditions := range [][]capiCondition{machine.Status.Conditions, machine.Status.V1Beta2.Conditions} {
+			for _, c := range conditions {
+				if c.Type == "HealthCheckSucceeded" && c.Status == "False" {
+					outcome = MachineHealthUnhealthy
+				}
+			}
+		}
+	}
+	return outcome
+}
+
+func listHealthCheckedMachines(namespace, clusterName string) ([]healthCheckedMachine, error) {
+	out, err := managementResourceJSON("machines.cluster.x-k8s.io", namespace, "cluster.x-k8s.io/cluster-name="+clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Machines of %s/%s: %w", namespace, clusterName, err)
+	}
+	var list struct {
+		Items []healthCheckedMachine `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse Machines: %w", err)
+	}
+	return list.Items, nil
+}
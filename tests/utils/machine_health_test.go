@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNodeNotReadyScript(t *testing.T) {
+	script := nodeNotReadyScript(12 * time.Minute)
+	for _, want := range []string{
+		"systemctl stop kubelet",
+		"mv /var/lib/rancher/k3s/agent/etc/cni/net.d/* /var/lib/rancher/k3s/agent/etc/cni/net.d.cluster-tests/",
+		"sleep 720;",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in NotReady script:\n%s", want, script)
+		}
+	}
+}
+
+func TestNodeReadinessRestoreCommand(t *testing.T) {
+	cmd := nodeReadinessRestoreCommand()
+	if strings.ContainsAny(cmd, "\n\"") {
+		t.Errorf("Expected a single line restore command without double quotes, got:\n%s", cmd)
+	}
+	if !strings.Contains(cmd, "systemctl start kubelet") || !strings.HasSuffix(cmd, "true") {
+		t.Errorf("Expected restore command to restart the kubelet and always succeed, got %q", cmd)
+	}
+}
+
+func TestParseMachineHealthCheckTimeout(t *testing.T) {
+	v1beta1 := `{"items":[{"spec":{"unhealthyConditions":[{"type":"Ready","status":"Unknown","timeout":"300s"},{"type":"Ready","status":"False","timeout":"6m"}]}}]}`
+	timeout, found, err := parseMachineHealthCheckTimeout([]byte(v1beta1))
+	if err != nil || !found || timeout != 6*time.Minute {
+		t.Errorf("Expected 6m, got %v (found=%t, err=%v)", timeout, found, err)
+	}
+
+	v1beta2 := `{"items":[{"spec":{"checks":{"unhealthyNodeConditions":[{"type":"Ready","status":"False","timeoutSeconds":120}]}}}]}`
+	timeout, _, err = parseMachineHealthCheckTimeout([]byte(v1beta2))
+	if err != nil || timeout != 2*time.Minute {
+		t.Errorf("Expected 2m, got %v (err=%v)", timeout, err)
+	}
+
+	if _, found, err := parseMachineHealthCheckTimeout([]byte(`{"items":[]}`)); err != nil || found {
+		t.Errorf("Expected no MachineHealthCheck, got found=%t err=%v", found, err)
+	}
+}
+
+func TestMachineHealthOutcome(t *testing.T) {
+	machine := func(name, uid, healthCheck string) healthCheckedMachine {
+		var m healthCheckedMachine
+		m.Metadata.Name, m.Metadata.UID = name, uid
+		if healthCheck != "" {
+			m.Status.Conditions = []capiCondition{{Type: "HealthCheckSucceeded", Status: healthCheck}}
+		}
+		return m
+	}
+	before := map[string]string{"cp-abc": "uid-1"}
+
+	tests := []struct {
+		name     string
+		machines []healthCheckedMachine
+		want     MachineHealthOutcome
+	}{
+		{"healthy", []healthCheckedMachine{machine("cp-abc", "uid-1", "True")}, MachineHealthPending},
+		{"unhealthy", []healthCheckedMachine{machine("cp-abc", "uid-1", "False")}, MachineHealthUnhealthy},
+		{"replaced", []healthCheckedMachine{machine("cp-def", "uid-2", "")}, MachineHealthRemediated},
+		{"recreated with the same name", []healthCheckedMachine{machine("cp-abc", "uid-3", "")}, MachineHealthRemediated},
+		{"deleted", nil, MachineHealthRemediated},
+	}
+	for _, test := range tests {
+		if got := machineHealthOutcome(test.machines, before); got != test.want {
+			t.Errorf("%s: expected %q, got %q", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "strings"
+
+// InvalidClusterRequest is a cluster create request that is invalid because of a single field.
+type InvalidClusterRequest struct {
+	Description string
+	// Field is the field of the request the rejection message has to name.
+	Field       string
+	ClusterName string
+	NodeGUID    string
+}
+
+// Body returns the cluster create body of the request for templateName.
+func (r InvalidClusterRequest) Body(templateName string) ([]byte, error) {
+	return RenderMultiNodeClusterConfig(r.ClusterName, AllRoleNodes(r.NodeGUID), templateName, ClusterConfigOptions{})
+}
+
+// InvalidClusterNameRequests returns requests whose cluster name is not a DNS-1123 subdomain, which the
+// name of the CAPI Cluster has to be. Their node GUIDs are valid and unknown.
+func InvalidClusterNameRequests() []InvalidClusterRequest {
+	names := []struct{ description, name string }{
+		{"uppercase letters", "Name-Validation"},
+		{"a name one over the length limit", LimitString(MaxClusterNameLength + 1)},
+		{"a space", "name validation"},
+		{"an underscore", "name_validation"},
+		{"a leading hyphen", "-name-validation"},
+		{"a trailing dot", "name-validation."},
+		{"a slash", "name/validation"},
+		{"a non-ASCII letter", "näme-validation"},
+	}
+	requests := make([]InvalidClusterRequest, 0, len(names))
+	for _, n := range names {
+		requests = append(requests, InvalidClusterRequest{
+			Description: "cluster name with " + n.description,
+			Field:       "name",
+			ClusterName: n.name,
+			NodeGUID:    NewProjectID(),
+		})
+	}
+	return requests
+}
+
+// MalformedNodeGUIDRequests returns requests whose node GUID is not a UUID. Their cluster names are
+// valid and distinct.
+func MalformedNodeGUIDRequests() []InvalidClusterRequest {
+	guids := []struct{ description, guid string }{
+		{"an empty node GUID", ""},
+		{"a node GUID that is not a UUID", "not-a-guid"},
+		{"a node GUID one character short", DefaultNodeGUID[:len(DefaultNodeGUID)-1]},
+		{"a node GUID one character long", DefaultNodeGUID + "0"},
+		{"a node GUID with a non-hex digit", "g" + DefaultNodeGUID[1:]},
+		{"a node GUID without hyphens", strings.ReplaceAll(DefaultNodeGUID, "-", "")},
+		{"a node GUID padded with spaces", " " + DefaultNodeGUID + " "},
+		{"a node GUID with a path", "../" + DefaultNodeGUID},
+	}
+	requests := make([]InvalidClusterRequest, 0, len(guids))
+	for i, g := range guids {
+		requests = append(requests, InvalidClusterRequest{
+			Description: "cluster with " + g.description,
+			Field:       "nodes",
+			ClusterName: "guid-validation-" + string(rune('a'+i)),
+			NodeGUID:    g.guid,
+		})
+	}
+	return requests
+}
+
+// NamesField reports whether a rejection message points at the field, e.g. `Error at "/nodes/0/id"` or
+// "invalid cluster name".
+func NamesField(message, field string) bool {
+	return strings.Contains(strings.ToLower(message), strings.ToLower(field))
+}
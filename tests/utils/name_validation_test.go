@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+var (
+	dns1123Subdomain = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+	uuidPattern      = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+func TestInvalidClusterNameRequests(t *testing.T) {
+	for _, request := range InvalidClusterNameRequests() {
+		if dns1123Subdomain.MatchString(request.ClusterName) && len(request.ClusterName) <= MaxClusterNameLength {
+			t.Errorf("%s: Expected an invalid name, got %q", request.Description, request.ClusterName)
+		}
+		if !uuidPattern.MatchString(request.NodeGUID) {
+			t.Errorf("%s: Expected a valid node GUID, got %q", request.Description, request.NodeGUID)
+		}
+	}
+}
+
+func TestMalformedNodeGUIDRequests(t *testing.T) {
+	names := map[string]bool{}
+	for _, request := range MalformedNodeGUIDRequests() {
+		if uuidPattern.MatchString(request.NodeGUID) {
+			t.Errorf("%s: Expected a malformed node GUID, got %q", request.Description, request.NodeGUID)
+		}
+		if !dns1123Subdomain.MatchString(request.ClusterName) || names[request.ClusterName] {
+			t.Errorf("%s: Expected a valid and distinct name, got %q", request.Description, request.ClusterName)
+		}
+		names[request.ClusterName] = true
+	}
+}
+
+func TestInvalidClusterRequestBody(t *testing.T) {
+	request := InvalidClusterRequest{ClusterName: "Name-Validation", NodeGUID: "not-a-guid"}
+	data, err := request.Body(K3sTemplateName)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var body struct {
+		Name     string `json:"name"`
+		Template string `json:"template"`
+		Nodes    []struct {
+			ID   string `json:"id"`
+			Role string `json:"role"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Expected a JSON body, got %v", err)
+	}
+	if body.Name != "Name-Validation" || body.Template != K3sTemplateName || len(body.Nodes) != 1 ||
+		body.Nodes[0].ID != "not-a-guid" || body.Nodes[0].Role != "all" {
+		t.Errorf("Expected the request fields in the body, got %s", data)
+	}
+}
+
+func TestNamesField(t *testing.T) {
+	message := `request body has an error: doesn't match schema #/components/schemas/ClusterSpec: Error at "/nodes/0/id"`
+	if !NamesField(message, "nodes") {
+		t.Errorf("Expected %q to name the nodes", message)
+	}
+	if NamesField("only single node clusters are supported", "name") {
+		t.Error("Expected a message without the field not to name it")
+	}
+}
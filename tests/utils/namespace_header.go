@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// ActiveProjectIDHeader is the header cluster-manager and the infra API read the project of a request from.
+	ActiveProjectIDHeader = "Activeprojectid"
+	// XNamespaceHeader is the namespace header of other orchestrator APIs, which cluster-manager may ignore.
+	XNamespaceHeader = "X-Namespace"
+
+	// NamespaceHeadersEnvVar is the comma-separated headers the project of every request is sent in.
+	NamespaceHeadersEnvVar = "NAMESPACE_HEADERS"
+	// NamespaceHeaderOverridesEnvVar overrides the headers of the endpoints under a path, as
+	// "/v2/templates=X-Namespace;/v2/clusters=Activeprojectid,X-Namespace".
+	NamespaceHeaderOverridesEnvVar = "NAMESPACE_HEADER_OVERRIDES"
+)
+
+var (
+	namespaceHeaderStrategy     NamespaceHeaderStrategy
+	namespaceHeaderStrategyOnce sync.Once
+)
+
+// NamespaceHeaderStrategy decides which headers carry the project of the requests to the orchestrator APIs.
+type NamespaceHeaderStrategy struct {
+	Headers []string
+	// Overrides are the headers of the endpoints whose path starts with the key; the longest key wins.
+	Overrides map[string][]string
+}
+
+// DefaultNamespaceHeaderStrategy sends the project in Activeprojectid to every endpoint.
+func DefaultNamespaceHeaderStrategy() NamespaceHeaderStrategy {
+	return NamespaceHeaderStrategy{Headers: []string{ActiveProjectIDHeader}}
+}
+
+// HeadersFor returns the headers of the endpoint at path.
+func (s NamespaceHeaderStrategy) HeadersFor(path string) []string {
+	headers, longest := s.Headers, -1
+	for prefix, override := range s.Overrides {
+		if strings.HasPrefix(path, prefix) && len(prefix) > longest {
+			headers, longest = override, len(prefix)
+		}
+	}
+	return headers
+}
+
+// Apply sets the project of req in the headers of its endpoint.
+func (s NamespaceHeaderStrategy) Apply(req *http.Request, namespace string) {
+	for _, header := range s.HeadersFor(req.URL.Path) {
+		req.Header.Set(header, namespace)
+	}
+}
+
+func (s NamespaceHeaderStrategy) String() string {
+	description := strings.Join(s.Headers, ",")
+	prefixes := make([]string, 0, len(s.Overrides))
+	for prefix := range s.Overrides {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		description += fmt.Sprintf("; %s=%s", prefix, strings.Join(s.Overrides[prefix], ","))
+	}
+	return description
+}
+
+// ParseNamespaceHeaderStrategy returns the strategy of the NAMESPACE_HEADERS and NAMESPACE_HEADER_OVERRIDES
+// values, with Activeprojectid when headers is empty.
+func ParseNamespaceHeaderStrategy(headers, overrides string) (NamespaceHeaderStrategy, error) {
+	strategy := DefaultNamespaceHeaderStrategy()
+	if list := splitList(headers); len(list) > 0 {
+		strategy.Headers = list
+	}
+	for _, entry := range strings.Split(overrides, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		prefix, list, ok := strings.Cut(entry, "=")
+		prefix = strings.TrimSpace(prefix)
+		if !ok || !strings.HasPrefix(prefix, "/") || len(splitList(list)) == 0 {
+			return NamespaceHeaderStrategy{}, fmt.Errorf("invalid namespace header override %q, expected /path=Header[,Header]", entry)
+		}
+		if strategy.Overrides == nil {
+			strategy.Overrides = map[string][]string{}
+		}
+		strategy.Overrides[prefix] = splitList(list)
+	}
+	return strategy, nil
+}
+
+// CurrentNamespaceHeaderStrategy returns the strategy of NAMESPACE_HEADERS and NAMESPACE_HEADER_OVERRIDES,
+// or the default one when they are not valid. It is read once per suite.
+func CurrentNamespaceHeaderStrategy() NamespaceHeaderStrategy {
+	namespaceHeaderStrategyOnce.Do(func() {
+		strategy, err := ParseNamespaceHeaderStrategy(os.Getenv(NamespaceHeadersEnvVar), os.Getenv(NamespaceHeaderOverridesEnvVar))
+		if err != nil {
+			fmt.Printf("Ignoring %s: %v\n", NamespaceHeaderOverridesEnvVar, err)
+			strategy = DefaultNamespaceHeaderStrategy()
+		}
+		namespaceHeaderStrategy = strategy
+	})
+	return namespaceHeaderStrategy
+}
+
+// SetNamespaceHeader sets the project of a request to the orchestrator APIs in the headers of the current
+// strategy.
+func SetNamespaceHeader(req *http.Request, namespace string) {
+	CurrentNamespaceHeaderStrategy().Apply(req, namespace)
+}
+
+// NamespaceHeaderCandidates are the headers the contract spec sends the project in, one at a time. The
+// empty candidate sends no project at all.
+var NamespaceHeaderCandidates = []string{"", ActiveProjectIDHeader, XNamespaceHeader}
+
+// NamespaceHeaderProbe is the status an endpoint answered with when the project was only sent in Header.
+type NamespaceHeaderProbe struct {
+	URL    string
+	Header string
+	Status int
+}
+
+// Honored reports whether the endpoint served the request.
+func (p NamespaceHeaderProbe) Honored() bool {
+	return p.Status >= 200 && p.Status < 300
+}
+
+func (p NamespaceHeaderProbe) String() string {
+	header := p.Header
+	if header == "" {
+		header = "no header"
+	}
+	return fmt.Sprintf("GET %s with %s: %d", p.URL, header, p.Status)
+}
+
+// ProbeNamespaceHeaders lists url once per candidate of NamespaceHeaderCandidates with client, sending the
+// project only in that header.
+func ProbeNamespaceHeaders(client *http.Client, url, namespace string) ([]NamespaceHeaderProbe, error) {
+	probes := make([]NamespaceHeaderProbe, 0, len(NamespaceHeaderCandidates))
+	for _, header := range NamespaceHeaderCandidates {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if header != "" {
+			req.Header.Set(header, namespace)
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe %s with %q: %w", url, header, err)
+		}
+		resp.Body.Close()
+		probes = append(probes, NamespaceHeaderProbe{URL: url, Header: header, Status: resp.StatusCode})
+	}
+	return probes, nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestParseNamespaceHeaderStrategy(t *testing.T) {
+	strategy, err := ParseNamespaceHeaderStrategy("", "")
+	if err != nil || !slices.Equal(strategy.HeadersFor("/v2/clusters"), []string{ActiveProjectIDHeader}) {
+		t.Fatalf("Expected Activeprojectid by default, got %v, %v", strategy, err)
+	}
+
+	strategy, err = ParseNamespaceHeaderStrategy("X-Namespace", "/v2/templates=Activeprojectid, X-Namespace; /v2/templates/default=X-Namespace")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for path, want := range map[string][]string{
+		"/v2/clusters":               {XNamespaceHeader},
+		"/v2/templates":              {ActiveProjectIDHeader, XNamespaceHeader},
+		"/v2/templates/default/v1.0": {XNamespaceHeader},
+		"/v2/templates/k3s/v1.0":     {ActiveProjectIDHeader, XNamespaceHeader},
+	} {
+		if headers := strategy.HeadersFor(path); !slices.Equal(headers, want) {
+			t.Errorf("Expected %v for %s, got %v", want, path, headers)
+		}
+	}
+
+	for _, overrides := range []string{"v2/clusters=X-Namespace", "/v2/clusters", "/v2/clusters= , "} {
+		if _, err := ParseNamespaceHeaderStrategy("", overrides); err == nil {
+			t.Errorf("Expected an error for %q", overrides)
+		}
+	}
+}
+
+func TestNamespaceHeaderStrategyApply(t *testing.T) {
+	strategy := NamespaceHeaderStrategy{
+		Headers:   []string{ActiveProjectIDHeader},
+		Overrides: map[string][]string{"/v2/templates": {ActiveProjectIDHeader, XNamespaceHeader}},
+	}
+	req := httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/v2/templates?default=true", nil)
+	strategy.Apply(req, "project")
+	if req.Header.Get(ActiveProjectIDHeader) != "project" || req.Header.Get(XNamespaceHeader) != "project" {
+		t.Errorf("Expected both headers, got %v", req.Header)
+	}
+	req = httptest.NewRequest(http.MethodGet, "http://127.0.0.1:8080/v2/clusters", nil)
+	strategy.Apply(req, "project")
+	if req.Header.Get(ActiveProjectIDHeader) != "project" || req.Header.Get(XNamespaceHeader) != "" {
+		t.Errorf("Expected only Activeprojectid, got %v", req.Header)
+	}
+}
+
+func TestProbeNamespaceHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(ActiveProjectIDHeader) != "project" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	probes, err := ProbeNamespaceHeaders(server.Client(), server.URL+"/v2/clusters", "project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	honored := map[string]bool{}
+	for _, probe := range probes {
+		honored[probe.Header] = probe.Honored()
+	}
+	if len(probes) != 3 || honored[""] || !honored[ActiveProjectIDHeader] || honored[XNamespaceHeader] {
+		t.Errorf("Expected only Activeprojectid honored, got %v", probes)
+	}
+}
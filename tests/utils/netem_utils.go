@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NetworkDegradationTargetEnvVar restricts the injected degradation to traffic towards this CIDR
+	// (e.g. the address the agent uses to reach the gateway). By default the whole uplink is degraded.
+	NetworkDegradationTargetEnvVar = "NETWORK_DEGRADATION_TARGET"
+)
+
+// NetworkDegradation describes the latency, jitter and packet loss injected on the agent↔gateway path.
+type NetworkDegradation struct {
+	Name   string
+	Delay  time.Duration
+	Jitter time.Duration
+	// LossPercent is the share of dropped packets, 0-100.
+	LossPercent float64
+}
+
+// DefaultNetworkDegradationLevels are the levels the connection to the gateway must survive.
+var DefaultNetworkDegradationLevels = []NetworkDegradation{
+	{Name: "mild", Delay: 100 * time.Millisecond, Jitter: 20 * time.Millisecond, LossPercent: 1},
+	{Name: "moderate", Delay: 300 * time.Millisecond, Jitter: 100 * time.Millisecond, LossPercent: 5},
+}
+
+func (d NetworkDegradation) String() string {
+	return fmt.Sprintf("%s (delay %v, jitter %v, loss %g%%)", d.Name, d.Delay, d.Jitter, d.LossPercent)
+}
+
+// netemArgs renders the netem parameters of the degradation.
+func (d NetworkDegradation) netemArgs() string {
+	args := []string{"netem"}
+	if d.Delay > 0 {
+		args = append(args, "delay", fmt.Sprintf("%dms", d.Delay.Milliseconds()))
+		if d.Jitter > 0 {
+			args = append(args, fmt.Sprintf("%dms", d.Jitter.Milliseconds()), "distribution", "normal")
+		}
+	}
+	if d.LossPercent > 0 {
+		args = append(args, "loss", strconv.FormatFloat(d.LossPercent, 'f', -1, 64)+"%")
+	}
+	return strings.Join(args, " ")
+}
+
+// EdgeNodeSupportsNetem reports whether tc and the netem qdisc are usable on the edge node.
+func EdgeNodeSupportsNetem() (bool, string) {
+	out, err := ExecOnEdgeNode(netemPreamble + `command -v tc >/dev/null || { echo "tc not installed"; exit 0; }
+$SUDO modprobe sch_netem 2>/dev/null || true
+if $SUDO tc qdisc add dev lo root netem delay 0ms 2>&1; then $SUDO tc qdisc del dev lo root; echo ok; fi`)
+	if err != nil {
+		return false, err.Error()
+	}
+	reason := strings.TrimSpace(string(out))
+	if reason == "ok" {
+		return true, ""
+	}
+	return false, reason
+}
+
+// ApplyNetworkDegradation degrades the uplink of the edge node, which carries the agent↔gateway
+// connection. The degradation is removed by ClearNetworkDegradation, or by the edge node itself once
+// maxDuration (plus a safety margin) has elapsed.
+func ApplyNetworkDegradation(d NetworkDegradation, maxDuration time.Duration) error {
+	target := GetEnv(NetworkDegradationTargetEnvVar, "")
+	if _, err := ExecOnEdgeNode(netemApplyScript(d, target, maxDuration+faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to apply %s network degradation: %w", d.Name, err)
+	}
+	return nil
+}
+
+// ClearNetworkDegradation removes any degradation installed by ApplyNetworkDegradation.
+func ClearNetworkDegradation() error {
+	if _, err := ExecOnEdgeNode(netemClearScript()); err != nil {
+		return fmt.Errorf("failed to clear network degradation: %w", err)
+	}
+	return nil
+}
+
+const (
+	netemPreamble = edgeNodeSudoPreamble + `DEV=$(ip route show default | awk '{for (i = 1; i < NF; i++) if ($i == "dev") { print $(i+1); exit }}')
+`
+	netemRevertPIDFile = "/tmp/cluster-tests-netem-revert.pid"
+)
+
+func netemApplyScript(d NetworkDegradation, target string, revertAfter time.Duration) string {
+	lines := []string{
+		"set -e",
+		netemPreamble + `[ -n "$DEV" ] || { echo "no default route"; exit 1; }`,
+		edgeNodeCancelRevertScript(netemRevertPIDFile),
+		"$SUDO tc qdisc del dev $DEV root 2>/dev/null || true",
+	}
+	if target == "" {
+		lines = append(lines, "$SUDO tc qdisc add dev $DEV root "+d.netemArgs())
+	} else {
+		// The default priomap never selects the fourth band, so only traffic to the target is degraded.
+		lines = append(lines,
+			"$SUDO tc qdisc add dev $DEV root handle 1: prio bands 4",
+			"$SUDO tc qdisc add dev $DEV parent 1:4 handle 40: "+d.netemArgs(),
+			fmt.Sprintf("$SUDO tc filter add dev $DEV parent 1:0 protocol ip prio 1 u32 match ip dst %s flowid 1:4", target))
+	}
+	lines = append(lines, edgeNodeScheduleRevertScript(netemRevertPIDFile, revertAfter, "$SUDO tc qdisc del dev $DEV root"))
+	return strings.Join(lines, "\n")
+}
+
+func netemClearScript() string {
+	return netemPreamble + edgeNodeCancelRevertScript(netemRevertPIDFile) + "\n$SUDO tc qdisc del dev $DEV root 2>/dev/null || true"
+}
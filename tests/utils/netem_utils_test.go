@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetemArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		degradation NetworkDegradation
+		expected    string
+	}{
+		{
+			name:        "delay with jitter and loss",
+			degradation: NetworkDegradation{Delay: 100 * time.Millisecond, Jitter: 20 * time.Millisecond, LossPercent: 1.5},
+			expected:    "netem delay 100ms 20ms distribution normal loss 1.5%",
+		},
+		{
+			name:        "jitter without delay is ignored",
+			degradation: NetworkDegradation{Jitter: 20 * time.Millisecond, LossPercent: 5},
+			expected:    "netem loss 5%",
+		},
+		{
+			name:        "delay only",
+			degradation: NetworkDegradation{Delay: time.Second},
+			expected:    "netem delay 1000ms",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.degradation.netemArgs(); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestNetemApplyScript(t *testing.T) {
+	d := NetworkDegradation{Delay: 100 * time.Millisecond}
+
+	script := netemApplyScript(d, "", 5*time.Minute)
+	if !strings.Contains(script, "tc qdisc add dev $DEV root netem delay 100ms") {
+		t.Errorf("Expected root netem qdisc, script:\n%s", script)
+	}
+	if !strings.Contains(script, "sleep 300;") {
+		t.Errorf("Expected revert after 300s, script:\n%s", script)
+	}
+
+	script = netemApplyScript(d, "10.1.2.3/32", time.Minute)
+	for _, want := range []string{"root handle 1: prio bands 4", "parent 1:4 handle 40: netem", "match ip dst 10.1.2.3/32 flowid 1:4"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in targeted script:\n%s", want, script)
+		}
+	}
+	if strings.Contains(script, "root netem") {
+		t.Errorf("Expected targeted script not to degrade the whole uplink:\n%s", script)
+	}
+}
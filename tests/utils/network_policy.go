@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// NetworkPolicyNamespace is the downstream namespace of the NetworkPolicy enforcement spec.
+	NetworkPolicyNamespace = "cluster-tests-netpol"
+	// NetworkPolicyServer serves HTTP on StreamPodHTTPPort behind a service of the same name.
+	NetworkPolicyServer = "netpol-server"
+	// NetworkPolicyAllowedClient is the only client the allow policy lets reach the server.
+	NetworkPolicyAllowedClient = "netpol-allowed"
+	// NetworkPolicyDeniedClient is not selected by the allow policy.
+	NetworkPolicyDeniedClient = "netpol-denied"
+
+	// NetworkPolicyDenyAll denies all ingress to the pods of NetworkPolicyNamespace.
+	NetworkPolicyDenyAll = "deny-all"
+	// NetworkPolicyAllowClient allows NetworkPolicyAllowedClient to reach NetworkPolicyServer.
+	NetworkPolicyAllowClient = "allow-netpol-allowed"
+
+	networkPolicyProbeTimeoutSeconds = 3
+	networkPolicyPodsReadyTimeout    = 3 * time.Minute
+
+	reachableMarker   = "REACHABLE"
+	unreachableMarker = "UNREACHABLE"
+)
+
+// NetworkPolicyExpectation is whether a client pod should reach the server.
+type NetworkPolicyExpectation struct {
+	Client    string
+	Reachable bool
+}
+
+// renderNetworkPolicyWorkloads returns the namespace, the server pod and service, and the client pods of
+// the NetworkPolicy spec.
+func renderNetworkPolicyWorkloads(image string) string {
+	client := func(name string) string {
+		return fmt.Sprintf(`---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+  labels:
+    app: %[1]s
+spec:
+  containers:
+    - name: client
+      image: %[3]s
+      command: ["sh", "-c", "sleep 86400"]
+`, name, NetworkPolicyNamespace, image)
+	}
+
+	return fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: %[1]s
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %[2]s
+  namespace: %[1]s
+  labels:
+    app: %[2]s
+spec:
+  containers:
+    - name: server
+      image: %[3]s
+      command: ["sh", "-c", "mkdir -p /www && echo %[2]s > /www/index.html && httpd -f -p %[4]s -h /www"]
+      ports:
+        - containerPort: %[4]s
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: %[2]s
+  namespace: %[1]s
+spec:
+  selector:
+    app: %[2]s
+  ports:
+    - port: %[4]s
+      targetPort: %[4]s
+`, NetworkPolicyNamespace, NetworkPolicyServer, image, StreamPodHTTPPort) +
+		client(NetworkPolicyAllowedClient) + client(NetworkPolicyDeniedClient)
+}
+
+// renderNetworkPolicy returns the manifest of NetworkPolicyDenyAll or NetworkPolicyAllowClient.
+func renderNetworkPolicy(name string) (string, error) {
+	switch name {
+	case NetworkPolicyDenyAll:
+		return fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  podSelector: {}
+  policyTypes:
+    - Ingress
+`, NetworkPolicyDenyAll, NetworkPolicyNamespace), nil
+	case NetworkPolicyAllowClient:
+		return fmt.Sprintf(`apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: %s
+  namespace: %s
+spec:
+  podSelector:
+    matchLabels:
+      app: %s
+  policyTypes:
+    - Ingress
+  ingress:
+    - from:
+        - podSelector:
+            matchLabels:
+              app: %s
+      ports:
+        - protocol: TCP
+          port: %s
+`, NetworkPolicyAllowClient, NetworkPolicyNamespace, NetworkPolicyServer, NetworkPolicyAllowedClient, StreamPodHTTPPort), nil
+	}
+	return "", fmt.Errorf("unknown network policy %q", name)
+}
+
+// CreateNetworkPolicyWorkloads creates the server and the client pods of the NetworkPolicy spec in a
+// downstream cluster and waits until they are ready. The image is the one of the streaming pod.
+func CreateNetworkPolicyWorkloads(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	if err := client.ApplyManifest(renderNetworkPolicyWorkloads(GetEnv(StreamPodImageEnvVar, DefaultStreamPodImage))); err != nil {
+		return fmt.Errorf("failed to create the network policy workloads: %w", err)
+	}
+	// The pods come up side by side, so they share the timeout.
+	deadline := time.Now().Add(networkPolicyPodsReadyTimeout)
+	for _, pod := range []string{NetworkPolicyServer, NetworkPolicyAllowedClient, NetworkPolicyDeniedClient} {
+		if err := client.WaitForPodReady(NetworkPolicyNamespace, pod, time.Until(deadline)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyNetworkPolicy applies NetworkPolicyDenyAll or NetworkPolicyAllowClient to a downstream cluster.
+func ApplyNetworkPolicy(kubeconfigPath, name string) error {
+	manifest, err := renderNetworkPolicy(name)
+	if err != nil {
+		return err
+	}
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	if err := client.ApplyManifest(manifest); err != nil {
+		return fmt.Errorf("failed to apply network policy %s: %w", name, err)
+	}
+	return nil
+}
+
+// DeleteNetworkPolicyWorkloads deletes the namespace of the NetworkPolicy spec from a downstream cluster.
+func DeleteNetworkPolicyWorkloads(kubeconfigPath string) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	return client.DeleteNamespace(NetworkPolicyNamespace)
+}
+
+// NetworkPolicyServerReachable reports whether a client pod reaches the server through its service. A
+// failure to run the probe is an error, not an unreachable server.
+func NetworkPolicyServerReachable(kubeconfigPath, client string) (bool, error) {
+	kubeClient, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return false, err
+	}
+	url := fmt.Sprintf("http://%s:%s/", NetworkPolicyServer, StreamPodHTTPPort)
+	out, err := kubeClient.Exec(NetworkPolicyNamespace, client, "sh", "-c",
+		fmt.Sprintf("wget -q -T %d -O - %s >/dev/null 2>&1 && echo %s || echo %s",
+			networkPolicyProbeTimeoutSeconds, url, reachableMarker, unreachableMarker))
+	if err != nil {
+		return false, err
+	}
+	return parseReachability(out)
+}
+
+// parseReachability reads the marker printed by the probe of NetworkPolicyServerReachable.
+func parseReachability(out string) (bool, error) {
+	switch strings.TrimSpace(out) {
+	case reachableMarker:
+		return true, nil
+	case unreachableMarker:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected probe output %q", strings.TrimSpace(out))
+}
+
+// NetworkPolicyViolations probes the server from every client of expectations and returns the clients
+// whose reachability differs from the expected one.
+func NetworkPolicyViolations(kubeconfigPath string, expectations []NetworkPolicyExpectation) ([]string, error) {
+	var violations []string
+	for _, expectation := range expectations {
+		reachable, err := NetworkPolicyServerReachable(kubeconfigPath, expectation.Client)
+		if err != nil {
+			return nil, err
+		}
+		if violation := networkPolicyViolation(expectation, reachable); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+// networkPolicyViolation describes a reachability that differs from the expectation, or returns "".
+func networkPolicyViolation(expectation NetworkPolicyExpectation, reachable bool) string {
+	switch {
+	case expectation.Reachable && !reachable:
+		return fmt.Sprintf("%s cannot reach %s", expectation.Client, NetworkPolicyServer)
+	case !expectation.Reachable && reachable:
+		return fmt.Sprintf("%s reaches %s despite the network policies", expectation.Client, NetworkPolicyServer)
+	}
+	return ""
+}
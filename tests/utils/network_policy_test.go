@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// manifestKinds returns the kind/name of every document of a manifest whose objects are in namespace.
+func manifestKinds(t *testing.T, manifest, namespace string) []string {
+	t.Helper()
+	var kinds []string
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		var object struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := decoder.Decode(&object); errors.Is(err, io.EOF) {
+			return kinds
+		} else if err != nil {
+			t.Fatalf("Failed to parse the manifest: %v", err)
+		}
+		if object.Kind != "Namespace" && object.Metadata.Namespace != namespace {
+			t.Errorf("Expected %s/%s in %s, got %q", object.Kind, object.Metadata.Name, namespace, object.Metadata.Namespace)
+		}
+		kinds = append(kinds, object.Kind+"/"+object.Metadata.Name)
+	}
+}
+
+func TestRenderNetworkPolicyWorkloads(t *testing.T) {
+	kinds := manifestKinds(t, renderNetworkPolicyWorkloads("busybox:1.36"), NetworkPolicyNamespace)
+	expected := []string{
+		"Namespace/" + NetworkPolicyNamespace,
+		"Pod/" + NetworkPolicyServer,
+		"Service/" + NetworkPolicyServer,
+		"Pod/" + NetworkPolicyAllowedClient,
+		"Pod/" + NetworkPolicyDeniedClient,
+	}
+	if strings.Join(kinds, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, kinds)
+	}
+}
+
+func TestRenderNetworkPolicy(t *testing.T) {
+	for _, name := range []string{NetworkPolicyDenyAll, NetworkPolicyAllowClient} {
+		manifest, err := renderNetworkPolicy(name)
+		if err != nil {
+			t.Fatalf("Expected no error for %s, got %v", name, err)
+		}
+		if kinds := manifestKinds(t, manifest, NetworkPolicyNamespace); len(kinds) != 1 || kinds[0] != "NetworkPolicy/"+name {
+			t.Errorf("Expected the NetworkPolicy %s, got %v", name, kinds)
+		}
+	}
+
+	allow, _ := renderNetworkPolicy(NetworkPolicyAllowClient)
+	if !strings.Contains(allow, "app: "+NetworkPolicyAllowedClient) || strings.Contains(allow, NetworkPolicyDeniedClient) {
+		t.Errorf("Expected the allow policy to select only %s, got:\n%s", NetworkPolicyAllowedClient, allow)
+	}
+
+	if _, err := renderNetworkPolicy("allow-all"); err == nil {
+		t.Error("Expected an error for an unknown policy")
+	}
+}
+
+func TestParseReachability(t *testing.T) {
+	if reachable, err := parseReachability("REACHABLE\n"); err != nil || !reachable {
+		t.Errorf("Expected reachable, got %t, %v", reachable, err)
+	}
+	if reachable, err := parseReachability("UNREACHABLE\n"); err != nil || reachable {
+		t.Errorf("Expected unreachable, got %t, %v", reachable, err)
+	}
+	if _, err := parseReachability("sh: wget: not found"); err == nil {
+		t.Error("Expected an error for an unexpected output")
+	}
+}
+
+func TestNetworkPolicyViolation(t *testing.T) {
+	allowed := NetworkPolicyExpectation{Client: NetworkPolicyAllowedClient, Reachable: true}
+	denied := NetworkPolicyExpectation{Client: NetworkPolicyDeniedClient, Reachable: false}
+
+	if violation := networkPolicyViolation(allowed, true); violation != "" {
+		t.Errorf("Expected no violation, got %q", violation)
+	}
+	if violation := networkPolicyViolation(denied, false); violation != "" {
+		t.Errorf("Expected no violation, got %q", violation)
+	}
+	if violation := networkPolicyViolation(allowed, false); !strings.Contains(violation, "cannot reach") {
+		t.Errorf("Expected the allowed client to be blocked, got %q", violation)
+	}
+	if violation := networkPolicyViolation(denied, true); !strings.Contains(violation, "despite the network policies") {
+		t.Errorf("Expected the denied client to get through, got %q", violation)
+	}
+}
@@ -3,7 +3,307 @@
 
 package utils
 
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // Constants for OIDC configuration
 const (
 	DefaultOIDCConfigFile = "oidc-mock-config-dynamic.yaml"
+	// OIDCMockImageEnvVar is the image DeployOIDCMock builds from cmd/oidc-mock and loads into kind.
+	OIDCMockImageEnvVar  = "OIDC_MOCK_IMAGE"
+	DefaultOIDCMockImage = "cluster-tests-oidc-mock:latest"
+
+	oidcMockNamespace         = "default"
+	oidcMockKeycloakNamespace = "orch-platform"
+	oidcMockDeployment        = "oidc-mock"
+	oidcMockContent           = "oidc-mock-content"
+	oidcMockNginxConfig       = "oidc-mock-nginx-config"
+	oidcMockKeySecret         = "oidc-mock-key"
+	oidcMockKeyFile           = "key.pem"
+	oidcMockDockerfile        = "cmd/oidc-mock/Dockerfile"
+	oidcMockPort              = 8080
+	oidcMockRolloutTimeout    = 2 * time.Minute
+)
+
+var (
+	oidcMockSyncOnce sync.Once
+	oidcMockSyncErr  error
 )
+
+// ServedJWKS returns the JWKS the deployed OIDC mock serves, empty when no mock is deployed.
+func ServedJWKS() (string, error) {
+	jwks, _, err := servedJWKS()
+	return jwks, err
+}
+
+// servedJWKS returns the JWKS of the nginx mock from its ConfigMap, or the one the Go mock serves, and whether
+// it is the Go mock, whose key is in the oidc-mock-key Secret.
+func servedJWKS() (string, bool, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", false, err
+	}
+	content, err := client.GetConfigMap(oidcMockNamespace, oidcMockContent)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", false, fmt.Errorf("failed to get the JWKS of the OIDC mock: %w", err)
+	}
+	if content != nil {
+		if jwks := strings.TrimSpace(content.Data["jwks.json"]); jwks != "" {
+			return jwks, false, nil
+		}
+	}
+
+	if _, err := client.GetSecret(oidcMockNamespace, oidcMockKeySecret); apierrors.IsNotFound(err) {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	body, err := client.Clientset.CoreV1().Services(oidcMockNamespace).
+		ProxyGet("http", oidcMockDeployment, "http", oidcMockIssuerPath()+"/keys", nil).DoRaw(ctx)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to get the JWKS of the OIDC mock: %w", err)
+	}
+	return strings.TrimSpace(string(body)), true, nil
+}
+
+// SyncOIDCMock makes the deployed OIDC mock serve the key of the auth state: when its JWKS holds another key,
+// the key Secret of the Go mock, or the whole nginx mock, is applied again and the mock restarted. It reports
+// whether the mock was updated; without a deployed mock there is nothing to synchronize.
+func SyncOIDCMock() (bool, error) {
+	served, keySecret, err := servedJWKS()
+	if err != nil || served == "" {
+		return false, err
+	}
+	matches, err := auth.JWKSMatches(served)
+	if err != nil || matches {
+		return false, err
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return false, err
+	}
+	if keySecret {
+		if err := applyOIDCMockKey(client); err != nil {
+			return false, err
+		}
+	} else {
+		manifest, err := auth.GenerateOIDCMockConfig()
+		if err != nil {
+			return false, err
+		}
+		if err := client.ApplyManifest(manifest); err != nil {
+			return false, fmt.Errorf("failed to apply the OIDC mock: %w", err)
+		}
+	}
+	// Secret and ConfigMap volumes are refreshed lazily; a restart serves the new key right away.
+	if err := restartOIDCMock(client); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// syncOIDCMockOnce runs SyncOIDCMock once per process, before the first token is used.
+func syncOIDCMockOnce() error {
+	oidcMockSyncOnce.Do(func() {
+		updated, err := SyncOIDCMock()
+		if err != nil {
+			oidcMockSyncErr = fmt.Errorf("failed to synchronize the OIDC mock with %s: %w", auth.AuthStatePath(), err)
+			return
+		}
+		if updated {
+			fmt.Printf("The OIDC mock served another key, updated it to the key of %s\n", auth.AuthStatePath())
+		}
+	})
+	return oidcMockSyncErr
+}
+
+// renderOIDCMock returns the deployment and services of the Go OIDC mock of cmd/oidc-mock. The signing key is
+// mounted from the oidc-mock-key Secret, see applyOIDCMockKey, and platform-keycloak in orch-platform points
+// at the mock, so the default issuer of the charts resolves to it.
+func renderOIDCMock(image string) string {
+	return fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+  labels:
+    app: %[1]s
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: %[1]s
+  template:
+    metadata:
+      labels:
+        app: %[1]s
+    spec:
+      containers:
+        - name: oidc-mock
+          image: %[3]s
+          imagePullPolicy: IfNotPresent
+          args: ["-listen=:%[4]d", "-issuer=%[5]s", "-key-file=/etc/oidc-mock/key.pem"]
+          ports:
+            - containerPort: %[4]d
+          readinessProbe:
+            httpGet:
+              path: %[6]s/.well-known/openid-configuration
+              port: %[4]d
+          volumeMounts:
+            - name: key
+              mountPath: /etc/oidc-mock
+              readOnly: true
+      volumes:
+        - name: key
+          secret:
+            secretName: %[7]s
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+spec:
+  selector:
+    app: %[1]s
+  ports:
+    - name: http
+      port: 80
+      targetPort: %[4]d
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: platform-keycloak
+  namespace: %[8]s
+spec:
+  type: ExternalName
+  externalName: %[1]s.%[2]s.svc.cluster.local
+  ports:
+    - name: http
+      port: 80
+`, oidcMockDeployment, oidcMockNamespace, image, oidcMockPort, auth.IssuerURL, oidcMockIssuerPath(),
+		oidcMockKeySecret, oidcMockKeycloakNamespace)
+}
+
+// oidcMockIssuerPath is the path of auth.IssuerURL, under which the mock serves its endpoints.
+func oidcMockIssuerPath() string {
+	u, err := url.Parse(auth.IssuerURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(u.Path, "/")
+}
+
+// DeployOIDCMock builds the image of cmd/oidc-mock (OIDC_MOCK_IMAGE), loads it into the kind cluster and
+// deploys it with the key of the auth state, replacing the nginx mock of auth.GenerateOIDCMockConfig. It
+// returns once the mock serves. Unlike the nginx mock, a rotated key only needs SyncOIDCMock to update the
+// Secret.
+func DeployOIDCMock() error {
+	image := GetEnv(OIDCMockImageEnvVar, DefaultOIDCMockImage)
+	root, err := moduleRoot()
+	if err != nil {
+		return err
+	}
+	if out, err := CommandCombinedOutput(exec.Command("docker", "build", "-f", filepath.Join(root, oidcMockDockerfile),
+		"-t", image, root)); err != nil {
+		return fmt.Errorf("failed to build %s: %w: %s", image, err, strings.TrimSpace(string(out)))
+	}
+	if out, err := CommandCombinedOutput(exec.Command("kind", "load", "docker-image", image)); err != nil {
+		return fmt.Errorf("failed to load %s into kind: %w: %s", image, err, strings.TrimSpace(string(out)))
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	if err := client.EnsureNamespace(oidcMockKeycloakNamespace); err != nil {
+		return err
+	}
+	if err := applyOIDCMockKey(client); err != nil {
+		return err
+	}
+	if err := client.ApplyManifest(renderOIDCMock(image)); err != nil {
+		return fmt.Errorf("failed to apply the OIDC mock: %w", err)
+	}
+	// The ConfigMaps of the nginx mock would otherwise still be taken for the served JWKS.
+	if err := client.DeleteConfigMaps(oidcMockNamespace, oidcMockContent, oidcMockNginxConfig); err != nil {
+		return fmt.Errorf("failed to delete the nginx OIDC mock: %w", err)
+	}
+	// A rebuilt image keeps its tag, a restart runs it.
+	return restartOIDCMock(client)
+}
+
+// applyOIDCMockKey creates or updates the Secret the Go OIDC mock reads the key of the auth state from.
+func applyOIDCMockKey(client *KubeClient) error {
+	generator, err := auth.NewTestJWTGenerator()
+	if err != nil {
+		return err
+	}
+	key, err := generator.GetPrivateKeyPEM()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	secrets := client.Clientset.CoreV1().Secrets(oidcMockNamespace)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: oidcMockKeySecret, Namespace: oidcMockNamespace},
+		Data:       map[string][]byte{oidcMockKeyFile: []byte(key)},
+	}
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{}); apierrors.IsNotFound(err) {
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create the key of the OIDC mock: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to update the key of the OIDC mock: %w", err)
+	}
+	return nil
+}
+
+// restartOIDCMock restarts the OIDC mock and waits for the rollout.
+func restartOIDCMock(client *KubeClient) error {
+	if err := client.RestartWorkload(oidcMockNamespace, "Deployment", oidcMockDeployment); err != nil {
+		return fmt.Errorf("failed to restart the OIDC mock: %w", err)
+	}
+	if err := client.WaitForRollout(oidcMockNamespace, "Deployment", oidcMockDeployment, oidcMockRolloutTimeout); err != nil {
+		return fmt.Errorf("failed to wait for the OIDC mock rollout: %w", err)
+	}
+	return nil
+}
+
+// moduleRoot returns the root of the repository, the first directory up from the working directory, e.g. the
+// one of a suite, with a go.mod.
+func moduleRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no go.mod found above the working directory")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderOIDCMock(t *testing.T) {
+	manifest := renderOIDCMock("registry.local/oidc-mock:v1")
+	for _, want := range []string{
+		"image: registry.local/oidc-mock:v1",
+		"imagePullPolicy: IfNotPresent",
+		"-issuer=http://platform-keycloak.orch-platform.svc/realms/master",
+		"path: /realms/master/.well-known/openid-configuration",
+		"secretName: oidc-mock-key",
+		"externalName: oidc-mock.default.svc.cluster.local",
+		"targetPort: 8080",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("Expected %q in OIDC mock manifest:\n%s", want, manifest)
+		}
+	}
+	if strings.Contains(manifest, "\t") {
+		t.Error("Expected no tabs in the OIDC mock manifest")
+	}
+}
+
+func TestModuleRoot(t *testing.T) {
+	root, err := moduleRoot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, oidcMockDockerfile)); err != nil {
+		t.Errorf("Expected the OIDC mock Dockerfile under %s, got %v", root, err)
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// edgeNodeAgentService is the systemd unit the vEN bootstrap installs for cluster-agent.
+	edgeNodeAgentService       = "cluster-agent"
+	edgeNodeAgentRevertPIDFile = "/tmp/cluster-tests-cluster-agent-revert.pid"
+)
+
+// HoldEdgeNodeAgent stops cluster-agent on the edge node, so the node looks like a host that has not
+// been onboarded yet. The edge node starts the agent again by itself once maxDuration (plus a safety
+// margin) has elapsed, or earlier through ReleaseEdgeNodeAgent.
+func HoldEdgeNodeAgent(maxDuration time.Duration) error {
+	if _, err := ExecOnEdgeNode(agentHoldScript(maxDuration + faultSafetyMargin)); err != nil {
+		return fmt.Errorf("failed to stop cluster-agent on the edge node: %w", err)
+	}
+	return nil
+}
+
+// ReleaseEdgeNodeAgent starts cluster-agent again after HoldEdgeNodeAgent.
+func ReleaseEdgeNodeAgent() error {
+	if _, err := ExecOnEdgeNode(edgeNodeSudoPreamble + edgeNodeCancelRevertScript(edgeNodeAgentRevertPIDFile) + "\n" + agentStartCommand()); err != nil {
+		return fmt.Errorf("failed to start cluster-agent on the edge node: %w", err)
+	}
+	return nil
+}
+
+// EdgeNodeAgentActive reports whether cluster-agent is running on the edge node.
+func EdgeNodeAgentActive() (bool, error) {
+	out, err := ExecOnEdgeNode(fmt.Sprintf("systemctl is-active %s || true", edgeNodeAgentService))
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(out)) == "active", nil
+}
+
+// EdgeNodeAgentRestarts returns how many times systemd restarted cluster-agent on the edge node by itself
+// since the unit was last started.
+func EdgeNodeAgentRestarts() (int, error) {
+	out, err := ExecOnEdgeNode(fmt.Sprintf("systemctl show -p NRestarts --value %s", edgeNodeAgentService))
+	if err != nil {
+		return 0, err
+	}
+	return parseAgentRestarts(string(out))
+}
+
+func parseAgentRestarts(out string) (int, error) {
+	restarts, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected restart count of %s %q: %w", edgeNodeAgentService, strings.TrimSpace(out), err)
+	}
+	return restarts, nil
+}
+
+func agentHoldScript(revertAfter time.Duration) string {
+	return strings.Join([]string{
+		"set -e",
+		edgeNodeSudoPreamble + edgeNodeCancelRevertScript(edgeNodeAgentRevertPIDFile),
+		"$SUDO systemctl stop " + edgeNodeAgentService,
+		edgeNodeScheduleRevertScript(edgeNodeAgentRevertPIDFile, revertAfter, agentStartCommand()),
+	}, "\n")
+}
+
+// agentStartCommand is a single line without double quotes, so it can also run from the background
+// revert timer.
+func agentStartCommand() string {
+	return "$SUDO systemctl start " + edgeNodeAgentService
+}
+
+// EnsureHostOnboardable makes sure the inventory of a project has a host record with the given UUID and
+// an operating system instance on it, i.e. what cluster-manager and the infra provider expect before a
+// cluster is created on the node. It reports whether the host was registered by this call.
+func EnsureHostOnboardable(namespace, name, uuid string) (*Host, bool, error) {
+	host, found, err := FindHost(namespace, uuid)
+	if err != nil {
+		return nil, false, err
+	}
+	registered := false
+	if !found {
+		if host, err = RegisterHost(namespace, name, uuid); err != nil {
+			return nil, false, err
+		}
+		registered = true
+	}
+	if host.Instance == nil || host.Instance.ResourceID == "" {
+		if _, err := ProvisionHost(namespace, host); err != nil {
+			return host, registered, err
+		}
+	}
+	return host, registered, nil
+}
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAgentHoldScript(t *testing.T) {
+	script := agentHoldScript(15 * time.Minute)
+	for _, want := range []string{
+		"$SUDO systemctl stop cluster-agent",
+		"sleep 900; $SUDO systemctl start cluster-agent",
+		"echo $! > " + edgeNodeAgentRevertPIDFile,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("Expected %q in hold script:\n%s", want, script)
+		}
+	}
+}
+
+func TestAgentStartCommand(t *testing.T) {
+	if cmd := agentStartCommand(); strings.ContainsAny(cmd, "\n\"") {
+		t.Errorf("Expected a single line start command without double quotes, got:\n%s", cmd)
+	}
+}
+
+func TestParseAgentRestarts(t *testing.T) {
+	if restarts, err := parseAgentRestarts("3\n"); err != nil || restarts != 3 {
+		t.Errorf("Expected 3 restarts, got %d, %v", restarts, err)
+	}
+	if _, err := parseAgentRestarts("[not set]"); err == nil {
+		t.Errorf("Expected an error for a unit without a restart count")
+	}
+}
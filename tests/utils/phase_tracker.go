@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProvisioningPhase is a milestone between requesting a cluster and the cluster being fully active.
+type ProvisioningPhase string
+
+const (
+	PhaseTemplateReady           ProvisioningPhase = "template-ready"
+	PhaseMachineCreated          ProvisioningPhase = "machine-created"
+	PhaseBootstrapDataReady      ProvisioningPhase = "bootstrap-data-ready"
+	PhaseControlPlaneInitialized ProvisioningPhase = "control-plane-initialized"
+	PhaseAgentConnected          ProvisioningPhase = "agent-connected"
+	PhaseAllComponentsReady      ProvisioningPhase = "all-components-ready"
+
+	// ProvisioningReportDirEnvVar selects where phase breakdown reports are written; defaults to the working directory.
+	ProvisioningReportDirEnvVar = "PROVISIONING_REPORT_DIR"
+)
+
+// ProvisioningPhases lists the phases in the order they are expected to complete.
+var ProvisioningPhases = []ProvisioningPhase{
+	PhaseTemplateReady,
+	PhaseMachineCreated,
+	PhaseBootstrapDataReady,
+	PhaseControlPlaneInitialized,
+	PhaseAgentConnected,
+	PhaseAllComponentsReady,
+}
+
+// PhaseTiming is one row of the phase breakdown.
+type PhaseTiming struct {
+	Phase ProvisioningPhase `json:"phase"`
+	// Elapsed is the time from tracker start until the phase completed.
+	Elapsed time.Duration `json:"elapsedNs"`
+	// Duration is the time spent since the previous completed phase.
+	Duration time.Duration `json:"durationNs"`
+	// Reached is false when the phase was never observed.
+	Reached bool `json:"reached"`
+}
+
+// PhaseTracker timestamps the provisioning phases of a cluster. Phases backed by CAPI conditions use
+// the condition transition time, so polling granularity does not skew the breakdown.
+type PhaseTracker struct {
+	namespace   string
+	clusterName string
+	start       time.Time
+
+	mu    sync.Mutex
+	marks map[ProvisioningPhase]time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewPhaseTracker starts timing the provisioning of a cluster from now.
+func NewPhaseTracker(namespace, clusterName string) *PhaseTracker {
+	return &PhaseTracker{
+		namespace:   namespace,
+		clusterName: clusterName,
+		start:       time.Now(),
+		marks:       map[ProvisioningPhase]time.Time{},
+	}
+}
+
+// Mark records that a phase completed at the given time. Only the first mark of a phase is kept.
+func (t *PhaseTracker) Mark(phase ProvisioningPhase, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.marks[phase]; ok {
+		return
+	}
+	if at.Before(t.start) {
+		at = t.start
+	}
+	t.marks[phase] = at
+}
+
+// MarkNow records that a phase completed now.
+func (t *PhaseTracker) MarkNow(phase ProvisioningPhase) {
+	t.Mark(phase, time.Now())
+}
+
+func (t *PhaseTracker) reached(phase ProvisioningPhase) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, ok := t.marks[phase]
+	return ok
+}
+
+// Observe takes one snapshot of the cluster and marks every phase that has completed since the last one.
+func (t *PhaseTracker) Observe() {
+	if !t.reached(PhaseMachineCreated) || !t.reached(PhaseBootstrapDataReady) {
+		if machine, err := firstCAPIObject(t.namespace, "machines.cluster.x-k8s.io", "cluster.x-k8s.io/cluster-name="+t.clusterName); err == nil && machine != nil {
+			t.Mark(PhaseMachineCreated, machine.Metadata.CreationTimestamp)
+			if at, ok := machine.conditionTrue("BootstrapReady", "BootstrapConfigReady"); ok {
+				t.Mark(PhaseBootstrapDataReady, at)
+			}
+		}
+	}
+
+	if !t.reached(PhaseControlPlaneInitialized) {
+		if cluster, err := getCAPIObject(t.namespace, "clusters.cluster.x-k8s.io", t.clusterName); err == nil && cluster != nil {
+			if at, ok := cluster.conditionTrue("ControlPlaneInitialized"); ok {
+				t.Mark(PhaseControlPlaneInitialized, at)
+			}
+		}
+	}
+
+	if !t.reached(PhaseAgentConnected) {
+		if metrics, err := FetchMetrics(); err == nil {
+			ok, err := ParseMetrics(metrics)
+			metrics.Close()
+			if err == nil && ok {
+				t.MarkNow(PhaseAgentConnected)
+			}
+		}
+	}
+
+	if !t.reached(PhaseAllComponentsReady) {
+		if status, err := GetClusterComponents("", t.namespace, t.clusterName); err == nil && status.AllReady() {
+			t.MarkNow(PhaseAllComponentsReady)
+		}
+	}
+}
+
+// Start observes the cluster in the background until Stop is called or every phase is reached.
+func (t *PhaseTracker) Start(interval time.Duration) {
+	t.stop = make(chan struct{})
+	t.done = make(chan struct{})
+
+	go func() {
+		defer close(t.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			t.Observe()
+			if t.reached(PhaseAllComponentsReady) && t.reached(PhaseAgentConnected) {
+				return
+			}
+			select {
+			case <-t.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends background observation started with Start and takes a final snapshot.
+func (t *PhaseTracker) Stop() {
+	if t == nil || t.stop == nil {
+		return
+	}
+	select {
+	case <-t.done:
+	default:
+		close(t.stop)
+		<-t.done
+	}
+	t.stop = nil
+	t.Observe()
+}
+
+// Breakdown returns the per-phase timings in phase order.
+func (t *PhaseTracker) Breakdown() []PhaseTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	timings := make([]PhaseTiming, 0, len(ProvisioningPhases))
+	previous := t.start
+	for _, phase := range ProvisioningPhases {
+		at, ok := t.marks[phase]
+		if !ok {
+			timings = append(timings, PhaseTiming{Phase: phase})
+			continue
+		}
+		duration := at.Sub(previous)
+		if duration < 0 {
+			duration = 0
+		}
+		timings = append(timings, PhaseTiming{Phase: phase, Elapsed: at.Sub(t.start), Duration: duration, Reached: true})
+		if at.After(previous) {
+			previous = at
+		}
+	}
+	return timings
+}
+
+// PrintBreakdown writes a human readable breakdown to stdout.
+func (t *PhaseTracker) PrintBreakdown() {
+	fmt.Printf("Provisioning phase breakdown for %s/%s:\n", t.namespace, t.clusterName)
+	for _, timing := range t.Breakdown() {
+		if !timing.Reached {
+			fmt.Printf("  %-26s not reached\n", timing.Phase)
+			continue
+		}
+		fmt.Printf("  %-26s +%-10v (at %v)\n", timing.Phase, timing.Duration.Round(time.Second), timing.Elapsed.Round(time.Second))
+	}
+}
+
+// WriteReport stores the breakdown as a JSON artifact and returns its path.
+func (t *PhaseTracker) WriteReport() (string, error) {
+	report := struct {
+		Namespace   string        `json:"namespace"`
+		ClusterName string        `json:"clusterName"`
+		Start       time.Time     `json:"start"`
+		Phases      []PhaseTiming `json:"phases"`
+	}{
+		Namespace:   t.namespace,
+		ClusterName: t.clusterName,
+		Start:       t.start,
+		Phases:      t.Breakdown(),
+	}
+
+	data, err := MarshalReport(report)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(GetEnv(ProvisioningReportDirEnvVar, "."), fmt.Sprintf("provisioning-phases-%s.json", t.clusterName))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write provisioning report %s: %w", path, err)
+	}
+	return path, nil
+}
+
+type capiCondition struct {
+	Type               string    `json:"type"`
+	Status             string    `json:"status"`
+	Reason             string    `json:"reason"`
+	Message            string    `json:"message"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// capiObjectRef covers both the v1beta1 (apiVersion) and v1beta2 (apiGroup) reference shapes.
+type capiObjectRef struct {
+	APIVersion string `json:"apiVersion"`
+	APIGroup   string `json:"apiGroup"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+type capiObject struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name              string    `json:"name"`
+		CreationTimestamp time.Time `json:"creationTimestamp"`
+	} `json:"metadata"`
+	Spec struct {
+		ControlPlaneRef   *capiObjectRef `json:"controlPlaneRef"`
+		InfrastructureRef *capiObjectRef `json:"infrastructureRef"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []capiCondition `json:"conditions"`
+		V1Beta2    struct {
+			Conditions []capiCondition `json:"conditions"`
+		} `json:"v1beta2"`
+	} `json:"status"`
+}
+
+// conditionTrue returns the transition time of the first of the given condition types that is True,
+// looking at both the v1beta1 and v1beta2 condition lists.
+func (o *capiObject) conditionTrue(types ...string) (time.Time, bool) {
+	for _, conditions := range [][]capiCondition{o.Status.Conditions, o.Status.V1Beta2.Conditions} {
+		for _, c := range conditions {
+			for _, conditionType := range types {
+				if c.Type == conditionType && c.Status == "True" {
+					return c.LastTransitionTime, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// getCAPIObject fetches a single CAPI object, or the first match of a label selector; nil means not found yet.
+func getCAPIObject(namespace, resource, name string) (*capiObject, error) {
+	out, err := managementObjectJSON(resource, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return parseCAPIObject(out)
+}
+
+// firstCAPIObject returns the first object of a resource matching a label selector, nil when none does.
+func firstCAPIObject(namespace, resource, labelSelector string) (*capiObject, error) {
+	out, err := managementResourceJSON(resource, namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+	return parseCAPIObject(out)
+}
+
+func parseCAPIObject(data []byte) (*capiObject, error) {
+	var list struct {
+		Kind  string       `json:"kind"`
+		Items []capiObject `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse CAPI object: %w", err)
+	}
+	if strings.HasSuffix(list.Kind, "List") {
+		if len(list.Items) == 0 {
+			return nil, nil
+		}
+		return &list.Items[0], nil
+	}
+
+	var obj capiObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("failed to parse CAPI object: %w", err)
+	}
+	return &obj, nil
+}
@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPhaseTrackerBreakdown(t *testing.T) {
+	tracker := NewPhaseTracker("ns", "demo")
+	start := tracker.start
+
+	tracker.Mark(PhaseTemplateReady, start.Add(2*time.Second))
+	tracker.Mark(PhaseMachineCreated, start.Add(5*time.Second))
+	tracker.Mark(PhaseMachineCreated, start.Add(50*time.Second)) // later marks are ignored
+	tracker.Mark(PhaseControlPlaneInitialized, start.Add(65*time.Second))
+
+	timings := tracker.Breakdown()
+	if len(timings) != len(ProvisioningPhases) {
+		t.Fatalf("Expected %d phases, got %d", len(ProvisioningPhases), len(timings))
+	}
+
+	expected := map[ProvisioningPhase]struct {
+		reached  bool
+		duration time.Duration
+	}{
+		PhaseTemplateReady:           {true, 2 * time.Second},
+		PhaseMachineCreated:          {true, 3 * time.Second},
+		PhaseBootstrapDataReady:      {false, 0},
+		PhaseControlPlaneInitialized: {true, 60 * time.Second},
+		PhaseAgentConnected:          {false, 0},
+		PhaseAllComponentsReady:      {false, 0},
+	}
+	for _, timing := range timings {
+		want := expected[timing.Phase]
+		if timing.Reached != want.reached || timing.Duration != want.duration {
+			t.Errorf("Phase %s: expected reached=%t duration=%v, got %+v", timing.Phase, want.reached, want.duration, timing)
+		}
+	}
+}
+
+func TestPhaseTrackerClampsEarlyMarks(t *testing.T) {
+	tracker := NewPhaseTracker("ns", "demo")
+	tracker.Mark(PhaseMachineCreated, tracker.start.Add(-time.Hour))
+
+	for _, timing := range tracker.Breakdown() {
+		if timing.Phase == PhaseMachineCreated && (!timing.Reached || timing.Elapsed != 0) {
+			t.Errorf("Expected a mark before the start to be clamped, got %+v", timing)
+		}
+	}
+}
+
+func TestParseCAPIObject(t *testing.T) {
+	machines := `{"kind":"MachineList","items":[{"metadata":{"creationTimestamp":"2026-01-02T03:04:05Z"},
+		"status":{"conditions":[{"type":"BootstrapReady","status":"True","lastTransitionTime":"2026-01-02T03:05:00Z"}]}}]}`
+	machine, err := parseCAPIObject([]byte(machines))
+	if err != nil || machine == nil {
+		t.Fatalf("Failed to parse machine list: %v", err)
+	}
+	if at, ok := machine.conditionTrue("BootstrapReady"); !ok || at.Format(time.RFC3339) != "2026-01-02T03:05:00Z" {
+		t.Errorf("Expected BootstrapReady at 03:05:00, got %v (%t)", at, ok)
+	}
+
+	empty, err := parseCAPIObject([]byte(`{"kind":"MachineList","items":[]}`))
+	if err != nil || empty != nil {
+		t.Errorf("Expected no object for an empty list, got %+v, %v", empty, err)
+	}
+
+	cluster := `{"kind":"Cluster","metadata":{"creationTimestamp":"2026-01-02T03:04:05Z"},
+		"status":{"conditions":[{"type":"ControlPlaneInitialized","status":"False","lastTransitionTime":"2026-01-02T03:04:05Z"}],
+		"v1beta2":{"conditions":[{"type":"ControlPlaneInitialized","status":"True","lastTransitionTime":"2026-01-02T03:10:00Z"}]}}}`
+	obj, err := parseCAPIObject([]byte(cluster))
+	if err != nil || obj == nil {
+		t.Fatalf("Failed to parse cluster: %v", err)
+	}
+	if at, ok := obj.conditionTrue("ControlPlaneInitialized"); !ok || at.Format(time.RFC3339) != "2026-01-02T03:10:00Z" {
+		t.Errorf("Expected ControlPlaneInitialized from v1beta2 conditions, got %v (%t)", at, ok)
+	}
+}
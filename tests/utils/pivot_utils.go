@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// PivotTargetKubeconfigEnvVar points at the kubeconfig of a second management cluster with the CAPI providers
+// and cluster orchestration components installed. The pivot suite is skipped without it.
+const PivotTargetKubeconfigEnvVar = "PIVOT_TARGET_KUBECONFIG"
+
+// PivotTargetKubeconfig returns the kubeconfig of the pivot target management cluster, or "" when not configured.
+func PivotTargetKubeconfig() string {
+	return os.Getenv(PivotTargetKubeconfigEnvVar)
+}
+
+// WriteCurrentKubeconfig writes the kubeconfig of the current context, with credentials inlined, to path
+// so that the current management cluster can be addressed explicitly once the context is switched.
+func WriteCurrentKubeconfig(path string) error {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(clientcmd.NewDefaultClientConfigLoadingRules(),
+		&clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return fmt.Errorf("failed to read the current kubeconfig: %w", err)
+	}
+	// As kubectl config view --raw --minify --flatten does: only the current context, certificates inlined.
+	if err := clientcmdapi.MinifyConfig(&config); err != nil {
+		return fmt.Errorf("failed to read the current kubeconfig: %w", err)
+	}
+	if err := clientcmdapi.FlattenConfig(&config); err != nil {
+		return fmt.Errorf("failed to read the current kubeconfig: %w", err)
+	}
+	if err := clientcmd.WriteToFile(config, path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig %s: %w", path, err)
+	}
+	RecordDebugKubeconfig(path)
+	return nil
+}
+
+// MoveClusterAPIObjects moves the CAPI objects of a namespace from one management cluster to another with
+// clusterctl move. An empty fromKubeconfig means the current management cluster. With dryRun nothing is
+// moved and the output lists the objects that would be.
+func MoveClusterAPIObjects(namespace, fromKubeconfig, toKubeconfig string, dryRun bool) (string, error) {
+	out, err := CommandCombinedOutput(exec.Command("clusterctl", clusterctlMoveArgs(namespace, fromKubeconfig, toKubeconfig, dryRun)...))
+	if err != nil {
+		return "", fmt.Errorf("failed to move namespace %s to %s: %w: %s", namespace, toKubeconfig, err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+func clusterctlMoveArgs(namespace, fromKubeconfig, toKubeconfig string, dryRun bool) []string {
+	args := append([]string{"move", "--namespace", namespace, "--to-kubeconfig", toKubeconfig}, kubeconfigArgs(fromKubeconfig)...)
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+	return args
+}
+
+// DescribeManagedCluster returns the clusterctl describe output of a cluster as seen by the management
+// cluster of kubeconfig ("" for the current one).
+func DescribeManagedCluster(kubeconfig, namespace, clusterName string) (string, error) {
+	args := append([]string{"describe", "cluster", clusterName, "-n", namespace}, kubeconfigArgs(kubeconfig)...)
+	out, err := CommandOutput(exec.Command("clusterctl", args...))
+	if err != nil {
+		return "", fmt.Errorf("failed to describe cluster %s/%s: %w", namespace, clusterName, err)
+	}
+	return string(out), nil
+}
+
+// ManagedClusterExists reports whether the management cluster of kubeconfig ("" for the current one)
+// holds the Cluster object.
+func ManagedClusterExists(kubeconfig, namespace, clusterName string) bool {
+	client, err := managementKubeClientFor(kubeconfig)
+	if err != nil {
+		return false
+	}
+	exists, err := client.ClusterExists(namespace, clusterName)
+	return err == nil && exists
+}
+
+func kubeconfigArgs(kubeconfig string) []string {
+	if kubeconfig == "" {
+		return nil
+	}
+	return []string{"--kubeconfig", kubeconfig}
+}
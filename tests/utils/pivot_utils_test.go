@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClusterctlMoveArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		dryRun   bool
+		expected []string
+	}{
+		{
+			name:     "from current context",
+			expected: []string{"move", "--namespace", "demo", "--to-kubeconfig", "/tmp/target"},
+		},
+		{
+			name:     "from explicit kubeconfig",
+			from:     "/tmp/source",
+			expected: []string{"move", "--namespace", "demo", "--to-kubeconfig", "/tmp/target", "--kubeconfig", "/tmp/source"},
+		},
+		{
+			name:     "dry run",
+			dryRun:   true,
+			expected: []string{"move", "--namespace", "demo", "--to-kubeconfig", "/tmp/target", "--dry-run"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := clusterctlMoveArgs("demo", tt.from, "/tmp/target", tt.dryRun)
+			if !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, args)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+const (
+	ComponentReadyTimeout  = 1 * time.Minute
+	componentReadyInterval = 1 * time.Second
+	componentReadyProbe    = 3 * time.Second
+)
+
+// StartPortForward forwards localPort to remotePort of the given service, e.g. "svc/cluster-manager", in the
+// namespace of the current kubeconfig context, and returns once the local port listens. The caller owns the
+// returned port-forward and should release it with StopPortForwards. In InClusterMode the local port relays
+// to the in-cluster address of the service instead, and when a port-forward of RegisterPortForwards already
+// serves the local port it is used as is; no port-forward is returned in either case.
+func StartPortForward(service, localPort, remotePort string) (*PortForwarder, error) {
+	recordDebugPortForward(service, localPort)
+	if InClusterMode() {
+		return nil, startServiceProxy(localPort, InClusterServiceAddress(service, remotePort))
+	}
+	if suitePortForwardServes(localPort) {
+		return nil, nil
+	}
+	if err := EnsureTCPPortAvailable(localPort, fmt.Sprintf("port-forward to %s", service)); err != nil {
+		return nil, err
+	}
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	forwarder := NewPortForwarder(client, client.Namespace, service, localPort, remotePort)
+	if err := forwarder.Start(PortForwardReadyTimeout); err != nil {
+		return nil, fmt.Errorf("failed to start port-forward to %s: %w", service, err)
+	}
+	return forwarder, nil
+}
+
+// StopPortForwards closes the given port-forwards; nil entries are ignored. Stopping the port-forward of
+// StartGatewayPortForward stops the one of the gateway metrics too.
+func StopPortForwards(forwarders ...*PortForwarder) {
+	for _, forwarder := range forwarders {
+		if forwarder == nil {
+			continue
+		}
+		forwarder.Close()
+		gatewayPortForwardsMu.Lock()
+		metrics := gatewayMetricsPortForwards[forwarder]
+		delete(gatewayMetricsPortForwards, forwarder)
+		gatewayPortForwardsMu.Unlock()
+		if metrics != nil {
+			metrics.Close()
+		}
+	}
+}
+
+// StartClusterManagerPortForward port-forwards the cluster-manager service and waits until its API answers.
+func StartClusterManagerPortForward() (*PortForwarder, error) {
+	forwarder, err := StartPortForward(PortForwardService, PortForwardLocalPort, PortForwardRemotePort)
+	if err != nil {
+		return nil, err
+	}
+	if err := WaitForClusterManagerReady(ComponentReadyTimeout); err != nil {
+		StopPortForwards(forwarder)
+		return nil, err
+	}
+	return forwarder, nil
+}
+
+// StartGatewayPortForward port-forwards the cluster-connect-gateway service and waits until it serves metrics.
+// When the metrics have a service port of their own, it is forwarded as well and stopped with the returned
+// port-forward.
+func StartGatewayPortForward() (*PortForwarder, error) {
+	forwarder, err := StartPortForward(PortForwardGatewayService, PortForwardGatewayLocalPort, PortForwardGatewayRemotePort)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := startGatewayMetricsPortForward()
+	if err != nil {
+		StopPortForwards(forwarder)
+		return nil, err
+	}
+	if err := WaitForGatewayReady(ComponentReadyTimeout); err != nil {
+		StopPortForwards(forwarder, metrics)
+		return nil, err
+	}
+	if forwarder != nil && metrics != nil {
+		gatewayPortForwardsMu.Lock()
+		gatewayMetricsPortForwards[forwarder] = metrics
+		gatewayPortForwardsMu.Unlock()
+	}
+	return forwarder, nil
+}
+
+// WaitForClusterManagerReady polls the cluster-manager /v2/healthz endpoint until it answers.
+// An unauthorized response counts as ready since it proves the API server is serving requests.
+func WaitForClusterManagerReady(timeout time.Duration) error {
+	endpoint := fmt.Sprintf("%s/v2/healthz", GetClusterManagerEndpoint())
+	return waitForHTTPReady("cluster-manager", endpoint, timeout, func(status int) bool {
+		return status == http.StatusOK || status == http.StatusUnauthorized
+	})
+}
+
+// WaitForGatewayReady polls the cluster-connect-gateway metrics endpoint until it answers successfully.
+func WaitForGatewayReady(timeout time.Duration) error {
+	endpoint := GatewayMetricsURL()
+	return waitForHTTPReady("cluster-connect-gateway", endpoint, timeout, func(status int) bool {
+		return status == http.StatusOK
+	})
+}
+
+func waitForHTTPReady(component, endpoint string, timeout time.Duration, ready func(status int) bool) error {
+	client := &http.Client{Timeout: componentReadyProbe}
+	deadline := time.Now().Add(timeout)
+
+	var lastErr error
+	for {
+		resp, err := client.Get(endpoint)
+		if err == nil {
+			resp.Body.Close()
+			if ready(resp.StatusCode) {
+				return nil
+			}
+			lastErr = fmt.Errorf("unexpected status %d", resp.StatusCode)
+		} else {
+			lastErr = err
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s at %s not ready after %s: %w", component, endpoint, timeout, lastErr)
+		}
+		time.Sleep(componentReadyInterval)
+	}
+}
+
+// SuitePortForward is a port-forward RegisterPortForwards holds open for a whole suite.
+type SuitePortForward struct {
+	Name  string
+	start func() (*PortForwarder, error)
+}
+
+// The port-forwards of the orchestrator components a suite can hold open with RegisterPortForwards.
+var (
+	ClusterManagerPortForward = SuitePortForward{Name: "cluster-manager", start: StartClusterManagerPortForward}
+	GatewayPortForward        = SuitePortForward{Name: "cluster-connect-gateway", start: StartGatewayPortForward}
+	TenancyAPIPortForward     = SuitePortForward{Name: "tenancy API", start: StartTenancyAPIPortForward}
+	InfraAPIPortForward       = SuitePortForward{Name: "infra API", start: StartInfraAPIPortForward}
+)
+
+var (
+	suitePortForwardsMu sync.Mutex
+	suitePortForwards   []*PortForwarder
+)
+
+// RegisterPortForwards opens the given port-forwards before the first spec of the suite and closes them after
+// the last one, including the AfterSuite nodes such as the teardown of RegisterSharedClusters. The
+// port-forwards reconnect when a component restarts, and the Start functions of the same port-forwards return
+// nil in the specs since the suite already serves their local ports. Call it from a suite file as
+// `var _ = utils.RegisterPortForwards(utils.ClusterManagerPortForward, utils.GatewayPortForward)`.
+func RegisterPortForwards(forwards ...SuitePortForward) bool {
+	ginkgo.BeforeSuite(func() {
+		for _, forward := range forwards {
+			ginkgo.By("Port forwarding to the " + forward.Name + " service")
+			forwarder, err := forward.start()
+			if err != nil {
+				ginkgo.Fail(fmt.Sprintf("failed to port-forward to the %s service: %v", forward.Name, err))
+			}
+			if forwarder == nil {
+				continue
+			}
+			suitePortForwardsMu.Lock()
+			suitePortForwards = append(suitePortForwards, forwarder)
+			suitePortForwardsMu.Unlock()
+			ginkgo.DeferCleanup(func() {
+				suitePortForwardsMu.Lock()
+				suitePortForwards = slices.DeleteFunc(suitePortForwards, func(f *PortForwarder) bool { return f == forwarder })
+				suitePortForwardsMu.Unlock()
+				StopPortForwards(forwarder)
+			})
+		}
+	})
+
+	return true
+}
+
+// suitePortForwardServes tells whether a port-forward of RegisterPortForwards, or the gateway metrics one
+// started with it, listens on localPort.
+func suitePortForwardServes(localPort string) bool {
+	suitePortForwardsMu.Lock()
+	defer suitePortForwardsMu.Unlock()
+	gatewayPortForwardsMu.Lock()
+	defer gatewayPortForwardsMu.Unlock()
+	for _, forwarder := range suitePortForwards {
+		if forwarder.LocalPort() == localPort {
+			return true
+		}
+		if metrics := gatewayMetricsPortForwards[forwarder]; metrics != nil && metrics.LocalPort() == localPort {
+			return true
+		}
+	}
+	return false
+}
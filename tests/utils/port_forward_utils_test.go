@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForHTTPReady(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	isUp := func(status int) bool { return status == http.StatusOK || status == http.StatusUnauthorized }
+	if err := waitForHTTPReady("test", server.URL, 10*time.Second, isUp); err != nil {
+		t.Fatalf("Expected endpoint to become ready, got: %v", err)
+	}
+	if calls.Load() < 2 {
+		t.Errorf("Expected at least two probes, got %d", calls.Load())
+	}
+}
+
+func TestWaitForHTTPReadyTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := waitForHTTPReady("test", server.URL, 0, func(status int) bool { return status == http.StatusOK })
+	if err == nil {
+		t.Fatal("Expected a timeout error")
+	}
+}
@@ -0,0 +1,264 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const (
+	// PortForwardReadyTimeout is how long a port-forward may take to listen, including waiting for a ready pod
+	// behind its service.
+	PortForwardReadyTimeout = 1 * time.Minute
+	// portForwardRetryInterval is how long a port-forward waits before connecting again after a failed attempt
+	// or a dropped connection.
+	portForwardRetryInterval = 2 * time.Second
+)
+
+// PortForwarder forwards a local port to a service or pod through the Kubernetes API, as kubectl port-forward
+// does, without the kubectl binary. Start blocks until the local port listens, and a dropped connection, e.g.
+// because the pod restarted, is reconnected to a ready pod on the same local port until Close.
+type PortForwarder struct {
+	client    *KubeClient
+	namespace string
+	// target is "svc/<name>", "service/<name>" or "pod/<name>".
+	target     string
+	remotePort string
+
+	mu         sync.Mutex
+	localPort  string
+	pod        string
+	reconnects int
+	stop       chan struct{}
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewPortForwarder returns a port-forward from localPort to remotePort of target in namespace. An empty or
+// "0" localPort picks a free port, see LocalPort.
+func NewPortForwarder(client *KubeClient, namespace, target, localPort, remotePort string) *PortForwarder {
+	if localPort == "" {
+		localPort = "0"
+	}
+	return &PortForwarder{
+		client:     client,
+		namespace:  namespace,
+		target:     target,
+		remotePort: remotePort,
+		localPort:  localPort,
+		stop:       make(chan struct{}),
+		closed:     make(chan struct{}),
+	}
+}
+
+// Start connects and returns once the local port listens, or the error of the last attempt after timeout.
+// The port-forward then keeps reconnecting in the background until Close.
+func (f *PortForwarder) Start(timeout time.Duration) error {
+	ready := make(chan error, 1)
+	go f.run(time.Now().Add(timeout), ready)
+	return <-ready
+}
+
+// LocalPort returns the local port, the picked one once Start returned when a free port was asked for.
+func (f *PortForwarder) LocalPort() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.localPort
+}
+
+// Reconnects returns how often the port-forward connected again after its connection dropped.
+func (f *PortForwarder) Reconnects() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reconnects
+}
+
+func (f *PortForwarder) String() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return fmt.Sprintf("127.0.0.1:%s -> %s/%s:%s (pod %s)", f.localPort, f.namespace, f.target, f.remotePort, f.pod)
+}
+
+// Close stops forwarding and releases the local port. It is safe to call more than once.
+func (f *PortForwarder) Close() {
+	f.closeOnce.Do(func() { close(f.stop) })
+	<-f.closed
+}
+
+// run connects until Close, reporting the outcome of the first connection on ready.
+func (f *PortForwarder) run(deadline time.Time, ready chan<- error) {
+	defer close(f.closed)
+	first := true
+	for {
+		err := f.forward(func() {
+			if first {
+				first = false
+				ready <- nil
+			}
+		})
+		select {
+		case <-f.stop:
+			if first {
+				ready <- fmt.Errorf("port-forward to %s/%s closed before it listened", f.namespace, f.target)
+			}
+			return
+		default:
+		}
+		if first && time.Now().After(deadline) {
+			ready <- fmt.Errorf("port-forward to %s/%s not ready: %w", f.namespace, f.target, err)
+			return
+		}
+		if !first {
+			f.mu.Lock()
+			f.reconnects++
+			f.mu.Unlock()
+			fmt.Printf("Port-forward %s dropped, reconnecting: %v\n", f, err)
+		}
+		select {
+		case <-f.stop:
+			return
+		case <-time.After(portForwardRetryInterval):
+		}
+	}
+}
+
+// forward forwards to a ready pod until the connection drops or Close, calling listening once the local port
+// listens.
+func (f *PortForwarder) forward(listening func()) error {
+	pod, podPort, err := f.resolve()
+	if err != nil {
+		return err
+	}
+	transport, upgrader, err := spdy.RoundTripperFor(f.client.Config)
+	if err != nil {
+		return err
+	}
+	url := f.client.Clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(f.namespace).Name(pod).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop, ready := make(chan struct{}), make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{PortForwardAddress},
+		[]string{fmt.Sprintf("%s:%d", f.LocalPort(), podPort)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- forwarder.ForwardPorts() }()
+
+	select {
+	case err := <-done:
+		return fmt.Errorf("failed to forward to pod %s: %w", pod, err)
+	case <-f.stop:
+		close(stop)
+		<-done
+		return nil
+	case <-ready:
+	}
+	if ports, err := forwarder.GetPorts(); err == nil && len(ports) == 1 {
+		f.mu.Lock()
+		f.localPort, f.pod = strconv.Itoa(int(ports[0].Local)), pod
+		f.mu.Unlock()
+	}
+	listening()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			err = fmt.Errorf("lost connection to pod %s", pod)
+		}
+		return err
+	case <-f.stop:
+		close(stop)
+		<-done
+		return nil
+	}
+}
+
+// resolve returns the pod to forward to and its port, picking a ready pod of the service for a service target.
+func (f *PortForwarder) resolve() (string, int, error) {
+	ctx, cancel := kubeClientContext()
+	defer cancel()
+	core := f.client.Clientset.CoreV1()
+
+	kind, name, _ := strings.Cut(f.target, "/")
+	switch kind {
+	case "pod", "pods":
+		port, err := strconv.Atoi(f.remotePort)
+		if err != nil {
+			pod, getErr := core.Pods(f.namespace).Get(ctx, name, metav1.GetOptions{})
+			if getErr != nil {
+				return "", 0, getErr
+			}
+			return resolveContainerPort(pod, intstr.FromString(f.remotePort))
+		}
+		return name, port, nil
+	case "svc", "service", "services":
+	default:
+		return "", 0, fmt.Errorf("cannot port-forward to %q, expected svc/<name> or pod/<name>", f.target)
+	}
+
+	service, err := core.Services(f.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", 0, err
+	}
+	targetPort, err := serviceTargetPort(service, f.remotePort)
+	if err != nil {
+		return "", 0, err
+	}
+	pods, err := core.Pods(f.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp == nil && podReady(pod) {
+			return resolveContainerPort(pod, targetPort)
+		}
+	}
+	return "", 0, fmt.Errorf("service %s/%s has no ready pod", f.namespace, name)
+}
+
+// serviceTargetPort returns the target port of the service port remotePort, given by number or name.
+func serviceTargetPort(service *corev1.Service, remotePort string) (intstr.IntOrString, error) {
+	for _, port := range service.Spec.Ports {
+		if strconv.Itoa(int(port.Port)) == remotePort || port.Name == remotePort {
+			if port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal == 0 {
+				return intstr.FromInt32(port.Port), nil
+			}
+			return port.TargetPort, nil
+		}
+	}
+	return intstr.IntOrString{}, fmt.Errorf("service %s/%s has no port %s", service.Namespace, service.Name, remotePort)
+}
+
+// resolveContainerPort returns the pod and the number of port, looking named ports up in its containers.
+func resolveContainerPort(pod *corev1.Pod, port intstr.IntOrString) (string, int, error) {
+	if port.Type == intstr.Int {
+		return pod.Name, int(port.IntVal), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return pod.Name, int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return "", 0, fmt.Errorf("pod %s/%s has no port named %s", pod.Namespace, pod.Name, port.StrVal)
+}
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func testPod(name string, ready corev1.ConditionStatus, ports ...corev1.ContainerPort) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name, Labels: map[string]string{"app": "gateway"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "gateway", Ports: ports}}},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: ready},
+		}},
+	}
+}
+
+func TestPortForwarderResolve(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gateway"},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "gateway"},
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 8080, TargetPort: intstr.FromInt32(9090)},
+				{Name: "metrics", Port: 8081, TargetPort: intstr.FromString("metrics")},
+				{Name: "plain", Port: 8082},
+			},
+		},
+	}
+	client := testKubeClient([]runtime.Object{
+		service,
+		testPod("gateway-old", corev1.ConditionFalse),
+		testPod("gateway-new", corev1.ConditionTrue, corev1.ContainerPort{Name: "metrics", ContainerPort: 9091}),
+	})
+
+	for _, tc := range []struct {
+		target, remotePort string
+		pod                string
+		port               int
+	}{
+		{"svc/gateway", "8080", "gateway-new", 9090},
+		{"svc/gateway", "http", "gateway-new", 9090},
+		{"svc/gateway", "8081", "gateway-new", 9091},
+		{"service/gateway", "8082", "gateway-new", 8082},
+		{"pod/gateway-old", "8080", "gateway-old", 8080},
+		{"pod/gateway-new", "metrics", "gateway-new", 9091},
+	} {
+		pod, port, err := NewPortForwarder(client, "ns", tc.target, "", tc.remotePort).resolve()
+		if err != nil || pod != tc.pod || port != tc.port {
+			t.Errorf("Expected %s:%d for %s:%s, got %s:%d, %v", tc.pod, tc.port, tc.target, tc.remotePort, pod, port, err)
+		}
+	}
+
+	for _, tc := range []struct{ target, remotePort string }{
+		{"svc/gateway", "9999"},
+		{"svc/missing", "8080"},
+		{"deployment/gateway", "8080"},
+		{"pod/gateway-old", "metrics"},
+	} {
+		if _, _, err := NewPortForwarder(client, "ns", tc.target, "", tc.remotePort).resolve(); err == nil {
+			t.Errorf("Expected an error for %s:%s", tc.target, tc.remotePort)
+		}
+	}
+}
+
+func TestPortForwarderResolveWithoutReadyPod(t *testing.T) {
+	client := testKubeClient([]runtime.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "gateway"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "gateway"},
+				Ports:    []corev1.ServicePort{{Port: 8080}},
+			},
+		},
+		testPod("gateway-0", corev1.ConditionFalse),
+	})
+	if _, _, err := NewPortForwarder(client, "ns", "svc/gateway", "", "8080").resolve(); err == nil {
+		t.Error("Expected an error for a service without a ready pod")
+	}
+}
+
+func TestPortForwarderStartTimesOut(t *testing.T) {
+	forwarder := NewPortForwarder(testKubeClient(nil), "ns", "svc/missing", "", "8080")
+	if forwarder.LocalPort() != "0" {
+		t.Errorf("Expected an empty local port to pick a free one, got %q", forwarder.LocalPort())
+	}
+	if err := forwarder.Start(10 * time.Millisecond); err == nil {
+		t.Error("Expected a port-forward to a missing service not to start")
+	}
+	forwarder.Close()
+}
@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ConnectionProbeIntervalEnvVar overrides the detected connection probe interval of the gateway (e.g. "20s").
+	ConnectionProbeIntervalEnvVar = "CONNECTION_PROBE_INTERVAL"
+	// DefaultConnectionProbeInterval is what .test-dependencies.yaml configures through the chart.
+	DefaultConnectionProbeInterval = 20 * time.Second
+
+	clusterConnectGatewayPrefix = "cluster-connect-gateway"
+)
+
+// ConnectionProbeInterval returns how often the gateway is configured to probe the connection to each cluster,
+// and where the value came from. CONNECTION_PROBE_INTERVAL takes precedence, then the flags and environment
+// of the cluster-connect-gateway deployments, then the default of the test environment.
+func ConnectionProbeInterval() (time.Duration, string) {
+	if value := os.Getenv(ConnectionProbeIntervalEnvVar); value != "" {
+		if interval, err := time.ParseDuration(value); err == nil && interval > 0 {
+			return interval, ConnectionProbeIntervalEnvVar + "=" + value
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", ConnectionProbeIntervalEnvVar, value)
+	}
+
+	settings, err := clusterConnectGatewaySettings()
+	if err == nil {
+		if interval, found := parseDurationSetting(settings, "connection-probe-interval"); found {
+			return interval, "cluster-connect-gateway deployment"
+		}
+	} else {
+		fmt.Printf("Unable to read the cluster-connect-gateway deployments: %v\n", err)
+	}
+	return DefaultConnectionProbeInterval, "test environment default"
+}
+
+// clusterConnectGatewaySettings returns the args of the cluster-connect-gateway containers followed by their
+// environment as -NAME=value args, so both can be searched the same way.
+func clusterConnectGatewaySettings() ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := client.ListDeployments(componentReleaseNamespace)
+	if err != nil {
+		return nil, err
+	}
+	var settings []string
+	for _, deployment := range deployments {
+		if !strings.HasPrefix(deployment.Name, clusterConnectGatewayPrefix) {
+			continue
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			settings = append(settings, container.Args...)
+			for _, env := range container.Env {
+				settings = append(settings, "-"+env.Name+"="+env.Value)
+			}
+		}
+	}
+	return settings, nil
+}
+
+// parseDurationSetting looks for a duration flag in args. Names are compared without dashes, underscores
+// and case, so -connection-probe-interval, --connectionProbeInterval and CONNECTION_PROBE_INTERVAL all match.
+func parseDurationSetting(args []string, name string) (time.Duration, bool) {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.NewReplacer("-", "", "_", "").Replace(s))
+	}
+	var value time.Duration
+	found := false
+	for _, arg := range args {
+		key, raw, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !hasValue || !strings.HasPrefix(arg, "-") || normalize(key) != normalize(name) {
+			continue
+		}
+		if parsed, err := time.ParseDuration(raw); err == nil && parsed > 0 {
+			value, found = parsed, true
+		}
+	}
+	return value, found
+}
+
+// ClusterConnectName returns the name of the only ClusterConnect of the test environment.
+func ClusterConnectName() (string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", err
+	}
+	list, err := client.ListClusterConnects()
+	if err != nil {
+		return "", err
+	}
+	if len(list.Items) == 0 {
+		return "", errors.New("there is no ClusterConnect")
+	}
+	return list.Items[0].GetName(), nil
+}
+
+// LastProbeSuccess returns the lastProbeSuccessTimestamp of a ClusterConnect, zero if it is not set.
+func LastProbeSuccess(clusterConnectName string) (time.Time, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return time.Time{}, err
+	}
+	clusterConnect, err := client.GetClusterConnect(clusterConnectName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	value, _, _ := unstructured.NestedString(clusterConnect.Object, "status", "connectionProbe", "lastProbeSuccessTimestamp")
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// ProbeCadence summarizes how the lastProbeSuccessTimestamp of a ClusterConnect moved while it was sampled.
+type ProbeCadence struct {
+	// Updates is how many times the timestamp advanced.
+	Updates int
+	// MedianGap and MaxGap are taken over the differences between consecutive distinct timestamps.
+	MedianGap time.Duration
+	MaxGap    time.Duration
+	// MaxStaleness is the oldest the last successful probe ever was when sampled.
+	MaxStaleness time.Duration
+}
+
+func (c ProbeCadence) String() string {
+	return fmt.Sprintf("%d updates, median gap %v, max gap %v, max staleness %v", c.Updates, c.MedianGap, c.MaxGap, c.MaxStaleness)
+}
+
+type probeSample struct {
+	At          time.Time
+	LastSuccess time.Time
+}
+
+// SampleConnectionProbes reads the lastProbeSuccessTimestamp of a ClusterConnect every period for window
+// and summarizes how it advanced. Samples that cannot be read are skipped.
+func SampleConnectionProbes(clusterConnectName string, window, period time.Duration) ProbeCadence {
+	var samples []probeSample
+	for deadline := time.Now().Add(window); time.Now().Before(deadline); time.Sleep(period) {
+		lastSuccess, err := LastProbeSuccess(clusterConnectName)
+		if err != nil {
+			fmt.Printf("Failed to sample the connection probe: %v\n", err)
+			continue
+		}
+		samples = append(samples, probeSample{At: time.Now(), LastSuccess: lastSuccess})
+	}
+	return summarizeProbeSamples(samples)
+}
+
+func summarizeProbeSamples(samples []probeSample) ProbeCadence {
+	var cadence ProbeCadence
+	var gaps []time.Duration
+	var previous time.Time
+	for _, sample := range samples {
+		if sample.LastSuccess.IsZero() {
+			continue
+		}
+		if staleness := sample.At.Sub(sample.LastSuccess); staleness > cadence.MaxStaleness {
+			cadence.MaxStaleness = staleness
+		}
+		if !sample.LastSuccess.After(previous) {
+			continue
+		}
+		if !previous.IsZero() {
+			cadence.Updates++
+			gaps = append(gaps, sample.LastSuccess.Sub(previous))
+		}
+		previous = sample.LastSuccess
+	}
+	if len(gaps) == 0 {
+		return cadence
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	cadence.MedianGap = gaps[len(gaps)/2]
+	cadence.MaxGap = gaps[len(gaps)-1]
+	return cadence
+}
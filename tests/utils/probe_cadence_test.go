@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationSetting(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		want  time.Duration
+		found bool
+	}{
+		{"kebab flag", []string{"--metrics-bind-address=:8080", "--connection-probe-interval=20s"}, 20 * time.Second, true},
+		{"camel flag", []string{"-connectionProbeInterval=1m"}, time.Minute, true},
+		{"environment", []string{"-CONNECTION_PROBE_INTERVAL=30s"}, 30 * time.Second, true},
+		{"last one wins", []string{"--connection-probe-interval=20s", "--connection-probe-interval=40s"}, 40 * time.Second, true},
+		{"invalid value", []string{"--connection-probe-interval=often"}, 0, false},
+		{"missing", []string{"--connection-probe-timeout=1m"}, 0, false},
+	}
+	for _, tt := range tests {
+		got, found := parseDurationSetting(tt.args, "connection-probe-interval")
+		if got != tt.want || found != tt.found {
+			t.Errorf("%s: Expected %v (%t), got %v (%t)", tt.name, tt.want, tt.found, got, found)
+		}
+	}
+}
+
+func TestSummarizeProbeSamples(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+	samples := []probeSample{
+		{At: at(0), LastSuccess: time.Time{}},
+		{At: at(5), LastSuccess: at(2)},
+		{At: at(15), LastSuccess: at(2)},
+		{At: at(25), LastSuccess: at(22)},
+		{At: at(35), LastSuccess: at(22)},
+		{At: at(45), LastSuccess: at(42)},
+		{At: at(90), LastSuccess: at(42)},
+		{At: at(95), LastSuccess: at(92)},
+	}
+	cadence := summarizeProbeSamples(samples)
+	if cadence.Updates != 3 {
+		t.Errorf("Expected 3 updates, got %d", cadence.Updates)
+	}
+	if cadence.MedianGap != 20*time.Second || cadence.MaxGap != 50*time.Second {
+		t.Errorf("Expected a median gap of 20s and a max gap of 50s, got %v and %v", cadence.MedianGap, cadence.MaxGap)
+	}
+	if cadence.MaxStaleness != 48*time.Second {
+		t.Errorf("Expected a max staleness of 48s, got %v", cadence.MaxStaleness)
+	}
+
+	if cadence := summarizeProbeSamples([]probeSample{{At: at(0), LastSuccess: at(0)}, {At: at(60), LastSuccess: at(0)}}); cadence.Updates != 0 || cadence.MaxStaleness != time.Minute {
+		t.Errorf("Expected a probe that stopped to have no updates and a minute of staleness, got %s", cadence)
+	}
+}
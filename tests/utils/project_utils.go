@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// clusterResources are the namespaced objects cluster orchestration creates for a cluster.
+var clusterResources = []string{
+	"clusters.cluster.x-k8s.io",
+	"machines.cluster.x-k8s.io",
+	"intelmachines.infrastructure.cluster.x-k8s.io",
+	"intelmachinebindings.infrastructure.cluster.x-k8s.io",
+}
+
+// projectResources are the namespaced objects cluster orchestration creates in a project.
+var projectResources = append(append([]string{}, clusterResources...), ClusterClassResource, ClusterTemplateResource)
+
+// NewProjectID returns a random project ID in the UUID form cluster-manager uses as the project namespace.
+func NewProjectID() string {
+	h := []byte(randomHex(16))
+	h[12] = '4'
+	h[16] = "89ab"[h[16]%4]
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
+
+// DeleteProjectNamespace starts deleting a project namespace without waiting for it to be gone.
+func DeleteProjectNamespace(namespace string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	return client.DeleteNamespace(namespace)
+}
+
+// NamespaceExists reports whether a namespace exists, including while it is terminating.
+func NamespaceExists(namespace string) (bool, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return false, err
+	}
+	_, err = client.GetNamespace(namespace)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ProjectResidue describes what keeps a project namespace from being deleted: the objects left in it,
+// marked when they are already being deleted, and the namespace conditions that report a problem.
+func ProjectResidue(namespace string) ([]string, error) {
+	residue, err := listProjectObjects(namespace, projectResources)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	ns, err := client.GetNamespace(namespace)
+	if apierrors.IsNotFound(err) {
+		return residue, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(residue, namespaceProblems(ns)...), nil
+}
+
+// ClusterObjects lists the CAPI objects of the clusters of a project namespace, e.g. to check that a rejected
+// request left nothing behind.
+func ClusterObjects(namespace string) ([]string, error) {
+	return listProjectObjects(namespace, clusterResources)
+}
+
+func listProjectObjects(namespace string, resources []string) ([]string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	var objects []string
+	for _, resource := range resources {
+		list, err := client.ListResource(resource, namespace, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s in %s: %w", resource, namespace, err)
+		}
+		objects = append(objects, residue(list.Items)...)
+	}
+	return objects, nil
+}
+
+// residue names the objects as Kind/name, marking those already being deleted.
+func residue(objects []unstructured.Unstructured) []string {
+	var residue []string
+	for _, object := range objects {
+		name := object.GetKind() + "/" + object.GetName()
+		if deletion := object.GetDeletionTimestamp(); deletion != nil {
+			name += " (deleting since " + deletion.UTC().Format(time.RFC3339) + ")"
+		}
+		residue = append(residue, name)
+	}
+	return residue
+}
+
+// namespaceProblems returns the True conditions of a terminating namespace, e.g. remaining finalizers.
+func namespaceProblems(ns *corev1.Namespace) []string {
+	var problems []string
+	for _, c := range ns.Status.Conditions {
+		if c.Status == corev1.ConditionTrue {
+			problems = append(problems, fmt.Sprintf("Namespace condition %s: %s", c.Type, c.Message))
+		}
+	}
+	return problems
+}
+
+// ProjectAPIResponse sends a request to cluster-manager on behalf of a project and returns the status
+// code and body, whatever the status.
+func ProjectAPIResponse(namespace, method, url string, body []byte) (int, string, error) {
+	return ProjectAPIResponseAuthenticated(nil, namespace, method, url, body)
+}
+
+// ProjectAPIResponseAuthenticated is ProjectAPIResponse with the token of authContext, when it is not nil.
+func ProjectAPIResponseAuthenticated(authContext *auth.TestAuthContext, namespace, method, url string, body []byte) (int, string, error) {
+	resp, err := ClusterManagerRequest(authContext, namespace, method, url, body)
+	return resp.Status, resp.Body, err
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewProjectID(t *testing.T) {
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	first := NewProjectID()
+	if !pattern.MatchString(first) {
+		t.Errorf("Expected a version 4 UUID, got %q", first)
+	}
+	if second := NewProjectID(); second == first {
+		t.Errorf("Expected distinct project IDs, got %q twice", first)
+	}
+}
+
+func TestResidue(t *testing.T) {
+	cluster := testObject(CAPIClusterGVR, "Cluster", "ns", "demo", map[string]interface{}{})
+	cluster.SetDeletionTimestamp(&metav1.Time{Time: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)})
+	template := testObject(ClusterTemplateGVR, "ClusterTemplate", "ns", "k3s-baseline-v0.0.1", map[string]interface{}{})
+	got := residue([]unstructured.Unstructured{*cluster, *template})
+	expected := []string{"Cluster/demo (deleting since 2026-01-02T03:04:05Z)", "ClusterTemplate/k3s-baseline-v0.0.1"}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestNamespaceProblems(t *testing.T) {
+	ns := &corev1.Namespace{Status: corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating, Conditions: []corev1.NamespaceCondition{
+		{Type: corev1.NamespaceDeletionDiscoveryFailure, Status: corev1.ConditionFalse, Message: "All resources successfully discovered"},
+		{Type: corev1.NamespaceFinalizersRemaining, Status: corev1.ConditionTrue,
+			Message: "Some content in the namespace has finalizers remaining: cluster.cluster.x-k8s.io in 1 resource instances"},
+	}}}
+	problems := namespaceProblems(ns)
+	if len(problems) != 1 || !strings.Contains(problems[0], "NamespaceFinalizersRemaining") {
+		t.Errorf("Expected the remaining finalizers to be reported, got %v", problems)
+	}
+
+	if problems := namespaceProblems(&corev1.Namespace{}); problems != nil {
+		t.Errorf("Expected no problems for an active namespace, got %v", problems)
+	}
+}
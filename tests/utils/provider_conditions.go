@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ConnectAgentDisconnectedReason is the reason of the False condition the providers report while the connect
+// agent of a cluster is disconnected from the gateway.
+const ConnectAgentDisconnectedReason = "ConnectAgentDisconnected"
+
+// ProviderCondition is a condition of an object a provider reconciles for a cluster: its IntelCluster, its
+// IntelMachines or its ClusterConnect.
+type ProviderCondition struct {
+	Kind      string
+	Name      string
+	Condition capiCondition
+}
+
+func (c ProviderCondition) String() string {
+	return TriageFinding(c).String()
+}
+
+// Matches reports whether the condition has the given type, status and reason; an empty value matches any.
+func (c ProviderCondition) Matches(conditionType, status, reason string) bool {
+	return (conditionType == "" || c.Condition.Type == conditionType) &&
+		(status == "" || c.Condition.Status == status) &&
+		(reason == "" || c.Condition.Reason == reason)
+}
+
+// clusterConnect is the part of a ClusterConnect the provider conditions need.
+type clusterConnect struct {
+	capiObject
+	Spec struct {
+		ClusterRef *struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"clusterRef"`
+	} `json:"spec"`
+}
+
+// GetProviderConditions returns the conditions of the IntelCluster, IntelMachines and ClusterConnect of a
+// cluster, from the management cluster of kubeconfig ("" for the current one).
+func GetProviderConditions(kubeconfig, namespace, clusterName string) ([]ProviderCondition, error) {
+	client, err := managementKubeClientFor(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := getCAPICluster(client, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var objects []capiObject
+	if ref := cluster.Spec.InfrastructureRef; ref != nil {
+		infra, err := getReferencedCAPIObject(client, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *infra)
+	}
+
+	machines, err := listCAPIObjects(client.ListIntelMachines(namespace, clusterName))
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, machines...)
+
+	connect, err := getClusterConnect(client, namespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	if connect != nil {
+		objects = append(objects, *connect)
+	}
+
+	var conditions []ProviderCondition
+	for _, obj := range objects {
+		for _, c := range obj.conditions() {
+			conditions = append(conditions, ProviderCondition{Kind: obj.Kind, Name: obj.Metadata.Name, Condition: c})
+		}
+	}
+	return conditions, nil
+}
+
+// GetClusterConnectConditions returns the conditions of the ClusterConnect of a cluster; found is false while
+// the provider has not created it.
+func GetClusterConnectConditions(namespace, clusterName string) (conditions []ProviderCondition, found bool, err error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, false, err
+	}
+	connect, err := getClusterConnect(client, namespace, clusterName)
+	if err != nil || connect == nil {
+		return nil, false, err
+	}
+	for _, c := range connect.conditions() {
+		conditions = append(conditions, ProviderCondition{Kind: connect.Kind, Name: connect.Metadata.Name, Condition: c})
+	}
+	return conditions, true, nil
+}
+
+// getClusterConnect returns the ClusterConnect of a cluster, nil when there is none yet.
+func getClusterConnect(client *KubeClient, namespace, clusterName string) (*capiObject, error) {
+	list, err := client.ListClusterConnects()
+	if err != nil {
+		return nil, err
+	}
+	data, err := list.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the ClusterConnects: %w", err)
+	}
+	return findClusterConnect(data, namespace, clusterName)
+}
+
+// findClusterConnect returns the ClusterConnect of a cluster from a ClusterConnect list, matched by its
+// clusterRef or else by the <namespace>-<cluster> name the provider gives it; nil when there is none yet.
+func findClusterConnect(list []byte, namespace, clusterName string) (*capiObject, error) {
+	var connects struct {
+		Items []clusterConnect `json:"items"`
+	}
+	if err := json.Unmarshal(list, &connects); err != nil {
+		return nil, fmt.Errorf("failed to parse the ClusterConnects: %w", err)
+	}
+	var byName *capiObject
+	for i := range connects.Items {
+		connect := &connects.Items[i]
+		if ref := connect.Spec.ClusterRef; ref != nil && ref.Name == clusterName && ref.Namespace == namespace {
+			return &connect.capiObject, nil
+		}
+		if connect.Metadata.Name == namespace+"-"+clusterName {
+			byName = &connect.capiObject
+		}
+	}
+	return byName, nil
+}
+
+// FindProviderCondition returns the first condition with the given type, status and reason; an empty
+// value matches any.
+func FindProviderCondition(conditions []ProviderCondition, conditionType, status, reason string) (ProviderCondition, bool) {
+	for _, c := range conditions {
+		if c.Matches(conditionType, status, reason) {
+			return c, true
+		}
+	}
+	return ProviderCondition{}, false
+}
+
+// CheckLostConnection reports whether a provider object of the cluster reports its connect agent as
+// disconnected, with a False condition of reason ConnectAgentDisconnected.
+func CheckLostConnection(kubeconfig, namespace, clusterName string) (bool, error) {
+	conditions, err := GetProviderConditions(kubeconfig, namespace, clusterName)
+	if err != nil {
+		return false, err
+	}
+	_, found := FindProviderCondition(conditions, "", "False", ConnectAgentDisconnectedReason)
+	return found, nil
+}
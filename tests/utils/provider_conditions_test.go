@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "testing"
+
+func TestFindClusterConnect(t *testing.T) {
+	list := []byte(`{"kind": "ClusterConnectList", "items": [
+		{"kind": "ClusterConnect", "metadata": {"name": "other-demo"}, "spec": {"clusterRef": {"name": "demo", "namespace": "other"}}},
+		{"kind": "ClusterConnect", "metadata": {"name": "ns-demo"}, "status": {"conditions": [
+			{"type": "ConnectionProbe", "status": "False", "reason": "ConnectAgentDisconnected"}]}}]}`)
+
+	connect, err := findClusterConnect(list, "ns", "demo")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if connect == nil || connect.Metadata.Name != "ns-demo" || len(connect.conditions()) != 1 {
+		t.Errorf("Expected the ClusterConnect named after the cluster, got %+v", connect)
+	}
+
+	connect, _ = findClusterConnect(list, "other", "demo")
+	if connect == nil || connect.Metadata.Name != "other-demo" {
+		t.Errorf("Expected the ClusterConnect referencing the cluster, got %+v", connect)
+	}
+
+	if connect, _ := findClusterConnect(list, "ns", "missing"); connect != nil {
+		t.Errorf("Expected no ClusterConnect, got %+v", connect)
+	}
+}
+
+func TestFindProviderCondition(t *testing.T) {
+	conditions := []ProviderCondition{
+		{Kind: "IntelCluster", Name: "demo", Condition: capiCondition{Type: "Ready", Status: "True"}},
+		{Kind: "IntelMachine", Name: "demo-im", Condition: capiCondition{Type: "Ready", Status: "False",
+			Reason: ConnectAgentDisconnectedReason, Message: "connect agent is disconnected"}},
+	}
+
+	found, ok := FindProviderCondition(conditions, "", "False", ConnectAgentDisconnectedReason)
+	if !ok {
+		t.Fatal("Expected the disconnected condition")
+	}
+	if got, want := found.String(), "IntelMachine/demo-im Ready=False (ConnectAgentDisconnected): connect agent is disconnected"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if found, _ := FindProviderCondition(conditions, "Ready", "True", ""); found.Kind != "IntelCluster" {
+		t.Errorf("Expected the IntelCluster condition, got %s", found)
+	}
+	if _, ok := FindProviderCondition(conditions, "Ready", "False", "HostNotProvisioned"); ok {
+		t.Error("Expected no condition with another reason")
+	}
+}
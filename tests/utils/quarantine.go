@@ -0,0 +1,129 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const (
+	// IssueTrackerURLEnvVar is the URL the issue references of SkipWithIssue are appended to, e.g.
+	// https://jira.example.com/browse/. Without it only GitHub references like #123 get a link.
+	IssueTrackerURLEnvVar = "ISSUE_TRACKER_URL"
+	// DefaultIssueTrackerURL is where the issues of this repository live.
+	DefaultIssueTrackerURL = "https://github.com/open-edge-platform/cluster-tests/issues/"
+
+	quarantineLabelPrefix = "quarantine:"
+)
+
+var (
+	quarantineMu sync.Mutex
+	// quarantineReasons are the reasons given to SkipWithIssue by issue, as labels cannot hold them.
+	quarantineReasons = map[string]string{}
+
+	githubIssuePattern = regexp.MustCompile(`^#?[0-9]+$`)
+)
+
+// SkipWithIssue is a Ginkgo decorator quarantining a container or It until the issue, e.g. "PROJECT-123" or
+// "#123", is fixed. The specs are marked pending, so that not even the BeforeAll nodes of a quarantined
+// Ordered container run, and labeled with the issue. Suites list them by issue at the end of the run with
+// RegisterQuarantine, and `mage test:Quarantined` lists them all.
+func SkipWithIssue(issue, reason string) []interface{} {
+	issue = strings.TrimSpace(issue)
+	if issue == "" {
+		panic("SkipWithIssue needs the reference of the issue tracking the skipped specs")
+	}
+	quarantineMu.Lock()
+	defer quarantineMu.Unlock()
+	if current, ok := quarantineReasons[issue]; ok && current != reason {
+		reason = current + "; " + reason
+	}
+	quarantineReasons[issue] = reason
+	return []interface{}{ginkgo.Pending, ginkgo.Labels{quarantineLabelPrefix + issue}}
+}
+
+// IssueLink returns the URL of an issue reference of SkipWithIssue, or "" when it is not known.
+func IssueLink(issue string) string {
+	if base := os.Getenv(IssueTrackerURLEnvVar); base != "" {
+		return base + strings.TrimPrefix(issue, "#")
+	}
+	if githubIssuePattern.MatchString(issue) {
+		return DefaultIssueTrackerURL + strings.TrimPrefix(issue, "#")
+	}
+	return ""
+}
+
+// describeIssue returns the issue reference followed by its link, if any.
+func describeIssue(issue string) string {
+	if link := IssueLink(issue); link != "" {
+		return fmt.Sprintf("%s (%s)", issue, link)
+	}
+	return issue
+}
+
+// quarantineReason returns the reason given to SkipWithIssue for an issue.
+func quarantineReason(issue string) string {
+	quarantineMu.Lock()
+	defer quarantineMu.Unlock()
+	return quarantineReasons[issue]
+}
+
+// quarantineIssues extracts the issues of SkipWithIssue from spec labels.
+func quarantineIssues(labels []string) []string {
+	var issues []string
+	for _, label := range labels {
+		if issue, ok := strings.CutPrefix(label, quarantineLabelPrefix); ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// quarantineSummary returns the quarantined specs of a report by issue.
+func quarantineSummary(report ginkgo.Report) map[string][]string {
+	specs := map[string][]string{}
+	for _, spec := range report.SpecReports {
+		if spec.State != types.SpecStatePending {
+			continue
+		}
+		for _, issue := range quarantineIssues(spec.Labels()) {
+			specs[issue] = append(specs[issue], spec.FullText())
+		}
+	}
+	return specs
+}
+
+// RegisterQuarantine lists the specs quarantined with SkipWithIssue by issue at the end of the run, so that
+// disabled coverage is not forgotten. diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterQuarantine() bool {
+	ginkgo.ReportAfterSuite("quarantine summary", func(report ginkgo.Report) {
+		specs := quarantineSummary(report)
+		if len(specs) == 0 {
+			return
+		}
+		issues := make([]string, 0, len(specs))
+		for issue := range specs {
+			issues = append(issues, issue)
+		}
+		sort.Strings(issues)
+
+		fmt.Printf("\n\033[33mSpecs quarantined until their issue is fixed (coverage gap):\033[0m\n")
+		for _, issue := range issues {
+			fmt.Printf("  - %s: %d spec(s), %s\n", describeIssue(issue), len(specs[issue]), quarantineReason(issue))
+			for _, spec := range specs[issue] {
+				fmt.Printf("      %s\n", spec)
+			}
+		}
+	})
+
+	return true
+}
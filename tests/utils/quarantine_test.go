@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+func TestSkipWithIssue(t *testing.T) {
+	decorators := SkipWithIssue("CO-4229", "fails on rke2")
+	SkipWithIssue("CO-4229", "leaks a namespace")
+	if len(decorators) != 2 || decorators[0] != ginkgo.Pending {
+		t.Fatalf("Expected the spec marked pending, got %v", decorators)
+	}
+	if labels, ok := decorators[1].(ginkgo.Labels); !ok || len(labels) != 1 || labels[0] != "quarantine:CO-4229" || !IsSpecLabel(labels[0]) {
+		t.Errorf("Expected the issue label, got %v", decorators[1])
+	}
+	if reason := quarantineReason("CO-4229"); reason != "fails on rke2; leaks a namespace" {
+		t.Errorf("Expected both reasons, got %q", reason)
+	}
+}
+
+func TestIssueLink(t *testing.T) {
+	for issue, want := range map[string]string{
+		"#42":      DefaultIssueTrackerURL + "42",
+		"42":       DefaultIssueTrackerURL + "42",
+		"CO-4229":  "",
+		"#42-fail": "",
+	} {
+		if link := IssueLink(issue); link != want {
+			t.Errorf("Expected %q for %s, got %q", want, issue, link)
+		}
+	}
+	t.Setenv(IssueTrackerURLEnvVar, "https://jira.example.com/browse/")
+	if link := IssueLink("CO-4229"); link != "https://jira.example.com/browse/CO-4229" {
+		t.Errorf("Expected a link to the issue tracker, got %q", link)
+	}
+}
+
+func TestQuarantineSummary(t *testing.T) {
+	report := ginkgo.Report{SpecReports: types.SpecReports{
+		{
+			ContainerHierarchyTexts:  []string{"Cluster creation"},
+			ContainerHierarchyLabels: [][]string{{ClusterOrchClusterApiAllTest, "quarantine:#42"}},
+			LeafNodeText:             "should create the cluster",
+			State:                    types.SpecStatePending,
+		},
+		{
+			ContainerHierarchyTexts: []string{"Cluster creation"},
+			LeafNodeText:            "should list the cluster",
+			LeafNodeLabels:          []string{"quarantine:#43"},
+			State:                   types.SpecStatePending,
+		},
+		{LeafNodeText: "pending without an issue", State: types.SpecStatePending},
+		{LeafNodeText: "passed", LeafNodeLabels: []string{ClusterOrchClusterApiAllTest}, State: types.SpecStatePassed},
+	}}
+
+	specs := quarantineSummary(report)
+	if len(specs) != 2 || len(specs["#42"]) != 1 || specs["#42"][0] != "Cluster creation should create the cluster" ||
+		len(specs["#43"]) != 1 {
+		t.Errorf("Expected one spec per issue, got %v", specs)
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const (
+	// RateLimitedEnvVar expects cluster-manager to answer request bursts with 429 when "true".
+	RateLimitedEnvVar = "CLUSTER_MANAGER_RATE_LIMITED"
+	// RateLimitBurstEnvVar is how many list requests a burst sends at once.
+	RateLimitBurstEnvVar  = "RATE_LIMIT_BURST"
+	DefaultRateLimitBurst = 50
+
+	// DefaultMaxRetries and DefaultMaxRetryWait bound how long the retry layer keeps a request waiting.
+	DefaultMaxRetries   = 5
+	DefaultMaxRetryWait = 10 * time.Second
+	// retryBackoff is the first wait of a 429 without a usable Retry-After; it doubles with every retry.
+	retryBackoff = time.Second
+)
+
+// RateLimitExpected reports whether CLUSTER_MANAGER_RATE_LIMITED asks for 429s under bursts.
+func RateLimitExpected() bool {
+	return os.Getenv(RateLimitedEnvVar) == "true"
+}
+
+// RateLimitBurst returns RATE_LIMIT_BURST, or the default when it is unset or invalid.
+func RateLimitBurst() int {
+	if burst, err := strconv.Atoi(os.Getenv(RateLimitBurstEnvVar)); err == nil && burst > 0 {
+		return burst
+	}
+	return DefaultRateLimitBurst
+}
+
+// ParseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP date.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// RetryTransport retries requests answered with 429 Too Many Requests, or 503 Service Unavailable with a
+// Retry-After header, after waiting as long as the server asks. Requests whose body cannot be replayed are
+// not retried.
+type RetryTransport struct {
+	Transport  http.RoundTripper
+	MaxRetries int
+	// MaxWait caps a single wait, so a bogus Retry-After cannot stall a suite.
+	MaxWait time.Duration
+	// sleep is replaced in tests.
+	sleep func(time.Duration)
+}
+
+// NewRetryTransport wraps transport with the default retry limits.
+func NewRetryTransport(transport http.RoundTripper) *RetryTransport {
+	return &RetryTransport{Transport: transport, MaxRetries: DefaultMaxRetries, MaxWait: DefaultMaxRetryWait}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sleep := t.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Transport.RoundTrip(req)
+		if err != nil || attempt >= t.MaxRetries {
+			return resp, err
+		}
+		wait, retry := retryWait(resp, attempt)
+		if !retry || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		if wait > t.MaxWait {
+			wait = t.MaxWait
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		fmt.Printf("%s %s returned %d, retrying in %v\n", req.Method, req.URL, resp.StatusCode, wait)
+		sleep(wait)
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay the request body: %w", err)
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryWait decides whether a response is retried and how long to wait first.
+func retryWait(resp *http.Response, attempt int) (time.Duration, bool) {
+	wait, hasRetryAfter := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests && hasRetryAfter:
+		return wait, true
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return retryBackoff << attempt, true
+	case resp.StatusCode == http.StatusServiceUnavailable && hasRetryAfter:
+		return wait, true
+	}
+	return 0, false
+}
+
+// BurstResult summarizes the responses to a burst of requests.
+type BurstResult struct {
+	// Statuses counts the responses per HTTP status.
+	Statuses map[int]int
+	// RetryAfters are the waits the 429 responses asked for.
+	RetryAfters []time.Duration
+	// MissingRetryAfter counts 429 responses without a valid Retry-After header.
+	MissingRetryAfter int
+	Errors            []string
+	Elapsed           time.Duration
+}
+
+// ServerErrors returns how many responses were 5xx.
+func (r BurstResult) ServerErrors() int {
+	count := 0
+	for status, n := range r.Statuses {
+		if status >= http.StatusInternalServerError {
+			count += n
+		}
+	}
+	return count
+}
+
+func (r BurstResult) String() string {
+	statuses := make([]int, 0, len(r.Statuses))
+	for status := range r.Statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("%d×%d", r.Statuses[status], status))
+	}
+	return fmt.Sprintf("%s in %v, %d errors, %d 429s without Retry-After", strings.Join(parts, " "),
+		r.Elapsed.Round(time.Millisecond), len(r.Errors), r.MissingRetryAfter)
+}
+
+// SendListBurst sends count GET requests to url at once on behalf of a project, with the token of
+// authContext when it is not nil. retry selects whether the requests go through the retry layer.
+func SendListBurst(authContext *auth.TestAuthContext, namespace, url string, count int, retry bool) BurstResult {
+	var transport http.RoundTripper = &TraceContextTransport{Transport: SharedHTTPTransport()}
+	if retry {
+		transport = NewRetryTransport(transport)
+	}
+	if authContext != nil {
+		transport = &AuthTransport{Transport: transport, Token: authContext.Token}
+	}
+	client := &http.Client{Transport: transport, Timeout: 2 * time.Minute}
+
+	result := BurstResult{Statuses: map[int]int{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest(http.MethodGet, url, nil)
+			if err != nil {
+				mu.Lock()
+				result.Errors = append(result.Errors, err.Error())
+				mu.Unlock()
+				return
+			}
+			SetNamespaceHeader(req, namespace)
+			req.Header.Set("Accept", "application/json")
+			resp, err := client.Do(req)
+			if err == nil {
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors = append(result.Errors, err.Error())
+				return
+			}
+			result.Statuses[resp.StatusCode]++
+			if resp.StatusCode == http.StatusTooManyRequests {
+				if wait, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					result.RetryAfters = append(result.RetryAfters, wait)
+				} else {
+					result.MissingRetryAfter++
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+	return result
+}
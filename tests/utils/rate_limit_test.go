@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"3", 3 * time.Second, true},
+		{" 0 ", 0, true},
+		{now.Add(7 * time.Second).Format(http.TimeFormat), 7 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		wait, ok := ParseRetryAfter(tt.value, now)
+		if wait != tt.wait || ok != tt.ok {
+			t.Errorf("Expected %q to give %v/%t, got %v/%t", tt.value, tt.wait, tt.ok, wait, ok)
+		}
+	}
+}
+
+// rateLimitedServer answers the first limited requests with status and the given Retry-After, then echoes
+// the request body.
+func rateLimitedServer(t *testing.T, limited int32, status int, retryAfter string) (*httptest.Server, *int32) {
+	t.Helper()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= limited {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	server, requests := rateLimitedServer(t, 2, http.StatusTooManyRequests, "3")
+	var waits []time.Duration
+	transport := NewRetryTransport(http.DefaultTransport)
+	transport.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, bytes.NewReader([]byte("payload")))
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || string(body) != "payload" {
+		t.Errorf("Expected the replayed body with 200, got %d %q", resp.StatusCode, body)
+	}
+	if *requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", *requests)
+	}
+	if len(waits) != 2 || waits[0] != 3*time.Second || waits[1] != 3*time.Second {
+		t.Errorf("Expected two waits of 3s, got %v", waits)
+	}
+}
+
+func TestRetryTransportBacksOffWithoutRetryAfter(t *testing.T) {
+	server, _ := rateLimitedServer(t, 3, http.StatusTooManyRequests, "")
+	var waits []time.Duration
+	transport := NewRetryTransport(http.DefaultTransport)
+	transport.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second}
+	if len(waits) != len(expected) {
+		t.Fatalf("Expected waits %v, got %v", expected, waits)
+	}
+	for i := range expected {
+		if waits[i] != expected[i] {
+			t.Errorf("Expected waits %v, got %v", expected, waits)
+		}
+	}
+}
+
+func TestRetryTransportLimits(t *testing.T) {
+	server, requests := rateLimitedServer(t, 100, http.StatusTooManyRequests, "3600")
+	var waits []time.Duration
+	transport := &RetryTransport{Transport: http.DefaultTransport, MaxRetries: 2, MaxWait: 5 * time.Second,
+		sleep: func(d time.Duration) { waits = append(waits, d) }}
+
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || *requests != 3 {
+		t.Errorf("Expected the 429 after 3 requests, got %d after %d", resp.StatusCode, *requests)
+	}
+	for _, wait := range waits {
+		if wait != 5*time.Second {
+			t.Errorf("Expected waits capped at 5s, got %v", waits)
+		}
+	}
+}
+
+func TestRetryTransportIgnoresOtherStatuses(t *testing.T) {
+	for _, tt := range []struct {
+		status     int
+		retryAfter string
+	}{
+		{http.StatusServiceUnavailable, ""},
+		{http.StatusInternalServerError, "1"},
+		{http.StatusBadRequest, "1"},
+	} {
+		server, requests := rateLimitedServer(t, 1, tt.status, tt.retryAfter)
+		transport := NewRetryTransport(http.DefaultTransport)
+		transport.sleep = func(time.Duration) { t.Errorf("Expected no retry of %d", tt.status) }
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status || *requests != 1 {
+			t.Errorf("Expected a single %d, got %d after %d requests", tt.status, resp.StatusCode, *requests)
+		}
+	}
+}
+
+func TestRetryTransportRetriesUnavailableWithRetryAfter(t *testing.T) {
+	server, requests := rateLimitedServer(t, 1, http.StatusServiceUnavailable, "1")
+	transport := NewRetryTransport(http.DefaultTransport)
+	transport.sleep = func(time.Duration) {}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || *requests != 2 {
+		t.Errorf("Expected 200 after 2 requests, got %d after %d", resp.StatusCode, *requests)
+	}
+}
+
+func TestSendListBurst(t *testing.T) {
+	server, _ := rateLimitedServer(t, 4, http.StatusTooManyRequests, "1")
+	result := SendListBurst(nil, "project", server.URL, 10, false)
+	if result.Statuses[http.StatusTooManyRequests] != 4 || result.Statuses[http.StatusOK] != 6 {
+		t.Errorf("Expected 4 429s and 6 200s, got %s", result)
+	}
+	if len(result.RetryAfters) != 4 || result.MissingRetryAfter != 0 || result.ServerErrors() != 0 {
+		t.Errorf("Expected 4 valid Retry-After headers, got %s", result)
+	}
+}
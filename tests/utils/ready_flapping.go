@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ReadyFlapLimitEnvVar is how often the Ready condition of a cluster may leave True during a window in which
+	// the cluster is expected to stay connected. By default it may not leave it at all.
+	ReadyFlapLimitEnvVar  = "READY_FLAP_LIMIT"
+	DefaultReadyFlapLimit = 0
+
+	readyCondition = "Ready"
+)
+
+// ReadyFlapLimit returns READY_FLAP_LIMIT, or DefaultReadyFlapLimit.
+func ReadyFlapLimit() int {
+	if limit, err := strconv.Atoi(os.Getenv(ReadyFlapLimitEnvVar)); err == nil && limit >= 0 {
+		return limit
+	}
+	return DefaultReadyFlapLimit
+}
+
+// ReadyFlaps returns the changes of the Ready condition of a Cluster from True to anything else, e.g. an agent
+// reconnecting, that were observed between from and to.
+func ReadyFlaps(entries []TimelineEntry, namespace, clusterName string, from, to time.Time) []TimelineEntry {
+	object := namespace + "/" + clusterName
+	var flaps []TimelineEntry
+	for _, entry := range entries {
+		if entry.Kind != "Cluster" || entry.Object != object || entry.Condition != readyCondition {
+			continue
+		}
+		if entry.At.Before(from) || entry.At.After(to) {
+			continue
+		}
+		if strings.HasPrefix(entry.From, "True") && !strings.HasPrefix(entry.To, "True") {
+			flaps = append(flaps, entry)
+		}
+	}
+	return flaps
+}
+
+// checkReadyFlaps describes flaps beyond limit in a window, nil when there are not that many.
+func checkReadyFlaps(flaps []TimelineEntry, window time.Duration, limit int) error {
+	if len(flaps) <= limit {
+		return nil
+	}
+	lines := make([]string, 0, len(flaps))
+	for _, flap := range flaps {
+		lines = append(lines, flap.String())
+	}
+	return fmt.Errorf("the Ready condition left True %d times in %v, more than the %d allowed by %s:\n  %s",
+		len(flaps), window.Round(time.Second), limit, ReadyFlapLimitEnvVar, strings.Join(lines, "\n  "))
+}
+
+// CheckReadyFlapping fails when the Ready condition of a cluster left True more than READY_FLAP_LIMIT times since
+// since, according to the condition timeline of the running spec. A cluster that ends up Ready can have
+// reconnected in between; this makes those reconnects count. Without a timeline nothing can be checked.
+func CheckReadyFlapping(namespace, clusterName string, since time.Time) error {
+	timeline := CurrentConditionTimeline()
+	if timeline == nil {
+		fmt.Println("No condition timeline is recorded, the Ready condition is not checked for flapping")
+		return nil
+	}
+	now := time.Now()
+	flaps := ReadyFlaps(timeline.Entries(), namespace, clusterName, since, now)
+	if len(flaps) > 0 {
+		fmt.Printf("The Ready condition of %s/%s left True %d times in %v\n", namespace, clusterName, len(flaps),
+			now.Sub(since).Round(time.Second))
+	}
+	return checkReadyFlaps(flaps, now.Sub(since), ReadyFlapLimit())
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadyFlaps(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+	entry := func(minute int, kind, object, condition, from, to string) TimelineEntry {
+		return TimelineEntry{At: start.Add(time.Duration(minute) * time.Minute), Kind: kind, Object: object, Condition: condition, From: from, To: to}
+	}
+	entries := []TimelineEntry{
+		entry(1, "Cluster", "ns/demo", "Ready", "False(WaitingForControlPlane)", "True"),
+		entry(2, "Cluster", "ns/demo", "Ready", "True", "False(SecureTunnelNotEstablished)"),
+		entry(3, "Cluster", "ns/demo", "Ready", "False(SecureTunnelNotEstablished)", "True"),
+		entry(4, "Machine", "ns/demo", "Ready", "True", "False(NodeNotReady)"),
+		entry(5, "Cluster", "ns/other", "Ready", "True", "False(SecureTunnelNotEstablished)"),
+		entry(6, "Cluster", "ns/demo", "v1beta2/Ready", "True", "False(NotReady)"),
+		entry(7, "Cluster", "ns/demo", "Ready", "True", "Unknown"),
+		entry(20, "Cluster", "ns/demo", "Ready", "True", "False(SecureTunnelNotEstablished)"),
+	}
+
+	flaps := ReadyFlaps(entries, "ns", "demo", start, start.Add(10*time.Minute))
+	if len(flaps) != 2 || !flaps[0].At.Equal(start.Add(2*time.Minute)) || flaps[1].To != "Unknown" {
+		t.Errorf("Expected the Ready condition of ns/demo to leave True at minutes 2 and 7, got %v", flaps)
+	}
+}
+
+func TestCheckReadyFlaps(t *testing.T) {
+	flaps := []TimelineEntry{
+		{At: time.Date(2026, 1, 2, 3, 2, 0, 0, time.UTC), Kind: "Cluster", Object: "ns/demo", Condition: "Ready", From: "True", To: "False"},
+		{At: time.Date(2026, 1, 2, 3, 7, 0, 0, time.UTC), Kind: "Cluster", Object: "ns/demo", Condition: "Ready", From: "True", To: "Unknown"},
+	}
+	if err := checkReadyFlaps(flaps, 10*time.Minute, 2); err != nil {
+		t.Errorf("Expected 2 flaps to be within a limit of 2, got %v", err)
+	}
+	err := checkReadyFlaps(flaps, 10*time.Minute, 1)
+	if err == nil || !strings.Contains(err.Error(), "2 times in 10m0s") || !strings.Contains(err.Error(), "03:07:00") {
+		t.Errorf("Expected an error listing both flaps, got %v", err)
+	}
+}
+
+func TestReadyFlapLimit(t *testing.T) {
+	for value, expected := range map[string]int{"": DefaultReadyFlapLimit, "3": 3, "0": 0, "-1": DefaultReadyFlapLimit, "x": DefaultReadyFlapLimit} {
+		t.Setenv(ReadyFlapLimitEnvVar, value)
+		if limit := ReadyFlapLimit(); limit != expected {
+			t.Errorf("Expected %d for %s=%q, got %d", expected, ReadyFlapLimitEnvVar, value, limit)
+		}
+	}
+}
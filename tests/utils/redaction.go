@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the secrets removed from logs and artifacts.
+const Redacted = "REDACTED"
+
+// redactionRule replaces the secret matched by pattern. Applying a rule to its own output changes nothing,
+// which is how ScanForSecrets tells a leaked secret from a redacted one.
+type redactionRule struct {
+	kind        string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+var redactionRules = []redactionRule{
+	{"private key", regexp.MustCompile(`(?s)-----BEGIN [A-Z ]*PRIVATE KEY-----.*?-----END [A-Z ]*PRIVATE KEY-----`), "[" + Redacted + " PRIVATE KEY]"},
+	{"bearer token", regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/-]+=*`), "${1}" + Redacted},
+	{"JWT", regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`), Redacted},
+	// Values stop at a backslash so that an escaped newline of a JSON string is not swallowed.
+	{"credential field", regexp.MustCompile(`(?i)\b(client-key-data|token|password|passwd|client-secret)(["']?\s*[:=]\s*["']?)[^\s"',}\]\\]+`), "${1}${2}" + Redacted},
+}
+
+// Redact replaces the private keys, JWTs, bearer tokens and credential fields of kubeconfigs and
+// configuration files in data.
+func Redact(data []byte) []byte {
+	return []byte(RedactString(string(data)))
+}
+
+// RedactString is Redact for strings.
+func RedactString(s string) string {
+	for _, rule := range redactionRules {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+// WriteArtifact writes data to path with its secrets redacted.
+func WriteArtifact(path string, data []byte) error {
+	return os.WriteFile(path, Redact(data), 0o644)
+}
+
+// SecretFinding is a secret left unredacted in a file.
+type SecretFinding struct {
+	Path string
+	Line int
+	Kind string
+}
+
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("%s:%d: %s", f.Path, f.Line, f.Kind)
+}
+
+// findSecrets returns the unredacted secrets of content, in the order they appear. A secret matched by
+// several rules, like the JWT of a bearer token, is reported once.
+func findSecrets(path, content string) []SecretFinding {
+	type leak struct {
+		start, end int
+		kind       string
+	}
+	var leaks []leak
+	for _, rule := range redactionRules {
+	matches:
+		for _, match := range rule.pattern.FindAllStringSubmatchIndex(content, -1) {
+			if content[match[0]:match[1]] == string(rule.pattern.ExpandString(nil, rule.replacement, content, match)) {
+				continue
+			}
+			for _, l := range leaks {
+				if match[0] < l.end && l.start < match[1] {
+					continue matches
+				}
+			}
+			leaks = append(leaks, leak{start: match[0], end: match[1], kind: rule.kind})
+		}
+	}
+	sort.Slice(leaks, func(i, j int) bool { return leaks[i].start < leaks[j].start })
+
+	findings := make([]SecretFinding, 0, len(leaks))
+	for _, l := range leaks {
+		findings = append(findings, SecretFinding{Path: path, Line: strings.Count(content[:l.start], "\n") + 1, Kind: l.kind})
+	}
+	return findings
+}
+
+// ScanForSecrets walks dir and returns the secrets its files leak unredacted. A dir that does not exist has
+// no findings.
+func ScanForSecrets(dir string) ([]SecretFinding, error) {
+	var findings []SecretFinding
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, findSecrets(path, string(data))...)
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return findings, err
+}
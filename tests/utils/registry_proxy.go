@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+const (
+	// ThrottledRegistryEnvVar enables the slow registry mode: image pulls of the edge node go through a
+	// bandwidth-limited pull-through registry proxy running next to the kind cluster.
+	ThrottledRegistryEnvVar = "THROTTLED_REGISTRY"
+	// ThrottledRegistryRateEnvVar is the bandwidth of the proxy in tc units (e.g. "4mbit").
+	ThrottledRegistryRateEnvVar = "THROTTLED_REGISTRY_RATE"
+	// ThrottledRegistryUpstreamEnvVar is the registry the proxy pulls from.
+	ThrottledRegistryUpstreamEnvVar = "THROTTLED_REGISTRY_UPSTREAM"
+
+	DefaultThrottledRegistryRate     = "4mbit"
+	DefaultThrottledRegistryUpstream = "https://registry-1.docker.io"
+	ThrottledRegistryPort            = "5000"
+
+	registryProxyContainer = "cluster-tests-registry-proxy"
+	registryProxyImage     = "registry:2"
+	// registryProxyToolsImage provides tc to shape the traffic of the proxy container.
+	registryProxyToolsImage = "nicolaka/netshoot"
+)
+
+// edgeNodeRegistriesFiles are the registry configurations k3s and rke2 read when they start.
+var edgeNodeRegistriesFiles = []string{"/etc/rancher/k3s/registries.yaml", "/etc/rancher/rke2/registries.yaml"}
+
+// ThrottledRegistryEnabled reports whether the tests run in slow registry mode.
+func ThrottledRegistryEnabled() bool {
+	return os.Getenv(ThrottledRegistryEnvVar) == "true"
+}
+
+// StartThrottledRegistryProxy (re)starts the pull-through registry proxy and limits the bandwidth
+// it serves images with to rate.
+func StartThrottledRegistryProxy(rate string) error {
+	_ = RunCommand(exec.Command("docker", "rm", "-f", registryProxyContainer))
+
+	upstream := GetEnv(ThrottledRegistryUpstreamEnvVar, DefaultThrottledRegistryUpstream)
+	out, err := CommandCombinedOutput(exec.Command("docker", "run", "-d", "--name", registryProxyContainer,
+		"-p", ThrottledRegistryPort+":5000",
+		"-e", "REGISTRY_PROXY_REMOTEURL="+upstream,
+		registryProxyImage))
+	if err != nil {
+		return fmt.Errorf("failed to start registry proxy: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	out, err = CommandCombinedOutput(exec.Command("docker", "run", "--rm", "--net", "container:"+registryProxyContainer, "--cap-add", "NET_ADMIN",
+		registryProxyToolsImage, "tc", "qdisc", "replace", "dev", "eth0", "root", "tbf",
+		"rate", rate, "burst", "32kbit", "latency", "400ms"))
+	if err != nil {
+		return fmt.Errorf("failed to throttle registry proxy to %s: %w: %s", rate, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// StopThrottledRegistryProxy removes the registry proxy container.
+func StopThrottledRegistryProxy() error {
+	if out, err := CommandCombinedOutput(exec.Command("docker", "rm", "-f", registryProxyContainer)); err != nil {
+		return fmt.Errorf("failed to remove registry proxy: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ThrottledRegistryBlobPulls returns how many image blobs the registry proxy has served.
+func ThrottledRegistryBlobPulls() (int, error) {
+	out, err := CommandCombinedOutput(exec.Command("docker", "logs", registryProxyContainer))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read registry proxy logs: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return countBlobPulls(string(out)), nil
+}
+
+func countBlobPulls(logs string) int {
+	count := 0
+	for _, line := range strings.Split(logs, "\n") {
+		if strings.Contains(line, "GET /v2/") && strings.Contains(line, "/blobs/sha256:") {
+			count++
+		}
+	}
+	return count
+}
+
+// EdgeNodeSSHClientAddress returns the address the edge node sees the test host connecting from,
+// which is where it can reach the registry proxy.
+func EdgeNodeSSHClientAddress() (string, error) {
+	out, err := ExecOnEdgeNode(`echo "${SSH_CLIENT%% *}"`)
+	if err != nil {
+		return "", err
+	}
+	addr := strings.TrimSpace(string(out))
+	if addr == "" {
+		return "", fmt.Errorf("edge node did not report the SSH client address")
+	}
+	return addr, nil
+}
+
+// ConfigureEdgeNodeRegistryMirror makes k3s and rke2 on the edge node pull docker.io images through
+// endpoint. Existing registry configurations are kept aside and put back by RemoveEdgeNodeRegistryMirror.
+func ConfigureEdgeNodeRegistryMirror(endpoint string) error {
+	config := renderRegistriesConfig(endpoint)
+	var script []string
+	script = append(script, "set -e", strings.TrimSuffix(edgeNodeSudoPreamble, "\n"))
+	for _, file := range edgeNodeRegistriesFiles {
+		script = append(script,
+			fmt.Sprintf(`$SUDO mkdir -p "$(dirname %s)"`, file),
+			fmt.Sprintf(`if [ -f %[1]s ] && [ ! -f %[1]s.cluster-tests.bak ]; then $SUDO mv %[1]s %[1]s.cluster-tests.bak; fi`, file),
+			fmt.Sprintf("printf '%%s' '%s' | $SUDO tee %s >/dev/null", config, file))
+	}
+	if _, err := ExecOnEdgeNode(strings.Join(script, "\n")); err != nil {
+		return fmt.Errorf("failed to configure registry mirror on the edge node: %w", err)
+	}
+	return nil
+}
+
+// RemoveEdgeNodeRegistryMirror undoes ConfigureEdgeNodeRegistryMirror.
+func RemoveEdgeNodeRegistryMirror() error {
+	var script []string
+	script = append(script, strings.TrimSuffix(edgeNodeSudoPreamble, "\n"))
+	for _, file := range edgeNodeRegistriesFiles {
+		script = append(script, fmt.Sprintf(
+			`$SUDO rm -f %[1]s; if [ -f %[1]s.cluster-tests.bak ]; then $SUDO mv %[1]s.cluster-tests.bak %[1]s; fi`, file))
+	}
+	if _, err := ExecOnEdgeNode(strings.Join(script, "\n")); err != nil {
+		return fmt.Errorf("failed to remove registry mirror from the edge node: %w", err)
+	}
+	return nil
+}
+
+func renderRegistriesConfig(endpoint string) string {
+	return fmt.Sprintf(`mirrors:
+  docker.io:
+    endpoint:
+      - "%s"
+`, endpoint)
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderRegistriesConfig(t *testing.T) {
+	var config struct {
+		Mirrors map[string]struct {
+			Endpoint []string `yaml:"endpoint"`
+		} `yaml:"mirrors"`
+	}
+	if err := yaml.Unmarshal([]byte(renderRegistriesConfig("http://10.0.0.1:5000")), &config); err != nil {
+		t.Fatalf("Expected valid YAML, got %v", err)
+	}
+
+	endpoints := config.Mirrors["docker.io"].Endpoint
+	if len(endpoints) != 1 || endpoints[0] != "http://10.0.0.1:5000" {
+		t.Errorf("Expected docker.io mirrored to the proxy, got %v", endpoints)
+	}
+}
+
+func TestCountBlobPulls(t *testing.T) {
+	logs := `time="..." level=info msg="response completed" http.request.method=GET http.request.uri="/v2/rancher/k3s/manifests/v1.30.0"
+10.0.0.2 - - [16/Oct/2026:10:00:00 +0000] "GET /v2/rancher/k3s/blobs/sha256:abc HTTP/1.1" 200 1024 "" "containerd/1.7"
+10.0.0.2 - - [16/Oct/2026:10:00:01 +0000] "HEAD /v2/rancher/k3s/blobs/sha256:def HTTP/1.1" 200 0 "" "containerd/1.7"
+10.0.0.2 - - [16/Oct/2026:10:00:02 +0000] "GET /v2/rancher/pause/blobs/sha256:123 HTTP/1.1" 200 2048 "" "containerd/1.7"`
+
+	if got := countBlobPulls(logs); got != 2 {
+		t.Errorf("Expected 2 blob pulls, got %d", got)
+	}
+}
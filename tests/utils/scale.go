@@ -0,0 +1,253 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+const (
+	// ScaleClusterCountEnvVar is the number of clusters the scale suite creates at once. Setting it makes
+	// mage test:bootstrap deploy as many simulated edge nodes, see DeploySimulatedEdgeNodes.
+	ScaleClusterCountEnvVar = "SCALE_CLUSTER_COUNT"
+	// ScaleNodeGUIDsEnvVar lists the GUIDs of the edge nodes the clusters are created on, comma-separated.
+	// mage test:bootstrap writes those of the simulated edge nodes to .scale.env.
+	ScaleNodeGUIDsEnvVar = "SCALE_NODE_GUIDS"
+	// ScaleReadyP95EnvVar is the highest p95 time-to-ready the scale suite accepts (e.g. "15m").
+	ScaleReadyP95EnvVar = "SCALE_READY_P95"
+	// ScaleReportDirEnvVar selects where scale reports are written; defaults to the working directory.
+	ScaleReportDirEnvVar = "SCALE_REPORT_DIR"
+
+	DefaultScaleClusterCount = 3
+	DefaultScaleReadyP95     = 15 * time.Minute
+
+	// SimulatedEdgeNodeImageEnvVar is the ENiC (edge node in a container) image running cluster-agent.
+	SimulatedEdgeNodeImageEnvVar = "ENIC_IMAGE"
+	// SimulatedEdgeNodeEntrypointEnvVar is the command of the image that starts cluster-agent, run with the
+	// node GUID in NODE_GUID.
+	SimulatedEdgeNodeEntrypointEnvVar  = "ENIC_ENTRYPOINT"
+	DefaultSimulatedEdgeNodeEntrypoint = "/entrypoint.sh"
+	SimulatedEdgeNodeNamespace         = "enic"
+	SimulatedEdgeNodeStatefulSet       = "enic"
+
+	// ScaleClusterPrefix prefixes the names of the clusters of CreateClusterN.
+	ScaleClusterPrefix = "scale-cluster-"
+
+	// simulatedEdgeNodeGUIDPrefix and the pod ordinal in hex make the GUID of a simulated edge node.
+	simulatedEdgeNodeGUIDPrefix = "e41c0000-0000-4000-8000-"
+	simulatedEdgeNodeRollout    = 10 * time.Minute
+	scaleLatencyPercentile      = 0.95
+)
+
+// ScaleClusterCount returns SCALE_CLUSTER_COUNT, or the default when it is unset or invalid.
+func ScaleClusterCount() int {
+	if count, err := strconv.Atoi(os.Getenv(ScaleClusterCountEnvVar)); err == nil && count > 0 {
+		return count
+	}
+	return DefaultScaleClusterCount
+}
+
+// ScaleReadyP95 returns SCALE_READY_P95, or the default when it is unset or invalid.
+func ScaleReadyP95() time.Duration {
+	if threshold, err := time.ParseDuration(os.Getenv(ScaleReadyP95EnvVar)); err == nil && threshold > 0 {
+		return threshold
+	}
+	return DefaultScaleReadyP95
+}
+
+// ScaleNodeGUIDs returns the first count GUIDs of SCALE_NODE_GUIDS, one edge node per cluster.
+func ScaleNodeGUIDs(count int) ([]string, error) {
+	guids := splitList(os.Getenv(ScaleNodeGUIDsEnvVar))
+	if len(guids) < count {
+		return nil, fmt.Errorf("%s lists %d edge nodes, %d clusters need %d; run mage test:bootstrap with %s=%d",
+			ScaleNodeGUIDsEnvVar, len(guids), count, count, ScaleClusterCountEnvVar, count)
+	}
+	return guids[:count], nil
+}
+
+// SimulatedEdgeNodeGUID returns the GUID of the simulated edge node with the given StatefulSet ordinal.
+func SimulatedEdgeNodeGUID(ordinal int) string {
+	return fmt.Sprintf("%s%012x", simulatedEdgeNodeGUIDPrefix, ordinal)
+}
+
+// renderSimulatedEdgeNodes renders the StatefulSet of replicas simulated edge nodes. Every pod derives its
+// GUID from its ordinal, the suffix of its hostname, as SimulatedEdgeNodeGUID does.
+func renderSimulatedEdgeNodes(image, entrypoint string, replicas int) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Service
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+spec:
+  clusterIP: None
+  selector:
+    app: %[1]s
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+  labels:
+    app: %[1]s
+spec:
+  serviceName: %[1]s
+  replicas: %[3]d
+  podManagementPolicy: Parallel
+  selector:
+    matchLabels:
+      app: %[1]s
+  template:
+    metadata:
+      labels:
+        app: %[1]s
+    spec:
+      containers:
+        - name: cluster-agent
+          image: %[4]s
+          command:
+            - /bin/sh
+            - -c
+            - export NODE_GUID=$(printf '%[5]s%%012x' "${HOSTNAME##*-}") && exec %[6]s
+          securityContext:
+            privileged: true
+`, SimulatedEdgeNodeStatefulSet, SimulatedEdgeNodeNamespace, replicas, image, simulatedEdgeNodeGUIDPrefix, entrypoint)
+}
+
+// DeploySimulatedEdgeNodes runs replicas simulated edge nodes in the management cluster, a StatefulSet of
+// the ENIC_IMAGE cluster-agent, and returns their GUIDs once all replicas are ready.
+func DeploySimulatedEdgeNodes(replicas int) ([]string, error) {
+	image := strings.TrimSpace(os.Getenv(SimulatedEdgeNodeImageEnvVar))
+	if image == "" {
+		return nil, fmt.Errorf("%s must name the simulated edge node image when %s is set",
+			SimulatedEdgeNodeImageEnvVar, ScaleClusterCountEnvVar)
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	if err := client.EnsureNamespace(SimulatedEdgeNodeNamespace); err != nil {
+		return nil, err
+	}
+	if err := client.ApplyManifest(renderSimulatedEdgeNodes(image,
+		GetEnv(SimulatedEdgeNodeEntrypointEnvVar, DefaultSimulatedEdgeNodeEntrypoint), replicas)); err != nil {
+		return nil, fmt.Errorf("failed to apply the simulated edge nodes: %w", err)
+	}
+	if err := client.WaitForRollout(SimulatedEdgeNodeNamespace, "StatefulSet", SimulatedEdgeNodeStatefulSet,
+		simulatedEdgeNodeRollout); err != nil {
+		return nil, fmt.Errorf("the simulated edge nodes are not ready: %w", err)
+	}
+
+	guids := make([]string, replicas)
+	for i := range guids {
+		guids[i] = SimulatedEdgeNodeGUID(i)
+	}
+	return guids, nil
+}
+
+// ClusterCreation is the outcome of one cluster of CreateClusterN.
+type ClusterCreation struct {
+	Name     string `json:"name"`
+	NodeGUID string `json:"nodeGUID"`
+	// TimeToReady runs from the create request until ready returned.
+	TimeToReady time.Duration `json:"timeToReadyNs"`
+	Error       string        `json:"error,omitempty"`
+}
+
+// CreateClusterN creates a single node cluster from templateName on each of nodeGUIDs at once, with the
+// token of authContext when it is not nil, and waits with ready for every cluster to be ready. The clusters
+// are named ScaleClusterPrefix and their index.
+func CreateClusterN(authContext *auth.TestAuthContext, namespace, templateName string, nodeGUIDs []string,
+	ready func(clusterName string) error) []ClusterCreation {
+	creations := make([]ClusterCreation, len(nodeGUIDs))
+	var wg sync.WaitGroup
+	for i, guid := range nodeGUIDs {
+		wg.Add(1)
+		go func(i int, guid string) {
+			defer wg.Done()
+			creation := ClusterCreation{Name: fmt.Sprintf("%s%d", ScaleClusterPrefix, i), NodeGUID: guid}
+			start := time.Now()
+			err := CreateMultiNodeCluster(authContext, namespace, creation.Name, []api.NodeSpec{{Id: guid, Role: api.All}},
+				templateName, ClusterConfigOptions{})
+			if err == nil {
+				err = ready(creation.Name)
+			}
+			creation.TimeToReady = time.Since(start)
+			if err != nil {
+				creation.Error = err.Error()
+			}
+			creations[i] = creation
+		}(i, guid)
+	}
+	wg.Wait()
+	return creations
+}
+
+// ScaleReport summarizes the time-to-ready of the clusters of CreateClusterN.
+type ScaleReport struct {
+	Clusters []ClusterCreation `json:"clusters"`
+	Failures int               `json:"failures"`
+	// P50, P95 and Max are over the clusters that became ready.
+	P50 time.Duration `json:"p50Ns"`
+	P95 time.Duration `json:"p95Ns"`
+	Max time.Duration `json:"maxNs"`
+}
+
+// NewScaleReport summarizes creations.
+func NewScaleReport(creations []ClusterCreation) *ScaleReport {
+	report := &ScaleReport{Clusters: creations}
+	var sorted []time.Duration
+	for _, creation := range creations {
+		if creation.Error != "" {
+			report.Failures++
+			continue
+		}
+		sorted = append(sorted, creation.TimeToReady)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	if len(sorted) > 0 {
+		report.P50 = sorted[(len(sorted)-1)/2]
+		report.P95 = sorted[int(float64(len(sorted)-1)*scaleLatencyPercentile)]
+		report.Max = sorted[len(sorted)-1]
+	}
+	return report
+}
+
+// Print writes a human readable summary of the run to stdout.
+func (r *ScaleReport) Print() {
+	fmt.Printf("Scale: %d clusters, %d failed, time-to-ready p50=%v p95=%v max=%v\n", len(r.Clusters), r.Failures,
+		r.P50.Round(time.Second), r.P95.Round(time.Second), r.Max.Round(time.Second))
+	for _, creation := range r.Clusters {
+		if creation.Error != "" {
+			fmt.Printf("  %s on %s failed after %v: %s\n", creation.Name, creation.NodeGUID,
+				creation.TimeToReady.Round(time.Second), creation.Error)
+			continue
+		}
+		fmt.Printf("  %s on %s ready after %v\n", creation.Name, creation.NodeGUID, creation.TimeToReady.Round(time.Second))
+	}
+}
+
+// WriteReport writes the report as JSON to SCALE_REPORT_DIR and returns its path.
+func (r *ScaleReport) WriteReport() (string, error) {
+	data, err := MarshalReport(r)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(GetEnv(ScaleReportDirEnvVar, "."), fmt.Sprintf("scale-%d-clusters.json", len(r.Clusters)))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write scale report %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestScaleNodeGUIDs(t *testing.T) {
+	t.Setenv(ScaleNodeGUIDsEnvVar, "a1b2, c3d4,e5f6")
+	guids, err := ScaleNodeGUIDs(2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(guids, ",") != "a1b2,c3d4" {
+		t.Errorf("Expected the first two GUIDs, got %v", guids)
+	}
+	if _, err := ScaleNodeGUIDs(4); err == nil {
+		t.Error("Expected an error for more clusters than edge nodes")
+	}
+}
+
+func TestSimulatedEdgeNodeGUID(t *testing.T) {
+	if guid := SimulatedEdgeNodeGUID(26); guid != "e41c0000-0000-4000-8000-00000000001a" {
+		t.Errorf("Expected the ordinal in hex as the last group, got %s", guid)
+	}
+}
+
+func TestRenderSimulatedEdgeNodes(t *testing.T) {
+	manifest := renderSimulatedEdgeNodes("enic:test", "/start", 5)
+	docs := strings.Split(manifest, "\n---\n")
+	if len(docs) != 2 {
+		t.Fatalf("Expected a Service and a StatefulSet, got %d documents", len(docs))
+	}
+	var statefulSet struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			Replicas int `yaml:"replicas"`
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						Image   string   `yaml:"image"`
+						Command []string `yaml:"command"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &statefulSet); err != nil {
+		t.Fatalf("Expected valid YAML, got %v\n%s", err, docs[1])
+	}
+	if statefulSet.Kind != "StatefulSet" || statefulSet.Spec.Replicas != 5 {
+		t.Errorf("Expected a StatefulSet with 5 replicas, got %+v", statefulSet)
+	}
+	containers := statefulSet.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Image != "enic:test" {
+		t.Fatalf("Expected one enic:test container, got %+v", containers)
+	}
+	script := containers[0].Command[len(containers[0].Command)-1]
+	if !strings.Contains(script, "printf '"+simulatedEdgeNodeGUIDPrefix+"%012x'") || !strings.HasSuffix(script, "exec /start") {
+		t.Errorf("Expected the GUID to be derived from the ordinal before starting the agent, got %s", script)
+	}
+}
+
+func TestNewScaleReport(t *testing.T) {
+	var creations []ClusterCreation
+	for i := 1; i <= 20; i++ {
+		creations = append(creations, ClusterCreation{Name: "c", TimeToReady: time.Duration(i) * time.Minute})
+	}
+	creations = append(creations, ClusterCreation{Name: "failed", TimeToReady: time.Hour, Error: "timed out"})
+
+	report := NewScaleReport(creations)
+	if report.Failures != 1 {
+		t.Errorf("Expected one failure, got %d", report.Failures)
+	}
+	if report.P50 != 10*time.Minute || report.P95 != 19*time.Minute || report.Max != 20*time.Minute {
+		t.Errorf("Expected p50=10m p95=19m max=20m over the ready clusters, got %+v", report)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+)
+
+var (
+	sharedClustersMu sync.Mutex
+	sharedClusters   []*SharedCluster
+)
+
+// SharedCluster is a cluster created once per suite run for every container that uses it, rather than once
+// per container or spec. Consumers Acquire it in a BeforeAll and Release it in the matching AfterAll; the
+// cluster is deleted at the end of the suite by RegisterSharedClusters.
+type SharedCluster struct {
+	Name   string
+	create func() error
+	remove func() error
+
+	mu        sync.Mutex
+	refs      int
+	acquired  int
+	attempted bool
+	createErr error
+}
+
+// NewSharedCluster returns a shared cluster that create creates and remove deletes, both waiting for as long
+// as their consumers need. The cluster is only created by the first Acquire.
+func NewSharedCluster(name string, create, remove func() error) *SharedCluster {
+	cluster := &SharedCluster{Name: name, create: create, remove: remove}
+	sharedClustersMu.Lock()
+	defer sharedClustersMu.Unlock()
+	sharedClusters = append(sharedClusters, cluster)
+	return cluster
+}
+
+// Acquire creates the cluster for the first consumer and counts the consumer. A cluster that failed to be
+// created is not created again; its later consumers get the same error.
+func (c *SharedCluster) Acquire() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.attempted {
+		c.attempted = true
+		c.createErr = c.create()
+		if c.createErr == nil {
+			fmt.Printf("Created shared cluster %s\n", c.Name)
+		}
+	}
+	if c.createErr != nil {
+		return fmt.Errorf("failed to create shared cluster %s: %w", c.Name, c.createErr)
+	}
+	c.refs++
+	c.acquired++
+	return nil
+}
+
+// Release stops counting a consumer of Acquire. The cluster stays until the end of the suite, for the
+// consumers still to come.
+func (c *SharedCluster) Release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refs > 0 {
+		c.refs--
+	}
+}
+
+// Consumers returns the number of consumers that acquired the cluster and have not released it yet.
+func (c *SharedCluster) Consumers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refs
+}
+
+// Teardown deletes the cluster if its creation was attempted, even partly, unless SKIP_DELETE_CLUSTER is set.
+func (c *SharedCluster) Teardown() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.attempted {
+		return nil
+	}
+	if c.refs > 0 {
+		fmt.Printf("Shared cluster %s is still acquired by %d consumer(s) at the end of the suite\n", c.Name, c.refs)
+	}
+	fmt.Printf("Shared cluster %s was used by %d consumer(s)\n", c.Name, c.acquired)
+	c.attempted, c.refs = false, 0
+	if SkipDeleteCluster {
+		fmt.Printf("Keeping shared cluster %s: SKIP_DELETE_CLUSTER is set\n", c.Name)
+		return nil
+	}
+	if err := c.remove(); err != nil {
+		return fmt.Errorf("failed to delete shared cluster %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// RegisterSharedClusters deletes the clusters of NewSharedCluster once every spec of the suite has run. Call
+// it from a suite file as `var _ = utils.RegisterSharedClusters()`.
+func RegisterSharedClusters() bool {
+	ginkgo.AfterSuite(func() {
+		sharedClustersMu.Lock()
+		clusters := append([]*SharedCluster(nil), sharedClusters...)
+		sharedClustersMu.Unlock()
+
+		for _, cluster := range clusters {
+			ginkgo.By("Deleting shared cluster " + cluster.Name)
+			if err := cluster.Teardown(); err != nil {
+				ginkgo.Fail(err.Error())
+			}
+		}
+	})
+
+	return true
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSharedClusterCreatesOnce(t *testing.T) {
+	created, removed := 0, 0
+	cluster := NewSharedCluster("shared", func() error { created++; return nil }, func() error { removed++; return nil })
+
+	for i := 0; i < 2; i++ {
+		if err := cluster.Acquire(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		cluster.Release()
+	}
+	if err := cluster.Acquire(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created != 1 || cluster.Consumers() != 1 {
+		t.Errorf("Expected one creation and one consumer, got %d and %d", created, cluster.Consumers())
+	}
+
+	if err := cluster.Teardown(); err != nil || removed != 1 {
+		t.Errorf("Expected the cluster deleted once, got %d, %v", removed, err)
+	}
+	if err := cluster.Teardown(); err != nil || removed != 1 {
+		t.Errorf("Expected a deleted cluster not to be deleted again, got %d, %v", removed, err)
+	}
+}
+
+func TestSharedClusterCreationFailure(t *testing.T) {
+	created, removed := 0, 0
+	cluster := NewSharedCluster("broken", func() error { created++; return errors.New("conflict") }, func() error { removed++; return nil })
+
+	for i := 0; i < 2; i++ {
+		if err := cluster.Acquire(); err == nil {
+			t.Errorf("Expected the creation error for consumer %d", i)
+		}
+	}
+	if created != 1 || cluster.Consumers() != 0 {
+		t.Errorf("Expected a single creation attempt and no consumer, got %d and %d", created, cluster.Consumers())
+	}
+	if err := cluster.Teardown(); err != nil || removed != 1 {
+		t.Errorf("Expected a partly created cluster to be deleted, got %d, %v", removed, err)
+	}
+}
+
+func TestSharedClusterNotAcquired(t *testing.T) {
+	cluster := NewSharedCluster("unused", func() error { return nil }, func() error { return errors.New("not found") })
+	if err := cluster.Teardown(); err != nil {
+		t.Errorf("Expected a cluster that was never acquired to be left alone, got %v", err)
+	}
+}
@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+// StatusField names the part of the cluster status a transition belongs to.
+type StatusField string
+
+const (
+	FieldLifecyclePhase StatusField = "lifecyclePhase"
+	FieldProviderStatus StatusField = "providerStatus"
+
+	// StatusNotObserved is the state before the first observation of a field.
+	StatusNotObserved = ""
+	// StatusDeleted is recorded for every field once cluster-manager no longer knows the cluster.
+	StatusDeleted = "deleted"
+
+	// StatusFlapWindow is how quickly a status has to bounce back and forth to count as flapping.
+	StatusFlapWindow = 2 * time.Minute
+)
+
+// Lifecycle phases are the messages cluster-manager derives from the CAPI Cluster phase.
+const (
+	LifecycleConditionNotFound = "Condition not found"
+	LifecycleWaitingForNodes   = "waiting for nodes"
+	LifecyclePending           = "pending"
+	LifecycleProvisioning      = "provisioning"
+	LifecycleProvisioned       = "provisioned"
+	LifecycleActive            = "active"
+	LifecycleFailed            = "failed"
+	LifecycleUnknown           = "unknown"
+	LifecycleDeleting          = "deleting"
+)
+
+// lifecycleTransitions is the documented lifecycle state machine. Polling can miss short-lived phases,
+// so forward skips are allowed; the only way back is provisioned <-> active, which follows the Ready
+// condition of the cluster (e.g. the connect agent disconnecting and reconnecting).
+var lifecycleTransitions = map[string][]string{
+	LifecycleConditionNotFound: {LifecycleWaitingForNodes, LifecyclePending, LifecycleProvisioning, LifecycleProvisioned, LifecycleActive, LifecycleDeleting, StatusDeleted},
+	LifecycleWaitingForNodes:   {LifecyclePending, LifecycleProvisioning, LifecycleProvisioned, LifecycleActive, LifecycleDeleting, StatusDeleted},
+	LifecyclePending:           {LifecycleProvisioning, LifecycleProvisioned, LifecycleActive, LifecycleFailed, LifecycleDeleting, StatusDeleted},
+	LifecycleProvisioning:      {LifecycleProvisioned, LifecycleActive, LifecycleFailed, LifecycleDeleting, StatusDeleted},
+	LifecycleProvisioned:       {LifecycleActive, LifecycleFailed, LifecycleDeleting, StatusDeleted},
+	LifecycleActive:            {LifecycleProvisioned, LifecycleFailed, LifecycleDeleting, StatusDeleted},
+	LifecycleFailed:            {LifecycleProvisioned, LifecycleActive, LifecycleDeleting, StatusDeleted},
+	LifecycleDeleting:          {StatusDeleted},
+}
+
+// StatusTransition is one observed change of a status field.
+type StatusTransition struct {
+	At    time.Time   `json:"at"`
+	Field StatusField `json:"field"`
+	From  string      `json:"from"`
+	To    string      `json:"to"`
+	// Message is the full status message of the new state, e.g. the reason a cluster is not ready.
+	Message string `json:"message,omitempty"`
+}
+
+func (t StatusTransition) String() string {
+	from := t.From
+	if from == StatusNotObserved {
+		from = "(none)"
+	}
+	return fmt.Sprintf("%s %s: %s -> %s", t.At.Format(time.TimeOnly), t.Field, from, t.To)
+}
+
+// LifecycleTransitionAllowed reports whether the lifecycle phase may change from one phase to another.
+func LifecycleTransitionAllowed(from, to string) bool {
+	if from == StatusNotObserved || from == LifecycleUnknown || to == LifecycleUnknown {
+		return true
+	}
+	next, ok := lifecycleTransitions[from]
+	if !ok {
+		return false
+	}
+	for _, phase := range next {
+		if phase == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ProviderStatusTransitionAllowed reports whether the providerStatus indicator may change from one
+// indicator to another. Ready, not ready and disconnected may follow each other in any order, but
+// once the Ready condition exists it never goes back to unspecified.
+func ProviderStatusTransitionAllowed(from, to string) bool {
+	switch {
+	case from == StatusDeleted:
+		return false
+	case from == StatusNotObserved || from == string(api.STATUSINDICATIONUNSPECIFIED):
+		return true
+	default:
+		return to != string(api.STATUSINDICATIONUNSPECIFIED)
+	}
+}
+
+// CheckStatusTransitions returns a description of every illegal transition and every status that
+// flapped, i.e. went A -> B -> A -> B within flapWindow.
+func CheckStatusTransitions(transitions []StatusTransition, flapWindow time.Duration) []string {
+	var problems []string
+	byField := map[StatusField][]StatusTransition{}
+	for _, t := range transitions {
+		allowed := true
+		switch t.Field {
+		case FieldLifecyclePhase:
+			allowed = LifecycleTransitionAllowed(t.From, t.To)
+		case FieldProviderStatus:
+			allowed = ProviderStatusTransitionAllowed(t.From, t.To)
+		}
+		if !allowed {
+			problems = append(problems, fmt.Sprintf("illegal transition %s", t))
+		}
+		byField[t.Field] = append(byField[t.Field], t)
+	}
+
+	for _, field := range []StatusField{FieldLifecyclePhase, FieldProviderStatus} {
+		seq := byField[field]
+		for i := 0; i+2 < len(seq); i++ {
+			if seq[i].From == seq[i+2].From && seq[i].To == seq[i+2].To && seq[i+2].At.Sub(seq[i].At) < flapWindow {
+				problems = append(problems, fmt.Sprintf("%s flapped between %q and %q within %v starting at %s",
+					field, seq[i].From, seq[i].To, seq[i+2].At.Sub(seq[i].At).Round(time.Second), seq[i].At.Format(time.TimeOnly)))
+			}
+		}
+	}
+	return problems
+}
+
+// StatusPathObserved reports whether the states were entered in the given order, not necessarily
+// back to back.
+func StatusPathObserved(transitions []StatusTransition, field StatusField, states ...string) bool {
+	next := 0
+	for _, t := range transitions {
+		if next < len(states) && t.Field == field && t.To == states[next] {
+			next++
+		}
+	}
+	return next == len(states)
+}
+
+// FirstTransitionSince returns the first transition of field at or after since into a state match accepts.
+func FirstTransitionSince(transitions []StatusTransition, field StatusField, since time.Time, match func(state string) bool) (StatusTransition, bool) {
+	for _, t := range transitions {
+		if t.Field == field && !t.At.Before(since) && match(t.To) {
+			return t, true
+		}
+	}
+	return StatusTransition{}, false
+}
+
+// ClusterStatusRecorder records the providerStatus and lifecyclePhase transitions of a cluster as
+// reported by cluster-manager. cluster-manager offers no watch API, so the REST API is polled and only
+// changes are kept.
+type ClusterStatusRecorder struct {
+	namespace   string
+	clusterName string
+
+	mu          sync.Mutex
+	current     map[StatusField]string
+	transitions []StatusTransition
+	errors      int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewClusterStatusRecorder creates a recorder for a cluster; call Start to begin observing.
+func NewClusterStatusRecorder(namespace, clusterName string) *ClusterStatusRecorder {
+	return &ClusterStatusRecorder{
+		namespace:   namespace,
+		clusterName: clusterName,
+		current:     map[StatusField]string{},
+	}
+}
+
+// Record stores the state of a field if it differs from the last recorded one.
+func (r *ClusterStatusRecorder) Record(field StatusField, state, message string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	from := r.current[field]
+	if from == state {
+		return
+	}
+	r.current[field] = state
+	r.transitions = append(r.transitions, StatusTransition{At: at, Field: field, From: from, To: state, Message: message})
+}
+
+// Observe takes one snapshot of the cluster status. Failed requests are counted but not recorded,
+// since they say nothing about the cluster.
+func (r *ClusterStatusRecorder) Observe() {
+	now := time.Now()
+	resp, err := GetClusterInfo(r.namespace, r.clusterName)
+	if err != nil {
+		r.countError()
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		// A cluster that has not been created yet is not "deleted".
+		if r.State(FieldLifecyclePhase) != StatusNotObserved {
+			r.Record(FieldLifecyclePhase, StatusDeleted, "", now)
+			r.Record(FieldProviderStatus, StatusDeleted, "", now)
+		}
+		return
+	}
+	if resp.StatusCode != http.StatusOK {
+		r.countError()
+		return
+	}
+
+	var cluster api.ClusterDetailInfo
+	if err := json.NewDecoder(resp.Body).Decode(&cluster); err != nil {
+		r.countError()
+		return
+	}
+	if phase, message := statusState(cluster.LifecyclePhase, false); phase != StatusNotObserved {
+		r.Record(FieldLifecyclePhase, phase, message, now)
+	}
+	if indicator, message := statusState(cluster.ProviderStatus, true); indicator != StatusNotObserved {
+		r.Record(FieldProviderStatus, indicator, message, now)
+	}
+}
+
+// statusState returns the state a status is tracked by: the indicator for providerStatus, whose
+// message carries the changing not-ready reason, and the message for the lifecycle phase.
+func statusState(status *api.GenericStatus, byIndicator bool) (string, string) {
+	if status == nil {
+		return StatusNotObserved, ""
+	}
+	message := ""
+	if status.Message != nil {
+		message = *status.Message
+	}
+	if !byIndicator {
+		return message, message
+	}
+	if status.Indicator == nil {
+		return StatusNotObserved, message
+	}
+	return string(*status.Indicator), message
+}
+
+// ClusterStatusMessages returns the non-empty status messages cluster-manager reports for a cluster.
+func ClusterStatusMessages(cluster *api.ClusterDetailInfo) []string {
+	var messages []string
+	for _, status := range []*api.GenericStatus{cluster.LifecyclePhase, cluster.ProviderStatus, cluster.InfrastructureReady,
+		cluster.ControlPlaneReady, cluster.NodeHealth} {
+		if status != nil && status.Message != nil && *status.Message != "" {
+			messages = append(messages, *status.Message)
+		}
+	}
+	return messages
+}
+
+func (r *ClusterStatusRecorder) countError() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors++
+}
+
+// State returns the last recorded state of a field.
+func (r *ClusterStatusRecorder) State(field StatusField) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current[field]
+}
+
+// Transitions returns the transitions recorded so far, oldest first.
+func (r *ClusterStatusRecorder) Transitions() []StatusTransition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]StatusTransition(nil), r.transitions...)
+}
+
+// Start observes the cluster in the background until Stop is called or the cluster is deleted.
+func (r *ClusterStatusRecorder) Start(interval time.Duration) {
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			r.Observe()
+			if r.State(FieldLifecyclePhase) == StatusDeleted {
+				return
+			}
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop ends background observation started with Start.
+func (r *ClusterStatusRecorder) Stop() {
+	if r == nil || r.stop == nil {
+		return
+	}
+	select {
+	case <-r.done:
+	default:
+		close(r.stop)
+		<-r.done
+	}
+	r.stop = nil
+}
+
+// PrintTransitions writes the recorded transitions to stdout.
+func (r *ClusterStatusRecorder) PrintTransitions() {
+	r.mu.Lock()
+	failed := r.errors
+	r.mu.Unlock()
+
+	fmt.Printf("Status transitions of %s/%s (%d failed observations):\n", r.namespace, r.clusterName, failed)
+	for _, t := range r.Transitions() {
+		if t.Message != "" && t.Message != t.To {
+			fmt.Printf("  %s (%s)\n", t, t.Message)
+			continue
+		}
+		fmt.Printf("  %s\n", t)
+	}
+}
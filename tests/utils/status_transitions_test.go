@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-edge-platform/cluster-manager/v2/pkg/api"
+)
+
+var (
+	idle       = string(api.STATUSINDICATIONIDLE)
+	inProgress = string(api.STATUSINDICATIONINPROGRESS)
+	failing    = string(api.STATUSINDICATIONERROR)
+)
+
+func TestLifecycleTransitionAllowed(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{StatusNotObserved, LifecyclePending, true},
+		{LifecyclePending, LifecycleProvisioned, true}, // provisioning missed between polls
+		{LifecycleProvisioned, LifecycleActive, true},
+		{LifecycleActive, LifecycleProvisioned, true}, // connect agent disconnected
+		{LifecycleActive, LifecycleDeleting, true},
+		{LifecycleDeleting, StatusDeleted, true},
+		{LifecycleActive, LifecyclePending, false},
+		{LifecycleProvisioned, LifecycleProvisioning, false},
+		{LifecycleDeleting, LifecycleActive, false},
+		{StatusDeleted, LifecycleActive, false},
+	}
+	for _, test := range tests {
+		if got := LifecycleTransitionAllowed(test.from, test.to); got != test.want {
+			t.Errorf("Expected %q -> %q allowed=%t, got %t", test.from, test.to, test.want, got)
+		}
+	}
+}
+
+func TestProviderStatusTransitionAllowed(t *testing.T) {
+	unspecified := string(api.STATUSINDICATIONUNSPECIFIED)
+	if !ProviderStatusTransitionAllowed(unspecified, inProgress) || !ProviderStatusTransitionAllowed(idle, failing) || !ProviderStatusTransitionAllowed(failing, idle) {
+		t.Errorf("Expected ready, not ready and disconnected to follow each other")
+	}
+	if ProviderStatusTransitionAllowed(idle, unspecified) {
+		t.Errorf("Expected a ready cluster not to lose its Ready condition")
+	}
+	if ProviderStatusTransitionAllowed(StatusDeleted, idle) {
+		t.Errorf("Expected a deleted cluster not to come back")
+	}
+}
+
+func TestCheckStatusTransitions(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	lifecycle := []StatusTransition{
+		{At: at(0), Field: FieldLifecyclePhase, From: StatusNotObserved, To: LifecyclePending},
+		{At: at(1), Field: FieldLifecyclePhase, From: LifecyclePending, To: LifecycleProvisioned},
+		{At: at(3), Field: FieldLifecyclePhase, From: LifecycleProvisioned, To: LifecycleActive},
+		{At: at(10), Field: FieldLifecyclePhase, From: LifecycleActive, To: LifecycleProvisioned},
+		{At: at(15), Field: FieldLifecyclePhase, From: LifecycleProvisioned, To: LifecycleActive},
+		{At: at(20), Field: FieldLifecyclePhase, From: LifecycleActive, To: LifecycleDeleting},
+		{At: at(21), Field: FieldLifecyclePhase, From: LifecycleDeleting, To: StatusDeleted},
+	}
+	if problems := CheckStatusTransitions(lifecycle, StatusFlapWindow); len(problems) != 0 {
+		t.Errorf("Expected a create, disconnect, reconnect and delete to be valid, got %v", problems)
+	}
+	if !StatusPathObserved(lifecycle, FieldLifecyclePhase, LifecycleActive, LifecycleProvisioned, LifecycleActive, StatusDeleted) {
+		t.Errorf("Expected the disconnect and reconnect path to be observed")
+	}
+	if StatusPathObserved(lifecycle, FieldLifecyclePhase, LifecycleDeleting, LifecycleActive) {
+		t.Errorf("Expected states entered out of order not to be observed")
+	}
+
+	illegal := append(lifecycle[:3:3], StatusTransition{At: at(4), Field: FieldLifecyclePhase, From: LifecycleActive, To: LifecyclePending})
+	problems := CheckStatusTransitions(illegal, StatusFlapWindow)
+	if len(problems) != 1 || !strings.Contains(problems[0], "illegal transition") {
+		t.Errorf("Expected one illegal transition, got %v", problems)
+	}
+
+	flapping := []StatusTransition{
+		{At: start, Field: FieldProviderStatus, From: StatusNotObserved, To: idle},
+		{At: start.Add(10 * time.Second), Field: FieldProviderStatus, From: idle, To: failing},
+		{At: start.Add(20 * time.Second), Field: FieldProviderStatus, From: failing, To: idle},
+		{At: start.Add(30 * time.Second), Field: FieldProviderStatus, From: idle, To: failing},
+	}
+	problems = CheckStatusTransitions(flapping, StatusFlapWindow)
+	if len(problems) != 1 || !strings.Contains(problems[0], "flapped") {
+		t.Errorf("Expected flapping to be reported, got %v", problems)
+	}
+	if problems := CheckStatusTransitions(flapping, 10*time.Second); len(problems) != 0 {
+		t.Errorf("Expected slow reconnects not to count as flapping, got %v", problems)
+	}
+}
+
+func TestFirstTransitionSince(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	transitions := []StatusTransition{
+		{At: start, Field: FieldProviderStatus, From: StatusNotObserved, To: failing},
+		{At: start.Add(time.Minute), Field: FieldProviderStatus, From: failing, To: idle},
+		{At: start.Add(2 * time.Minute), Field: FieldLifecyclePhase, From: LifecycleActive, To: LifecycleProvisioned},
+		{At: start.Add(3 * time.Minute), Field: FieldProviderStatus, From: idle, To: failing},
+	}
+	notIdle := func(state string) bool { return state != idle }
+
+	transition, ok := FirstTransitionSince(transitions, FieldProviderStatus, start.Add(time.Minute), notIdle)
+	if !ok || !transition.At.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("Expected the providerStatus failure at +3m, got %s (%t)", transition, ok)
+	}
+	if _, ok := FirstTransitionSince(transitions, FieldProviderStatus, start.Add(4*time.Minute), notIdle); ok {
+		t.Errorf("Expected no transition after the last one")
+	}
+}
+
+func TestClusterStatusRecorderKeepsChangesOnly(t *testing.T) {
+	recorder := NewClusterStatusRecorder("ns", "demo")
+	now := time.Now()
+	recorder.Record(FieldProviderStatus, inProgress, "not ready;WaitingForControlPlane", now)
+	recorder.Record(FieldProviderStatus, inProgress, "not ready;WaitingForInfrastructure", now)
+	recorder.Record(FieldProviderStatus, idle, "ready", now)
+
+	transitions := recorder.Transitions()
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions, got %v", transitions)
+	}
+	if transitions[1].From != inProgress || transitions[1].To != idle {
+		t.Errorf("Expected in progress -> idle, got %s", transitions[1])
+	}
+	if recorder.State(FieldProviderStatus) != idle {
+		t.Errorf("Expected current state %q, got %q", idle, recorder.State(FieldProviderStatus))
+	}
+}
+
+func TestClusterStatusMessages(t *testing.T) {
+	provisioning, pulling, empty := LifecycleProvisioning, "Back-off pulling image", ""
+	cluster := &api.ClusterDetailInfo{
+		LifecyclePhase:    &api.GenericStatus{Message: &provisioning},
+		ProviderStatus:    &api.GenericStatus{Message: &pulling},
+		ControlPlaneReady: &api.GenericStatus{Message: &empty},
+	}
+	messages := ClusterStatusMessages(cluster)
+	if strings.Join(messages, "|") != provisioning+"|"+pulling {
+		t.Errorf("Expected the lifecycle and provider messages, got %q", messages)
+	}
+}
@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// OTelEndpointEnvVar enables exporting spans of the test run to an OTLP/HTTP collector.
+	OTelEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	telemetryServiceName = "cluster-tests"
+	// maxCommandAttributeLen keeps long inline scripts from bloating the command spans.
+	maxCommandAttributeLen = 512
+)
+
+var tracer = otel.Tracer("github.com/open-edge-platform/cluster-tests/tests/utils")
+
+var telemetry struct {
+	once     sync.Once
+	provider *sdktrace.TracerProvider
+
+	mu        sync.Mutex
+	suiteCtx  context.Context
+	suiteSpan trace.Span
+	specCtx   context.Context
+	specSpan  trace.Span
+}
+
+// TelemetryEnabled reports whether spans are exported.
+func TelemetryEnabled() bool {
+	return strings.TrimSpace(os.Getenv(OTelEndpointEnvVar)) != ""
+}
+
+// RegisterTelemetry emits a span for the suite and one for each spec when TelemetryEnabled. Utils API
+// calls and external commands run by the utils become children of the running spec's span.
+// diagnostics.RegisterSuiteHooks registers it for every suite.
+func RegisterTelemetry() bool {
+	ginkgo.BeforeEach(func() {
+		if TelemetryEnabled() {
+			startSpecSpan(ginkgo.CurrentSpecReport())
+		}
+	})
+	ginkgo.ReportAfterEach(func(report ginkgo.SpecReport) {
+		endSpecSpan(report)
+	})
+	ginkgo.ReportAfterSuite("telemetry", func(report ginkgo.Report) {
+		shutdownTelemetry(report)
+	})
+	return true
+}
+
+// SpecContext returns the context of the running spec's span, or a background context when no
+// span is recorded.
+func SpecContext() context.Context {
+	telemetry.mu.Lock()
+	defer telemetry.mu.Unlock()
+	if telemetry.specCtx != nil {
+		return telemetry.specCtx
+	}
+	if telemetry.suiteCtx != nil {
+		return telemetry.suiteCtx
+	}
+	return context.Background()
+}
+
+func setupTelemetry() {
+	telemetry.once.Do(func() {
+		ctx := context.Background()
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			fmt.Printf("Failed to create OTLP exporter, spans will not be exported: %v\n", err)
+			return
+		}
+		res, err := resource.New(ctx,
+			resource.WithAttributes(attribute.String("service.name", telemetryServiceName)),
+			resource.WithFromEnv(),
+			resource.WithTelemetrySDK())
+		if err != nil {
+			fmt.Printf("Failed to detect telemetry resource: %v\n", err)
+		}
+
+		telemetry.provider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+		otel.SetTracerProvider(telemetry.provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+
+		suiteDir := filepath.Base(filepath.Dir(ginkgo.CurrentSpecReport().LeafNodeLocation.FileName))
+		telemetry.suiteCtx, telemetry.suiteSpan = tracer.Start(ctx, "suite "+suiteDir)
+	})
+}
+
+func startSpecSpan(report ginkgo.SpecReport) {
+	setupTelemetry()
+	if telemetry.provider == nil {
+		return
+	}
+
+	telemetry.mu.Lock()
+	defer telemetry.mu.Unlock()
+	telemetry.specCtx, telemetry.specSpan = tracer.Start(telemetry.suiteCtx, report.FullText(),
+		trace.WithAttributes(
+			attribute.StringSlice("ginkgo.labels", report.Labels()),
+			attribute.String("code.location", report.LeafNodeLocation.String())))
+}
+
+func endSpecSpan(report ginkgo.SpecReport) {
+	telemetry.mu.Lock()
+	span := telemetry.specSpan
+	telemetry.specCtx, telemetry.specSpan = nil, nil
+	telemetry.mu.Unlock()
+	if span == nil {
+		return
+	}
+
+	span.SetAttributes(attribute.String("ginkgo.state", report.State.String()))
+	if report.Failed() {
+		span.SetStatus(codes.Error, report.Failure.Message)
+	}
+	span.End()
+	if err := telemetry.provider.ForceFlush(context.Background()); err != nil {
+		fmt.Printf("Failed to export spans: %v\n", err)
+	}
+}
+
+func shutdownTelemetry(report ginkgo.Report) {
+	if telemetry.provider == nil {
+		return
+	}
+	if telemetry.suiteSpan != nil {
+		if !report.SuiteSucceeded {
+			telemetry.suiteSpan.SetStatus(codes.Error, "suite failed")
+		}
+		telemetry.suiteSpan.End()
+	}
+	if err := telemetry.provider.Shutdown(context.Background()); err != nil {
+		fmt.Printf("Failed to shut down telemetry: %v\n", err)
+	}
+}
+
+// CommandOutput runs cmd like cmd.Output, recording a span for it.
+func CommandOutput(cmd *exec.Cmd) ([]byte, error) {
+	var out []byte
+	err := traceCommand(cmd, func() (err error) {
+		out, err = cmd.Output()
+		return err
+	})
+	return out, err
+}
+
+// CommandCombinedOutput runs cmd like cmd.CombinedOutput, recording a span for it.
+func CommandCombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	var out []byte
+	err := traceCommand(cmd, func() (err error) {
+		out, err = cmd.CombinedOutput()
+		return err
+	})
+	return out, err
+}
+
+// RunCommand runs cmd like cmd.Run, recording a span for it.
+func RunCommand(cmd *exec.Cmd) error {
+	return traceCommand(cmd, cmd.Run)
+}
+
+func traceCommand(cmd *exec.Cmd, run func() error) error {
+	_, span := tracer.Start(SpecContext(), "exec "+filepath.Base(cmd.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("process.command_line", commandLine(cmd))))
+	defer span.End()
+
+	err := run()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func commandLine(cmd *exec.Cmd) string {
+	line := strings.Join(cmd.Args, " ")
+	if len(line) > maxCommandAttributeLen {
+		line = line[:maxCommandAttributeLen] + "..."
+	}
+	return line
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestCommandLine(t *testing.T) {
+	if got := commandLine(exec.Command("kubectl", "get", "pods")); got != "kubectl get pods" {
+		t.Errorf("Expected %q, got %q", "kubectl get pods", got)
+	}
+
+	long := commandLine(exec.Command("sh", "-c", strings.Repeat("x", 2*maxCommandAttributeLen)))
+	if len(long) != maxCommandAttributeLen+len("...") || !strings.HasSuffix(long, "...") {
+		t.Errorf("Expected the command line to be truncated, got %d characters", len(long))
+	}
+}
+
+func TestTelemetrySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(previous)
+
+	out, err := CommandOutput(exec.Command("echo", "hello"))
+	if err != nil || strings.TrimSpace(string(out)) != "hello" {
+		t.Fatalf("Expected command output %q, got %q (%v)", "hello", out, err)
+	}
+	if err := RunCommand(exec.Command("false")); err == nil {
+		t.Fatalf("Expected the failing command to return an error")
+	}
+
+	var traceParent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get(TraceParentHeader)
+	}))
+	defer server.Close()
+	resp, err := NewHTTPClient().Get(server.URL + "/v2/clusters")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "exec echo" || spans[1].Name() != "exec false" || spans[2].Name() != "HTTP GET /v2/clusters" {
+		t.Errorf("Unexpected span names: %q, %q, %q", spans[0].Name(), spans[1].Name(), spans[2].Name())
+	}
+	if spans[1].Status().Code.String() != "Error" {
+		t.Errorf("Expected the failing command span to have an error status, got %v", spans[1].Status().Code)
+	}
+	traceID, spanID, ok := ParseTraceParent(traceParent)
+	if !ok || traceID != spans[2].SpanContext().TraceID().String() || spanID != spans[2].SpanContext().SpanID().String() {
+		t.Errorf("Expected traceparent %q to match the HTTP span", traceParent)
+	}
+}
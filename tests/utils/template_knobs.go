@@ -0,0 +1,326 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// TemplateFeatureGatesEnvVar are the feature gates the knobs template enables on the API server and the
+	// kubelet, comma-separated. The defaults are alpha gates, which are off unless the template is honored.
+	TemplateFeatureGatesEnvVar  = "TEMPLATE_FEATURE_GATES"
+	DefaultTemplateFeatureGates = "MutatingAdmissionPolicy=true"
+	// TemplateRuntimeConfigEnvVar are the API versions the knobs template serves, comma-separated. Either is left
+	// out of the template when set to an empty value.
+	TemplateRuntimeConfigEnvVar  = "TEMPLATE_RUNTIME_CONFIG"
+	DefaultTemplateRuntimeConfig = "admissionregistration.k8s.io/v1alpha1=true"
+
+	// KnobsTemplateOnlyName is the template the knobs are imported as, a variant of the k3s baseline.
+	KnobsTemplateOnlyName = "knobs-k3s"
+	KnobsTemplateName     = KnobsTemplateOnlyName + "-" + K3sTemplateOnlyVersion
+
+	// knobsMaxPods and knobsNodePortRange differ from the k3s defaults and the baseline template.
+	knobsMaxPods       = 180
+	knobsNodePortRange = "30000-30999"
+
+	featureEnabledMetric = "kubernetes_feature_enabled"
+)
+
+// TemplateKnobs are kubernetes settings a cluster template configures.
+type TemplateKnobs struct {
+	// FeatureGates are enabled on the API server and the kubelet, e.g. "MutatingAdmissionPolicy=true".
+	FeatureGates map[string]bool
+	// RuntimeConfig enables or disables API versions, e.g. "admissionregistration.k8s.io/v1alpha1=true".
+	RuntimeConfig map[string]bool
+	// NodePortRange is the service node port range of the API server.
+	NodePortRange string
+	// MaxPods is the pod capacity of the kubelet.
+	MaxPods int
+}
+
+// TemplateKnobsFromEnv returns the knobs of TEMPLATE_FEATURE_GATES and TEMPLATE_RUNTIME_CONFIG, with a node port
+// range and pod capacity that differ from the defaults.
+func TemplateKnobsFromEnv() (TemplateKnobs, error) {
+	featureGates, err := parseKnobList(GetEnv(TemplateFeatureGatesEnvVar, DefaultTemplateFeatureGates))
+	if err != nil {
+		return TemplateKnobs{}, fmt.Errorf("invalid %s: %w", TemplateFeatureGatesEnvVar, err)
+	}
+	runtimeConfig, err := parseKnobList(GetEnv(TemplateRuntimeConfigEnvVar, DefaultTemplateRuntimeConfig))
+	if err != nil {
+		return TemplateKnobs{}, fmt.Errorf("invalid %s: %w", TemplateRuntimeConfigEnvVar, err)
+	}
+	return TemplateKnobs{
+		FeatureGates:  featureGates,
+		RuntimeConfig: runtimeConfig,
+		NodePortRange: knobsNodePortRange,
+		MaxPods:       knobsMaxPods,
+	}, nil
+}
+
+// parseKnobList reads "name=true,other=false".
+func parseKnobList(value string) (map[string]bool, error) {
+	knobs := map[string]bool{}
+	for _, item := range splitList(value) {
+		name, setting, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not name=true|false", item)
+		}
+		enabled, err := strconv.ParseBool(setting)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not name=true|false", item)
+		}
+		knobs[strings.TrimSpace(name)] = enabled
+	}
+	return knobs, nil
+}
+
+// formatKnobList writes knobs as "name=true,other=false", sorted by name.
+func formatKnobList(knobs map[string]bool) string {
+	items := make([]string, 0, len(knobs))
+	for name, enabled := range knobs {
+		items = append(items, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// KnobsTemplate returns the k3s baseline template as KnobsTemplateOnlyName, with the knobs set as arguments of
+// the API server and the kubelet in its clusterconfiguration.
+func KnobsTemplate(knobs TemplateKnobs) ([]byte, error) {
+	data, err := readClusterTemplate(TemplateTypeK3sBaseline)
+	if err != nil {
+		return nil, err
+	}
+	return renderKnobsTemplate(data, knobs)
+}
+
+func renderKnobsTemplate(data []byte, knobs TemplateKnobs) ([]byte, error) {
+	var template map[string]interface{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse the baseline template: %w", err)
+	}
+	template["name"] = KnobsTemplateOnlyName
+	template["description"] = "k3s template with kubernetes feature gates and configuration knobs"
+
+	spec, err := kthreesConfigSpec(template["clusterconfiguration"])
+	if err != nil {
+		return nil, err
+	}
+	serverConfig, _ := spec["serverConfig"].(map[string]interface{})
+	if serverConfig == nil {
+		serverConfig = map[string]interface{}{}
+		spec["serverConfig"] = serverConfig
+	}
+	agentConfig, _ := spec["agentConfig"].(map[string]interface{})
+	if agentConfig == nil {
+		agentConfig = map[string]interface{}{}
+		spec["agentConfig"] = agentConfig
+	}
+
+	var apiServerArgs, kubeletArgs []string
+	if len(knobs.FeatureGates) > 0 {
+		gates := "--feature-gates=" + formatKnobList(knobs.FeatureGates)
+		apiServerArgs = append(apiServerArgs, gates)
+		kubeletArgs = append(kubeletArgs, gates)
+	}
+	if len(knobs.RuntimeConfig) > 0 {
+		apiServerArgs = append(apiServerArgs, "--runtime-config="+formatKnobList(knobs.RuntimeConfig))
+	}
+	if knobs.NodePortRange != "" {
+		apiServerArgs = append(apiServerArgs, "--service-node-port-range="+knobs.NodePortRange)
+	}
+	if knobs.MaxPods > 0 {
+		kubeletArgs = append(kubeletArgs, fmt.Sprintf("--max-pods=%d", knobs.MaxPods))
+	}
+	serverConfig["kubeApiServerArg"] = setArgs(serverConfig["kubeApiServerArg"], apiServerArgs)
+	agentConfig["kubeletArgs"] = setArgs(agentConfig["kubeletArgs"], kubeletArgs)
+	return json.Marshal(template)
+}
+
+// setArgs returns the "--flag=value" arguments of existing with those of args replacing the same flags.
+func setArgs(existing interface{}, args []string) []interface{} {
+	replaced := map[string]bool{}
+	for _, arg := range args {
+		flag, _, _ := strings.Cut(arg, "=")
+		replaced[flag] = true
+	}
+	var merged []interface{}
+	list, _ := existing.([]interface{})
+	for _, item := range list {
+		arg, _ := item.(string)
+		if flag, _, _ := strings.Cut(arg, "="); !replaced[flag] {
+			merged = append(merged, item)
+		}
+	}
+	for _, arg := range args {
+		merged = append(merged, arg)
+	}
+	return merged
+}
+
+// enabledFeatures reads the kubernetes_feature_enabled metric of an API server or kubelet.
+func enabledFeatures(metrics Metrics) map[string]bool {
+	features := map[string]bool{}
+	for _, sample := range metrics {
+		if sample.Name == featureEnabledMetric && sample.Labels["name"] != "" {
+			features[sample.Labels["name"]] = sample.Value == 1
+		}
+	}
+	return features
+}
+
+// featureGateMismatches describes the gates whose state differs from the expected one; a gate the component
+// does not report counts as a mismatch.
+func featureGateMismatches(component string, expected, actual map[string]bool) []string {
+	var mismatches []string
+	for name, enabled := range expected {
+		state, ok := actual[name]
+		switch {
+		case !ok:
+			mismatches = append(mismatches, fmt.Sprintf("%s does not report the feature gate %s", component, name))
+		case state != enabled:
+			mismatches = append(mismatches, fmt.Sprintf("%s runs with %s=%t instead of %t", component, name, state, enabled))
+		}
+	}
+	sort.Strings(mismatches)
+	return mismatches
+}
+
+// downstreamFeatures reads the feature gates a downstream component reports on its metrics, fetched through
+// the API server at path.
+func downstreamFeatures(kubeconfigPath, path string) (map[string]bool, error) {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	out, err := client.GetRaw(path, kubeClientTimeout)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := ParsePrometheusMetrics(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics of %s: %w", path, err)
+	}
+	return enabledFeatures(metrics), nil
+}
+
+// CheckAPIServerFeatureGates verifies through the gateway that the downstream API server runs with the feature
+// gates of knobs.
+func CheckAPIServerFeatureGates(kubeconfigPath string, knobs TemplateKnobs) error {
+	features, err := downstreamFeatures(kubeconfigPath, "/metrics")
+	if err != nil {
+		return err
+	}
+	return knobsError(featureGateMismatches("the API server", knobs.FeatureGates, features))
+}
+
+// CheckKubeletKnobs verifies through the gateway that the kubelet of every downstream node runs with the
+// feature gates and pod capacity of knobs.
+func CheckKubeletKnobs(kubeconfigPath string, knobs TemplateKnobs) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	nodes, err := client.ListNodes()
+	if err != nil {
+		return err
+	}
+	var mismatches []string
+	for _, node := range nodes {
+		pods := node.Status.Capacity.Pods().Value()
+		if knobs.MaxPods > 0 && pods != int64(knobs.MaxPods) {
+			mismatches = append(mismatches, fmt.Sprintf("node %s has a capacity of %d pods instead of %d", node.Name, pods, knobs.MaxPods))
+		}
+		features, err := downstreamFeatures(kubeconfigPath, "/api/v1/nodes/"+node.Name+"/proxy/metrics")
+		if err != nil {
+			return err
+		}
+		mismatches = append(mismatches, featureGateMismatches("the kubelet of "+node.Name, knobs.FeatureGates, features)...)
+	}
+	return knobsError(mismatches)
+}
+
+// CheckRuntimeConfig verifies through the gateway that the downstream API server serves the API versions the
+// runtime config of knobs enables, and not those it disables.
+func CheckRuntimeConfig(kubeconfigPath string, knobs TemplateKnobs) error {
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	versions, err := client.APIVersions()
+	if err != nil {
+		return err
+	}
+	served := map[string]bool{}
+	for _, version := range versions {
+		served[version] = true
+	}
+	var mismatches []string
+	for version, enabled := range knobs.RuntimeConfig {
+		if served[version] != enabled {
+			mismatches = append(mismatches, fmt.Sprintf("the API server serves %s: %t, the template sets %t", version, served[version], enabled))
+		}
+	}
+	sort.Strings(mismatches)
+	return knobsError(mismatches)
+}
+
+// CheckNodePortRange verifies that the downstream API server rejects a NodePort service just outside the node
+// port range of knobs and admits one inside it, without creating either.
+func CheckNodePortRange(kubeconfigPath string, knobs TemplateKnobs) error {
+	low, high, err := parsePortRange(knobs.NodePortRange)
+	if err != nil {
+		return err
+	}
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return err
+	}
+	nodePortService := func(name string, port int) error {
+		return client.DryRunCreateService(&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Type:     corev1.ServiceTypeNodePort,
+				Selector: map[string]string{"app": name},
+				Ports:    []corev1.ServicePort{{Name: "80-80", Port: 80, TargetPort: intstr.FromInt32(80), NodePort: int32(port)}},
+			},
+		})
+	}
+	if err := nodePortService("knobs-inside", low); err != nil {
+		return fmt.Errorf("a node port inside %s was rejected: %w", knobs.NodePortRange, err)
+	}
+	if err := nodePortService("knobs-outside", high+1); err == nil {
+		return fmt.Errorf("node port %d outside %s was admitted, the API server does not run with the range of the template", high+1, knobs.NodePortRange)
+	} else if !strings.Contains(err.Error(), "valid range") {
+		return fmt.Errorf("node port %d outside %s was rejected for another reason: %w", high+1, knobs.NodePortRange, err)
+	}
+	return nil
+}
+
+func parsePortRange(value string) (int, int, error) {
+	lowText, highText, ok := strings.Cut(value, "-")
+	low, lowErr := strconv.Atoi(lowText)
+	high, highErr := strconv.Atoi(highText)
+	if !ok || lowErr != nil || highErr != nil || low > high {
+		return 0, 0, fmt.Errorf("invalid port range %q", value)
+	}
+	return low, high, nil
+}
+
+func knobsError(mismatches []string) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the cluster does not honor its template: %s", strings.Join(mismatches, "; "))
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderKnobsTemplate(t *testing.T) {
+	baseline, err := readClusterTemplate(TemplateTypeK3sBaseline)
+	if err != nil {
+		t.Fatalf("Failed to read the baseline template: %v", err)
+	}
+	knobs := TemplateKnobs{
+		FeatureGates:  map[string]bool{"MutatingAdmissionPolicy": true, "InPlacePodVerticalScaling": false},
+		RuntimeConfig: map[string]bool{"admissionregistration.k8s.io/v1alpha1": true},
+		NodePortRange: "30000-30999",
+		MaxPods:       180,
+	}
+	data, err := renderKnobsTemplate(baseline, knobs)
+	if err != nil {
+		t.Fatalf("Failed to render the knobs template: %v", err)
+	}
+
+	var template map[string]interface{}
+	if err := json.Unmarshal(data, &template); err != nil {
+		t.Fatalf("Failed to parse the knobs template: %v", err)
+	}
+	if template["name"] != KnobsTemplateOnlyName || template["version"] != K3sTemplateOnlyVersion {
+		t.Errorf("Expected the template to be %s, got %v-%v", KnobsTemplateName, template["name"], template["version"])
+	}
+	spec, err := kthreesConfigSpec(template["clusterconfiguration"])
+	if err != nil {
+		t.Fatalf("Failed to find the kthreesConfigSpec: %v", err)
+	}
+	apiServerArgs := spec["serverConfig"].(map[string]interface{})["kubeApiServerArg"].([]interface{})
+	kubeletArgs := spec["agentConfig"].(map[string]interface{})["kubeletArgs"].([]interface{})
+
+	gates := "--feature-gates=InPlacePodVerticalScaling=false,MutatingAdmissionPolicy=true"
+	for _, arg := range []string{gates, "--runtime-config=admissionregistration.k8s.io/v1alpha1=true", "--service-node-port-range=30000-30999",
+		"--admission-control-config-file=/var/lib/rancher/k3s/server/psa.yaml"} {
+		if !containsArg(apiServerArgs, arg) {
+			t.Errorf("Expected the API server arguments to contain %s, got %v", arg, apiServerArgs)
+		}
+	}
+	for _, arg := range []string{gates, "--max-pods=180", "--cpu-manager-policy=static"} {
+		if !containsArg(kubeletArgs, arg) {
+			t.Errorf("Expected the kubelet arguments to contain %s, got %v", arg, kubeletArgs)
+		}
+	}
+	if containsArg(kubeletArgs, "--max-pods=250") {
+		t.Errorf("Expected the max-pods of the baseline to be replaced, got %v", kubeletArgs)
+	}
+}
+
+func containsArg(args []interface{}, arg string) bool {
+	for _, item := range args {
+		if item == arg {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTemplateKnobsFromEnv(t *testing.T) {
+	t.Setenv(TemplateFeatureGatesEnvVar, "A=true, B=false")
+	t.Setenv(TemplateRuntimeConfigEnvVar, "")
+	knobs, err := TemplateKnobsFromEnv()
+	if err != nil {
+		t.Fatalf("Failed to read the knobs: %v", err)
+	}
+	if expected := map[string]bool{"A": true, "B": false}; !reflect.DeepEqual(knobs.FeatureGates, expected) {
+		t.Errorf("Expected feature gates %v, got %v", expected, knobs.FeatureGates)
+	}
+	if len(knobs.RuntimeConfig) != 0 {
+		t.Errorf("Expected no runtime config for an empty %s, got %v", TemplateRuntimeConfigEnvVar, knobs.RuntimeConfig)
+	}
+
+	t.Setenv(TemplateFeatureGatesEnvVar, "A=yes")
+	if _, err := TemplateKnobsFromEnv(); err == nil {
+		t.Error("Expected an error for a feature gate that is not true or false")
+	}
+}
+
+func TestFeatureGateMismatches(t *testing.T) {
+	metrics, err := ParsePrometheusMetrics(strings.NewReader(`# TYPE kubernetes_feature_enabled gauge
+kubernetes_feature_enabled{name="MutatingAdmissionPolicy",stage="ALPHA"} 1
+kubernetes_feature_enabled{name="InPlacePodVerticalScaling",stage="BETA"} 1
+apiserver_request_total{code="200"} 12
+`))
+	if err != nil {
+		t.Fatalf("Failed to parse metrics: %v", err)
+	}
+	features := enabledFeatures(metrics)
+	if expected := map[string]bool{"MutatingAdmissionPolicy": true, "InPlacePodVerticalScaling": true}; !reflect.DeepEqual(features, expected) {
+		t.Errorf("Expected features %v, got %v", expected, features)
+	}
+
+	expected := []string{
+		"the API server does not report the feature gate Unknown",
+		"the API server runs with InPlacePodVerticalScaling=true instead of false",
+	}
+	gates := map[string]bool{"MutatingAdmissionPolicy": true, "InPlacePodVerticalScaling": false, "Unknown": true}
+	if mismatches := featureGateMismatches("the API server", gates, features); !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("Expected %v, got %v", expected, mismatches)
+	}
+}
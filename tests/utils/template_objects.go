@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterClassResource is the fully qualified resource name of CAPI cluster classes.
+const ClusterClassResource = "clusterclasses.cluster.x-k8s.io"
+
+// TemplateObject is a Kubernetes object that exists because a cluster template was imported.
+type TemplateObject struct {
+	// Resource is kubectl's kind.group form, e.g. intelmachinetemplate.infrastructure.cluster.x-k8s.io.
+	Resource string
+	Name     string
+}
+
+func (o TemplateObject) String() string {
+	return o.Resource + "/" + o.Name
+}
+
+// ListClusterTemplateObjects returns the ClusterTemplate CR of a template, its ClusterClass and the
+// control plane and infrastructure templates the ClusterClass references.
+func ListClusterTemplateObjects(namespace, templateName string) ([]TemplateObject, error) {
+	out, err := managementObjectJSON(ClusterClassResource, namespace, templateName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster class %s/%s: %w", namespace, templateName, err)
+	}
+	refs, err := parseClusterClassRefs(out)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []TemplateObject{
+		{Resource: ClusterTemplateResource, Name: templateName},
+		{Resource: ClusterClassResource, Name: templateName},
+	}
+	return append(objects, refs...), nil
+}
+
+// parseClusterClassRefs returns the templates referenced by a v1beta1 (ref) or v1beta2 (templateRef)
+// ClusterClass.
+func parseClusterClassRefs(data []byte) ([]TemplateObject, error) {
+	type refs struct {
+		Ref         *capiObjectRef `json:"ref"`
+		TemplateRef *capiObjectRef `json:"templateRef"`
+	}
+	var cc struct {
+		Spec struct {
+			ControlPlane struct {
+				refs
+				MachineInfrastructure *refs `json:"machineInfrastructure"`
+			} `json:"controlPlane"`
+			Infrastructure refs `json:"infrastructure"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(data, &cc); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster class: %w", err)
+	}
+
+	candidates := []refs{cc.Spec.ControlPlane.refs, cc.Spec.Infrastructure}
+	if cc.Spec.ControlPlane.MachineInfrastructure != nil {
+		candidates = append(candidates, *cc.Spec.ControlPlane.MachineInfrastructure)
+	}
+	var objects []TemplateObject
+	for _, candidate := range candidates {
+		for _, ref := range []*capiObjectRef{candidate.Ref, candidate.TemplateRef} {
+			if ref == nil || ref.Kind == "" || ref.Name == "" {
+				continue
+			}
+			group := ref.APIGroup
+			if group == "" {
+				group, _, _ = strings.Cut(ref.APIVersion, "/")
+			}
+			objects = append(objects, TemplateObject{Resource: strings.ToLower(ref.Kind) + "." + group, Name: ref.Name})
+		}
+	}
+	return objects, nil
+}
+
+// RemainingObjects returns the objects that still exist in the namespace.
+func RemainingObjects(namespace string, objects []TemplateObject) ([]TemplateObject, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return nil, err
+	}
+	var remaining []TemplateObject
+	for _, obj := range objects {
+		exists, err := client.ResourceExists(obj.Resource, namespace, obj.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", obj, err)
+		}
+		if exists {
+			remaining = append(remaining, obj)
+		}
+	}
+	return remaining, nil
+}
+
+// ObjectUID returns the UID of an object and the UID of its controller owner, which is empty when the
+// object has none.
+func ObjectUID(namespace string, obj TemplateObject) (uid, controllerUID string, err error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", "", err
+	}
+	object, err := client.GetResource(obj.Resource, namespace, obj.Name)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get %s: %w", obj, err)
+	}
+	if owner := metav1.GetControllerOf(object); owner != nil {
+		controllerUID = string(owner.UID)
+	}
+	return string(object.GetUID()), controllerUID, nil
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseClusterClassRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"v1beta1", `{"spec":{
+			"controlPlane":{"ref":{"apiVersion":"controlplane.cluster.x-k8s.io/v1beta2","kind":"KThreesControlPlaneTemplate","name":"k3s-baseline-v0.0.1"},
+				"machineInfrastructure":{"ref":{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha1","kind":"IntelMachineTemplate","name":"k3s-baseline-v0.0.1-controlplane"}}},
+			"infrastructure":{"ref":{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha1","kind":"IntelClusterTemplate","name":"k3s-baseline-v0.0.1"}}}}`},
+		{"v1beta2", `{"spec":{
+			"controlPlane":{"templateRef":{"apiVersion":"controlplane.cluster.x-k8s.io/v1beta2","kind":"KThreesControlPlaneTemplate","name":"k3s-baseline-v0.0.1"},
+				"machineInfrastructure":{"templateRef":{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha1","kind":"IntelMachineTemplate","name":"k3s-baseline-v0.0.1-controlplane"}}},
+			"infrastructure":{"templateRef":{"apiVersion":"infrastructure.cluster.x-k8s.io/v1alpha1","kind":"IntelClusterTemplate","name":"k3s-baseline-v0.0.1"}}}}`},
+	}
+	expected := []string{
+		"kthreescontrolplanetemplate.controlplane.cluster.x-k8s.io/k3s-baseline-v0.0.1",
+		"intelclustertemplate.infrastructure.cluster.x-k8s.io/k3s-baseline-v0.0.1",
+		"intelmachinetemplate.infrastructure.cluster.x-k8s.io/k3s-baseline-v0.0.1-controlplane",
+	}
+
+	for _, test := range tests {
+		objects, err := parseClusterClassRefs([]byte(test.data))
+		if err != nil {
+			t.Fatalf("%s: failed to parse cluster class: %v", test.name, err)
+		}
+		if len(objects) != len(expected) {
+			t.Fatalf("%s: expected %d objects, got %v", test.name, len(expected), objects)
+		}
+		for i, obj := range objects {
+			if obj.String() != expected[i] {
+				t.Errorf("%s: expected %s, got %s", test.name, expected[i], obj)
+			}
+		}
+	}
+}
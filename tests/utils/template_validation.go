@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+)
+
+// InvalidTemplateRequest is a template import cluster-manager has to reject because of a single field of the
+// k3s baseline template.
+type InvalidTemplateRequest struct {
+	Description string
+	// Field is the field the rejection message has to name, empty when the message need not name one.
+	Field string
+	// Status is the status code of the rejection.
+	Status int
+	// Overrides replaces top-level fields of the k3s baseline template.
+	Overrides map[string]any
+	// Remove lists top-level fields left out of the k3s baseline template.
+	Remove []string
+}
+
+// Body returns the template definition of the request.
+func (r InvalidTemplateRequest) Body() ([]byte, error) {
+	data, err := readClusterTemplate(TemplateTypeK3sBaseline)
+	if err != nil {
+		return nil, err
+	}
+	var template map[string]any
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", TemplateTypeK3sBaseline, err)
+	}
+	for field, value := range r.Overrides {
+		template[field] = value
+	}
+	for _, field := range r.Remove {
+		delete(template, field)
+	}
+	return json.Marshal(template)
+}
+
+// InvalidTemplateRequests returns template imports with a malformed field, each under a name of its own so
+// a wrongly accepted one does not hide the others, and the import of the k3s baseline template once more,
+// which conflicts with the imported one.
+func InvalidTemplateRequests() []InvalidTemplateRequest {
+	requests := []InvalidTemplateRequest{
+		{
+			Description: "template with a Kubernetes version that is not a version",
+			Field:       "version",
+			Overrides:   map[string]any{"kubernetesVersion": "latest"},
+		},
+		{
+			Description: "template with a Kubernetes version of another control plane provider",
+			Field:       "version",
+			Overrides:   map[string]any{"kubernetesVersion": "v1.33.5+rke2r1"},
+		},
+		{
+			Description: "template without a control plane configuration",
+			Remove:      []string{"clusterconfiguration"},
+		},
+		{
+			Description: "template with an unknown control plane provider",
+			Field:       "controlplaneprovidertype",
+			Overrides:   map[string]any{"controlplaneprovidertype": "kubeadm-unknown"},
+		},
+		{
+			Description: "template with a cluster label value over the length limit",
+			Field:       "label",
+			Overrides:   map[string]any{"cluster-labels": map[string]string{"negative": LimitString(MaxLabelValueLength + 1)}},
+		},
+		{
+			Description: "template with a cluster label name over the length limit",
+			Field:       "label",
+			Overrides:   map[string]any{"cluster-labels": map[string]string{LimitString(MaxLabelNameLength + 1): "negative"}},
+		},
+		{
+			Description: "template name with invalid characters",
+			Field:       "name",
+			Overrides:   map[string]any{"name": "Negative_Template!"},
+		},
+	}
+	for i := range requests {
+		if _, ok := requests[i].Overrides["name"]; !ok {
+			if requests[i].Overrides == nil {
+				requests[i].Overrides = map[string]any{}
+			}
+			requests[i].Overrides["name"] = fmt.Sprintf("negative-template-%c", 'a'+i)
+		}
+		requests[i].Status = http.StatusBadRequest
+	}
+	return append(requests, InvalidTemplateRequest{
+		Description: "template with the name and version of an imported template",
+		Status:      http.StatusConflict,
+	})
+}
+
+// ImportClusterTemplateRaw posts a template definition as it is, with the token of authContext when it is
+// not nil, and returns the status and body of the response, for definitions cluster-manager has to reject.
+// ImportClusterTemplateData is the one for valid definitions.
+func ImportClusterTemplateRaw(authContext *auth.TestAuthContext, namespace string, body []byte) (int, string, error) {
+	return ProjectAPIResponseAuthenticated(authContext, namespace, http.MethodPost, ClusterTemplateURL, body)
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestInvalidTemplateRequests(t *testing.T) {
+	names := map[string]string{}
+	for _, request := range InvalidTemplateRequests() {
+		data, err := request.Body()
+		if err != nil {
+			t.Fatalf("%s: expected a body, got %v", request.Description, err)
+		}
+		var template map[string]any
+		if err := json.Unmarshal(data, &template); err != nil {
+			t.Fatalf("%s: expected a JSON body, got %v", request.Description, err)
+		}
+		name, _ := template["name"].(string)
+		if other, ok := names[name]; ok {
+			t.Errorf("Expected a name of its own for %q, shares %s with %q", request.Description, name, other)
+		}
+		names[name] = request.Description
+		for _, field := range request.Remove {
+			if _, ok := template[field]; ok {
+				t.Errorf("%s: expected %s to be left out", request.Description, field)
+			}
+		}
+		if request.Status == http.StatusConflict && name != K3sTemplateOnlyName {
+			t.Errorf("Expected the conflicting request to keep the name %s, got %s", K3sTemplateOnlyName, name)
+		}
+		if request.Status != http.StatusBadRequest && request.Status != http.StatusConflict {
+			t.Errorf("%s: expected a 400 or a 409, got %d", request.Description, request.Status)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ClusterTemplateResource is the fully qualified resource name of cluster templates.
+const ClusterTemplateResource = "clustertemplates.edge-orchestrator.intel.com"
+
+// ClusterTemplateCR renders a k3s ClusterTemplate custom resource. Creating it with kubectl bypasses the
+// cluster-manager API, so the request reaches the admission webhook unvalidated.
+func ClusterTemplateCR(namespace, name, kubernetesVersion, clusterConfiguration string) ([]byte, error) {
+	cr := map[string]any{
+		"apiVersion": "edge-orchestrator.intel.com/v1alpha1",
+		"kind":       "ClusterTemplate",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]any{
+			"controlPlaneProviderType": "k3s",
+			"infraProviderType":        "intel",
+			"kubernetesVersion":        kubernetesVersion,
+			"clusterConfiguration":     clusterConfiguration,
+		},
+	}
+	data, err := yaml.Marshal(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render cluster template %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// CreateClusterTemplateCR creates a ClusterTemplate custom resource. On failure the error carries the
+// API server response, including the denial message of the admission webhook.
+func CreateClusterTemplateCR(manifest []byte) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	if err := client.CreateManifest(string(manifest)); err != nil {
+		return fmt.Errorf("failed to create cluster template: %w", err)
+	}
+	return nil
+}
+
+// PatchClusterTemplateCR applies a JSON merge patch to a ClusterTemplate custom resource.
+func PatchClusterTemplateCR(namespace, name, patch string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	return client.PatchResource(ClusterTemplateResource, namespace, name, types.MergePatchType, []byte(patch))
+}
+
+// DeleteClusterTemplateCR deletes a ClusterTemplate custom resource if it exists.
+func DeleteClusterTemplateCR(namespace, name string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	return client.DeleteResource(ClusterTemplateResource, namespace, name)
+}
+
+// DeleteAllClusterTemplateCRs deletes every ClusterTemplate custom resource of a namespace, bypassing the
+// cluster-manager API.
+func DeleteAllClusterTemplateCRs(namespace string) error {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return err
+	}
+	templates, err := client.ListResource(ClusterTemplateResource, namespace, "")
+	if err != nil {
+		return err
+	}
+	for _, template := range templates.Items {
+		if err := client.DeleteResource(ClusterTemplateResource, namespace, template.GetName()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClusterTemplateVariant returns the definition of a template type with the given top-level fields
+// overridden, for importing variants of it through the cluster-manager API.
+func ClusterTemplateVariant(templateType string, overrides map[string]any) ([]byte, error) {
+	data, err := readClusterTemplate(templateType)
+	if err != nil {
+		return nil, err
+	}
+	var template map[string]any
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", templateType, err)
+	}
+	for field, value := range overrides {
+		template[field] = value
+	}
+	return json.Marshal(template)
+}
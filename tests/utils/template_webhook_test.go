@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestClusterTemplateCR(t *testing.T) {
+	data, err := ClusterTemplateCR("demo-ns", "demo-v0.0.1", "v1.33.5+k3s1", "not json")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var cr struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"metadata"`
+		Spec map[string]string `yaml:"spec"`
+	}
+	if err := yaml.Unmarshal(data, &cr); err != nil {
+		t.Fatalf("Expected valid YAML, got %v", err)
+	}
+	if cr.Kind != "ClusterTemplate" || cr.Metadata.Name != "demo-v0.0.1" || cr.Metadata.Namespace != "demo-ns" {
+		t.Errorf("Expected ClusterTemplate demo-ns/demo-v0.0.1, got %s %s/%s", cr.Kind, cr.Metadata.Namespace, cr.Metadata.Name)
+	}
+	if cr.Spec["clusterConfiguration"] != "not json" || cr.Spec["kubernetesVersion"] != "v1.33.5+k3s1" {
+		t.Errorf("Expected the given spec to be kept as is, got %v", cr.Spec)
+	}
+}
+
+func TestClusterTemplateVariant(t *testing.T) {
+	data, err := ClusterTemplateVariant(TemplateTypeK3sBaseline, map[string]any{"version": "1.0.0"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var template map[string]any
+	if err := json.Unmarshal(data, &template); err != nil {
+		t.Fatalf("Expected valid JSON, got %v", err)
+	}
+	if template["version"] != "1.0.0" {
+		t.Errorf("Expected version to be overridden, got %v", template["version"])
+	}
+	if template["name"] != K3sTemplateOnlyName || template["clusterconfiguration"] == nil {
+		t.Errorf("Expected the other fields of the baseline template to be kept, got %v", template)
+	}
+
+	if _, err := ClusterTemplateVariant("unknown", nil); err == nil {
+		t.Errorf("Expected an error for an unknown template type")
+	}
+}
@@ -0,0 +1,336 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/auth"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// MultiTenancyEnvVar overrides the detected multi-tenancy mode of cluster-manager when set to "true" or "false".
+	MultiTenancyEnvVar = "MULTI_TENANCY"
+	// TenancyAPIURLEnvVar is the base URL of the tenancy API, by default the port-forward of StartTenancyAPIPortForward.
+	TenancyAPIURLEnvVar  = "TENANCY_API_URL"
+	DefaultTenancyAPIURL = "http://127.0.0.1:8082"
+	// TenancyOrgEnvVar is the org the test projects are created in.
+	TenancyOrgEnvVar  = "TENANCY_ORG"
+	DefaultTenancyOrg = "cluster-tests"
+	// TenancyProjectEnvVar is the project bootstrap creates for the suites.
+	TenancyProjectEnvVar  = "TENANCY_PROJECT"
+	DefaultTenancyProject = "cluster-tests"
+
+	PortForwardTenancyService    = "svc/nexus-api-gw"
+	PortForwardTenancyLocalPort  = "8082"
+	PortForwardTenancyRemotePort = "8082"
+
+	// RuntimeProjectCRD is installed by the tenancy datamodel; cluster-manager sets up a project per RuntimeProject.
+	RuntimeProjectCRD            = "runtimeprojects.runtimeproject.edge-orchestrator.intel.com"
+	projectActiveWatcherResource = "projectactivewatchers.projectactivewatcher.edge-orchestrator.intel.com"
+	runtimeProjectLabel          = RuntimeProjectCRD
+	nexusDisplayNameLabel        = "nexus/display_name"
+	clusterManagerWatcher        = "cluster-manager"
+	podSecurityAdmissionSecret   = "pod-security-admission-config"
+
+	TenancyStatusIdle  = "STATUS_INDICATION_IDLE"
+	TenancyStatusError = "STATUS_INDICATION_ERROR"
+
+	ProjectSetupTimeout  = 3 * time.Minute
+	projectSetupInterval = 5 * time.Second
+	tenancySubject       = "cluster-tests"
+)
+
+var (
+	multiTenancyEnabled     bool
+	multiTenancyEnabledOnce sync.Once
+)
+
+// MultiTenancyEnabled reports whether projects are set up by cluster-manager from the tenancy datamodel,
+// i.e. the tenancy component of .test-dependencies.yaml is deployed. MULTI_TENANCY takes precedence;
+// otherwise cluster-manager must run without -disable-multi-tenancy and the RuntimeProject CRD must
+// exist. The result is detected once per suite.
+func MultiTenancyEnabled() bool {
+	multiTenancyEnabledOnce.Do(func() {
+		enabled, source := detectMultiTenancy()
+		multiTenancyEnabled = enabled
+		fmt.Printf("Multi-tenancy %s (%s)\n", map[bool]string{true: "enabled", false: "disabled"}[enabled], source)
+	})
+	return multiTenancyEnabled
+}
+
+func detectMultiTenancy() (bool, string) {
+	if value := os.Getenv(MultiTenancyEnvVar); value != "" {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			return enabled, MultiTenancyEnvVar + "=" + value
+		}
+		fmt.Printf("Ignoring invalid %s=%q\n", MultiTenancyEnvVar, value)
+	}
+
+	args, err := clusterManagerArgs()
+	if err != nil {
+		return false, fmt.Sprintf("unable to read the cluster-manager deployment: %v", err)
+	}
+	if disabled, _ := parseBoolFlag(args, "disable-multi-tenancy"); disabled {
+		return false, "cluster-manager deployment flags"
+	}
+
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return false, fmt.Sprintf("unable to look up %s: %v", RuntimeProjectCRD, err)
+	}
+	installed, err := client.ResourceExists(crdResource, "", RuntimeProjectCRD)
+	if err != nil {
+		return false, fmt.Sprintf("unable to look up %s: %v", RuntimeProjectCRD, err)
+	}
+	if !installed {
+		return false, "tenancy datamodel not installed"
+	}
+	return true, "cluster-manager deployment and tenancy datamodel"
+}
+
+// TenantProject is a project created through the tenancy API. Its ID is the namespace of the project.
+type TenantProject struct {
+	Org   string
+	OrgID string
+	Name  string
+	ID    string
+}
+
+// tenancyStatus is the status the tenancy API reports for orgs and projects.
+type tenancyStatus struct {
+	StatusIndicator string `json:"statusIndicator"`
+	Message         string `json:"message"`
+	UID             string `json:"uID"`
+}
+
+// parseTenancyStatus returns the org or project status of a tenancy API response.
+func parseTenancyStatus(data []byte) (tenancyStatus, error) {
+	var resource struct {
+		Status struct {
+			OrgStatus     *tenancyStatus `json:"orgStatus"`
+			ProjectStatus *tenancyStatus `json:"projectStatus"`
+		} `json:"status"`
+	}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return tenancyStatus{}, fmt.Errorf("failed to parse tenancy status: %w", err)
+	}
+	switch {
+	case resource.Status.ProjectStatus != nil:
+		return *resource.Status.ProjectStatus, nil
+	case resource.Status.OrgStatus != nil:
+		return *resource.Status.OrgStatus, nil
+	}
+	return tenancyStatus{}, nil
+}
+
+// StartTenancyAPIPortForward port-forwards the API gateway that serves the tenancy API.
+func StartTenancyAPIPortForward() (*PortForwarder, error) {
+	return StartPortForward(PortForwardTenancyService, PortForwardTenancyLocalPort, PortForwardTenancyRemotePort)
+}
+
+func tenancyRequest(method, path, orgID string, body any) (int, []byte, error) {
+	token, err := auth.GenerateTenancyJWT(tenancySubject, orgID)
+	if err != nil {
+		return 0, nil, err
+	}
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, strings.TrimSuffix(GetEnv(TenancyAPIURLEnvVar, DefaultTenancyAPIURL), "/")+path, reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := NewHTTPClient().Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, data, err
+}
+
+// putTenancyResource creates an org or project, or keeps it when it already exists, and waits until
+// the tenancy controllers report it idle.
+func putTenancyResource(path, orgID, description string) (tenancyStatus, error) {
+	status, body, err := tenancyRequest(http.MethodPut, path, orgID, map[string]string{"description": description})
+	if err != nil {
+		return tenancyStatus{}, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if status != http.StatusOK && status != http.StatusCreated && status != http.StatusConflict {
+		return tenancyStatus{}, NewAPIError(fmt.Sprintf("create %s", path), status, body)
+	}
+
+	var last tenancyStatus
+	deadline := time.Now().Add(ProjectSetupTimeout)
+	for time.Now().Before(deadline) {
+		status, body, err := tenancyRequest(http.MethodGet, path, orgID, nil)
+		if err == nil && status == http.StatusOK {
+			if last, err = parseTenancyStatus(body); err != nil {
+				return tenancyStatus{}, err
+			}
+			switch last.StatusIndicator {
+			case TenancyStatusIdle:
+				if last.UID != "" {
+					return last, nil
+				}
+			case TenancyStatusError:
+				return last, fmt.Errorf("%s failed: %s", path, last.Message)
+			}
+		}
+		time.Sleep(projectSetupInterval)
+	}
+	return last, fmt.Errorf("%s is not ready after %s: %s %s", path, ProjectSetupTimeout, last.StatusIndicator, last.Message)
+}
+
+// CreateProject creates a project, and its org when needed, through the tenancy API. This is the real
+// multitenancy flow: the tenancy controllers create the runtime project, cluster-manager creates its
+// namespace and default templates, and the returned ID is the namespace. Run WaitForProjectSetup
+// before using it.
+func CreateProject(org, name string) (*TenantProject, error) {
+	orgStatus, err := putTenancyResource("/v1/orgs/"+org, "", "cluster-tests org")
+	if err != nil {
+		return nil, err
+	}
+	projectStatus, err := putTenancyResource("/v1/projects/"+name, orgStatus.UID, "cluster-tests project")
+	if err != nil {
+		return nil, err
+	}
+	return &TenantProject{Org: org, OrgID: orgStatus.UID, Name: name, ID: projectStatus.UID}, nil
+}
+
+// DeleteProject deletes a project through the tenancy API without waiting for its namespace to go away.
+func DeleteProject(project *TenantProject) error {
+	status, body, err := tenancyRequest(http.MethodDelete, "/v1/projects/"+project.Name, project.OrgID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete project %s: %w", project.Name, err)
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return NewAPIError(fmt.Sprintf("delete project %s", project.Name), status, body)
+	}
+	return nil
+}
+
+// WaitForProjectSetup waits until cluster-manager has set up the project of a namespace: the namespace and
+// its pod security admission secret exist and cluster-manager reports itself idle on the project.
+func WaitForProjectSetup(namespace string, timeout time.Duration) error {
+	problem := "not checked"
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		var err error
+		if problem, err = projectSetupProblem(namespace); err != nil {
+			return err
+		}
+		if problem == "" {
+			return nil
+		}
+		time.Sleep(projectSetupInterval)
+	}
+	return fmt.Errorf("project %s is not set up after %s: %s", namespace, timeout, problem)
+}
+
+// projectSetupProblem returns what is still missing from the project of a namespace, or an error when the
+// setup failed or the namespace does not belong to a project.
+func projectSetupProblem(namespace string) (string, error) {
+	out, err := managementResourceJSON(RuntimeProjectCRD, "", "")
+	if err != nil {
+		return "", fmt.Errorf("failed to list runtime projects: %w", err)
+	}
+	project, found, err := findRuntimeProject(out, namespace)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("namespace %s does not belong to a project; create one through the tenancy API and set %s to its ID", namespace, NamespaceEnvVar)
+	}
+
+	out, err = managementResourceJSON(projectActiveWatcherResource, "",
+		fmt.Sprintf("%s=%s,%s=%s", nexusDisplayNameLabel, clusterManagerWatcher, runtimeProjectLabel, project))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the project watchers of %s: %w", project, err)
+	}
+	watcher, found, err := parseProjectWatcher(out)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case !found:
+		return "cluster-manager has not picked up the project", nil
+	case watcher.StatusIndicator == TenancyStatusError:
+		return "", fmt.Errorf("cluster-manager failed to set up project %s: %s", project, watcher.Message)
+	case watcher.StatusIndicator != TenancyStatusIdle:
+		return fmt.Sprintf("cluster-manager is %s: %s", watcher.StatusIndicator, watcher.Message), nil
+	}
+
+	exists, err := NamespaceExists(namespace)
+	if err != nil || !exists {
+		return "the namespace does not exist", err
+	}
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", err
+	}
+	if _, err := client.GetSecret(namespace, podSecurityAdmissionSecret); apierrors.IsNotFound(err) {
+		return "the pod security admission secret does not exist", nil
+	} else if err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// findRuntimeProject returns the display name of the runtime project whose UID is the namespace.
+func findRuntimeProject(data []byte, namespace string) (string, bool, error) {
+	var list struct {
+		Items []struct {
+			Metadata struct {
+				UID    string            `json:"uid"`
+				Labels map[string]string `json:"labels"`
+			} `json:"metadata"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return "", false, fmt.Errorf("failed to parse runtime projects: %w", err)
+	}
+	for _, item := range list.Items {
+		if item.Metadata.UID == namespace {
+			return item.Metadata.Labels[nexusDisplayNameLabel], true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// parseProjectWatcher returns the status cluster-manager reports in its project watcher.
+func parseProjectWatcher(data []byte) (tenancyStatus, bool, error) {
+	var list struct {
+		Items []struct {
+			Spec tenancyStatus `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return tenancyStatus{}, false, fmt.Errorf("failed to parse project watchers: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return tenancyStatus{}, false, nil
+	}
+	return list.Items[0].Spec, true, nil
+}
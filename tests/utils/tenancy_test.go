@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"testing"
+)
+
+func TestParseBoolFlag(t *testing.T) {
+	args := []string{"-loglevel=0", "-disable-auth=true", "-disable-multi-tenancy=false"}
+	if value, found := parseBoolFlag(args, "disable-multi-tenancy"); value || !found {
+		t.Errorf("Expected (false, true), got (%t, %t)", value, found)
+	}
+	if value, found := parseBoolFlag(args, "disable-inventory"); value || found {
+		t.Errorf("Expected (false, false), got (%t, %t)", value, found)
+	}
+}
+
+func TestParseTenancyStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		indicator string
+		uid       string
+	}{
+		{"project", `{"spec":{"description":"p"},"status":{"projectStatus":{"statusIndicator":"STATUS_INDICATION_IDLE","message":"Project p CREATE is complete","timeStamp":1,"uID":"0f3e4a2b"}}}`, TenancyStatusIdle, "0f3e4a2b"},
+		{"org", `{"spec":{"description":"o"},"status":{"orgStatus":{"statusIndicator":"STATUS_INDICATION_IN_PROGRESS","message":"Waiting for watchers","uID":"9a8b"}}}`, "STATUS_INDICATION_IN_PROGRESS", "9a8b"},
+		{"no status yet", `{"spec":{"description":"p"}}`, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := parseTenancyStatus([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Failed to parse status: %v", err)
+			}
+			if status.StatusIndicator != tt.indicator || status.UID != tt.uid {
+				t.Errorf("Expected (%s, %s), got (%s, %s)", tt.indicator, tt.uid, status.StatusIndicator, status.UID)
+			}
+		})
+	}
+}
+
+func TestFindRuntimeProject(t *testing.T) {
+	data := `{"items":[
+		{"metadata":{"name":"6f1c","uid":"11111111-aaaa","labels":{"nexus/display_name":"other"}}},
+		{"metadata":{"name":"2b7e","uid":"22222222-bbbb","labels":{"nexus/display_name":"cluster-tests"}}}]}`
+
+	name, found, err := findRuntimeProject([]byte(data), "22222222-bbbb")
+	if err != nil || !found || name != "cluster-tests" {
+		t.Errorf("Expected cluster-tests, got %q (found %t, %v)", name, found, err)
+	}
+	if _, found, _ := findRuntimeProject([]byte(data), DefaultNamespace); found {
+		t.Errorf("Expected the default namespace not to belong to a project")
+	}
+}
+
+func TestParseProjectWatcher(t *testing.T) {
+	watcher, found, err := parseProjectWatcher([]byte(`{"items":[{"spec":{"statusIndicator":"STATUS_INDICATION_ERROR","message":"Error setting up cluster resources for project","timeStamp":1}}]}`))
+	if err != nil || !found {
+		t.Fatalf("Expected a watcher, got found %t (%v)", found, err)
+	}
+	if watcher.StatusIndicator != TenancyStatusError {
+		t.Errorf("Expected %s, got %s", TenancyStatusError, watcher.StatusIndicator)
+	}
+
+	if _, found, err := parseProjectWatcher([]byte(`{"items":[]}`)); found || err != nil {
+		t.Errorf("Expected no watcher, got found %t (%v)", found, err)
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// TestWorkloadImageEnvVar selects the image of the nginx test workload.
+	TestWorkloadImageEnvVar  = "TEST_WORKLOAD_IMAGE"
+	DefaultTestWorkloadImage = "nginx:1.27-alpine"
+	// TestWorkloadNamespace is the downstream namespace of the nginx test workload.
+	TestWorkloadNamespace = "cluster-tests-workload"
+	// TestWorkloadName names the deployment and the service of the nginx test workload.
+	TestWorkloadName = "nginx"
+	// TestWorkloadClient is the pod ValidateWorkload requests the services of a workload from.
+	TestWorkloadClient = "workload-client"
+
+	testWorkloadReplicas            = 2
+	testWorkloadRolloutTimeout      = 3 * time.Minute
+	testWorkloadClientReadyTimeout  = 3 * time.Minute
+	testWorkloadProbeTimeoutSeconds = 5
+)
+
+// TestWorkload is a workload deployed to a downstream cluster by DeployTestWorkload.
+type TestWorkload struct {
+	KubeconfigPath string
+	// Namespace is the namespace of the deployments and services, where the client pod of ValidateWorkload runs.
+	Namespace   string
+	Deployments []string
+	// Services are the in-cluster URLs of the first port of every service.
+	Services []string
+
+	manifest string
+}
+
+// workloadObject is what DeployTestWorkload reads of the objects of a manifest.
+type workloadObject struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Ports []struct {
+			Port int `yaml:"port"`
+		} `yaml:"ports"`
+	} `yaml:"spec"`
+}
+
+// NginxTestWorkload returns the manifest of a namespace with an nginx deployment and its service, the image
+// being TEST_WORKLOAD_IMAGE.
+func NginxTestWorkload() string {
+	return renderNginxTestWorkload(GetEnv(TestWorkloadImageEnvVar, DefaultTestWorkloadImage))
+}
+
+func renderNginxTestWorkload(image string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: %[1]s
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %[2]s
+  namespace: %[1]s
+spec:
+  replicas: %[4]d
+  selector:
+    matchLabels:
+      app: %[2]s
+  template:
+    metadata:
+      labels:
+        app: %[2]s
+    spec:
+      containers:
+        - name: nginx
+          image: %[3]s
+          ports:
+            - containerPort: 80
+          readinessProbe:
+            httpGet:
+              path: /
+              port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: %[2]s
+  namespace: %[1]s
+spec:
+  selector:
+    app: %[2]s
+  ports:
+    - port: 80
+      targetPort: 80
+`, TestWorkloadNamespace, TestWorkloadName, image, testWorkloadReplicas)
+}
+
+// parseTestWorkload reads the deployments and services of a manifest, which must all be in one namespace.
+func parseTestWorkload(manifest string) (*TestWorkload, error) {
+	workload := &TestWorkload{manifest: manifest}
+	decoder := yaml.NewDecoder(strings.NewReader(manifest))
+	for {
+		var object workloadObject
+		if err := decoder.Decode(&object); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid workload manifest: %w", err)
+		}
+		if object.Kind != "Deployment" && object.Kind != "Service" {
+			continue
+		}
+		namespace := object.Metadata.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		if workload.Namespace == "" {
+			workload.Namespace = namespace
+		} else if namespace != workload.Namespace {
+			return nil, fmt.Errorf("%s/%s is in %s, the workload in %s", object.Kind, object.Metadata.Name, namespace, workload.Namespace)
+		}
+		if object.Kind == "Deployment" {
+			workload.Deployments = append(workload.Deployments, object.Metadata.Name)
+			continue
+		}
+		if len(object.Spec.Ports) == 0 {
+			return nil, fmt.Errorf("service %s has no port", object.Metadata.Name)
+		}
+		workload.Services = append(workload.Services, fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/",
+			object.Metadata.Name, namespace, object.Spec.Ports[0].Port))
+	}
+	if len(workload.Deployments) == 0 {
+		return nil, errors.New("the workload manifest has no deployment")
+	}
+	return workload, nil
+}
+
+// DeployTestWorkload applies manifest to a downstream cluster, typically through the connect-gateway
+// kubeconfig, and waits until every deployment of it has rolled out. Delete the workload with Delete.
+func DeployTestWorkload(kubeconfigPath, manifest string) (*TestWorkload, error) {
+	workload, err := parseTestWorkload(manifest)
+	if err != nil {
+		return nil, err
+	}
+	workload.KubeconfigPath = kubeconfigPath
+	client, err := NewKubeClient(kubeconfigPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.ApplyManifest(manifest); err != nil {
+		return nil, fmt.Errorf("failed to deploy the workload: %w", err)
+	}
+	for _, deployment := range workload.Deployments {
+		if err := client.WaitForRollout(workload.Namespace, "Deployment", deployment, testWorkloadRolloutTimeout); err != nil {
+			return workload, err
+		}
+	}
+	return workload, nil
+}
+
+// ValidateWorkload requests every service of a workload from a client pod in its namespace, so the requests
+// go through the cluster DNS and the service network, and fails unless each answers with a page. The client
+// pod runs the image of the streaming pod and is created on first use.
+func ValidateWorkload(workload *TestWorkload) error {
+	client, err := NewKubeClient(workload.KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	err = client.CreatePod(workload.Namespace, TestWorkloadClient, GetEnv(StreamPodImageEnvVar, DefaultStreamPodImage), "sleep", "86400")
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create the workload client: %w", err)
+	}
+	if err := client.WaitForPodReady(workload.Namespace, TestWorkloadClient, testWorkloadClientReadyTimeout); err != nil {
+		return err
+	}
+
+	for _, url := range workload.Services {
+		out, err := client.Exec(workload.Namespace, TestWorkloadClient,
+			"wget", "-q", "-T", fmt.Sprint(testWorkloadProbeTimeoutSeconds), "-O", "-", url)
+		if err != nil {
+			return fmt.Errorf("failed to request %s: %w", url, err)
+		}
+		if strings.TrimSpace(out) == "" {
+			return fmt.Errorf("%s answered with an empty page", url)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the objects of the workload and its client pod from the downstream cluster, without waiting.
+func (w *TestWorkload) Delete() error {
+	client, err := NewKubeClient(w.KubeconfigPath)
+	if err != nil {
+		return err
+	}
+	if err := client.DeletePod(w.Namespace, TestWorkloadClient); err != nil {
+		return err
+	}
+	if err := client.DeleteManifest(w.manifest); err != nil {
+		return fmt.Errorf("failed to delete the workload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderNginxTestWorkload(t *testing.T) {
+	manifest := renderNginxTestWorkload("nginx:test")
+	kinds := manifestKinds(t, manifest, TestWorkloadNamespace)
+	expected := []string{
+		"Namespace/" + TestWorkloadNamespace,
+		"Deployment/" + TestWorkloadName,
+		"Service/" + TestWorkloadName,
+	}
+	if strings.Join(kinds, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected %v, got %v", expected, kinds)
+	}
+	if !strings.Contains(manifest, "image: nginx:test") {
+		t.Errorf("Expected the nginx:test image, got:\n%s", manifest)
+	}
+}
+
+func TestParseTestWorkload(t *testing.T) {
+	workload, err := parseTestWorkload(renderNginxTestWorkload("nginx:test"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if workload.Namespace != TestWorkloadNamespace {
+		t.Errorf("Expected namespace %s, got %s", TestWorkloadNamespace, workload.Namespace)
+	}
+	if strings.Join(workload.Deployments, ",") != TestWorkloadName {
+		t.Errorf("Expected deployment %s, got %v", TestWorkloadName, workload.Deployments)
+	}
+	expected := "http://nginx.cluster-tests-workload.svc.cluster.local:80/"
+	if strings.Join(workload.Services, ",") != expected {
+		t.Errorf("Expected service %s, got %v", expected, workload.Services)
+	}
+}
+
+func TestParseTestWorkloadErrors(t *testing.T) {
+	manifests := map[string]string{
+		"no deployment": "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\nspec:\n  ports:\n    - port: 80\n",
+		"two namespaces": "kind: Deployment\nmetadata:\n  name: web\n  namespace: a\n---\n" +
+			"kind: Deployment\nmetadata:\n  name: api\n  namespace: b\n",
+		"service without port": "kind: Deployment\nmetadata:\n  name: web\n---\nkind: Service\nmetadata:\n  name: web\n",
+		"invalid YAML":         "kind: [Deployment\n",
+	}
+	for name, manifest := range manifests {
+		if _, err := parseTestWorkload(manifest); err == nil {
+			t.Errorf("Expected an error for a manifest with %s", name)
+		}
+	}
+}
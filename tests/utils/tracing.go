@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TraceParentHeader carries the W3C trace context of a request.
+	TraceParentHeader = "traceparent"
+	// TraceResponseHeader is the W3C draft header with which servers report the trace they used.
+	TraceResponseHeader = "traceresponse"
+)
+
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+type traceIDKey struct{}
+
+// ContextWithTraceID makes every request sent with ctx through NewHTTPClient join the given trace.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// NewTraceID returns a random W3C trace ID.
+func NewTraceID() string {
+	return randomHex(16)
+}
+
+// NewTraceParent returns a sampled traceparent header value for a new span of the given trace.
+func NewTraceParent(traceID string) string {
+	return fmt.Sprintf("00-%s-%s-01", traceID, randomHex(8))
+}
+
+// ParseTraceParent extracts the trace and span IDs of a traceparent (or traceresponse) header value.
+func ParseTraceParent(value string) (traceID, spanID string, ok bool) {
+	m := traceParentPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if m == nil || m[1] == strings.Repeat("0", 32) || m[2] == strings.Repeat("0", 16) {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// TraceIDFromResponse returns the trace ID a server reported back in its response headers, if any.
+func TraceIDFromResponse(resp *http.Response) string {
+	for _, header := range []string{TraceResponseHeader, TraceParentHeader} {
+		if traceID, _, ok := ParseTraceParent(resp.Header.Get(header)); ok {
+			return traceID
+		}
+	}
+	return ""
+}
+
+// TraceContextTransport injects a W3C traceparent header into every request that does not carry one,
+// and logs the trace ID of failed requests so they can be looked up in the component traces.
+type TraceContextTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *TraceContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The span is a child of the running spec when telemetry is enabled and a no-op otherwise.
+	_, span := tracer.Start(SpecContext(), "HTTP "+req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("http.request.method", req.Method), attribute.String("url.full", req.URL.String())))
+	defer span.End()
+
+	traceParent := req.Header.Get(TraceParentHeader)
+	if traceParent == "" {
+		traceID, _ := req.Context().Value(traceIDKey{}).(string)
+		switch sc := span.SpanContext(); {
+		case traceID != "":
+			traceParent = NewTraceParent(traceID)
+		case sc.IsValid():
+			traceParent = fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+		default:
+			traceParent = NewTraceParent(NewTraceID())
+		}
+		req = req.Clone(req.Context())
+		req.Header.Set(TraceParentHeader, traceParent)
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		fmt.Printf("%s %s failed (traceparent %s): %v\n", req.Method, req.URL, traceParent, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+		fmt.Printf("%s %s returned %d (traceparent %s)\n", req.Method, req.URL, resp.StatusCode, traceParent)
+	}
+	return resp, nil
+}
+
+// NewHTTPClient returns the HTTP client used to talk to the orchestrator components. Requests that are
+// rate limited are retried after the Retry-After the server asks for, and connections are reused through
+// SharedHTTPTransport. With HTTP_RECORDING=true every attempt is recorded for RegisterHTTPRecording.
+func NewHTTPClient() *http.Client {
+	var transport http.RoundTripper = SharedHTTPTransport()
+	if HTTPRecordingEnabled() {
+		transport = &RecordingTransport{Transport: transport}
+	}
+	return &http.Client{Transport: NewRetryTransport(&TraceContextTransport{Transport: transport})}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		traceID string
+		ok      bool
+	}{
+		{"valid", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00F067AA0BA902B7-00", "4bf92f3577b34da6a3ce929d0e0e4736", true},
+		{"zero trace id", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", false},
+		{"zero span id", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", false},
+		{"malformed", "not-a-traceparent", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceID, _, ok := ParseTraceParent(tt.value)
+			if ok != tt.ok || traceID != tt.traceID {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.traceID, tt.ok, traceID, ok)
+			}
+		})
+	}
+}
+
+func TestTraceContextTransport(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(TraceParentHeader))
+		w.Header().Set(TraceResponseHeader, r.Header.Get(TraceParentHeader))
+	}))
+	defer server.Close()
+
+	client := NewHTTPClient()
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	traceID, _, ok := ParseTraceParent(received[0])
+	if !ok {
+		t.Fatalf("Expected a valid traceparent to be injected, got %q", received[0])
+	}
+	if got := TraceIDFromResponse(resp); got != traceID {
+		t.Errorf("Expected echoed trace ID %q, got %q", traceID, got)
+	}
+
+	wantTraceID := NewTraceID()
+	req, _ := http.NewRequestWithContext(ContextWithTraceID(context.Background(), wantTraceID), http.MethodGet, server.URL, nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if traceID, _, _ := ParseTraceParent(received[1]); traceID != wantTraceID {
+		t.Errorf("Expected trace ID %q from the context, got %q", wantTraceID, traceID)
+	}
+
+	explicit := NewTraceParent(NewTraceID())
+	req, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set(TraceParentHeader, explicit)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	resp.Body.Close()
+	if received[2] != explicit {
+		t.Errorf("Expected explicit traceparent %q to be kept, got %q", explicit, received[2])
+	}
+}
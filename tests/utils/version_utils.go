@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/ginkgo/v2/types"
+)
+
+const (
+	// ComponentClusterManager and ComponentGateway name the components in ComponentSemVerConstraint decorators
+	// and in the --sem-ver-filter flag, e.g. --sem-ver-filter=cluster-manager=2.2.11.
+	ComponentClusterManager = "cluster-manager"
+	ComponentGateway        = "cluster-connect-gateway"
+
+	componentReleaseNamespace = "default"
+)
+
+var (
+	componentVersions     map[string]string
+	componentVersionsOnce sync.Once
+)
+
+// ComponentVersions returns the detected versions of the orchestration components keyed by component name.
+// Components whose version cannot be determined are omitted, so specs constrained on them still run.
+func ComponentVersions() map[string]string {
+	componentVersionsOnce.Do(func() {
+		componentVersions = map[string]string{}
+		for _, component := range []string{ComponentClusterManager, ComponentGateway} {
+			version, err := DetectComponentVersion(component)
+			if err != nil {
+				fmt.Printf("Unable to detect %s version: %v\n", component, err)
+				continue
+			}
+			componentVersions[component] = version
+		}
+	})
+	return componentVersions
+}
+
+// ComponentVersion returns the detected version of a single component, or "" when unknown.
+func ComponentVersion(component string) string {
+	return ComponentVersions()[component]
+}
+
+// DetectComponentVersion reads a component's version from its helm release, falling back to the image tag
+// of its deployment. Only versions that parse as semantic versions are returned.
+func DetectComponentVersion(component string) (string, error) {
+	version, helmErr := helmReleaseVersion(component)
+	if helmErr == nil && isSemVer(version) {
+		return normalizeVersion(version), nil
+	}
+
+	version, imageErr := deploymentImageVersion(component)
+	if imageErr != nil {
+		return "", fmt.Errorf("helm: %v; image: %w", helmErr, imageErr)
+	}
+	if !isSemVer(version) {
+		return "", fmt.Errorf("image tag %q of %s is not a semantic version", version, component)
+	}
+	return normalizeVersion(version), nil
+}
+
+func helmReleaseVersion(release string) (string, error) {
+	cmd := exec.Command("helm", "list", "-n", componentReleaseNamespace, "--filter", "^"+release+"$", "-o", "json")
+	out, err := CommandCombinedOutput(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to list helm release %s: %w: %s", release, err, strings.TrimSpace(string(out)))
+	}
+	return parseHelmReleaseVersion(out, release)
+}
+
+func parseHelmReleaseVersion(out []byte, release string) (string, error) {
+	var releases []struct {
+		Name       string `json:"name"`
+		Chart      string `json:"chart"`
+		AppVersion string `json:"app_version"`
+	}
+	if err := json.Unmarshal(out, &releases); err != nil {
+		return "", fmt.Errorf("failed to parse helm releases: %w", err)
+	}
+
+	for _, r := range releases {
+		if r.Name != release {
+			continue
+		}
+		if isSemVer(r.AppVersion) {
+			return r.AppVersion, nil
+		}
+		// Fall back to the chart version, e.g. "cluster-manager-2.2.11" or "cluster-manager-2.3.0-dev".
+		for i, c := range r.Chart {
+			if c == '-' && isSemVer(r.Chart[i+1:]) {
+				return r.Chart[i+1:], nil
+			}
+		}
+		return "", fmt.Errorf("helm release %s has no semantic version (app %q, chart %q)", release, r.AppVersion, r.Chart)
+	}
+	return "", fmt.Errorf("helm release %s not found", release)
+}
+
+func deploymentImageVersion(deployment string) (string, error) {
+	client, err := ManagementKubeClient()
+	if err != nil {
+		return "", err
+	}
+	object, err := client.GetDeployment(componentReleaseNamespace, deployment)
+	if err != nil {
+		return "", err
+	}
+	if len(object.Spec.Template.Spec.Containers) == 0 {
+		return "", fmt.Errorf("deployment %s has no container", deployment)
+	}
+	return imageTag(object.Spec.Template.Spec.Containers[0].Image), nil
+}
+
+// imageTag returns the tag of an image reference, ignoring registry ports and digests.
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		return image[idx+1:]
+	}
+	return ""
+}
+
+func normalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
+
+func isSemVer(version string) bool {
+	version = normalizeVersion(version)
+	if version == "" {
+		return false
+	}
+	_, err := types.ParseSemVerFilter(version)
+	return err == nil
+}
+
+// ComponentSemVerFilter renders the detected component versions in --sem-ver-filter syntax.
+func ComponentSemVerFilter(versions map[string]string) string {
+	parts := make([]string, 0, len(versions))
+	for component, version := range versions {
+		parts = append(parts, component+"="+version)
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
+}
+
+// VersionAwareSuiteConfig returns the Ginkgo configuration with a sem-ver filter built from the deployed
+// component versions, so specs decorated with ComponentSemVerConstraint skip against older components.
+// An explicit --sem-ver-filter takes precedence over detection.
+func VersionAwareSuiteConfig() (types.SuiteConfig, types.ReporterConfig) {
+	suiteConfig, reporterConfig := ginkgo.GinkgoConfiguration()
+	if suiteConfig.SemVerFilter == "" {
+		suiteConfig.SemVerFilter = ComponentSemVerFilter(ComponentVersions())
+	}
+	if suiteConfig.SemVerFilter != "" {
+		fmt.Printf("Filtering specs by component versions: %s\n", suiteConfig.SemVerFilter)
+	}
+	return suiteConfig, reporterConfig
+}
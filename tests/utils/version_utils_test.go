@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import "testing"
+
+func TestParseHelmReleaseVersion(t *testing.T) {
+	testCases := []struct {
+		name    string
+		output  string
+		version string
+		wantErr bool
+	}{
+		{"app version", `[{"name":"cluster-manager","chart":"cluster-manager-2.2.11","app_version":"2.2.11"}]`, "2.2.11", false},
+		{"chart fallback", `[{"name":"cluster-manager","chart":"cluster-manager-2.3.0-dev","app_version":"latest"}]`, "2.3.0-dev", false},
+		{"other release", `[{"name":"cluster-manager-crd","chart":"cluster-manager-crd-1.0.0","app_version":"1.0.0"}]`, "", true},
+		{"invalid json", `not json`, "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := parseHelmReleaseVersion([]byte(tc.output), "cluster-manager")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Expected error=%t, got %v", tc.wantErr, err)
+			}
+			if version != tc.version {
+				t.Errorf("Expected version %q, got %q", tc.version, version)
+			}
+		})
+	}
+}
+
+func TestImageTag(t *testing.T) {
+	testCases := map[string]string{
+		"registry.example.com:5000/edge/cluster-manager:v2.2.11":  "v2.2.11",
+		"cluster-connect-gateway:1.2.3@sha256:abcdef":             "1.2.3",
+		"registry.example.com:5000/edge/cluster-manager":          "",
+		"registry.example.com/edge/cluster-manager@sha256:abcdef": "",
+	}
+
+	for image, tag := range testCases {
+		if got := imageTag(image); got != tag {
+			t.Errorf("imageTag(%q) = %q, want %q", image, got, tag)
+		}
+	}
+}
+
+func TestComponentSemVerFilter(t *testing.T) {
+	filter := ComponentSemVerFilter(map[string]string{ComponentGateway: "1.2.3", ComponentClusterManager: "2.2.11"})
+	if want := "cluster-connect-gateway=1.2.3,cluster-manager=2.2.11"; filter != want {
+		t.Errorf("Expected %q, got %q", want, filter)
+	}
+	if filter := ComponentSemVerFilter(nil); filter != "" {
+		t.Errorf("Expected an empty filter, got %q", filter)
+	}
+}
@@ -0,0 +1,376 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+// Package wait holds the named waiters of the suites. Each reads its budget from the environment, logs its
+// progress while it polls and, when the budget runs out, returns a TimeoutError carrying the diagnostics of
+// the object it waited for.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/open-edge-platform/cluster-tests/tests/utils"
+)
+
+const (
+	// TemplateReadyBudgetEnvVar is how long an imported cluster template may take to be ready (e.g. "2m").
+	TemplateReadyBudgetEnvVar  = "TEMPLATE_READY_BUDGET"
+	DefaultTemplateReadyBudget = 2 * time.Minute
+	TemplateReadyInterval      = 2 * time.Second
+
+	// ClusterReadyBudgetEnvVar is how long a cluster may take until all its CAPI components are ready. vEN
+	// clusters get DefaultVENClusterReadyBudget by default, for the VM bring-up and first image pulls.
+	ClusterReadyBudgetEnvVar     = "CLUSTER_READINESS_TIMEOUT"
+	DefaultClusterReadyBudget    = 5 * time.Minute
+	DefaultVENClusterReadyBudget = 10 * time.Minute
+	ClusterReadyInterval         = 10 * time.Second
+
+	// ClusterGoneBudgetEnvVar is how long the Cluster object of a deleted cluster may take to go away.
+	ClusterGoneBudgetEnvVar  = "CLUSTER_GONE_BUDGET"
+	DefaultClusterGoneBudget = 5 * time.Minute
+	ClusterGoneInterval      = 5 * time.Second
+
+	// ProviderConditionInterval is how often WaitForProviderCondition reads the provider conditions.
+	ProviderConditionInterval = 10 * time.Second
+	// ConnectionLostInterval is how often ConnectionLost reads the ClusterConnect, often enough to time the
+	// detection against the DISCONNECT_DETECTION_BUDGET.
+	ConnectionLostInterval = 5 * time.Second
+
+	// progressInterval is how often a waiter logs that it is still waiting.
+	progressInterval = 30 * time.Second
+)
+
+// Condition reports whether what is waited for happened, and otherwise describes the current state for the
+// progress logs and the TimeoutError.
+type Condition func() (done bool, status string, err error)
+
+// TimeoutError is returned by a waiter whose budget ran out.
+type TimeoutError struct {
+	Description string
+	Budget      time.Duration
+	// LastStatus and LastErr are what the last poll reported.
+	LastStatus  string
+	LastErr     error
+	Diagnostics string
+}
+
+func (e *TimeoutError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: not done after %v", e.Description, e.Budget)
+	if e.LastStatus != "" {
+		fmt.Fprintf(&b, ", last status: %s", e.LastStatus)
+	}
+	if e.LastErr != nil {
+		fmt.Fprintf(&b, ", last error: %v", e.LastErr)
+	}
+	if e.Diagnostics != "" {
+		fmt.Fprintf(&b, "\n%s", strings.TrimRight(e.Diagnostics, "\n"))
+	}
+	return b.String()
+}
+
+// Poll is what WaitFor polls: once done, value is what was waited for. Otherwise status describes the
+// current state for the progress logs and the TimeoutError. ctx ends with the wait.
+type Poll[T any] func(ctx context.Context) (value T, done bool, status string, err error)
+
+// WaitFor polls every interval until poll is done or ctx ends. The deadline of ctx is the budget: when it
+// passes, WaitFor returns a TimeoutError with the last status and the output of diagnose, when not nil.
+// A ctx canceled otherwise returns its error.
+func WaitFor[T any](ctx context.Context, description string, interval time.Duration, poll Poll[T], diagnose func() string) (T, error) {
+	start := time.Now()
+	budget := time.Duration(0)
+	if deadline, ok := ctx.Deadline(); ok {
+		budget = deadline.Sub(start).Round(time.Millisecond)
+		fmt.Printf("Waiting up to %v for %s\n", budget, description)
+	} else {
+		fmt.Printf("Waiting for %s\n", description)
+	}
+	lastProgress := start
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		value, done, status, err := poll(ctx)
+		if done && err == nil {
+			fmt.Printf("Done waiting for %s after %v\n", description, time.Since(start).Round(time.Second))
+			return value, nil
+		}
+
+		if ctx.Err() == nil && time.Since(lastProgress) >= progressInterval {
+			lastProgress = time.Now()
+			fmt.Printf("Still waiting for %s after %v of %v: %s\n", description, time.Since(start).Round(time.Second), budget,
+				progressStatus(status, err))
+		}
+		select {
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return value, fmt.Errorf("stopped waiting for %s: %w", description, ctx.Err())
+			}
+			timeout := &TimeoutError{Description: description, Budget: budget, LastStatus: status, LastErr: err}
+			if diagnose != nil {
+				timeout.Diagnostics = diagnose()
+			}
+			return value, timeout
+		case <-ticker.C:
+		}
+	}
+}
+
+// Until polls condition every interval until it is done or the budget runs out. diagnose, when not nil,
+// is called once on timeout to describe the state of the objects involved.
+func Until(description string, budget, interval time.Duration, condition Condition, diagnose func() string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), budget)
+	defer cancel()
+	_, err := WaitFor(ctx, description, interval, func(context.Context) (struct{}, bool, string, error) {
+		done, status, err := condition()
+		return struct{}{}, done, status, err
+	}, diagnose)
+	return err
+}
+
+func progressStatus(status string, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return status
+}
+
+// budget returns the duration of the environment variable, or the default when it is unset or invalid.
+func budget(envVar string, defaultBudget time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(envVar)); err == nil && value > 0 {
+		return value
+	}
+	return defaultBudget
+}
+
+// TemplateReadyBudget returns TEMPLATE_READY_BUDGET, or the default.
+func TemplateReadyBudget() time.Duration {
+	return budget(TemplateReadyBudgetEnvVar, DefaultTemplateReadyBudget)
+}
+
+// ClusterReadyBudget returns CLUSTER_READINESS_TIMEOUT, or the default of the edge node provider.
+func ClusterReadyBudget() time.Duration {
+	if utils.GetEdgeNodeProvider() == utils.EdgeNodeProviderVEN {
+		return budget(ClusterReadyBudgetEnvVar, DefaultVENClusterReadyBudget)
+	}
+	return budget(ClusterReadyBudgetEnvVar, DefaultClusterReadyBudget)
+}
+
+// ClusterGoneBudget returns CLUSTER_GONE_BUDGET, or the default.
+func ClusterGoneBudget() time.Duration {
+	return budget(ClusterGoneBudgetEnvVar, DefaultClusterGoneBudget)
+}
+
+// WaitForTemplateReady waits TemplateReadyBudget until a cluster template reports ready.
+func WaitForTemplateReady(namespace, templateName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), TemplateReadyBudget())
+	defer cancel()
+	return TemplateReady(ctx, namespace, templateName)
+}
+
+// TemplateReady waits until a cluster template reports ready, or ctx ends.
+func TemplateReady(ctx context.Context, namespace, templateName string) error {
+	var last utils.ClusterTemplateStatus
+	_, err := WaitFor(ctx, fmt.Sprintf("cluster template %s/%s to be ready", namespace, templateName), TemplateReadyInterval,
+		func(context.Context) (struct{}, bool, string, error) {
+			client, err := utils.ManagementKubeClient()
+			if err != nil {
+				return struct{}{}, false, "", err
+			}
+			last, err = client.GetClusterTemplateStatus(namespace, templateName)
+			if err != nil {
+				return struct{}{}, false, "", err
+			}
+			return struct{}{}, last.Ready, "not ready", nil
+		},
+		func() string {
+			return fmt.Sprintf("The status of the cluster template: %+v", last)
+		})
+	return err
+}
+
+// WaitForClusterReady waits ClusterReadyBudget until every CAPI component of a cluster is ready.
+func WaitForClusterReady(namespace, clusterName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ClusterReadyBudget())
+	defer cancel()
+	return ClusterReady(ctx, namespace, clusterName)
+}
+
+// ClusterReady waits until every CAPI component of a cluster is ready, or ctx ends. The status names the
+// component that blocks the cluster and for how long.
+func ClusterReady(ctx context.Context, namespace, clusterName string) error {
+	return ManagedClusterReady(ctx, "", namespace, clusterName)
+}
+
+// ManagedClusterReady is ClusterReady on the management cluster of kubeconfig ("" for the current one), e.g.
+// the target of a pivot.
+func ManagedClusterReady(ctx context.Context, kubeconfig, namespace, clusterName string) error {
+	_, err := WaitFor(ctx, fmt.Sprintf("cluster %s/%s to be ready", namespace, clusterName), ClusterReadyInterval,
+		func(context.Context) (struct{}, bool, string, error) {
+			err := utils.ClusterComponentsReady(kubeconfig, namespace, clusterName)
+			if err != nil {
+				// The first line names the blocking component, the rest is the tree.
+				blocking, _, _ := strings.Cut(err.Error(), "\n")
+				return struct{}{}, false, blocking, nil
+			}
+			return struct{}{}, true, "", nil
+		},
+		func() string {
+			if kubeconfig == "" {
+				return utils.TriageCluster(namespace, clusterName)
+			}
+			description, err := utils.DescribeManagedCluster(kubeconfig, namespace, clusterName)
+			if err != nil {
+				return fmt.Sprintf("Failed to describe cluster %s/%s: %v", namespace, clusterName, err)
+			}
+			return description
+		})
+	return err
+}
+
+// WaitForIntelMachinesReady waits until a cluster has count IntelMachines and all of them are Ready.
+func WaitForIntelMachinesReady(namespace, clusterName string, count int) error {
+	return Until(fmt.Sprintf("%d IntelMachines of cluster %s/%s to be ready", count, namespace, clusterName),
+		ClusterReadyBudget(), ClusterReadyInterval,
+		func() (bool, string, error) {
+			ready, notReady, err := utils.IntelMachinesReady(namespace, clusterName)
+			if err != nil {
+				return false, "", err
+			}
+			status := fmt.Sprintf("%d of %d ready", len(ready), count)
+			if len(notReady) > 0 {
+				status += ", waiting for " + strings.Join(notReady, ", ")
+			}
+			return len(ready) == count && len(notReady) == 0, status, nil
+		},
+		func() string {
+			return utils.TriageCluster(namespace, clusterName)
+		})
+}
+
+// WaitForMachinesGone waits ClusterGoneBudget until the named Machines of a cluster are gone. CAPI removes a
+// Machine once its node has been drained and deleted and its IntelMachine is gone, so this covers all of them.
+func WaitForMachinesGone(namespace, clusterName string, machines ...string) error {
+	return Until(fmt.Sprintf("Machines %s of cluster %s/%s to be gone", strings.Join(machines, ", "), namespace, clusterName),
+		ClusterGoneBudget(), ClusterGoneInterval,
+		func() (bool, string, error) {
+			names, err := utils.ClusterMachineNames(namespace, clusterName)
+			if err != nil {
+				return false, "", err
+			}
+			var left []string
+			for _, machine := range machines {
+				if slices.Contains(names, machine) {
+					left = append(left, machine)
+				}
+			}
+			return len(left) == 0, "waiting for " + strings.Join(left, ", "), nil
+		},
+		func() string {
+			return utils.TriageCluster(namespace, clusterName)
+		})
+}
+
+// WaitForClusterDeleted waits ClusterGoneBudget until a deleted cluster has left no Cluster, Machine or
+// IntelMachine behind.
+func WaitForClusterDeleted(namespace, clusterName string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ClusterGoneBudget())
+	defer cancel()
+	return ClusterDeleted(ctx, namespace, clusterName)
+}
+
+// ClusterDeleted waits until a deleted cluster has left no Cluster, Machine or IntelMachine behind, or ctx
+// ends. The status lists the objects left.
+func ClusterDeleted(ctx context.Context, namespace, clusterName string) error {
+	_, err := WaitFor(ctx, fmt.Sprintf("cluster %s/%s to be deleted", namespace, clusterName), ClusterGoneInterval,
+		func(context.Context) (struct{}, bool, string, error) {
+			remaining, err := utils.RemainingClusterObjects(namespace, clusterName)
+			if err != nil {
+				return struct{}{}, false, "", err
+			}
+			return struct{}{}, len(remaining) == 0, "left: " + strings.Join(remaining, ", "), nil
+		},
+		func() string {
+			return remainingObjectsDiagnostics(namespace, clusterName)
+		})
+	return err
+}
+
+func remainingObjectsDiagnostics(namespace, clusterName string) string {
+	var b strings.Builder
+	b.WriteString(utils.TriageCluster(namespace, clusterName))
+	if objects, err := utils.ClusterObjects(namespace); err != nil {
+		fmt.Fprintf(&b, "Failed to list the CAPI objects of %s: %v\n", namespace, err)
+	} else if len(objects) > 0 {
+		fmt.Fprintf(&b, "CAPI objects left in %s:\n  %s\n", namespace, strings.Join(objects, "\n  "))
+	}
+	return b.String()
+}
+
+// WaitForConnectionLost waits DisconnectDetectionBudget until the ClusterConnect of a cluster reports the
+// connection to its connect agent lost, and returns the False condition.
+func WaitForConnectionLost(namespace, clusterName string) (utils.ProviderCondition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), utils.DisconnectDetectionBudget())
+	defer cancel()
+	return ConnectionLost(ctx, namespace, clusterName)
+}
+
+// ConnectionLost waits until the ClusterConnect of a cluster reports a False condition, or ctx ends, and
+// returns that condition. The status lists the conditions of the ClusterConnect.
+func ConnectionLost(ctx context.Context, namespace, clusterName string) (utils.ProviderCondition, error) {
+	return WaitFor(ctx, fmt.Sprintf("the connection of cluster %s/%s to be reported lost", namespace, clusterName), ConnectionLostInterval,
+		func(context.Context) (utils.ProviderCondition, bool, string, error) {
+			conditions, found, err := utils.GetClusterConnectConditions(namespace, clusterName)
+			if err != nil || !found {
+				return utils.ProviderCondition{}, false, "no ClusterConnect yet", err
+			}
+			if lost, ok := utils.FindProviderCondition(conditions, "", "False", ""); ok {
+				return lost, true, "", nil
+			}
+			states := make([]string, 0, len(conditions))
+			for _, c := range conditions {
+				states = append(states, c.Condition.Type+"="+c.Condition.Status)
+			}
+			return utils.ProviderCondition{}, false, "ClusterConnect conditions " + strings.Join(states, ", "), nil
+		},
+		func() string {
+			return utils.TriageCluster(namespace, clusterName)
+		})
+}
+
+// WaitForProviderCondition waits until a provider object of a cluster (IntelCluster, IntelMachine or
+// ClusterConnect) reports a condition with the given type, status and reason, an empty value matching any,
+// and returns it. The budget is the caller's as the providers react to faults on very different scales.
+func WaitForProviderCondition(namespace, clusterName, conditionType, status, reason string, budget time.Duration) (utils.ProviderCondition, error) {
+	var found utils.ProviderCondition
+	err := Until(fmt.Sprintf("cluster %s/%s to report condition %s", namespace, clusterName, conditionDescription(conditionType, status, reason)),
+		budget, ProviderConditionInterval,
+		func() (bool, string, error) {
+			conditions, err := utils.GetProviderConditions("", namespace, clusterName)
+			if err != nil {
+				return false, "", err
+			}
+			var ok bool
+			found, ok = utils.FindProviderCondition(conditions, conditionType, status, reason)
+			return ok, fmt.Sprintf("%d provider conditions, none matching", len(conditions)), nil
+		},
+		func() string {
+			return utils.TriageCluster(namespace, clusterName)
+		})
+	return found, err
+}
+
+func conditionDescription(conditionType, status, reason string) string {
+	orAny := func(value string) string {
+		if value == "" {
+			return "*"
+		}
+		return value
+	}
+	return fmt.Sprintf("%s=%s (%s)", orAny(conditionType), orAny(status), orAny(reason))
+}
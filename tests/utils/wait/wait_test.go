@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: (C) 2026 Intel Corporation
+// SPDX-License-Identifier: Apache-2.0
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUntilDone(t *testing.T) {
+	polls := 0
+	err := Until("the third poll", time.Second, time.Millisecond, func() (bool, string, error) {
+		polls++
+		return polls == 3, "pending", nil
+	}, func() string {
+		t.Error("Expected no diagnostics when the condition is done")
+		return ""
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Expected 3 polls, got %d", polls)
+	}
+}
+
+func TestUntilTimeout(t *testing.T) {
+	err := Until("something that never happens", 20*time.Millisecond, time.Millisecond, func() (bool, string, error) {
+		return false, "still pending", errors.New("connection refused")
+	}, func() string {
+		return "the diagnostics\n"
+	})
+	var timeout *TimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("Expected a TimeoutError, got %v", err)
+	}
+	message := err.Error()
+	for _, want := range []string{"something that never happens", "20ms", "still pending", "connection refused", "the diagnostics"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected %q in %q", want, message)
+		}
+	}
+}
+
+func TestUntilIgnoresDoneWithError(t *testing.T) {
+	err := Until("a condition that errors", 10*time.Millisecond, time.Millisecond, func() (bool, string, error) {
+		return true, "", errors.New("failed")
+	}, nil)
+	if err == nil {
+		t.Error("Expected a condition that errors not to be done")
+	}
+}
+
+func TestBudgets(t *testing.T) {
+	t.Setenv(TemplateReadyBudgetEnvVar, "45s")
+	if got := TemplateReadyBudget(); got != 45*time.Second {
+		t.Errorf("Expected 45s, got %v", got)
+	}
+	for _, value := range []string{"", "soon", "-1m", "0s"} {
+		t.Setenv(ClusterGoneBudgetEnvVar, value)
+		if got := ClusterGoneBudget(); got != DefaultClusterGoneBudget {
+			t.Errorf("Expected the default budget for %q, got %v", value, got)
+		}
+	}
+}
+
+func TestWaitForValue(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	polls := 0
+	value, err := WaitFor(ctx, "the second poll", time.Millisecond, func(context.Context) (int, bool, string, error) {
+		polls++
+		return polls * 10, polls == 2, "pending", nil
+	}, nil)
+	if err != nil || value != 20 {
+		t.Errorf("Expected 20 from the second poll, got %d, %v", value, err)
+	}
+}
+
+func TestWaitForCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := WaitFor(ctx, "a canceled wait", time.Millisecond, func(context.Context) (struct{}, bool, string, error) {
+		return struct{}{}, false, "pending", nil
+	}, func() string {
+		t.Error("Expected no diagnostics when the wait is canceled")
+		return ""
+	})
+	var timeout *TimeoutError
+	if errors.As(err, &timeout) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected a canceled error rather than a timeout, got %v", err)
+	}
+}